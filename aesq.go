@@ -0,0 +1,77 @@
+package aes
+
+// AESQ is the 512-bit permutation of the PAEQ authenticated encryption family
+// (Biryukov and Khovratovich). The state is four AES blocks. Each of the 10
+// steps applies two AES rounds to every block, with round keys that are a
+// counter, then shuffles the 32-bit words of the state with the permutation
+// π512 also used by Pholkos-512:
+//
+//	for s = 0 .. 9:
+//	    for j = 0 .. 3:
+//	        Xj = AESENC(AESENC(Xj, c(8s+2j+1)), c(8s+2j+2))
+//	    (X0, X1, X2, X3) = π512(X0, X1, X2, X3)
+//
+// where c(i) is the block whose first byte is i and the others are zero.
+
+// AESQ is a 512-bit AESQ state.
+type AESQ [64]byte
+
+// aesqSteps is the number of two-round steps.
+const aesqSteps = 10
+
+// aesqConstant returns the round key of AES round i, counted from 1.
+func aesqConstant(i int) Block {
+	return Block{0: byte(i)}
+}
+
+func aesqPermute(state *AESQ, hw bool) {
+	x := [4]*Block{(*Block)(state[0:16]), (*Block)(state[16:32]), (*Block)(state[32:48]), (*Block)(state[48:64])}
+	for s := range aesqSteps {
+		for j, b := range x {
+			k0 := aesqConstant(8*s + 2*j + 1)
+			k1 := aesqConstant(8*s + 2*j + 2)
+			if hw {
+				RoundHW(b, &k0)
+				RoundHW(b, &k1)
+			} else {
+				Round(b, &k0)
+				Round(b, &k1)
+			}
+		}
+		pholkos512PermuteWordsState(x[0], x[1], x[2], x[3])
+	}
+}
+
+func aesqInversePermute(state *AESQ, hw bool) {
+	x := [4]*Block{(*Block)(state[0:16]), (*Block)(state[16:32]), (*Block)(state[32:48]), (*Block)(state[48:64])}
+	for s := aesqSteps - 1; s >= 0; s-- {
+		pholkos512PermuteWordsStateInv(x[0], x[1], x[2], x[3])
+		for j, b := range x {
+			k0 := aesqConstant(8*s + 2*j + 1)
+			k1 := aesqConstant(8*s + 2*j + 2)
+			XorBlock(b, b, &k1)
+			if hw {
+				InvRoundNoKeyHW(b)
+			} else {
+				InvRoundNoKey(b)
+			}
+			XorBlock(b, b, &k0)
+			if hw {
+				InvRoundNoKeyHW(b)
+			} else {
+				InvRoundNoKey(b)
+			}
+		}
+	}
+}
+
+// Permute applies the AESQ permutation in place, using AES instructions when
+// available.
+func (state *AESQ) Permute() {
+	aesqPermute(state, true)
+}
+
+// InversePermute applies the inverse of the AESQ permutation in place.
+func (state *AESQ) InversePermute() {
+	aesqInversePermute(state, true)
+}
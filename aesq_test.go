@@ -0,0 +1,74 @@
+package aes
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// aesqReference follows the AESQ description with the software AES round
+// and an explicit word shuffle.
+func aesqReference(state *AESQ) {
+	for s := range aesqSteps {
+		for j := range 4 {
+			b := (*Block)(state[16*j:])
+			k0 := Block{0: byte(8*s + 2*j + 1)}
+			k1 := Block{0: byte(8*s + 2*j + 2)}
+			Round(b, &k0)
+			Round(b, &k1)
+		}
+		var t AESQ
+		for j := range 16 {
+			copy(t[4*j:4*j+4], state[4*pi512[j]:4*pi512[j]+4])
+		}
+		*state = t
+	}
+}
+
+func TestAESQMatchesReference(t *testing.T) {
+	var in AESQ
+	for i := range in {
+		in[i] = byte(i * 11)
+	}
+	want := in
+	aesqReference(&want)
+
+	forEachCPUConfig(t, func(t *testing.T) {
+		s := in
+		s.Permute()
+		if s != want {
+			t.Fatalf("%+v: got %x, expected %x", CPU, s, want)
+		}
+		s.InversePermute()
+		if s != in {
+			t.Fatalf("%+v: InversePermute did not restore the state", CPU)
+		}
+	})
+
+	s := in
+	aesqPermute(&s, false)
+	if s != want {
+		t.Fatalf("software permutation mismatch")
+	}
+	aesqInversePermute(&s, false)
+	if s != in {
+		t.Fatalf("software inverse permutation mismatch")
+	}
+}
+
+// Regression value for the all-zero state.
+func TestAESQKnownAnswer(t *testing.T) {
+	var s AESQ
+	s.Permute()
+	const expected = "54eeb4fcb79a365ce301ce2d5a3eb170adcb8e06b567d5bf81ae93abc451cd41e5f89827fb7514947dae7eca2ae01a28a3e3182e783e819b318a39b99827416c"
+	if hex.EncodeToString(s[:]) != expected {
+		t.Errorf("got %x, expected %s", s, expected)
+	}
+}
+
+func BenchmarkAESQ(b *testing.B) {
+	var s AESQ
+	b.SetBytes(int64(len(s)))
+	for i := 0; i < b.N; i++ {
+		s.Permute()
+	}
+}
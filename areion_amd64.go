@@ -13,3 +13,116 @@ func areion512PermuteAsm(state *Areion512)
 
 //go:noescape
 func areion512InversePermuteAsm(state *Areion512)
+
+//go:noescape
+func areion256x2PermuteVAES(states *Areion256x2)
+
+//go:noescape
+func areion256x2InversePermuteVAES(states *Areion256x2)
+
+//go:noescape
+func areion256x4PermuteVAES(states *Areion256x4)
+
+//go:noescape
+func areion256x4InversePermuteVAES(states *Areion256x4)
+
+//go:noescape
+func areion512x2PermuteVAES(states *Areion512x2)
+
+//go:noescape
+func areion512x2InversePermuteVAES(states *Areion512x2)
+
+//go:noescape
+func areion512x4PermuteVAES(states *Areion512x4)
+
+//go:noescape
+func areion512x4InversePermuteVAES(states *Areion512x4)
+
+// areion512x4PermuteAVX interleaves four states in X0-X15 using the
+// three-operand AVX encoding of AESENC, for CPUs without VAES.
+//
+//go:noescape
+func areion512x4PermuteAVX(states *Areion512x4)
+
+//go:noescape
+func areion512x4InversePermuteAVX(states *Areion512x4)
+
+func areion256x2Permute(s *Areion256x2) {
+	if CPU.HasVAES && CPU.HasAVX2 {
+		areion256x2PermuteVAES(s)
+		return
+	}
+	areion256Permute(&s[0])
+	areion256Permute(&s[1])
+}
+
+func areion256x2InversePermute(s *Areion256x2) {
+	if CPU.HasVAES && CPU.HasAVX2 {
+		areion256x2InversePermuteVAES(s)
+		return
+	}
+	areion256InversePermute(&s[0])
+	areion256InversePermute(&s[1])
+}
+
+func areion256x4Permute(s *Areion256x4) {
+	if CPU.HasVAES && CPU.HasAVX512 {
+		areion256x4PermuteVAES(s)
+		return
+	}
+	areion256x2Permute((*Areion256x2)(s[0:2]))
+	areion256x2Permute((*Areion256x2)(s[2:4]))
+}
+
+func areion256x4InversePermute(s *Areion256x4) {
+	if CPU.HasVAES && CPU.HasAVX512 {
+		areion256x4InversePermuteVAES(s)
+		return
+	}
+	areion256x2InversePermute((*Areion256x2)(s[0:2]))
+	areion256x2InversePermute((*Areion256x2)(s[2:4]))
+}
+
+func areion512x2Permute(s *Areion512x2) {
+	if CPU.HasVAES && CPU.HasAVX2 {
+		areion512x2PermuteVAES(s)
+		return
+	}
+	areion512Permute(&s[0])
+	areion512Permute(&s[1])
+}
+
+func areion512x2InversePermute(s *Areion512x2) {
+	if CPU.HasVAES && CPU.HasAVX2 {
+		areion512x2InversePermuteVAES(s)
+		return
+	}
+	areion512InversePermute(&s[0])
+	areion512InversePermute(&s[1])
+}
+
+func areion512x4Permute(s *Areion512x4) {
+	switch {
+	case CPU.HasVAES && CPU.HasAVX512:
+		areion512x4PermuteVAES(s)
+	case CPU.HasAESNI && CPU.HasAVX:
+		areion512x4PermuteAVX(s)
+	default:
+		for i := range s {
+			areion512Permute(&s[i])
+		}
+	}
+}
+
+func areion512x4InversePermute(s *Areion512x4) {
+	switch {
+	case CPU.HasVAES && CPU.HasAVX512:
+		areion512x4InversePermuteVAES(s)
+	case CPU.HasAESNI && CPU.HasAVX:
+		areion512x4InversePermuteAVX(s)
+	default:
+		for i := range s {
+			areion512InversePermute(&s[i])
+		}
+	}
+}
@@ -401,3 +401,648 @@ TEXT areion512_inv_round<>(SB),NOSPLIT,$0
 	MOVOA X5, X3
 
 	RET
+
+DATA areionZero<>+0(SB)/8, $0
+DATA areionZero<>+8(SB)/8, $0
+GLOBL areionZero<>(SB), (NOPTR+RODATA), $16
+
+// One Areion512 round on a single state held in registers a, b, c, d.
+// The three-operand VEX forms avoid temporaries, so four states fit in
+// X0-X15 with the round constant and the zero block taken from memory.
+#define AREION512_ROUND(a, b, c, d, rc) \
+	VAESENC b, a, b; \
+	VAESENC d, c, d; \
+	VAESENCLAST areionZero<>(SB), a, a; \
+	VAESENCLAST rc, c, c; \
+	VAESENC areionZero<>(SB), c, c
+
+// One Areion512 inverse round on a single state.
+#define AREION512_INV_ROUND(a, b, c, d, rc) \
+	VAESDECLAST areionZero<>(SB), a, a; \
+	VAESIMC c, c; \
+	VAESDECLAST rc, c, c; \
+	VAESDECLAST areionZero<>(SB), c, c; \
+	VAESENC b, a, b; \
+	VAESENC d, c, d
+
+// Round r of four interleaved states starts at word r mod 4.
+#define AREION512_ROUND4_0(R, rc) \
+	R(X0, X1, X2, X3, rc); \
+	R(X4, X5, X6, X7, rc); \
+	R(X8, X9, X10, X11, rc); \
+	R(X12, X13, X14, X15, rc)
+
+#define AREION512_ROUND4_1(R, rc) \
+	R(X1, X2, X3, X0, rc); \
+	R(X5, X6, X7, X4, rc); \
+	R(X9, X10, X11, X8, rc); \
+	R(X13, X14, X15, X12, rc)
+
+#define AREION512_ROUND4_2(R, rc) \
+	R(X2, X3, X0, X1, rc); \
+	R(X6, X7, X4, X5, rc); \
+	R(X10, X11, X8, X9, rc); \
+	R(X14, X15, X12, X13, rc)
+
+#define AREION512_ROUND4_3(R, rc) \
+	R(X3, X0, X1, X2, rc); \
+	R(X7, X4, X5, X6, rc); \
+	R(X11, X8, X9, X10, rc); \
+	R(X15, X12, X13, X14, rc)
+
+// Four independent Areion512 permutations using AES-NI with AVX
+// func areion512x4PermuteAVX(states *Areion512x4)
+TEXT ·areion512x4PermuteAVX(SB),NOSPLIT,$0
+	MOVQ states+0(FP), AX
+	LEAQ ·areionRoundConstants(SB), BX
+
+	VMOVDQU 0(AX), X0
+	VMOVDQU 16(AX), X1
+	VMOVDQU 32(AX), X2
+	VMOVDQU 48(AX), X3
+	VMOVDQU 64(AX), X4
+	VMOVDQU 80(AX), X5
+	VMOVDQU 96(AX), X6
+	VMOVDQU 112(AX), X7
+	VMOVDQU 128(AX), X8
+	VMOVDQU 144(AX), X9
+	VMOVDQU 160(AX), X10
+	VMOVDQU 176(AX), X11
+	VMOVDQU 192(AX), X12
+	VMOVDQU 208(AX), X13
+	VMOVDQU 224(AX), X14
+	VMOVDQU 240(AX), X15
+
+	AREION512_ROUND4_0(AREION512_ROUND, 0*16(BX))
+	AREION512_ROUND4_1(AREION512_ROUND, 1*16(BX))
+	AREION512_ROUND4_2(AREION512_ROUND, 2*16(BX))
+	AREION512_ROUND4_3(AREION512_ROUND, 3*16(BX))
+	AREION512_ROUND4_0(AREION512_ROUND, 4*16(BX))
+	AREION512_ROUND4_1(AREION512_ROUND, 5*16(BX))
+	AREION512_ROUND4_2(AREION512_ROUND, 6*16(BX))
+	AREION512_ROUND4_3(AREION512_ROUND, 7*16(BX))
+	AREION512_ROUND4_0(AREION512_ROUND, 8*16(BX))
+	AREION512_ROUND4_1(AREION512_ROUND, 9*16(BX))
+	AREION512_ROUND4_2(AREION512_ROUND, 10*16(BX))
+	AREION512_ROUND4_3(AREION512_ROUND, 11*16(BX))
+	AREION512_ROUND4_0(AREION512_ROUND, 12*16(BX))
+	AREION512_ROUND4_1(AREION512_ROUND, 13*16(BX))
+	AREION512_ROUND4_2(AREION512_ROUND, 14*16(BX))
+
+	// Final rotation: words (x3, x0, x1, x2)
+	VMOVDQU X3, 0(AX)
+	VMOVDQU X0, 16(AX)
+	VMOVDQU X1, 32(AX)
+	VMOVDQU X2, 48(AX)
+	VMOVDQU X7, 64(AX)
+	VMOVDQU X4, 80(AX)
+	VMOVDQU X5, 96(AX)
+	VMOVDQU X6, 112(AX)
+	VMOVDQU X11, 128(AX)
+	VMOVDQU X8, 144(AX)
+	VMOVDQU X9, 160(AX)
+	VMOVDQU X10, 176(AX)
+	VMOVDQU X15, 192(AX)
+	VMOVDQU X12, 208(AX)
+	VMOVDQU X13, 224(AX)
+	VMOVDQU X14, 240(AX)
+	VZEROUPPER
+	RET
+
+// Four independent Areion512 inverse permutations using AES-NI with AVX
+// func areion512x4InversePermuteAVX(states *Areion512x4)
+TEXT ·areion512x4InversePermuteAVX(SB),NOSPLIT,$0
+	MOVQ states+0(FP), AX
+	LEAQ ·areionRoundConstants(SB), BX
+
+	// Undo the final rotation while loading
+	VMOVDQU 0(AX), X3
+	VMOVDQU 16(AX), X0
+	VMOVDQU 32(AX), X1
+	VMOVDQU 48(AX), X2
+	VMOVDQU 64(AX), X7
+	VMOVDQU 80(AX), X4
+	VMOVDQU 96(AX), X5
+	VMOVDQU 112(AX), X6
+	VMOVDQU 128(AX), X11
+	VMOVDQU 144(AX), X8
+	VMOVDQU 160(AX), X9
+	VMOVDQU 176(AX), X10
+	VMOVDQU 192(AX), X15
+	VMOVDQU 208(AX), X12
+	VMOVDQU 224(AX), X13
+	VMOVDQU 240(AX), X14
+
+	AREION512_ROUND4_2(AREION512_INV_ROUND, 14*16(BX))
+	AREION512_ROUND4_1(AREION512_INV_ROUND, 13*16(BX))
+	AREION512_ROUND4_0(AREION512_INV_ROUND, 12*16(BX))
+	AREION512_ROUND4_3(AREION512_INV_ROUND, 11*16(BX))
+	AREION512_ROUND4_2(AREION512_INV_ROUND, 10*16(BX))
+	AREION512_ROUND4_1(AREION512_INV_ROUND, 9*16(BX))
+	AREION512_ROUND4_0(AREION512_INV_ROUND, 8*16(BX))
+	AREION512_ROUND4_3(AREION512_INV_ROUND, 7*16(BX))
+	AREION512_ROUND4_2(AREION512_INV_ROUND, 6*16(BX))
+	AREION512_ROUND4_1(AREION512_INV_ROUND, 5*16(BX))
+	AREION512_ROUND4_0(AREION512_INV_ROUND, 4*16(BX))
+	AREION512_ROUND4_3(AREION512_INV_ROUND, 3*16(BX))
+	AREION512_ROUND4_2(AREION512_INV_ROUND, 2*16(BX))
+	AREION512_ROUND4_1(AREION512_INV_ROUND, 1*16(BX))
+	AREION512_ROUND4_0(AREION512_INV_ROUND, 0*16(BX))
+
+	VMOVDQU X0, 0(AX)
+	VMOVDQU X1, 16(AX)
+	VMOVDQU X2, 32(AX)
+	VMOVDQU X3, 48(AX)
+	VMOVDQU X4, 64(AX)
+	VMOVDQU X5, 80(AX)
+	VMOVDQU X6, 96(AX)
+	VMOVDQU X7, 112(AX)
+	VMOVDQU X8, 128(AX)
+	VMOVDQU X9, 144(AX)
+	VMOVDQU X10, 160(AX)
+	VMOVDQU X11, 176(AX)
+	VMOVDQU X12, 192(AX)
+	VMOVDQU X13, 208(AX)
+	VMOVDQU X14, 224(AX)
+	VMOVDQU X15, 240(AX)
+	VZEROUPPER
+	RET
+
+// Multi-state Areion permutations using VAES. Lane i of each vector register
+// holds one 128-bit word of state i, so every VAESENC advances all states.
+// The 256-bit (Y) forms need VAES with AVX2, the 512-bit (Z) forms AVX-512.
+
+// One Areion256 round: b ^= RoundNoKey(RoundNoKey(a) ⊕ rc), a = FinalRoundNoKey(a).
+#define AREION256_VROUND(a, b, rc, t, zero) \
+	VAESENC rc, a, t; \
+	VAESENC b, t, b; \
+	VAESENCLAST zero, a, a
+
+// Inverse of AREION256_VROUND.
+#define AREION256_VINV_ROUND(a, b, rc, t, zero) \
+	VAESDECLAST zero, a, a; \
+	VAESENC rc, a, t; \
+	VAESENC b, t, b
+
+// One Areion512 round with the round constant and zero block in registers.
+#define AREION512_VROUND(a, b, c, d, rc, zero) \
+	VAESENC b, a, b; \
+	VAESENC d, c, d; \
+	VAESENCLAST zero, a, a; \
+	VAESENCLAST rc, c, c; \
+	VAESENC zero, c, c
+
+// Inverse of AREION512_VROUND. There is no wide AESIMC, so InvMixColumns is
+// computed as AESDEC(AESENCLAST(c, 0), 0).
+#define AREION512_VINV_ROUND(a, b, c, d, rc, zero) \
+	VAESDECLAST zero, a, a; \
+	VAESENCLAST zero, c, c; \
+	VAESDEC zero, c, c; \
+	VAESDECLAST rc, c, c; \
+	VAESDECLAST zero, c, c; \
+	VAESENC b, a, b; \
+	VAESENC d, c, d
+
+// 2 independent Areion256 permutations using VAES
+// func areion256x2PermuteVAES(states *Areion256x2)
+TEXT ·areion256x2PermuteVAES(SB),NOSPLIT,$0
+	MOVQ states+0(FP), AX
+	LEAQ ·areionRoundConstants(SB), BX
+	VPXOR X5, X5, X5
+
+	VMOVDQU 0(AX), X0
+	VINSERTI128 $1, 32(AX), Y0, Y0
+	VMOVDQU 16(AX), X1
+	VINSERTI128 $1, 48(AX), Y1, Y1
+
+	VBROADCASTI128 0*16(BX), Y2
+	AREION256_VROUND(Y0, Y1, Y2, Y3, Y5)
+	VBROADCASTI128 1*16(BX), Y2
+	AREION256_VROUND(Y1, Y0, Y2, Y3, Y5)
+	VBROADCASTI128 2*16(BX), Y2
+	AREION256_VROUND(Y0, Y1, Y2, Y3, Y5)
+	VBROADCASTI128 3*16(BX), Y2
+	AREION256_VROUND(Y1, Y0, Y2, Y3, Y5)
+	VBROADCASTI128 4*16(BX), Y2
+	AREION256_VROUND(Y0, Y1, Y2, Y3, Y5)
+	VBROADCASTI128 5*16(BX), Y2
+	AREION256_VROUND(Y1, Y0, Y2, Y3, Y5)
+	VBROADCASTI128 6*16(BX), Y2
+	AREION256_VROUND(Y0, Y1, Y2, Y3, Y5)
+	VBROADCASTI128 7*16(BX), Y2
+	AREION256_VROUND(Y1, Y0, Y2, Y3, Y5)
+	VBROADCASTI128 8*16(BX), Y2
+	AREION256_VROUND(Y0, Y1, Y2, Y3, Y5)
+	VBROADCASTI128 9*16(BX), Y2
+	AREION256_VROUND(Y1, Y0, Y2, Y3, Y5)
+
+	VMOVDQU X0, 0(AX)
+	VEXTRACTI128 $1, Y0, 32(AX)
+	VMOVDQU X1, 16(AX)
+	VEXTRACTI128 $1, Y1, 48(AX)
+	VZEROUPPER
+	RET
+
+// 2 independent Areion256 inverse permutations using VAES
+// func areion256x2InversePermuteVAES(states *Areion256x2)
+TEXT ·areion256x2InversePermuteVAES(SB),NOSPLIT,$0
+	MOVQ states+0(FP), AX
+	LEAQ ·areionRoundConstants(SB), BX
+	VPXOR X5, X5, X5
+
+	VMOVDQU 0(AX), X0
+	VINSERTI128 $1, 32(AX), Y0, Y0
+	VMOVDQU 16(AX), X1
+	VINSERTI128 $1, 48(AX), Y1, Y1
+
+	VBROADCASTI128 9*16(BX), Y2
+	AREION256_VINV_ROUND(Y1, Y0, Y2, Y3, Y5)
+	VBROADCASTI128 8*16(BX), Y2
+	AREION256_VINV_ROUND(Y0, Y1, Y2, Y3, Y5)
+	VBROADCASTI128 7*16(BX), Y2
+	AREION256_VINV_ROUND(Y1, Y0, Y2, Y3, Y5)
+	VBROADCASTI128 6*16(BX), Y2
+	AREION256_VINV_ROUND(Y0, Y1, Y2, Y3, Y5)
+	VBROADCASTI128 5*16(BX), Y2
+	AREION256_VINV_ROUND(Y1, Y0, Y2, Y3, Y5)
+	VBROADCASTI128 4*16(BX), Y2
+	AREION256_VINV_ROUND(Y0, Y1, Y2, Y3, Y5)
+	VBROADCASTI128 3*16(BX), Y2
+	AREION256_VINV_ROUND(Y1, Y0, Y2, Y3, Y5)
+	VBROADCASTI128 2*16(BX), Y2
+	AREION256_VINV_ROUND(Y0, Y1, Y2, Y3, Y5)
+	VBROADCASTI128 1*16(BX), Y2
+	AREION256_VINV_ROUND(Y1, Y0, Y2, Y3, Y5)
+	VBROADCASTI128 0*16(BX), Y2
+	AREION256_VINV_ROUND(Y0, Y1, Y2, Y3, Y5)
+
+	VMOVDQU X0, 0(AX)
+	VEXTRACTI128 $1, Y0, 32(AX)
+	VMOVDQU X1, 16(AX)
+	VEXTRACTI128 $1, Y1, 48(AX)
+	VZEROUPPER
+	RET
+
+// 4 independent Areion256 permutations using VAES
+// func areion256x4PermuteVAES(states *Areion256x4)
+TEXT ·areion256x4PermuteVAES(SB),NOSPLIT,$0
+	MOVQ states+0(FP), AX
+	LEAQ ·areionRoundConstants(SB), BX
+	VPXOR X5, X5, X5
+
+	VMOVDQU 0(AX), X0
+	VINSERTI32X4 $1, 32(AX), Z0, Z0
+	VINSERTI32X4 $2, 64(AX), Z0, Z0
+	VINSERTI32X4 $3, 96(AX), Z0, Z0
+	VMOVDQU 16(AX), X1
+	VINSERTI32X4 $1, 48(AX), Z1, Z1
+	VINSERTI32X4 $2, 80(AX), Z1, Z1
+	VINSERTI32X4 $3, 112(AX), Z1, Z1
+
+	VBROADCASTI32X4 0*16(BX), Z2
+	AREION256_VROUND(Z0, Z1, Z2, Z3, Z5)
+	VBROADCASTI32X4 1*16(BX), Z2
+	AREION256_VROUND(Z1, Z0, Z2, Z3, Z5)
+	VBROADCASTI32X4 2*16(BX), Z2
+	AREION256_VROUND(Z0, Z1, Z2, Z3, Z5)
+	VBROADCASTI32X4 3*16(BX), Z2
+	AREION256_VROUND(Z1, Z0, Z2, Z3, Z5)
+	VBROADCASTI32X4 4*16(BX), Z2
+	AREION256_VROUND(Z0, Z1, Z2, Z3, Z5)
+	VBROADCASTI32X4 5*16(BX), Z2
+	AREION256_VROUND(Z1, Z0, Z2, Z3, Z5)
+	VBROADCASTI32X4 6*16(BX), Z2
+	AREION256_VROUND(Z0, Z1, Z2, Z3, Z5)
+	VBROADCASTI32X4 7*16(BX), Z2
+	AREION256_VROUND(Z1, Z0, Z2, Z3, Z5)
+	VBROADCASTI32X4 8*16(BX), Z2
+	AREION256_VROUND(Z0, Z1, Z2, Z3, Z5)
+	VBROADCASTI32X4 9*16(BX), Z2
+	AREION256_VROUND(Z1, Z0, Z2, Z3, Z5)
+
+	VMOVDQU X0, 0(AX)
+	VEXTRACTI32X4 $1, Z0, 32(AX)
+	VEXTRACTI32X4 $2, Z0, 64(AX)
+	VEXTRACTI32X4 $3, Z0, 96(AX)
+	VMOVDQU X1, 16(AX)
+	VEXTRACTI32X4 $1, Z1, 48(AX)
+	VEXTRACTI32X4 $2, Z1, 80(AX)
+	VEXTRACTI32X4 $3, Z1, 112(AX)
+	VZEROUPPER
+	RET
+
+// 4 independent Areion256 inverse permutations using VAES
+// func areion256x4InversePermuteVAES(states *Areion256x4)
+TEXT ·areion256x4InversePermuteVAES(SB),NOSPLIT,$0
+	MOVQ states+0(FP), AX
+	LEAQ ·areionRoundConstants(SB), BX
+	VPXOR X5, X5, X5
+
+	VMOVDQU 0(AX), X0
+	VINSERTI32X4 $1, 32(AX), Z0, Z0
+	VINSERTI32X4 $2, 64(AX), Z0, Z0
+	VINSERTI32X4 $3, 96(AX), Z0, Z0
+	VMOVDQU 16(AX), X1
+	VINSERTI32X4 $1, 48(AX), Z1, Z1
+	VINSERTI32X4 $2, 80(AX), Z1, Z1
+	VINSERTI32X4 $3, 112(AX), Z1, Z1
+
+	VBROADCASTI32X4 9*16(BX), Z2
+	AREION256_VINV_ROUND(Z1, Z0, Z2, Z3, Z5)
+	VBROADCASTI32X4 8*16(BX), Z2
+	AREION256_VINV_ROUND(Z0, Z1, Z2, Z3, Z5)
+	VBROADCASTI32X4 7*16(BX), Z2
+	AREION256_VINV_ROUND(Z1, Z0, Z2, Z3, Z5)
+	VBROADCASTI32X4 6*16(BX), Z2
+	AREION256_VINV_ROUND(Z0, Z1, Z2, Z3, Z5)
+	VBROADCASTI32X4 5*16(BX), Z2
+	AREION256_VINV_ROUND(Z1, Z0, Z2, Z3, Z5)
+	VBROADCASTI32X4 4*16(BX), Z2
+	AREION256_VINV_ROUND(Z0, Z1, Z2, Z3, Z5)
+	VBROADCASTI32X4 3*16(BX), Z2
+	AREION256_VINV_ROUND(Z1, Z0, Z2, Z3, Z5)
+	VBROADCASTI32X4 2*16(BX), Z2
+	AREION256_VINV_ROUND(Z0, Z1, Z2, Z3, Z5)
+	VBROADCASTI32X4 1*16(BX), Z2
+	AREION256_VINV_ROUND(Z1, Z0, Z2, Z3, Z5)
+	VBROADCASTI32X4 0*16(BX), Z2
+	AREION256_VINV_ROUND(Z0, Z1, Z2, Z3, Z5)
+
+	VMOVDQU X0, 0(AX)
+	VEXTRACTI32X4 $1, Z0, 32(AX)
+	VEXTRACTI32X4 $2, Z0, 64(AX)
+	VEXTRACTI32X4 $3, Z0, 96(AX)
+	VMOVDQU X1, 16(AX)
+	VEXTRACTI32X4 $1, Z1, 48(AX)
+	VEXTRACTI32X4 $2, Z1, 80(AX)
+	VEXTRACTI32X4 $3, Z1, 112(AX)
+	VZEROUPPER
+	RET
+
+// 2 independent Areion512 permutations using VAES
+// func areion512x2PermuteVAES(states *Areion512x2)
+TEXT ·areion512x2PermuteVAES(SB),NOSPLIT,$0
+	MOVQ states+0(FP), AX
+	LEAQ ·areionRoundConstants(SB), BX
+	VPXOR X5, X5, X5
+
+	VMOVDQU 0(AX), X0
+	VINSERTI128 $1, 64(AX), Y0, Y0
+	VMOVDQU 16(AX), X1
+	VINSERTI128 $1, 80(AX), Y1, Y1
+	VMOVDQU 32(AX), X2
+	VINSERTI128 $1, 96(AX), Y2, Y2
+	VMOVDQU 48(AX), X3
+	VINSERTI128 $1, 112(AX), Y3, Y3
+
+	VBROADCASTI128 0*16(BX), Y4
+	AREION512_VROUND(Y0, Y1, Y2, Y3, Y4, Y5)
+	VBROADCASTI128 1*16(BX), Y4
+	AREION512_VROUND(Y1, Y2, Y3, Y0, Y4, Y5)
+	VBROADCASTI128 2*16(BX), Y4
+	AREION512_VROUND(Y2, Y3, Y0, Y1, Y4, Y5)
+	VBROADCASTI128 3*16(BX), Y4
+	AREION512_VROUND(Y3, Y0, Y1, Y2, Y4, Y5)
+	VBROADCASTI128 4*16(BX), Y4
+	AREION512_VROUND(Y0, Y1, Y2, Y3, Y4, Y5)
+	VBROADCASTI128 5*16(BX), Y4
+	AREION512_VROUND(Y1, Y2, Y3, Y0, Y4, Y5)
+	VBROADCASTI128 6*16(BX), Y4
+	AREION512_VROUND(Y2, Y3, Y0, Y1, Y4, Y5)
+	VBROADCASTI128 7*16(BX), Y4
+	AREION512_VROUND(Y3, Y0, Y1, Y2, Y4, Y5)
+	VBROADCASTI128 8*16(BX), Y4
+	AREION512_VROUND(Y0, Y1, Y2, Y3, Y4, Y5)
+	VBROADCASTI128 9*16(BX), Y4
+	AREION512_VROUND(Y1, Y2, Y3, Y0, Y4, Y5)
+	VBROADCASTI128 10*16(BX), Y4
+	AREION512_VROUND(Y2, Y3, Y0, Y1, Y4, Y5)
+	VBROADCASTI128 11*16(BX), Y4
+	AREION512_VROUND(Y3, Y0, Y1, Y2, Y4, Y5)
+	VBROADCASTI128 12*16(BX), Y4
+	AREION512_VROUND(Y0, Y1, Y2, Y3, Y4, Y5)
+	VBROADCASTI128 13*16(BX), Y4
+	AREION512_VROUND(Y1, Y2, Y3, Y0, Y4, Y5)
+	VBROADCASTI128 14*16(BX), Y4
+	AREION512_VROUND(Y2, Y3, Y0, Y1, Y4, Y5)
+
+	// Final rotation: words (x3, x0, x1, x2)
+	VMOVDQU X3, 0(AX)
+	VEXTRACTI128 $1, Y3, 64(AX)
+	VMOVDQU X0, 16(AX)
+	VEXTRACTI128 $1, Y0, 80(AX)
+	VMOVDQU X1, 32(AX)
+	VEXTRACTI128 $1, Y1, 96(AX)
+	VMOVDQU X2, 48(AX)
+	VEXTRACTI128 $1, Y2, 112(AX)
+	VZEROUPPER
+	RET
+
+// 2 independent Areion512 inverse permutations using VAES
+// func areion512x2InversePermuteVAES(states *Areion512x2)
+TEXT ·areion512x2InversePermuteVAES(SB),NOSPLIT,$0
+	MOVQ states+0(FP), AX
+	LEAQ ·areionRoundConstants(SB), BX
+	VPXOR X5, X5, X5
+
+	// Undo the final rotation while loading
+	VMOVDQU 0(AX), X3
+	VINSERTI128 $1, 64(AX), Y3, Y3
+	VMOVDQU 16(AX), X0
+	VINSERTI128 $1, 80(AX), Y0, Y0
+	VMOVDQU 32(AX), X1
+	VINSERTI128 $1, 96(AX), Y1, Y1
+	VMOVDQU 48(AX), X2
+	VINSERTI128 $1, 112(AX), Y2, Y2
+
+	VBROADCASTI128 14*16(BX), Y4
+	AREION512_VINV_ROUND(Y2, Y3, Y0, Y1, Y4, Y5)
+	VBROADCASTI128 13*16(BX), Y4
+	AREION512_VINV_ROUND(Y1, Y2, Y3, Y0, Y4, Y5)
+	VBROADCASTI128 12*16(BX), Y4
+	AREION512_VINV_ROUND(Y0, Y1, Y2, Y3, Y4, Y5)
+	VBROADCASTI128 11*16(BX), Y4
+	AREION512_VINV_ROUND(Y3, Y0, Y1, Y2, Y4, Y5)
+	VBROADCASTI128 10*16(BX), Y4
+	AREION512_VINV_ROUND(Y2, Y3, Y0, Y1, Y4, Y5)
+	VBROADCASTI128 9*16(BX), Y4
+	AREION512_VINV_ROUND(Y1, Y2, Y3, Y0, Y4, Y5)
+	VBROADCASTI128 8*16(BX), Y4
+	AREION512_VINV_ROUND(Y0, Y1, Y2, Y3, Y4, Y5)
+	VBROADCASTI128 7*16(BX), Y4
+	AREION512_VINV_ROUND(Y3, Y0, Y1, Y2, Y4, Y5)
+	VBROADCASTI128 6*16(BX), Y4
+	AREION512_VINV_ROUND(Y2, Y3, Y0, Y1, Y4, Y5)
+	VBROADCASTI128 5*16(BX), Y4
+	AREION512_VINV_ROUND(Y1, Y2, Y3, Y0, Y4, Y5)
+	VBROADCASTI128 4*16(BX), Y4
+	AREION512_VINV_ROUND(Y0, Y1, Y2, Y3, Y4, Y5)
+	VBROADCASTI128 3*16(BX), Y4
+	AREION512_VINV_ROUND(Y3, Y0, Y1, Y2, Y4, Y5)
+	VBROADCASTI128 2*16(BX), Y4
+	AREION512_VINV_ROUND(Y2, Y3, Y0, Y1, Y4, Y5)
+	VBROADCASTI128 1*16(BX), Y4
+	AREION512_VINV_ROUND(Y1, Y2, Y3, Y0, Y4, Y5)
+	VBROADCASTI128 0*16(BX), Y4
+	AREION512_VINV_ROUND(Y0, Y1, Y2, Y3, Y4, Y5)
+
+	VMOVDQU X0, 0(AX)
+	VEXTRACTI128 $1, Y0, 64(AX)
+	VMOVDQU X1, 16(AX)
+	VEXTRACTI128 $1, Y1, 80(AX)
+	VMOVDQU X2, 32(AX)
+	VEXTRACTI128 $1, Y2, 96(AX)
+	VMOVDQU X3, 48(AX)
+	VEXTRACTI128 $1, Y3, 112(AX)
+	VZEROUPPER
+	RET
+
+// 4 independent Areion512 permutations using VAES
+// func areion512x4PermuteVAES(states *Areion512x4)
+TEXT ·areion512x4PermuteVAES(SB),NOSPLIT,$0
+	MOVQ states+0(FP), AX
+	LEAQ ·areionRoundConstants(SB), BX
+	VPXOR X5, X5, X5
+
+	VMOVDQU 0(AX), X0
+	VINSERTI32X4 $1, 64(AX), Z0, Z0
+	VINSERTI32X4 $2, 128(AX), Z0, Z0
+	VINSERTI32X4 $3, 192(AX), Z0, Z0
+	VMOVDQU 16(AX), X1
+	VINSERTI32X4 $1, 80(AX), Z1, Z1
+	VINSERTI32X4 $2, 144(AX), Z1, Z1
+	VINSERTI32X4 $3, 208(AX), Z1, Z1
+	VMOVDQU 32(AX), X2
+	VINSERTI32X4 $1, 96(AX), Z2, Z2
+	VINSERTI32X4 $2, 160(AX), Z2, Z2
+	VINSERTI32X4 $3, 224(AX), Z2, Z2
+	VMOVDQU 48(AX), X3
+	VINSERTI32X4 $1, 112(AX), Z3, Z3
+	VINSERTI32X4 $2, 176(AX), Z3, Z3
+	VINSERTI32X4 $3, 240(AX), Z3, Z3
+
+	VBROADCASTI32X4 0*16(BX), Z4
+	AREION512_VROUND(Z0, Z1, Z2, Z3, Z4, Z5)
+	VBROADCASTI32X4 1*16(BX), Z4
+	AREION512_VROUND(Z1, Z2, Z3, Z0, Z4, Z5)
+	VBROADCASTI32X4 2*16(BX), Z4
+	AREION512_VROUND(Z2, Z3, Z0, Z1, Z4, Z5)
+	VBROADCASTI32X4 3*16(BX), Z4
+	AREION512_VROUND(Z3, Z0, Z1, Z2, Z4, Z5)
+	VBROADCASTI32X4 4*16(BX), Z4
+	AREION512_VROUND(Z0, Z1, Z2, Z3, Z4, Z5)
+	VBROADCASTI32X4 5*16(BX), Z4
+	AREION512_VROUND(Z1, Z2, Z3, Z0, Z4, Z5)
+	VBROADCASTI32X4 6*16(BX), Z4
+	AREION512_VROUND(Z2, Z3, Z0, Z1, Z4, Z5)
+	VBROADCASTI32X4 7*16(BX), Z4
+	AREION512_VROUND(Z3, Z0, Z1, Z2, Z4, Z5)
+	VBROADCASTI32X4 8*16(BX), Z4
+	AREION512_VROUND(Z0, Z1, Z2, Z3, Z4, Z5)
+	VBROADCASTI32X4 9*16(BX), Z4
+	AREION512_VROUND(Z1, Z2, Z3, Z0, Z4, Z5)
+	VBROADCASTI32X4 10*16(BX), Z4
+	AREION512_VROUND(Z2, Z3, Z0, Z1, Z4, Z5)
+	VBROADCASTI32X4 11*16(BX), Z4
+	AREION512_VROUND(Z3, Z0, Z1, Z2, Z4, Z5)
+	VBROADCASTI32X4 12*16(BX), Z4
+	AREION512_VROUND(Z0, Z1, Z2, Z3, Z4, Z5)
+	VBROADCASTI32X4 13*16(BX), Z4
+	AREION512_VROUND(Z1, Z2, Z3, Z0, Z4, Z5)
+	VBROADCASTI32X4 14*16(BX), Z4
+	AREION512_VROUND(Z2, Z3, Z0, Z1, Z4, Z5)
+
+	// Final rotation: words (x3, x0, x1, x2)
+	VMOVDQU X3, 0(AX)
+	VEXTRACTI32X4 $1, Z3, 64(AX)
+	VEXTRACTI32X4 $2, Z3, 128(AX)
+	VEXTRACTI32X4 $3, Z3, 192(AX)
+	VMOVDQU X0, 16(AX)
+	VEXTRACTI32X4 $1, Z0, 80(AX)
+	VEXTRACTI32X4 $2, Z0, 144(AX)
+	VEXTRACTI32X4 $3, Z0, 208(AX)
+	VMOVDQU X1, 32(AX)
+	VEXTRACTI32X4 $1, Z1, 96(AX)
+	VEXTRACTI32X4 $2, Z1, 160(AX)
+	VEXTRACTI32X4 $3, Z1, 224(AX)
+	VMOVDQU X2, 48(AX)
+	VEXTRACTI32X4 $1, Z2, 112(AX)
+	VEXTRACTI32X4 $2, Z2, 176(AX)
+	VEXTRACTI32X4 $3, Z2, 240(AX)
+	VZEROUPPER
+	RET
+
+// 4 independent Areion512 inverse permutations using VAES
+// func areion512x4InversePermuteVAES(states *Areion512x4)
+TEXT ·areion512x4InversePermuteVAES(SB),NOSPLIT,$0
+	MOVQ states+0(FP), AX
+	LEAQ ·areionRoundConstants(SB), BX
+	VPXOR X5, X5, X5
+
+	// Undo the final rotation while loading
+	VMOVDQU 0(AX), X3
+	VINSERTI32X4 $1, 64(AX), Z3, Z3
+	VINSERTI32X4 $2, 128(AX), Z3, Z3
+	VINSERTI32X4 $3, 192(AX), Z3, Z3
+	VMOVDQU 16(AX), X0
+	VINSERTI32X4 $1, 80(AX), Z0, Z0
+	VINSERTI32X4 $2, 144(AX), Z0, Z0
+	VINSERTI32X4 $3, 208(AX), Z0, Z0
+	VMOVDQU 32(AX), X1
+	VINSERTI32X4 $1, 96(AX), Z1, Z1
+	VINSERTI32X4 $2, 160(AX), Z1, Z1
+	VINSERTI32X4 $3, 224(AX), Z1, Z1
+	VMOVDQU 48(AX), X2
+	VINSERTI32X4 $1, 112(AX), Z2, Z2
+	VINSERTI32X4 $2, 176(AX), Z2, Z2
+	VINSERTI32X4 $3, 240(AX), Z2, Z2
+
+	VBROADCASTI32X4 14*16(BX), Z4
+	AREION512_VINV_ROUND(Z2, Z3, Z0, Z1, Z4, Z5)
+	VBROADCASTI32X4 13*16(BX), Z4
+	AREION512_VINV_ROUND(Z1, Z2, Z3, Z0, Z4, Z5)
+	VBROADCASTI32X4 12*16(BX), Z4
+	AREION512_VINV_ROUND(Z0, Z1, Z2, Z3, Z4, Z5)
+	VBROADCASTI32X4 11*16(BX), Z4
+	AREION512_VINV_ROUND(Z3, Z0, Z1, Z2, Z4, Z5)
+	VBROADCASTI32X4 10*16(BX), Z4
+	AREION512_VINV_ROUND(Z2, Z3, Z0, Z1, Z4, Z5)
+	VBROADCASTI32X4 9*16(BX), Z4
+	AREION512_VINV_ROUND(Z1, Z2, Z3, Z0, Z4, Z5)
+	VBROADCASTI32X4 8*16(BX), Z4
+	AREION512_VINV_ROUND(Z0, Z1, Z2, Z3, Z4, Z5)
+	VBROADCASTI32X4 7*16(BX), Z4
+	AREION512_VINV_ROUND(Z3, Z0, Z1, Z2, Z4, Z5)
+	VBROADCASTI32X4 6*16(BX), Z4
+	AREION512_VINV_ROUND(Z2, Z3, Z0, Z1, Z4, Z5)
+	VBROADCASTI32X4 5*16(BX), Z4
+	AREION512_VINV_ROUND(Z1, Z2, Z3, Z0, Z4, Z5)
+	VBROADCASTI32X4 4*16(BX), Z4
+	AREION512_VINV_ROUND(Z0, Z1, Z2, Z3, Z4, Z5)
+	VBROADCASTI32X4 3*16(BX), Z4
+	AREION512_VINV_ROUND(Z3, Z0, Z1, Z2, Z4, Z5)
+	VBROADCASTI32X4 2*16(BX), Z4
+	AREION512_VINV_ROUND(Z2, Z3, Z0, Z1, Z4, Z5)
+	VBROADCASTI32X4 1*16(BX), Z4
+	AREION512_VINV_ROUND(Z1, Z2, Z3, Z0, Z4, Z5)
+	VBROADCASTI32X4 0*16(BX), Z4
+	AREION512_VINV_ROUND(Z0, Z1, Z2, Z3, Z4, Z5)
+
+	VMOVDQU X0, 0(AX)
+	VEXTRACTI32X4 $1, Z0, 64(AX)
+	VEXTRACTI32X4 $2, Z0, 128(AX)
+	VEXTRACTI32X4 $3, Z0, 192(AX)
+	VMOVDQU X1, 16(AX)
+	VEXTRACTI32X4 $1, Z1, 80(AX)
+	VEXTRACTI32X4 $2, Z1, 144(AX)
+	VEXTRACTI32X4 $3, Z1, 208(AX)
+	VMOVDQU X2, 32(AX)
+	VEXTRACTI32X4 $1, Z2, 96(AX)
+	VEXTRACTI32X4 $2, Z2, 160(AX)
+	VEXTRACTI32X4 $3, Z2, 224(AX)
+	VMOVDQU X3, 48(AX)
+	VEXTRACTI32X4 $1, Z3, 112(AX)
+	VEXTRACTI32X4 $2, Z3, 176(AX)
+	VEXTRACTI32X4 $3, Z3, 240(AX)
+	VZEROUPPER
+	RET
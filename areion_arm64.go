@@ -13,3 +13,107 @@ func areion512PermuteAsm(state *Areion512)
 
 //go:noescape
 func areion512InversePermuteAsm(state *Areion512)
+
+//go:noescape
+func areion256x2PermuteAsm(states *Areion256x2)
+
+//go:noescape
+func areion256x2InversePermuteAsm(states *Areion256x2)
+
+//go:noescape
+func areion256x4PermuteAsm(states *Areion256x4)
+
+//go:noescape
+func areion256x4InversePermuteAsm(states *Areion256x4)
+
+//go:noescape
+func areion512x2PermuteAsm(states *Areion512x2)
+
+//go:noescape
+func areion512x2InversePermuteAsm(states *Areion512x2)
+
+//go:noescape
+func areion512x4PermuteAsm(states *Areion512x4)
+
+//go:noescape
+func areion512x4InversePermuteAsm(states *Areion512x4)
+
+func areion256x2Permute(s *Areion256x2) {
+	if CPU.HasARMCrypto {
+		areion256x2PermuteAsm(s)
+		return
+	}
+	for i := range s {
+		areion256PermuteSoftware(&s[i])
+	}
+}
+
+func areion256x2InversePermute(s *Areion256x2) {
+	if CPU.HasARMCrypto {
+		areion256x2InversePermuteAsm(s)
+		return
+	}
+	for i := range s {
+		areion256InversePermuteSoftware(&s[i])
+	}
+}
+
+func areion256x4Permute(s *Areion256x4) {
+	if CPU.HasARMCrypto {
+		areion256x4PermuteAsm(s)
+		return
+	}
+	for i := range s {
+		areion256PermuteSoftware(&s[i])
+	}
+}
+
+func areion256x4InversePermute(s *Areion256x4) {
+	if CPU.HasARMCrypto {
+		areion256x4InversePermuteAsm(s)
+		return
+	}
+	for i := range s {
+		areion256InversePermuteSoftware(&s[i])
+	}
+}
+
+func areion512x2Permute(s *Areion512x2) {
+	if CPU.HasARMCrypto {
+		areion512x2PermuteAsm(s)
+		return
+	}
+	for i := range s {
+		areion512PermuteSoftware(&s[i])
+	}
+}
+
+func areion512x2InversePermute(s *Areion512x2) {
+	if CPU.HasARMCrypto {
+		areion512x2InversePermuteAsm(s)
+		return
+	}
+	for i := range s {
+		areion512InversePermuteSoftware(&s[i])
+	}
+}
+
+func areion512x4Permute(s *Areion512x4) {
+	if CPU.HasARMCrypto {
+		areion512x4PermuteAsm(s)
+		return
+	}
+	for i := range s {
+		areion512PermuteSoftware(&s[i])
+	}
+}
+
+func areion512x4InversePermute(s *Areion512x4) {
+	if CPU.HasARMCrypto {
+		areion512x4InversePermuteAsm(s)
+		return
+	}
+	for i := range s {
+		areion512InversePermuteSoftware(&s[i])
+	}
+}
@@ -452,3 +452,1334 @@ TEXT areion512_inv_round<>(SB),NOSPLIT,$0
 	VEOR V3.B16, V5.B16, V3.B16
 
 	RET
+
+// Multi-state Areion permutations. Each state keeps its own registers and the
+// instructions of all states are interleaved, so the AESE/AESMC pipelines stay
+// busy. AESE with a zero key followed by AESMC is RoundNoKey, and AESE with the
+// round constant as key XORs it in before the next SubBytes/ShiftRows.
+
+// 2 independent Areion256 permutations, interleaved instruction by instruction
+// func areion256x2PermuteAsm(states *Areion256x2)
+TEXT ·areion256x2PermuteAsm(SB),NOSPLIT,$0
+	MOVD states+0(FP), R0
+	MOVD R0, R1
+	MOVD $·areionRoundConstants(SB), R2
+	VEOR V31.B16, V31.B16, V31.B16
+
+	VLD1.P 32(R0), [V0.B16, V1.B16]
+	VLD1.P 32(R0), [V2.B16, V3.B16]
+	MOVD $5, R3
+areion256x2_loop:
+
+	// Even round
+	VLD1.P 16(R2), [V30.B16]
+	AESE V31.B16, V0.B16
+	AESE V31.B16, V2.B16
+	AESMC V0.B16, V16.B16
+	AESMC V2.B16, V17.B16
+	AESE V30.B16, V16.B16
+	AESE V30.B16, V17.B16
+	AESMC V16.B16, V16.B16
+	AESMC V17.B16, V17.B16
+	VEOR V16.B16, V1.B16, V1.B16
+	VEOR V17.B16, V3.B16, V3.B16
+
+	// Odd round
+	VLD1.P 16(R2), [V30.B16]
+	AESE V31.B16, V1.B16
+	AESE V31.B16, V3.B16
+	AESMC V1.B16, V16.B16
+	AESMC V3.B16, V17.B16
+	AESE V30.B16, V16.B16
+	AESE V30.B16, V17.B16
+	AESMC V16.B16, V16.B16
+	AESMC V17.B16, V17.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V2.B16, V2.B16
+
+	SUB $1, R3, R3
+	CBNZ R3, areion256x2_loop
+
+	VST1.P [V0.B16, V1.B16], 32(R1)
+	VST1.P [V2.B16, V3.B16], 32(R1)
+	RET
+
+// 2 independent Areion256 inverse permutations, interleaved instruction by instruction
+// func areion256x2InversePermuteAsm(states *Areion256x2)
+TEXT ·areion256x2InversePermuteAsm(SB),NOSPLIT,$0
+	MOVD states+0(FP), R0
+	MOVD R0, R1
+	MOVD $·areionRoundConstants+144(SB), R2
+	VEOR V31.B16, V31.B16, V31.B16
+
+	VLD1.P 32(R0), [V0.B16, V1.B16]
+	VLD1.P 32(R0), [V2.B16, V3.B16]
+	MOVD $5, R3
+areion256x2_inv_loop:
+
+	// Odd round
+	VLD1 (R2), [V30.B16]
+	SUB $16, R2
+	AESD V31.B16, V1.B16
+	AESD V31.B16, V3.B16
+	VMOV V1.B16, V16.B16
+	VMOV V3.B16, V17.B16
+	AESE V31.B16, V16.B16
+	AESE V31.B16, V17.B16
+	AESMC V16.B16, V16.B16
+	AESMC V17.B16, V17.B16
+	AESE V30.B16, V16.B16
+	AESE V30.B16, V17.B16
+	AESMC V16.B16, V16.B16
+	AESMC V17.B16, V17.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V2.B16, V2.B16
+
+	// Even round
+	VLD1 (R2), [V30.B16]
+	SUB $16, R2
+	AESD V31.B16, V0.B16
+	AESD V31.B16, V2.B16
+	VMOV V0.B16, V16.B16
+	VMOV V2.B16, V17.B16
+	AESE V31.B16, V16.B16
+	AESE V31.B16, V17.B16
+	AESMC V16.B16, V16.B16
+	AESMC V17.B16, V17.B16
+	AESE V30.B16, V16.B16
+	AESE V30.B16, V17.B16
+	AESMC V16.B16, V16.B16
+	AESMC V17.B16, V17.B16
+	VEOR V16.B16, V1.B16, V1.B16
+	VEOR V17.B16, V3.B16, V3.B16
+
+	SUB $1, R3, R3
+	CBNZ R3, areion256x2_inv_loop
+
+	VST1.P [V0.B16, V1.B16], 32(R1)
+	VST1.P [V2.B16, V3.B16], 32(R1)
+	RET
+
+// 4 independent Areion256 permutations, interleaved instruction by instruction
+// func areion256x4PermuteAsm(states *Areion256x4)
+TEXT ·areion256x4PermuteAsm(SB),NOSPLIT,$0
+	MOVD states+0(FP), R0
+	MOVD R0, R1
+	MOVD $·areionRoundConstants(SB), R2
+	VEOR V31.B16, V31.B16, V31.B16
+
+	VLD1.P 32(R0), [V0.B16, V1.B16]
+	VLD1.P 32(R0), [V2.B16, V3.B16]
+	VLD1.P 32(R0), [V4.B16, V5.B16]
+	VLD1.P 32(R0), [V6.B16, V7.B16]
+	MOVD $5, R3
+areion256x4_loop:
+
+	// Even round
+	VLD1.P 16(R2), [V30.B16]
+	AESE V31.B16, V0.B16
+	AESE V31.B16, V2.B16
+	AESE V31.B16, V4.B16
+	AESE V31.B16, V6.B16
+	AESMC V0.B16, V16.B16
+	AESMC V2.B16, V17.B16
+	AESMC V4.B16, V18.B16
+	AESMC V6.B16, V19.B16
+	AESE V30.B16, V16.B16
+	AESE V30.B16, V17.B16
+	AESE V30.B16, V18.B16
+	AESE V30.B16, V19.B16
+	AESMC V16.B16, V16.B16
+	AESMC V17.B16, V17.B16
+	AESMC V18.B16, V18.B16
+	AESMC V19.B16, V19.B16
+	VEOR V16.B16, V1.B16, V1.B16
+	VEOR V17.B16, V3.B16, V3.B16
+	VEOR V18.B16, V5.B16, V5.B16
+	VEOR V19.B16, V7.B16, V7.B16
+
+	// Odd round
+	VLD1.P 16(R2), [V30.B16]
+	AESE V31.B16, V1.B16
+	AESE V31.B16, V3.B16
+	AESE V31.B16, V5.B16
+	AESE V31.B16, V7.B16
+	AESMC V1.B16, V16.B16
+	AESMC V3.B16, V17.B16
+	AESMC V5.B16, V18.B16
+	AESMC V7.B16, V19.B16
+	AESE V30.B16, V16.B16
+	AESE V30.B16, V17.B16
+	AESE V30.B16, V18.B16
+	AESE V30.B16, V19.B16
+	AESMC V16.B16, V16.B16
+	AESMC V17.B16, V17.B16
+	AESMC V18.B16, V18.B16
+	AESMC V19.B16, V19.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V2.B16, V2.B16
+	VEOR V18.B16, V4.B16, V4.B16
+	VEOR V19.B16, V6.B16, V6.B16
+
+	SUB $1, R3, R3
+	CBNZ R3, areion256x4_loop
+
+	VST1.P [V0.B16, V1.B16], 32(R1)
+	VST1.P [V2.B16, V3.B16], 32(R1)
+	VST1.P [V4.B16, V5.B16], 32(R1)
+	VST1.P [V6.B16, V7.B16], 32(R1)
+	RET
+
+// 4 independent Areion256 inverse permutations, interleaved instruction by instruction
+// func areion256x4InversePermuteAsm(states *Areion256x4)
+TEXT ·areion256x4InversePermuteAsm(SB),NOSPLIT,$0
+	MOVD states+0(FP), R0
+	MOVD R0, R1
+	MOVD $·areionRoundConstants+144(SB), R2
+	VEOR V31.B16, V31.B16, V31.B16
+
+	VLD1.P 32(R0), [V0.B16, V1.B16]
+	VLD1.P 32(R0), [V2.B16, V3.B16]
+	VLD1.P 32(R0), [V4.B16, V5.B16]
+	VLD1.P 32(R0), [V6.B16, V7.B16]
+	MOVD $5, R3
+areion256x4_inv_loop:
+
+	// Odd round
+	VLD1 (R2), [V30.B16]
+	SUB $16, R2
+	AESD V31.B16, V1.B16
+	AESD V31.B16, V3.B16
+	AESD V31.B16, V5.B16
+	AESD V31.B16, V7.B16
+	VMOV V1.B16, V16.B16
+	VMOV V3.B16, V17.B16
+	VMOV V5.B16, V18.B16
+	VMOV V7.B16, V19.B16
+	AESE V31.B16, V16.B16
+	AESE V31.B16, V17.B16
+	AESE V31.B16, V18.B16
+	AESE V31.B16, V19.B16
+	AESMC V16.B16, V16.B16
+	AESMC V17.B16, V17.B16
+	AESMC V18.B16, V18.B16
+	AESMC V19.B16, V19.B16
+	AESE V30.B16, V16.B16
+	AESE V30.B16, V17.B16
+	AESE V30.B16, V18.B16
+	AESE V30.B16, V19.B16
+	AESMC V16.B16, V16.B16
+	AESMC V17.B16, V17.B16
+	AESMC V18.B16, V18.B16
+	AESMC V19.B16, V19.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V2.B16, V2.B16
+	VEOR V18.B16, V4.B16, V4.B16
+	VEOR V19.B16, V6.B16, V6.B16
+
+	// Even round
+	VLD1 (R2), [V30.B16]
+	SUB $16, R2
+	AESD V31.B16, V0.B16
+	AESD V31.B16, V2.B16
+	AESD V31.B16, V4.B16
+	AESD V31.B16, V6.B16
+	VMOV V0.B16, V16.B16
+	VMOV V2.B16, V17.B16
+	VMOV V4.B16, V18.B16
+	VMOV V6.B16, V19.B16
+	AESE V31.B16, V16.B16
+	AESE V31.B16, V17.B16
+	AESE V31.B16, V18.B16
+	AESE V31.B16, V19.B16
+	AESMC V16.B16, V16.B16
+	AESMC V17.B16, V17.B16
+	AESMC V18.B16, V18.B16
+	AESMC V19.B16, V19.B16
+	AESE V30.B16, V16.B16
+	AESE V30.B16, V17.B16
+	AESE V30.B16, V18.B16
+	AESE V30.B16, V19.B16
+	AESMC V16.B16, V16.B16
+	AESMC V17.B16, V17.B16
+	AESMC V18.B16, V18.B16
+	AESMC V19.B16, V19.B16
+	VEOR V16.B16, V1.B16, V1.B16
+	VEOR V17.B16, V3.B16, V3.B16
+	VEOR V18.B16, V5.B16, V5.B16
+	VEOR V19.B16, V7.B16, V7.B16
+
+	SUB $1, R3, R3
+	CBNZ R3, areion256x4_inv_loop
+
+	VST1.P [V0.B16, V1.B16], 32(R1)
+	VST1.P [V2.B16, V3.B16], 32(R1)
+	VST1.P [V4.B16, V5.B16], 32(R1)
+	VST1.P [V6.B16, V7.B16], 32(R1)
+	RET
+
+// 2 independent Areion512 permutations, interleaved instruction by instruction
+// func areion512x2PermuteAsm(states *Areion512x2)
+TEXT ·areion512x2PermuteAsm(SB),NOSPLIT,$0
+	MOVD states+0(FP), R0
+	MOVD R0, R1
+	MOVD $·areionRoundConstants(SB), R2
+	VEOR V31.B16, V31.B16, V31.B16
+
+	VLD1.P 64(R0), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VLD1.P 64(R0), [V4.B16, V5.B16, V6.B16, V7.B16]
+
+	MOVD $3, R3
+areion512x2_loop:
+
+	// Round i+0
+	VLD1.P 16(R2), [V30.B16]
+	AESE V31.B16, V0.B16
+	AESE V31.B16, V4.B16
+	AESE V31.B16, V2.B16
+	AESE V31.B16, V6.B16
+	AESMC V0.B16, V16.B16
+	AESMC V4.B16, V18.B16
+	AESMC V2.B16, V17.B16
+	AESMC V6.B16, V19.B16
+	VEOR V16.B16, V1.B16, V1.B16
+	VEOR V18.B16, V5.B16, V5.B16
+	VEOR V17.B16, V3.B16, V3.B16
+	VEOR V19.B16, V7.B16, V7.B16
+	AESE V30.B16, V2.B16
+	AESE V30.B16, V6.B16
+	AESMC V2.B16, V2.B16
+	AESMC V6.B16, V6.B16
+
+	// Round i+1
+	VLD1.P 16(R2), [V30.B16]
+	AESE V31.B16, V1.B16
+	AESE V31.B16, V5.B16
+	AESE V31.B16, V3.B16
+	AESE V31.B16, V7.B16
+	AESMC V1.B16, V16.B16
+	AESMC V5.B16, V18.B16
+	AESMC V3.B16, V17.B16
+	AESMC V7.B16, V19.B16
+	VEOR V16.B16, V2.B16, V2.B16
+	VEOR V18.B16, V6.B16, V6.B16
+	VEOR V17.B16, V0.B16, V0.B16
+	VEOR V19.B16, V4.B16, V4.B16
+	AESE V30.B16, V3.B16
+	AESE V30.B16, V7.B16
+	AESMC V3.B16, V3.B16
+	AESMC V7.B16, V7.B16
+
+	// Round i+2
+	VLD1.P 16(R2), [V30.B16]
+	AESE V31.B16, V2.B16
+	AESE V31.B16, V6.B16
+	AESE V31.B16, V0.B16
+	AESE V31.B16, V4.B16
+	AESMC V2.B16, V16.B16
+	AESMC V6.B16, V18.B16
+	AESMC V0.B16, V17.B16
+	AESMC V4.B16, V19.B16
+	VEOR V16.B16, V3.B16, V3.B16
+	VEOR V18.B16, V7.B16, V7.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V19.B16, V5.B16, V5.B16
+	AESE V30.B16, V0.B16
+	AESE V30.B16, V4.B16
+	AESMC V0.B16, V0.B16
+	AESMC V4.B16, V4.B16
+
+	// Round i+3
+	VLD1.P 16(R2), [V30.B16]
+	AESE V31.B16, V3.B16
+	AESE V31.B16, V7.B16
+	AESE V31.B16, V1.B16
+	AESE V31.B16, V5.B16
+	AESMC V3.B16, V16.B16
+	AESMC V7.B16, V18.B16
+	AESMC V1.B16, V17.B16
+	AESMC V5.B16, V19.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V18.B16, V4.B16, V4.B16
+	VEOR V17.B16, V2.B16, V2.B16
+	VEOR V19.B16, V6.B16, V6.B16
+	AESE V30.B16, V1.B16
+	AESE V30.B16, V5.B16
+	AESMC V1.B16, V1.B16
+	AESMC V5.B16, V5.B16
+
+	SUB $1, R3, R3
+	CBNZ R3, areion512x2_loop
+
+	// Round 12
+	VLD1.P 16(R2), [V30.B16]
+	AESE V31.B16, V0.B16
+	AESE V31.B16, V4.B16
+	AESE V31.B16, V2.B16
+	AESE V31.B16, V6.B16
+	AESMC V0.B16, V16.B16
+	AESMC V4.B16, V18.B16
+	AESMC V2.B16, V17.B16
+	AESMC V6.B16, V19.B16
+	VEOR V16.B16, V1.B16, V1.B16
+	VEOR V18.B16, V5.B16, V5.B16
+	VEOR V17.B16, V3.B16, V3.B16
+	VEOR V19.B16, V7.B16, V7.B16
+	AESE V30.B16, V2.B16
+	AESE V30.B16, V6.B16
+	AESMC V2.B16, V2.B16
+	AESMC V6.B16, V6.B16
+
+	// Round 13
+	VLD1.P 16(R2), [V30.B16]
+	AESE V31.B16, V1.B16
+	AESE V31.B16, V5.B16
+	AESE V31.B16, V3.B16
+	AESE V31.B16, V7.B16
+	AESMC V1.B16, V16.B16
+	AESMC V5.B16, V18.B16
+	AESMC V3.B16, V17.B16
+	AESMC V7.B16, V19.B16
+	VEOR V16.B16, V2.B16, V2.B16
+	VEOR V18.B16, V6.B16, V6.B16
+	VEOR V17.B16, V0.B16, V0.B16
+	VEOR V19.B16, V4.B16, V4.B16
+	AESE V30.B16, V3.B16
+	AESE V30.B16, V7.B16
+	AESMC V3.B16, V3.B16
+	AESMC V7.B16, V7.B16
+
+	// Round 14
+	VLD1.P 16(R2), [V30.B16]
+	AESE V31.B16, V2.B16
+	AESE V31.B16, V6.B16
+	AESE V31.B16, V0.B16
+	AESE V31.B16, V4.B16
+	AESMC V2.B16, V16.B16
+	AESMC V6.B16, V18.B16
+	AESMC V0.B16, V17.B16
+	AESMC V4.B16, V19.B16
+	VEOR V16.B16, V3.B16, V3.B16
+	VEOR V18.B16, V7.B16, V7.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V19.B16, V5.B16, V5.B16
+	AESE V30.B16, V0.B16
+	AESE V30.B16, V4.B16
+	AESMC V0.B16, V0.B16
+	AESMC V4.B16, V4.B16
+
+	VST1.P [V3.B16], 16(R1)
+	VST1.P [V0.B16], 16(R1)
+	VST1.P [V1.B16], 16(R1)
+	VST1.P [V2.B16], 16(R1)
+	VST1.P [V7.B16], 16(R1)
+	VST1.P [V4.B16], 16(R1)
+	VST1.P [V5.B16], 16(R1)
+	VST1.P [V6.B16], 16(R1)
+	RET
+
+// 2 independent Areion512 inverse permutations, interleaved instruction by instruction
+// func areion512x2InversePermuteAsm(states *Areion512x2)
+TEXT ·areion512x2InversePermuteAsm(SB),NOSPLIT,$0
+	MOVD states+0(FP), R0
+	MOVD R0, R1
+	MOVD $·areionRoundConstants+224(SB), R2
+	VEOR V31.B16, V31.B16, V31.B16
+
+	VLD1.P 64(R0), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VLD1.P 64(R0), [V4.B16, V5.B16, V6.B16, V7.B16]
+
+	// Round 14
+	VLD1 (R2), [V30.B16]
+	SUB $16, R2
+	AESD V31.B16, V3.B16
+	AESD V31.B16, V7.B16
+	AESIMC V1.B16, V1.B16
+	AESIMC V5.B16, V5.B16
+	AESD V31.B16, V1.B16
+	AESD V31.B16, V5.B16
+	AESD V30.B16, V1.B16
+	AESD V30.B16, V5.B16
+	VMOV V3.B16, V16.B16
+	VMOV V7.B16, V18.B16
+	VMOV V1.B16, V17.B16
+	VMOV V5.B16, V19.B16
+	AESE V31.B16, V16.B16
+	AESE V31.B16, V18.B16
+	AESE V31.B16, V17.B16
+	AESE V31.B16, V19.B16
+	AESMC V16.B16, V16.B16
+	AESMC V18.B16, V18.B16
+	AESMC V17.B16, V17.B16
+	AESMC V19.B16, V19.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V18.B16, V4.B16, V4.B16
+	VEOR V17.B16, V2.B16, V2.B16
+	VEOR V19.B16, V6.B16, V6.B16
+
+	// Round 13
+	VLD1 (R2), [V30.B16]
+	SUB $16, R2
+	AESD V31.B16, V2.B16
+	AESD V31.B16, V6.B16
+	AESIMC V0.B16, V0.B16
+	AESIMC V4.B16, V4.B16
+	AESD V31.B16, V0.B16
+	AESD V31.B16, V4.B16
+	AESD V30.B16, V0.B16
+	AESD V30.B16, V4.B16
+	VMOV V2.B16, V16.B16
+	VMOV V6.B16, V18.B16
+	VMOV V0.B16, V17.B16
+	VMOV V4.B16, V19.B16
+	AESE V31.B16, V16.B16
+	AESE V31.B16, V18.B16
+	AESE V31.B16, V17.B16
+	AESE V31.B16, V19.B16
+	AESMC V16.B16, V16.B16
+	AESMC V18.B16, V18.B16
+	AESMC V17.B16, V17.B16
+	AESMC V19.B16, V19.B16
+	VEOR V16.B16, V3.B16, V3.B16
+	VEOR V18.B16, V7.B16, V7.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V19.B16, V5.B16, V5.B16
+
+	// Round 12
+	VLD1 (R2), [V30.B16]
+	SUB $16, R2
+	AESD V31.B16, V1.B16
+	AESD V31.B16, V5.B16
+	AESIMC V3.B16, V3.B16
+	AESIMC V7.B16, V7.B16
+	AESD V31.B16, V3.B16
+	AESD V31.B16, V7.B16
+	AESD V30.B16, V3.B16
+	AESD V30.B16, V7.B16
+	VMOV V1.B16, V16.B16
+	VMOV V5.B16, V18.B16
+	VMOV V3.B16, V17.B16
+	VMOV V7.B16, V19.B16
+	AESE V31.B16, V16.B16
+	AESE V31.B16, V18.B16
+	AESE V31.B16, V17.B16
+	AESE V31.B16, V19.B16
+	AESMC V16.B16, V16.B16
+	AESMC V18.B16, V18.B16
+	AESMC V17.B16, V17.B16
+	AESMC V19.B16, V19.B16
+	VEOR V16.B16, V2.B16, V2.B16
+	VEOR V18.B16, V6.B16, V6.B16
+	VEOR V17.B16, V0.B16, V0.B16
+	VEOR V19.B16, V4.B16, V4.B16
+
+	MOVD $3, R3
+areion512x2_inv_loop:
+
+	// Round 11-i
+	VLD1 (R2), [V30.B16]
+	SUB $16, R2
+	AESD V31.B16, V0.B16
+	AESD V31.B16, V4.B16
+	AESIMC V2.B16, V2.B16
+	AESIMC V6.B16, V6.B16
+	AESD V31.B16, V2.B16
+	AESD V31.B16, V6.B16
+	AESD V30.B16, V2.B16
+	AESD V30.B16, V6.B16
+	VMOV V0.B16, V16.B16
+	VMOV V4.B16, V18.B16
+	VMOV V2.B16, V17.B16
+	VMOV V6.B16, V19.B16
+	AESE V31.B16, V16.B16
+	AESE V31.B16, V18.B16
+	AESE V31.B16, V17.B16
+	AESE V31.B16, V19.B16
+	AESMC V16.B16, V16.B16
+	AESMC V18.B16, V18.B16
+	AESMC V17.B16, V17.B16
+	AESMC V19.B16, V19.B16
+	VEOR V16.B16, V1.B16, V1.B16
+	VEOR V18.B16, V5.B16, V5.B16
+	VEOR V17.B16, V3.B16, V3.B16
+	VEOR V19.B16, V7.B16, V7.B16
+
+	// Round 10-i
+	VLD1 (R2), [V30.B16]
+	SUB $16, R2
+	AESD V31.B16, V3.B16
+	AESD V31.B16, V7.B16
+	AESIMC V1.B16, V1.B16
+	AESIMC V5.B16, V5.B16
+	AESD V31.B16, V1.B16
+	AESD V31.B16, V5.B16
+	AESD V30.B16, V1.B16
+	AESD V30.B16, V5.B16
+	VMOV V3.B16, V16.B16
+	VMOV V7.B16, V18.B16
+	VMOV V1.B16, V17.B16
+	VMOV V5.B16, V19.B16
+	AESE V31.B16, V16.B16
+	AESE V31.B16, V18.B16
+	AESE V31.B16, V17.B16
+	AESE V31.B16, V19.B16
+	AESMC V16.B16, V16.B16
+	AESMC V18.B16, V18.B16
+	AESMC V17.B16, V17.B16
+	AESMC V19.B16, V19.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V18.B16, V4.B16, V4.B16
+	VEOR V17.B16, V2.B16, V2.B16
+	VEOR V19.B16, V6.B16, V6.B16
+
+	// Round 9-i
+	VLD1 (R2), [V30.B16]
+	SUB $16, R2
+	AESD V31.B16, V2.B16
+	AESD V31.B16, V6.B16
+	AESIMC V0.B16, V0.B16
+	AESIMC V4.B16, V4.B16
+	AESD V31.B16, V0.B16
+	AESD V31.B16, V4.B16
+	AESD V30.B16, V0.B16
+	AESD V30.B16, V4.B16
+	VMOV V2.B16, V16.B16
+	VMOV V6.B16, V18.B16
+	VMOV V0.B16, V17.B16
+	VMOV V4.B16, V19.B16
+	AESE V31.B16, V16.B16
+	AESE V31.B16, V18.B16
+	AESE V31.B16, V17.B16
+	AESE V31.B16, V19.B16
+	AESMC V16.B16, V16.B16
+	AESMC V18.B16, V18.B16
+	AESMC V17.B16, V17.B16
+	AESMC V19.B16, V19.B16
+	VEOR V16.B16, V3.B16, V3.B16
+	VEOR V18.B16, V7.B16, V7.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V19.B16, V5.B16, V5.B16
+
+	// Round 8-i
+	VLD1 (R2), [V30.B16]
+	SUB $16, R2
+	AESD V31.B16, V1.B16
+	AESD V31.B16, V5.B16
+	AESIMC V3.B16, V3.B16
+	AESIMC V7.B16, V7.B16
+	AESD V31.B16, V3.B16
+	AESD V31.B16, V7.B16
+	AESD V30.B16, V3.B16
+	AESD V30.B16, V7.B16
+	VMOV V1.B16, V16.B16
+	VMOV V5.B16, V18.B16
+	VMOV V3.B16, V17.B16
+	VMOV V7.B16, V19.B16
+	AESE V31.B16, V16.B16
+	AESE V31.B16, V18.B16
+	AESE V31.B16, V17.B16
+	AESE V31.B16, V19.B16
+	AESMC V16.B16, V16.B16
+	AESMC V18.B16, V18.B16
+	AESMC V17.B16, V17.B16
+	AESMC V19.B16, V19.B16
+	VEOR V16.B16, V2.B16, V2.B16
+	VEOR V18.B16, V6.B16, V6.B16
+	VEOR V17.B16, V0.B16, V0.B16
+	VEOR V19.B16, V4.B16, V4.B16
+
+	SUB $1, R3, R3
+	CBNZ R3, areion512x2_inv_loop
+
+	VST1.P [V1.B16], 16(R1)
+	VST1.P [V2.B16], 16(R1)
+	VST1.P [V3.B16], 16(R1)
+	VST1.P [V0.B16], 16(R1)
+	VST1.P [V5.B16], 16(R1)
+	VST1.P [V6.B16], 16(R1)
+	VST1.P [V7.B16], 16(R1)
+	VST1.P [V4.B16], 16(R1)
+	RET
+
+// 4 independent Areion512 permutations, interleaved instruction by instruction
+// func areion512x4PermuteAsm(states *Areion512x4)
+TEXT ·areion512x4PermuteAsm(SB),NOSPLIT,$0
+	MOVD states+0(FP), R0
+	MOVD R0, R1
+	MOVD $·areionRoundConstants(SB), R2
+	VEOR V31.B16, V31.B16, V31.B16
+
+	VLD1.P 64(R0), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VLD1.P 64(R0), [V4.B16, V5.B16, V6.B16, V7.B16]
+	VLD1.P 64(R0), [V8.B16, V9.B16, V10.B16, V11.B16]
+	VLD1.P 64(R0), [V12.B16, V13.B16, V14.B16, V15.B16]
+
+	MOVD $3, R3
+areion512x4_loop:
+
+	// Round i+0
+	VLD1.P 16(R2), [V30.B16]
+	AESE V31.B16, V0.B16
+	AESE V31.B16, V4.B16
+	AESE V31.B16, V8.B16
+	AESE V31.B16, V12.B16
+	AESE V31.B16, V2.B16
+	AESE V31.B16, V6.B16
+	AESE V31.B16, V10.B16
+	AESE V31.B16, V14.B16
+	AESMC V0.B16, V16.B16
+	AESMC V4.B16, V18.B16
+	AESMC V8.B16, V20.B16
+	AESMC V12.B16, V22.B16
+	AESMC V2.B16, V17.B16
+	AESMC V6.B16, V19.B16
+	AESMC V10.B16, V21.B16
+	AESMC V14.B16, V23.B16
+	VEOR V16.B16, V1.B16, V1.B16
+	VEOR V18.B16, V5.B16, V5.B16
+	VEOR V20.B16, V9.B16, V9.B16
+	VEOR V22.B16, V13.B16, V13.B16
+	VEOR V17.B16, V3.B16, V3.B16
+	VEOR V19.B16, V7.B16, V7.B16
+	VEOR V21.B16, V11.B16, V11.B16
+	VEOR V23.B16, V15.B16, V15.B16
+	AESE V30.B16, V2.B16
+	AESE V30.B16, V6.B16
+	AESE V30.B16, V10.B16
+	AESE V30.B16, V14.B16
+	AESMC V2.B16, V2.B16
+	AESMC V6.B16, V6.B16
+	AESMC V10.B16, V10.B16
+	AESMC V14.B16, V14.B16
+
+	// Round i+1
+	VLD1.P 16(R2), [V30.B16]
+	AESE V31.B16, V1.B16
+	AESE V31.B16, V5.B16
+	AESE V31.B16, V9.B16
+	AESE V31.B16, V13.B16
+	AESE V31.B16, V3.B16
+	AESE V31.B16, V7.B16
+	AESE V31.B16, V11.B16
+	AESE V31.B16, V15.B16
+	AESMC V1.B16, V16.B16
+	AESMC V5.B16, V18.B16
+	AESMC V9.B16, V20.B16
+	AESMC V13.B16, V22.B16
+	AESMC V3.B16, V17.B16
+	AESMC V7.B16, V19.B16
+	AESMC V11.B16, V21.B16
+	AESMC V15.B16, V23.B16
+	VEOR V16.B16, V2.B16, V2.B16
+	VEOR V18.B16, V6.B16, V6.B16
+	VEOR V20.B16, V10.B16, V10.B16
+	VEOR V22.B16, V14.B16, V14.B16
+	VEOR V17.B16, V0.B16, V0.B16
+	VEOR V19.B16, V4.B16, V4.B16
+	VEOR V21.B16, V8.B16, V8.B16
+	VEOR V23.B16, V12.B16, V12.B16
+	AESE V30.B16, V3.B16
+	AESE V30.B16, V7.B16
+	AESE V30.B16, V11.B16
+	AESE V30.B16, V15.B16
+	AESMC V3.B16, V3.B16
+	AESMC V7.B16, V7.B16
+	AESMC V11.B16, V11.B16
+	AESMC V15.B16, V15.B16
+
+	// Round i+2
+	VLD1.P 16(R2), [V30.B16]
+	AESE V31.B16, V2.B16
+	AESE V31.B16, V6.B16
+	AESE V31.B16, V10.B16
+	AESE V31.B16, V14.B16
+	AESE V31.B16, V0.B16
+	AESE V31.B16, V4.B16
+	AESE V31.B16, V8.B16
+	AESE V31.B16, V12.B16
+	AESMC V2.B16, V16.B16
+	AESMC V6.B16, V18.B16
+	AESMC V10.B16, V20.B16
+	AESMC V14.B16, V22.B16
+	AESMC V0.B16, V17.B16
+	AESMC V4.B16, V19.B16
+	AESMC V8.B16, V21.B16
+	AESMC V12.B16, V23.B16
+	VEOR V16.B16, V3.B16, V3.B16
+	VEOR V18.B16, V7.B16, V7.B16
+	VEOR V20.B16, V11.B16, V11.B16
+	VEOR V22.B16, V15.B16, V15.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V19.B16, V5.B16, V5.B16
+	VEOR V21.B16, V9.B16, V9.B16
+	VEOR V23.B16, V13.B16, V13.B16
+	AESE V30.B16, V0.B16
+	AESE V30.B16, V4.B16
+	AESE V30.B16, V8.B16
+	AESE V30.B16, V12.B16
+	AESMC V0.B16, V0.B16
+	AESMC V4.B16, V4.B16
+	AESMC V8.B16, V8.B16
+	AESMC V12.B16, V12.B16
+
+	// Round i+3
+	VLD1.P 16(R2), [V30.B16]
+	AESE V31.B16, V3.B16
+	AESE V31.B16, V7.B16
+	AESE V31.B16, V11.B16
+	AESE V31.B16, V15.B16
+	AESE V31.B16, V1.B16
+	AESE V31.B16, V5.B16
+	AESE V31.B16, V9.B16
+	AESE V31.B16, V13.B16
+	AESMC V3.B16, V16.B16
+	AESMC V7.B16, V18.B16
+	AESMC V11.B16, V20.B16
+	AESMC V15.B16, V22.B16
+	AESMC V1.B16, V17.B16
+	AESMC V5.B16, V19.B16
+	AESMC V9.B16, V21.B16
+	AESMC V13.B16, V23.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V18.B16, V4.B16, V4.B16
+	VEOR V20.B16, V8.B16, V8.B16
+	VEOR V22.B16, V12.B16, V12.B16
+	VEOR V17.B16, V2.B16, V2.B16
+	VEOR V19.B16, V6.B16, V6.B16
+	VEOR V21.B16, V10.B16, V10.B16
+	VEOR V23.B16, V14.B16, V14.B16
+	AESE V30.B16, V1.B16
+	AESE V30.B16, V5.B16
+	AESE V30.B16, V9.B16
+	AESE V30.B16, V13.B16
+	AESMC V1.B16, V1.B16
+	AESMC V5.B16, V5.B16
+	AESMC V9.B16, V9.B16
+	AESMC V13.B16, V13.B16
+
+	SUB $1, R3, R3
+	CBNZ R3, areion512x4_loop
+
+	// Round 12
+	VLD1.P 16(R2), [V30.B16]
+	AESE V31.B16, V0.B16
+	AESE V31.B16, V4.B16
+	AESE V31.B16, V8.B16
+	AESE V31.B16, V12.B16
+	AESE V31.B16, V2.B16
+	AESE V31.B16, V6.B16
+	AESE V31.B16, V10.B16
+	AESE V31.B16, V14.B16
+	AESMC V0.B16, V16.B16
+	AESMC V4.B16, V18.B16
+	AESMC V8.B16, V20.B16
+	AESMC V12.B16, V22.B16
+	AESMC V2.B16, V17.B16
+	AESMC V6.B16, V19.B16
+	AESMC V10.B16, V21.B16
+	AESMC V14.B16, V23.B16
+	VEOR V16.B16, V1.B16, V1.B16
+	VEOR V18.B16, V5.B16, V5.B16
+	VEOR V20.B16, V9.B16, V9.B16
+	VEOR V22.B16, V13.B16, V13.B16
+	VEOR V17.B16, V3.B16, V3.B16
+	VEOR V19.B16, V7.B16, V7.B16
+	VEOR V21.B16, V11.B16, V11.B16
+	VEOR V23.B16, V15.B16, V15.B16
+	AESE V30.B16, V2.B16
+	AESE V30.B16, V6.B16
+	AESE V30.B16, V10.B16
+	AESE V30.B16, V14.B16
+	AESMC V2.B16, V2.B16
+	AESMC V6.B16, V6.B16
+	AESMC V10.B16, V10.B16
+	AESMC V14.B16, V14.B16
+
+	// Round 13
+	VLD1.P 16(R2), [V30.B16]
+	AESE V31.B16, V1.B16
+	AESE V31.B16, V5.B16
+	AESE V31.B16, V9.B16
+	AESE V31.B16, V13.B16
+	AESE V31.B16, V3.B16
+	AESE V31.B16, V7.B16
+	AESE V31.B16, V11.B16
+	AESE V31.B16, V15.B16
+	AESMC V1.B16, V16.B16
+	AESMC V5.B16, V18.B16
+	AESMC V9.B16, V20.B16
+	AESMC V13.B16, V22.B16
+	AESMC V3.B16, V17.B16
+	AESMC V7.B16, V19.B16
+	AESMC V11.B16, V21.B16
+	AESMC V15.B16, V23.B16
+	VEOR V16.B16, V2.B16, V2.B16
+	VEOR V18.B16, V6.B16, V6.B16
+	VEOR V20.B16, V10.B16, V10.B16
+	VEOR V22.B16, V14.B16, V14.B16
+	VEOR V17.B16, V0.B16, V0.B16
+	VEOR V19.B16, V4.B16, V4.B16
+	VEOR V21.B16, V8.B16, V8.B16
+	VEOR V23.B16, V12.B16, V12.B16
+	AESE V30.B16, V3.B16
+	AESE V30.B16, V7.B16
+	AESE V30.B16, V11.B16
+	AESE V30.B16, V15.B16
+	AESMC V3.B16, V3.B16
+	AESMC V7.B16, V7.B16
+	AESMC V11.B16, V11.B16
+	AESMC V15.B16, V15.B16
+
+	// Round 14
+	VLD1.P 16(R2), [V30.B16]
+	AESE V31.B16, V2.B16
+	AESE V31.B16, V6.B16
+	AESE V31.B16, V10.B16
+	AESE V31.B16, V14.B16
+	AESE V31.B16, V0.B16
+	AESE V31.B16, V4.B16
+	AESE V31.B16, V8.B16
+	AESE V31.B16, V12.B16
+	AESMC V2.B16, V16.B16
+	AESMC V6.B16, V18.B16
+	AESMC V10.B16, V20.B16
+	AESMC V14.B16, V22.B16
+	AESMC V0.B16, V17.B16
+	AESMC V4.B16, V19.B16
+	AESMC V8.B16, V21.B16
+	AESMC V12.B16, V23.B16
+	VEOR V16.B16, V3.B16, V3.B16
+	VEOR V18.B16, V7.B16, V7.B16
+	VEOR V20.B16, V11.B16, V11.B16
+	VEOR V22.B16, V15.B16, V15.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V19.B16, V5.B16, V5.B16
+	VEOR V21.B16, V9.B16, V9.B16
+	VEOR V23.B16, V13.B16, V13.B16
+	AESE V30.B16, V0.B16
+	AESE V30.B16, V4.B16
+	AESE V30.B16, V8.B16
+	AESE V30.B16, V12.B16
+	AESMC V0.B16, V0.B16
+	AESMC V4.B16, V4.B16
+	AESMC V8.B16, V8.B16
+	AESMC V12.B16, V12.B16
+
+	VST1.P [V3.B16], 16(R1)
+	VST1.P [V0.B16], 16(R1)
+	VST1.P [V1.B16], 16(R1)
+	VST1.P [V2.B16], 16(R1)
+	VST1.P [V7.B16], 16(R1)
+	VST1.P [V4.B16], 16(R1)
+	VST1.P [V5.B16], 16(R1)
+	VST1.P [V6.B16], 16(R1)
+	VST1.P [V11.B16], 16(R1)
+	VST1.P [V8.B16], 16(R1)
+	VST1.P [V9.B16], 16(R1)
+	VST1.P [V10.B16], 16(R1)
+	VST1.P [V15.B16], 16(R1)
+	VST1.P [V12.B16], 16(R1)
+	VST1.P [V13.B16], 16(R1)
+	VST1.P [V14.B16], 16(R1)
+	RET
+
+// 4 independent Areion512 inverse permutations, interleaved instruction by instruction
+// func areion512x4InversePermuteAsm(states *Areion512x4)
+TEXT ·areion512x4InversePermuteAsm(SB),NOSPLIT,$0
+	MOVD states+0(FP), R0
+	MOVD R0, R1
+	MOVD $·areionRoundConstants+224(SB), R2
+	VEOR V31.B16, V31.B16, V31.B16
+
+	VLD1.P 64(R0), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VLD1.P 64(R0), [V4.B16, V5.B16, V6.B16, V7.B16]
+	VLD1.P 64(R0), [V8.B16, V9.B16, V10.B16, V11.B16]
+	VLD1.P 64(R0), [V12.B16, V13.B16, V14.B16, V15.B16]
+
+	// Round 14
+	VLD1 (R2), [V30.B16]
+	SUB $16, R2
+	AESD V31.B16, V3.B16
+	AESD V31.B16, V7.B16
+	AESD V31.B16, V11.B16
+	AESD V31.B16, V15.B16
+	AESIMC V1.B16, V1.B16
+	AESIMC V5.B16, V5.B16
+	AESIMC V9.B16, V9.B16
+	AESIMC V13.B16, V13.B16
+	AESD V31.B16, V1.B16
+	AESD V31.B16, V5.B16
+	AESD V31.B16, V9.B16
+	AESD V31.B16, V13.B16
+	AESD V30.B16, V1.B16
+	AESD V30.B16, V5.B16
+	AESD V30.B16, V9.B16
+	AESD V30.B16, V13.B16
+	VMOV V3.B16, V16.B16
+	VMOV V7.B16, V18.B16
+	VMOV V11.B16, V20.B16
+	VMOV V15.B16, V22.B16
+	VMOV V1.B16, V17.B16
+	VMOV V5.B16, V19.B16
+	VMOV V9.B16, V21.B16
+	VMOV V13.B16, V23.B16
+	AESE V31.B16, V16.B16
+	AESE V31.B16, V18.B16
+	AESE V31.B16, V20.B16
+	AESE V31.B16, V22.B16
+	AESE V31.B16, V17.B16
+	AESE V31.B16, V19.B16
+	AESE V31.B16, V21.B16
+	AESE V31.B16, V23.B16
+	AESMC V16.B16, V16.B16
+	AESMC V18.B16, V18.B16
+	AESMC V20.B16, V20.B16
+	AESMC V22.B16, V22.B16
+	AESMC V17.B16, V17.B16
+	AESMC V19.B16, V19.B16
+	AESMC V21.B16, V21.B16
+	AESMC V23.B16, V23.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V18.B16, V4.B16, V4.B16
+	VEOR V20.B16, V8.B16, V8.B16
+	VEOR V22.B16, V12.B16, V12.B16
+	VEOR V17.B16, V2.B16, V2.B16
+	VEOR V19.B16, V6.B16, V6.B16
+	VEOR V21.B16, V10.B16, V10.B16
+	VEOR V23.B16, V14.B16, V14.B16
+
+	// Round 13
+	VLD1 (R2), [V30.B16]
+	SUB $16, R2
+	AESD V31.B16, V2.B16
+	AESD V31.B16, V6.B16
+	AESD V31.B16, V10.B16
+	AESD V31.B16, V14.B16
+	AESIMC V0.B16, V0.B16
+	AESIMC V4.B16, V4.B16
+	AESIMC V8.B16, V8.B16
+	AESIMC V12.B16, V12.B16
+	AESD V31.B16, V0.B16
+	AESD V31.B16, V4.B16
+	AESD V31.B16, V8.B16
+	AESD V31.B16, V12.B16
+	AESD V30.B16, V0.B16
+	AESD V30.B16, V4.B16
+	AESD V30.B16, V8.B16
+	AESD V30.B16, V12.B16
+	VMOV V2.B16, V16.B16
+	VMOV V6.B16, V18.B16
+	VMOV V10.B16, V20.B16
+	VMOV V14.B16, V22.B16
+	VMOV V0.B16, V17.B16
+	VMOV V4.B16, V19.B16
+	VMOV V8.B16, V21.B16
+	VMOV V12.B16, V23.B16
+	AESE V31.B16, V16.B16
+	AESE V31.B16, V18.B16
+	AESE V31.B16, V20.B16
+	AESE V31.B16, V22.B16
+	AESE V31.B16, V17.B16
+	AESE V31.B16, V19.B16
+	AESE V31.B16, V21.B16
+	AESE V31.B16, V23.B16
+	AESMC V16.B16, V16.B16
+	AESMC V18.B16, V18.B16
+	AESMC V20.B16, V20.B16
+	AESMC V22.B16, V22.B16
+	AESMC V17.B16, V17.B16
+	AESMC V19.B16, V19.B16
+	AESMC V21.B16, V21.B16
+	AESMC V23.B16, V23.B16
+	VEOR V16.B16, V3.B16, V3.B16
+	VEOR V18.B16, V7.B16, V7.B16
+	VEOR V20.B16, V11.B16, V11.B16
+	VEOR V22.B16, V15.B16, V15.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V19.B16, V5.B16, V5.B16
+	VEOR V21.B16, V9.B16, V9.B16
+	VEOR V23.B16, V13.B16, V13.B16
+
+	// Round 12
+	VLD1 (R2), [V30.B16]
+	SUB $16, R2
+	AESD V31.B16, V1.B16
+	AESD V31.B16, V5.B16
+	AESD V31.B16, V9.B16
+	AESD V31.B16, V13.B16
+	AESIMC V3.B16, V3.B16
+	AESIMC V7.B16, V7.B16
+	AESIMC V11.B16, V11.B16
+	AESIMC V15.B16, V15.B16
+	AESD V31.B16, V3.B16
+	AESD V31.B16, V7.B16
+	AESD V31.B16, V11.B16
+	AESD V31.B16, V15.B16
+	AESD V30.B16, V3.B16
+	AESD V30.B16, V7.B16
+	AESD V30.B16, V11.B16
+	AESD V30.B16, V15.B16
+	VMOV V1.B16, V16.B16
+	VMOV V5.B16, V18.B16
+	VMOV V9.B16, V20.B16
+	VMOV V13.B16, V22.B16
+	VMOV V3.B16, V17.B16
+	VMOV V7.B16, V19.B16
+	VMOV V11.B16, V21.B16
+	VMOV V15.B16, V23.B16
+	AESE V31.B16, V16.B16
+	AESE V31.B16, V18.B16
+	AESE V31.B16, V20.B16
+	AESE V31.B16, V22.B16
+	AESE V31.B16, V17.B16
+	AESE V31.B16, V19.B16
+	AESE V31.B16, V21.B16
+	AESE V31.B16, V23.B16
+	AESMC V16.B16, V16.B16
+	AESMC V18.B16, V18.B16
+	AESMC V20.B16, V20.B16
+	AESMC V22.B16, V22.B16
+	AESMC V17.B16, V17.B16
+	AESMC V19.B16, V19.B16
+	AESMC V21.B16, V21.B16
+	AESMC V23.B16, V23.B16
+	VEOR V16.B16, V2.B16, V2.B16
+	VEOR V18.B16, V6.B16, V6.B16
+	VEOR V20.B16, V10.B16, V10.B16
+	VEOR V22.B16, V14.B16, V14.B16
+	VEOR V17.B16, V0.B16, V0.B16
+	VEOR V19.B16, V4.B16, V4.B16
+	VEOR V21.B16, V8.B16, V8.B16
+	VEOR V23.B16, V12.B16, V12.B16
+
+	MOVD $3, R3
+areion512x4_inv_loop:
+
+	// Round 11-i
+	VLD1 (R2), [V30.B16]
+	SUB $16, R2
+	AESD V31.B16, V0.B16
+	AESD V31.B16, V4.B16
+	AESD V31.B16, V8.B16
+	AESD V31.B16, V12.B16
+	AESIMC V2.B16, V2.B16
+	AESIMC V6.B16, V6.B16
+	AESIMC V10.B16, V10.B16
+	AESIMC V14.B16, V14.B16
+	AESD V31.B16, V2.B16
+	AESD V31.B16, V6.B16
+	AESD V31.B16, V10.B16
+	AESD V31.B16, V14.B16
+	AESD V30.B16, V2.B16
+	AESD V30.B16, V6.B16
+	AESD V30.B16, V10.B16
+	AESD V30.B16, V14.B16
+	VMOV V0.B16, V16.B16
+	VMOV V4.B16, V18.B16
+	VMOV V8.B16, V20.B16
+	VMOV V12.B16, V22.B16
+	VMOV V2.B16, V17.B16
+	VMOV V6.B16, V19.B16
+	VMOV V10.B16, V21.B16
+	VMOV V14.B16, V23.B16
+	AESE V31.B16, V16.B16
+	AESE V31.B16, V18.B16
+	AESE V31.B16, V20.B16
+	AESE V31.B16, V22.B16
+	AESE V31.B16, V17.B16
+	AESE V31.B16, V19.B16
+	AESE V31.B16, V21.B16
+	AESE V31.B16, V23.B16
+	AESMC V16.B16, V16.B16
+	AESMC V18.B16, V18.B16
+	AESMC V20.B16, V20.B16
+	AESMC V22.B16, V22.B16
+	AESMC V17.B16, V17.B16
+	AESMC V19.B16, V19.B16
+	AESMC V21.B16, V21.B16
+	AESMC V23.B16, V23.B16
+	VEOR V16.B16, V1.B16, V1.B16
+	VEOR V18.B16, V5.B16, V5.B16
+	VEOR V20.B16, V9.B16, V9.B16
+	VEOR V22.B16, V13.B16, V13.B16
+	VEOR V17.B16, V3.B16, V3.B16
+	VEOR V19.B16, V7.B16, V7.B16
+	VEOR V21.B16, V11.B16, V11.B16
+	VEOR V23.B16, V15.B16, V15.B16
+
+	// Round 10-i
+	VLD1 (R2), [V30.B16]
+	SUB $16, R2
+	AESD V31.B16, V3.B16
+	AESD V31.B16, V7.B16
+	AESD V31.B16, V11.B16
+	AESD V31.B16, V15.B16
+	AESIMC V1.B16, V1.B16
+	AESIMC V5.B16, V5.B16
+	AESIMC V9.B16, V9.B16
+	AESIMC V13.B16, V13.B16
+	AESD V31.B16, V1.B16
+	AESD V31.B16, V5.B16
+	AESD V31.B16, V9.B16
+	AESD V31.B16, V13.B16
+	AESD V30.B16, V1.B16
+	AESD V30.B16, V5.B16
+	AESD V30.B16, V9.B16
+	AESD V30.B16, V13.B16
+	VMOV V3.B16, V16.B16
+	VMOV V7.B16, V18.B16
+	VMOV V11.B16, V20.B16
+	VMOV V15.B16, V22.B16
+	VMOV V1.B16, V17.B16
+	VMOV V5.B16, V19.B16
+	VMOV V9.B16, V21.B16
+	VMOV V13.B16, V23.B16
+	AESE V31.B16, V16.B16
+	AESE V31.B16, V18.B16
+	AESE V31.B16, V20.B16
+	AESE V31.B16, V22.B16
+	AESE V31.B16, V17.B16
+	AESE V31.B16, V19.B16
+	AESE V31.B16, V21.B16
+	AESE V31.B16, V23.B16
+	AESMC V16.B16, V16.B16
+	AESMC V18.B16, V18.B16
+	AESMC V20.B16, V20.B16
+	AESMC V22.B16, V22.B16
+	AESMC V17.B16, V17.B16
+	AESMC V19.B16, V19.B16
+	AESMC V21.B16, V21.B16
+	AESMC V23.B16, V23.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V18.B16, V4.B16, V4.B16
+	VEOR V20.B16, V8.B16, V8.B16
+	VEOR V22.B16, V12.B16, V12.B16
+	VEOR V17.B16, V2.B16, V2.B16
+	VEOR V19.B16, V6.B16, V6.B16
+	VEOR V21.B16, V10.B16, V10.B16
+	VEOR V23.B16, V14.B16, V14.B16
+
+	// Round 9-i
+	VLD1 (R2), [V30.B16]
+	SUB $16, R2
+	AESD V31.B16, V2.B16
+	AESD V31.B16, V6.B16
+	AESD V31.B16, V10.B16
+	AESD V31.B16, V14.B16
+	AESIMC V0.B16, V0.B16
+	AESIMC V4.B16, V4.B16
+	AESIMC V8.B16, V8.B16
+	AESIMC V12.B16, V12.B16
+	AESD V31.B16, V0.B16
+	AESD V31.B16, V4.B16
+	AESD V31.B16, V8.B16
+	AESD V31.B16, V12.B16
+	AESD V30.B16, V0.B16
+	AESD V30.B16, V4.B16
+	AESD V30.B16, V8.B16
+	AESD V30.B16, V12.B16
+	VMOV V2.B16, V16.B16
+	VMOV V6.B16, V18.B16
+	VMOV V10.B16, V20.B16
+	VMOV V14.B16, V22.B16
+	VMOV V0.B16, V17.B16
+	VMOV V4.B16, V19.B16
+	VMOV V8.B16, V21.B16
+	VMOV V12.B16, V23.B16
+	AESE V31.B16, V16.B16
+	AESE V31.B16, V18.B16
+	AESE V31.B16, V20.B16
+	AESE V31.B16, V22.B16
+	AESE V31.B16, V17.B16
+	AESE V31.B16, V19.B16
+	AESE V31.B16, V21.B16
+	AESE V31.B16, V23.B16
+	AESMC V16.B16, V16.B16
+	AESMC V18.B16, V18.B16
+	AESMC V20.B16, V20.B16
+	AESMC V22.B16, V22.B16
+	AESMC V17.B16, V17.B16
+	AESMC V19.B16, V19.B16
+	AESMC V21.B16, V21.B16
+	AESMC V23.B16, V23.B16
+	VEOR V16.B16, V3.B16, V3.B16
+	VEOR V18.B16, V7.B16, V7.B16
+	VEOR V20.B16, V11.B16, V11.B16
+	VEOR V22.B16, V15.B16, V15.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V19.B16, V5.B16, V5.B16
+	VEOR V21.B16, V9.B16, V9.B16
+	VEOR V23.B16, V13.B16, V13.B16
+
+	// Round 8-i
+	VLD1 (R2), [V30.B16]
+	SUB $16, R2
+	AESD V31.B16, V1.B16
+	AESD V31.B16, V5.B16
+	AESD V31.B16, V9.B16
+	AESD V31.B16, V13.B16
+	AESIMC V3.B16, V3.B16
+	AESIMC V7.B16, V7.B16
+	AESIMC V11.B16, V11.B16
+	AESIMC V15.B16, V15.B16
+	AESD V31.B16, V3.B16
+	AESD V31.B16, V7.B16
+	AESD V31.B16, V11.B16
+	AESD V31.B16, V15.B16
+	AESD V30.B16, V3.B16
+	AESD V30.B16, V7.B16
+	AESD V30.B16, V11.B16
+	AESD V30.B16, V15.B16
+	VMOV V1.B16, V16.B16
+	VMOV V5.B16, V18.B16
+	VMOV V9.B16, V20.B16
+	VMOV V13.B16, V22.B16
+	VMOV V3.B16, V17.B16
+	VMOV V7.B16, V19.B16
+	VMOV V11.B16, V21.B16
+	VMOV V15.B16, V23.B16
+	AESE V31.B16, V16.B16
+	AESE V31.B16, V18.B16
+	AESE V31.B16, V20.B16
+	AESE V31.B16, V22.B16
+	AESE V31.B16, V17.B16
+	AESE V31.B16, V19.B16
+	AESE V31.B16, V21.B16
+	AESE V31.B16, V23.B16
+	AESMC V16.B16, V16.B16
+	AESMC V18.B16, V18.B16
+	AESMC V20.B16, V20.B16
+	AESMC V22.B16, V22.B16
+	AESMC V17.B16, V17.B16
+	AESMC V19.B16, V19.B16
+	AESMC V21.B16, V21.B16
+	AESMC V23.B16, V23.B16
+	VEOR V16.B16, V2.B16, V2.B16
+	VEOR V18.B16, V6.B16, V6.B16
+	VEOR V20.B16, V10.B16, V10.B16
+	VEOR V22.B16, V14.B16, V14.B16
+	VEOR V17.B16, V0.B16, V0.B16
+	VEOR V19.B16, V4.B16, V4.B16
+	VEOR V21.B16, V8.B16, V8.B16
+	VEOR V23.B16, V12.B16, V12.B16
+
+	SUB $1, R3, R3
+	CBNZ R3, areion512x4_inv_loop
+
+	VST1.P [V1.B16], 16(R1)
+	VST1.P [V2.B16], 16(R1)
+	VST1.P [V3.B16], 16(R1)
+	VST1.P [V0.B16], 16(R1)
+	VST1.P [V5.B16], 16(R1)
+	VST1.P [V6.B16], 16(R1)
+	VST1.P [V7.B16], 16(R1)
+	VST1.P [V4.B16], 16(R1)
+	VST1.P [V9.B16], 16(R1)
+	VST1.P [V10.B16], 16(R1)
+	VST1.P [V11.B16], 16(R1)
+	VST1.P [V8.B16], 16(R1)
+	VST1.P [V13.B16], 16(R1)
+	VST1.P [V14.B16], 16(R1)
+	VST1.P [V15.B16], 16(R1)
+	VST1.P [V12.B16], 16(R1)
+	RET
@@ -0,0 +1,160 @@
+package aes
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"hash"
+)
+
+// Areion-based hash functions for short inputs.
+//
+// Areion-256-DM hashes exactly 32 bytes with a Davies-Meyer feed-forward of
+// the Areion256 permutation:
+//
+//	H(x) = Areion256(x) ⊕ x
+//
+// Areion-512-MD hashes arbitrary inputs with the Merkle-Damgård construction.
+// The compression function applies Areion512 in Davies-Meyer mode to the
+// 32-byte chaining value followed by a 32-byte message block, and truncates
+// the 64-byte result to 32 bytes, keeping the high halves of the first two
+// 128-bit words and the low halves of the last two (as in Haraka-512):
+//
+//	f(h, m) = trunc(Areion512(h || m) ⊕ (h || m))
+//
+// The chaining value starts at the SHA-256 IV (big-endian words), and the
+// message is padded as in SHA-256: a 0x80 byte, zeros, then the 64-bit
+// big-endian bit length, to a multiple of 32 bytes.
+
+const (
+	// Areion512MDSize is the Areion-512-MD digest size in bytes.
+	Areion512MDSize = 32
+
+	// Areion512MDBlockSize is the Areion-512-MD message block size in bytes.
+	Areion512MDBlockSize = 32
+)
+
+var areion512MDIV = [8]uint32{
+	0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a,
+	0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+}
+
+// AreionHash256DM returns Areion256(in) ⊕ in.
+func AreionHash256DM(in *[32]byte) [32]byte {
+	state := Areion256(*in)
+	areion256Permute(&state)
+	var out [32]byte
+	for i := range out {
+		out[i] = state[i] ^ in[i]
+	}
+	return out
+}
+
+// AreionHash256DMx4 computes AreionHash256DM of four independent inputs with
+// the multi-state Areion256 permutation.
+func AreionHash256DMx4(out *[4][32]byte, in *[4][32]byte) {
+	var states Areion256x4
+	for i := range states {
+		states[i] = Areion256(in[i])
+	}
+	states.Permute()
+	for i := range states {
+		subtle.XORBytes(out[i][:], states[i][:], in[i][:])
+	}
+}
+
+// areion512MDCompress updates the chaining value h with the 32-byte block m.
+func areion512MDCompress(h *[32]byte, m []byte) {
+	var state, in Areion512
+	copy(in[:32], h[:])
+	copy(in[32:], m[:Areion512MDBlockSize])
+	state = in
+	areion512Permute(&state)
+	for i := range state {
+		state[i] ^= in[i]
+	}
+	copy(h[0:8], state[8:16])
+	copy(h[8:16], state[24:32])
+	copy(h[16:24], state[32:40])
+	copy(h[24:32], state[48:56])
+}
+
+// Areion512MD computes the Areion-512-MD hash and implements hash.Hash.
+// The zero value is not ready for use; create instances with NewAreion512MD.
+type Areion512MD struct {
+	h   [32]byte
+	buf [Areion512MDBlockSize]byte
+	n   int
+	len uint64
+}
+
+var _ hash.Hash = (*Areion512MD)(nil)
+
+// NewAreion512MD returns a new Areion-512-MD hash.
+func NewAreion512MD() *Areion512MD {
+	d := &Areion512MD{}
+	d.Reset()
+	return d
+}
+
+// Reset resets the hash to its initial state.
+func (d *Areion512MD) Reset() {
+	for i, w := range areion512MDIV {
+		binary.BigEndian.PutUint32(d.h[4*i:], w)
+	}
+	d.n = 0
+	d.len = 0
+}
+
+// Size returns the digest size in bytes.
+func (d *Areion512MD) Size() int { return Areion512MDSize }
+
+// BlockSize returns the message block size in bytes.
+func (d *Areion512MD) BlockSize() int { return Areion512MDBlockSize }
+
+// Write absorbs p. It never returns an error.
+func (d *Areion512MD) Write(p []byte) (int, error) {
+	nn := len(p)
+	d.len += uint64(nn)
+	if d.n > 0 {
+		k := copy(d.buf[d.n:], p)
+		d.n += k
+		p = p[k:]
+		if d.n < Areion512MDBlockSize {
+			return nn, nil
+		}
+		areion512MDCompress(&d.h, d.buf[:])
+		d.n = 0
+	}
+	for len(p) >= Areion512MDBlockSize {
+		areion512MDCompress(&d.h, p)
+		p = p[Areion512MDBlockSize:]
+	}
+	d.n = copy(d.buf[:], p)
+	return nn, nil
+}
+
+// Sum appends the digest of the data written so far to b. It does not change
+// the underlying hash state.
+func (d *Areion512MD) Sum(b []byte) []byte {
+	c := *d
+	bitLen := c.len << 3
+
+	var pad [2 * Areion512MDBlockSize]byte
+	pad[0] = 0x80
+	k := Areion512MDBlockSize - c.n
+	if k < 9 {
+		k += Areion512MDBlockSize
+	}
+	binary.BigEndian.PutUint64(pad[k-8:k], bitLen)
+	c.Write(pad[:k])
+	return append(b, c.h[:]...)
+}
+
+// Areion512MDSum returns the Areion-512-MD digest of data.
+func Areion512MDSum(data []byte) [Areion512MDSize]byte {
+	d := NewAreion512MD()
+	d.Write(data)
+	var out [Areion512MDSize]byte
+	d.Sum(out[:0])
+	return out
+}
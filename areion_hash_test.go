@@ -0,0 +1,181 @@
+package aes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+)
+
+func TestAreionHash256DM(t *testing.T) {
+	var in [32]byte
+	for i := range in {
+		in[i] = byte(i)
+	}
+	state := Areion256(in)
+	areion256PermuteSoftware(&state)
+	var want [32]byte
+	for i := range want {
+		want[i] = state[i] ^ in[i]
+	}
+	if got := AreionHash256DM(&in); got != want {
+		t.Fatalf("got %x, want %x", got, want)
+	}
+}
+
+// On the all-zero input the feed-forward is the identity, so the digest is the
+// Areion256 permutation test vector itself.
+func TestAreionHash256DMZero(t *testing.T) {
+	var in [32]byte
+	got := AreionHash256DM(&in)
+	if want := "2812a72465b26e9fca7583f6e4123aa1490e35e7d5203e4ba2e927b0482f4db8"; hex.EncodeToString(got[:]) != want {
+		t.Fatalf("got %x, want %s", got, want)
+	}
+}
+
+// areion512MDReference pads the whole message up front and compresses it with
+// the software permutation.
+func areion512MDReference(msg []byte) []byte {
+	padded := append(bytes.Clone(msg), 0x80)
+	for len(padded)%32 != 24 {
+		padded = append(padded, 0)
+	}
+	padded = binary.BigEndian.AppendUint64(padded, uint64(len(msg))*8)
+
+	var h [32]byte
+	for i, w := range areion512MDIV {
+		binary.BigEndian.PutUint32(h[4*i:], w)
+	}
+	for ; len(padded) > 0; padded = padded[32:] {
+		var x Areion512
+		copy(x[:32], h[:])
+		copy(x[32:], padded[:32])
+		y := x
+		areion512PermuteSoftware(&y)
+		for i := range y {
+			y[i] ^= x[i]
+		}
+		h = [32]byte(append(append(append(append([]byte{}, y[8:16]...), y[24:32]...), y[32:40]...), y[48:56]...))
+	}
+	return h[:]
+}
+
+func TestAreion512MDMatchesReference(t *testing.T) {
+	for n := 0; n <= 200; n++ {
+		msg := make([]byte, n)
+		for i := range msg {
+			msg[i] = byte(i * 13)
+		}
+		want := areion512MDReference(msg)
+		got := Areion512MDSum(msg)
+		if !bytes.Equal(got[:], want) {
+			t.Fatalf("len=%d:\ngot:  %x\nwant: %x", n, got, want)
+		}
+	}
+}
+
+func TestAreion512MDStreaming(t *testing.T) {
+	msg := make([]byte, 1000)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+	want := Areion512MDSum(msg)
+
+	for _, chunk := range []int{1, 3, 31, 32, 33, 100} {
+		d := NewAreion512MD()
+		for i := 0; i < len(msg); i += chunk {
+			d.Write(msg[i:min(i+chunk, len(msg))])
+		}
+		if got := d.Sum(nil); !bytes.Equal(got, want[:]) {
+			t.Fatalf("chunk=%d: got %x, want %x", chunk, got, want)
+		}
+		// Sum must not change the state
+		if got := d.Sum(nil); !bytes.Equal(got, want[:]) {
+			t.Fatalf("chunk=%d: second Sum differs", chunk)
+		}
+	}
+
+	d := NewAreion512MD()
+	d.Write([]byte("garbage"))
+	d.Reset()
+	d.Write(msg)
+	if got := d.Sum([]byte{0xaa}); got[0] != 0xaa || !bytes.Equal(got[1:], want[:]) {
+		t.Fatal("Reset or Sum append mismatch")
+	}
+	if d.Size() != 32 || d.BlockSize() != 32 {
+		t.Fatal("unexpected sizes")
+	}
+}
+
+// The Areion-256-DM values are the Areion256 vectors of the reference Zig
+// implementation (see areion_test.go) XORed with their inputs. The
+// Areion-512-MD digests are regression values.
+func TestAreionHashKnownAnswers(t *testing.T) {
+	var zero, seq [32]byte
+	for i := range seq {
+		seq[i] = byte(i)
+	}
+	for _, tt := range []struct {
+		in   *[32]byte
+		want string
+	}{
+		{&zero, "2812a72465b26e9fca7583f6e4123aa1490e35e7d5203e4ba2e927b0482f4db8"},
+		{&seq, "68855d102ae167676ece08d24eaebcccb366e44807ae13d0d506a88795b2bf9a"},
+	} {
+		dm := AreionHash256DM(tt.in)
+		if got := hex.EncodeToString(dm[:]); got != tt.want {
+			t.Errorf("Areion-256-DM(%x): got %s, want %s", tt.in[:], got, tt.want)
+		}
+	}
+
+	tests := []struct {
+		msg, want string
+	}{
+		{"", "47d75f622aaac09d2fa8700ba5a78dc3f4f58abf61d2d0a4579b55e44b648f7e"},
+		{"abc", "b04c3bd558d7f69fae1c3366a4d35ba16f9ba7415b872c63732cea967af3d245"},
+		{"The quick brown fox jumps over the lazy dog", "725c806d2f715ede4759d28062798f55d5445faf1fbc78d9744478444aa1e319"},
+	}
+	for _, tt := range tests {
+		sum := Areion512MDSum([]byte(tt.msg))
+		if got := hex.EncodeToString(sum[:]); got != tt.want {
+			t.Errorf("Areion-512-MD(%q): got %s, want %s", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func BenchmarkAreionHash256DM(b *testing.B) {
+	var in [32]byte
+	b.SetBytes(32)
+	for i := 0; i < b.N; i++ {
+		in = AreionHash256DM(&in)
+	}
+}
+
+func BenchmarkAreion512MD(b *testing.B) {
+	msg := make([]byte, 1024)
+	d := NewAreion512MD()
+	out := make([]byte, 0, 32)
+	b.SetBytes(int64(len(msg)))
+	for i := 0; i < b.N; i++ {
+		d.Reset()
+		d.Write(msg)
+		d.Sum(out)
+	}
+}
+
+func TestAreionHash256DMx4(t *testing.T) {
+	forEachCPUConfig(t, func(t *testing.T) {
+		var in, out [4][32]byte
+		for i := range in {
+			for j := range in[i] {
+				in[i][j] = byte(i*41 + j*3)
+			}
+		}
+		AreionHash256DMx4(&out, &in)
+		for i := range in {
+			if want := AreionHash256DM(&in[i]); out[i] != want {
+				t.Fatalf("%+v: input %d mismatch\nGot:      %x\nExpected: %x", CPU, i, out[i], want)
+			}
+		}
+	})
+}
@@ -0,0 +1,327 @@
+package aes
+
+import (
+	"hash"
+	"io"
+)
+
+// AreionK12 is a tree hash in the style of KangarooTwelve, with Areion512 in
+// place of Keccak-p[1600, 12].
+//
+// Every node is hashed with a sponge over Areion512 with a 32-byte rate and
+// a 32-byte capacity. The input is S = M || C || length_encode(|C|), where C
+// is the customization string, cut into 8 KiB chunks S0, S1, ..., Sn-1. The
+// nodes follow the Sakura encoding of KangarooTwelve:
+//
+//	n = 1:  F(S0, 0x07)
+//	n > 1:  F(S0 || 0x03 || 0^7 || CV1 || ... || CVn-1 || length_encode(n-1) || 0xFF 0xFF, 0x06)
+//	        CVi = F(Si, 0x0B), 32 bytes
+//
+// where F(X, d) absorbs X, adds the domain byte d after it and a final 0x80
+// bit, and squeezes. length_encode(x) is the big-endian encoding of x without
+// leading zeros, followed by its length in bytes.
+//
+// Chunks are hashed four at a time with Areion512x4, and large inputs are
+// split across goroutines.
+
+const (
+	// AreionK12Size is the digest size in bytes returned by Sum.
+	AreionK12Size = 32
+
+	// AreionK12ChunkSize is the size of the chunks hashed in parallel.
+	AreionK12ChunkSize = 8192
+
+	areionK12Rate   = 32
+	areionK12CVSize = 32
+)
+
+// Domain bytes of the nodes.
+const (
+	areionK12DomainSingle = 0x07
+	areionK12DomainLeaf   = 0x0b
+	areionK12DomainFinal  = 0x06
+)
+
+// areionK12BufferChunks is the number of chunks buffered by Write before
+// hashing them together, and areionK12MaxChunks the number of chunks hashed
+// at a time from a large Write.
+const (
+	areionK12BufferChunks = 4
+	areionK12MaxChunks    = 1024
+)
+
+// areionK12ParallelThreshold is the number of chunks from which hashing is
+// split across goroutines.
+var areionK12ParallelThreshold = 64
+
+// areionK12Sponge is the sponge of a single node.
+type areionK12Sponge struct {
+	s Areion512
+	n int // position in the rate, for absorbing or squeezing
+}
+
+func (h *areionK12Sponge) absorb(p []byte) {
+	for len(p) > 0 {
+		if h.n == 0 && len(p) >= areionK12Rate {
+			XorBlock2((*Block2)(h.s[:]), (*Block2)(h.s[:]), (*Block2)(p))
+			h.s.Permute()
+			p = p[areionK12Rate:]
+			continue
+		}
+		k := min(areionK12Rate-h.n, len(p))
+		for i := range k {
+			h.s[h.n+i] ^= p[i]
+		}
+		h.n += k
+		p = p[k:]
+		if h.n == areionK12Rate {
+			h.s.Permute()
+			h.n = 0
+		}
+	}
+}
+
+// pad adds the domain byte and the final bit, and switches to squeezing.
+func (h *areionK12Sponge) pad(domain byte) {
+	h.s[h.n] ^= domain
+	h.s[areionK12Rate-1] ^= 0x80
+	h.n = areionK12Rate
+}
+
+func (h *areionK12Sponge) squeeze(p []byte) {
+	for len(p) > 0 {
+		if h.n == areionK12Rate {
+			h.s.Permute()
+			h.n = 0
+		}
+		k := copy(p, h.s[h.n:areionK12Rate])
+		h.n += k
+		p = p[k:]
+	}
+}
+
+// areionK12Leaf4 computes the chaining values of four consecutive full
+// chunks.
+func areionK12Leaf4(cvs, chunks []byte) {
+	var st Areion512x4
+	for off := 0; off < AreionK12ChunkSize; off += areionK12Rate {
+		for i := range st {
+			x := (*Block2)(st[i][:])
+			XorBlock2(x, x, (*Block2)(chunks[i*AreionK12ChunkSize+off:]))
+		}
+		st.Permute()
+	}
+	for i := range st {
+		st[i][0] ^= areionK12DomainLeaf
+		st[i][areionK12Rate-1] ^= 0x80
+	}
+	st.Permute()
+	for i := range st {
+		copy(cvs[i*areionK12CVSize:], st[i][:areionK12CVSize])
+	}
+}
+
+// areionK12Leaf computes the chaining value of a chunk of any length.
+func areionK12Leaf(cv, chunk []byte) {
+	var h areionK12Sponge
+	h.absorb(chunk)
+	h.pad(areionK12DomainLeaf)
+	h.squeeze(cv[:areionK12CVSize])
+}
+
+// areionK12Leaves fills cvs with the chaining values of the chunks of data.
+// The last chunk may be partial.
+func areionK12Leaves(cvs, data []byte) {
+	n := (len(data) + AreionK12ChunkSize - 1) / AreionK12ChunkSize
+	full := len(data) / AreionK12ChunkSize
+	parallelRanges(full, areionK12ParallelThreshold, func(lo, hi int) {
+		i := lo
+		for ; i+4 <= hi; i += 4 {
+			areionK12Leaf4(cvs[i*areionK12CVSize:], data[i*AreionK12ChunkSize:])
+		}
+		for ; i < hi; i++ {
+			areionK12Leaf(cvs[i*areionK12CVSize:], data[i*AreionK12ChunkSize:(i+1)*AreionK12ChunkSize])
+		}
+	})
+	if full < n {
+		areionK12Leaf(cvs[full*areionK12CVSize:], data[full*AreionK12ChunkSize:])
+	}
+}
+
+// areionK12LengthEncode appends length_encode(x) to b.
+func areionK12LengthEncode(b []byte, x uint64) []byte {
+	var buf [9]byte
+	n := 0
+	for v := x; v > 0; v >>= 8 {
+		n++
+	}
+	for i := range n {
+		buf[i] = byte(x >> (8 * (n - 1 - i)))
+	}
+	buf[n] = byte(n)
+	return append(b, buf[:n+1]...)
+}
+
+// areionK12FirstChunkSuffix follows S0 in the final node of a tree.
+var areionK12FirstChunkSuffix = [8]byte{0x03}
+
+// AreionK12 computes the tree hash. It implements hash.Hash, with Sum
+// returning the first 32 output bytes, and io.Reader to produce output of any
+// length. Writing after the first Read panics.
+type AreionK12 struct {
+	suffix    []byte          // C || length_encode(|C|)
+	final     areionK12Sponge // final node, once S0 is known not to be the only chunk
+	tree      bool
+	leaves    uint64 // chaining values absorbed into the final node
+	buf       []byte // S0, or the chunks not hashed yet
+	cvs       []byte
+	out       areionK12Sponge
+	squeezing bool
+}
+
+var (
+	_ hash.Hash = (*AreionK12)(nil)
+	_ io.Reader = (*AreionK12)(nil)
+)
+
+// NewAreionK12 returns a tree hash with the customization string custom,
+// which may be empty.
+func NewAreionK12(custom []byte) *AreionK12 {
+	suffix := append([]byte(nil), custom...)
+	suffix = areionK12LengthEncode(suffix, uint64(len(custom)))
+	return &AreionK12{
+		suffix: suffix,
+		buf:    make([]byte, 0, areionK12BufferChunks*AreionK12ChunkSize),
+	}
+}
+
+// Reset resets the hash to its initial state, keeping the customization
+// string.
+func (h *AreionK12) Reset() {
+	h.final = areionK12Sponge{}
+	h.tree = false
+	h.leaves = 0
+	h.buf = h.buf[:0]
+	h.squeezing = false
+}
+
+// Size returns the digest size in bytes.
+func (h *AreionK12) Size() int { return AreionK12Size }
+
+// BlockSize returns the chunk size in bytes.
+func (h *AreionK12) BlockSize() int { return AreionK12ChunkSize }
+
+// hashChunks absorbs the chaining values of the full chunks of data into the
+// final node.
+func (h *AreionK12) hashChunks(data []byte) {
+	for len(data) > 0 {
+		n := min(len(data)/AreionK12ChunkSize, areionK12MaxChunks)
+		if cap(h.cvs) < n*areionK12CVSize {
+			h.cvs = make([]byte, n*areionK12CVSize)
+		}
+		cvs := h.cvs[:n*areionK12CVSize]
+		areionK12Leaves(cvs, data[:n*AreionK12ChunkSize])
+		h.final.absorb(cvs)
+		h.leaves += uint64(n)
+		data = data[n*AreionK12ChunkSize:]
+	}
+}
+
+// Write absorbs p. It never returns an error.
+func (h *AreionK12) Write(p []byte) (int, error) {
+	if h.squeezing {
+		panic("aes: write to AreionK12 after read")
+	}
+	nn := len(p)
+	if !h.tree {
+		// S0 goes to the final node, once more input shows there is a tree
+		k := min(AreionK12ChunkSize-len(h.buf), len(p))
+		h.buf = append(h.buf, p[:k]...)
+		p = p[k:]
+		if len(p) == 0 {
+			return nn, nil
+		}
+		h.final.absorb(h.buf)
+		h.final.absorb(areionK12FirstChunkSuffix[:])
+		h.buf = h.buf[:0]
+		h.tree = true
+	}
+
+	bufSize := cap(h.buf)
+	for len(p) > 0 {
+		if len(h.buf) == 0 && len(p) >= bufSize {
+			n := len(p) / AreionK12ChunkSize * AreionK12ChunkSize
+			h.hashChunks(p[:n])
+			p = p[n:]
+			continue
+		}
+		k := min(bufSize-len(h.buf), len(p))
+		h.buf = append(h.buf, p[:k]...)
+		p = p[k:]
+		if len(h.buf) == bufSize {
+			h.hashChunks(h.buf)
+			h.buf = h.buf[:0]
+		}
+	}
+	return nn, nil
+}
+
+// finish returns the final node after absorbing the rest of S, without
+// changing h.
+func (h *AreionK12) finish() areionK12Sponge {
+	tail := append(append([]byte(nil), h.buf...), h.suffix...)
+	final := h.final
+	leaves := h.leaves
+	if !h.tree {
+		if len(tail) <= AreionK12ChunkSize {
+			var single areionK12Sponge
+			single.absorb(tail)
+			single.pad(areionK12DomainSingle)
+			return single
+		}
+		final.absorb(tail[:AreionK12ChunkSize])
+		final.absorb(areionK12FirstChunkSuffix[:])
+		tail = tail[AreionK12ChunkSize:]
+	}
+
+	n := (len(tail) + AreionK12ChunkSize - 1) / AreionK12ChunkSize
+	cvs := make([]byte, n*areionK12CVSize)
+	areionK12Leaves(cvs, tail)
+	final.absorb(cvs)
+	leaves += uint64(n)
+
+	end := areionK12LengthEncode(nil, leaves)
+	final.absorb(append(end, 0xff, 0xff))
+	final.pad(areionK12DomainFinal)
+	return final
+}
+
+// Read squeezes len(p) bytes of output. It never returns an error.
+func (h *AreionK12) Read(p []byte) (int, error) {
+	if !h.squeezing {
+		h.out = h.finish()
+		h.squeezing = true
+	}
+	h.out.squeeze(p)
+	return len(p), nil
+}
+
+// Sum appends the first 32 output bytes to b. It does not change the
+// underlying hash state and can only be called before Read.
+func (h *AreionK12) Sum(b []byte) []byte {
+	if h.squeezing {
+		panic("aes: AreionK12 Sum after read")
+	}
+	out := h.finish()
+	ret, digest := sliceForAppend(b, AreionK12Size)
+	out.squeeze(digest)
+	return ret
+}
+
+// AreionK12Sum fills out with the tree hash of in with the customization
+// string custom.
+func AreionK12Sum(out, in, custom []byte) {
+	h := NewAreionK12(custom)
+	h.Write(in)
+	h.Read(out)
+}
@@ -0,0 +1,162 @@
+package aes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// areionK12F is the node function of the reference: a sponge over Areion512
+// with a 32-byte rate.
+func areionK12F(x []byte, domain byte, outLen int) []byte {
+	var s Areion512
+	for len(x) >= 32 {
+		for i := range 32 {
+			s[i] ^= x[i]
+		}
+		s.Permute()
+		x = x[32:]
+	}
+	for i := range x {
+		s[i] ^= x[i]
+	}
+	s[len(x)] ^= domain
+	s[31] ^= 0x80
+	var out []byte
+	for len(out) < outLen {
+		s.Permute()
+		out = append(out, s[:32]...)
+	}
+	return out[:outLen]
+}
+
+func areionK12LengthEncodeReference(x uint64) []byte {
+	var b []byte
+	for ; x > 0; x >>= 8 {
+		b = append([]byte{byte(x)}, b...)
+	}
+	return append(b, byte(len(b)))
+}
+
+// areionK12Reference computes the tree hash from its definition.
+func areionK12Reference(m, custom []byte, outLen int) []byte {
+	s := append(bytes.Clone(m), custom...)
+	s = append(s, areionK12LengthEncodeReference(uint64(len(custom)))...)
+	if len(s) <= 8192 {
+		return areionK12F(s, 0x07, outLen)
+	}
+	node := append(bytes.Clone(s[:8192]), 0x03, 0, 0, 0, 0, 0, 0, 0)
+	n := uint64(0)
+	for rest := s[8192:]; len(rest) > 0; n++ {
+		k := min(len(rest), 8192)
+		node = append(node, areionK12F(rest[:k], 0x0b, 32)...)
+		rest = rest[k:]
+	}
+	node = append(node, areionK12LengthEncodeReference(n)...)
+	node = append(node, 0xff, 0xff)
+	return areionK12F(node, 0x06, outLen)
+}
+
+func areionK12TestInput(n int) []byte {
+	m := make([]byte, n)
+	for i := range m {
+		m[i] = byte(i % 251)
+	}
+	return m
+}
+
+func TestAreionK12LengthEncode(t *testing.T) {
+	tests := map[uint64]string{0: "00", 1: "0101", 255: "ff01", 256: "010002", 0x123456: "12345603"}
+	for x, want := range tests {
+		if got := hex.EncodeToString(areionK12LengthEncode(nil, x)); got != want {
+			t.Errorf("length_encode(%d) = %s, expected %s", x, got, want)
+		}
+	}
+}
+
+func TestAreionK12MatchesReference(t *testing.T) {
+	defer func(v int) { areionK12ParallelThreshold = v }(areionK12ParallelThreshold)
+	areionK12ParallelThreshold = 4
+
+	sizes := []int{0, 1, 31, 32, 8190, 8191, 8192, 8193, 2 * 8192, 3*8192 + 5, 5 * 8192, 13*8192 + 100}
+	for _, custom := range [][]byte{nil, []byte("custom"), areionK12TestInput(300)} {
+		for _, n := range sizes {
+			m := areionK12TestInput(n)
+			want := areionK12Reference(m, custom, 100)
+			got := make([]byte, 100)
+			AreionK12Sum(got, m, custom)
+			if !bytes.Equal(got, want) {
+				t.Errorf("%d bytes, %d-byte customization: got %x, expected %x", n, len(custom), got, want)
+			}
+		}
+	}
+}
+
+func TestAreionK12Streaming(t *testing.T) {
+	m := areionK12TestInput(11*8192 + 77)
+	want := areionK12Reference(m, []byte("c"), 32)
+	h := NewAreionK12([]byte("c"))
+	for _, step := range []int{1000, 8192, 8193, 40000, len(m)} {
+		h.Reset()
+		for i := 0; i < len(m); i += step {
+			h.Write(m[i:min(i+step, len(m))])
+			if i == 0 {
+				// Sum must not disturb the state
+				h.Sum(nil)
+			}
+		}
+		if got := h.Sum(nil); !bytes.Equal(got, want) {
+			t.Errorf("step %d: got %x, expected %x", step, got, want)
+		}
+	}
+
+	h.Reset()
+	h.Write(m)
+	out := make([]byte, 64)
+	h.Read(out[:10])
+	h.Read(out[10:])
+	if !bytes.Equal(out[:32], want) {
+		t.Error("XOF output does not extend the digest")
+	}
+}
+
+func TestAreionK12CustomizationMatters(t *testing.T) {
+	a := make([]byte, 32)
+	b := make([]byte, 32)
+	AreionK12Sum(a, []byte("message"), nil)
+	AreionK12Sum(b, []byte("message"), []byte("x"))
+	if bytes.Equal(a, b) {
+		t.Error("customization string is ignored")
+	}
+}
+
+func TestAreionK12KnownAnswers(t *testing.T) {
+	tests := []struct {
+		n        int
+		custom   string
+		expected string
+	}{
+		{0, "", "1a2cc6a850f4c0723c1a824e46030436035aa7166019d5c9f837e1ca6faf3ec8"},
+		{17, "", "2515234deb27e3b25eebbea4a28620d3dc5d2d89d014b4bf6f4bf8baf92158a9"},
+		{8192, "", "454d433e132122385a86d98c7eb0eff25b67f3f9f90be47e0fe5a20226a494e9"},
+		{100000, "custom", "32b579ad13a000e19f5474e512b4bce2c860aa236db3cec9245ec405fd24fb0a"},
+	}
+	for _, tc := range tests {
+		out := make([]byte, 32)
+		AreionK12Sum(out, areionK12TestInput(tc.n), []byte(tc.custom))
+		if got := hex.EncodeToString(out); got != tc.expected {
+			t.Errorf("%d bytes, customization %q: got %s, expected %s", tc.n, tc.custom, got, tc.expected)
+		}
+	}
+}
+
+func BenchmarkAreionK12(b *testing.B) {
+	buf := make([]byte, 1<<20)
+	h := NewAreionK12(nil)
+	b.SetBytes(int64(len(buf)))
+	for i := 0; i < b.N; i++ {
+		h.Reset()
+		h.Write(buf)
+		h.Sum(nil)
+	}
+}
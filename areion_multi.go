@@ -0,0 +1,55 @@
+package aes
+
+// Areion256x2 holds two independent Areion256 states. Permute processes both
+// in one pass, with the states interleaved across vector lanes when VAES is
+// available.
+type Areion256x2 [2]Areion256
+
+// Areion256x4 holds four independent Areion256 states.
+type Areion256x4 [4]Areion256
+
+// Areion512x2 holds two independent Areion512 states.
+type Areion512x2 [2]Areion512
+
+// Areion512x4 holds four independent Areion512 states.
+type Areion512x4 [4]Areion512
+
+// Permute applies the Areion256 permutation to both states in-place.
+func (s *Areion256x2) Permute() {
+	areion256x2Permute(s)
+}
+
+// InversePermute applies the inverse Areion256 permutation to both states in-place.
+func (s *Areion256x2) InversePermute() {
+	areion256x2InversePermute(s)
+}
+
+// Permute applies the Areion256 permutation to all four states in-place.
+func (s *Areion256x4) Permute() {
+	areion256x4Permute(s)
+}
+
+// InversePermute applies the inverse Areion256 permutation to all four states in-place.
+func (s *Areion256x4) InversePermute() {
+	areion256x4InversePermute(s)
+}
+
+// Permute applies the Areion512 permutation to both states in-place.
+func (s *Areion512x2) Permute() {
+	areion512x2Permute(s)
+}
+
+// InversePermute applies the inverse Areion512 permutation to both states in-place.
+func (s *Areion512x2) InversePermute() {
+	areion512x2InversePermute(s)
+}
+
+// Permute applies the Areion512 permutation to all four states in-place.
+func (s *Areion512x4) Permute() {
+	areion512x4Permute(s)
+}
+
+// InversePermute applies the inverse Areion512 permutation to all four states in-place.
+func (s *Areion512x4) InversePermute() {
+	areion512x4InversePermute(s)
+}
@@ -0,0 +1,126 @@
+package aes
+
+import "testing"
+
+func TestAreion256Multi(t *testing.T) {
+	forEachCPUConfig(t, func(t *testing.T) {
+		var s2 Areion256x2
+		var s4 Areion256x4
+		for i := range s4 {
+			for j := range s4[i] {
+				s4[i][j] = byte(i*32 + j*5)
+			}
+		}
+		copy(s2[:], s4[:2])
+		orig2, orig4 := s2, s4
+
+		var want [4]Areion256
+		for i := range want {
+			want[i] = orig4[i]
+			areion256PermuteSoftware(&want[i])
+		}
+
+		s2.Permute()
+		s4.Permute()
+		for i := range s4 {
+			if s4[i] != want[i] {
+				t.Fatalf("%+v: Areion256x4 state %d mismatch", CPU, i)
+			}
+		}
+		for i := range s2 {
+			if s2[i] != want[i] {
+				t.Fatalf("%+v: Areion256x2 state %d mismatch", CPU, i)
+			}
+		}
+
+		s2.InversePermute()
+		s4.InversePermute()
+		if s2 != orig2 || s4 != orig4 {
+			t.Fatalf("%+v: Areion256 multi-state inverse mismatch", CPU)
+		}
+	})
+}
+
+func TestAreion512Multi(t *testing.T) {
+	forEachCPUConfig(t, func(t *testing.T) {
+		var s2 Areion512x2
+		var s4 Areion512x4
+		for i := range s4 {
+			for j := range s4[i] {
+				s4[i][j] = byte(i*64 + j*3)
+			}
+		}
+		copy(s2[:], s4[2:])
+		orig2, orig4 := s2, s4
+
+		var want [4]Areion512
+		for i := range want {
+			want[i] = orig4[i]
+			areion512PermuteSoftware(&want[i])
+		}
+
+		s2.Permute()
+		s4.Permute()
+		for i := range s4 {
+			if s4[i] != want[i] {
+				t.Fatalf("%+v: Areion512x4 state %d mismatch", CPU, i)
+			}
+		}
+		for i := range s2 {
+			if s2[i] != want[i+2] {
+				t.Fatalf("%+v: Areion512x2 state %d mismatch", CPU, i)
+			}
+		}
+
+		s2.InversePermute()
+		s4.InversePermute()
+		if s2 != orig2 || s4 != orig4 {
+			t.Fatalf("%+v: Areion512 multi-state inverse mismatch", CPU)
+		}
+	})
+}
+
+func TestAreionMultiZeroVector(t *testing.T) {
+	// Each lane must reproduce the single-state reference vector
+	var s Areion512x4
+	s.Permute()
+	var want Areion512
+	want.Permute()
+	for i := range s {
+		if s[i] != want {
+			t.Fatalf("lane %d differs from Areion512 on the zero state", i)
+		}
+	}
+}
+
+func BenchmarkAreion256x4(b *testing.B) {
+	var s Areion256x4
+	b.SetBytes(int64(len(s) * 32))
+	for i := 0; i < b.N; i++ {
+		s.Permute()
+	}
+}
+
+func BenchmarkAreion512x2(b *testing.B) {
+	var s Areion512x2
+	b.SetBytes(int64(len(s) * 64))
+	for i := 0; i < b.N; i++ {
+		s.Permute()
+	}
+}
+
+func BenchmarkAreion512x4(b *testing.B) {
+	var s Areion512x4
+	b.SetBytes(int64(len(s) * 64))
+	for i := 0; i < b.N; i++ {
+		s.Permute()
+	}
+}
+
+func BenchmarkAreion512x4Inverse(b *testing.B) {
+	var s Areion512x4
+	b.SetBytes(int64(len(s) * 64))
+	for i := 0; i < b.N; i++ {
+		s.InversePermute()
+	}
+}
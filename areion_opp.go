@@ -0,0 +1,298 @@
+package aes
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// Areion-OPP authenticated encryption.
+//
+// OPP, the Offset Public Permutation mode (Granger, Jovanovic, Mennink and
+// Neves, "Improved Masking for Tweakable Blockciphers with Applications to
+// Authenticated Encryption", EUROCRYPT 2016), builds a tweakable block cipher
+// from a public permutation with the masked Even-Mansour (MEM) construction:
+//
+//	Ẽ(i0, i1, i2)(X) = P(X ⊕ δ) ⊕ δ,  δ = φ2^i2 ∘ φ1^i1 ∘ φ^i0 (L),  L = P(N || 0 || K)
+//
+// φ is an LFSR on the eight little-endian 64-bit words of the state,
+//
+//	φ(x0, ..., x7) = (x1, ..., x7, (x0 <<< 29) ⊕ (x1 << 9))
+//
+// with φ1 = φ ⊕ id and φ2 = φ² ⊕ φ ⊕ id. The block index i0 advances the mask
+// with a single φ step, i1 marks partial blocks and the tag, and i2 separates
+// associated data (0) from the message (1). Areion-OPP instantiates P with
+// Areion512, so blocks are 64 bytes.
+//
+// For a message M = M0 ... Mm-1 M* and associated data A = A0 ... Aa-1 A*:
+//
+//	Ci       = Ẽ(i, 0, 1)(Mi)
+//	C*       = M* ⊕ Ẽ(m, 1, 1)(0)[:|M*|]                    if M* ≠ ε
+//	Checksum = M0 ⊕ ... ⊕ Mm-1 (⊕ M*10*)
+//	Auth     = ⊕ Ẽ(i, 0, 0)(Ai) (⊕ Ẽ(a, 1, 0)(A*10*))
+//	Tag      = (Ẽ(m, 2, 1)(Checksum) ⊕ Auth)[:16]              if M* = ε
+//	Tag      = (Ẽ(m, 3, 1)(Checksum) ⊕ Auth)[:16]              otherwise
+//
+// The 10* padding appends a 0x01 byte followed by zeros. Full blocks are
+// processed four at a time through a multi-state Areion512 kernel.
+
+const (
+	// AreionOPPKeySize is the key size of Areion-OPP.
+	AreionOPPKeySize = 16
+
+	// AreionOPPNonceSize is the nonce size of Areion-OPP.
+	AreionOPPNonceSize = 16
+
+	// AreionOPPTagSize is the tag size of Areion-OPP.
+	AreionOPPTagSize = 16
+
+	// areionOPPBlockSize is the Areion512 block size.
+	areionOPPBlockSize = 64
+)
+
+var errAreionOPPOpen = errors.New("aes: Areion-OPP message authentication failed")
+
+// areionOPPMask is an OPP mask as eight little-endian 64-bit words.
+type areionOPPMask [8]uint64
+
+func (m *areionOPPMask) load(s *Areion512) {
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint64(s[8*i:])
+	}
+}
+
+// phi advances the mask to the next block index.
+func (m *areionOPPMask) phi() {
+	t := bits.RotateLeft64(m[0], 29) ^ m[1]<<9
+	copy(m[:7], m[1:])
+	m[7] = t
+}
+
+// phi1 applies φ ⊕ id.
+func (m *areionOPPMask) phi1() {
+	x := *m
+	m.phi()
+	for i := range m {
+		m[i] ^= x[i]
+	}
+}
+
+// phi2 applies φ² ⊕ φ ⊕ id.
+func (m *areionOPPMask) phi2() {
+	x := *m
+	m.phi()
+	y := *m
+	m.phi()
+	for i := range m {
+		m[i] ^= x[i] ^ y[i]
+	}
+}
+
+// xor sets dst = src ⊕ m for 64-byte dst and src.
+func (m *areionOPPMask) xor(dst, src []byte) {
+	_ = dst[63]
+	_ = src[63]
+	for i := range m {
+		binary.LittleEndian.PutUint64(dst[8*i:], binary.LittleEndian.Uint64(src[8*i:])^m[i])
+	}
+}
+
+// encrypt applies Ẽ with mask m to a single block in place.
+func (m *areionOPPMask) encrypt(block *Areion512) {
+	m.xor(block[:], block[:])
+	areion512Permute(block)
+	m.xor(block[:], block[:])
+}
+
+// areionOPPBlocks applies Ẽ (or its inverse) to the full blocks of src, one
+// mask step per block starting from m, and leaves m at the next index. The
+// results are written to dst, or XORed into sum when dst is nil.
+func areionOPPBlocks(dst, src, sum []byte, m *areionOPPMask, inverse bool) {
+	var states Areion512x4
+	var masks [4]areionOPPMask
+
+	for len(src) >= 4*areionOPPBlockSize {
+		for j := range states {
+			masks[j] = *m
+			m.xor(states[j][:], src[j*areionOPPBlockSize:])
+			m.phi()
+		}
+		if inverse {
+			states.InversePermute()
+		} else {
+			states.Permute()
+		}
+		for j := range states {
+			masks[j].xor(states[j][:], states[j][:])
+			if dst == nil {
+				subtle.XORBytes(sum, sum, states[j][:])
+			} else {
+				copy(dst[j*areionOPPBlockSize:], states[j][:])
+			}
+		}
+		src = src[4*areionOPPBlockSize:]
+		if dst != nil {
+			dst = dst[4*areionOPPBlockSize:]
+		}
+	}
+
+	for len(src) >= areionOPPBlockSize {
+		s := &states[0]
+		m.xor(s[:], src)
+		if inverse {
+			areion512InversePermute(s)
+		} else {
+			areion512Permute(s)
+		}
+		m.xor(s[:], s[:])
+		m.phi()
+		if dst == nil {
+			subtle.XORBytes(sum, sum, s[:])
+		} else {
+			copy(dst, s[:])
+			dst = dst[areionOPPBlockSize:]
+		}
+		src = src[areionOPPBlockSize:]
+	}
+}
+
+// areionOPP implements cipher.AEAD. It only holds the key and is safe for
+// concurrent use.
+type areionOPP struct {
+	key [AreionOPPKeySize]byte
+}
+
+// NewAreionOPP returns Areion-OPP with the given 16-byte key.
+func NewAreionOPP(key []byte) (cipher.AEAD, error) {
+	if len(key) != AreionOPPKeySize {
+		return nil, errors.New("aes: invalid Areion-OPP key size")
+	}
+	a := &areionOPP{}
+	copy(a.key[:], key)
+	return a, nil
+}
+
+// NonceSize returns the nonce size in bytes.
+func (a *areionOPP) NonceSize() int { return AreionOPPNonceSize }
+
+// Overhead returns the tag size in bytes.
+func (a *areionOPP) Overhead() int { return AreionOPPTagSize }
+
+// initMask computes L = P(N || 0 || K).
+func (a *areionOPP) initMask(l *areionOPPMask, nonce []byte) {
+	var s Areion512
+	copy(s[:AreionOPPNonceSize], nonce)
+	copy(s[areionOPPBlockSize-AreionOPPKeySize:], a.key[:])
+	areion512Permute(&s)
+	l.load(&s)
+}
+
+// auth computes the associated data hash into sum.
+func (a *areionOPP) auth(sum []byte, l *areionOPPMask, ad []byte) {
+	m := *l
+	full := len(ad) &^ (areionOPPBlockSize - 1)
+	areionOPPBlocks(nil, ad[:full], sum, &m, false)
+	if full < len(ad) {
+		var buf Areion512
+		copy(buf[:], ad[full:])
+		buf[len(ad)-full] = 0x01
+		m.phi1()
+		m.encrypt(&buf)
+		subtle.XORBytes(sum, sum, buf[:])
+	}
+}
+
+// finalize turns the checksum into the tag. m must be the message mask at
+// index (m, 1, 1) after a partial last block, and (m, 0, 1) otherwise.
+func (a *areionOPP) finalize(tag []byte, checksum *Areion512, m, l *areionOPPMask, ad []byte) {
+	m.phi1()
+	m.phi1()
+	m.encrypt(checksum)
+	a.auth(checksum[:], l, ad)
+	copy(tag, checksum[:AreionOPPTagSize])
+}
+
+// Seal encrypts and authenticates plaintext, authenticates additionalData,
+// and appends the result to dst.
+func (a *areionOPP) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != AreionOPPNonceSize {
+		panic("aes: incorrect nonce length given to Areion-OPP")
+	}
+	ret, out := sliceForAppend(dst, len(plaintext)+AreionOPPTagSize)
+	if inexactOverlap(out, plaintext) {
+		panic("aes: invalid buffer overlap")
+	}
+
+	var l areionOPPMask
+	a.initMask(&l, nonce)
+	m := l
+	m.phi2()
+
+	var checksum Areion512
+	full := len(plaintext) &^ (areionOPPBlockSize - 1)
+	for i := 0; i < full; i += areionOPPBlockSize {
+		subtle.XORBytes(checksum[:], checksum[:], plaintext[i:i+areionOPPBlockSize])
+	}
+	areionOPPBlocks(out[:full], plaintext[:full], nil, &m, false)
+
+	if rest := plaintext[full:]; len(rest) > 0 {
+		var pad Areion512
+		m.phi1()
+		m.encrypt(&pad)
+		subtle.XORBytes(checksum[:], checksum[:], rest)
+		checksum[len(rest)] ^= 0x01
+		subtle.XORBytes(out[full:], rest, pad[:len(rest)])
+	}
+
+	a.finalize(out[len(plaintext):], &checksum, &m, &l, additionalData)
+	return ret
+}
+
+// Open authenticates and decrypts ciphertext, authenticates additionalData,
+// and appends the resulting plaintext to dst.
+func (a *areionOPP) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != AreionOPPNonceSize {
+		panic("aes: incorrect nonce length given to Areion-OPP")
+	}
+	if len(ciphertext) < AreionOPPTagSize {
+		return nil, errAreionOPPOpen
+	}
+	tag := ciphertext[len(ciphertext)-AreionOPPTagSize:]
+	ciphertext = ciphertext[:len(ciphertext)-AreionOPPTagSize]
+
+	ret, out := sliceForAppend(dst, len(ciphertext))
+	if inexactOverlap(out, ciphertext) {
+		panic("aes: invalid buffer overlap")
+	}
+
+	var l areionOPPMask
+	a.initMask(&l, nonce)
+	m := l
+	m.phi2()
+
+	var checksum Areion512
+	full := len(ciphertext) &^ (areionOPPBlockSize - 1)
+	areionOPPBlocks(out[:full], ciphertext[:full], nil, &m, true)
+	for i := 0; i < full; i += areionOPPBlockSize {
+		subtle.XORBytes(checksum[:], checksum[:], out[i:i+areionOPPBlockSize])
+	}
+
+	if rest := ciphertext[full:]; len(rest) > 0 {
+		var pad Areion512
+		m.phi1()
+		m.encrypt(&pad)
+		subtle.XORBytes(out[full:], rest, pad[:len(rest)])
+		subtle.XORBytes(checksum[:], checksum[:], out[full:])
+		checksum[len(rest)] ^= 0x01
+	}
+
+	var expected [AreionOPPTagSize]byte
+	a.finalize(expected[:], &checksum, &m, &l, additionalData)
+	if subtle.ConstantTimeCompare(expected[:], tag) != 1 {
+		clear(out)
+		return nil, errAreionOPPOpen
+	}
+	return ret, nil
+}
@@ -0,0 +1,288 @@
+package aes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// areionOPPReference is a direct, block-at-a-time transcription of the OPP
+// equations that recomputes every mask from L.
+func areionOPPReference(key, nonce, plaintext, ad []byte) []byte {
+	var s Areion512
+	copy(s[:16], nonce)
+	copy(s[48:], key)
+	s.Permute()
+	var l areionOPPMask
+	l.load(&s)
+
+	delta := func(i0, i1, i2 int) areionOPPMask {
+		d := l
+		for range i2 {
+			d.phi2()
+		}
+		for range i1 {
+			d.phi1()
+		}
+		for range i0 {
+			d.phi()
+		}
+		return d
+	}
+	tbc := func(block []byte, i0, i1, i2 int) Areion512 {
+		var x Areion512
+		copy(x[:], block)
+		d := delta(i0, i1, i2)
+		d.xor(x[:], x[:])
+		x.Permute()
+		d.xor(x[:], x[:])
+		return x
+	}
+	pad := func(b []byte) []byte {
+		out := make([]byte, 64)
+		copy(out, b)
+		out[len(b)] = 0x01
+		return out
+	}
+
+	var checksum, auth [64]byte
+	ct := make([]byte, 0, len(plaintext)+16)
+	i := 0
+	for ; len(plaintext) >= 64; i++ {
+		c := tbc(plaintext[:64], i, 0, 1)
+		ct = append(ct, c[:]...)
+		for j := range checksum {
+			checksum[j] ^= plaintext[j]
+		}
+		plaintext = plaintext[64:]
+	}
+	tagTweak := 2
+	if len(plaintext) > 0 {
+		z := tbc(make([]byte, 64), i, 1, 1)
+		for j := range plaintext {
+			ct = append(ct, plaintext[j]^z[j])
+		}
+		p := pad(plaintext)
+		for j := range checksum {
+			checksum[j] ^= p[j]
+		}
+		tagTweak = 3
+	}
+	final := tbc(checksum[:], i, tagTweak, 1)
+
+	k := 0
+	for ; len(ad) >= 64; k++ {
+		x := tbc(ad[:64], k, 0, 0)
+		for j := range auth {
+			auth[j] ^= x[j]
+		}
+		ad = ad[64:]
+	}
+	if len(ad) > 0 {
+		x := tbc(pad(ad), k, 1, 0)
+		for j := range auth {
+			auth[j] ^= x[j]
+		}
+	}
+	for j := range 16 {
+		ct = append(ct, final[j]^auth[j])
+	}
+	return ct
+}
+
+func areionOPPTestInput(n int, seed byte) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i*7) ^ seed
+	}
+	return b
+}
+
+func TestAreionOPPMask(t *testing.T) {
+	var m areionOPPMask
+	for i := range m {
+		m[i] = uint64(i+1) * 0x0101010101010101
+	}
+	x := m
+	m.phi()
+	for i := range 7 {
+		if m[i] != x[i+1] {
+			t.Fatalf("phi: word %d not shifted", i)
+		}
+	}
+	if want := (x[0]<<29 | x[0]>>35) ^ x[1]<<9; m[7] != want {
+		t.Fatalf("phi: feedback word %x, want %x", m[7], want)
+	}
+
+	// φ2 = φ1 ∘ φ1 ⊕ φ, and the three maps commute
+	a, b := x, x
+	a.phi2()
+	b.phi1()
+	b.phi1()
+	c := x
+	c.phi()
+	for i := range b {
+		b[i] ^= c[i]
+	}
+	if a != b {
+		t.Fatal("phi2 != phi1² ⊕ phi")
+	}
+	a, b = x, x
+	a.phi()
+	a.phi1()
+	b.phi1()
+	b.phi()
+	if a != b {
+		t.Fatal("phi and phi1 do not commute")
+	}
+}
+
+func TestAreionOPPMatchesReference(t *testing.T) {
+	key := areionOPPTestInput(AreionOPPKeySize, 0x11)
+	nonce := areionOPPTestInput(AreionOPPNonceSize, 0x22)
+	aead, err := NewAreionOPP(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lengths := []int{0, 1, 15, 16, 63, 64, 65, 127, 128, 191, 255, 256, 257, 320, 511, 575, 1000}
+	for _, n := range lengths {
+		for _, adLen := range []int{0, 1, 63, 64, 65, 300} {
+			pt := areionOPPTestInput(n, 0x33)
+			ad := areionOPPTestInput(adLen, 0x44)
+			want := areionOPPReference(key, nonce, pt, ad)
+			got := aead.Seal(nil, nonce, pt, ad)
+			if !bytes.Equal(got, want) {
+				t.Fatalf("len=%d ad=%d:\ngot:  %x\nwant: %x", n, adLen, got, want)
+			}
+			dec, err := aead.Open(nil, nonce, got, ad)
+			if err != nil {
+				t.Fatalf("len=%d ad=%d: %v", n, adLen, err)
+			}
+			if !bytes.Equal(dec, pt) {
+				t.Fatalf("len=%d ad=%d: decryption mismatch", n, adLen)
+			}
+		}
+	}
+}
+
+// These vectors were generated by this implementation and cross-checked
+// against areionOPPReference; they guard against regressions only.
+func TestAreionOPPKnownAnswers(t *testing.T) {
+	key := areionOPPTestInput(AreionOPPKeySize, 0)
+	nonce := areionOPPTestInput(AreionOPPNonceSize, 0x80)
+	aead, _ := NewAreionOPP(key)
+
+	tests := []struct {
+		ptLen, adLen int
+		want         string
+	}{
+		{0, 0, "9c2c0a551e5ea54c4580c2d0b781d464"},
+		{0, 32, "8d174b836c2a7e7a84a5927f886c6dc6"},
+		{32, 0, "f4b463190f187fb3a0cde9005f4a5c89af29d3d8fe589ffce46a48ca1eb55c62b0959a2c6d10204e58d5767456f8bb55"},
+		{64, 64, "6e097b0abe473b03c10856f4017fbf8ca0ada561f3bd3cdcabfe0278aeb6ff8c75a638673e0b82bc5dcbd33a3c0c8cb20577864f4a005fa5de224bd78d1158d68e20a4add13df15c78ae1eadbc7d2f7e"},
+		{100, 20, "6e097b0abe473b03c10856f4017fbf8ca0ada561f3bd3cdcabfe0278aeb6ff8c75a638673e0b82bc5dcbd33a3c0c8cb20577864f4a005fa5de224bd78d1158d680f57947ed4b617b4f75c5f211f3ffaed23b3e014d39120234c47b0bae1ba19d2a99ab6c74d6eccb47d6f61afe738768c78a9964"},
+	}
+	for _, tt := range tests {
+		got := aead.Seal(nil, nonce, areionOPPTestInput(tt.ptLen, 1), areionOPPTestInput(tt.adLen, 2))
+		if hex.EncodeToString(got) != tt.want {
+			t.Errorf("pt=%d ad=%d:\ngot:  %x\nwant: %s", tt.ptLen, tt.adLen, got, tt.want)
+		}
+	}
+}
+
+func TestAreionOPPInPlace(t *testing.T) {
+	aead, _ := NewAreionOPP(make([]byte, AreionOPPKeySize))
+	nonce := make([]byte, AreionOPPNonceSize)
+	pt := areionOPPTestInput(300, 5)
+	want := aead.Seal(nil, nonce, pt, nil)
+
+	buf := make([]byte, len(pt), len(pt)+AreionOPPTagSize)
+	copy(buf, pt)
+	got := aead.Seal(buf[:0], nonce, buf, nil)
+	if !bytes.Equal(got, want) {
+		t.Fatal("in-place Seal mismatch")
+	}
+	dec, err := aead.Open(got[:0], nonce, got, nil)
+	if err != nil || !bytes.Equal(dec, pt) {
+		t.Fatal("in-place Open mismatch")
+	}
+}
+
+func TestAreionOPPTamper(t *testing.T) {
+	aead, _ := NewAreionOPP(areionOPPTestInput(AreionOPPKeySize, 9))
+	nonce := areionOPPTestInput(AreionOPPNonceSize, 10)
+	pt := areionOPPTestInput(200, 11)
+	ad := []byte("header")
+	ct := aead.Seal(nil, nonce, pt, ad)
+
+	for _, pos := range []int{0, 64, 150, 199, 200, len(ct) - 1} {
+		bad := bytes.Clone(ct)
+		bad[pos] ^= 0x40
+		if _, err := aead.Open(nil, nonce, bad, ad); err == nil {
+			t.Errorf("modified byte %d accepted", pos)
+		}
+	}
+	if _, err := aead.Open(nil, nonce, ct, []byte("Header")); err == nil {
+		t.Error("modified associated data accepted")
+	}
+	badNonce := bytes.Clone(nonce)
+	badNonce[15] ^= 1
+	if _, err := aead.Open(nil, badNonce, ct, ad); err == nil {
+		t.Error("wrong nonce accepted")
+	}
+	if _, err := aead.Open(nil, nonce, ct[:len(ct)-1], ad); err == nil {
+		t.Error("truncated ciphertext accepted")
+	}
+	if _, err := aead.Open(nil, nonce, ct[:AreionOPPTagSize-1], ad); err == nil {
+		t.Error("short ciphertext accepted")
+	}
+}
+
+func TestAreionOPPErrors(t *testing.T) {
+	if _, err := NewAreionOPP(make([]byte, 32)); err == nil {
+		t.Error("expected error for a 32-byte key")
+	}
+	aead, _ := NewAreionOPP(make([]byte, AreionOPPKeySize))
+	if aead.NonceSize() != AreionOPPNonceSize || aead.Overhead() != AreionOPPTagSize {
+		t.Error("unexpected nonce or tag size")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for a short nonce")
+		}
+	}()
+	aead.Seal(nil, make([]byte, 12), nil, nil)
+}
+
+func benchmarkAreionOPP(b *testing.B, size int) {
+	aead, _ := NewAreionOPP(make([]byte, AreionOPPKeySize))
+	nonce := make([]byte, AreionOPPNonceSize)
+	pt := make([]byte, size)
+	out := make([]byte, 0, size+AreionOPPTagSize)
+
+	b.SetBytes(int64(size))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		aead.Seal(out, nonce, pt, nil)
+	}
+}
+
+func BenchmarkAreionOPPSeal64(b *testing.B)  { benchmarkAreionOPP(b, 64) }
+func BenchmarkAreionOPPSeal1K(b *testing.B)  { benchmarkAreionOPP(b, 1024) }
+func BenchmarkAreionOPPSeal16K(b *testing.B) { benchmarkAreionOPP(b, 16384) }
+
+func BenchmarkAreionOPPOpen16K(b *testing.B) {
+	aead, _ := NewAreionOPP(make([]byte, AreionOPPKeySize))
+	nonce := make([]byte, AreionOPPNonceSize)
+	ct := aead.Seal(nil, nonce, make([]byte, 16384), nil)
+	out := make([]byte, 0, 16384)
+
+	b.SetBytes(16384)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := aead.Open(out, nonce, ct, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
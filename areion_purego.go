@@ -19,3 +19,51 @@ func areion512Permute(state *Areion512) {
 func areion512InversePermute(state *Areion512) {
 	areion512InversePermuteSoftware(state)
 }
+
+func areion256x2Permute(s *Areion256x2) {
+	for i := range s {
+		areion256PermuteSoftware(&s[i])
+	}
+}
+
+func areion256x2InversePermute(s *Areion256x2) {
+	for i := range s {
+		areion256InversePermuteSoftware(&s[i])
+	}
+}
+
+func areion256x4Permute(s *Areion256x4) {
+	for i := range s {
+		areion256PermuteSoftware(&s[i])
+	}
+}
+
+func areion256x4InversePermute(s *Areion256x4) {
+	for i := range s {
+		areion256InversePermuteSoftware(&s[i])
+	}
+}
+
+func areion512x2Permute(s *Areion512x2) {
+	for i := range s {
+		areion512PermuteSoftware(&s[i])
+	}
+}
+
+func areion512x2InversePermute(s *Areion512x2) {
+	for i := range s {
+		areion512InversePermuteSoftware(&s[i])
+	}
+}
+
+func areion512x4Permute(s *Areion512x4) {
+	for i := range s {
+		areion512PermuteSoftware(&s[i])
+	}
+}
+
+func areion512x4InversePermute(s *Areion512x4) {
+	for i := range s {
+		areion512InversePermuteSoftware(&s[i])
+	}
+}
@@ -0,0 +1,290 @@
+package aes
+
+import (
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+)
+
+// ARIA (RFC 5794) is a 128-bit block cipher with a 128, 192 or 256-bit key
+// and 12, 14 or 16 rounds of a substitution-permutation network. A round
+// adds a round key, applies a layer of four S-boxes SB1, SB2, SB3 and SB4 in
+// turn to the sixteen bytes (in the reverse order SB3, SB4, SB1, SB2 on even
+// rounds) and mixes the bytes with an involutive binary matrix A. The last
+// round has no A and ends with another round key.
+//
+// SB1 is the AES S-box and SB3 its inverse. SB2 is x^247 followed by an
+// affine map, and SB4 its inverse; since x^247 = (x^-1)^8 and squaring is
+// linear in GF(2^8), they are
+//
+//	SB2(x) = P2·SubBytes(x) ⊕ 0x88
+//	SB4(x) = InvSubBytes(Q4·x ⊕ 0x04)
+//
+// for two 8x8 bit matrices. The batch functions use this to run four blocks
+// per call on the AES final round instructions, with the matrices and A as
+// byte shuffles. Single blocks use tables.
+
+const (
+	// ARIABlockSize is the ARIA block size in bytes.
+	ARIABlockSize = 16
+
+	ariaMaxRounds = 16
+)
+
+var ariaSB2 = [256]byte{
+	0xe2, 0x4e, 0x54, 0xfc, 0x94, 0xc2, 0x4a, 0xcc, 0x62, 0x0d, 0x6a, 0x46, 0x3c, 0x4d, 0x8b, 0xd1,
+	0x5e, 0xfa, 0x64, 0xcb, 0xb4, 0x97, 0xbe, 0x2b, 0xbc, 0x77, 0x2e, 0x03, 0xd3, 0x19, 0x59, 0xc1,
+	0x1d, 0x06, 0x41, 0x6b, 0x55, 0xf0, 0x99, 0x69, 0xea, 0x9c, 0x18, 0xae, 0x63, 0xdf, 0xe7, 0xbb,
+	0x00, 0x73, 0x66, 0xfb, 0x96, 0x4c, 0x85, 0xe4, 0x3a, 0x09, 0x45, 0xaa, 0x0f, 0xee, 0x10, 0xeb,
+	0x2d, 0x7f, 0xf4, 0x29, 0xac, 0xcf, 0xad, 0x91, 0x8d, 0x78, 0xc8, 0x95, 0xf9, 0x2f, 0xce, 0xcd,
+	0x08, 0x7a, 0x88, 0x38, 0x5c, 0x83, 0x2a, 0x28, 0x47, 0xdb, 0xb8, 0xc7, 0x93, 0xa4, 0x12, 0x53,
+	0xff, 0x87, 0x0e, 0x31, 0x36, 0x21, 0x58, 0x48, 0x01, 0x8e, 0x37, 0x74, 0x32, 0xca, 0xe9, 0xb1,
+	0xb7, 0xab, 0x0c, 0xd7, 0xc4, 0x56, 0x42, 0x26, 0x07, 0x98, 0x60, 0xd9, 0xb6, 0xb9, 0x11, 0x40,
+	0xec, 0x20, 0x8c, 0xbd, 0xa0, 0xc9, 0x84, 0x04, 0x49, 0x23, 0xf1, 0x4f, 0x50, 0x1f, 0x13, 0xdc,
+	0xd8, 0xc0, 0x9e, 0x57, 0xe3, 0xc3, 0x7b, 0x65, 0x3b, 0x02, 0x8f, 0x3e, 0xe8, 0x25, 0x92, 0xe5,
+	0x15, 0xdd, 0xfd, 0x17, 0xa9, 0xbf, 0xd4, 0x9a, 0x7e, 0xc5, 0x39, 0x67, 0xfe, 0x76, 0x9d, 0x43,
+	0xa7, 0xe1, 0xd0, 0xf5, 0x68, 0xf2, 0x1b, 0x34, 0x70, 0x05, 0xa3, 0x8a, 0xd5, 0x79, 0x86, 0xa8,
+	0x30, 0xc6, 0x51, 0x4b, 0x1e, 0xa6, 0x27, 0xf6, 0x35, 0xd2, 0x6e, 0x24, 0x16, 0x82, 0x5f, 0xda,
+	0xe6, 0x75, 0xa2, 0xef, 0x2c, 0xb2, 0x1c, 0x9f, 0x5d, 0x6f, 0x80, 0x0a, 0x72, 0x44, 0x9b, 0x6c,
+	0x90, 0x0b, 0x5b, 0x33, 0x7d, 0x5a, 0x52, 0xf3, 0x61, 0xa1, 0xf7, 0xb0, 0xd6, 0x3f, 0x7c, 0x6d,
+	0xed, 0x14, 0xe0, 0xa5, 0x3d, 0x22, 0xb3, 0xf8, 0x89, 0xde, 0x71, 0x1a, 0xaf, 0xba, 0xb5, 0x81,
+}
+
+var ariaSB4 = func() (t [256]byte) {
+	for x, y := range ariaSB2 {
+		t[y] = byte(x)
+	}
+	return t
+}()
+
+// Key schedule constants: the fractional part of 1/π.
+var ariaCK = [3]Block{
+	{0x51, 0x7c, 0xc1, 0xb7, 0x27, 0x22, 0x0a, 0x94, 0xfe, 0x13, 0xab, 0xe8, 0xfa, 0x9a, 0x6e, 0xe0},
+	{0x6d, 0xb1, 0x4a, 0xcc, 0x9e, 0x21, 0xc8, 0x20, 0xff, 0x28, 0xb1, 0xd5, 0xef, 0x5d, 0xe2, 0xb0},
+	{0xdb, 0x92, 0x37, 0x1d, 0x21, 0x26, 0xe9, 0x70, 0x03, 0x24, 0x97, 0x75, 0x04, 0xe8, 0xc9, 0x0e},
+}
+
+// ARIA is ARIA with an expanded key. It is safe for concurrent use.
+type ARIA struct {
+	rounds int
+	enc    [ariaMaxRounds + 1]Block
+	dec    [ariaMaxRounds + 1]Block
+}
+
+// NewARIA expands a 16, 24 or 32-byte key.
+func NewARIA(key []byte) (*ARIA, error) {
+	var n, ck int
+	switch len(key) {
+	case 16:
+		n, ck = 12, 0
+	case 24:
+		n, ck = 14, 1
+	case 32:
+		n, ck = 16, 2
+	default:
+		return nil, errors.New("aes: ARIA key must be 16, 24 or 32 bytes")
+	}
+
+	var w [4]Block
+	var kr Block
+	copy(w[0][:], key[:16])
+	copy(kr[:], key[16:])
+	w[1] = w[0]
+	ariaFO(&w[1], &ariaCK[ck])
+	ariaAddKey(&w[1], &kr)
+	w[2] = w[1]
+	ariaFE(&w[2], &ariaCK[(ck+1)%3])
+	ariaAddKey(&w[2], &w[0])
+	w[3] = w[2]
+	ariaFO(&w[3], &ariaCK[(ck+2)%3])
+	ariaAddKey(&w[3], &w[1])
+
+	c := &ARIA{rounds: n}
+	// ek(4j+i+1) = W(i) ⊕ (W(i+1) >>> r(j)), with rotations to the right.
+	rot := [5]uint{19, 31, 128 - 61, 128 - 31, 128 - 19}
+	for k := range n + 1 {
+		i := k % 4
+		c.enc[k] = ariaRotR(&w[(i+1)%4], rot[k/4])
+		ariaAddKey(&c.enc[k], &w[i])
+	}
+	c.dec[0], c.dec[n] = c.enc[n], c.enc[0]
+	for k := 1; k < n; k++ {
+		c.dec[k] = c.enc[n-k]
+		ariaA(&c.dec[k])
+	}
+	return c, nil
+}
+
+// BlockSize returns the block size in bytes.
+func (c *ARIA) BlockSize() int { return ARIABlockSize }
+
+// Encrypt encrypts src into dst. dst and src may point to the same block.
+func (c *ARIA) Encrypt(dst, src *Block) {
+	ariaCrypt(c.enc[:c.rounds+1], dst, src)
+}
+
+// Decrypt decrypts src into dst. dst and src may point to the same block.
+func (c *ARIA) Decrypt(dst, src *Block) {
+	ariaCrypt(c.dec[:c.rounds+1], dst, src)
+}
+
+// Encrypt4 encrypts four blocks.
+func (c *ARIA) Encrypt4(dst, src *Block4) {
+	ariaCrypt4(c.enc[:c.rounds+1], dst, src)
+}
+
+// Decrypt4 decrypts four blocks.
+func (c *ARIA) Decrypt4(dst, src *Block4) {
+	ariaCrypt4(c.dec[:c.rounds+1], dst, src)
+}
+
+// Encrypt8 encrypts eight blocks.
+func (c *ARIA) Encrypt8(dst, src *[8 * ARIABlockSize]byte) {
+	ariaCrypt8(c.enc[:c.rounds+1], dst, src)
+}
+
+// Decrypt8 decrypts eight blocks.
+func (c *ARIA) Decrypt8(dst, src *[8 * ARIABlockSize]byte) {
+	ariaCrypt8(c.dec[:c.rounds+1], dst, src)
+}
+
+// EncryptBlocks encrypts each 16-byte block of src into dst, independently
+// (ECB), eight or four blocks at a time. len(src) must be a multiple of the
+// block size and dst must be at least as long; dst and src may be the same
+// slice.
+func (c *ARIA) EncryptBlocks(dst, src []byte) {
+	ariaBlocks(c.enc[:c.rounds+1], dst, src)
+}
+
+// DecryptBlocks decrypts each 16-byte block of src into dst, independently.
+func (c *ARIA) DecryptBlocks(dst, src []byte) {
+	ariaBlocks(c.dec[:c.rounds+1], dst, src)
+}
+
+// NewARIACipher returns ARIA with the given 16, 24 or 32-byte key as a
+// cipher.Block, for use with the modes of crypto/cipher.
+func NewARIACipher(key []byte) (cipher.Block, error) {
+	c, err := NewARIA(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipherBlock{"ARIA", c}, nil
+}
+
+func ariaBlocks(rk []Block, dst, src []byte) {
+	checkBlocks("ARIA", dst, src)
+	for len(src) >= 8*ARIABlockSize {
+		ariaCrypt8(rk, (*[8 * ARIABlockSize]byte)(dst), (*[8 * ARIABlockSize]byte)(src))
+		dst, src = dst[8*ARIABlockSize:], src[8*ARIABlockSize:]
+	}
+	if len(src) >= 4*ARIABlockSize {
+		ariaCrypt4(rk, (*Block4)(dst), (*Block4)(src))
+		dst, src = dst[4*ARIABlockSize:], src[4*ARIABlockSize:]
+	}
+	for len(src) > 0 {
+		ariaCrypt(rk, (*Block)(dst), (*Block)(src))
+		dst, src = dst[ARIABlockSize:], src[ARIABlockSize:]
+	}
+}
+
+// ariaCrypt runs the rounds with the len(rk)-1 round keys rk, which are
+// either the encryption or the decryption keys.
+func ariaCrypt(rk []Block, dst, src *Block) {
+	n := len(rk) - 1
+	x := *src
+	for r := range n - 1 {
+		if r%2 == 0 {
+			ariaFO(&x, &rk[r])
+		} else {
+			ariaFE(&x, &rk[r])
+		}
+	}
+	ariaAddKey(&x, &rk[n-1])
+	ariaSL2(&x)
+	ariaAddKey(&x, &rk[n])
+	*dst = x
+}
+
+// ariaCrypt8Generic runs eight blocks as two groups of four.
+func ariaCrypt8Generic(rk []Block, dst, src *[8 * ARIABlockSize]byte) {
+	ariaCrypt4(rk, (*Block4)(dst[:64]), (*Block4)(src[:64]))
+	ariaCrypt4(rk, (*Block4)(dst[64:]), (*Block4)(src[64:]))
+}
+
+// ariaCrypt4Generic runs four blocks one at a time.
+func ariaCrypt4Generic(rk []Block, dst, src *Block4) {
+	for i := 0; i < 64; i += 16 {
+		ariaCrypt(rk, (*Block)(dst[i:]), (*Block)(src[i:]))
+	}
+}
+
+// ariaFO is the odd round function A(SL1(x ⊕ rk)).
+func ariaFO(x, rk *Block) {
+	ariaAddKey(x, rk)
+	for i := 0; i < 16; i += 4 {
+		x[i] = sbox[x[i]]
+		x[i+1] = ariaSB2[x[i+1]]
+		x[i+2] = invSbox[x[i+2]]
+		x[i+3] = ariaSB4[x[i+3]]
+	}
+	ariaA(x)
+}
+
+// ariaFE is the even round function A(SL2(x ⊕ rk)).
+func ariaFE(x, rk *Block) {
+	ariaAddKey(x, rk)
+	ariaSL2(x)
+	ariaA(x)
+}
+
+func ariaSL2(x *Block) {
+	for i := 0; i < 16; i += 4 {
+		x[i] = invSbox[x[i]]
+		x[i+1] = ariaSB4[x[i+1]]
+		x[i+2] = sbox[x[i+2]]
+		x[i+3] = ariaSB2[x[i+3]]
+	}
+}
+
+// ariaA is the diffusion layer, an involution.
+func ariaA(x *Block) {
+	y0, y1, y2, y3, y4, y5, y6, y7 := x[0], x[1], x[2], x[3], x[4], x[5], x[6], x[7]
+	y8, y9, y10, y11, y12, y13, y14, y15 := x[8], x[9], x[10], x[11], x[12], x[13], x[14], x[15]
+	x[0] = y3 ^ y4 ^ y6 ^ y8 ^ y9 ^ y13 ^ y14
+	x[1] = y2 ^ y5 ^ y7 ^ y8 ^ y9 ^ y12 ^ y15
+	x[2] = y1 ^ y4 ^ y6 ^ y10 ^ y11 ^ y12 ^ y15
+	x[3] = y0 ^ y5 ^ y7 ^ y10 ^ y11 ^ y13 ^ y14
+	x[4] = y0 ^ y2 ^ y5 ^ y8 ^ y11 ^ y14 ^ y15
+	x[5] = y1 ^ y3 ^ y4 ^ y9 ^ y10 ^ y14 ^ y15
+	x[6] = y0 ^ y2 ^ y7 ^ y9 ^ y10 ^ y12 ^ y13
+	x[7] = y1 ^ y3 ^ y6 ^ y8 ^ y11 ^ y12 ^ y13
+	x[8] = y0 ^ y1 ^ y4 ^ y7 ^ y10 ^ y13 ^ y15
+	x[9] = y0 ^ y1 ^ y5 ^ y6 ^ y11 ^ y12 ^ y14
+	x[10] = y2 ^ y3 ^ y5 ^ y6 ^ y8 ^ y13 ^ y15
+	x[11] = y2 ^ y3 ^ y4 ^ y7 ^ y9 ^ y12 ^ y14
+	x[12] = y1 ^ y2 ^ y6 ^ y7 ^ y9 ^ y11 ^ y12
+	x[13] = y0 ^ y3 ^ y6 ^ y7 ^ y8 ^ y10 ^ y13
+	x[14] = y0 ^ y3 ^ y4 ^ y5 ^ y9 ^ y11 ^ y14
+	x[15] = y1 ^ y2 ^ y4 ^ y5 ^ y8 ^ y10 ^ y15
+}
+
+// ariaAddKey xors rk into x.
+func ariaAddKey(x, rk *Block) {
+	binary.LittleEndian.PutUint64(x[:8], binary.LittleEndian.Uint64(x[:8])^binary.LittleEndian.Uint64(rk[:8]))
+	binary.LittleEndian.PutUint64(x[8:], binary.LittleEndian.Uint64(x[8:])^binary.LittleEndian.Uint64(rk[8:]))
+}
+
+// ariaRotR rotates a 128-bit big-endian number right by n bits, 0 < n < 128.
+func ariaRotR(x *Block, n uint) (y Block) {
+	hi, lo := binary.BigEndian.Uint64(x[:8]), binary.BigEndian.Uint64(x[8:])
+	if n >= 64 {
+		hi, lo = lo, hi
+		n -= 64
+	}
+	if n > 0 {
+		hi, lo = hi>>n|lo<<(64-n), lo>>n|hi<<(64-n)
+	}
+	binary.BigEndian.PutUint64(y[:8], hi)
+	binary.BigEndian.PutUint64(y[8:], lo)
+	return y
+}
@@ -0,0 +1,25 @@
+//go:build amd64 && !purego
+
+package aes
+
+//go:noescape
+func ariaCrypt4AESNI(rk *Block, rounds int, dst, src *Block4)
+
+//go:noescape
+func ariaCrypt8VAES(rk *Block, rounds int, dst, src *[8 * ARIABlockSize]byte)
+
+func ariaCrypt4(rk []Block, dst, src *Block4) {
+	if CPU.HasAESNI && CPU.HasAVX {
+		ariaCrypt4AESNI(&rk[0], len(rk)-1, dst, src)
+	} else {
+		ariaCrypt4Generic(rk, dst, src)
+	}
+}
+
+func ariaCrypt8(rk []Block, dst, src *[8 * ARIABlockSize]byte) {
+	if CPU.HasVAES && CPU.HasAVX2 {
+		ariaCrypt8VAES(&rk[0], len(rk)-1, dst, src)
+	} else {
+		ariaCrypt8Generic(rk, dst, src)
+	}
+}
@@ -0,0 +1,558 @@
+// ARIA with AES-NI and AVX for AMD64
+#include "textflag.h"
+
+// Low nibble mask
+DATA ariaConsts<>+0x00(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA ariaConsts<>+0x08(SB)/8, $0x0f0f0f0f0f0f0f0f
+// InvShiftRows
+DATA ariaConsts<>+0x10(SB)/8, $0x0b0e0104070a0d00
+DATA ariaConsts<>+0x18(SB)/8, $0x0306090c0f020508
+// ShiftRows
+DATA ariaConsts<>+0x20(SB)/8, $0x030e09040f0a0500
+DATA ariaConsts<>+0x28(SB)/8, $0x0b06010c07020d08
+// P2·x ^ 0x88 for the low nibble x
+DATA ariaConsts<>+0x30(SB)/8, $0x3abf8500b2370d88
+DATA ariaConsts<>+0x38(SB)/8, $0x1a9fa52092172da8
+// P2·(x << 4) for the high nibble x
+DATA ariaConsts<>+0x40(SB)/8, $0x6e50ba84ead43e00
+DATA ariaConsts<>+0x48(SB)/8, $0xa39d77492719f3cd
+// Q4·x ^ 0x04 for the low nibble x
+DATA ariaConsts<>+0x50(SB)/8, $0xbcfd5617afee4504
+DATA ariaConsts<>+0x58(SB)/8, $0xebaa0140f8b91253
+// Q4·(x << 4) for the high nibble x
+DATA ariaConsts<>+0x60(SB)/8, $0x68de60d6be08b600
+DATA ariaConsts<>+0x68(SB)/8, $0x3b8d3385ed5be553
+// Bytes t, t+4, t+8, t+12 to word t
+DATA ariaConsts<>+0x70(SB)/8, $0x0d0905010c080400
+DATA ariaConsts<>+0x78(SB)/8, $0x0f0b07030e0a0602
+// Round key bytes 0, 4, 8, 12 in every word
+DATA ariaConsts<>+0x80(SB)/8, $0x0c0804000c080400
+DATA ariaConsts<>+0x88(SB)/8, $0x0c0804000c080400
+// Round key bytes 1, 5, 9, 13 in every word
+DATA ariaConsts<>+0x90(SB)/8, $0x0d0905010d090501
+DATA ariaConsts<>+0x98(SB)/8, $0x0d0905010d090501
+// Round key bytes 2, 6, 10, 14 in every word
+DATA ariaConsts<>+0xa0(SB)/8, $0x0e0a06020e0a0602
+DATA ariaConsts<>+0xa8(SB)/8, $0x0e0a06020e0a0602
+// Round key bytes 3, 7, 11, 15 in every word
+DATA ariaConsts<>+0xb0(SB)/8, $0x0f0b07030f0b0703
+DATA ariaConsts<>+0xb8(SB)/8, $0x0f0b07030f0b0703
+// A from register t to register t ^ 0, first byte
+DATA ariaConsts<>+0xc0(SB)/8, $0x0704040503000001
+DATA ariaConsts<>+0xc8(SB)/8, $0x0f0c0c0d0b080809
+// A from register t to register t ^ 0, second byte
+DATA ariaConsts<>+0xd0(SB)/8, $0x8005060680010202
+DATA ariaConsts<>+0xd8(SB)/8, $0x800d0e0e80090a0a
+// A from register t to register t ^ 1, first byte
+DATA ariaConsts<>+0xe0(SB)/8, $0x0404050600000102
+DATA ariaConsts<>+0xe8(SB)/8, $0x0c0c0d0e0808090a
+// A from register t to register t ^ 1, second byte
+DATA ariaConsts<>+0xf0(SB)/8, $0x0607800702038003
+DATA ariaConsts<>+0xf8(SB)/8, $0x0e0f800f0a0b800b
+// A from register t to register t ^ 2, first byte
+DATA ariaConsts<>+0x100(SB)/8, $0x0406040500020001
+DATA ariaConsts<>+0x108(SB)/8, $0x0c0e0c0d080a0809
+// A from register t to register t ^ 2, second byte
+DATA ariaConsts<>+0x110(SB)/8, $0x0580070701800303
+DATA ariaConsts<>+0x118(SB)/8, $0x0d800f0f09800b0b
+// A from register t to register t ^ 3, first byte
+DATA ariaConsts<>+0x120(SB)/8, $0x0505060401010200
+DATA ariaConsts<>+0x128(SB)/8, $0x0d0d0e0c09090a08
+// A from register t to register t ^ 3, second byte
+DATA ariaConsts<>+0x130(SB)/8, $0x0607078002030380
+DATA ariaConsts<>+0x138(SB)/8, $0x0e0f0f800a0b0b80
+GLOBL ariaConsts<>(SB), RODATA|NOPTR, $320
+
+// The same constants with every 16-byte row repeated, for 256-bit registers.
+// Low nibble mask
+DATA ariaConsts8<>+0x00(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA ariaConsts8<>+0x08(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA ariaConsts8<>+0x10(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA ariaConsts8<>+0x18(SB)/8, $0x0f0f0f0f0f0f0f0f
+// InvShiftRows
+DATA ariaConsts8<>+0x20(SB)/8, $0x0b0e0104070a0d00
+DATA ariaConsts8<>+0x28(SB)/8, $0x0306090c0f020508
+DATA ariaConsts8<>+0x30(SB)/8, $0x0b0e0104070a0d00
+DATA ariaConsts8<>+0x38(SB)/8, $0x0306090c0f020508
+// ShiftRows
+DATA ariaConsts8<>+0x40(SB)/8, $0x030e09040f0a0500
+DATA ariaConsts8<>+0x48(SB)/8, $0x0b06010c07020d08
+DATA ariaConsts8<>+0x50(SB)/8, $0x030e09040f0a0500
+DATA ariaConsts8<>+0x58(SB)/8, $0x0b06010c07020d08
+// P2·x ^ 0x88 for the low nibble x
+DATA ariaConsts8<>+0x60(SB)/8, $0x3abf8500b2370d88
+DATA ariaConsts8<>+0x68(SB)/8, $0x1a9fa52092172da8
+DATA ariaConsts8<>+0x70(SB)/8, $0x3abf8500b2370d88
+DATA ariaConsts8<>+0x78(SB)/8, $0x1a9fa52092172da8
+// P2·(x << 4) for the high nibble x
+DATA ariaConsts8<>+0x80(SB)/8, $0x6e50ba84ead43e00
+DATA ariaConsts8<>+0x88(SB)/8, $0xa39d77492719f3cd
+DATA ariaConsts8<>+0x90(SB)/8, $0x6e50ba84ead43e00
+DATA ariaConsts8<>+0x98(SB)/8, $0xa39d77492719f3cd
+// Q4·x ^ 0x04 for the low nibble x
+DATA ariaConsts8<>+0xa0(SB)/8, $0xbcfd5617afee4504
+DATA ariaConsts8<>+0xa8(SB)/8, $0xebaa0140f8b91253
+DATA ariaConsts8<>+0xb0(SB)/8, $0xbcfd5617afee4504
+DATA ariaConsts8<>+0xb8(SB)/8, $0xebaa0140f8b91253
+// Q4·(x << 4) for the high nibble x
+DATA ariaConsts8<>+0xc0(SB)/8, $0x68de60d6be08b600
+DATA ariaConsts8<>+0xc8(SB)/8, $0x3b8d3385ed5be553
+DATA ariaConsts8<>+0xd0(SB)/8, $0x68de60d6be08b600
+DATA ariaConsts8<>+0xd8(SB)/8, $0x3b8d3385ed5be553
+// Bytes t, t+4, t+8, t+12 to word t
+DATA ariaConsts8<>+0xe0(SB)/8, $0x0d0905010c080400
+DATA ariaConsts8<>+0xe8(SB)/8, $0x0f0b07030e0a0602
+DATA ariaConsts8<>+0xf0(SB)/8, $0x0d0905010c080400
+DATA ariaConsts8<>+0xf8(SB)/8, $0x0f0b07030e0a0602
+// Round key bytes 0, 4, 8, 12 in every word
+DATA ariaConsts8<>+0x100(SB)/8, $0x0c0804000c080400
+DATA ariaConsts8<>+0x108(SB)/8, $0x0c0804000c080400
+DATA ariaConsts8<>+0x110(SB)/8, $0x0c0804000c080400
+DATA ariaConsts8<>+0x118(SB)/8, $0x0c0804000c080400
+// Round key bytes 1, 5, 9, 13 in every word
+DATA ariaConsts8<>+0x120(SB)/8, $0x0d0905010d090501
+DATA ariaConsts8<>+0x128(SB)/8, $0x0d0905010d090501
+DATA ariaConsts8<>+0x130(SB)/8, $0x0d0905010d090501
+DATA ariaConsts8<>+0x138(SB)/8, $0x0d0905010d090501
+// Round key bytes 2, 6, 10, 14 in every word
+DATA ariaConsts8<>+0x140(SB)/8, $0x0e0a06020e0a0602
+DATA ariaConsts8<>+0x148(SB)/8, $0x0e0a06020e0a0602
+DATA ariaConsts8<>+0x150(SB)/8, $0x0e0a06020e0a0602
+DATA ariaConsts8<>+0x158(SB)/8, $0x0e0a06020e0a0602
+// Round key bytes 3, 7, 11, 15 in every word
+DATA ariaConsts8<>+0x160(SB)/8, $0x0f0b07030f0b0703
+DATA ariaConsts8<>+0x168(SB)/8, $0x0f0b07030f0b0703
+DATA ariaConsts8<>+0x170(SB)/8, $0x0f0b07030f0b0703
+DATA ariaConsts8<>+0x178(SB)/8, $0x0f0b07030f0b0703
+// A from register t to register t ^ 0, first byte
+DATA ariaConsts8<>+0x180(SB)/8, $0x0704040503000001
+DATA ariaConsts8<>+0x188(SB)/8, $0x0f0c0c0d0b080809
+DATA ariaConsts8<>+0x190(SB)/8, $0x0704040503000001
+DATA ariaConsts8<>+0x198(SB)/8, $0x0f0c0c0d0b080809
+// A from register t to register t ^ 0, second byte
+DATA ariaConsts8<>+0x1a0(SB)/8, $0x8005060680010202
+DATA ariaConsts8<>+0x1a8(SB)/8, $0x800d0e0e80090a0a
+DATA ariaConsts8<>+0x1b0(SB)/8, $0x8005060680010202
+DATA ariaConsts8<>+0x1b8(SB)/8, $0x800d0e0e80090a0a
+// A from register t to register t ^ 1, first byte
+DATA ariaConsts8<>+0x1c0(SB)/8, $0x0404050600000102
+DATA ariaConsts8<>+0x1c8(SB)/8, $0x0c0c0d0e0808090a
+DATA ariaConsts8<>+0x1d0(SB)/8, $0x0404050600000102
+DATA ariaConsts8<>+0x1d8(SB)/8, $0x0c0c0d0e0808090a
+// A from register t to register t ^ 1, second byte
+DATA ariaConsts8<>+0x1e0(SB)/8, $0x0607800702038003
+DATA ariaConsts8<>+0x1e8(SB)/8, $0x0e0f800f0a0b800b
+DATA ariaConsts8<>+0x1f0(SB)/8, $0x0607800702038003
+DATA ariaConsts8<>+0x1f8(SB)/8, $0x0e0f800f0a0b800b
+// A from register t to register t ^ 2, first byte
+DATA ariaConsts8<>+0x200(SB)/8, $0x0406040500020001
+DATA ariaConsts8<>+0x208(SB)/8, $0x0c0e0c0d080a0809
+DATA ariaConsts8<>+0x210(SB)/8, $0x0406040500020001
+DATA ariaConsts8<>+0x218(SB)/8, $0x0c0e0c0d080a0809
+// A from register t to register t ^ 2, second byte
+DATA ariaConsts8<>+0x220(SB)/8, $0x0580070701800303
+DATA ariaConsts8<>+0x228(SB)/8, $0x0d800f0f09800b0b
+DATA ariaConsts8<>+0x230(SB)/8, $0x0580070701800303
+DATA ariaConsts8<>+0x238(SB)/8, $0x0d800f0f09800b0b
+// A from register t to register t ^ 3, first byte
+DATA ariaConsts8<>+0x240(SB)/8, $0x0505060401010200
+DATA ariaConsts8<>+0x248(SB)/8, $0x0d0d0e0c09090a08
+DATA ariaConsts8<>+0x250(SB)/8, $0x0505060401010200
+DATA ariaConsts8<>+0x258(SB)/8, $0x0d0d0e0c09090a08
+// A from register t to register t ^ 3, second byte
+DATA ariaConsts8<>+0x260(SB)/8, $0x0607078002030380
+DATA ariaConsts8<>+0x268(SB)/8, $0x0e0f0f800a0b0b80
+DATA ariaConsts8<>+0x270(SB)/8, $0x0607078002030380
+DATA ariaConsts8<>+0x278(SB)/8, $0x0e0f0f800a0b0b80
+GLOBL ariaConsts8<>(SB), RODATA|NOPTR, $640
+
+// The state of four blocks is in X0 to X3: lane 4b+i of Xt is byte 4i+t of
+// block b, so that every register goes through a single S-box.
+
+// ARIAKEY sets X0 to X3 to a0 to a3 xored with the round key at off(AX).
+#define ARIAKEY(off, a0, a1, a2, a3) \
+	VMOVDQU off(AX), X8; \
+	VPSHUFB ariaConsts<>+0x80(SB), X8, X9; \
+	VPXOR X9, a0, X0; \
+	VPSHUFB ariaConsts<>+0x90(SB), X8, X9; \
+	VPXOR X9, a1, X1; \
+	VPSHUFB ariaConsts<>+0xa0(SB), X8, X9; \
+	VPXOR X9, a2, X2; \
+	VPSHUFB ariaConsts<>+0xb0(SB), X8, X9; \
+	VPXOR X9, a3, X3
+
+// SENC is the AES S-box: AESENCLAST with a zero key, after InvShiftRows.
+#define SENC(x) \
+	VPSHUFB ariaConsts<>+0x10(SB), x, x; \
+	VAESENCLAST X15, x, x
+
+// SDEC is the inverse AES S-box.
+#define SDEC(x) \
+	VPSHUFB ariaConsts<>+0x20(SB), x, x; \
+	VAESDECLAST X15, x, x
+
+// AFFINE applies the bit matrix with the nibble tables lo and hi to x.
+#define AFFINE(x, lo, hi) \
+	VPSRLW $4, x, X8; \
+	VPAND X10, X8, X8; \
+	VPAND X10, x, x; \
+	VPSHUFB x, lo, x; \
+	VPSHUFB X8, hi, X8; \
+	VPXOR X8, x, x
+
+// SL1 is the substitution layer of odd rounds: SB1, SB2, SB3 and SB4.
+#define SL1 \
+	SENC(X0); \
+	SENC(X1); \
+	AFFINE(X1, X11, X12); \
+	SDEC(X2); \
+	AFFINE(X3, X13, X14); \
+	SDEC(X3)
+
+// SL2 is the substitution layer of even rounds: SB3, SB4, SB1 and SB2.
+#define SL2 \
+	SDEC(X0); \
+	AFFINE(X1, X13, X14); \
+	SDEC(X1); \
+	SENC(X2); \
+	SENC(X3); \
+	AFFINE(X3, X11, X12)
+
+// DIFFUSE is the diffusion layer A from X0 to X3 into X4 to X7. Every output
+// byte is the sum of seven input bytes, two or one from each register.
+#define DIFFUSE \
+	VPSHUFB ariaConsts<>+0xc0(SB), X0, X4 \
+	VPSHUFB ariaConsts<>+0xd0(SB), X0, X8 \
+	VPXOR X8, X4, X4 \
+	VPSHUFB ariaConsts<>+0xe0(SB), X1, X8 \
+	VPXOR X8, X4, X4 \
+	VPSHUFB ariaConsts<>+0xf0(SB), X1, X8 \
+	VPXOR X8, X4, X4 \
+	VPSHUFB ariaConsts<>+0x100(SB), X2, X8 \
+	VPXOR X8, X4, X4 \
+	VPSHUFB ariaConsts<>+0x110(SB), X2, X8 \
+	VPXOR X8, X4, X4 \
+	VPSHUFB ariaConsts<>+0x120(SB), X3, X8 \
+	VPXOR X8, X4, X4 \
+	VPSHUFB ariaConsts<>+0x130(SB), X3, X8 \
+	VPXOR X8, X4, X4 \
+	VPSHUFB ariaConsts<>+0xe0(SB), X0, X5 \
+	VPSHUFB ariaConsts<>+0xf0(SB), X0, X8 \
+	VPXOR X8, X5, X5 \
+	VPSHUFB ariaConsts<>+0xc0(SB), X1, X8 \
+	VPXOR X8, X5, X5 \
+	VPSHUFB ariaConsts<>+0xd0(SB), X1, X8 \
+	VPXOR X8, X5, X5 \
+	VPSHUFB ariaConsts<>+0x120(SB), X2, X8 \
+	VPXOR X8, X5, X5 \
+	VPSHUFB ariaConsts<>+0x130(SB), X2, X8 \
+	VPXOR X8, X5, X5 \
+	VPSHUFB ariaConsts<>+0x100(SB), X3, X8 \
+	VPXOR X8, X5, X5 \
+	VPSHUFB ariaConsts<>+0x110(SB), X3, X8 \
+	VPXOR X8, X5, X5 \
+	VPSHUFB ariaConsts<>+0x100(SB), X0, X6 \
+	VPSHUFB ariaConsts<>+0x110(SB), X0, X8 \
+	VPXOR X8, X6, X6 \
+	VPSHUFB ariaConsts<>+0x120(SB), X1, X8 \
+	VPXOR X8, X6, X6 \
+	VPSHUFB ariaConsts<>+0x130(SB), X1, X8 \
+	VPXOR X8, X6, X6 \
+	VPSHUFB ariaConsts<>+0xc0(SB), X2, X8 \
+	VPXOR X8, X6, X6 \
+	VPSHUFB ariaConsts<>+0xd0(SB), X2, X8 \
+	VPXOR X8, X6, X6 \
+	VPSHUFB ariaConsts<>+0xe0(SB), X3, X8 \
+	VPXOR X8, X6, X6 \
+	VPSHUFB ariaConsts<>+0xf0(SB), X3, X8 \
+	VPXOR X8, X6, X6 \
+	VPSHUFB ariaConsts<>+0x120(SB), X0, X7 \
+	VPSHUFB ariaConsts<>+0x130(SB), X0, X8 \
+	VPXOR X8, X7, X7 \
+	VPSHUFB ariaConsts<>+0x100(SB), X1, X8 \
+	VPXOR X8, X7, X7 \
+	VPSHUFB ariaConsts<>+0x110(SB), X1, X8 \
+	VPXOR X8, X7, X7 \
+	VPSHUFB ariaConsts<>+0xe0(SB), X2, X8 \
+	VPXOR X8, X7, X7 \
+	VPSHUFB ariaConsts<>+0xf0(SB), X2, X8 \
+	VPXOR X8, X7, X7 \
+	VPSHUFB ariaConsts<>+0xc0(SB), X3, X8 \
+	VPXOR X8, X7, X7 \
+	VPSHUFB ariaConsts<>+0xd0(SB), X3, X8 \
+	VPXOR X8, X7, X7
+
+// func ariaCrypt4AESNI(rk *Block, rounds int, dst, src *Block4)
+TEXT ·ariaCrypt4AESNI(SB),NOSPLIT,$0
+	MOVQ rk+0(FP), AX
+	MOVQ rounds+8(FP), CX
+	MOVQ dst+16(FP), DI
+	MOVQ src+24(FP), SI
+
+	// Group the bytes of every block by position mod 4 into words, then
+	// transpose so that Xt holds word t of the four blocks.
+	VMOVDQU ariaConsts<>+0x70(SB), X8
+	VMOVDQU (SI), X0
+	VMOVDQU 16(SI), X1
+	VMOVDQU 32(SI), X2
+	VMOVDQU 48(SI), X3
+	VPSHUFB X8, X0, X0
+	VPSHUFB X8, X1, X1
+	VPSHUFB X8, X2, X2
+	VPSHUFB X8, X3, X3
+	VPUNPCKLDQ X1, X0, X4
+	VPUNPCKHDQ X1, X0, X5
+	VPUNPCKLDQ X3, X2, X6
+	VPUNPCKHDQ X3, X2, X7
+	VPUNPCKLQDQ X6, X4, X0
+	VPUNPCKHQDQ X6, X4, X1
+	VPUNPCKLQDQ X7, X5, X2
+	VPUNPCKHQDQ X7, X5, X3
+
+	VMOVDQU ariaConsts<>+0x00(SB), X10
+	VMOVDQU ariaConsts<>+0x30(SB), X11
+	VMOVDQU ariaConsts<>+0x40(SB), X12
+	VMOVDQU ariaConsts<>+0x50(SB), X13
+	VMOVDQU ariaConsts<>+0x60(SB), X14
+	VPXOR X15, X15, X15
+
+	ARIAKEY(0, X0, X1, X2, X3)
+
+	// rounds-2 rounds in pairs, then an odd round and the last round.
+	SUBQ $2, CX
+	SHRQ $1, CX
+
+loop:
+	SL1
+	DIFFUSE
+	ARIAKEY(16, X4, X5, X6, X7)
+	SL2
+	DIFFUSE
+	ARIAKEY(32, X4, X5, X6, X7)
+	ADDQ $32, AX
+	DECQ CX
+	JNZ loop
+
+	SL1
+	DIFFUSE
+	ARIAKEY(16, X4, X5, X6, X7)
+	SL2
+	ARIAKEY(32, X0, X1, X2, X3)
+
+	VPUNPCKLDQ X1, X0, X4
+	VPUNPCKHDQ X1, X0, X5
+	VPUNPCKLDQ X3, X2, X6
+	VPUNPCKHDQ X3, X2, X7
+	VPUNPCKLQDQ X6, X4, X0
+	VPUNPCKHQDQ X6, X4, X1
+	VPUNPCKLQDQ X7, X5, X2
+	VPUNPCKHQDQ X7, X5, X3
+	VMOVDQU ariaConsts<>+0x70(SB), X8
+	VPSHUFB X8, X0, X0
+	VPSHUFB X8, X1, X1
+	VPSHUFB X8, X2, X2
+	VPSHUFB X8, X3, X3
+	VMOVDQU X0, (DI)
+	VMOVDQU X1, 16(DI)
+	VMOVDQU X2, 32(DI)
+	VMOVDQU X3, 48(DI)
+	RET
+
+// The macros below are the ones above on 256-bit registers, for eight
+// blocks.
+
+// ARIAKEY8 sets Y0 to Y3 to a0 to a3 xored with the round key at off(AX).
+#define ARIAKEY8(off, a0, a1, a2, a3) \
+	VBROADCASTI128 off(AX), Y8; \
+	VPSHUFB ariaConsts8<>+0x100(SB), Y8, Y9; \
+	VPXOR Y9, a0, Y0; \
+	VPSHUFB ariaConsts8<>+0x120(SB), Y8, Y9; \
+	VPXOR Y9, a1, Y1; \
+	VPSHUFB ariaConsts8<>+0x140(SB), Y8, Y9; \
+	VPXOR Y9, a2, Y2; \
+	VPSHUFB ariaConsts8<>+0x160(SB), Y8, Y9; \
+	VPXOR Y9, a3, Y3
+
+// SENC8 is the AES S-box: AESENCLAST with a zero key, after InvShiftRows.
+#define SENC8(x) \
+	VPSHUFB ariaConsts8<>+0x20(SB), x, x; \
+	VAESENCLAST Y15, x, x
+
+// SDEC8 is the inverse AES S-box.
+#define SDEC8(x) \
+	VPSHUFB ariaConsts8<>+0x40(SB), x, x; \
+	VAESDECLAST Y15, x, x
+
+// AFFINE8 applies the bit matrix with the nibble tables lo and hi to x.
+#define AFFINE8(x, lo, hi) \
+	VPSRLW $4, x, Y8; \
+	VPAND Y10, Y8, Y8; \
+	VPAND Y10, x, x; \
+	VPSHUFB x, lo, x; \
+	VPSHUFB Y8, hi, Y8; \
+	VPXOR Y8, x, x
+
+// SLODD8 is the substitution layer of odd rounds: SB1, SB2, SB3 and SB4.
+#define SLODD8 \
+	SENC8(Y0); \
+	SENC8(Y1); \
+	AFFINE8(Y1, Y11, Y12); \
+	SDEC8(Y2); \
+	AFFINE8(Y3, Y13, Y14); \
+	SDEC8(Y3)
+
+// SLEVEN8 is the substitution layer of even rounds: SB3, SB4, SB1 and SB2.
+#define SLEVEN8 \
+	SDEC8(Y0); \
+	AFFINE8(Y1, Y13, Y14); \
+	SDEC8(Y1); \
+	SENC8(Y2); \
+	SENC8(Y3); \
+	AFFINE8(Y3, Y11, Y12)
+
+// DIFFUSE8 is the diffusion layer A from Y0 to Y3 into Y4 to Y7.
+#define DIFFUSE8 \
+	VPSHUFB ariaConsts8<>+0x180(SB), Y0, Y4 \
+	VPSHUFB ariaConsts8<>+0x1a0(SB), Y0, Y8 \
+	VPXOR Y8, Y4, Y4 \
+	VPSHUFB ariaConsts8<>+0x1c0(SB), Y1, Y8 \
+	VPXOR Y8, Y4, Y4 \
+	VPSHUFB ariaConsts8<>+0x1e0(SB), Y1, Y8 \
+	VPXOR Y8, Y4, Y4 \
+	VPSHUFB ariaConsts8<>+0x200(SB), Y2, Y8 \
+	VPXOR Y8, Y4, Y4 \
+	VPSHUFB ariaConsts8<>+0x220(SB), Y2, Y8 \
+	VPXOR Y8, Y4, Y4 \
+	VPSHUFB ariaConsts8<>+0x240(SB), Y3, Y8 \
+	VPXOR Y8, Y4, Y4 \
+	VPSHUFB ariaConsts8<>+0x260(SB), Y3, Y8 \
+	VPXOR Y8, Y4, Y4 \
+	VPSHUFB ariaConsts8<>+0x1c0(SB), Y0, Y5 \
+	VPSHUFB ariaConsts8<>+0x1e0(SB), Y0, Y8 \
+	VPXOR Y8, Y5, Y5 \
+	VPSHUFB ariaConsts8<>+0x180(SB), Y1, Y8 \
+	VPXOR Y8, Y5, Y5 \
+	VPSHUFB ariaConsts8<>+0x1a0(SB), Y1, Y8 \
+	VPXOR Y8, Y5, Y5 \
+	VPSHUFB ariaConsts8<>+0x240(SB), Y2, Y8 \
+	VPXOR Y8, Y5, Y5 \
+	VPSHUFB ariaConsts8<>+0x260(SB), Y2, Y8 \
+	VPXOR Y8, Y5, Y5 \
+	VPSHUFB ariaConsts8<>+0x200(SB), Y3, Y8 \
+	VPXOR Y8, Y5, Y5 \
+	VPSHUFB ariaConsts8<>+0x220(SB), Y3, Y8 \
+	VPXOR Y8, Y5, Y5 \
+	VPSHUFB ariaConsts8<>+0x200(SB), Y0, Y6 \
+	VPSHUFB ariaConsts8<>+0x220(SB), Y0, Y8 \
+	VPXOR Y8, Y6, Y6 \
+	VPSHUFB ariaConsts8<>+0x240(SB), Y1, Y8 \
+	VPXOR Y8, Y6, Y6 \
+	VPSHUFB ariaConsts8<>+0x260(SB), Y1, Y8 \
+	VPXOR Y8, Y6, Y6 \
+	VPSHUFB ariaConsts8<>+0x180(SB), Y2, Y8 \
+	VPXOR Y8, Y6, Y6 \
+	VPSHUFB ariaConsts8<>+0x1a0(SB), Y2, Y8 \
+	VPXOR Y8, Y6, Y6 \
+	VPSHUFB ariaConsts8<>+0x1c0(SB), Y3, Y8 \
+	VPXOR Y8, Y6, Y6 \
+	VPSHUFB ariaConsts8<>+0x1e0(SB), Y3, Y8 \
+	VPXOR Y8, Y6, Y6 \
+	VPSHUFB ariaConsts8<>+0x240(SB), Y0, Y7 \
+	VPSHUFB ariaConsts8<>+0x260(SB), Y0, Y8 \
+	VPXOR Y8, Y7, Y7 \
+	VPSHUFB ariaConsts8<>+0x200(SB), Y1, Y8 \
+	VPXOR Y8, Y7, Y7 \
+	VPSHUFB ariaConsts8<>+0x220(SB), Y1, Y8 \
+	VPXOR Y8, Y7, Y7 \
+	VPSHUFB ariaConsts8<>+0x1c0(SB), Y2, Y8 \
+	VPXOR Y8, Y7, Y7 \
+	VPSHUFB ariaConsts8<>+0x1e0(SB), Y2, Y8 \
+	VPXOR Y8, Y7, Y7 \
+	VPSHUFB ariaConsts8<>+0x180(SB), Y3, Y8 \
+	VPXOR Y8, Y7, Y7 \
+	VPSHUFB ariaConsts8<>+0x1a0(SB), Y3, Y8 \
+	VPXOR Y8, Y7, Y7
+
+// func ariaCrypt8VAES(rk *Block, rounds int, dst, src *[8 * ARIABlockSize]byte)
+TEXT ·ariaCrypt8VAES(SB),NOSPLIT,$0
+	MOVQ rk+0(FP), AX
+	MOVQ rounds+8(FP), CX
+	MOVQ dst+16(FP), DI
+	MOVQ src+24(FP), SI
+
+	// The same layout as ariaCrypt4AESNI, with blocks 0 to 3 in the low
+	// lanes and blocks 4 to 7 in the high lanes.
+	VMOVDQU ariaConsts8<>+0xe0(SB), Y8
+	VMOVDQU (SI), X0
+	VMOVDQU 16(SI), X1
+	VMOVDQU 32(SI), X2
+	VMOVDQU 48(SI), X3
+	VINSERTI128 $1, 64(SI), Y0, Y0
+	VINSERTI128 $1, 80(SI), Y1, Y1
+	VINSERTI128 $1, 96(SI), Y2, Y2
+	VINSERTI128 $1, 112(SI), Y3, Y3
+	VPSHUFB Y8, Y0, Y0
+	VPSHUFB Y8, Y1, Y1
+	VPSHUFB Y8, Y2, Y2
+	VPSHUFB Y8, Y3, Y3
+	VPUNPCKLDQ Y1, Y0, Y4
+	VPUNPCKHDQ Y1, Y0, Y5
+	VPUNPCKLDQ Y3, Y2, Y6
+	VPUNPCKHDQ Y3, Y2, Y7
+	VPUNPCKLQDQ Y6, Y4, Y0
+	VPUNPCKHQDQ Y6, Y4, Y1
+	VPUNPCKLQDQ Y7, Y5, Y2
+	VPUNPCKHQDQ Y7, Y5, Y3
+
+	VMOVDQU ariaConsts8<>+0x00(SB), Y10
+	VMOVDQU ariaConsts8<>+0x60(SB), Y11
+	VMOVDQU ariaConsts8<>+0x80(SB), Y12
+	VMOVDQU ariaConsts8<>+0xa0(SB), Y13
+	VMOVDQU ariaConsts8<>+0xc0(SB), Y14
+	VPXOR Y15, Y15, Y15
+
+	ARIAKEY8(0, Y0, Y1, Y2, Y3)
+
+	SUBQ $2, CX
+	SHRQ $1, CX
+
+loop:
+	SLODD8
+	DIFFUSE8
+	ARIAKEY8(16, Y4, Y5, Y6, Y7)
+	SLEVEN8
+	DIFFUSE8
+	ARIAKEY8(32, Y4, Y5, Y6, Y7)
+	ADDQ $32, AX
+	DECQ CX
+	JNZ loop
+
+	SLODD8
+	DIFFUSE8
+	ARIAKEY8(16, Y4, Y5, Y6, Y7)
+	SLEVEN8
+	ARIAKEY8(32, Y0, Y1, Y2, Y3)
+
+	VPUNPCKLDQ Y1, Y0, Y4
+	VPUNPCKHDQ Y1, Y0, Y5
+	VPUNPCKLDQ Y3, Y2, Y6
+	VPUNPCKHDQ Y3, Y2, Y7
+	VPUNPCKLQDQ Y6, Y4, Y0
+	VPUNPCKHQDQ Y6, Y4, Y1
+	VPUNPCKLQDQ Y7, Y5, Y2
+	VPUNPCKHQDQ Y7, Y5, Y3
+	VMOVDQU ariaConsts8<>+0xe0(SB), Y8
+	VPSHUFB Y8, Y0, Y0
+	VPSHUFB Y8, Y1, Y1
+	VPSHUFB Y8, Y2, Y2
+	VPSHUFB Y8, Y3, Y3
+	VMOVDQU X0, (DI)
+	VMOVDQU X1, 16(DI)
+	VMOVDQU X2, 32(DI)
+	VMOVDQU X3, 48(DI)
+	VEXTRACTI128 $1, Y0, 64(DI)
+	VEXTRACTI128 $1, Y1, 80(DI)
+	VEXTRACTI128 $1, Y2, 96(DI)
+	VEXTRACTI128 $1, Y3, 112(DI)
+	VZEROUPPER
+	RET
@@ -0,0 +1,25 @@
+//go:build arm64 && !purego
+
+package aes
+
+//go:noescape
+func ariaCrypt4HW(rk *Block, rounds int, dst, src *Block4)
+
+//go:noescape
+func ariaCrypt8HW(rk *Block, rounds int, dst, src *[8 * ARIABlockSize]byte)
+
+func ariaCrypt4(rk []Block, dst, src *Block4) {
+	if CPU.HasARMCrypto {
+		ariaCrypt4HW(&rk[0], len(rk)-1, dst, src)
+	} else {
+		ariaCrypt4Generic(rk, dst, src)
+	}
+}
+
+func ariaCrypt8(rk []Block, dst, src *[8 * ARIABlockSize]byte) {
+	if CPU.HasARMCrypto {
+		ariaCrypt8HW(&rk[0], len(rk)-1, dst, src)
+	} else {
+		ariaCrypt8Generic(rk, dst, src)
+	}
+}
@@ -0,0 +1,405 @@
+// ARIA with ARM Crypto for ARM64
+#include "textflag.h"
+
+// Low nibble mask
+DATA ariaConsts<>+0x00(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA ariaConsts<>+0x08(SB)/8, $0x0f0f0f0f0f0f0f0f
+// InvShiftRows
+DATA ariaConsts<>+0x10(SB)/8, $0x0b0e0104070a0d00
+DATA ariaConsts<>+0x18(SB)/8, $0x0306090c0f020508
+// ShiftRows
+DATA ariaConsts<>+0x20(SB)/8, $0x030e09040f0a0500
+DATA ariaConsts<>+0x28(SB)/8, $0x0b06010c07020d08
+// P2·x ^ 0x88 for the low nibble x
+DATA ariaConsts<>+0x30(SB)/8, $0x3abf8500b2370d88
+DATA ariaConsts<>+0x38(SB)/8, $0x1a9fa52092172da8
+// P2·(x << 4) for the high nibble x
+DATA ariaConsts<>+0x40(SB)/8, $0x6e50ba84ead43e00
+DATA ariaConsts<>+0x48(SB)/8, $0xa39d77492719f3cd
+// Q4·x ^ 0x04 for the low nibble x
+DATA ariaConsts<>+0x50(SB)/8, $0xbcfd5617afee4504
+DATA ariaConsts<>+0x58(SB)/8, $0xebaa0140f8b91253
+// Q4·(x << 4) for the high nibble x
+DATA ariaConsts<>+0x60(SB)/8, $0x68de60d6be08b600
+DATA ariaConsts<>+0x68(SB)/8, $0x3b8d3385ed5be553
+// Round key bytes 0, 4, 8, 12 in every word
+DATA ariaConsts<>+0x70(SB)/8, $0x0c0804000c080400
+DATA ariaConsts<>+0x78(SB)/8, $0x0c0804000c080400
+// Round key bytes 1, 5, 9, 13 in every word
+DATA ariaConsts<>+0x80(SB)/8, $0x0d0905010d090501
+DATA ariaConsts<>+0x88(SB)/8, $0x0d0905010d090501
+// Round key bytes 2, 6, 10, 14 in every word
+DATA ariaConsts<>+0x90(SB)/8, $0x0e0a06020e0a0602
+DATA ariaConsts<>+0x98(SB)/8, $0x0e0a06020e0a0602
+// Round key bytes 3, 7, 11, 15 in every word
+DATA ariaConsts<>+0xa0(SB)/8, $0x0f0b07030f0b0703
+DATA ariaConsts<>+0xa8(SB)/8, $0x0f0b07030f0b0703
+// A from register t to register t ^ 0, first byte
+DATA ariaConsts<>+0xb0(SB)/8, $0x0704040503000001
+DATA ariaConsts<>+0xb8(SB)/8, $0x0f0c0c0d0b080809
+// A from register t to register t ^ 0, second byte
+DATA ariaConsts<>+0xc0(SB)/8, $0x8005060680010202
+DATA ariaConsts<>+0xc8(SB)/8, $0x800d0e0e80090a0a
+// A from register t to register t ^ 1, first byte
+DATA ariaConsts<>+0xd0(SB)/8, $0x0404050600000102
+DATA ariaConsts<>+0xd8(SB)/8, $0x0c0c0d0e0808090a
+// A from register t to register t ^ 1, second byte
+DATA ariaConsts<>+0xe0(SB)/8, $0x0607800702038003
+DATA ariaConsts<>+0xe8(SB)/8, $0x0e0f800f0a0b800b
+// A from register t to register t ^ 2, first byte
+DATA ariaConsts<>+0xf0(SB)/8, $0x0406040500020001
+DATA ariaConsts<>+0xf8(SB)/8, $0x0c0e0c0d080a0809
+// A from register t to register t ^ 2, second byte
+DATA ariaConsts<>+0x100(SB)/8, $0x0580070701800303
+DATA ariaConsts<>+0x108(SB)/8, $0x0d800f0f09800b0b
+// A from register t to register t ^ 3, first byte
+DATA ariaConsts<>+0x110(SB)/8, $0x0505060401010200
+DATA ariaConsts<>+0x118(SB)/8, $0x0d0d0e0c09090a08
+// A from register t to register t ^ 3, second byte
+DATA ariaConsts<>+0x120(SB)/8, $0x0607078002030380
+DATA ariaConsts<>+0x128(SB)/8, $0x0e0f0f800a0b0b80
+GLOBL ariaConsts<>(SB), RODATA|NOPTR, $304
+
+// The state of four blocks is in V0 to V3: lane 4b+i of Vt is byte 4i+t of
+// block b, so that every register goes through a single S-box.
+
+// ARIAKEY sets V0 to V3 to a0 to a3 xored with the next round key at R0.
+#define ARIAKEY(a0, a1, a2, a3) \
+	VLD1.P 16(R0), [V8.B16]; \
+	VTBL V17.B16, [V8.B16], V9.B16; \
+	VEOR V9.B16, a0, V0.B16; \
+	VTBL V18.B16, [V8.B16], V9.B16; \
+	VEOR V9.B16, a1, V1.B16; \
+	VTBL V19.B16, [V8.B16], V9.B16; \
+	VEOR V9.B16, a2, V2.B16; \
+	VTBL V20.B16, [V8.B16], V9.B16; \
+	VEOR V9.B16, a3, V3.B16
+
+// SENC is the AES S-box: AESE with a zero key, after InvShiftRows.
+#define SENC(x) \
+	VTBL V11.B16, [x], x; \
+	AESE V29.B16, x
+
+// SDEC is the inverse AES S-box.
+#define SDEC(x) \
+	VTBL V12.B16, [x], x; \
+	AESD V29.B16, x
+
+// AFFINE applies the bit matrix with the nibble tables lo and hi to x.
+#define AFFINE(x, lo, hi) \
+	VUSHR $4, x, V8.B16; \
+	VAND V10.B16, x, x; \
+	VTBL x, [lo], x; \
+	VTBL V8.B16, [hi], V8.B16; \
+	VEOR V8.B16, x, x
+
+// SL1 is the substitution layer of odd rounds: SB1, SB2, SB3 and SB4.
+#define SL1 \
+	SENC(V0.B16); \
+	SENC(V1.B16); \
+	AFFINE(V1.B16, V13.B16, V14.B16); \
+	SDEC(V2.B16); \
+	AFFINE(V3.B16, V15.B16, V16.B16); \
+	SDEC(V3.B16)
+
+// SL2 is the substitution layer of even rounds: SB3, SB4, SB1 and SB2.
+#define SL2 \
+	SDEC(V0.B16); \
+	AFFINE(V1.B16, V15.B16, V16.B16); \
+	SDEC(V1.B16); \
+	SENC(V2.B16); \
+	SENC(V3.B16); \
+	AFFINE(V3.B16, V13.B16, V14.B16)
+
+// DIFFUSE is the diffusion layer A from V0 to V3 into V4 to V7. Every output
+// byte is the sum of seven input bytes, two or one from each register.
+#define DIFFUSE \
+	VTBL V21.B16, [V0.B16], V4.B16 \
+	VTBL V22.B16, [V0.B16], V8.B16 \
+	VEOR V8.B16, V4.B16, V4.B16 \
+	VTBL V23.B16, [V1.B16], V8.B16 \
+	VEOR V8.B16, V4.B16, V4.B16 \
+	VTBL V24.B16, [V1.B16], V8.B16 \
+	VEOR V8.B16, V4.B16, V4.B16 \
+	VTBL V25.B16, [V2.B16], V8.B16 \
+	VEOR V8.B16, V4.B16, V4.B16 \
+	VTBL V26.B16, [V2.B16], V8.B16 \
+	VEOR V8.B16, V4.B16, V4.B16 \
+	VTBL V27.B16, [V3.B16], V8.B16 \
+	VEOR V8.B16, V4.B16, V4.B16 \
+	VTBL V28.B16, [V3.B16], V8.B16 \
+	VEOR V8.B16, V4.B16, V4.B16 \
+	VTBL V23.B16, [V0.B16], V5.B16 \
+	VTBL V24.B16, [V0.B16], V8.B16 \
+	VEOR V8.B16, V5.B16, V5.B16 \
+	VTBL V21.B16, [V1.B16], V8.B16 \
+	VEOR V8.B16, V5.B16, V5.B16 \
+	VTBL V22.B16, [V1.B16], V8.B16 \
+	VEOR V8.B16, V5.B16, V5.B16 \
+	VTBL V27.B16, [V2.B16], V8.B16 \
+	VEOR V8.B16, V5.B16, V5.B16 \
+	VTBL V28.B16, [V2.B16], V8.B16 \
+	VEOR V8.B16, V5.B16, V5.B16 \
+	VTBL V25.B16, [V3.B16], V8.B16 \
+	VEOR V8.B16, V5.B16, V5.B16 \
+	VTBL V26.B16, [V3.B16], V8.B16 \
+	VEOR V8.B16, V5.B16, V5.B16 \
+	VTBL V25.B16, [V0.B16], V6.B16 \
+	VTBL V26.B16, [V0.B16], V8.B16 \
+	VEOR V8.B16, V6.B16, V6.B16 \
+	VTBL V27.B16, [V1.B16], V8.B16 \
+	VEOR V8.B16, V6.B16, V6.B16 \
+	VTBL V28.B16, [V1.B16], V8.B16 \
+	VEOR V8.B16, V6.B16, V6.B16 \
+	VTBL V21.B16, [V2.B16], V8.B16 \
+	VEOR V8.B16, V6.B16, V6.B16 \
+	VTBL V22.B16, [V2.B16], V8.B16 \
+	VEOR V8.B16, V6.B16, V6.B16 \
+	VTBL V23.B16, [V3.B16], V8.B16 \
+	VEOR V8.B16, V6.B16, V6.B16 \
+	VTBL V24.B16, [V3.B16], V8.B16 \
+	VEOR V8.B16, V6.B16, V6.B16 \
+	VTBL V27.B16, [V0.B16], V7.B16 \
+	VTBL V28.B16, [V0.B16], V8.B16 \
+	VEOR V8.B16, V7.B16, V7.B16 \
+	VTBL V25.B16, [V1.B16], V8.B16 \
+	VEOR V8.B16, V7.B16, V7.B16 \
+	VTBL V26.B16, [V1.B16], V8.B16 \
+	VEOR V8.B16, V7.B16, V7.B16 \
+	VTBL V23.B16, [V2.B16], V8.B16 \
+	VEOR V8.B16, V7.B16, V7.B16 \
+	VTBL V24.B16, [V2.B16], V8.B16 \
+	VEOR V8.B16, V7.B16, V7.B16 \
+	VTBL V21.B16, [V3.B16], V8.B16 \
+	VEOR V8.B16, V7.B16, V7.B16 \
+	VTBL V22.B16, [V3.B16], V8.B16 \
+	VEOR V8.B16, V7.B16, V7.B16
+
+// func ariaCrypt4HW(rk *Block, rounds int, dst, src *Block4)
+TEXT ·ariaCrypt4HW(SB),NOSPLIT,$0
+	MOVD rk+0(FP), R0
+	MOVD rounds+8(FP), R5
+	MOVD dst+16(FP), R1
+	MOVD src+24(FP), R2
+
+	// Vt holds the bytes of the four blocks at the positions t mod 4.
+	VLD4 (R2), [V0.B16, V1.B16, V2.B16, V3.B16]
+
+	MOVD $ariaConsts<>(SB), R4
+	VLD1.P 64(R4), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VLD1.P 64(R4), [V14.B16, V15.B16, V16.B16, V17.B16]
+	VLD1.P 64(R4), [V18.B16, V19.B16, V20.B16, V21.B16]
+	VLD1.P 64(R4), [V22.B16, V23.B16, V24.B16, V25.B16]
+	VLD1 (R4), [V26.B16, V27.B16, V28.B16]
+	VEOR V29.B16, V29.B16, V29.B16
+
+	ARIAKEY(V0.B16, V1.B16, V2.B16, V3.B16)
+
+	// rounds-2 rounds in pairs, then an odd round and the last round.
+	SUB $2, R5
+	LSR $1, R5
+
+loop:
+	SL1
+	DIFFUSE
+	ARIAKEY(V4.B16, V5.B16, V6.B16, V7.B16)
+	SL2
+	DIFFUSE
+	ARIAKEY(V4.B16, V5.B16, V6.B16, V7.B16)
+	SUBS $1, R5
+	BNE loop
+
+	SL1
+	DIFFUSE
+	ARIAKEY(V4.B16, V5.B16, V6.B16, V7.B16)
+	SL2
+	ARIAKEY(V0.B16, V1.B16, V2.B16, V3.B16)
+
+	VST4 [V0.B16, V1.B16, V2.B16, V3.B16], (R1)
+	RET
+
+// ariaCrypt8HW keeps eight blocks in two sets of registers: V0 to V3 and V4
+// to V7, with the DIFFUSE outputs in V8 to V11 and V12 to V15. This leaves no
+// room for all the tables, so V21 to V28 hold the S-box and round key tables
+// outside of the diffusion layer, and the tables of A during it.
+
+// ARIAKEY8 sets V0 to V7 to a0 to a3 and b0 to b3 xored with the next round
+// key at R0.
+#define ARIAKEY8(a0, a1, a2, a3, b0, b1, b2, b3) \
+	VLD1.P 16(R0), [V30.B16]; \
+	VTBL V25.B16, [V30.B16], V16.B16; \
+	VEOR V16.B16, a0, V0.B16; \
+	VEOR V16.B16, b0, V4.B16; \
+	VTBL V26.B16, [V30.B16], V16.B16; \
+	VEOR V16.B16, a1, V1.B16; \
+	VEOR V16.B16, b1, V5.B16; \
+	VTBL V27.B16, [V30.B16], V16.B16; \
+	VEOR V16.B16, a2, V2.B16; \
+	VEOR V16.B16, b2, V6.B16; \
+	VTBL V28.B16, [V30.B16], V16.B16; \
+	VEOR V16.B16, a3, V3.B16; \
+	VEOR V16.B16, b3, V7.B16
+
+// SENC8 and SDEC8 are SENC and SDEC with the tables in V19 and V20.
+#define SENC8(x) \
+	VTBL V19.B16, [x], x; \
+	AESE V29.B16, x
+
+#define SDEC8(x) \
+	VTBL V20.B16, [x], x; \
+	AESD V29.B16, x
+
+// AFFINE8 is AFFINE with the mask in V18 and the temporary register t.
+#define AFFINE8(x, lo, hi, t) \
+	VUSHR $4, x, t; \
+	VAND V18.B16, x, x; \
+	VTBL x, [lo], x; \
+	VTBL t, [hi], t; \
+	VEOR t, x, x
+
+// SLODD8 and SLEVEN8 are SL1 and SL2 on both sets.
+#define SLODD8 \
+	SENC8(V0.B16); \
+	SENC8(V4.B16); \
+	SENC8(V1.B16); \
+	SENC8(V5.B16); \
+	AFFINE8(V1.B16, V21.B16, V22.B16, V16.B16); \
+	AFFINE8(V5.B16, V21.B16, V22.B16, V17.B16); \
+	SDEC8(V2.B16); \
+	SDEC8(V6.B16); \
+	AFFINE8(V3.B16, V23.B16, V24.B16, V16.B16); \
+	AFFINE8(V7.B16, V23.B16, V24.B16, V17.B16); \
+	SDEC8(V3.B16); \
+	SDEC8(V7.B16)
+
+#define SLEVEN8 \
+	SDEC8(V0.B16); \
+	SDEC8(V4.B16); \
+	AFFINE8(V1.B16, V23.B16, V24.B16, V16.B16); \
+	AFFINE8(V5.B16, V23.B16, V24.B16, V17.B16); \
+	SDEC8(V1.B16); \
+	SDEC8(V5.B16); \
+	SENC8(V2.B16); \
+	SENC8(V6.B16); \
+	SENC8(V3.B16); \
+	SENC8(V7.B16); \
+	AFFINE8(V3.B16, V21.B16, V22.B16, V16.B16); \
+	AFFINE8(V7.B16, V21.B16, V22.B16, V17.B16)
+
+// DIFFUSE4 is DIFFUSE from x0 to x3 into y0 to y3 with the temporary
+// register t.
+#define DIFFUSE4(x0, x1, x2, x3, y0, y1, y2, y3, t) \
+	VTBL V21.B16, [x0], y0 \
+	VTBL V22.B16, [x0], t \
+	VEOR t, y0, y0 \
+	VTBL V23.B16, [x1], t \
+	VEOR t, y0, y0 \
+	VTBL V24.B16, [x1], t \
+	VEOR t, y0, y0 \
+	VTBL V25.B16, [x2], t \
+	VEOR t, y0, y0 \
+	VTBL V26.B16, [x2], t \
+	VEOR t, y0, y0 \
+	VTBL V27.B16, [x3], t \
+	VEOR t, y0, y0 \
+	VTBL V28.B16, [x3], t \
+	VEOR t, y0, y0 \
+	VTBL V23.B16, [x0], y1 \
+	VTBL V24.B16, [x0], t \
+	VEOR t, y1, y1 \
+	VTBL V21.B16, [x1], t \
+	VEOR t, y1, y1 \
+	VTBL V22.B16, [x1], t \
+	VEOR t, y1, y1 \
+	VTBL V27.B16, [x2], t \
+	VEOR t, y1, y1 \
+	VTBL V28.B16, [x2], t \
+	VEOR t, y1, y1 \
+	VTBL V25.B16, [x3], t \
+	VEOR t, y1, y1 \
+	VTBL V26.B16, [x3], t \
+	VEOR t, y1, y1 \
+	VTBL V25.B16, [x0], y2 \
+	VTBL V26.B16, [x0], t \
+	VEOR t, y2, y2 \
+	VTBL V27.B16, [x1], t \
+	VEOR t, y2, y2 \
+	VTBL V28.B16, [x1], t \
+	VEOR t, y2, y2 \
+	VTBL V21.B16, [x2], t \
+	VEOR t, y2, y2 \
+	VTBL V22.B16, [x2], t \
+	VEOR t, y2, y2 \
+	VTBL V23.B16, [x3], t \
+	VEOR t, y2, y2 \
+	VTBL V24.B16, [x3], t \
+	VEOR t, y2, y2 \
+	VTBL V27.B16, [x0], y3 \
+	VTBL V28.B16, [x0], t \
+	VEOR t, y3, y3 \
+	VTBL V25.B16, [x1], t \
+	VEOR t, y3, y3 \
+	VTBL V26.B16, [x1], t \
+	VEOR t, y3, y3 \
+	VTBL V23.B16, [x2], t \
+	VEOR t, y3, y3 \
+	VTBL V24.B16, [x2], t \
+	VEOR t, y3, y3 \
+	VTBL V21.B16, [x3], t \
+	VEOR t, y3, y3 \
+	VTBL V22.B16, [x3], t \
+	VEOR t, y3, y3
+
+// DIFFUSE8 loads the tables of A, runs DIFFUSE4 on both sets, then restores
+// the S-box and round key tables.
+#define DIFFUSE8 \
+	VLD1 (R7), [V21.B16, V22.B16, V23.B16, V24.B16]; \
+	VLD1 (R8), [V25.B16, V26.B16, V27.B16, V28.B16]; \
+	DIFFUSE4(V0.B16, V1.B16, V2.B16, V3.B16, V8.B16, V9.B16, V10.B16, V11.B16, V16.B16); \
+	DIFFUSE4(V4.B16, V5.B16, V6.B16, V7.B16, V12.B16, V13.B16, V14.B16, V15.B16, V17.B16); \
+	VLD1 (R4), [V21.B16, V22.B16, V23.B16, V24.B16]; \
+	VLD1 (R6), [V25.B16, V26.B16, V27.B16, V28.B16]
+
+// func ariaCrypt8HW(rk *Block, rounds int, dst, src *[8 * ARIABlockSize]byte)
+TEXT ·ariaCrypt8HW(SB),NOSPLIT,$0
+	MOVD rk+0(FP), R0
+	MOVD rounds+8(FP), R5
+	MOVD dst+16(FP), R1
+	MOVD src+24(FP), R2
+
+	VLD4.P 64(R2), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VLD4 (R2), [V4.B16, V5.B16, V6.B16, V7.B16]
+
+	MOVD $ariaConsts<>(SB), R4
+	VLD1.P 48(R4), [V18.B16, V19.B16, V20.B16]
+	ADD $64, R4, R6
+	ADD $128, R4, R7
+	ADD $192, R4, R8
+	VLD1 (R4), [V21.B16, V22.B16, V23.B16, V24.B16]
+	VLD1 (R6), [V25.B16, V26.B16, V27.B16, V28.B16]
+	VEOR V29.B16, V29.B16, V29.B16
+
+	ARIAKEY8(V0.B16, V1.B16, V2.B16, V3.B16, V4.B16, V5.B16, V6.B16, V7.B16)
+
+	SUB $2, R5
+	LSR $1, R5
+
+loop8:
+	SLODD8
+	DIFFUSE8
+	ARIAKEY8(V8.B16, V9.B16, V10.B16, V11.B16, V12.B16, V13.B16, V14.B16, V15.B16)
+	SLEVEN8
+	DIFFUSE8
+	ARIAKEY8(V8.B16, V9.B16, V10.B16, V11.B16, V12.B16, V13.B16, V14.B16, V15.B16)
+	SUBS $1, R5
+	BNE loop8
+
+	SLODD8
+	DIFFUSE8
+	ARIAKEY8(V8.B16, V9.B16, V10.B16, V11.B16, V12.B16, V13.B16, V14.B16, V15.B16)
+	SLEVEN8
+	ARIAKEY8(V0.B16, V1.B16, V2.B16, V3.B16, V4.B16, V5.B16, V6.B16, V7.B16)
+
+	VST4.P [V0.B16, V1.B16, V2.B16, V3.B16], 64(R1)
+	VST4 [V4.B16, V5.B16, V6.B16, V7.B16], (R1)
+	RET
@@ -0,0 +1,11 @@
+//go:build (!amd64 && !arm64) || purego
+
+package aes
+
+func ariaCrypt4(rk []Block, dst, src *Block4) {
+	ariaCrypt4Generic(rk, dst, src)
+}
+
+func ariaCrypt8(rk []Block, dst, src *[8 * ARIABlockSize]byte) {
+	ariaCrypt8Generic(rk, dst, src)
+}
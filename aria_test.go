@@ -0,0 +1,157 @@
+package aes
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"encoding/hex"
+	"testing"
+)
+
+// RFC 5794, Appendix A.
+var ariaVectors = []struct {
+	key, ct string
+}{
+	{"000102030405060708090a0b0c0d0e0f", "d718fbd6ab644c739da95f3be6451778"},
+	{"000102030405060708090a0b0c0d0e0f1011121314151617", "26449c1805dbe7aa25a468ce263a9e79"},
+	{"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", "f92bd7c79fb72e2f2b8f80c1972d24fc"},
+}
+
+func TestARIAVectors(t *testing.T) {
+	pt := mustHex(t, "00112233445566778899aabbccddeeff")
+	for _, v := range ariaVectors {
+		c, err := NewARIA(mustHex(t, v.key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var b Block
+		c.Encrypt(&b, (*Block)(pt))
+		if hex.EncodeToString(b[:]) != v.ct {
+			t.Errorf("ARIA-%d: Encrypt = %x, want %s", len(v.key)*4, b, v.ct)
+		}
+		c.Decrypt(&b, &b)
+		if !bytes.Equal(b[:], pt) {
+			t.Errorf("ARIA-%d: Decrypt = %x, want %x", len(v.key)*4, b, pt)
+		}
+	}
+}
+
+// SB2 and SB4 computed around the AES S-box match the tables.
+func TestARIASboxFromAES(t *testing.T) {
+	var p2, q4 [256]byte
+	for x := range 256 {
+		p2[x] = ariaSB2[invSbox[x]]
+		q4[x] = sbox[ariaSB4[x]]
+	}
+	for _, m := range []*[256]byte{&p2, &q4} {
+		for x := range 256 {
+			if m[x] != m[x&15]^m[x&0xf0]^m[0] {
+				t.Fatalf("map %x is not affine at %#02x", m[:16], x)
+			}
+		}
+	}
+	if p2[0] != 0x88 || q4[0] != 0x04 {
+		t.Fatalf("constants %#02x, %#02x, want 0x88, 0x04", p2[0], q4[0])
+	}
+}
+
+func TestARIABatches(t *testing.T) {
+	src := make([]byte, 13*ARIABlockSize)
+	for i := range src {
+		src[i] = byte(i*7 + 3)
+	}
+	for _, kl := range []int{16, 24, 32} {
+		key := make([]byte, kl)
+		for i := range key {
+			key[i] = byte(i * 29)
+		}
+		c, _ := NewARIA(key)
+		want := make([]byte, len(src))
+		for i := 0; i < len(src); i += ARIABlockSize {
+			c.Encrypt((*Block)(want[i:]), (*Block)(src[i:]))
+		}
+
+		forEachCPUConfig(t, func(t *testing.T) {
+			var b4 Block4
+			c.Encrypt4(&b4, (*Block4)(src))
+			if !bytes.Equal(b4[:], want[:64]) {
+				t.Fatalf("ARIA-%d: Encrypt4 = %x, want %x", kl*8, b4, want[:64])
+			}
+			c.Decrypt4(&b4, &b4)
+			if !bytes.Equal(b4[:], src[:64]) {
+				t.Fatalf("ARIA-%d: Decrypt4 does not invert Encrypt4", kl*8)
+			}
+
+			var b8 [8 * ARIABlockSize]byte
+			c.Encrypt8(&b8, (*[8 * ARIABlockSize]byte)(src))
+			if !bytes.Equal(b8[:], want[:128]) {
+				t.Fatalf("ARIA-%d: Encrypt8 = %x, want %x", kl*8, b8, want[:128])
+			}
+			c.Decrypt8(&b8, &b8)
+			if !bytes.Equal(b8[:], src[:128]) {
+				t.Fatalf("ARIA-%d: Decrypt8 does not invert Encrypt8", kl*8)
+			}
+
+			for n := 0; n <= len(src); n += ARIABlockSize {
+				out := make([]byte, n)
+				c.EncryptBlocks(out, src[:n])
+				if !bytes.Equal(out, want[:n]) {
+					t.Fatalf("ARIA-%d: EncryptBlocks(%d bytes) = %x, want %x", kl*8, n, out, want[:n])
+				}
+				c.DecryptBlocks(out, out)
+				if !bytes.Equal(out, src[:n]) {
+					t.Fatalf("ARIA-%d: DecryptBlocks(%d bytes) does not invert EncryptBlocks", kl*8, n)
+				}
+			}
+		})
+	}
+}
+
+func TestARIACipher(t *testing.T) {
+	for _, n := range []int{0, 15, 17, 33} {
+		if _, err := NewARIACipher(make([]byte, n)); err == nil {
+			t.Errorf("NewARIACipher accepted a %d-byte key", n)
+		}
+	}
+
+	v := ariaVectors[2]
+	block, err := NewARIACipher(mustHex(t, v.key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.BlockSize() != ARIABlockSize {
+		t.Fatalf("BlockSize() = %d", block.BlockSize())
+	}
+	out := make([]byte, 16)
+	block.Encrypt(out, mustHex(t, "00112233445566778899aabbccddeeff"))
+	if hex.EncodeToString(out) != v.ct {
+		t.Fatalf("Encrypt = %x, want %s", out, v.ct)
+	}
+
+	iv := make([]byte, ARIABlockSize)
+	msg := bytes.Repeat([]byte("aria in cbc mode"), 5)
+	ct := make([]byte, len(msg))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ct, msg)
+	pt := make([]byte, len(ct))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(pt, ct)
+	if !bytes.Equal(pt, msg) {
+		t.Fatal("CBC round trip failed")
+	}
+}
+
+func BenchmarkARIAEncrypt(b *testing.B) {
+	c, _ := NewARIA(make([]byte, 16))
+	var x Block
+	b.SetBytes(ARIABlockSize)
+	for b.Loop() {
+		c.Encrypt(&x, &x)
+	}
+}
+
+func BenchmarkARIAEncrypt8(b *testing.B) {
+	c, _ := NewARIA(make([]byte, 16))
+	var x [8 * ARIABlockSize]byte
+	b.SetBytes(int64(len(x)))
+	for b.Loop() {
+		c.Encrypt8(&x, &x)
+	}
+}
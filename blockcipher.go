@@ -0,0 +1,55 @@
+package aes
+
+import "crypto/cipher"
+
+// Helpers shared by the 128-bit block ciphers of this package.
+
+// block16 is a block cipher on 16-byte blocks.
+type block16 interface {
+	Encrypt(dst, src *Block)
+	Decrypt(dst, src *Block)
+}
+
+// cipherBlock adapts a block16 to cipher.Block, with the argument checks of
+// crypto/aes. name is the cipher name used in panic messages.
+type cipherBlock struct {
+	name string
+	b    block16
+}
+
+var _ cipher.Block = cipherBlock{}
+
+func (c cipherBlock) BlockSize() int { return 16 }
+
+func (c cipherBlock) Encrypt(dst, src []byte) {
+	c.check(dst, src)
+	c.b.Encrypt((*Block)(dst), (*Block)(src))
+}
+
+func (c cipherBlock) Decrypt(dst, src []byte) {
+	c.check(dst, src)
+	c.b.Decrypt((*Block)(dst), (*Block)(src))
+}
+
+func (c cipherBlock) check(dst, src []byte) {
+	if len(src) < 16 {
+		panic("aes: " + c.name + " input not full block")
+	}
+	if len(dst) < 16 {
+		panic("aes: " + c.name + " output not full block")
+	}
+	if inexactOverlap(dst[:16], src[:16]) {
+		panic("aes: " + c.name + " invalid buffer overlap")
+	}
+}
+
+// checkBlocks checks the arguments of an EncryptBlocks or DecryptBlocks
+// method.
+func checkBlocks(name string, dst, src []byte) {
+	if len(src)%16 != 0 {
+		panic("aes: " + name + " input not full blocks")
+	}
+	if len(dst) < len(src) {
+		panic("aes: " + name + " output smaller than input")
+	}
+}
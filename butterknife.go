@@ -95,3 +95,44 @@ func (ctx *ButterKnifeContext) Eval(input *Block) *ButterKnifeOutput {
 
 	return &output
 }
+
+// Eval4HW evaluates ButterKnife on 4 independent inputs at once.
+// The pre-fork rounds and each of the 8 branches run the 4 states through
+// the parallel multi-round functions, so VAES/ARM Crypto process all inputs
+// in the same instructions.
+func (ctx *ButterKnifeContextHW) Eval4HW(inputs *Block4, outputs *[4]ButterKnifeOutput) {
+	// Pre-fork: XOR the first subtweakey, then 6 keyed rounds and one
+	// round with a zero key (the next key is added per branch).
+	var forkStates Block4
+	var preKey4 Block4
+	for l := 0; l < 4; l++ {
+		copy(preKey4[l*16:(l+1)*16], ctx.preForkSTK[0][:])
+	}
+	XorBlock4(&forkStates, inputs, &preKey4)
+
+	var preKeys RoundKeys7
+	copy(preKeys[:6], ctx.preForkSTK[1:7])
+	Rounds7_4HW(&forkStates, &preKeys)
+
+	var branchKey4 Block4
+	var lo, hi RoundKeys4
+	for j := 0; j < 8; j++ {
+		stk := &ctx.branchSTK[j]
+		for l := 0; l < 4; l++ {
+			copy(branchKey4[l*16:(l+1)*16], stk[0][:])
+		}
+		copy(lo[:], stk[1:5])
+		copy(hi[:], stk[5:9])
+
+		var state Block4
+		XorBlock4(&state, &forkStates, &branchKey4)
+		Rounds4_4HW(&state, &lo)
+		Rounds4_4HW(&state, &hi)
+
+		// Feed-forward
+		XorBlock4(&state, &state, &forkStates)
+		for l := 0; l < 4; l++ {
+			copy(outputs[l][j][:], state[l*16:(l+1)*16])
+		}
+	}
+}
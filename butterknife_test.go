@@ -1,7 +1,9 @@
 package aes
 
 import (
+	"bytes"
 	"encoding/hex"
+	"io"
 	"testing"
 )
 
@@ -341,3 +343,154 @@ func BenchmarkButterKnifeContextHWEval(b *testing.B) {
 		ctx.EvalHW(&input)
 	}
 }
+
+// TestButterKnifeEval4HW checks the 4-input evaluation against single-input evaluation
+func TestButterKnifeEval4HW(t *testing.T) {
+	var tweakey Tweakey256
+	for i := 0; i < 32; i++ {
+		tweakey[i] = byte(i * 3)
+	}
+	ctx := NewButterKnifeContextHW(&tweakey)
+
+	var inputs Block4
+	for i := range inputs {
+		inputs[i] = byte(i * 7)
+	}
+
+	var outputs [4]ButterKnifeOutput
+	ctx.Eval4HW(&inputs, &outputs)
+
+	for l := 0; l < 4; l++ {
+		expected := ButterKnife(&tweakey, inputs.GetBlock(l))
+		if outputs[l] != *expected {
+			t.Errorf("Lane %d mismatch:\ngot:  %x\nwant: %x", l, outputs[l], *expected)
+		}
+	}
+}
+
+// butterKnifeCounterReference is a direct transcription of Skye's FExp counter mode
+func butterKnifeCounterReference(key *Block, info *[32]byte, length int) []byte {
+	var tweakey Tweakey256
+	copy(tweakey[0:16], key[:])
+	copy(tweakey[16:32], info[0:16])
+	var input Block
+	copy(input[:], info[16:32])
+
+	output := ButterKnife(&tweakey, &input)
+	k1, k2 := output[0], output[1]
+
+	var result []byte
+	for _, b := range output {
+		result = append(result, b[:]...)
+	}
+	copy(tweakey[16:32], k1[:])
+	for counter := 0; len(result) < length; counter++ {
+		in := k2
+		in[12] ^= byte(counter >> 24)
+		in[13] ^= byte(counter >> 16)
+		in[14] ^= byte(counter >> 8)
+		in[15] ^= byte(counter)
+		for _, b := range ButterKnife(&tweakey, &in) {
+			result = append(result, b[:]...)
+		}
+	}
+	return result[:length]
+}
+
+// TestButterKnifeXOF compares the XOF against the reference counter mode
+func TestButterKnifeXOF(t *testing.T) {
+	var key Block
+	var info [32]byte
+	for i := range key {
+		key[i] = byte(i + 1)
+	}
+	for i := range info {
+		info[i] = byte(0xa0 + i)
+	}
+
+	for _, length := range []int{0, 1, 16, 127, 128, 129, 256, 640, 641, 1000, 2176} {
+		expected := butterKnifeCounterReference(&key, &info, length)
+		got := make([]byte, length)
+		x := NewButterKnifeXOF(&key, &info)
+		if _, err := io.ReadFull(x, got); err != nil {
+			t.Fatalf("length %d: %v", length, err)
+		}
+		if !bytes.Equal(got, expected) {
+			t.Errorf("length %d: output mismatch", length)
+		}
+	}
+
+	// Small, unaligned reads must produce the same stream
+	expected := butterKnifeCounterReference(&key, &info, 1500)
+	x := NewButterKnifeXOF(&key, &info)
+	var got []byte
+	buf := make([]byte, 37)
+	for len(got) < len(expected) {
+		n, err := x.Read(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, buf[:n]...)
+	}
+	if !bytes.Equal(got[:len(expected)], expected) {
+		t.Error("Chunked reads mismatch")
+	}
+}
+
+// TestButterKnifeXOFSeek tests random access into the output stream
+func TestButterKnifeXOFSeek(t *testing.T) {
+	var key Block
+	var info [32]byte
+	for i := range info {
+		info[i] = byte(i)
+	}
+
+	expected := butterKnifeCounterReference(&key, &info, 3000)
+	x := NewButterKnifeXOF(&key, &info)
+
+	for _, off := range []int64{2900, 0, 127, 128, 1000, 513, 2047} {
+		pos, err := x.Seek(off, io.SeekStart)
+		if err != nil || pos != off {
+			t.Fatalf("Seek(%d) = %d, %v", off, pos, err)
+		}
+		got := make([]byte, 64)
+		if _, err := io.ReadFull(x, got); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, expected[off:off+64]) {
+			t.Errorf("Mismatch at offset %d", off)
+		}
+	}
+
+	pos, _ := x.Seek(-100, io.SeekCurrent)
+	if pos != 2047+64-100 {
+		t.Errorf("SeekCurrent returned %d", pos)
+	}
+	if _, err := x.Seek(-1, io.SeekStart); err == nil {
+		t.Error("Seek to negative position should fail")
+	}
+
+	// Reading at the end of the stream returns io.EOF
+	if _, err := x.Seek(-10, io.SeekEnd); err != nil {
+		t.Fatal(err)
+	}
+	n, err := x.Read(make([]byte, 32))
+	if n != 10 || err != io.EOF {
+		t.Errorf("Read at end = %d, %v; want 10, EOF", n, err)
+	}
+}
+
+// BenchmarkButterKnifeXOF benchmarks bulk output generation
+func BenchmarkButterKnifeXOF(b *testing.B) {
+	var key Block
+	var info [32]byte
+	x := NewButterKnifeXOF(&key, &info)
+	buf := make([]byte, 4096)
+
+	b.SetBytes(int64(len(buf)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Seek(0, io.SeekStart)
+		x.Read(buf)
+	}
+}
@@ -0,0 +1,131 @@
+package aes
+
+import (
+	"errors"
+	"io"
+)
+
+// ButterKnifeXOF is an extendable-output function built from ButterKnife in
+// counter mode, as used by the FExp expansion step of the Skye KDF
+// (https://eprint.iacr.org/2024/781).
+//
+// Given a 128-bit key K and a 256-bit info string I = I0 || I1:
+//   - The first 128 bytes are ButterKnife(K || I0, I1) = (Y0, ..., Y7).
+//   - Subsequent 128-byte chunks are ButterKnife(K || Y0, Y1 ⊕ ⟨i⟩) for
+//     i = 0, 1, 2, ..., where ⟨i⟩ is i encoded big-endian in the last 4 bytes.
+//
+// ButterKnifeXOF implements io.Reader and io.Seeker. Counter chunks are
+// computed 4 at a time with ButterKnifeContextHW.Eval4HW.
+type ButterKnifeXOF struct {
+	first  ButterKnifeOutput
+	ctx    *ButterKnifeContextHW
+	k2     Block
+	offset int64
+
+	// buf holds 4 consecutive counter chunks starting at counter bufCounter.
+	buf        [4]ButterKnifeOutput
+	bufCounter uint64
+	bufValid   bool
+}
+
+const (
+	// butterKnifeChunkSize is the number of bytes produced by one ButterKnife call.
+	butterKnifeChunkSize = 128
+
+	// ButterKnifeXOFMaxOutput is the maximum number of bytes a ButterKnifeXOF
+	// can produce: the initial chunk plus 2^32 counter chunks.
+	ButterKnifeXOFMaxOutput = butterKnifeChunkSize + (1<<32)*butterKnifeChunkSize
+)
+
+// NewButterKnifeXOF creates a ButterKnife counter-mode XOF from a 128-bit key
+// and a 256-bit info string. The output is identical to Skye's FExp.
+func NewButterKnifeXOF(key *Block, info *[32]byte) *ButterKnifeXOF {
+	var tweakey Tweakey256
+	copy(tweakey[0:16], key[:])
+	copy(tweakey[16:32], info[0:16])
+
+	var input Block
+	copy(input[:], info[16:32])
+
+	x := &ButterKnifeXOF{}
+	x.first = *NewButterKnifeContextHW(&tweakey).EvalHW(&input)
+
+	// Counter chunks use tweakey K || Y0 and inputs Y1 ⊕ ⟨i⟩
+	copy(tweakey[16:32], x.first[0][:])
+	x.ctx = NewButterKnifeContextHW(&tweakey)
+	x.k2 = x.first[1]
+
+	return x
+}
+
+// fill computes the 4 counter chunks starting at counter c into the buffer.
+func (x *ButterKnifeXOF) fill(c uint64) {
+	var inputs Block4
+	for l := 0; l < 4; l++ {
+		in := x.k2
+		ctr := uint32(c + uint64(l))
+		in[12] ^= byte(ctr >> 24)
+		in[13] ^= byte(ctr >> 16)
+		in[14] ^= byte(ctr >> 8)
+		in[15] ^= byte(ctr)
+		copy(inputs[l*16:(l+1)*16], in[:])
+	}
+	x.ctx.Eval4HW(&inputs, &x.buf)
+	x.bufCounter = c
+	x.bufValid = true
+}
+
+// chunk returns the 128-byte output chunk with index n (0 is the initial chunk).
+func (x *ButterKnifeXOF) chunk(n uint64) *ButterKnifeOutput {
+	if n == 0 {
+		return &x.first
+	}
+	c := n - 1
+	base := c &^ 3
+	if !x.bufValid || x.bufCounter != base {
+		x.fill(base)
+	}
+	return &x.buf[c-base]
+}
+
+// Read fills p with the next bytes of output. It returns io.EOF once
+// ButterKnifeXOFMaxOutput bytes have been produced.
+func (x *ButterKnifeXOF) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if x.offset >= ButterKnifeXOFMaxOutput {
+			return n, io.EOF
+		}
+		out := x.chunk(uint64(x.offset) / butterKnifeChunkSize)
+		pos := int(x.offset % butterKnifeChunkSize)
+		for pos < butterKnifeChunkSize && n < len(p) {
+			m := copy(p[n:], out[pos/16][pos%16:])
+			pos += m
+			n += m
+			x.offset += int64(m)
+		}
+	}
+	return n, nil
+}
+
+// Seek sets the output offset for the next Read, interpreted according to
+// whence (io.SeekStart, io.SeekCurrent or io.SeekEnd, where the end is
+// ButterKnifeXOFMaxOutput). It returns the new absolute offset.
+func (x *ButterKnifeXOF) Seek(offset int64, whence int) (int64, error) {
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = x.offset + offset
+	case io.SeekEnd:
+		abs = ButterKnifeXOFMaxOutput + offset
+	default:
+		return 0, errors.New("aes: ButterKnifeXOF seek: invalid whence")
+	}
+	if abs < 0 {
+		return 0, errors.New("aes: ButterKnifeXOF seek: negative position")
+	}
+	x.offset = abs
+	return abs, nil
+}
@@ -0,0 +1,347 @@
+package aes
+
+import (
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+	"slices"
+)
+
+// Camellia (RFC 3713) is a 128-bit block cipher with a 128, 192 or 256-bit
+// key. It is a Feistel network of 18 or 24 rounds on two 64-bit halves, with
+// the FL and FL⁻¹ functions applied to the halves after every six rounds.
+// The round function F adds a round key, applies four S-boxes s1 to s4 to
+// the eight bytes and mixes them with a linear map P.
+//
+// The S-boxes are all derived from s1, which is an inversion in GF(2^8)
+// between two affine maps, like the AES S-box, so
+//
+//	s1(x) = Mout·SubBytes(Min·x ⊕ 0x1d) ⊕ 0x78
+//	s2(x) = s1(x) <<< 1
+//	s3(x) = s1(x) <<< 7
+//	s4(x) = s1(x <<< 1)
+//
+// for two 8x8 bit matrices. The batch functions use this to run eight blocks
+// per call on the AES final round instructions, with every byte position of
+// the eight blocks in one half of a vector register. Single blocks use
+// tables.
+
+const (
+	// CamelliaBlockSize is the Camellia block size in bytes.
+	CamelliaBlockSize = 16
+
+	// kw1, kw2, 24 round keys, 3 pairs of FL keys, kw3, kw4.
+	camelliaMaxKeys = 2 + 24 + 6 + 2
+)
+
+var camelliaS1 = [256]byte{
+	0x70, 0x82, 0x2c, 0xec, 0xb3, 0x27, 0xc0, 0xe5, 0xe4, 0x85, 0x57, 0x35, 0xea, 0x0c, 0xae, 0x41,
+	0x23, 0xef, 0x6b, 0x93, 0x45, 0x19, 0xa5, 0x21, 0xed, 0x0e, 0x4f, 0x4e, 0x1d, 0x65, 0x92, 0xbd,
+	0x86, 0xb8, 0xaf, 0x8f, 0x7c, 0xeb, 0x1f, 0xce, 0x3e, 0x30, 0xdc, 0x5f, 0x5e, 0xc5, 0x0b, 0x1a,
+	0xa6, 0xe1, 0x39, 0xca, 0xd5, 0x47, 0x5d, 0x3d, 0xd9, 0x01, 0x5a, 0xd6, 0x51, 0x56, 0x6c, 0x4d,
+	0x8b, 0x0d, 0x9a, 0x66, 0xfb, 0xcc, 0xb0, 0x2d, 0x74, 0x12, 0x2b, 0x20, 0xf0, 0xb1, 0x84, 0x99,
+	0xdf, 0x4c, 0xcb, 0xc2, 0x34, 0x7e, 0x76, 0x05, 0x6d, 0xb7, 0xa9, 0x31, 0xd1, 0x17, 0x04, 0xd7,
+	0x14, 0x58, 0x3a, 0x61, 0xde, 0x1b, 0x11, 0x1c, 0x32, 0x0f, 0x9c, 0x16, 0x53, 0x18, 0xf2, 0x22,
+	0xfe, 0x44, 0xcf, 0xb2, 0xc3, 0xb5, 0x7a, 0x91, 0x24, 0x08, 0xe8, 0xa8, 0x60, 0xfc, 0x69, 0x50,
+	0xaa, 0xd0, 0xa0, 0x7d, 0xa1, 0x89, 0x62, 0x97, 0x54, 0x5b, 0x1e, 0x95, 0xe0, 0xff, 0x64, 0xd2,
+	0x10, 0xc4, 0x00, 0x48, 0xa3, 0xf7, 0x75, 0xdb, 0x8a, 0x03, 0xe6, 0xda, 0x09, 0x3f, 0xdd, 0x94,
+	0x87, 0x5c, 0x83, 0x02, 0xcd, 0x4a, 0x90, 0x33, 0x73, 0x67, 0xf6, 0xf3, 0x9d, 0x7f, 0xbf, 0xe2,
+	0x52, 0x9b, 0xd8, 0x26, 0xc8, 0x37, 0xc6, 0x3b, 0x81, 0x96, 0x6f, 0x4b, 0x13, 0xbe, 0x63, 0x2e,
+	0xe9, 0x79, 0xa7, 0x8c, 0x9f, 0x6e, 0xbc, 0x8e, 0x29, 0xf5, 0xf9, 0xb6, 0x2f, 0xfd, 0xb4, 0x59,
+	0x78, 0x98, 0x06, 0x6a, 0xe7, 0x46, 0x71, 0xba, 0xd4, 0x25, 0xab, 0x42, 0x88, 0xa2, 0x8d, 0xfa,
+	0x72, 0x07, 0xb9, 0x55, 0xf8, 0xee, 0xac, 0x0a, 0x36, 0x49, 0x2a, 0x68, 0x3c, 0x38, 0xf1, 0xa4,
+	0x40, 0x28, 0xd3, 0x7b, 0xbb, 0xc9, 0x43, 0xc1, 0x15, 0xe3, 0xad, 0xf4, 0x77, 0xc7, 0x80, 0x9e,
+}
+
+// camelliaSP[i][x] is P applied to s(x) in byte i of the input of P, with
+// the S-box s of that byte: P(S(x)) is the xor of the eight lookups.
+var camelliaSP = func() (t [8][256]uint64) {
+	for x := range 256 {
+		s1 := camelliaS1[x]
+		s2 := bits.RotateLeft8(s1, 1)
+		s3 := bits.RotateLeft8(s1, 7)
+		s4 := camelliaS1[bits.RotateLeft8(byte(x), 1)]
+		for i, s := range [8]byte{s1, s2, s3, s4, s2, s3, s4, s1} {
+			var z [8]byte
+			z[i] = s
+			t[i][x] = camelliaP(z)
+		}
+	}
+	return t
+}()
+
+// Key schedule constants: hexadecimal digits of the square roots of the
+// first six primes.
+var camelliaSigma = [6]uint64{
+	0xa09e667f3bcc908b, 0xb67ae8584caa73b2, 0xc6ef372fe94f82be,
+	0x54ff53a5f1d36f1c, 0x10e527fade682d1d, 0xb05688c2b3e6c1fd,
+}
+
+// Camellia is Camellia with an expanded key. It is safe for concurrent use.
+type Camellia struct {
+	n   int // number of subkeys in enc and dec
+	enc [camelliaMaxKeys]uint64
+	dec [camelliaMaxKeys]uint64
+}
+
+// NewCamellia expands a 16, 24 or 32-byte key.
+func NewCamellia(key []byte) (*Camellia, error) {
+	var kl, kr [2]uint64
+	switch len(key) {
+	case 16, 32:
+	case 24:
+		kr[1] = ^binary.BigEndian.Uint64(key[16:])
+	default:
+		return nil, errors.New("aes: Camellia key must be 16, 24 or 32 bytes")
+	}
+	kl[0] = binary.BigEndian.Uint64(key[0:])
+	kl[1] = binary.BigEndian.Uint64(key[8:])
+	if len(key) > 16 {
+		kr[0] = binary.BigEndian.Uint64(key[16:])
+	}
+	if len(key) == 32 {
+		kr[1] = binary.BigEndian.Uint64(key[24:])
+	}
+
+	d1, d2 := kl[0]^kr[0], kl[1]^kr[1]
+	d2 ^= camelliaF(d1 ^ camelliaSigma[0])
+	d1 ^= camelliaF(d2 ^ camelliaSigma[1])
+	d1 ^= kl[0]
+	d2 ^= kl[1]
+	d2 ^= camelliaF(d1 ^ camelliaSigma[2])
+	d1 ^= camelliaF(d2 ^ camelliaSigma[3])
+	ka := [2]uint64{d1, d2}
+
+	// rot returns the two halves of x <<< n.
+	rot := func(x [2]uint64, n uint) (uint64, uint64) {
+		if n >= 64 {
+			x[0], x[1] = x[1], x[0]
+			n -= 64
+		}
+		if n == 0 {
+			return x[0], x[1]
+		}
+		return x[0]<<n | x[1]>>(64-n), x[1]<<n | x[0]>>(64-n)
+	}
+	hi := func(x [2]uint64, n uint) uint64 { h, _ := rot(x, n); return h }
+	lo := func(x [2]uint64, n uint) uint64 { _, l := rot(x, n); return l }
+
+	var kw [4]uint64
+	var k, ke []uint64
+	if len(key) == 16 {
+		kw[0], kw[1] = rot(kl, 0)
+		kw[2], kw[3] = rot(ka, 111)
+		k = make([]uint64, 18)
+		k[0], k[1] = rot(ka, 0)
+		k[2], k[3] = rot(kl, 15)
+		k[4], k[5] = rot(ka, 15)
+		k[6], k[7] = rot(kl, 45)
+		k[8], k[9] = hi(ka, 45), lo(kl, 60)
+		k[10], k[11] = rot(ka, 60)
+		k[12], k[13] = rot(kl, 94)
+		k[14], k[15] = rot(ka, 94)
+		k[16], k[17] = rot(kl, 111)
+		ke = make([]uint64, 4)
+		ke[0], ke[1] = rot(ka, 30)
+		ke[2], ke[3] = rot(kl, 77)
+	} else {
+		d1, d2 = ka[0]^kr[0], ka[1]^kr[1]
+		d2 ^= camelliaF(d1 ^ camelliaSigma[4])
+		d1 ^= camelliaF(d2 ^ camelliaSigma[5])
+		kb := [2]uint64{d1, d2}
+
+		kw[0], kw[1] = rot(kl, 0)
+		kw[2], kw[3] = rot(kb, 111)
+		k = make([]uint64, 24)
+		k[0], k[1] = rot(kb, 0)
+		k[2], k[3] = rot(kr, 15)
+		k[4], k[5] = rot(ka, 15)
+		k[6], k[7] = rot(kb, 30)
+		k[8], k[9] = rot(kl, 45)
+		k[10], k[11] = rot(ka, 45)
+		k[12], k[13] = rot(kr, 60)
+		k[14], k[15] = rot(kb, 60)
+		k[16], k[17] = rot(kl, 77)
+		k[18], k[19] = rot(kr, 94)
+		k[20], k[21] = rot(ka, 94)
+		k[22], k[23] = rot(kl, 111)
+		ke = make([]uint64, 6)
+		ke[0], ke[1] = rot(kr, 30)
+		ke[2], ke[3] = rot(kl, 60)
+		ke[4], ke[5] = rot(ka, 77)
+	}
+
+	c := &Camellia{}
+	c.n = camelliaSchedule(c.enc[:], kw, k, ke)
+
+	// Decryption swaps kw1, kw2 with kw3, kw4 and reverses k and ke.
+	kw[0], kw[1], kw[2], kw[3] = kw[2], kw[3], kw[0], kw[1]
+	slices.Reverse(k)
+	slices.Reverse(ke)
+	camelliaSchedule(c.dec[:], kw, k, ke)
+	return c, nil
+}
+
+// camelliaSchedule writes the subkeys in the order they are used to rk:
+// kw1, kw2, then for every group of six rounds the FL and FL⁻¹ keys (except
+// for the first group) and the six round keys, then kw3, kw4. It returns the
+// number of subkeys.
+func camelliaSchedule(rk []uint64, kw [4]uint64, k, ke []uint64) int {
+	rk = append(rk[:0], kw[0], kw[1])
+	for g := 0; g < len(k); g += 6 {
+		if g > 0 {
+			rk = append(rk, ke[g/3-2], ke[g/3-1])
+		}
+		rk = append(rk, k[g:g+6]...)
+	}
+	rk = append(rk, kw[2], kw[3])
+	return len(rk)
+}
+
+// BlockSize returns the block size in bytes.
+func (c *Camellia) BlockSize() int { return CamelliaBlockSize }
+
+// Encrypt encrypts src into dst. dst and src may point to the same block.
+func (c *Camellia) Encrypt(dst, src *Block) {
+	camelliaCrypt(c.enc[:c.n], dst, src)
+}
+
+// Decrypt decrypts src into dst. dst and src may point to the same block.
+func (c *Camellia) Decrypt(dst, src *Block) {
+	camelliaCrypt(c.dec[:c.n], dst, src)
+}
+
+// Encrypt4 encrypts four blocks.
+func (c *Camellia) Encrypt4(dst, src *Block4) {
+	camelliaCrypt4(c.enc[:c.n], dst, src)
+}
+
+// Decrypt4 decrypts four blocks.
+func (c *Camellia) Decrypt4(dst, src *Block4) {
+	camelliaCrypt4(c.dec[:c.n], dst, src)
+}
+
+// Encrypt8 encrypts eight blocks.
+func (c *Camellia) Encrypt8(dst, src *[8 * CamelliaBlockSize]byte) {
+	camelliaCrypt8(c.enc[:c.n], dst, src)
+}
+
+// Decrypt8 decrypts eight blocks.
+func (c *Camellia) Decrypt8(dst, src *[8 * CamelliaBlockSize]byte) {
+	camelliaCrypt8(c.dec[:c.n], dst, src)
+}
+
+// EncryptBlocks encrypts each 16-byte block of src into dst, independently
+// (ECB), eight or four blocks at a time. len(src) must be a multiple of the
+// block size and dst must be at least as long; dst and src may be the same
+// slice.
+func (c *Camellia) EncryptBlocks(dst, src []byte) {
+	camelliaBlocks(c.enc[:c.n], dst, src)
+}
+
+// DecryptBlocks decrypts each 16-byte block of src into dst, independently.
+func (c *Camellia) DecryptBlocks(dst, src []byte) {
+	camelliaBlocks(c.dec[:c.n], dst, src)
+}
+
+// NewCamelliaCipher returns Camellia with the given 16, 24 or 32-byte key as
+// a cipher.Block, for use with the modes of crypto/cipher.
+func NewCamelliaCipher(key []byte) (cipher.Block, error) {
+	c, err := NewCamellia(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipherBlock{"Camellia", c}, nil
+}
+
+func camelliaBlocks(rk []uint64, dst, src []byte) {
+	checkBlocks("Camellia", dst, src)
+	for len(src) >= 8*CamelliaBlockSize {
+		camelliaCrypt8(rk, (*[8 * CamelliaBlockSize]byte)(dst), (*[8 * CamelliaBlockSize]byte)(src))
+		dst, src = dst[8*CamelliaBlockSize:], src[8*CamelliaBlockSize:]
+	}
+	if len(src) >= 4*CamelliaBlockSize {
+		camelliaCrypt4(rk, (*Block4)(dst), (*Block4)(src))
+		dst, src = dst[4*CamelliaBlockSize:], src[4*CamelliaBlockSize:]
+	}
+	for len(src) > 0 {
+		camelliaCrypt(rk, (*Block)(dst), (*Block)(src))
+		dst, src = dst[CamelliaBlockSize:], src[CamelliaBlockSize:]
+	}
+}
+
+// camelliaCrypt runs the rounds with the subkeys rk, in the order of
+// camelliaSchedule.
+func camelliaCrypt(rk []uint64, dst, src *Block) {
+	d1 := binary.BigEndian.Uint64(src[:8]) ^ rk[0]
+	d2 := binary.BigEndian.Uint64(src[8:]) ^ rk[1]
+	groups := (len(rk) - 2) / 8
+	rk = rk[2:]
+	for g := range groups {
+		if g > 0 {
+			d1 = camelliaFL(d1, rk[0])
+			d2 = camelliaFLInv(d2, rk[1])
+			rk = rk[2:]
+		}
+		for i := 0; i < 6; i += 2 {
+			d2 ^= camelliaF(d1 ^ rk[i])
+			d1 ^= camelliaF(d2 ^ rk[i+1])
+		}
+		rk = rk[6:]
+	}
+	d2 ^= rk[0]
+	d1 ^= rk[1]
+	binary.BigEndian.PutUint64(dst[:8], d2)
+	binary.BigEndian.PutUint64(dst[8:], d1)
+}
+
+// camelliaCrypt4Generic runs four blocks one at a time.
+func camelliaCrypt4Generic(rk []uint64, dst, src *Block4) {
+	for i := 0; i < 64; i += 16 {
+		camelliaCrypt(rk, (*Block)(dst[i:]), (*Block)(src[i:]))
+	}
+}
+
+// camelliaCrypt8Generic runs eight blocks one at a time.
+func camelliaCrypt8Generic(rk []uint64, dst, src *[8 * CamelliaBlockSize]byte) {
+	for i := 0; i < 128; i += 16 {
+		camelliaCrypt(rk, (*Block)(dst[i:]), (*Block)(src[i:]))
+	}
+}
+
+// camelliaF is the round function F without the key addition: P(S(x)).
+func camelliaF(x uint64) uint64 {
+	return camelliaSP[0][x>>56] ^ camelliaSP[1][byte(x>>48)] ^
+		camelliaSP[2][byte(x>>40)] ^ camelliaSP[3][byte(x>>32)] ^
+		camelliaSP[4][byte(x>>24)] ^ camelliaSP[5][byte(x>>16)] ^
+		camelliaSP[6][byte(x>>8)] ^ camelliaSP[7][byte(x)]
+}
+
+// camelliaP is the linear map P of the round function.
+func camelliaP(z [8]byte) uint64 {
+	return binary.BigEndian.Uint64([]byte{
+		z[0] ^ z[2] ^ z[3] ^ z[5] ^ z[6] ^ z[7],
+		z[0] ^ z[1] ^ z[3] ^ z[4] ^ z[6] ^ z[7],
+		z[0] ^ z[1] ^ z[2] ^ z[4] ^ z[5] ^ z[7],
+		z[1] ^ z[2] ^ z[3] ^ z[4] ^ z[5] ^ z[6],
+		z[0] ^ z[1] ^ z[5] ^ z[6] ^ z[7],
+		z[1] ^ z[2] ^ z[4] ^ z[6] ^ z[7],
+		z[2] ^ z[3] ^ z[4] ^ z[5] ^ z[7],
+		z[0] ^ z[3] ^ z[4] ^ z[5] ^ z[6],
+	})
+}
+
+func camelliaFL(x, k uint64) uint64 {
+	x1, x2 := uint32(x>>32), uint32(x)
+	x2 ^= bits.RotateLeft32(x1&uint32(k>>32), 1)
+	x1 ^= x2 | uint32(k)
+	return uint64(x1)<<32 | uint64(x2)
+}
+
+func camelliaFLInv(y, k uint64) uint64 {
+	y1, y2 := uint32(y>>32), uint32(y)
+	y1 ^= y2 | uint32(k)
+	y2 ^= bits.RotateLeft32(y1&uint32(k>>32), 1)
+	return uint64(y1)<<32 | uint64(y2)
+}
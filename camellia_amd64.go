@@ -0,0 +1,25 @@
+//go:build amd64 && !purego
+
+package aes
+
+//go:noescape
+func camelliaCrypt4AESNI(rk *uint64, groups int, dst, src *Block4)
+
+//go:noescape
+func camelliaCrypt8AESNI(rk *uint64, groups int, dst, src *[8 * CamelliaBlockSize]byte)
+
+func camelliaCrypt4(rk []uint64, dst, src *Block4) {
+	if CPU.HasAESNI && CPU.HasAVX {
+		camelliaCrypt4AESNI(&rk[0], (len(rk)-2)/8, dst, src)
+	} else {
+		camelliaCrypt4Generic(rk, dst, src)
+	}
+}
+
+func camelliaCrypt8(rk []uint64, dst, src *[8 * CamelliaBlockSize]byte) {
+	if CPU.HasAESNI && CPU.HasAVX {
+		camelliaCrypt8AESNI(&rk[0], (len(rk)-2)/8, dst, src)
+	} else {
+		camelliaCrypt8Generic(rk, dst, src)
+	}
+}
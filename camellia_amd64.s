@@ -0,0 +1,658 @@
+// Camellia with AES-NI and AVX for AMD64
+#include "textflag.h"
+
+// Low nibble mask
+DATA camelliaConsts<>+0x00(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA camelliaConsts<>+0x08(SB)/8, $0x0f0f0f0f0f0f0f0f
+// InvShiftRows
+DATA camelliaConsts<>+0x10(SB)/8, $0x0b0e0104070a0d00
+DATA camelliaConsts<>+0x18(SB)/8, $0x0306090c0f020508
+// Min·x ^ 0x1d for the low nibble x
+DATA camelliaConsts<>+0x20(SB)/8, $0x1617f0f1fafb1c1d
+DATA camelliaConsts<>+0x28(SB)/8, $0xf9f81f1e1514f3f2
+// Min·(x << 4) for the high nibble x
+DATA camelliaConsts<>+0x30(SB)/8, $0xfc4374cb3788bf00
+DATA camelliaConsts<>+0x38(SB)/8, $0xc7784ff00cb3843b
+// Min·(x <<< 1) ^ 0x1d for the low nibble x
+DATA camelliaConsts<>+0x40(SB)/8, $0xf81e14f217f1fb1d
+DATA camelliaConsts<>+0x48(SB)/8, $0x47a1ab4da84e44a2
+// Min·((x << 4) <<< 1) for the high nibble x
+DATA camelliaConsts<>+0x50(SB)/8, $0x78f0b33b43cb8800
+DATA camelliaConsts<>+0x58(SB)/8, $0x79f1b23a42ca8901
+// Mout·x ^ 0x78 for the low nibble x
+DATA camelliaConsts<>+0x60(SB)/8, $0x1233bb9af0d15978
+DATA camelliaConsts<>+0x68(SB)/8, $0x2c0d85a4ceef6746
+// Mout·(x << 4) for the high nibble x
+DATA camelliaConsts<>+0x70(SB)/8, $0x3b9e52f7cc69a500
+DATA camelliaConsts<>+0x78(SB)/8, $0xb81dd1744fea2683
+// (Mout·x ^ 0x78) <<< 1 for the low nibble x
+DATA camelliaConsts<>+0x80(SB)/8, $0x24667735e1a3b2f0
+DATA camelliaConsts<>+0x88(SB)/8, $0x581a0b499ddfce8c
+// (Mout·(x << 4)) <<< 1 for the high nibble x
+DATA camelliaConsts<>+0x90(SB)/8, $0x763da4ef99d24b00
+DATA camelliaConsts<>+0x98(SB)/8, $0x713aa3e89ed54c07
+// (Mout·x ^ 0x78) <<< 7 for the low nibble x
+DATA camelliaConsts<>+0xa0(SB)/8, $0x0999dd4d78e8ac3c
+DATA camelliaConsts<>+0xa8(SB)/8, $0x1686c25267f7b323
+// (Mout·(x << 4)) <<< 7 for the high nibble x
+DATA camelliaConsts<>+0xb0(SB)/8, $0x9d4f29fb66b4d200
+DATA camelliaConsts<>+0xb8(SB)/8, $0x5c8ee83aa77513c1
+// Subkey bytes 0 and 7, in big-endian order, to the halves of class 0
+DATA camelliaConsts<>+0xc0(SB)/8, $0x0707070707070707
+DATA camelliaConsts<>+0xc8(SB)/8, $0x0000000000000000
+// Subkey bytes 1 and 4, in big-endian order, to the halves of class 1
+DATA camelliaConsts<>+0xd0(SB)/8, $0x0606060606060606
+DATA camelliaConsts<>+0xd8(SB)/8, $0x0303030303030303
+// Subkey bytes 2 and 5, in big-endian order, to the halves of class 2
+DATA camelliaConsts<>+0xe0(SB)/8, $0x0505050505050505
+DATA camelliaConsts<>+0xe8(SB)/8, $0x0202020202020202
+// Subkey bytes 3 and 6, in big-endian order, to the halves of class 3
+DATA camelliaConsts<>+0xf0(SB)/8, $0x0404040404040404
+DATA camelliaConsts<>+0xf8(SB)/8, $0x0101010101010101
+// Bytes of every 16-bit unit half*4+s of a block
+DATA camelliaConsts<>+0x100(SB)/8, $0x0603050204010700
+DATA camelliaConsts<>+0x108(SB)/8, $0x0e0b0d0a0c090f08
+// Inverse of the above
+DATA camelliaConsts<>+0x110(SB)/8, $0x0107050306040200
+DATA camelliaConsts<>+0x118(SB)/8, $0x090f0d0b0e0c0a08
+// Low bytes of the 16-bit lanes, then the high bytes
+DATA camelliaConsts<>+0x120(SB)/8, $0x0e0c0a0806040200
+DATA camelliaConsts<>+0x128(SB)/8, $0x0f0d0b0907050301
+// Inverse of the above
+DATA camelliaConsts<>+0x130(SB)/8, $0x0b030a0209010800
+DATA camelliaConsts<>+0x138(SB)/8, $0x0f070e060d050c04
+// Swap the halves
+DATA camelliaConsts<>+0x140(SB)/8, $0x0f0e0d0c0b0a0908
+DATA camelliaConsts<>+0x148(SB)/8, $0x0706050403020100
+// Low half
+DATA camelliaConsts<>+0x150(SB)/8, $0x0706050403020100
+DATA camelliaConsts<>+0x158(SB)/8, $0x8080808080808080
+// High half to the low half
+DATA camelliaConsts<>+0x160(SB)/8, $0x0f0e0d0c0b0a0908
+DATA camelliaConsts<>+0x168(SB)/8, $0x8080808080808080
+// High half
+DATA camelliaConsts<>+0x170(SB)/8, $0x8080808080808080
+DATA camelliaConsts<>+0x178(SB)/8, $0x0f0e0d0c0b0a0908
+// Low half to the high half
+DATA camelliaConsts<>+0x180(SB)/8, $0x8080808080808080
+DATA camelliaConsts<>+0x188(SB)/8, $0x0706050403020100
+// Low bit mask
+DATA camelliaConsts<>+0x190(SB)/8, $0x0101010101010101
+DATA camelliaConsts<>+0x198(SB)/8, $0x0101010101010101
+// Bytes of the 16-bit units of a block in the order 0, 4, 1, 5, 2, 6, 3, 7
+DATA camelliaConsts<>+0x1a0(SB)/8, $0x0c0904010f080700
+DATA camelliaConsts<>+0x1a8(SB)/8, $0x0e0b06030d0a0502
+// Inverse of the above, with the halves swapped
+DATA camelliaConsts<>+0x1b0(SB)/8, $0x030f0b070e0a0602
+DATA camelliaConsts<>+0x1b8(SB)/8, $0x010d09050c080400
+// Subkey bytes of class 0 to both halves of four blocks
+DATA camelliaConsts<>+0x1c0(SB)/8, $0x0f070f070f070f07
+DATA camelliaConsts<>+0x1c8(SB)/8, $0x0800080008000800
+// Subkey bytes of class 1 to both halves of four blocks
+DATA camelliaConsts<>+0x1d0(SB)/8, $0x0e060e060e060e06
+DATA camelliaConsts<>+0x1d8(SB)/8, $0x0b030b030b030b03
+// Subkey bytes of class 2 to both halves of four blocks
+DATA camelliaConsts<>+0x1e0(SB)/8, $0x0d050d050d050d05
+DATA camelliaConsts<>+0x1e8(SB)/8, $0x0a020a020a020a02
+// Subkey bytes of class 3 to both halves of four blocks
+DATA camelliaConsts<>+0x1f0(SB)/8, $0x0c040c040c040c04
+DATA camelliaConsts<>+0x1f8(SB)/8, $0x0901090109010901
+// Subkey bytes of class 0 to the left halves of four blocks
+DATA camelliaConsts<>+0x200(SB)/8, $0x8007800780078007
+DATA camelliaConsts<>+0x208(SB)/8, $0x8000800080008000
+// Subkey bytes of class 1 to the left halves of four blocks
+DATA camelliaConsts<>+0x210(SB)/8, $0x8006800680068006
+DATA camelliaConsts<>+0x218(SB)/8, $0x8003800380038003
+// Subkey bytes of class 2 to the left halves of four blocks
+DATA camelliaConsts<>+0x220(SB)/8, $0x8005800580058005
+DATA camelliaConsts<>+0x228(SB)/8, $0x8002800280028002
+// Subkey bytes of class 3 to the left halves of four blocks
+DATA camelliaConsts<>+0x230(SB)/8, $0x8004800480048004
+DATA camelliaConsts<>+0x238(SB)/8, $0x8001800180018001
+// Subkey bytes of class 0 to the right halves of four blocks
+DATA camelliaConsts<>+0x240(SB)/8, $0x0f800f800f800f80
+DATA camelliaConsts<>+0x248(SB)/8, $0x0880088008800880
+// Subkey bytes of class 1 to the right halves of four blocks
+DATA camelliaConsts<>+0x250(SB)/8, $0x0e800e800e800e80
+DATA camelliaConsts<>+0x258(SB)/8, $0x0b800b800b800b80
+// Subkey bytes of class 2 to the right halves of four blocks
+DATA camelliaConsts<>+0x260(SB)/8, $0x0d800d800d800d80
+DATA camelliaConsts<>+0x268(SB)/8, $0x0a800a800a800a80
+// Subkey bytes of class 3 to the right halves of four blocks
+DATA camelliaConsts<>+0x270(SB)/8, $0x0c800c800c800c80
+DATA camelliaConsts<>+0x278(SB)/8, $0x0980098009800980
+GLOBL camelliaConsts<>(SB), RODATA|NOPTR, $640
+
+// The state of eight blocks is in X0 to X7. The bytes of a 64-bit half at
+// the positions (0, 7), (1, 4), (2, 5) and (3, 6) go through the same S-box
+// up to a rotation, and are called classes 0 to 3. X0 to X3 hold the classes
+// of the left halves, X4 to X7 those of the right halves: lane 8h+b of a
+// register is the byte at the position h of its class in block b.
+
+// CAMKEY xors the subkey at off(AX) into the half in x0 to x3.
+#define CAMKEY(off, x0, x1, x2, x3) \
+	VMOVQ off(AX), X12; \
+	VPSHUFB camelliaConsts<>+0xc0(SB), X12, X13; \
+	VPXOR X13, x0, x0 \
+	VPSHUFB camelliaConsts<>+0xd0(SB), X12, X13; \
+	VPXOR X13, x1, x1 \
+	VPSHUFB camelliaConsts<>+0xe0(SB), X12, X13; \
+	VPXOR X13, x2, x2 \
+	VPSHUFB camelliaConsts<>+0xf0(SB), X12, X13; \
+	VPXOR X13, x3, x3
+
+// AFFINE applies the bit matrix with the nibble tables lo and hi to x.
+#define AFFINE(x, lo, hi) \
+	VPSRLW $4, x, X12; \
+	VPAND X14, X12, X12; \
+	VPAND X14, x, x; \
+	VMOVDQU lo, X13; \
+	VPSHUFB x, X13, x; \
+	VMOVDQU hi, X13; \
+	VPSHUFB X12, X13, X12; \
+	VPXOR X12, x, x
+
+// SBOX is post(SubBytes(pre(x))) with AESENCLAST and a zero key, after
+// InvShiftRows.
+#define SBOX(x, prelo, prehi, postlo, posthi) \
+	AFFINE(x, prelo, prehi); \
+	VPSHUFB camelliaConsts<>+0x10(SB), x, x; \
+	VAESENCLAST X15, x, x; \
+	AFFINE(x, postlo, posthi)
+
+// CAMF xors F(a, k) into b, with the round key k at off(AX). The bytes of
+// every class of P(z) are xors of whole halves of classes of z.
+#define CAMF(off, a0, a1, a2, a3, b0, b1, b2, b3) \
+	VMOVQ off(AX), X12; \
+	VPSHUFB camelliaConsts<>+0xc0(SB), X12, X8 \
+	VPXOR a0, X8, X8 \
+	VPSHUFB camelliaConsts<>+0xd0(SB), X12, X9 \
+	VPXOR a1, X9, X9 \
+	VPSHUFB camelliaConsts<>+0xe0(SB), X12, X10 \
+	VPXOR a2, X10, X10 \
+	VPSHUFB camelliaConsts<>+0xf0(SB), X12, X11 \
+	VPXOR a3, X11, X11 \
+	SBOX(X8, camelliaConsts<>+0x20(SB), camelliaConsts<>+0x30(SB), camelliaConsts<>+0x60(SB), camelliaConsts<>+0x70(SB)) \
+	SBOX(X9, camelliaConsts<>+0x20(SB), camelliaConsts<>+0x30(SB), camelliaConsts<>+0x80(SB), camelliaConsts<>+0x90(SB)) \
+	SBOX(X10, camelliaConsts<>+0x20(SB), camelliaConsts<>+0x30(SB), camelliaConsts<>+0xa0(SB), camelliaConsts<>+0xb0(SB)) \
+	SBOX(X11, camelliaConsts<>+0x40(SB), camelliaConsts<>+0x50(SB), camelliaConsts<>+0x60(SB), camelliaConsts<>+0x70(SB)) \
+	VPSHUFB camelliaConsts<>+0x140(SB), X8, X12 \
+	VPXOR X12, b0, b0 \
+	VPSHUFB camelliaConsts<>+0x150(SB), X8, X12 \
+	VPXOR X12, b0, b0 \
+	VPSHUFB camelliaConsts<>+0x170(SB), X9, X12 \
+	VPXOR X12, b0, b0 \
+	VPXOR X10, b0, b0 \
+	VPSHUFB camelliaConsts<>+0x160(SB), X10, X12 \
+	VPXOR X12, b0, b0 \
+	VPXOR X11, b0, b0 \
+	VPSHUFB camelliaConsts<>+0x140(SB), X11, X12 \
+	VPXOR X12, b0, b0 \
+	VPXOR X8, b1, b1 \
+	VPSHUFB camelliaConsts<>+0x140(SB), X8, X12 \
+	VPXOR X12, b1, b1 \
+	VPSHUFB camelliaConsts<>+0x140(SB), X9, X12 \
+	VPXOR X12, b1, b1 \
+	VPSHUFB camelliaConsts<>+0x150(SB), X9, X12 \
+	VPXOR X12, b1, b1 \
+	VPSHUFB camelliaConsts<>+0x170(SB), X10, X12 \
+	VPXOR X12, b1, b1 \
+	VPXOR X11, b1, b1 \
+	VPSHUFB camelliaConsts<>+0x160(SB), X11, X12 \
+	VPXOR X12, b1, b1 \
+	VPXOR X8, b2, b2 \
+	VPSHUFB camelliaConsts<>+0x160(SB), X8, X12 \
+	VPXOR X12, b2, b2 \
+	VPXOR X9, b2, b2 \
+	VPSHUFB camelliaConsts<>+0x140(SB), X9, X12 \
+	VPXOR X12, b2, b2 \
+	VPSHUFB camelliaConsts<>+0x140(SB), X10, X12 \
+	VPXOR X12, b2, b2 \
+	VPSHUFB camelliaConsts<>+0x150(SB), X10, X12 \
+	VPXOR X12, b2, b2 \
+	VPSHUFB camelliaConsts<>+0x170(SB), X11, X12 \
+	VPXOR X12, b2, b2 \
+	VPSHUFB camelliaConsts<>+0x170(SB), X8, X12 \
+	VPXOR X12, b3, b3 \
+	VPXOR X9, b3, b3 \
+	VPSHUFB camelliaConsts<>+0x160(SB), X9, X12 \
+	VPXOR X12, b3, b3 \
+	VPXOR X10, b3, b3 \
+	VPSHUFB camelliaConsts<>+0x140(SB), X10, X12 \
+	VPXOR X12, b3, b3 \
+	VPSHUFB camelliaConsts<>+0x140(SB), X11, X12 \
+	VPXOR X12, b3, b3 \
+	VPSHUFB camelliaConsts<>+0x150(SB), X11, X12 \
+	VPXOR X12, b3, b3
+
+// FLSTEP1 is x2 ^= (x1 & k1) <<< 1 on the half in x0 to x3, with the subkey
+// at off(AX). The rotation moves the top bit of every byte to the next
+// byte to the left, which is in the next class.
+#define FLSTEP1(off, x0, x1, x2, x3) \
+	VMOVQ off(AX), X12; \
+	VPSHUFB camelliaConsts<>+0xc0(SB), X12, X8 \
+	VPAND x0, X8, X8 \
+	VPSHUFB camelliaConsts<>+0xd0(SB), X12, X9 \
+	VPAND x1, X9, X9 \
+	VPSHUFB camelliaConsts<>+0xe0(SB), X12, X10 \
+	VPAND x2, X10, X10 \
+	VPSHUFB camelliaConsts<>+0xf0(SB), X12, X11 \
+	VPAND x3, X11, X11 \
+	VPADDB X8, X8, X12 \
+	VPSRLW $7, X9, X13 \
+	VPAND camelliaConsts<>+0x190(SB), X13, X13 \
+	VPOR X13, X12, X12 \
+	VPSHUFB camelliaConsts<>+0x180(SB), X12, X12 \
+	VPXOR X12, x1, x1 \
+	VPADDB X9, X9, X12 \
+	VPSRLW $7, X10, X13 \
+	VPAND camelliaConsts<>+0x190(SB), X13, X13 \
+	VPOR X13, X12, X12 \
+	VPSHUFB camelliaConsts<>+0x180(SB), X12, X12 \
+	VPXOR X12, x2, x2 \
+	VPADDB X10, X10, X12 \
+	VPSRLW $7, X11, X13 \
+	VPAND camelliaConsts<>+0x190(SB), X13, X13 \
+	VPOR X13, X12, X12 \
+	VPSHUFB camelliaConsts<>+0x180(SB), X12, X12 \
+	VPXOR X12, x3, x3 \
+	VPADDB X11, X11, X12 \
+	VPSRLW $7, X8, X13 \
+	VPAND camelliaConsts<>+0x190(SB), X13, X13 \
+	VPOR X13, X12, X12 \
+	VPSHUFB camelliaConsts<>+0x180(SB), X12, X12 \
+	VPXOR X12, x0, x0
+
+// FLSTEP2 is x1 ^= x2 | k2 on the half in x0 to x3, with the subkey at
+// off(AX).
+#define FLSTEP2(off, x0, x1, x2, x3) \
+	VMOVQ off(AX), X12; \
+	VPSHUFB camelliaConsts<>+0xc0(SB), X12, X8 \
+	VPOR x0, X8, X8 \
+	VPSHUFB camelliaConsts<>+0xd0(SB), X12, X9 \
+	VPOR x1, X9, X9 \
+	VPSHUFB camelliaConsts<>+0xe0(SB), X12, X10 \
+	VPOR x2, X10, X10 \
+	VPSHUFB camelliaConsts<>+0xf0(SB), X12, X11 \
+	VPOR x3, X11, X11 \
+	VPSHUFB camelliaConsts<>+0x160(SB), X9, X12 \
+	VPXOR X12, x0, x0 \
+	VPSHUFB camelliaConsts<>+0x160(SB), X10, X12 \
+	VPXOR X12, x1, x1 \
+	VPSHUFB camelliaConsts<>+0x160(SB), X11, X12 \
+	VPXOR X12, x2, x2 \
+	VPSHUFB camelliaConsts<>+0x160(SB), X8, X12 \
+	VPXOR X12, x3, x3
+
+// func camelliaCrypt8AESNI(rk *uint64, groups int, dst, src *[128]byte)
+TEXT ·camelliaCrypt8AESNI(SB),NOSPLIT,$0
+	MOVQ rk+0(FP), AX
+	MOVQ groups+8(FP), CX
+	MOVQ dst+16(FP), DI
+	MOVQ src+24(FP), SI
+
+	// Gather the bytes of every class of a block into 16-bit units, transpose
+	// the 8x8 units so that a register holds a unit of the eight blocks, and
+	// separate the two bytes of the units.
+	VMOVDQU (SI), X0
+	VMOVDQU 16(SI), X1
+	VMOVDQU 32(SI), X2
+	VMOVDQU 48(SI), X3
+	VMOVDQU 64(SI), X4
+	VMOVDQU 80(SI), X5
+	VMOVDQU 96(SI), X6
+	VMOVDQU 112(SI), X7
+	VPSHUFB camelliaConsts<>+0x100(SB), X0, X0
+	VPSHUFB camelliaConsts<>+0x100(SB), X1, X1
+	VPSHUFB camelliaConsts<>+0x100(SB), X2, X2
+	VPSHUFB camelliaConsts<>+0x100(SB), X3, X3
+	VPSHUFB camelliaConsts<>+0x100(SB), X4, X4
+	VPSHUFB camelliaConsts<>+0x100(SB), X5, X5
+	VPSHUFB camelliaConsts<>+0x100(SB), X6, X6
+	VPSHUFB camelliaConsts<>+0x100(SB), X7, X7
+	VPUNPCKLWD X1, X0, X8
+	VPUNPCKHWD X1, X0, X9
+	VPUNPCKLWD X3, X2, X10
+	VPUNPCKHWD X3, X2, X11
+	VPUNPCKLWD X5, X4, X12
+	VPUNPCKHWD X5, X4, X13
+	VPUNPCKLWD X7, X6, X14
+	VPUNPCKHWD X7, X6, X15
+	VPUNPCKLDQ X10, X8, X0
+	VPUNPCKHDQ X10, X8, X1
+	VPUNPCKLDQ X11, X9, X2
+	VPUNPCKHDQ X11, X9, X3
+	VPUNPCKLDQ X14, X12, X4
+	VPUNPCKHDQ X14, X12, X5
+	VPUNPCKLDQ X15, X13, X6
+	VPUNPCKHDQ X15, X13, X7
+	VPUNPCKLQDQ X4, X0, X8
+	VPUNPCKHQDQ X4, X0, X9
+	VPUNPCKLQDQ X5, X1, X10
+	VPUNPCKHQDQ X5, X1, X11
+	VPUNPCKLQDQ X6, X2, X12
+	VPUNPCKHQDQ X6, X2, X13
+	VPUNPCKLQDQ X7, X3, X14
+	VPUNPCKHQDQ X7, X3, X15
+	VPSHUFB camelliaConsts<>+0x120(SB), X8, X0
+	VPSHUFB camelliaConsts<>+0x120(SB), X9, X1
+	VPSHUFB camelliaConsts<>+0x120(SB), X10, X2
+	VPSHUFB camelliaConsts<>+0x120(SB), X11, X3
+	VPSHUFB camelliaConsts<>+0x120(SB), X12, X4
+	VPSHUFB camelliaConsts<>+0x120(SB), X13, X5
+	VPSHUFB camelliaConsts<>+0x120(SB), X14, X6
+	VPSHUFB camelliaConsts<>+0x120(SB), X15, X7
+
+	VMOVDQU camelliaConsts<>+0x00(SB), X14
+	VPXOR X15, X15, X15
+
+	CAMKEY(0, X0, X1, X2, X3)
+	CAMKEY(8, X4, X5, X6, X7)
+	ADDQ $16, AX
+	JMP rounds
+
+loop:
+	FLSTEP1(0, X0, X1, X2, X3)
+	FLSTEP2(0, X0, X1, X2, X3)
+	FLSTEP2(8, X4, X5, X6, X7)
+	FLSTEP1(8, X4, X5, X6, X7)
+	ADDQ $16, AX
+
+rounds:
+	CAMF(0, X0, X1, X2, X3, X4, X5, X6, X7)
+	CAMF(8, X4, X5, X6, X7, X0, X1, X2, X3)
+	CAMF(16, X0, X1, X2, X3, X4, X5, X6, X7)
+	CAMF(24, X4, X5, X6, X7, X0, X1, X2, X3)
+	CAMF(32, X0, X1, X2, X3, X4, X5, X6, X7)
+	CAMF(40, X4, X5, X6, X7, X0, X1, X2, X3)
+	ADDQ $48, AX
+	DECQ CX
+	JNZ loop
+
+	CAMKEY(0, X4, X5, X6, X7)
+	CAMKEY(8, X0, X1, X2, X3)
+
+	// The output is the right half, then the left half.
+	VPSHUFB camelliaConsts<>+0x130(SB), X4, X8
+	VPSHUFB camelliaConsts<>+0x130(SB), X5, X9
+	VPSHUFB camelliaConsts<>+0x130(SB), X6, X10
+	VPSHUFB camelliaConsts<>+0x130(SB), X7, X11
+	VPSHUFB camelliaConsts<>+0x130(SB), X0, X12
+	VPSHUFB camelliaConsts<>+0x130(SB), X1, X13
+	VPSHUFB camelliaConsts<>+0x130(SB), X2, X14
+	VPSHUFB camelliaConsts<>+0x130(SB), X3, X15
+	VPUNPCKLWD X9, X8, X0
+	VPUNPCKHWD X9, X8, X1
+	VPUNPCKLWD X11, X10, X2
+	VPUNPCKHWD X11, X10, X3
+	VPUNPCKLWD X13, X12, X4
+	VPUNPCKHWD X13, X12, X5
+	VPUNPCKLWD X15, X14, X6
+	VPUNPCKHWD X15, X14, X7
+	VPUNPCKLDQ X2, X0, X8
+	VPUNPCKHDQ X2, X0, X9
+	VPUNPCKLDQ X3, X1, X10
+	VPUNPCKHDQ X3, X1, X11
+	VPUNPCKLDQ X6, X4, X12
+	VPUNPCKHDQ X6, X4, X13
+	VPUNPCKLDQ X7, X5, X14
+	VPUNPCKHDQ X7, X5, X15
+	VPUNPCKLQDQ X12, X8, X0
+	VPUNPCKHQDQ X12, X8, X1
+	VPUNPCKLQDQ X13, X9, X2
+	VPUNPCKHQDQ X13, X9, X3
+	VPUNPCKLQDQ X14, X10, X4
+	VPUNPCKHQDQ X14, X10, X5
+	VPUNPCKLQDQ X15, X11, X6
+	VPUNPCKHQDQ X15, X11, X7
+	VPSHUFB camelliaConsts<>+0x110(SB), X0, X0
+	VPSHUFB camelliaConsts<>+0x110(SB), X1, X1
+	VPSHUFB camelliaConsts<>+0x110(SB), X2, X2
+	VPSHUFB camelliaConsts<>+0x110(SB), X3, X3
+	VPSHUFB camelliaConsts<>+0x110(SB), X4, X4
+	VPSHUFB camelliaConsts<>+0x110(SB), X5, X5
+	VPSHUFB camelliaConsts<>+0x110(SB), X6, X6
+	VPSHUFB camelliaConsts<>+0x110(SB), X7, X7
+	VMOVDQU X0, (DI)
+	VMOVDQU X1, 16(DI)
+	VMOVDQU X2, 32(DI)
+	VMOVDQU X3, 48(DI)
+	VMOVDQU X4, 64(DI)
+	VMOVDQU X5, 80(DI)
+	VMOVDQU X6, 96(DI)
+	VMOVDQU X7, 112(DI)
+	RET
+
+// The state of four blocks is in X0 to X3, with the same classes, but lane
+// 8h+p of Xs holds the byte at the position h of class s in the left half of
+// block p/2 if p is even, and in its right half if p is odd. The round
+// function runs on both halves, and the half that is not its input is
+// discarded.
+
+// CAMKEY4 xors the subkeys in X12 into the halves: bytes 0 to 7 into the
+// left halves, bytes 8 to 15 into the right halves.
+#define CAMKEY4 \
+	VPSHUFB camelliaConsts<>+0x1c0(SB), X12, X13 \
+	VPXOR X13, X0, X0 \
+	VPSHUFB camelliaConsts<>+0x1d0(SB), X12, X13 \
+	VPXOR X13, X1, X1 \
+	VPSHUFB camelliaConsts<>+0x1e0(SB), X12, X13 \
+	VPXOR X13, X2, X2 \
+	VPSHUFB camelliaConsts<>+0x1f0(SB), X12, X13 \
+	VPXOR X13, X3, X3
+
+// CAMF4 xors F(x, k) into the other half of every block, with the round key
+// k at off(AX). The two halves of a block share the 16-bit lanes, so shift
+// is VPSLLW to use F of the left halves, and VPSRLW to use F of the right
+// halves.
+#define CAMF4(off, shift) \
+	VMOVQ off(AX), X12 \
+	VPSHUFB camelliaConsts<>+0xc0(SB), X12, X8 \
+	VPXOR X0, X8, X8 \
+	VPSHUFB camelliaConsts<>+0xd0(SB), X12, X9 \
+	VPXOR X1, X9, X9 \
+	VPSHUFB camelliaConsts<>+0xe0(SB), X12, X10 \
+	VPXOR X2, X10, X10 \
+	VPSHUFB camelliaConsts<>+0xf0(SB), X12, X11 \
+	VPXOR X3, X11, X11 \
+	SBOX(X8, camelliaConsts<>+0x20(SB), camelliaConsts<>+0x30(SB), camelliaConsts<>+0x60(SB), camelliaConsts<>+0x70(SB)) \
+	SBOX(X9, camelliaConsts<>+0x20(SB), camelliaConsts<>+0x30(SB), camelliaConsts<>+0x80(SB), camelliaConsts<>+0x90(SB)) \
+	SBOX(X10, camelliaConsts<>+0x20(SB), camelliaConsts<>+0x30(SB), camelliaConsts<>+0xa0(SB), camelliaConsts<>+0xb0(SB)) \
+	SBOX(X11, camelliaConsts<>+0x40(SB), camelliaConsts<>+0x50(SB), camelliaConsts<>+0x60(SB), camelliaConsts<>+0x70(SB)) \
+	VPSHUFB camelliaConsts<>+0x140(SB), X8, X4 \
+	VPSHUFB camelliaConsts<>+0x150(SB), X8, X12 \
+	VPXOR X12, X4, X4 \
+	VPSHUFB camelliaConsts<>+0x170(SB), X9, X12 \
+	VPXOR X12, X4, X4 \
+	VPXOR X10, X4, X4 \
+	VPSHUFB camelliaConsts<>+0x160(SB), X10, X12 \
+	VPXOR X12, X4, X4 \
+	VPXOR X11, X4, X4 \
+	VPSHUFB camelliaConsts<>+0x140(SB), X11, X12 \
+	VPXOR X12, X4, X4 \
+	VMOVDQA X8, X5 \
+	VPSHUFB camelliaConsts<>+0x140(SB), X8, X12 \
+	VPXOR X12, X5, X5 \
+	VPSHUFB camelliaConsts<>+0x140(SB), X9, X12 \
+	VPXOR X12, X5, X5 \
+	VPSHUFB camelliaConsts<>+0x150(SB), X9, X12 \
+	VPXOR X12, X5, X5 \
+	VPSHUFB camelliaConsts<>+0x170(SB), X10, X12 \
+	VPXOR X12, X5, X5 \
+	VPXOR X11, X5, X5 \
+	VPSHUFB camelliaConsts<>+0x160(SB), X11, X12 \
+	VPXOR X12, X5, X5 \
+	VMOVDQA X8, X6 \
+	VPSHUFB camelliaConsts<>+0x160(SB), X8, X12 \
+	VPXOR X12, X6, X6 \
+	VPXOR X9, X6, X6 \
+	VPSHUFB camelliaConsts<>+0x140(SB), X9, X12 \
+	VPXOR X12, X6, X6 \
+	VPSHUFB camelliaConsts<>+0x140(SB), X10, X12 \
+	VPXOR X12, X6, X6 \
+	VPSHUFB camelliaConsts<>+0x150(SB), X10, X12 \
+	VPXOR X12, X6, X6 \
+	VPSHUFB camelliaConsts<>+0x170(SB), X11, X12 \
+	VPXOR X12, X6, X6 \
+	VPSHUFB camelliaConsts<>+0x170(SB), X8, X7 \
+	VPXOR X9, X7, X7 \
+	VPSHUFB camelliaConsts<>+0x160(SB), X9, X12 \
+	VPXOR X12, X7, X7 \
+	VPXOR X10, X7, X7 \
+	VPSHUFB camelliaConsts<>+0x140(SB), X10, X12 \
+	VPXOR X12, X7, X7 \
+	VPSHUFB camelliaConsts<>+0x140(SB), X11, X12 \
+	VPXOR X12, X7, X7 \
+	VPSHUFB camelliaConsts<>+0x150(SB), X11, X12 \
+	VPXOR X12, X7, X7 \
+	shift $8, X4, X4 \
+	VPXOR X4, X0, X0 \
+	shift $8, X5, X5 \
+	VPXOR X5, X1, X1 \
+	shift $8, X6, X6 \
+	VPXOR X6, X2, X2 \
+	shift $8, X7, X7 \
+	VPXOR X7, X3, X3
+
+// FL4STEP1 and FL4STEP2 are FLSTEP1 and FLSTEP2 on the halves in X0 to X3,
+// with the subkeys in X4 spread by the tables sp0 to sp3. The tables for a
+// single half give a zero subkey to the other half, which FL4STEP1 then
+// leaves unchanged.
+#define FL4STEP1(sp0, sp1, sp2, sp3) \
+	VPSHUFB sp0, X4, X8 \
+	VPAND X0, X8, X8 \
+	VPSHUFB sp1, X4, X9 \
+	VPAND X1, X9, X9 \
+	VPSHUFB sp2, X4, X10 \
+	VPAND X2, X10, X10 \
+	VPSHUFB sp3, X4, X11 \
+	VPAND X3, X11, X11 \
+	VPADDB X8, X8, X12 \
+	VPSRLW $7, X9, X13 \
+	VPAND camelliaConsts<>+0x190(SB), X13, X13 \
+	VPOR X13, X12, X12 \
+	VPSHUFB camelliaConsts<>+0x180(SB), X12, X12 \
+	VPXOR X12, X1, X1 \
+	VPADDB X9, X9, X12 \
+	VPSRLW $7, X10, X13 \
+	VPAND camelliaConsts<>+0x190(SB), X13, X13 \
+	VPOR X13, X12, X12 \
+	VPSHUFB camelliaConsts<>+0x180(SB), X12, X12 \
+	VPXOR X12, X2, X2 \
+	VPADDB X10, X10, X12 \
+	VPSRLW $7, X11, X13 \
+	VPAND camelliaConsts<>+0x190(SB), X13, X13 \
+	VPOR X13, X12, X12 \
+	VPSHUFB camelliaConsts<>+0x180(SB), X12, X12 \
+	VPXOR X12, X3, X3 \
+	VPADDB X11, X11, X12 \
+	VPSRLW $7, X8, X13 \
+	VPAND camelliaConsts<>+0x190(SB), X13, X13 \
+	VPOR X13, X12, X12 \
+	VPSHUFB camelliaConsts<>+0x180(SB), X12, X12 \
+	VPXOR X12, X0, X0
+
+#define FL4STEP2(sp0, sp1, sp2, sp3) \
+	VPSHUFB sp0, X4, X8 \
+	VPOR X0, X8, X8 \
+	VPSHUFB sp1, X4, X9 \
+	VPOR X1, X9, X9 \
+	VPSHUFB sp2, X4, X10 \
+	VPOR X2, X10, X10 \
+	VPSHUFB sp3, X4, X11 \
+	VPOR X3, X11, X11 \
+	VPSHUFB camelliaConsts<>+0x160(SB), X9, X12 \
+	VPXOR X12, X0, X0 \
+	VPSHUFB camelliaConsts<>+0x160(SB), X10, X12 \
+	VPXOR X12, X1, X1 \
+	VPSHUFB camelliaConsts<>+0x160(SB), X11, X12 \
+	VPXOR X12, X2, X2 \
+	VPSHUFB camelliaConsts<>+0x160(SB), X8, X12 \
+	VPXOR X12, X3, X3
+
+// func camelliaCrypt4AESNI(rk *uint64, groups int, dst, src *Block4)
+TEXT ·camelliaCrypt4AESNI(SB),NOSPLIT,$0
+	MOVQ rk+0(FP), AX
+	MOVQ groups+8(FP), CX
+	MOVQ dst+16(FP), DI
+	MOVQ src+24(FP), SI
+
+	// Pair the 16-bit units of the two halves in every block, transpose the
+	// 4x4 pairs, and separate the two bytes of the units.
+	VMOVDQU camelliaConsts<>+0x1a0(SB), X8
+	VMOVDQU (SI), X0
+	VMOVDQU 16(SI), X1
+	VMOVDQU 32(SI), X2
+	VMOVDQU 48(SI), X3
+	VPSHUFB X8, X0, X0
+	VPSHUFB X8, X1, X1
+	VPSHUFB X8, X2, X2
+	VPSHUFB X8, X3, X3
+	VPUNPCKLDQ X1, X0, X4
+	VPUNPCKHDQ X1, X0, X5
+	VPUNPCKLDQ X3, X2, X6
+	VPUNPCKHDQ X3, X2, X7
+	VPUNPCKLQDQ X6, X4, X0
+	VPUNPCKHQDQ X6, X4, X1
+	VPUNPCKLQDQ X7, X5, X2
+	VPUNPCKHQDQ X7, X5, X3
+	VMOVDQU camelliaConsts<>+0x120(SB), X8
+	VPSHUFB X8, X0, X0
+	VPSHUFB X8, X1, X1
+	VPSHUFB X8, X2, X2
+	VPSHUFB X8, X3, X3
+
+	VMOVDQU camelliaConsts<>+0x00(SB), X14
+	VPXOR X15, X15, X15
+
+	VMOVDQU (AX), X12
+	CAMKEY4
+	ADDQ $16, AX
+	JMP rounds
+
+loop:
+	// FL on the left halves, FL⁻¹ on the right halves.
+	VMOVDQU (AX), X4
+	FL4STEP1(camelliaConsts<>+0x200(SB), camelliaConsts<>+0x210(SB), camelliaConsts<>+0x220(SB), camelliaConsts<>+0x230(SB))
+	FL4STEP2(camelliaConsts<>+0x1c0(SB), camelliaConsts<>+0x1d0(SB), camelliaConsts<>+0x1e0(SB), camelliaConsts<>+0x1f0(SB))
+	FL4STEP1(camelliaConsts<>+0x240(SB), camelliaConsts<>+0x250(SB), camelliaConsts<>+0x260(SB), camelliaConsts<>+0x270(SB))
+	ADDQ $16, AX
+
+rounds:
+	CAMF4(0, VPSLLW)
+	CAMF4(8, VPSRLW)
+	CAMF4(16, VPSLLW)
+	CAMF4(24, VPSRLW)
+	CAMF4(32, VPSLLW)
+	CAMF4(40, VPSRLW)
+	ADDQ $48, AX
+	DECQ CX
+	JNZ loop
+
+	// The last subkeys go to the right halves, then the left halves.
+	VPSHUFD $0x4e, (AX), X12
+	CAMKEY4
+
+	VMOVDQU camelliaConsts<>+0x130(SB), X8
+	VPSHUFB X8, X0, X0
+	VPSHUFB X8, X1, X1
+	VPSHUFB X8, X2, X2
+	VPSHUFB X8, X3, X3
+	VPUNPCKLDQ X1, X0, X4
+	VPUNPCKHDQ X1, X0, X5
+	VPUNPCKLDQ X3, X2, X6
+	VPUNPCKHDQ X3, X2, X7
+	VPUNPCKLQDQ X6, X4, X0
+	VPUNPCKHQDQ X6, X4, X1
+	VPUNPCKLQDQ X7, X5, X2
+	VPUNPCKHQDQ X7, X5, X3
+	VMOVDQU camelliaConsts<>+0x1b0(SB), X8
+	VPSHUFB X8, X0, X0
+	VPSHUFB X8, X1, X1
+	VPSHUFB X8, X2, X2
+	VPSHUFB X8, X3, X3
+	VMOVDQU X0, (DI)
+	VMOVDQU X1, 16(DI)
+	VMOVDQU X2, 32(DI)
+	VMOVDQU X3, 48(DI)
+	RET
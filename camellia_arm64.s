@@ -0,0 +1,636 @@
+// Camellia with ARM Crypto for ARM64
+#include "textflag.h"
+
+// Low nibble mask
+DATA camelliaConsts<>+0x00(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA camelliaConsts<>+0x08(SB)/8, $0x0f0f0f0f0f0f0f0f
+// InvShiftRows
+DATA camelliaConsts<>+0x10(SB)/8, $0x0b0e0104070a0d00
+DATA camelliaConsts<>+0x18(SB)/8, $0x0306090c0f020508
+// Min·x ^ 0x1d for the low nibble x
+DATA camelliaConsts<>+0x20(SB)/8, $0x1617f0f1fafb1c1d
+DATA camelliaConsts<>+0x28(SB)/8, $0xf9f81f1e1514f3f2
+// Min·(x << 4) for the high nibble x
+DATA camelliaConsts<>+0x30(SB)/8, $0xfc4374cb3788bf00
+DATA camelliaConsts<>+0x38(SB)/8, $0xc7784ff00cb3843b
+// Min·(x <<< 1) ^ 0x1d for the low nibble x
+DATA camelliaConsts<>+0x40(SB)/8, $0xf81e14f217f1fb1d
+DATA camelliaConsts<>+0x48(SB)/8, $0x47a1ab4da84e44a2
+// Min·((x << 4) <<< 1) for the high nibble x
+DATA camelliaConsts<>+0x50(SB)/8, $0x78f0b33b43cb8800
+DATA camelliaConsts<>+0x58(SB)/8, $0x79f1b23a42ca8901
+// Mout·x ^ 0x78 for the low nibble x
+DATA camelliaConsts<>+0x60(SB)/8, $0x1233bb9af0d15978
+DATA camelliaConsts<>+0x68(SB)/8, $0x2c0d85a4ceef6746
+// Mout·(x << 4) for the high nibble x
+DATA camelliaConsts<>+0x70(SB)/8, $0x3b9e52f7cc69a500
+DATA camelliaConsts<>+0x78(SB)/8, $0xb81dd1744fea2683
+// (Mout·x ^ 0x78) <<< 1 for the low nibble x
+DATA camelliaConsts<>+0x80(SB)/8, $0x24667735e1a3b2f0
+DATA camelliaConsts<>+0x88(SB)/8, $0x581a0b499ddfce8c
+// (Mout·(x << 4)) <<< 1 for the high nibble x
+DATA camelliaConsts<>+0x90(SB)/8, $0x763da4ef99d24b00
+DATA camelliaConsts<>+0x98(SB)/8, $0x713aa3e89ed54c07
+// (Mout·x ^ 0x78) <<< 7 for the low nibble x
+DATA camelliaConsts<>+0xa0(SB)/8, $0x0999dd4d78e8ac3c
+DATA camelliaConsts<>+0xa8(SB)/8, $0x1686c25267f7b323
+// (Mout·(x << 4)) <<< 7 for the high nibble x
+DATA camelliaConsts<>+0xb0(SB)/8, $0x9d4f29fb66b4d200
+DATA camelliaConsts<>+0xb8(SB)/8, $0x5c8ee83aa77513c1
+// Subkey bytes 0 and 7, in big-endian order, to the halves of class 0
+DATA camelliaConsts<>+0xc0(SB)/8, $0x0707070707070707
+DATA camelliaConsts<>+0xc8(SB)/8, $0x0000000000000000
+// Subkey bytes 1 and 4, in big-endian order, to the halves of class 1
+DATA camelliaConsts<>+0xd0(SB)/8, $0x0606060606060606
+DATA camelliaConsts<>+0xd8(SB)/8, $0x0303030303030303
+// Subkey bytes 2 and 5, in big-endian order, to the halves of class 2
+DATA camelliaConsts<>+0xe0(SB)/8, $0x0505050505050505
+DATA camelliaConsts<>+0xe8(SB)/8, $0x0202020202020202
+// Subkey bytes 3 and 6, in big-endian order, to the halves of class 3
+DATA camelliaConsts<>+0xf0(SB)/8, $0x0404040404040404
+DATA camelliaConsts<>+0xf8(SB)/8, $0x0101010101010101
+// High half
+DATA camelliaConsts<>+0x100(SB)/8, $0x8080808080808080
+DATA camelliaConsts<>+0x108(SB)/8, $0x0f0e0d0c0b0a0908
+// Bytes of every 16-bit unit half*4+s of a block
+DATA camelliaConsts<>+0x110(SB)/8, $0x0603050204010700
+DATA camelliaConsts<>+0x118(SB)/8, $0x0e0b0d0a0c090f08
+// Inverse of the above
+DATA camelliaConsts<>+0x120(SB)/8, $0x0107050306040200
+DATA camelliaConsts<>+0x128(SB)/8, $0x090f0d0b0e0c0a08
+// Low bytes of the 16-bit lanes, then the high bytes
+DATA camelliaConsts<>+0x130(SB)/8, $0x0e0c0a0806040200
+DATA camelliaConsts<>+0x138(SB)/8, $0x0f0d0b0907050301
+// Inverse of the above
+DATA camelliaConsts<>+0x140(SB)/8, $0x0b030a0209010800
+DATA camelliaConsts<>+0x148(SB)/8, $0x0f070e060d050c04
+// Bytes of the 16-bit units of a block in the order 0, 4, 1, 5, 2, 6, 3, 7
+DATA camelliaConsts<>+0x150(SB)/8, $0x0c0904010f080700
+DATA camelliaConsts<>+0x158(SB)/8, $0x0e0b06030d0a0502
+// Inverse of the above, with the halves swapped
+DATA camelliaConsts<>+0x160(SB)/8, $0x030f0b070e0a0602
+DATA camelliaConsts<>+0x168(SB)/8, $0x010d09050c080400
+// Subkey bytes of class 0 to both halves of four blocks
+DATA camelliaConsts<>+0x170(SB)/8, $0x0f070f070f070f07
+DATA camelliaConsts<>+0x178(SB)/8, $0x0800080008000800
+// Subkey bytes of class 1 to both halves of four blocks
+DATA camelliaConsts<>+0x180(SB)/8, $0x0e060e060e060e06
+DATA camelliaConsts<>+0x188(SB)/8, $0x0b030b030b030b03
+// Subkey bytes of class 2 to both halves of four blocks
+DATA camelliaConsts<>+0x190(SB)/8, $0x0d050d050d050d05
+DATA camelliaConsts<>+0x198(SB)/8, $0x0a020a020a020a02
+// Subkey bytes of class 3 to both halves of four blocks
+DATA camelliaConsts<>+0x1a0(SB)/8, $0x0c040c040c040c04
+DATA camelliaConsts<>+0x1a8(SB)/8, $0x0901090109010901
+// Subkey bytes of class 0 to the left halves of four blocks
+DATA camelliaConsts<>+0x1b0(SB)/8, $0x8007800780078007
+DATA camelliaConsts<>+0x1b8(SB)/8, $0x8000800080008000
+// Subkey bytes of class 1 to the left halves of four blocks
+DATA camelliaConsts<>+0x1c0(SB)/8, $0x8006800680068006
+DATA camelliaConsts<>+0x1c8(SB)/8, $0x8003800380038003
+// Subkey bytes of class 2 to the left halves of four blocks
+DATA camelliaConsts<>+0x1d0(SB)/8, $0x8005800580058005
+DATA camelliaConsts<>+0x1d8(SB)/8, $0x8002800280028002
+// Subkey bytes of class 3 to the left halves of four blocks
+DATA camelliaConsts<>+0x1e0(SB)/8, $0x8004800480048004
+DATA camelliaConsts<>+0x1e8(SB)/8, $0x8001800180018001
+// Subkey bytes of class 0 to the right halves of four blocks
+DATA camelliaConsts<>+0x1f0(SB)/8, $0x0f800f800f800f80
+DATA camelliaConsts<>+0x1f8(SB)/8, $0x0880088008800880
+// Subkey bytes of class 1 to the right halves of four blocks
+DATA camelliaConsts<>+0x200(SB)/8, $0x0e800e800e800e80
+DATA camelliaConsts<>+0x208(SB)/8, $0x0b800b800b800b80
+// Subkey bytes of class 2 to the right halves of four blocks
+DATA camelliaConsts<>+0x210(SB)/8, $0x0d800d800d800d80
+DATA camelliaConsts<>+0x218(SB)/8, $0x0a800a800a800a80
+// Subkey bytes of class 3 to the right halves of four blocks
+DATA camelliaConsts<>+0x220(SB)/8, $0x0c800c800c800c80
+DATA camelliaConsts<>+0x228(SB)/8, $0x0980098009800980
+GLOBL camelliaConsts<>(SB), RODATA|NOPTR, $560
+
+// The state of eight blocks is in V0 to V7. The bytes of a 64-bit half at
+// the positions (0, 7), (1, 4), (2, 5) and (3, 6) go through the same S-box
+// up to a rotation, and are called classes 0 to 3. V0 to V3 hold the classes
+// of the left halves, V4 to V7 those of the right halves: lane 8h+b of a
+// register is the byte at the position h of its class in block b.
+
+// CAMKEY xors the subkey at off(R0) into the half in x0 to x3.
+#define CAMKEY(off, x0, x1, x2, x3) \
+	FMOVD off(R0), F12; \
+	VTBL V26.B16, [V12.B16], V13.B16; \
+	VEOR V13.B16, x0, x0 \
+	VTBL V27.B16, [V12.B16], V13.B16; \
+	VEOR V13.B16, x1, x1 \
+	VTBL V28.B16, [V12.B16], V13.B16; \
+	VEOR V13.B16, x2, x2 \
+	VTBL V29.B16, [V12.B16], V13.B16; \
+	VEOR V13.B16, x3, x3
+
+// AFFINE applies the bit matrix with the nibble tables lo and hi to x.
+#define AFFINE(x, lo, hi) \
+	VUSHR $4, x, V12.B16; \
+	VAND V14.B16, x, x; \
+	VTBL x, [lo], x; \
+	VTBL V12.B16, [hi], V12.B16; \
+	VEOR V12.B16, x, x
+
+// SBOX is post(SubBytes(pre(x))) with AESE and a zero key, after
+// InvShiftRows.
+#define SBOX(x, prelo, prehi, postlo, posthi) \
+	AFFINE(x, prelo, prehi); \
+	VTBL V15.B16, [x], x; \
+	AESE V30.B16, x; \
+	AFFINE(x, postlo, posthi)
+
+// CAMF xors F(a, k) into b, with the round key k at off(R0). The bytes of
+// every class of P(z) are xors of whole halves of classes of z.
+#define CAMF(off, a0, a1, a2, a3, b0, b1, b2, b3) \
+	FMOVD off(R0), F12; \
+	VTBL V26.B16, [V12.B16], V8.B16 \
+	VEOR a0, V8.B16, V8.B16 \
+	VTBL V27.B16, [V12.B16], V9.B16 \
+	VEOR a1, V9.B16, V9.B16 \
+	VTBL V28.B16, [V12.B16], V10.B16 \
+	VEOR a2, V10.B16, V10.B16 \
+	VTBL V29.B16, [V12.B16], V11.B16 \
+	VEOR a3, V11.B16, V11.B16 \
+	SBOX(V8.B16, V16.B16, V17.B16, V20.B16, V21.B16) \
+	SBOX(V9.B16, V16.B16, V17.B16, V22.B16, V23.B16) \
+	SBOX(V10.B16, V16.B16, V17.B16, V24.B16, V25.B16) \
+	SBOX(V11.B16, V18.B16, V19.B16, V20.B16, V21.B16) \
+	VEXT $8, V8.B16, V8.B16, V12.B16 \
+	VEOR V12.B16, b0, b0 \
+	FMOVD F8, F12 \
+	VEOR V12.B16, b0, b0 \
+	VTBL V31.B16, [V9.B16], V12.B16 \
+	VEOR V12.B16, b0, b0 \
+	VEOR V10.B16, b0, b0 \
+	VEXT $8, V30.B16, V10.B16, V12.B16 \
+	VEOR V12.B16, b0, b0 \
+	VEOR V11.B16, b0, b0 \
+	VEXT $8, V11.B16, V11.B16, V12.B16 \
+	VEOR V12.B16, b0, b0 \
+	VEOR V8.B16, b1, b1 \
+	VEXT $8, V8.B16, V8.B16, V12.B16 \
+	VEOR V12.B16, b1, b1 \
+	VEXT $8, V9.B16, V9.B16, V12.B16 \
+	VEOR V12.B16, b1, b1 \
+	FMOVD F9, F12 \
+	VEOR V12.B16, b1, b1 \
+	VTBL V31.B16, [V10.B16], V12.B16 \
+	VEOR V12.B16, b1, b1 \
+	VEOR V11.B16, b1, b1 \
+	VEXT $8, V30.B16, V11.B16, V12.B16 \
+	VEOR V12.B16, b1, b1 \
+	VEOR V8.B16, b2, b2 \
+	VEXT $8, V30.B16, V8.B16, V12.B16 \
+	VEOR V12.B16, b2, b2 \
+	VEOR V9.B16, b2, b2 \
+	VEXT $8, V9.B16, V9.B16, V12.B16 \
+	VEOR V12.B16, b2, b2 \
+	VEXT $8, V10.B16, V10.B16, V12.B16 \
+	VEOR V12.B16, b2, b2 \
+	FMOVD F10, F12 \
+	VEOR V12.B16, b2, b2 \
+	VTBL V31.B16, [V11.B16], V12.B16 \
+	VEOR V12.B16, b2, b2 \
+	VTBL V31.B16, [V8.B16], V12.B16 \
+	VEOR V12.B16, b3, b3 \
+	VEOR V9.B16, b3, b3 \
+	VEXT $8, V30.B16, V9.B16, V12.B16 \
+	VEOR V12.B16, b3, b3 \
+	VEOR V10.B16, b3, b3 \
+	VEXT $8, V10.B16, V10.B16, V12.B16 \
+	VEOR V12.B16, b3, b3 \
+	VEXT $8, V11.B16, V11.B16, V12.B16 \
+	VEOR V12.B16, b3, b3 \
+	FMOVD F11, F12 \
+	VEOR V12.B16, b3, b3
+
+// FLSTEP1 is x2 ^= (x1 & k1) <<< 1 on the half in x0 to x3, with the subkey
+// at off(R0). The rotation moves the top bit of every byte to the next
+// byte to the left, which is in the next class.
+#define FLSTEP1(off, x0, x1, x2, x3) \
+	FMOVD off(R0), F12; \
+	VTBL V26.B16, [V12.B16], V8.B16 \
+	VAND x0, V8.B16, V8.B16 \
+	VTBL V27.B16, [V12.B16], V9.B16 \
+	VAND x1, V9.B16, V9.B16 \
+	VTBL V28.B16, [V12.B16], V10.B16 \
+	VAND x2, V10.B16, V10.B16 \
+	VTBL V29.B16, [V12.B16], V11.B16 \
+	VAND x3, V11.B16, V11.B16 \
+	VSHL $1, V8.B16, V12.B16 \
+	VUSHR $7, V9.B16, V13.B16 \
+	VORR V13.B16, V12.B16, V12.B16 \
+	VEXT $8, V12.B16, V30.B16, V12.B16 \
+	VEOR V12.B16, x1, x1 \
+	VSHL $1, V9.B16, V12.B16 \
+	VUSHR $7, V10.B16, V13.B16 \
+	VORR V13.B16, V12.B16, V12.B16 \
+	VEXT $8, V12.B16, V30.B16, V12.B16 \
+	VEOR V12.B16, x2, x2 \
+	VSHL $1, V10.B16, V12.B16 \
+	VUSHR $7, V11.B16, V13.B16 \
+	VORR V13.B16, V12.B16, V12.B16 \
+	VEXT $8, V12.B16, V30.B16, V12.B16 \
+	VEOR V12.B16, x3, x3 \
+	VSHL $1, V11.B16, V12.B16 \
+	VUSHR $7, V8.B16, V13.B16 \
+	VORR V13.B16, V12.B16, V12.B16 \
+	VEXT $8, V12.B16, V30.B16, V12.B16 \
+	VEOR V12.B16, x0, x0
+
+// FLSTEP2 is x1 ^= x2 | k2 on the half in x0 to x3, with the subkey at
+// off(R0).
+#define FLSTEP2(off, x0, x1, x2, x3) \
+	FMOVD off(R0), F12; \
+	VTBL V26.B16, [V12.B16], V8.B16 \
+	VORR x0, V8.B16, V8.B16 \
+	VTBL V27.B16, [V12.B16], V9.B16 \
+	VORR x1, V9.B16, V9.B16 \
+	VTBL V28.B16, [V12.B16], V10.B16 \
+	VORR x2, V10.B16, V10.B16 \
+	VTBL V29.B16, [V12.B16], V11.B16 \
+	VORR x3, V11.B16, V11.B16 \
+	VEXT $8, V30.B16, V9.B16, V12.B16 \
+	VEOR V12.B16, x0, x0 \
+	VEXT $8, V30.B16, V10.B16, V12.B16 \
+	VEOR V12.B16, x1, x1 \
+	VEXT $8, V30.B16, V11.B16, V12.B16 \
+	VEOR V12.B16, x2, x2 \
+	VEXT $8, V30.B16, V8.B16, V12.B16 \
+	VEOR V12.B16, x3, x3
+
+// func camelliaCrypt8HW(rk *uint64, groups int, dst, src *[128]byte)
+TEXT ·camelliaCrypt8HW(SB),NOSPLIT,$0
+	MOVD rk+0(FP), R0
+	MOVD groups+8(FP), R5
+	MOVD dst+16(FP), R1
+	MOVD src+24(FP), R2
+
+	// Gather the bytes of every class of a block into 16-bit units, transpose
+	// the 8x8 units so that a register holds a unit of the eight blocks, and
+	// separate the two bytes of the units.
+	MOVD $camelliaConsts<>+0x110(SB), R4
+	VLD1 (R4), [V16.B16, V17.B16, V18.B16, V19.B16]
+	VLD1.P 64(R2), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VLD1 (R2), [V4.B16, V5.B16, V6.B16, V7.B16]
+	VTBL V16.B16, [V0.B16], V0.B16
+	VTBL V16.B16, [V1.B16], V1.B16
+	VTBL V16.B16, [V2.B16], V2.B16
+	VTBL V16.B16, [V3.B16], V3.B16
+	VTBL V16.B16, [V4.B16], V4.B16
+	VTBL V16.B16, [V5.B16], V5.B16
+	VTBL V16.B16, [V6.B16], V6.B16
+	VTBL V16.B16, [V7.B16], V7.B16
+	VZIP1 V1.H8, V0.H8, V8.H8
+	VZIP2 V1.H8, V0.H8, V9.H8
+	VZIP1 V3.H8, V2.H8, V10.H8
+	VZIP2 V3.H8, V2.H8, V11.H8
+	VZIP1 V5.H8, V4.H8, V12.H8
+	VZIP2 V5.H8, V4.H8, V13.H8
+	VZIP1 V7.H8, V6.H8, V14.H8
+	VZIP2 V7.H8, V6.H8, V15.H8
+	VZIP1 V10.S4, V8.S4, V0.S4
+	VZIP2 V10.S4, V8.S4, V1.S4
+	VZIP1 V11.S4, V9.S4, V2.S4
+	VZIP2 V11.S4, V9.S4, V3.S4
+	VZIP1 V14.S4, V12.S4, V4.S4
+	VZIP2 V14.S4, V12.S4, V5.S4
+	VZIP1 V15.S4, V13.S4, V6.S4
+	VZIP2 V15.S4, V13.S4, V7.S4
+	VZIP1 V4.D2, V0.D2, V8.D2
+	VZIP2 V4.D2, V0.D2, V9.D2
+	VZIP1 V5.D2, V1.D2, V10.D2
+	VZIP2 V5.D2, V1.D2, V11.D2
+	VZIP1 V6.D2, V2.D2, V12.D2
+	VZIP2 V6.D2, V2.D2, V13.D2
+	VZIP1 V7.D2, V3.D2, V14.D2
+	VZIP2 V7.D2, V3.D2, V15.D2
+	VTBL V18.B16, [V8.B16], V0.B16
+	VTBL V18.B16, [V9.B16], V1.B16
+	VTBL V18.B16, [V10.B16], V2.B16
+	VTBL V18.B16, [V11.B16], V3.B16
+	VTBL V18.B16, [V12.B16], V4.B16
+	VTBL V18.B16, [V13.B16], V5.B16
+	VTBL V18.B16, [V14.B16], V6.B16
+	VTBL V18.B16, [V15.B16], V7.B16
+
+	MOVD $camelliaConsts<>(SB), R4
+	VLD1.P 64(R4), [V14.B16, V15.B16, V16.B16, V17.B16]
+	VLD1.P 64(R4), [V18.B16, V19.B16, V20.B16, V21.B16]
+	VLD1.P 64(R4), [V22.B16, V23.B16, V24.B16, V25.B16]
+	VLD1.P 64(R4), [V26.B16, V27.B16, V28.B16, V29.B16]
+	VLD1 (R4), [V31.B16]
+	VEOR V30.B16, V30.B16, V30.B16
+
+	CAMKEY(0, V0.B16, V1.B16, V2.B16, V3.B16)
+	CAMKEY(8, V4.B16, V5.B16, V6.B16, V7.B16)
+	ADD $16, R0
+	B rounds
+
+loop:
+	FLSTEP1(0, V0.B16, V1.B16, V2.B16, V3.B16)
+	FLSTEP2(0, V0.B16, V1.B16, V2.B16, V3.B16)
+	FLSTEP2(8, V4.B16, V5.B16, V6.B16, V7.B16)
+	FLSTEP1(8, V4.B16, V5.B16, V6.B16, V7.B16)
+	ADD $16, R0
+
+rounds:
+	CAMF(0, V0.B16, V1.B16, V2.B16, V3.B16, V4.B16, V5.B16, V6.B16, V7.B16)
+	CAMF(8, V4.B16, V5.B16, V6.B16, V7.B16, V0.B16, V1.B16, V2.B16, V3.B16)
+	CAMF(16, V0.B16, V1.B16, V2.B16, V3.B16, V4.B16, V5.B16, V6.B16, V7.B16)
+	CAMF(24, V4.B16, V5.B16, V6.B16, V7.B16, V0.B16, V1.B16, V2.B16, V3.B16)
+	CAMF(32, V0.B16, V1.B16, V2.B16, V3.B16, V4.B16, V5.B16, V6.B16, V7.B16)
+	CAMF(40, V4.B16, V5.B16, V6.B16, V7.B16, V0.B16, V1.B16, V2.B16, V3.B16)
+	ADD $48, R0
+	SUBS $1, R5
+	BNE loop
+
+	CAMKEY(0, V4.B16, V5.B16, V6.B16, V7.B16)
+	CAMKEY(8, V0.B16, V1.B16, V2.B16, V3.B16)
+
+	// The output is the right half, then the left half.
+	MOVD $camelliaConsts<>+0x110(SB), R4
+	VLD1 (R4), [V16.B16, V17.B16, V18.B16, V19.B16]
+	VTBL V19.B16, [V4.B16], V8.B16
+	VTBL V19.B16, [V5.B16], V9.B16
+	VTBL V19.B16, [V6.B16], V10.B16
+	VTBL V19.B16, [V7.B16], V11.B16
+	VTBL V19.B16, [V0.B16], V12.B16
+	VTBL V19.B16, [V1.B16], V13.B16
+	VTBL V19.B16, [V2.B16], V14.B16
+	VTBL V19.B16, [V3.B16], V15.B16
+	VZIP1 V9.H8, V8.H8, V0.H8
+	VZIP2 V9.H8, V8.H8, V1.H8
+	VZIP1 V11.H8, V10.H8, V2.H8
+	VZIP2 V11.H8, V10.H8, V3.H8
+	VZIP1 V13.H8, V12.H8, V4.H8
+	VZIP2 V13.H8, V12.H8, V5.H8
+	VZIP1 V15.H8, V14.H8, V6.H8
+	VZIP2 V15.H8, V14.H8, V7.H8
+	VZIP1 V2.S4, V0.S4, V8.S4
+	VZIP2 V2.S4, V0.S4, V9.S4
+	VZIP1 V3.S4, V1.S4, V10.S4
+	VZIP2 V3.S4, V1.S4, V11.S4
+	VZIP1 V6.S4, V4.S4, V12.S4
+	VZIP2 V6.S4, V4.S4, V13.S4
+	VZIP1 V7.S4, V5.S4, V14.S4
+	VZIP2 V7.S4, V5.S4, V15.S4
+	VZIP1 V12.D2, V8.D2, V0.D2
+	VZIP2 V12.D2, V8.D2, V1.D2
+	VZIP1 V13.D2, V9.D2, V2.D2
+	VZIP2 V13.D2, V9.D2, V3.D2
+	VZIP1 V14.D2, V10.D2, V4.D2
+	VZIP2 V14.D2, V10.D2, V5.D2
+	VZIP1 V15.D2, V11.D2, V6.D2
+	VZIP2 V15.D2, V11.D2, V7.D2
+	VTBL V17.B16, [V0.B16], V0.B16
+	VTBL V17.B16, [V1.B16], V1.B16
+	VTBL V17.B16, [V2.B16], V2.B16
+	VTBL V17.B16, [V3.B16], V3.B16
+	VTBL V17.B16, [V4.B16], V4.B16
+	VTBL V17.B16, [V5.B16], V5.B16
+	VTBL V17.B16, [V6.B16], V6.B16
+	VTBL V17.B16, [V7.B16], V7.B16
+	VST1.P [V0.B16, V1.B16, V2.B16, V3.B16], 64(R1)
+	VST1 [V4.B16, V5.B16, V6.B16, V7.B16], (R1)
+	RET
+
+// The state of four blocks is in V0 to V3, with the same classes, but lane
+// 8h+p of Vs holds the byte at the position h of class s in the left half of
+// block p/2 if p is even, and in its right half if p is odd. The round
+// function runs on both halves, and the half that is not its input is
+// discarded. R6, R7 and R8 point to the tables that spread subkeys to both
+// halves, to the left halves and to the right halves.
+
+// CAMKEY4 xors the subkeys in V12 into the halves: bytes 0 to 7 into the
+// left halves, bytes 8 to 15 into the right halves.
+#define CAMKEY4 \
+	VLD1 (R6), [V8.B16, V9.B16, V10.B16, V11.B16] \
+	VTBL V8.B16, [V12.B16], V8.B16 \
+	VEOR V8.B16, V0.B16, V0.B16 \
+	VTBL V9.B16, [V12.B16], V9.B16 \
+	VEOR V9.B16, V1.B16, V1.B16 \
+	VTBL V10.B16, [V12.B16], V10.B16 \
+	VEOR V10.B16, V2.B16, V2.B16 \
+	VTBL V11.B16, [V12.B16], V11.B16 \
+	VEOR V11.B16, V3.B16, V3.B16
+
+// CAMF4 xors F(x, k) into the other half of every block, with the round key
+// k at off(R0). The two halves of a block share the 16-bit lanes, so shift
+// is VSHL to use F of the left halves, and VUSHR to use F of the right
+// halves.
+#define CAMF4(off, shift) \
+	FMOVD off(R0), F12 \
+	VTBL V26.B16, [V12.B16], V8.B16 \
+	VEOR V0.B16, V8.B16, V8.B16 \
+	VTBL V27.B16, [V12.B16], V9.B16 \
+	VEOR V1.B16, V9.B16, V9.B16 \
+	VTBL V28.B16, [V12.B16], V10.B16 \
+	VEOR V2.B16, V10.B16, V10.B16 \
+	VTBL V29.B16, [V12.B16], V11.B16 \
+	VEOR V3.B16, V11.B16, V11.B16 \
+	SBOX(V8.B16, V16.B16, V17.B16, V20.B16, V21.B16) \
+	SBOX(V9.B16, V16.B16, V17.B16, V22.B16, V23.B16) \
+	SBOX(V10.B16, V16.B16, V17.B16, V24.B16, V25.B16) \
+	SBOX(V11.B16, V18.B16, V19.B16, V20.B16, V21.B16) \
+	VEXT $8, V8.B16, V8.B16, V4.B16 \
+	FMOVD F8, F12 \
+	VEOR V12.B16, V4.B16, V4.B16 \
+	VTBL V31.B16, [V9.B16], V12.B16 \
+	VEOR V12.B16, V4.B16, V4.B16 \
+	VEOR V10.B16, V4.B16, V4.B16 \
+	VEXT $8, V30.B16, V10.B16, V12.B16 \
+	VEOR V12.B16, V4.B16, V4.B16 \
+	VEOR V11.B16, V4.B16, V4.B16 \
+	VEXT $8, V11.B16, V11.B16, V12.B16 \
+	VEOR V12.B16, V4.B16, V4.B16 \
+	VMOV V8.B16, V5.B16 \
+	VEXT $8, V8.B16, V8.B16, V12.B16 \
+	VEOR V12.B16, V5.B16, V5.B16 \
+	VEXT $8, V9.B16, V9.B16, V12.B16 \
+	VEOR V12.B16, V5.B16, V5.B16 \
+	FMOVD F9, F12 \
+	VEOR V12.B16, V5.B16, V5.B16 \
+	VTBL V31.B16, [V10.B16], V12.B16 \
+	VEOR V12.B16, V5.B16, V5.B16 \
+	VEOR V11.B16, V5.B16, V5.B16 \
+	VEXT $8, V30.B16, V11.B16, V12.B16 \
+	VEOR V12.B16, V5.B16, V5.B16 \
+	VMOV V8.B16, V6.B16 \
+	VEXT $8, V30.B16, V8.B16, V12.B16 \
+	VEOR V12.B16, V6.B16, V6.B16 \
+	VEOR V9.B16, V6.B16, V6.B16 \
+	VEXT $8, V9.B16, V9.B16, V12.B16 \
+	VEOR V12.B16, V6.B16, V6.B16 \
+	VEXT $8, V10.B16, V10.B16, V12.B16 \
+	VEOR V12.B16, V6.B16, V6.B16 \
+	FMOVD F10, F12 \
+	VEOR V12.B16, V6.B16, V6.B16 \
+	VTBL V31.B16, [V11.B16], V12.B16 \
+	VEOR V12.B16, V6.B16, V6.B16 \
+	VTBL V31.B16, [V8.B16], V7.B16 \
+	VEOR V9.B16, V7.B16, V7.B16 \
+	VEXT $8, V30.B16, V9.B16, V12.B16 \
+	VEOR V12.B16, V7.B16, V7.B16 \
+	VEOR V10.B16, V7.B16, V7.B16 \
+	VEXT $8, V10.B16, V10.B16, V12.B16 \
+	VEOR V12.B16, V7.B16, V7.B16 \
+	VEXT $8, V11.B16, V11.B16, V12.B16 \
+	VEOR V12.B16, V7.B16, V7.B16 \
+	FMOVD F11, F12 \
+	VEOR V12.B16, V7.B16, V7.B16 \
+	shift $8, V4.H8, V4.H8 \
+	VEOR V4.B16, V0.B16, V0.B16 \
+	shift $8, V5.H8, V5.H8 \
+	VEOR V5.B16, V1.B16, V1.B16 \
+	shift $8, V6.H8, V6.H8 \
+	VEOR V6.B16, V2.B16, V2.B16 \
+	shift $8, V7.H8, V7.H8 \
+	VEOR V7.B16, V3.B16, V3.B16
+
+// FL4STEP1 and FL4STEP2 are FLSTEP1 and FLSTEP2 on the halves in V0 to V3,
+// with the subkeys in V4 spread by the tables at sp. The tables for a single
+// half give a zero subkey to the other half, which FL4STEP1 then leaves
+// unchanged.
+#define FL4STEP1(sp) \
+	VLD1 (sp), [V8.B16, V9.B16, V10.B16, V11.B16] \
+	VTBL V8.B16, [V4.B16], V8.B16 \
+	VAND V0.B16, V8.B16, V8.B16 \
+	VTBL V9.B16, [V4.B16], V9.B16 \
+	VAND V1.B16, V9.B16, V9.B16 \
+	VTBL V10.B16, [V4.B16], V10.B16 \
+	VAND V2.B16, V10.B16, V10.B16 \
+	VTBL V11.B16, [V4.B16], V11.B16 \
+	VAND V3.B16, V11.B16, V11.B16 \
+	VSHL $1, V8.B16, V12.B16 \
+	VUSHR $7, V9.B16, V13.B16 \
+	VORR V13.B16, V12.B16, V12.B16 \
+	VEXT $8, V12.B16, V30.B16, V12.B16 \
+	VEOR V12.B16, V1.B16, V1.B16 \
+	VSHL $1, V9.B16, V12.B16 \
+	VUSHR $7, V10.B16, V13.B16 \
+	VORR V13.B16, V12.B16, V12.B16 \
+	VEXT $8, V12.B16, V30.B16, V12.B16 \
+	VEOR V12.B16, V2.B16, V2.B16 \
+	VSHL $1, V10.B16, V12.B16 \
+	VUSHR $7, V11.B16, V13.B16 \
+	VORR V13.B16, V12.B16, V12.B16 \
+	VEXT $8, V12.B16, V30.B16, V12.B16 \
+	VEOR V12.B16, V3.B16, V3.B16 \
+	VSHL $1, V11.B16, V12.B16 \
+	VUSHR $7, V8.B16, V13.B16 \
+	VORR V13.B16, V12.B16, V12.B16 \
+	VEXT $8, V12.B16, V30.B16, V12.B16 \
+	VEOR V12.B16, V0.B16, V0.B16
+
+#define FL4STEP2(sp) \
+	VLD1 (sp), [V8.B16, V9.B16, V10.B16, V11.B16] \
+	VTBL V8.B16, [V4.B16], V8.B16 \
+	VORR V0.B16, V8.B16, V8.B16 \
+	VTBL V9.B16, [V4.B16], V9.B16 \
+	VORR V1.B16, V9.B16, V9.B16 \
+	VTBL V10.B16, [V4.B16], V10.B16 \
+	VORR V2.B16, V10.B16, V10.B16 \
+	VTBL V11.B16, [V4.B16], V11.B16 \
+	VORR V3.B16, V11.B16, V11.B16 \
+	VEXT $8, V30.B16, V9.B16, V12.B16 \
+	VEOR V12.B16, V0.B16, V0.B16 \
+	VEXT $8, V30.B16, V10.B16, V12.B16 \
+	VEOR V12.B16, V1.B16, V1.B16 \
+	VEXT $8, V30.B16, V11.B16, V12.B16 \
+	VEOR V12.B16, V2.B16, V2.B16 \
+	VEXT $8, V30.B16, V8.B16, V12.B16 \
+	VEOR V12.B16, V3.B16, V3.B16
+
+// func camelliaCrypt4HW(rk *uint64, groups int, dst, src *Block4)
+TEXT ·camelliaCrypt4HW(SB),NOSPLIT,$0
+	MOVD rk+0(FP), R0
+	MOVD groups+8(FP), R5
+	MOVD dst+16(FP), R1
+	MOVD src+24(FP), R2
+
+	// Pair the 16-bit units of the two halves in every block, transpose the
+	// 4x4 pairs, and separate the two bytes of the units.
+	MOVD $camelliaConsts<>+0x130(SB), R4
+	VLD1 (R4), [V16.B16, V17.B16, V18.B16, V19.B16]
+	VLD1 (R2), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VTBL V18.B16, [V0.B16], V0.B16
+	VTBL V18.B16, [V1.B16], V1.B16
+	VTBL V18.B16, [V2.B16], V2.B16
+	VTBL V18.B16, [V3.B16], V3.B16
+	VZIP1 V1.S4, V0.S4, V4.S4
+	VZIP2 V1.S4, V0.S4, V5.S4
+	VZIP1 V3.S4, V2.S4, V6.S4
+	VZIP2 V3.S4, V2.S4, V7.S4
+	VZIP1 V6.D2, V4.D2, V8.D2
+	VZIP2 V6.D2, V4.D2, V9.D2
+	VZIP1 V7.D2, V5.D2, V10.D2
+	VZIP2 V7.D2, V5.D2, V11.D2
+	VTBL V16.B16, [V8.B16], V0.B16
+	VTBL V16.B16, [V9.B16], V1.B16
+	VTBL V16.B16, [V10.B16], V2.B16
+	VTBL V16.B16, [V11.B16], V3.B16
+
+	MOVD $camelliaConsts<>(SB), R4
+	ADD $0x170, R4, R6
+	ADD $0x1b0, R4, R7
+	ADD $0x1f0, R4, R8
+	VLD1.P 64(R4), [V14.B16, V15.B16, V16.B16, V17.B16]
+	VLD1.P 64(R4), [V18.B16, V19.B16, V20.B16, V21.B16]
+	VLD1.P 64(R4), [V22.B16, V23.B16, V24.B16, V25.B16]
+	VLD1.P 64(R4), [V26.B16, V27.B16, V28.B16, V29.B16]
+	VLD1 (R4), [V31.B16]
+	VEOR V30.B16, V30.B16, V30.B16
+
+	VLD1 (R0), [V12.B16]
+	CAMKEY4
+	ADD $16, R0
+	B rounds
+
+loop:
+	// FL on the left halves, FL⁻¹ on the right halves.
+	VLD1 (R0), [V4.B16]
+	FL4STEP1(R7)
+	FL4STEP2(R6)
+	FL4STEP1(R8)
+	ADD $16, R0
+
+rounds:
+	CAMF4(0, VSHL)
+	CAMF4(8, VUSHR)
+	CAMF4(16, VSHL)
+	CAMF4(24, VUSHR)
+	CAMF4(32, VSHL)
+	CAMF4(40, VUSHR)
+	ADD $48, R0
+	SUBS $1, R5
+	BNE loop
+
+	// The last subkeys go to the right halves, then the left halves.
+	VLD1 (R0), [V12.B16]
+	VEXT $8, V12.B16, V12.B16, V12.B16
+	CAMKEY4
+
+	MOVD $camelliaConsts<>+0x130(SB), R4
+	VLD1 (R4), [V16.B16, V17.B16, V18.B16, V19.B16]
+	VTBL V17.B16, [V0.B16], V0.B16
+	VTBL V17.B16, [V1.B16], V1.B16
+	VTBL V17.B16, [V2.B16], V2.B16
+	VTBL V17.B16, [V3.B16], V3.B16
+	VZIP1 V1.S4, V0.S4, V4.S4
+	VZIP2 V1.S4, V0.S4, V5.S4
+	VZIP1 V3.S4, V2.S4, V6.S4
+	VZIP2 V3.S4, V2.S4, V7.S4
+	VZIP1 V6.D2, V4.D2, V8.D2
+	VZIP2 V6.D2, V4.D2, V9.D2
+	VZIP1 V7.D2, V5.D2, V10.D2
+	VZIP2 V7.D2, V5.D2, V11.D2
+	VTBL V19.B16, [V8.B16], V0.B16
+	VTBL V19.B16, [V9.B16], V1.B16
+	VTBL V19.B16, [V10.B16], V2.B16
+	VTBL V19.B16, [V11.B16], V3.B16
+	VST1 [V0.B16, V1.B16, V2.B16, V3.B16], (R1)
+	RET
@@ -0,0 +1,11 @@
+//go:build (!amd64 && !arm64) || purego
+
+package aes
+
+func camelliaCrypt4(rk []uint64, dst, src *Block4) {
+	camelliaCrypt4Generic(rk, dst, src)
+}
+
+func camelliaCrypt8(rk []uint64, dst, src *[8 * CamelliaBlockSize]byte) {
+	camelliaCrypt8Generic(rk, dst, src)
+}
@@ -0,0 +1,155 @@
+package aes
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"encoding/hex"
+	"testing"
+)
+
+// RFC 3713, Appendix A.
+var camelliaVectors = []struct {
+	key, ct string
+}{
+	{"0123456789abcdeffedcba9876543210", "67673138549669730857065648eabe43"},
+	{"0123456789abcdeffedcba98765432100011223344556677", "b4993401b3e996f84ee5cee7d79b09b9"},
+	{"0123456789abcdeffedcba987654321000112233445566778899aabbccddeeff", "9acc237dff16d76c20ef7c919e3a7509"},
+}
+
+func TestCamelliaVectors(t *testing.T) {
+	pt := mustHex(t, "0123456789abcdeffedcba9876543210")
+	for _, v := range camelliaVectors {
+		c, err := NewCamellia(mustHex(t, v.key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var b Block
+		c.Encrypt(&b, (*Block)(pt))
+		if hex.EncodeToString(b[:]) != v.ct {
+			t.Errorf("Camellia-%d: Encrypt = %x, want %s", len(v.key)*4, b, v.ct)
+		}
+		c.Decrypt(&b, &b)
+		if !bytes.Equal(b[:], pt) {
+			t.Errorf("Camellia-%d: Decrypt = %x, want %x", len(v.key)*4, b, pt)
+		}
+	}
+}
+
+// s1 computed around the AES S-box matches the table.
+func TestCamelliaSboxFromAES(t *testing.T) {
+	in := [8]byte{0x01, 0xe6, 0xec, 0xef, 0xbf, 0x88, 0xcb, 0x3b}
+	out := [8]byte{0x21, 0xa9, 0xe2, 0x3e, 0xa5, 0x69, 0xf7, 0x83}
+	mul := func(m *[8]byte, x byte) (y byte) {
+		for j := range 8 {
+			y ^= m[j] & -(x >> j & 1)
+		}
+		return y
+	}
+	for x := range 256 {
+		if got := mul(&out, sbox[mul(&in, byte(x))^0x1d]) ^ 0x78; got != camelliaS1[x] {
+			t.Fatalf("s1(%#02x) = %#02x, want %#02x", x, got, camelliaS1[x])
+		}
+	}
+}
+
+func TestCamelliaBatches(t *testing.T) {
+	src := make([]byte, 13*CamelliaBlockSize)
+	for i := range src {
+		src[i] = byte(i*7 + 3)
+	}
+	for _, kl := range []int{16, 24, 32} {
+		key := make([]byte, kl)
+		for i := range key {
+			key[i] = byte(i * 29)
+		}
+		c, _ := NewCamellia(key)
+		want := make([]byte, len(src))
+		for i := 0; i < len(src); i += CamelliaBlockSize {
+			c.Encrypt((*Block)(want[i:]), (*Block)(src[i:]))
+		}
+
+		forEachCPUConfig(t, func(t *testing.T) {
+			var b4 Block4
+			c.Encrypt4(&b4, (*Block4)(src))
+			if !bytes.Equal(b4[:], want[:64]) {
+				t.Fatalf("Camellia-%d: Encrypt4 = %x, want %x", kl*8, b4, want[:64])
+			}
+			c.Decrypt4(&b4, &b4)
+			if !bytes.Equal(b4[:], src[:64]) {
+				t.Fatalf("Camellia-%d: Decrypt4 does not invert Encrypt4", kl*8)
+			}
+
+			var b8 [8 * CamelliaBlockSize]byte
+			c.Encrypt8(&b8, (*[8 * CamelliaBlockSize]byte)(src))
+			if !bytes.Equal(b8[:], want[:128]) {
+				t.Fatalf("Camellia-%d: Encrypt8 = %x, want %x", kl*8, b8, want[:128])
+			}
+			c.Decrypt8(&b8, &b8)
+			if !bytes.Equal(b8[:], src[:128]) {
+				t.Fatalf("Camellia-%d: Decrypt8 does not invert Encrypt8", kl*8)
+			}
+
+			for n := 0; n <= len(src); n += CamelliaBlockSize {
+				out := make([]byte, n)
+				c.EncryptBlocks(out, src[:n])
+				if !bytes.Equal(out, want[:n]) {
+					t.Fatalf("Camellia-%d: EncryptBlocks(%d bytes) = %x, want %x", kl*8, n, out, want[:n])
+				}
+				c.DecryptBlocks(out, out)
+				if !bytes.Equal(out, src[:n]) {
+					t.Fatalf("Camellia-%d: DecryptBlocks(%d bytes) does not invert EncryptBlocks", kl*8, n)
+				}
+			}
+		})
+	}
+}
+
+func TestCamelliaCipher(t *testing.T) {
+	for _, n := range []int{0, 15, 17, 33} {
+		if _, err := NewCamelliaCipher(make([]byte, n)); err == nil {
+			t.Errorf("NewCamelliaCipher accepted a %d-byte key", n)
+		}
+	}
+
+	v := camelliaVectors[2]
+	block, err := NewCamelliaCipher(mustHex(t, v.key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.BlockSize() != CamelliaBlockSize {
+		t.Fatalf("BlockSize() = %d", block.BlockSize())
+	}
+	out := make([]byte, 16)
+	block.Encrypt(out, mustHex(t, "0123456789abcdeffedcba9876543210"))
+	if hex.EncodeToString(out) != v.ct {
+		t.Fatalf("Encrypt = %x, want %s", out, v.ct)
+	}
+
+	iv := make([]byte, CamelliaBlockSize)
+	msg := bytes.Repeat([]byte("camellia in cbc!"), 5)
+	ct := make([]byte, len(msg))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ct, msg)
+	pt := make([]byte, len(ct))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(pt, ct)
+	if !bytes.Equal(pt, msg) {
+		t.Fatal("CBC round trip failed")
+	}
+}
+
+func BenchmarkCamelliaEncrypt(b *testing.B) {
+	c, _ := NewCamellia(make([]byte, 16))
+	var x Block
+	b.SetBytes(CamelliaBlockSize)
+	for b.Loop() {
+		c.Encrypt(&x, &x)
+	}
+}
+
+func BenchmarkCamelliaEncrypt8(b *testing.B) {
+	c, _ := NewCamellia(make([]byte, 16))
+	var x [8 * CamelliaBlockSize]byte
+	b.SetBytes(int64(len(x)))
+	for b.Loop() {
+		c.Encrypt8(&x, &x)
+	}
+}
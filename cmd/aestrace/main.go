@@ -0,0 +1,277 @@
+// Command aestrace prints the intermediate states of AES and of the AES-based
+// primitives of the aes package, round by round.
+//
+// Usage:
+//
+//	aestrace [flags]
+//
+// Without flags, it prints the AES-128 example of FIPS-197 Appendix C.1.
+// The key and the input default to the bytes 00 01 02 ... and 00 11 22 ...
+// of the required lengths, as in FIPS-197, and the tweak to zeros.
+//
+// The flags are:
+//
+//	-cipher name
+//		aes, kiasu, deoxys, areion256, areion512, haraka256, haraka512,
+//		pholkos256, pholkos512, vistrutah256 or vistrutah512
+//	-key hex
+//		key: 16, 24 or 32 bytes for AES, 16 for KIASU-BC, 32 for
+//		Pholkos and Deoxys-BC-256 (key and tweak), 16 or 32 for
+//		Vistrutah-256 and 32 or 64 for Vistrutah-512
+//	-tweak hex
+//		tweak: 8 bytes for KIASU-BC, 16 for Pholkos
+//	-in hex
+//		input block or state
+//	-rounds n
+//		number of rounds of Vistrutah (default: the short variant)
+//	-d
+//		decrypt, with the inverse cipher (AES, KIASU-BC and Deoxys-BC)
+//	-json
+//		print the trace as JSON
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	aes "github.com/jedisct1/go-aes"
+)
+
+func main() {
+	err := run(os.Args[1:], os.Stdout)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "aestrace:", err)
+		os.Exit(2)
+	}
+}
+
+// primitive describes how to trace one of the supported primitives.
+type primitive struct {
+	keySizes []int // accepted key sizes, the first one is the default
+	tweak    int   // tweak size, 0 if none
+	block    int   // input size
+	inverse  bool  // whether decryption is supported
+	trace    func(t aes.Tracer, key, tweak, in []byte, rounds int, decrypt bool) ([]byte, error)
+}
+
+var primitives = map[string]primitive{
+	"aes": {[]int{16, 24, 32}, 0, 16, true, func(t aes.Tracer, key, _, in []byte, _ int, decrypt bool) ([]byte, error) {
+		ks, err := aes.NewKeySchedule(key)
+		if err != nil {
+			return nil, err
+		}
+		b := aes.Block(in)
+		if decrypt {
+			aes.TraceDecryptBlockAES(t, &b, ks)
+		} else {
+			aes.TraceEncryptBlockAES(t, &b, ks)
+		}
+		return b[:], nil
+	}},
+	"kiasu": {[]int{16}, 8, 16, true, func(t aes.Tracer, key, tweak, in []byte, _ int, decrypt bool) ([]byte, error) {
+		ctx, err := aes.NewKiasuContext([16]byte(key))
+		if err != nil {
+			return nil, err
+		}
+		var out [16]byte
+		if decrypt {
+			out = ctx.TraceDecrypt(t, [16]byte(in), [8]byte(tweak))
+		} else {
+			out = ctx.TraceEncrypt(t, [16]byte(in), [8]byte(tweak))
+		}
+		return out[:], nil
+	}},
+	"deoxys": {[]int{32}, 0, 16, true, func(t aes.Tracer, key, _, in []byte, _ int, decrypt bool) ([]byte, error) {
+		tk := aes.Tweakey256(key)
+		rk := aes.NewDeoxysBC256(&tk)
+		b := aes.Block(in)
+		if decrypt {
+			b = aes.TraceDeoxysBC256Decrypt(t, rk, &b)
+		} else {
+			b = aes.TraceDeoxysBC256Encrypt(t, rk, &b)
+		}
+		return b[:], nil
+	}},
+	"areion256": {nil, 0, 32, false, func(t aes.Tracer, _, _, in []byte, _ int, _ bool) ([]byte, error) {
+		s := aes.Areion256(in)
+		s.TracePermute(t)
+		return s[:], nil
+	}},
+	"areion512": {nil, 0, 64, false, func(t aes.Tracer, _, _, in []byte, _ int, _ bool) ([]byte, error) {
+		s := aes.Areion512(in)
+		s.TracePermute(t)
+		return s[:], nil
+	}},
+	"haraka256": {nil, 0, 32, false, func(t aes.Tracer, _, _, in []byte, _ int, _ bool) ([]byte, error) {
+		out := aes.TraceHaraka256(t, (*[32]byte)(in))
+		return out[:], nil
+	}},
+	"haraka512": {nil, 0, 64, false, func(t aes.Tracer, _, _, in []byte, _ int, _ bool) ([]byte, error) {
+		out := aes.TraceHaraka512(t, (*[64]byte)(in))
+		return out[:], nil
+	}},
+	"pholkos256": {[]int{32}, 16, 32, false, func(t aes.Tracer, key, tweak, in []byte, _ int, _ bool) ([]byte, error) {
+		k, tw := aes.Pholkos256Key(key), aes.PholkosTweak(tweak)
+		b := aes.Pholkos256Block(in)
+		aes.NewPholkos256Context(&k, &tw).TraceEncrypt(t, &b)
+		return b[:], nil
+	}},
+	"pholkos512": {[]int{32}, 16, 64, false, func(t aes.Tracer, key, tweak, in []byte, _ int, _ bool) ([]byte, error) {
+		k, tw := aes.Pholkos256Key(key), aes.PholkosTweak(tweak)
+		b := aes.Pholkos512Block(in)
+		aes.NewPholkos512Context(&k, &tw).TraceEncrypt(t, &b)
+		return b[:], nil
+	}},
+	"vistrutah256": {[]int{32, 16}, 0, 32, false, func(t aes.Tracer, key, _, in []byte, rounds int, _ bool) ([]byte, error) {
+		if rounds == 0 {
+			rounds = aes.Vistrutah256RoundsShort
+		}
+		c, err := aes.NewVistrutah256Cipher(key, rounds)
+		if err != nil {
+			return nil, err
+		}
+		var out aes.Vistrutah256Block
+		c.TraceEncrypt(t, &out, (*aes.Vistrutah256Block)(in))
+		return out[:], nil
+	}},
+	"vistrutah512": {[]int{32, 64}, 0, 64, false, func(t aes.Tracer, key, _, in []byte, rounds int, _ bool) ([]byte, error) {
+		if rounds == 0 {
+			rounds = aes.Vistrutah512RoundsShort256Key
+			if len(key) == 64 {
+				rounds = aes.Vistrutah512RoundsShort512Key
+			}
+		}
+		c, err := aes.NewVistrutah512Cipher(key, rounds)
+		if err != nil {
+			return nil, err
+		}
+		var out aes.Vistrutah512Block
+		c.TraceEncrypt(t, &out, (*aes.Vistrutah512Block)(in))
+		return out[:], nil
+	}},
+}
+
+// sequence returns the bytes 0, step, 2·step, ... modulo 256.
+func sequence(n int, step byte) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i) * step
+	}
+	return b
+}
+
+// parseHex decodes s, or returns def if s is empty, and checks its length.
+func parseHex(name, s string, def []byte, sizes ...int) ([]byte, error) {
+	if s == "" {
+		return def, nil
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("-%s: %v", name, err)
+	}
+	for _, n := range sizes {
+		if len(b) == n {
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("-%s must be %v bytes, not %d", name, sizes, len(b))
+}
+
+func run(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("aestrace", flag.ContinueOnError)
+	name := fs.String("cipher", "aes", "primitive to trace")
+	keyHex := fs.String("key", "", "key, in hexadecimal")
+	tweakHex := fs.String("tweak", "", "tweak, in hexadecimal")
+	inHex := fs.String("in", "", "input, in hexadecimal")
+	rounds := fs.Int("rounds", 0, "number of rounds of Vistrutah")
+	decrypt := fs.Bool("d", false, "decrypt")
+	asJSON := fs.Bool("json", false, "print the trace as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	p, ok := primitives[*name]
+	if !ok {
+		return fmt.Errorf("unknown primitive %q", *name)
+	}
+	if *rounds != 0 && !strings.HasPrefix(*name, "vistrutah") {
+		return fmt.Errorf("%s has a fixed number of rounds", *name)
+	}
+	if *decrypt && !p.inverse {
+		return fmt.Errorf("%s cannot be traced in the inverse direction", *name)
+	}
+	var key, tweak []byte
+	var err error
+	if p.keySizes != nil {
+		if key, err = parseHex("key", *keyHex, sequence(p.keySizes[0], 1), p.keySizes...); err != nil {
+			return err
+		}
+	} else if *keyHex != "" {
+		return fmt.Errorf("%s has no key", *name)
+	}
+	if p.tweak != 0 {
+		if tweak, err = parseHex("tweak", *tweakHex, make([]byte, p.tweak), p.tweak); err != nil {
+			return err
+		}
+	} else if *tweakHex != "" {
+		return fmt.Errorf("%s has no tweak", *name)
+	}
+	in, err := parseHex("in", *inHex, sequence(p.block, 0x11), p.block)
+	if err != nil {
+		return err
+	}
+
+	var log aes.TraceLog
+	out, err := p.trace(&log, key, tweak, in, *rounds, *decrypt)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			Cipher  string           `json:"cipher"`
+			Decrypt bool             `json:"decrypt,omitempty"`
+			Key     string           `json:"key,omitempty"`
+			Tweak   string           `json:"tweak,omitempty"`
+			Input   string           `json:"input"`
+			Output  string           `json:"output"`
+			Events  []aes.TraceEvent `json:"events"`
+		}{*name, *decrypt, hex.EncodeToString(key), hex.EncodeToString(tweak),
+			hex.EncodeToString(in), hex.EncodeToString(out), log.Events})
+	}
+
+	// The header of the examples of FIPS-197 Appendix C.
+	inLabel, title := "PLAINTEXT:", "CIPHER (ENCRYPT):"
+	switch {
+	case *decrypt:
+		inLabel, title = "CIPHERTEXT:", "INVERSE CIPHER (DECRYPT):"
+	case strings.HasPrefix(*name, "haraka"):
+		inLabel, title = "INPUT:", "HASH:"
+	case p.keySizes == nil:
+		inLabel, title = "INPUT:", "PERMUTATION:"
+	}
+	fmt.Fprintf(w, "%-20s%x\n", inLabel, in)
+	if key != nil {
+		fmt.Fprintf(w, "%-20s%x\n", "KEY:", key)
+	}
+	if tweak != nil {
+		fmt.Fprintf(w, "%-20s%x\n", "TWEAK:", tweak)
+	}
+	fmt.Fprintln(w, title)
+	_, err = log.WriteTo(w)
+	return err
+}
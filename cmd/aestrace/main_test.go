@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// FIPS-197, Appendix C.1.
+func TestDefault(t *testing.T) {
+	var out strings.Builder
+	if err := run(nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(out.String(), "\n")
+	for i, want := range []string{
+		"PLAINTEXT:          00112233445566778899aabbccddeeff",
+		"KEY:                000102030405060708090a0b0c0d0e0f",
+		"CIPHER (ENCRYPT):",
+		"round[ 0].input     00112233445566778899aabbccddeeff",
+		"round[ 0].k_sch     000102030405060708090a0b0c0d0e0f",
+		"round[ 1].start     00102030405060708090a0b0c0d0e0f0",
+	} {
+		if lines[i] != want {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want)
+		}
+	}
+	if want := "round[10].output    69c4e0d86a7b0430d8cdb78070b4c55a"; lines[len(lines)-2] != want {
+		t.Errorf("last line = %q, want %q", lines[len(lines)-2], want)
+	}
+}
+
+func TestDecrypt(t *testing.T) {
+	var out strings.Builder
+	err := run([]string{"-d", "-key", "000102030405060708090a0b0c0d0e0f1011121314151617", "-in", "dda97ca4864cdfe06eaf70a0ec0d7191"}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out.String(), "CIPHERTEXT:") || !strings.Contains(out.String(), "INVERSE CIPHER (DECRYPT):\n") {
+		t.Errorf("unexpected header:\n%s", out.String())
+	}
+	if want := "round[12].ioutput   00112233445566778899aabbccddeeff\n"; !strings.HasSuffix(out.String(), want) {
+		t.Errorf("output does not end with %q", want)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	for name := range primitives {
+		var out strings.Builder
+		if err := run([]string{"-cipher", name, "-json"}, &out); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		var trace struct {
+			Cipher string `json:"cipher"`
+			Output string `json:"output"`
+			Events []struct {
+				Round int    `json:"round"`
+				Step  string `json:"step"`
+				Lane  int    `json:"lane"`
+				State string `json:"state"`
+			} `json:"events"`
+		}
+		if err := json.Unmarshal([]byte(out.String()), &trace); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		last := trace.Events[len(trace.Events)-1]
+		if trace.Cipher != name || last.Step != "output" || last.State != trace.Output {
+			t.Errorf("%s: cipher %q, last event %+v, output %s", name, trace.Cipher, last, trace.Output)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"-cipher", "des"},
+		{"-key", "0011"},
+		{"-in", "zz"},
+		{"-cipher", "areion256", "-d"},
+		{"-cipher", "haraka256", "-key", "00"},
+		{"-cipher", "aes", "-tweak", "00"},
+		{"-rounds", "4"},
+		{"-cipher", "vistrutah256", "-rounds", "3"},
+		{"extra"},
+	} {
+		var out strings.Builder
+		if err := run(args, &out); err == nil {
+			t.Errorf("run(%q) succeeded", args)
+		}
+	}
+}
@@ -11,8 +11,11 @@ type CPUFeatures struct {
 	HasAESNI     bool // Intel AES-NI instructions (AESENC/AESDEC)
 	HasARMCrypto bool // ARM Crypto Extensions (AESE/AESD)
 	HasVAES      bool // Vector AES instructions (VAESENC/VAESDEC)
+	HasAVX       bool // AVX support (VEX-encoded three-operand AES instructions)
 	HasAVX2      bool // AVX2 support for 256-bit vectors (2 AES blocks with VAES)
 	HasAVX512    bool // AVX512 support for 512-bit vectors (4 AES blocks with VAES)
+	HasPCLMULQDQ bool // Intel carry-less multiplication (PCLMULQDQ)
+	HasPMULL     bool // ARM polynomial multiplication (PMULL/PMULL2)
 }
 
 // CPU holds the detected CPU features for the current processor.
@@ -29,8 +32,11 @@ func detectCPUFeatures() {
 	CPU.HasAESNI = cpu.X86.HasAES
 	CPU.HasARMCrypto = cpu.ARM64.HasAES
 	CPU.HasVAES = cpu.X86.HasAVX512VAES
+	CPU.HasAVX = cpu.X86.HasAVX
 	CPU.HasAVX2 = cpu.X86.HasAVX2
 	CPU.HasAVX512 = cpu.X86.HasAVX512F
+	CPU.HasPCLMULQDQ = cpu.X86.HasPCLMULQDQ
+	CPU.HasPMULL = cpu.ARM64.HasPMULL
 }
 
 // UseHardwareAcceleration returns true if single-block hardware AES acceleration
@@ -0,0 +1,36 @@
+package aes
+
+import "testing"
+
+// cpuConfigs returns feature sets that select each multi-block code path
+// the current CPU supports, from the widest down to the software fallback.
+func cpuConfigs() []CPUFeatures {
+	full := CPU
+	configs := []CPUFeatures{full}
+	if full.HasVAES && full.HasAVX512 {
+		c := full
+		c.HasAVX512 = false
+		configs = append(configs, c)
+	}
+	if full.HasVAES {
+		c := full
+		c.HasVAES = false
+		configs = append(configs, c)
+	}
+	if full.HasAVX {
+		c := full
+		c.HasVAES, c.HasAVX = false, false
+		configs = append(configs, c)
+	}
+	configs = append(configs, CPUFeatures{})
+	return configs
+}
+
+func forEachCPUConfig(t *testing.T, f func(t *testing.T)) {
+	saved := CPU
+	defer func() { CPU = saved }()
+	for _, c := range cpuConfigs() {
+		CPU = c
+		f(t)
+	}
+}
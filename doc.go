@@ -136,7 +136,7 @@
 // # Security Notes
 //
 // This package provides low-level AES primitives and does NOT implement:
-//   - Authenticated encryption modes (GCM, EAX, etc.)
+//   - Standard authenticated encryption modes (GCM, EAX, etc.)
 //   - Block cipher modes of operation (CBC, CTR, etc.)
 //   - Key derivation or management
 //   - Protection against side-channel attacks beyond hardware instructions
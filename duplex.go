@@ -0,0 +1,202 @@
+package aes
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"errors"
+)
+
+// Duplex is a full-state keyed duplex over any Permutation. The state starts
+// as K || IV || 0* and is permuted once. Every duplex call then absorbs up to
+// Size()-1 bytes over the whole state, with a domain byte, permutes, and
+// outputs up to rate bytes:
+//
+//	S = P(S ⊕ (σ || 0x01 || 0*) ⊕ (0* || domain<<1))
+//	Z = S[:rate]
+//
+// The padding byte and the domain share the last byte of the state when σ is
+// as long as possible; the low bit of that byte tells the two cases apart.
+// Absorbing over the full state is secure because the key, not a capacity
+// kept out of reach of the input, provides the secret.
+type Duplex struct {
+	p    Permutation
+	rate int
+}
+
+// NewDuplex returns a duplex that owns the state p, keyed with key and iv.
+// It panics if rate is not between 1 and p.Size()-1, or if key and iv do not
+// fit in the state.
+func NewDuplex(p Permutation, rate int, key, iv []byte) *Duplex {
+	if rate <= 0 || rate >= p.Size() {
+		panic("aes: invalid duplex rate")
+	}
+	if len(key)+len(iv) > p.Size() {
+		panic("aes: duplex key and IV are too long")
+	}
+	state := p.Bytes()
+	clear(state)
+	copy(state, key)
+	copy(state[len(key):], iv)
+	p.Permute()
+	return &Duplex{p: p, rate: rate}
+}
+
+// Rate returns the maximum output size of a duplex call in bytes.
+func (d *Duplex) Rate() int { return d.rate }
+
+// MaxInput returns the maximum input size of a duplex call in bytes.
+func (d *Duplex) MaxInput() int { return d.p.Size() - 1 }
+
+// Duplex absorbs in with the given domain, permutes the state and fills out
+// with the first len(out) bytes of the new state. It panics if in is longer
+// than MaxInput(), out is longer than Rate() or domain is not below 0x80.
+func (d *Duplex) Duplex(out, in []byte, domain byte) {
+	if len(out) > d.rate {
+		panic("aes: duplex output longer than the rate")
+	}
+	d.absorb(in, domain)
+	copy(out, d.p.Bytes())
+}
+
+// absorb XORs the padded input and the domain into the state and permutes it.
+func (d *Duplex) absorb(in []byte, domain byte) {
+	state := d.p.Bytes()
+	if len(in) >= len(state) {
+		panic("aes: duplex input longer than the maximum")
+	}
+	subtle.XORBytes(state, state, in)
+	d.finish(len(in), domain)
+}
+
+// finish pads an input of n bytes that has already been added to the state,
+// adds the domain and permutes the state.
+func (d *Duplex) finish(n int, domain byte) {
+	if domain >= 0x80 {
+		panic("aes: invalid duplex domain")
+	}
+	state := d.p.Bytes()
+	state[n] ^= 0x01
+	state[len(state)-1] ^= domain << 1
+	d.p.Permute()
+}
+
+// Domains of the SpongeWrap duplex calls.
+const (
+	spongeWrapDomainAD      = 1
+	spongeWrapDomainMessage = 2
+	spongeWrapDomainTag     = 3
+)
+
+const (
+	spongeWrapNonceSize = 16
+	spongeWrapTagSize   = 16
+)
+
+var errSpongeWrapOpen = errors.New("aes: SpongeWrap message authentication failed")
+
+// spongeWrap implements cipher.AEAD with the SpongeWrap mode over a keyed
+// duplex. It only holds the key, and starts every message from a new state.
+type spongeWrap struct {
+	newPermutation func() Permutation
+	rate           int
+	key            []byte
+}
+
+// NewSpongeWrap returns a SpongeWrap-style AEAD over a full-state keyed
+// duplex, with a 16-byte nonce and a 16-byte tag. newPermutation must return
+// a new state for each message; each of them is keyed with key || nonce.
+//
+// The associated data is absorbed over the full state, Size()-1 bytes per
+// call. The message is then encrypted rate bytes at a time with the output of
+// the previous call, and the plaintext block is absorbed. A last call outputs
+// the tag.
+//
+// rate must be between 16 and Size()-16, and key must be at least 16 bytes
+// long and leave room for the nonce in the state.
+func NewSpongeWrap(newPermutation func() Permutation, rate int, key []byte) (cipher.AEAD, error) {
+	size := newPermutation().Size()
+	if rate < spongeWrapTagSize || rate > size-16 {
+		return nil, errors.New("aes: invalid SpongeWrap rate")
+	}
+	if len(key) < 16 || len(key)+spongeWrapNonceSize > size {
+		return nil, errors.New("aes: invalid SpongeWrap key size")
+	}
+	return &spongeWrap{newPermutation: newPermutation, rate: rate, key: append([]byte(nil), key...)}, nil
+}
+
+// NonceSize returns the nonce size in bytes.
+func (w *spongeWrap) NonceSize() int { return spongeWrapNonceSize }
+
+// Overhead returns the tag size in bytes.
+func (w *spongeWrap) Overhead() int { return spongeWrapTagSize }
+
+// seal computes the tag of the message into tag, encrypting or decrypting src
+// into dst on the way.
+func (w *spongeWrap) seal(tag, dst, src, nonce, ad []byte, decrypt bool) {
+	d := NewDuplex(w.newPermutation(), w.rate, w.key, nonce)
+	state := d.p.Bytes()
+
+	for bs := d.MaxInput(); len(ad) > 0; {
+		n := min(len(ad), bs)
+		d.absorb(ad[:n], spongeWrapDomainAD)
+		ad = ad[n:]
+	}
+
+	// Absorbing the plaintext into the keystream leaves the ciphertext in
+	// the state, for both directions.
+	for len(src) > 0 {
+		n := min(len(src), d.rate)
+		if decrypt {
+			for i, c := range src[:n] {
+				dst[i] = state[i] ^ c
+				state[i] = c
+			}
+		} else {
+			subtle.XORBytes(state[:n], state[:n], src[:n])
+			copy(dst[:n], state[:n])
+		}
+		d.finish(n, spongeWrapDomainMessage)
+		dst, src = dst[n:], src[n:]
+	}
+
+	d.Duplex(tag, nil, spongeWrapDomainTag)
+}
+
+// Seal encrypts and authenticates plaintext, authenticates additionalData,
+// and appends the result to dst.
+func (w *spongeWrap) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != spongeWrapNonceSize {
+		panic("aes: incorrect nonce length given to SpongeWrap")
+	}
+	ret, out := sliceForAppend(dst, len(plaintext)+spongeWrapTagSize)
+	if inexactOverlap(out, plaintext) {
+		panic("aes: invalid buffer overlap")
+	}
+	w.seal(out[len(plaintext):], out[:len(plaintext)], plaintext, nonce, additionalData, false)
+	return ret
+}
+
+// Open authenticates and decrypts ciphertext, authenticates additionalData,
+// and appends the resulting plaintext to dst.
+func (w *spongeWrap) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != spongeWrapNonceSize {
+		panic("aes: incorrect nonce length given to SpongeWrap")
+	}
+	if len(ciphertext) < spongeWrapTagSize {
+		return nil, errSpongeWrapOpen
+	}
+	tag := ciphertext[len(ciphertext)-spongeWrapTagSize:]
+	ciphertext = ciphertext[:len(ciphertext)-spongeWrapTagSize]
+
+	ret, out := sliceForAppend(dst, len(ciphertext))
+	if inexactOverlap(out, ciphertext) {
+		panic("aes: invalid buffer overlap")
+	}
+	var expected [spongeWrapTagSize]byte
+	w.seal(expected[:], out, ciphertext, nonce, additionalData, true)
+	if subtle.ConstantTimeCompare(expected[:], tag) != 1 {
+		clear(out)
+		return nil, errSpongeWrapOpen
+	}
+	return ret, nil
+}
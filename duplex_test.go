@@ -0,0 +1,181 @@
+package aes
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"encoding/hex"
+	"testing"
+)
+
+// duplexReference computes one duplex call on a copy of state with
+// explicit padding.
+func duplexReference(state Areion512, in []byte, domain byte) Areion512 {
+	var block [64]byte
+	copy(block[:], in)
+	block[len(in)] ^= 0x01
+	block[63] ^= domain << 1
+	for i := range state {
+		state[i] ^= block[i]
+	}
+	state.Permute()
+	return state
+}
+
+func TestDuplexMatchesReference(t *testing.T) {
+	key := bytes.Repeat([]byte{0x11}, 32)
+	iv := bytes.Repeat([]byte{0x22}, 16)
+
+	var ref Areion512
+	copy(ref[:], key)
+	copy(ref[32:], iv)
+	ref.Permute()
+
+	d := NewDuplex(new(Areion512), 32, key, iv)
+	in := make([]byte, 63)
+	for i := range in {
+		in[i] = byte(i)
+	}
+	for _, n := range []int{0, 1, 32, 62, 63} {
+		ref = duplexReference(ref, in[:n], byte(n%4))
+		out := make([]byte, 32)
+		d.Duplex(out, in[:n], byte(n%4))
+		if !bytes.Equal(out, ref[:32]) {
+			t.Fatalf("%d bytes: got %x, expected %x", n, out, ref[:32])
+		}
+	}
+}
+
+func TestDuplexPanics(t *testing.T) {
+	d := NewDuplex(new(Areion512), 32, make([]byte, 32), nil)
+	for name, f := range map[string]func(){
+		"long input":  func() { d.Duplex(nil, make([]byte, 64), 0) },
+		"long output": func() { d.Duplex(make([]byte, 33), nil, 0) },
+		"domain":      func() { d.Duplex(nil, nil, 0x80) },
+		"rate":        func() { NewDuplex(new(Areion512), 64, nil, nil) },
+		"key":         func() { NewDuplex(new(Areion512), 32, make([]byte, 48), make([]byte, 17)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: no panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func newTestSpongeWrap(t testing.TB) cipher.AEAD {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	aead, err := NewSpongeWrap(func() Permutation { return new(Areion512) }, 32, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return aead
+}
+
+func TestSpongeWrapRoundTrip(t *testing.T) {
+	aead := newTestSpongeWrap(t)
+	nonce := make([]byte, aead.NonceSize())
+	msg := make([]byte, 200)
+	ad := make([]byte, 150)
+	for i := range msg {
+		msg[i] = byte(i * 7)
+	}
+	for _, n := range []int{0, 1, 31, 32, 33, 64, 200} {
+		for _, a := range []int{0, 1, 63, 64, 150} {
+			ct := aead.Seal(nil, nonce, msg[:n], ad[:a])
+			if len(ct) != n+aead.Overhead() {
+				t.Fatalf("ciphertext length %d, expected %d", len(ct), n+aead.Overhead())
+			}
+			pt, err := aead.Open(nil, nonce, ct, ad[:a])
+			if err != nil || !bytes.Equal(pt, msg[:n]) {
+				t.Fatalf("%d bytes, %d AD bytes: round trip failed: %v", n, a, err)
+			}
+
+			// in place
+			buf := append([]byte(nil), msg[:n]...)
+			ct2 := aead.Seal(buf[:0], nonce, buf, ad[:a])
+			if !bytes.Equal(ct2, ct) {
+				t.Fatalf("%d bytes: in-place Seal differs", n)
+			}
+			pt, err = aead.Open(ct2[:0], nonce, ct2, ad[:a])
+			if err != nil || !bytes.Equal(pt, msg[:n]) {
+				t.Fatalf("%d bytes: in-place Open failed: %v", n, err)
+			}
+		}
+	}
+}
+
+func TestSpongeWrapTamper(t *testing.T) {
+	aead := newTestSpongeWrap(t)
+	nonce := make([]byte, aead.NonceSize())
+	ct := aead.Seal(nil, nonce, []byte("attack at dawn, attack at dawn, attack!"), []byte("header"))
+	for i := range ct {
+		c := bytes.Clone(ct)
+		c[i] ^= 1
+		if pt, err := aead.Open(nil, nonce, c, []byte("header")); err == nil || pt != nil {
+			t.Fatalf("tampered byte %d accepted", i)
+		}
+	}
+	if _, err := aead.Open(nil, nonce, ct, []byte("Header")); err == nil {
+		t.Fatal("tampered AD accepted")
+	}
+	nonce[0] ^= 1
+	if _, err := aead.Open(nil, nonce, ct, []byte("header")); err == nil {
+		t.Fatal("wrong nonce accepted")
+	}
+	if _, err := aead.Open(nil, nonce, ct[:15], nil); err == nil {
+		t.Fatal("short ciphertext accepted")
+	}
+}
+
+func TestSpongeWrapErrors(t *testing.T) {
+	newSmall := func() Permutation { return new(Areion256) }
+	newBig := func() Permutation { return new(Areion512) }
+	if _, err := NewSpongeWrap(newSmall, 32, make([]byte, 16)); err == nil {
+		t.Error("rate without capacity accepted")
+	}
+	if _, err := NewSpongeWrap(newBig, 8, make([]byte, 16)); err == nil {
+		t.Error("rate shorter than the tag accepted")
+	}
+	if _, err := NewSpongeWrap(newBig, 32, make([]byte, 15)); err == nil {
+		t.Error("short key accepted")
+	}
+	if _, err := NewSpongeWrap(newSmall, 16, make([]byte, 17)); err == nil {
+		t.Error("key without room for the nonce accepted")
+	}
+	if _, err := NewSpongeWrap(newSmall, 16, make([]byte, 16)); err != nil {
+		t.Errorf("Areion256 with a 16-byte rate rejected: %v", err)
+	}
+}
+
+func TestSpongeWrapKnownAnswer(t *testing.T) {
+	aead := newTestSpongeWrap(t)
+	nonce := make([]byte, aead.NonceSize())
+	for i := range nonce {
+		nonce[i] = byte(0xf0 + i)
+	}
+	msg := make([]byte, 40)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+	ct := aead.Seal(nil, nonce, msg, []byte("associated data"))
+	expected := "17b5b4a296b21270b9cec168c242dc59aed9eecb4a86f95cacf64edc52f398b9bf176462cec0482ac86cb58fd4f0138eed3c224a4b0e14ff"
+	if hex.EncodeToString(ct) != expected {
+		t.Errorf("got %x, expected %s", ct, expected)
+	}
+}
+
+func BenchmarkSpongeWrapAreion512(b *testing.B) {
+	aead := newTestSpongeWrap(b)
+	nonce := make([]byte, aead.NonceSize())
+	buf := make([]byte, 1024, 1024+aead.Overhead())
+	b.SetBytes(int64(len(buf)))
+	for i := 0; i < b.N; i++ {
+		aead.Seal(buf[:0], nonce, buf, nil)
+	}
+}
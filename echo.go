@@ -0,0 +1,221 @@
+package aes
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"hash"
+)
+
+// ECHO (Benadjila, Billet, Gilbert, Macario-Rat, Peyrin, Robshaw and Seurin)
+// is a SHA-3 candidate that applies the structure of AES to a 2048-bit state
+// of 16 128-bit words, arranged in a 4x4 matrix with word i in column i/4 and
+// row i%4. A round is:
+//
+//	BIG.SubWords     two AES rounds on every word, keyed with a 128-bit counter
+//	                 that increases with every word, then with the salt
+//	BIG.ShiftRows    row r rotated left by r words
+//	BIG.MixColumns   the AES MixColumns applied bytewise to every column
+//
+// The chaining value fills the first column (ECHO-256) or the first two
+// columns (ECHO-512), and the message block the rest. After 8 (ECHO-256) or
+// 10 (ECHO-512) rounds, the input and the output of the rounds are added and
+// folded onto the chaining value. The counter starts at the number of message
+// bits hashed up to the end of the block, or 0 for a block without message
+// bits. The salt is zero.
+//
+// BIG.SubWords runs on one column at a time with Round4HW.
+
+const (
+	// ECHO256Size is the size of an ECHO-256 digest in bytes.
+	ECHO256Size = 32
+
+	// ECHO512Size is the size of an ECHO-512 digest in bytes.
+	ECHO512Size = 64
+
+	// ECHO256BlockSize is the message block size of ECHO-256 in bytes.
+	ECHO256BlockSize = 192
+
+	// ECHO512BlockSize is the message block size of ECHO-512 in bytes.
+	ECHO512BlockSize = 128
+
+	echoStateSize = 256
+)
+
+// echoState is the 4x4 matrix of words, stored column by column.
+type echoState [echoStateSize]byte
+
+// column returns the four words of column c.
+func (s *echoState) column(c int) *Block4 {
+	return (*Block4)(s[64*c:])
+}
+
+// echoMixColumns applies BIG.ShiftRows and BIG.MixColumns to s, eight byte
+// lanes at a time.
+func echoMixColumns(s *echoState) {
+	var w [32]uint64 // word i in lanes 2i and 2i+1
+	for i := range w {
+		w[i] = binary.LittleEndian.Uint64(s[8*i:])
+	}
+	for c := range 4 {
+		for h := range 2 {
+			a0 := w[(8*c+h)&31]
+			a1 := w[(8*c+8+2+h)&31]
+			a2 := w[(8*c+16+4+h)&31]
+			a3 := w[(8*c+24+6+h)&31]
+			all := a0 ^ a1 ^ a2 ^ a3
+			o := s[64*c+8*h:]
+			binary.LittleEndian.PutUint64(o[0:], all^a0^echoXtime(a0^a1))
+			binary.LittleEndian.PutUint64(o[16:], all^a1^echoXtime(a1^a2))
+			binary.LittleEndian.PutUint64(o[32:], all^a2^echoXtime(a2^a3))
+			binary.LittleEndian.PutUint64(o[48:], all^a3^echoXtime(a3^a0))
+		}
+	}
+}
+
+// echoXtime multiplies eight packed bytes by x in GF(2^8).
+func echoXtime(x uint64) uint64 {
+	return (x&0x7f7f7f7f7f7f7f7f)<<1 ^ (x>>7&0x0101010101010101)*0x1b
+}
+
+// echoCompress applies the compression function to the chaining value v
+// (64 or 128 bytes) and the message block m, with the counter (lo, hi).
+func echoCompress(v []byte, m []byte, lo, hi uint64, rounds int) {
+	var s echoState
+	copy(s[:], v)
+	copy(s[len(v):], m)
+	in := s
+
+	var keys, salt Key4
+	for range rounds {
+		for c := range 4 {
+			for r := range 4 {
+				binary.LittleEndian.PutUint64(keys[16*r:], lo)
+				binary.LittleEndian.PutUint64(keys[16*r+8:], hi)
+				lo++
+				if lo == 0 {
+					hi++
+				}
+			}
+			Round4HW(s.column(c), &keys)
+			Round4HW(s.column(c), &salt)
+		}
+		echoMixColumns(&s)
+	}
+
+	for c := range 4 {
+		XorBlock4(s.column(c), s.column(c), in.column(c))
+	}
+	clear(v)
+	for i := 0; i < echoStateSize; i += len(v) {
+		subtle.XORBytes(v, v, s[i:i+len(v)])
+	}
+}
+
+// ECHO computes ECHO-256 or ECHO-512 and implements hash.Hash. The zero
+// value is not ready for use; create instances with NewECHO256 or
+// NewECHO512.
+type ECHO struct {
+	v      [echoStateSize - ECHO512BlockSize]byte // chaining value, 64 or 128 bytes
+	buf    [ECHO256BlockSize]byte
+	n      int
+	len    uint64 // bytes hashed in full blocks
+	size   int
+	bs     int
+	rounds int
+}
+
+var _ hash.Hash = (*ECHO)(nil)
+
+// NewECHO256 returns a new ECHO-256 hash.
+func NewECHO256() *ECHO {
+	d := &ECHO{size: ECHO256Size, bs: ECHO256BlockSize, rounds: 8}
+	d.Reset()
+	return d
+}
+
+// NewECHO512 returns a new ECHO-512 hash.
+func NewECHO512() *ECHO {
+	d := &ECHO{size: ECHO512Size, bs: ECHO512BlockSize, rounds: 10}
+	d.Reset()
+	return d
+}
+
+// chain returns the chaining value.
+func (d *ECHO) chain() []byte {
+	return d.v[:echoStateSize-d.bs]
+}
+
+// Reset resets the hash to its initial state.
+func (d *ECHO) Reset() {
+	v := d.chain()
+	clear(v)
+	for i := 0; i < len(v); i += 16 {
+		binary.LittleEndian.PutUint16(v[i:], uint16(8*d.size))
+	}
+	d.n = 0
+	d.len = 0
+}
+
+// Size returns the digest size in bytes.
+func (d *ECHO) Size() int { return d.size }
+
+// BlockSize returns the message block size in bytes.
+func (d *ECHO) BlockSize() int { return d.bs }
+
+// block compresses a full message block.
+func (d *ECHO) block(m []byte) {
+	d.len += uint64(d.bs)
+	echoCompress(d.chain(), m, d.len<<3, d.len>>61, d.rounds)
+}
+
+// Write absorbs p. It never returns an error.
+func (d *ECHO) Write(p []byte) (int, error) {
+	nn := len(p)
+	buf := d.buf[:d.bs]
+	if d.n > 0 {
+		k := copy(buf[d.n:], p)
+		d.n += k
+		p = p[k:]
+		if d.n < d.bs {
+			return nn, nil
+		}
+		d.block(buf)
+		d.n = 0
+	}
+	for len(p) >= d.bs {
+		d.block(p[:d.bs])
+		p = p[d.bs:]
+	}
+	d.n = copy(buf, p)
+	return nn, nil
+}
+
+// Sum appends the digest to b. It does not change the underlying hash state.
+func (d *ECHO) Sum(b []byte) []byte {
+	dd := *d
+	v := dd.chain()
+	buf := dd.buf[:dd.bs]
+	total := dd.len + uint64(dd.n)
+	lo, hi := total<<3, total>>61
+
+	// The counter of a block is zero if it has no message bits.
+	var clo, chi uint64
+	if dd.n > 0 {
+		clo, chi = lo, hi
+	}
+	clear(buf[dd.n:])
+	buf[dd.n] = 0x80
+	if dd.n+1 > dd.bs-18 {
+		echoCompress(v, buf, clo, chi, dd.rounds)
+		clear(buf)
+		clo, chi = 0, 0
+	}
+	binary.LittleEndian.PutUint16(buf[dd.bs-18:], uint16(8*dd.size))
+	binary.LittleEndian.PutUint64(buf[dd.bs-16:], lo)
+	binary.LittleEndian.PutUint64(buf[dd.bs-8:], hi)
+	echoCompress(v, buf, clo, chi, dd.rounds)
+
+	ret, out := sliceForAppend(b, dd.size)
+	copy(out, v)
+	return ret
+}
@@ -0,0 +1,177 @@
+package aes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+)
+
+// echoReference hashes msg one word and one byte at a time, following the
+// description of ECHO with the single-block functions of this package.
+func echoReference(msg []byte, size int) []byte {
+	bs, rounds := ECHO256BlockSize, 8
+	if size == ECHO512Size {
+		bs, rounds = ECHO512BlockSize, 10
+	}
+	cv := echoStateSize - bs
+
+	// Pad the message and record the counter of each block.
+	bits := uint64(len(msg)) * 8
+	padded := append(append([]byte(nil), msg...), 0x80)
+	for len(padded)%bs != bs-18 {
+		padded = append(padded, 0)
+	}
+	padded = binary.LittleEndian.AppendUint16(padded, uint16(8*size))
+	padded = binary.LittleEndian.AppendUint64(padded, bits)
+	padded = binary.LittleEndian.AppendUint64(padded, 0)
+
+	var w [16]Block
+	v := make([]byte, cv)
+	for i := 0; i < cv; i += 16 {
+		v[i] = byte(8 * size)
+		v[i+1] = byte(8 * size >> 8)
+	}
+	for off := 0; off < len(padded); off += bs {
+		var counter uint64
+		if off < len(msg) {
+			counter = min(uint64(off+bs), uint64(len(msg))) * 8
+		}
+		block := append(append([]byte(nil), v...), padded[off:off+bs]...)
+		for i := range w {
+			copy(w[i][:], block[16*i:])
+		}
+		in := w
+
+		for range rounds {
+			for i := range w {
+				var k Block
+				binary.LittleEndian.PutUint64(k[:], counter)
+				counter++
+				Round(&w[i], &k)
+				Round(&w[i], &Block{})
+			}
+			var t [16]Block
+			for c := range 4 {
+				for r := range 4 {
+					t[4*c+r] = w[4*((c+r)%4)+r]
+				}
+			}
+			for c := range 4 {
+				for b := range 16 {
+					var col Block
+					for r := range 4 {
+						col[r] = t[4*c+r][b]
+					}
+					MixColumns(&col)
+					for r := range 4 {
+						w[4*c+r][b] = col[r]
+					}
+				}
+			}
+		}
+
+		clear(v)
+		for i := range w {
+			for j := range 16 {
+				v[(16*i+j)%cv] ^= w[i][j] ^ in[i][j]
+			}
+		}
+	}
+	return v[:size]
+}
+
+func TestECHOReference(t *testing.T) {
+	msg := make([]byte, 600)
+	for i := range msg {
+		msg[i] = byte(i * 7)
+	}
+	for _, size := range []int{ECHO256Size, ECHO512Size} {
+		for _, n := range []int{0, 1, 55, 110, 111, 127, 128, 173, 174, 175, 191, 192, 193, 384, 600} {
+			want := echoReference(msg[:n], size)
+			var h *ECHO
+			if size == ECHO256Size {
+				h = NewECHO256()
+			} else {
+				h = NewECHO512()
+			}
+			h.Write(msg[:n])
+			if got := h.Sum(nil); !bytes.Equal(got, want) {
+				t.Errorf("ECHO-%d, %d bytes: got %x, want %x", 8*size, n, got, want)
+			}
+		}
+	}
+}
+
+func TestECHOStreaming(t *testing.T) {
+	msg := make([]byte, 1000)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+	for _, newHash := range []func() *ECHO{NewECHO256, NewECHO512} {
+		h := newHash()
+		h.Write(msg)
+		want := h.Sum(nil)
+		if again := h.Sum(nil); !bytes.Equal(again, want) {
+			t.Fatal("Sum changed the hash state")
+		}
+		for _, step := range []int{1, 13, 64, 127, 192, 500} {
+			h.Reset()
+			for i := 0; i < len(msg); i += step {
+				h.Write(msg[i:min(i+step, len(msg))])
+			}
+			if got := h.Sum(nil); !bytes.Equal(got, want) {
+				t.Errorf("step %d: got %x, want %x", step, got, want)
+			}
+		}
+	}
+}
+
+// The empty-message digests are the Len = 0 entries of ShortMsgKAT_256.txt and
+// ShortMsgKAT_512.txt in the ECHO round 2 SHA-3 submission package. The "abc"
+// digests are regression values.
+func TestECHOKnownAnswers(t *testing.T) {
+	tests := []struct {
+		newHash func() *ECHO
+		msg     string
+		want    string
+	}{
+		{NewECHO256, "",
+			"4496cd09d425999aefa75189ee7fd3c97362aa9e4ca898328002d20a4b519788"},
+		{NewECHO256, "abc",
+			"871b1fad479135c37e1aad71ac9a99def41730f3e5b3e0dc3f6b7cf072fa5649"},
+		{NewECHO512, "",
+			"158f58cc79d300a9aa292515049275d051a28ab931726d0ec44bdd9faef4a702c36db9e7922fff077402236465833c5cc76af4efc352b4b44c7fa15aa0ef234e"},
+		{NewECHO512, "abc",
+			"3bf04ec89d67e0dafd1b8ab26b176abaead6b3cdc706ff7198c3c6045e77d4eaf64cd90af9c5a7674919b90ff8c9b4a7554d6cfeffb334406ec233fb0b0dd6bc"},
+	}
+	for _, tc := range tests {
+		h := tc.newHash()
+		h.Write([]byte(tc.msg))
+		if got := hex.EncodeToString(h.Sum(nil)); got != tc.want {
+			t.Errorf("ECHO-%d(%q) = %s, want %s", 8*h.Size(), tc.msg, got, tc.want)
+		}
+	}
+}
+
+func BenchmarkECHO256(b *testing.B) {
+	buf := make([]byte, 8192)
+	h := NewECHO256()
+	b.SetBytes(int64(len(buf)))
+	for b.Loop() {
+		h.Reset()
+		h.Write(buf)
+		h.Sum(nil)
+	}
+}
+
+func BenchmarkECHO512(b *testing.B) {
+	buf := make([]byte, 8192)
+	h := NewECHO512()
+	b.SetBytes(int64(len(buf)))
+	for b.Loop() {
+		h.Reset()
+		h.Write(buf)
+		h.Sum(nil)
+	}
+}
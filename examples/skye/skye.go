@@ -3,6 +3,7 @@ package skye
 import (
 	"errors"
 	"fmt"
+	"io"
 
 	aes "github.com/jedisct1/go-aes"
 )
@@ -97,73 +98,12 @@ func FExp(key *aes.Block, info *SkyeInfo, length int) []byte {
 		return nil
 	}
 
-	const blockSize = 16
-	const blocksPerCall = 8
-
-	numBlocks := (length + blockSize - 1) / blockSize
-
-	// Construct tweakey for ButterKnife: key || info[0:16]
-	var tweakey aes.Tweakey256
-	copy(tweakey[0:16], key[:])
-	copy(tweakey[16:32], info[0:16])
-
-	// First input: info[16:32]
-	var input aes.Block
-	copy(input[:], info[16:32])
-
-	// First ButterKnife call
-	output := aes.ButterKnife(&tweakey, &input)
-
-	// Store K1 and K2 for subsequent calls
-	K1 := output[0]
-	K2 := output[1]
-
-	// Build result
-	result := make([]byte, 0, numBlocks*blockSize)
-
-	blocksToAdd := numBlocks
-	if blocksToAdd > blocksPerCall {
-		blocksToAdd = blocksPerCall
-	}
-	for i := 0; i < blocksToAdd; i++ {
-		result = append(result, output[i][:]...)
-	}
-
-	if numBlocks <= blocksPerCall {
-		return result[:length]
+	result := make([]byte, length)
+	xof := aes.NewButterKnifeXOF(key, (*[32]byte)(info))
+	if _, err := io.ReadFull(xof, result); err != nil {
+		panic("FExp: requested length exceeds ButterKnifeXOF output limit")
 	}
-
-	// Generate additional blocks
-	blocksRemaining := numBlocks - blocksPerCall
-	counter := 0
-
-	for blocksRemaining > 0 {
-		// Update tweakey: key || K1
-		copy(tweakey[16:32], K1[:])
-
-		// Input: K2 ⊕ ⟨counter⟩ (big-endian encoding)
-		var counterInput aes.Block
-		copy(counterInput[:], K2[:])
-		counterInput[15] ^= byte(counter)
-		counterInput[14] ^= byte(counter >> 8)
-		counterInput[13] ^= byte(counter >> 16)
-		counterInput[12] ^= byte(counter >> 24)
-
-		output = aes.ButterKnife(&tweakey, &counterInput)
-
-		blocksToAdd = blocksRemaining
-		if blocksToAdd > blocksPerCall {
-			blocksToAdd = blocksPerCall
-		}
-		for i := 0; i < blocksToAdd; i++ {
-			result = append(result, output[i][:]...)
-		}
-
-		blocksRemaining -= blocksToAdd
-		counter++
-	}
-
-	return result[:length]
+	return result
 }
 
 // Skye derives key material from DH samples (DExtLsb + FExp).
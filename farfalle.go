@@ -0,0 +1,175 @@
+package aes
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// Farfalle (Bertoni, Daemen, Hoffert, Peeters, Van Assche and Van Keer) is a
+// construction for deck functions: keyed functions that take a sequence of
+// strings and return an arbitrarily long output, incrementally.
+//
+// AreionFarfalle uses Areion512 for all four permutations of the
+// construction. With the state seen as 16 little-endian 32-bit words, the
+// rolling functions are those of Xoofff extended to the whole state:
+//
+//	roll_c: w0 ← w0 ⊕ (w0 << 13) ⊕ (w1 <<< 3), then rotate the words by one
+//	roll_e: w0 ← (w1 ∧ w2) ⊕ (w0 <<< 5) ⊕ (w1 <<< 13) ⊕ 7, then rotate
+//
+// The key K (16 to 63 bytes) gives the mask k0 = P(K || 0x01 || 0*), and
+// ki = roll_c^i(k0). Every string is followed by its frame bits and a 1 bit,
+// padded to a multiple of 64 bytes, and its blocks Mi are compressed in
+// parallel with the masks of their position in the sequence:
+//
+//	x = Σ P(Mi ⊕ ki)
+//
+// The output is expanded in parallel from y = P(x):
+//
+//	Zj = P(roll_e^j(y)) ⊕ k'
+//
+// where k' = roll_c(kn) and kn is the mask of the next input block. Blocks
+// are processed four at a time with Areion512x4.
+
+// AreionFarfalleBlockSize is the input and output block size in bytes.
+const AreionFarfalleBlockSize = 64
+
+// AreionFarfalle is a Farfalle deck function over Areion512. Absorb appends a
+// string to the input sequence, and Expand reads the output of the sequence
+// absorbed so far without changing it.
+type AreionFarfalle struct {
+	key  Areion512 // k0
+	mask Areion512 // mask of the next input block
+	acc  Areion512 // sum of the compressed blocks
+}
+
+// NewAreionFarfalle returns a deck function keyed with key, which must be 16
+// to 63 bytes long, with an empty input sequence.
+func NewAreionFarfalle(key []byte) (*AreionFarfalle, error) {
+	if len(key) < 16 || len(key) >= AreionFarfalleBlockSize {
+		return nil, errors.New("aes: invalid Farfalle key size")
+	}
+	f := &AreionFarfalle{}
+	copy(f.key[:], key)
+	f.key[len(key)] = 0x01
+	f.key.Permute()
+	f.Reset()
+	return f, nil
+}
+
+// Reset empties the input sequence, keeping the key.
+func (f *AreionFarfalle) Reset() {
+	f.mask = f.key
+	clear(f.acc[:])
+}
+
+// Clone returns a copy of f, which can absorb further strings independently.
+func (f *AreionFarfalle) Clone() *AreionFarfalle {
+	c := *f
+	return &c
+}
+
+// Absorb appends the string m to the input sequence.
+func (f *AreionFarfalle) Absorb(m []byte) {
+	f.absorb(m, 0, 0)
+}
+
+// absorb appends m followed by the n low bits of frame, n <= 7.
+func (f *AreionFarfalle) absorb(m []byte, frame byte, n int) {
+	var batch Areion512x4
+	for len(m) >= 4*AreionFarfalleBlockSize {
+		for i := range batch {
+			farfalleXor(&batch[i], (*Areion512)(m[64*i:]), &f.mask)
+			farfalleRollC(&f.mask)
+		}
+		batch.Permute()
+		for i := range batch {
+			farfalleXor(&f.acc, &f.acc, &batch[i])
+		}
+		m = m[4*AreionFarfalleBlockSize:]
+	}
+	for len(m) >= AreionFarfalleBlockSize {
+		f.compress((*Areion512)(m))
+		m = m[AreionFarfalleBlockSize:]
+	}
+	var last Areion512
+	copy(last[:], m)
+	last[len(m)] = frame | 1<<n
+	f.compress(&last)
+}
+
+// compress adds P(b ⊕ mask) to the accumulator and rolls the mask.
+func (f *AreionFarfalle) compress(b *Areion512) {
+	var x Areion512
+	farfalleXor(&x, b, &f.mask)
+	farfalleRollC(&f.mask)
+	x.Permute()
+	farfalleXor(&f.acc, &f.acc, &x)
+}
+
+// Expand fills out with the output of the input sequence, starting at byte
+// offset.
+func (f *AreionFarfalle) Expand(out []byte, offset int) {
+	clear(out)
+	f.expandXOR(out, offset)
+}
+
+// expandXOR adds the output, starting at byte offset, to dst.
+func (f *AreionFarfalle) expandXOR(dst []byte, offset int) {
+	if len(dst) == 0 {
+		return
+	}
+	y := f.acc
+	y.Permute()
+	k := f.mask
+	farfalleRollC(&k)
+	for range offset / AreionFarfalleBlockSize {
+		farfalleRollE(&y)
+	}
+	skip := offset % AreionFarfalleBlockSize
+
+	var batch Areion512x4
+	for len(dst) > 0 {
+		nb := min((skip+len(dst)+AreionFarfalleBlockSize-1)/AreionFarfalleBlockSize, len(batch))
+		for i := range nb {
+			batch[i] = y
+			farfalleRollE(&y)
+		}
+		if nb == 1 {
+			batch[0].Permute()
+		} else {
+			batch.Permute()
+		}
+		for i := range nb {
+			farfalleXor(&batch[i], &batch[i], &k)
+			n := subtle.XORBytes(dst, dst, batch[i][skip:])
+			dst = dst[n:]
+			skip = 0
+		}
+	}
+}
+
+// farfalleXor computes dst = a XOR b.
+func farfalleXor(dst, a, b *Areion512) {
+	XorBlock4((*Block4)(dst), (*Block4)(a), (*Block4)(b))
+}
+
+// farfalleRollC applies roll_c to s.
+func farfalleRollC(s *Areion512) {
+	w0 := binary.LittleEndian.Uint32(s[0:])
+	w1 := binary.LittleEndian.Uint32(s[4:])
+	t := w0 ^ w0<<13 ^ bits.RotateLeft32(w1, 3)
+	copy(s[:60], s[4:])
+	binary.LittleEndian.PutUint32(s[60:], t)
+}
+
+// farfalleRollE applies roll_e to s.
+func farfalleRollE(s *Areion512) {
+	w0 := binary.LittleEndian.Uint32(s[0:])
+	w1 := binary.LittleEndian.Uint32(s[4:])
+	w2 := binary.LittleEndian.Uint32(s[8:])
+	t := w1&w2 ^ bits.RotateLeft32(w0, 5) ^ bits.RotateLeft32(w1, 13) ^ 7
+	copy(s[:60], s[4:])
+	binary.LittleEndian.PutUint32(s[60:], t)
+}
@@ -0,0 +1,261 @@
+package aes
+
+import (
+	"crypto/subtle"
+	"errors"
+)
+
+// Session and wide-block modes of the Farfalle deck function over Areion512.
+//
+// The modes append frame bits to the strings they absorb, before the padding
+// bit. With e the session bit, which flips after every message:
+//
+//	Deck-SANE   A || 0 || e, C || 1 || e
+//	Deck-SANSE  A || 0 || e, P || 01 || e (tag), T || 11 || e (key stream)
+//	Deck-WBC    X || side || round kind, after the tweak W
+//
+// The tag of SANE and SANSE is the first 32 bytes of the output. SANE
+// encrypts with the output that follows the tag of the same history.
+
+// AreionFarfalleTagSize is the tag size of the session modes in bytes.
+const AreionFarfalleTagSize = 32
+
+var errAreionFarfalleOpen = errors.New("aes: Farfalle message authentication failed")
+
+// AreionSANE is a Deck-SANE session: a nonce-based authenticated encryption
+// session, where every tag authenticates all the previous messages in order.
+// Sessions are not safe for concurrent use.
+type AreionSANE struct {
+	f AreionFarfalle // history
+	e byte
+}
+
+// NewAreionSANE starts a session keyed with key (16 to 63 bytes) and the given
+// nonce, and returns the initial tag. The receiver starts its own session
+// with the same key and nonce, and compares the tags.
+func NewAreionSANE(key, nonce []byte) (*AreionSANE, []byte, error) {
+	f, err := NewAreionFarfalle(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	f.Absorb(nonce)
+	tag := make([]byte, AreionFarfalleTagSize)
+	f.Expand(tag, 0)
+	return &AreionSANE{f: *f}, tag, nil
+}
+
+// Seal encrypts plaintext, authenticates it with additionalData and the
+// session history, and appends the ciphertext and the tag to dst.
+func (s *AreionSANE) Seal(dst, plaintext, additionalData []byte) []byte {
+	ret, out := sliceForAppend(dst, len(plaintext)+AreionFarfalleTagSize)
+	if inexactOverlap(out, plaintext) {
+		panic("aes: invalid buffer overlap")
+	}
+	c, tag := out[:len(plaintext)], out[len(plaintext):]
+	copy(c, plaintext)
+	s.f.expandXOR(c, AreionFarfalleTagSize)
+
+	if len(additionalData) > 0 || len(plaintext) == 0 {
+		s.f.absorb(additionalData, s.e<<1, 2)
+	}
+	if len(plaintext) > 0 {
+		s.f.absorb(c, 1|s.e<<1, 2)
+	}
+	s.f.Expand(tag, 0)
+	s.e ^= 1
+	return ret
+}
+
+// Open authenticates ciphertext with additionalData and the session history,
+// decrypts it and appends the plaintext to dst. If authentication fails, the
+// session is left unchanged and nothing is written.
+func (s *AreionSANE) Open(dst, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < AreionFarfalleTagSize {
+		return nil, errAreionFarfalleOpen
+	}
+	n := len(ciphertext) - AreionFarfalleTagSize
+	c, tag := ciphertext[:n], ciphertext[n:]
+
+	f := s.f
+	if len(additionalData) > 0 || n == 0 {
+		f.absorb(additionalData, s.e<<1, 2)
+	}
+	if n > 0 {
+		f.absorb(c, 1|s.e<<1, 2)
+	}
+	var expected [AreionFarfalleTagSize]byte
+	f.Expand(expected[:], 0)
+	if subtle.ConstantTimeCompare(expected[:], tag) != 1 {
+		return nil, errAreionFarfalleOpen
+	}
+
+	ret, out := sliceForAppend(dst, n)
+	if inexactOverlap(out, c) {
+		panic("aes: invalid buffer overlap")
+	}
+	copy(out, c)
+	s.f.expandXOR(out, AreionFarfalleTagSize)
+	s.f = f
+	s.e ^= 1
+	return ret, nil
+}
+
+// AreionSANSE is a Deck-SANSE session: authenticated encryption without a
+// nonce, in the style of SIV, where every tag authenticates all the previous
+// messages in order. Repeating a session only reveals whether the same
+// sequence of messages was sent. Sessions are not safe for concurrent use.
+type AreionSANSE struct {
+	f AreionFarfalle // history
+	e byte
+}
+
+// NewAreionSANSE starts a session keyed with key (16 to 63 bytes).
+func NewAreionSANSE(key []byte) (*AreionSANSE, error) {
+	f, err := NewAreionFarfalle(key)
+	if err != nil {
+		return nil, err
+	}
+	return &AreionSANSE{f: *f}, nil
+}
+
+// Seal encrypts plaintext, authenticates it with additionalData and the
+// session history, and appends the ciphertext and the tag to dst.
+func (s *AreionSANSE) Seal(dst, plaintext, additionalData []byte) []byte {
+	ret, out := sliceForAppend(dst, len(plaintext)+AreionFarfalleTagSize)
+	if inexactOverlap(out, plaintext) {
+		panic("aes: invalid buffer overlap")
+	}
+	c, tag := out[:len(plaintext)], out[len(plaintext):]
+
+	if len(additionalData) > 0 || len(plaintext) == 0 {
+		s.f.absorb(additionalData, s.e<<1, 2)
+	}
+	if len(plaintext) == 0 {
+		s.f.Expand(tag, 0)
+		s.e ^= 1
+		return ret
+	}
+
+	g := s.f
+	g.absorb(plaintext, 2|s.e<<2, 3)
+	g.Expand(tag, 0)
+
+	h := s.f
+	h.absorb(tag, 3|s.e<<2, 3)
+	copy(c, plaintext)
+	h.expandXOR(c, 0)
+
+	s.f = g
+	s.e ^= 1
+	return ret
+}
+
+// Open decrypts ciphertext, authenticates the plaintext with additionalData
+// and the session history, and appends it to dst. If authentication fails,
+// the session is left unchanged and the output is cleared.
+func (s *AreionSANSE) Open(dst, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < AreionFarfalleTagSize {
+		return nil, errAreionFarfalleOpen
+	}
+	n := len(ciphertext) - AreionFarfalleTagSize
+	c, tag := ciphertext[:n], ciphertext[n:]
+
+	ret, out := sliceForAppend(dst, n)
+	if inexactOverlap(out, c) {
+		panic("aes: invalid buffer overlap")
+	}
+
+	f := s.f
+	if len(additionalData) > 0 || n == 0 {
+		f.absorb(additionalData, s.e<<1, 2)
+	}
+	var expected [AreionFarfalleTagSize]byte
+	if n > 0 {
+		h := f
+		h.absorb(tag, 3|s.e<<2, 3)
+		copy(out, c)
+		h.expandXOR(out, 0)
+		f.absorb(out, 2|s.e<<2, 3)
+	}
+	f.Expand(expected[:], 0)
+	if subtle.ConstantTimeCompare(expected[:], tag) != 1 {
+		clear(out)
+		return nil, errAreionFarfalleOpen
+	}
+	s.f = f
+	s.e ^= 1
+	return ret, nil
+}
+
+// AreionWBC is the Deck-WBC tweakable wide-block cipher: a four-round Feistel
+// network whose round functions are the deck function applied to the tweak
+// and one half. It encrypts messages of any length from 2 bytes, without
+// expansion. The first and last rounds only mask the first 64 bytes of a
+// half. It is safe for concurrent use.
+type AreionWBC struct {
+	f AreionFarfalle
+}
+
+// NewAreionWBC returns the wide-block cipher keyed with key (16 to 63 bytes).
+func NewAreionWBC(key []byte) (*AreionWBC, error) {
+	f, err := NewAreionFarfalle(key)
+	if err != nil {
+		return nil, err
+	}
+	return &AreionWBC{f: *f}, nil
+}
+
+// Frame bits of the WBC rounds: the half given to the deck function, then
+// whether the round masks a whole half.
+const (
+	wbcFrameHL = 0 // H(L)
+	wbcFrameHR = 1 // H(R)
+	wbcFrameGL = 2 // G(L)
+	wbcFrameGR = 3 // G(R)
+)
+
+// round adds the output of the deck function on the tweak and x to dst.
+func (w *AreionWBC) round(base *AreionFarfalle, dst, x []byte, frame byte) {
+	f := *base
+	f.absorb(x, frame, 2)
+	f.expandXOR(dst, 0)
+}
+
+// halves copies src to dst and returns the two halves of dst.
+func (w *AreionWBC) halves(dst, src []byte) (l, r []byte) {
+	if len(src) < 2 {
+		panic("aes: Farfalle-WBC input too short")
+	}
+	if len(dst) < len(src) {
+		panic("aes: output smaller than input")
+	}
+	if inexactOverlap(dst[:len(src)], src) {
+		panic("aes: invalid buffer overlap")
+	}
+	copy(dst, src)
+	n := len(src) / 2
+	return dst[:n], dst[n:len(src)]
+}
+
+// Encrypt encrypts src into dst under the given tweak. dst must be at least
+// as long as src, and may overlap src exactly.
+func (w *AreionWBC) Encrypt(dst, src, tweak []byte) {
+	l, r := w.halves(dst, src)
+	base := w.f
+	base.Absorb(tweak)
+	w.round(&base, r[:min(len(r), AreionFarfalleBlockSize)], l, wbcFrameHL)
+	w.round(&base, l, r, wbcFrameGR)
+	w.round(&base, r, l, wbcFrameGL)
+	w.round(&base, l[:min(len(l), AreionFarfalleBlockSize)], r, wbcFrameHR)
+}
+
+// Decrypt decrypts src into dst under the given tweak.
+func (w *AreionWBC) Decrypt(dst, src, tweak []byte) {
+	l, r := w.halves(dst, src)
+	base := w.f
+	base.Absorb(tweak)
+	w.round(&base, l[:min(len(l), AreionFarfalleBlockSize)], r, wbcFrameHR)
+	w.round(&base, r, l, wbcFrameGL)
+	w.round(&base, l, r, wbcFrameGR)
+	w.round(&base, r[:min(len(r), AreionFarfalleBlockSize)], l, wbcFrameHL)
+}
@@ -0,0 +1,357 @@
+package aes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"math/bits"
+	"testing"
+)
+
+// farfalleString is an input string with its frame bits.
+type farfalleString struct {
+	m     []byte
+	frame byte
+	n     int
+}
+
+// farfalleRoll applies roll_c, or roll_e if expand is set, on words.
+func farfalleRoll(s *Areion512, expand bool) {
+	var w [17]uint32
+	for i := range 16 {
+		w[i] = binary.LittleEndian.Uint32(s[4*i:])
+	}
+	if expand {
+		w[16] = w[1]&w[2] ^ bits.RotateLeft32(w[0], 5) ^ bits.RotateLeft32(w[1], 13) ^ 7
+	} else {
+		w[16] = w[0] ^ w[0]<<13 ^ bits.RotateLeft32(w[1], 3)
+	}
+	for i := range 16 {
+		binary.LittleEndian.PutUint32(s[4*i:], w[i+1])
+	}
+}
+
+// farfalleReference computes the deck function one block at a time.
+func farfalleReference(key []byte, seq []farfalleString, out []byte, offset int) {
+	var k Areion512
+	copy(k[:], key)
+	k[len(key)] = 0x01
+	k.Permute()
+
+	var blocks []Areion512
+	for _, s := range seq {
+		padded := append(bytes.Clone(s.m), s.frame|1<<s.n)
+		for len(padded)%64 != 0 {
+			padded = append(padded, 0)
+		}
+		for i := 0; i < len(padded); i += 64 {
+			blocks = append(blocks, Areion512(padded[i:]))
+		}
+	}
+
+	var x Areion512
+	for _, b := range blocks {
+		for i := range b {
+			b[i] ^= k[i]
+		}
+		b.Permute()
+		for i := range x {
+			x[i] ^= b[i]
+		}
+		farfalleRoll(&k, false)
+	}
+	farfalleRoll(&k, false)
+
+	y := x
+	y.Permute()
+	var stream []byte
+	for len(stream) < offset+len(out) {
+		z := y
+		z.Permute()
+		for i := range z {
+			z[i] ^= k[i]
+		}
+		stream = append(stream, z[:]...)
+		farfalleRoll(&y, true)
+	}
+	copy(out, stream[offset:])
+}
+
+var farfalleTestKey = []byte("0123456789abcdef0123456789abcdef")
+
+func farfalleTestInput(n int) []byte {
+	m := make([]byte, n)
+	for i := range m {
+		m[i] = byte(i*13 + 1)
+	}
+	return m
+}
+
+func TestAreionFarfalleMatchesReference(t *testing.T) {
+	f, err := NewAreionFarfalle(farfalleTestKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []int{0, 1, 63, 64, 255, 256, 257, 600} {
+		seq := []farfalleString{{m: farfalleTestInput(n)}, {m: farfalleTestInput(n / 3)}}
+		f.Reset()
+		for _, s := range seq {
+			f.Absorb(s.m)
+		}
+		for _, offset := range []int{0, 1, 64, 100} {
+			for _, outLen := range []int{1, 64, 65, 300} {
+				want := make([]byte, outLen)
+				farfalleReference(farfalleTestKey, seq, want, offset)
+				got := make([]byte, outLen)
+				f.Expand(got, offset)
+				if !bytes.Equal(got, want) {
+					t.Fatalf("n=%d offset=%d len=%d: got %x, expected %x", n, offset, outLen, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestAreionFarfalleFrameBits(t *testing.T) {
+	f, _ := NewAreionFarfalle(farfalleTestKey)
+	seq := []farfalleString{{m: farfalleTestInput(70), frame: 2, n: 2}, {m: nil, frame: 5, n: 3}}
+	for _, s := range seq {
+		f.absorb(s.m, s.frame, s.n)
+	}
+	want := make([]byte, 100)
+	farfalleReference(farfalleTestKey, seq, want, 0)
+	got := make([]byte, 100)
+	f.Expand(got, 0)
+	if !bytes.Equal(got, want) {
+		t.Fatalf("got %x, expected %x", got, want)
+	}
+}
+
+func TestAreionFarfalleIncremental(t *testing.T) {
+	f, _ := NewAreionFarfalle(farfalleTestKey)
+	f.Absorb([]byte("first"))
+	a := make([]byte, 80)
+	f.Expand(a, 0)
+	b := make([]byte, 80)
+	f.Expand(b, 0)
+	if !bytes.Equal(a, b) {
+		t.Fatal("Expand changed the state")
+	}
+
+	c := f.Clone()
+	c.Absorb([]byte("second"))
+	f.Expand(b, 0)
+	if !bytes.Equal(a, b) {
+		t.Fatal("absorbing into a clone changed the original")
+	}
+	c.Expand(b, 0)
+	if bytes.Equal(a, b) {
+		t.Fatal("absorbing a string did not change the output")
+	}
+
+	// string boundaries matter
+	g, _ := NewAreionFarfalle(farfalleTestKey)
+	g.Absorb([]byte("firstsecond"))
+	g.Expand(a, 0)
+	if bytes.Equal(a, b) {
+		t.Fatal("string boundaries are not encoded")
+	}
+}
+
+func TestAreionFarfalleKeySize(t *testing.T) {
+	for _, n := range []int{0, 15, 64} {
+		if _, err := NewAreionFarfalle(make([]byte, n)); err == nil {
+			t.Errorf("%d-byte key accepted", n)
+		}
+	}
+	for _, n := range []int{16, 32, 63} {
+		if _, err := NewAreionFarfalle(make([]byte, n)); err != nil {
+			t.Errorf("%d-byte key rejected: %v", n, err)
+		}
+	}
+}
+
+func TestAreionSANE(t *testing.T) {
+	nonce := []byte("nonce")
+	alice, t0, err := NewAreionSANE(farfalleTestKey, nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, t1, _ := NewAreionSANE(farfalleTestKey, nonce)
+	if !bytes.Equal(t0, t1) {
+		t.Fatal("initial tags differ")
+	}
+	if _, t2, _ := NewAreionSANE(farfalleTestKey, []byte("other")); bytes.Equal(t0, t2) {
+		t.Fatal("initial tags do not depend on the nonce")
+	}
+
+	msgs := []struct{ ad, pt []byte }{
+		{nil, farfalleTestInput(10)},
+		{[]byte("header"), farfalleTestInput(200)},
+		{[]byte("ad only"), nil},
+		{nil, nil},
+		{[]byte("x"), farfalleTestInput(64)},
+	}
+	var first []byte
+	for i, m := range msgs {
+		ct := alice.Seal(nil, m.pt, m.ad)
+		if i == 0 {
+			first = ct
+		}
+
+		// a tampered message is rejected and does not disturb the session
+		bad := bytes.Clone(ct)
+		bad[0] ^= 1
+		if _, err := bob.Open(nil, bad, m.ad); err == nil {
+			t.Fatalf("message %d: tampered ciphertext accepted", i)
+		}
+
+		pt, err := bob.Open(nil, ct, m.ad)
+		if err != nil || !bytes.Equal(pt, m.pt) {
+			t.Fatalf("message %d: Open failed: %v", i, err)
+		}
+	}
+
+	// replaying the first message in a later position fails
+	if _, err := bob.Open(nil, first, msgs[0].ad); err == nil {
+		t.Fatal("replayed message accepted")
+	}
+}
+
+func TestAreionSANEInPlace(t *testing.T) {
+	alice, _, _ := NewAreionSANE(farfalleTestKey, nil)
+	bob, _, _ := NewAreionSANE(farfalleTestKey, nil)
+	buf := farfalleTestInput(100)
+	ct := alice.Seal(buf[:0], buf, nil)
+	pt, err := bob.Open(ct[:0], ct, nil)
+	if err != nil || !bytes.Equal(pt, farfalleTestInput(100)) {
+		t.Fatalf("in-place round trip failed: %v", err)
+	}
+}
+
+func TestAreionSANSE(t *testing.T) {
+	alice, _ := NewAreionSANSE(farfalleTestKey)
+	bob, _ := NewAreionSANSE(farfalleTestKey)
+	msgs := []struct{ ad, pt []byte }{
+		{nil, farfalleTestInput(10)},
+		{[]byte("header"), farfalleTestInput(200)},
+		{[]byte("ad only"), nil},
+		{nil, farfalleTestInput(1)},
+	}
+	var cts [][]byte
+	for i, m := range msgs {
+		buf := bytes.Clone(m.pt)
+		ct := alice.Seal(buf[:0], buf, m.ad)
+		cts = append(cts, bytes.Clone(ct))
+
+		bad := bytes.Clone(ct)
+		bad[len(bad)-1] ^= 1
+		if _, err := bob.Open(nil, bad, m.ad); err == nil {
+			t.Fatalf("message %d: tampered tag accepted", i)
+		}
+		if _, err := bob.Open(nil, ct, append([]byte("z"), m.ad...)); err == nil {
+			t.Fatalf("message %d: tampered AD accepted", i)
+		}
+
+		pt, err := bob.Open(ct[:0], ct, m.ad)
+		if err != nil || !bytes.Equal(pt, m.pt) {
+			t.Fatalf("message %d: Open failed: %v", i, err)
+		}
+	}
+
+	// a new session with the same messages is deterministic
+	carol, _ := NewAreionSANSE(farfalleTestKey)
+	for i, m := range msgs {
+		if ct := carol.Seal(nil, m.pt, m.ad); !bytes.Equal(ct, cts[i]) {
+			t.Fatalf("message %d: sessions are not deterministic", i)
+		}
+	}
+}
+
+func TestAreionWBC(t *testing.T) {
+	w, err := NewAreionWBC(farfalleTestKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tweak := []byte("tweak")
+	for _, n := range []int{2, 3, 64, 127, 128, 129, 300, 1000} {
+		pt := farfalleTestInput(n)
+		ct := make([]byte, n)
+		w.Encrypt(ct, pt, tweak)
+		if bytes.Equal(ct, pt) {
+			t.Fatalf("n=%d: ciphertext equals plaintext", n)
+		}
+		got := bytes.Clone(ct)
+		w.Decrypt(got, got, tweak)
+		if !bytes.Equal(got, pt) {
+			t.Fatalf("n=%d: round trip failed", n)
+		}
+
+		other := make([]byte, n)
+		w.Encrypt(other, pt, []byte("tweak2"))
+		if bytes.Equal(other, ct) {
+			t.Fatalf("n=%d: the tweak is ignored", n)
+		}
+
+		// a change anywhere changes both ends of the ciphertext
+		pt[n-1] ^= 1
+		w.Encrypt(other, pt, tweak)
+		if other[0] == ct[0] && other[n-1] == ct[n-1] {
+			t.Fatalf("n=%d: poor diffusion", n)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("1-byte input accepted")
+		}
+	}()
+	w.Encrypt(make([]byte, 1), []byte{0}, nil)
+}
+
+func TestAreionFarfalleKnownAnswers(t *testing.T) {
+	f, _ := NewAreionFarfalle(farfalleTestKey)
+	f.Absorb([]byte("abc"))
+	out := make([]byte, 32)
+	f.Expand(out, 0)
+	if got, want := hex.EncodeToString(out), "b1113e51a6e1acc20cf09f8f7440b4264e7d3d75fb630c467bf83b5ec0ff766a"; got != want {
+		t.Errorf("deck: got %s, expected %s", got, want)
+	}
+
+	s, tag, _ := NewAreionSANE(farfalleTestKey, []byte("nonce"))
+	ct := s.Seal(tag, []byte("plaintext"), []byte("ad"))
+	if got, want := hex.EncodeToString(ct), "f13640a35331f8b6f4bed0bc34097b9bdcb186903d75a7862276ccba3e3ffb7cedbd318ebd01bb4048aa8f2e17d50b4e6ac852c48553a67cea3de0b864b2fcb5103f0c4820e93a3903"; got != want {
+		t.Errorf("SANE: got %s, expected %s", got, want)
+	}
+
+	ss, _ := NewAreionSANSE(farfalleTestKey)
+	ct = ss.Seal(nil, []byte("plaintext"), []byte("ad"))
+	if got, want := hex.EncodeToString(ct), "ee4edefc4120810414b542d7e94c89c326e4afcd8a2fcd54e7f08622561f7fa744b438743d0731d05d"; got != want {
+		t.Errorf("SANSE: got %s, expected %s", got, want)
+	}
+
+	w, _ := NewAreionWBC(farfalleTestKey)
+	ct = make([]byte, 40)
+	w.Encrypt(ct, farfalleTestInput(40), []byte("tweak"))
+	if got, want := hex.EncodeToString(ct), "5dab85743f3dfceb0df7ed02922492165b6ef16c080322271aaaedb0ac0d541702f80dd3499d1fe6"; got != want {
+		t.Errorf("WBC: got %s, expected %s", got, want)
+	}
+}
+
+func BenchmarkAreionFarfalleAbsorb(b *testing.B) {
+	f, _ := NewAreionFarfalle(farfalleTestKey)
+	buf := make([]byte, 16384)
+	b.SetBytes(int64(len(buf)))
+	for i := 0; i < b.N; i++ {
+		f.Absorb(buf)
+	}
+}
+
+func BenchmarkAreionFarfalleExpand(b *testing.B) {
+	f, _ := NewAreionFarfalle(farfalleTestKey)
+	buf := make([]byte, 16384)
+	b.SetBytes(int64(len(buf)))
+	for i := 0; i < b.N; i++ {
+		f.Expand(buf, 0)
+	}
+}
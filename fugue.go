@@ -0,0 +1,228 @@
+package aes
+
+import (
+	"encoding/binary"
+	"hash"
+)
+
+// Fugue (Halevi, Hall and Jutla) is a SHA-3 candidate that absorbs the
+// message one 32-bit word at a time into a state of 30 columns of 4 bytes,
+// S0 to S29. Fugue-256 applies, for every big-endian message word I:
+//
+//	TIX(I)  S10 ^= S0, S0 = I, S8 ^= S0, S1 ^= S24
+//	2 x     ROR3, CMIX, SMIX
+//
+// where ROR3 rotates the columns by three positions (S0 receives S27), CMIX
+// adds S4, S5, S6 to S0, S1, S2 and to S15, S16, S17, and SMIX applies the
+// AES S-box to the 16 bytes of S0..S3 and mixes them with the Super-Mix
+// matrix. The message is padded with zeros to a whole word and followed by
+// its length in bits as a 64-bit big-endian number. The final round mixes
+// the state further, and the digest is S1..S4 || S15..S18.
+//
+// The S-box layer of SMIX runs with SubBytesHW, and Super-Mix uses tables of
+// the columns of its 4x4 circulant matrix.
+
+const (
+	// Fugue256Size is the size of a Fugue-256 digest in bytes.
+	Fugue256Size = 32
+
+	// Fugue256BlockSize is the word size by which Fugue-256 absorbs input.
+	Fugue256BlockSize = 4
+
+	fugueColumns = 30
+)
+
+var fugue256IV = [8]uint32{
+	0xe952bdde, 0x6671135f, 0xe0d4f668, 0xd2b0b594,
+	0xf96c621d, 0xfbf929de, 0x9149e899, 0x34f8c248,
+}
+
+// fugueMix[i][x] is column i of the matrix
+//
+//	1 4 7 1
+//	1 1 4 7
+//	7 1 1 4
+//	4 7 1 1
+//
+// multiplied by x, with the first row in the most significant byte.
+var fugueMix = func() (t [4][256]uint32) {
+	m := [4][4]byte{{1, 1, 7, 4}, {4, 1, 1, 7}, {7, 4, 1, 1}, {1, 7, 4, 1}}
+	for x := range 256 {
+		b := byte(x)
+		mul := [8]byte{1: b, 4: gfMul4(b), 7: gfMul4(b) ^ gfMul2(b) ^ b}
+		for i := range 4 {
+			for k := range 4 {
+				t[i][x] |= uint32(mul[m[i][k]]) << (24 - 8*k)
+			}
+		}
+	}
+	return t
+}()
+
+// fugueState is the 30 columns, with Si stored at s[(base+i)%30] so that
+// rotations only move base.
+type fugueState struct {
+	s    [fugueColumns]uint32
+	base int
+}
+
+// at returns a pointer to Si.
+func (f *fugueState) at(i int) *uint32 {
+	return &f.s[(f.base+i)%fugueColumns]
+}
+
+// ror rotates the columns by n positions: Si receives S(i-n).
+func (f *fugueState) ror(n int) {
+	f.base = (f.base + fugueColumns - n) % fugueColumns
+}
+
+// cmix applies CMIX.
+func (f *fugueState) cmix() {
+	s4, s5, s6 := *f.at(4), *f.at(5), *f.at(6)
+	*f.at(0) ^= s4
+	*f.at(1) ^= s5
+	*f.at(2) ^= s6
+	*f.at(15) ^= s4
+	*f.at(16) ^= s5
+	*f.at(17) ^= s6
+}
+
+// smix applies SMIX to S0..S3.
+func (f *fugueState) smix() {
+	var u Block // u[4*j+i] is row i of column j
+	for j := range 4 {
+		binary.BigEndian.PutUint32(u[4*j:], *f.at(j))
+	}
+	SubBytesHW(&u)
+
+	// Every column is multiplied by the matrix. Each row i also adds its
+	// bytes outside the diagonal, times column i of the matrix, to the
+	// output.
+	var c, r [4]uint32
+	for i := range 4 {
+		sum := u[i] ^ u[4+i] ^ u[8+i] ^ u[12+i]
+		for j := range 4 {
+			c[j] ^= fugueMix[i][u[4*j+i]]
+		}
+		r[i] = fugueMix[i][sum^u[5*i]]
+	}
+
+	// Row k of the output is rotated by k columns.
+	for j := range 4 {
+		var w uint32
+		for k := range 4 {
+			m := (j + k) & 3
+			b := byte(c[m]>>(24-8*k)) ^ byte(r[k]>>(24-8*m))
+			w |= uint32(b) << (24 - 8*k)
+		}
+		*f.at(j) = w
+	}
+}
+
+// word absorbs a message word.
+func (f *fugueState) word(x uint32) {
+	*f.at(10) ^= *f.at(0)
+	*f.at(0) = x
+	*f.at(8) ^= x
+	*f.at(1) ^= *f.at(24)
+	for range 2 {
+		f.ror(3)
+		f.cmix()
+		f.smix()
+	}
+}
+
+// final applies the final round of Fugue-256.
+func (f *fugueState) final() {
+	for range 10 {
+		f.ror(3)
+		f.cmix()
+		f.smix()
+	}
+	for range 13 {
+		*f.at(4) ^= *f.at(0)
+		*f.at(15) ^= *f.at(0)
+		f.ror(15)
+		f.smix()
+		*f.at(4) ^= *f.at(0)
+		*f.at(16) ^= *f.at(0)
+		f.ror(14)
+		f.smix()
+	}
+	*f.at(4) ^= *f.at(0)
+	*f.at(15) ^= *f.at(0)
+}
+
+// Fugue256 computes Fugue-256 and implements hash.Hash. The zero value is not
+// ready for use; create instances with NewFugue256.
+type Fugue256 struct {
+	st  fugueState
+	buf [4]byte
+	n   int
+	len uint64
+}
+
+var _ hash.Hash = (*Fugue256)(nil)
+
+// NewFugue256 returns a new Fugue-256 hash.
+func NewFugue256() *Fugue256 {
+	d := &Fugue256{}
+	d.Reset()
+	return d
+}
+
+// Reset resets the hash to its initial state.
+func (d *Fugue256) Reset() {
+	d.st = fugueState{}
+	copy(d.st.s[fugueColumns-len(fugue256IV):], fugue256IV[:])
+	d.n = 0
+	d.len = 0
+}
+
+// Size returns the digest size in bytes.
+func (d *Fugue256) Size() int { return Fugue256Size }
+
+// BlockSize returns the word size in bytes.
+func (d *Fugue256) BlockSize() int { return Fugue256BlockSize }
+
+// Write absorbs p. It never returns an error.
+func (d *Fugue256) Write(p []byte) (int, error) {
+	nn := len(p)
+	d.len += uint64(nn)
+	if d.n > 0 {
+		k := copy(d.buf[d.n:], p)
+		d.n += k
+		p = p[k:]
+		if d.n < 4 {
+			return nn, nil
+		}
+		d.st.word(binary.BigEndian.Uint32(d.buf[:]))
+		d.n = 0
+	}
+	for len(p) >= 4 {
+		d.st.word(binary.BigEndian.Uint32(p))
+		p = p[4:]
+	}
+	d.n = copy(d.buf[:], p)
+	return nn, nil
+}
+
+// Sum appends the digest to b. It does not change the underlying hash state.
+func (d *Fugue256) Sum(b []byte) []byte {
+	st := d.st
+	if d.n > 0 {
+		var last [4]byte
+		copy(last[:], d.buf[:d.n])
+		st.word(binary.BigEndian.Uint32(last[:]))
+	}
+	bits := d.len << 3
+	st.word(uint32(bits >> 32))
+	st.word(uint32(bits))
+	st.final()
+
+	ret, out := sliceForAppend(b, Fugue256Size)
+	for k, i := range [8]int{1, 2, 3, 4, 15, 16, 17, 18} {
+		binary.BigEndian.PutUint32(out[4*k:], *st.at(i))
+	}
+	return ret
+}
@@ -0,0 +1,165 @@
+package aes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+)
+
+// fugueReference computes Fugue-256, moving the columns on every rotation
+// and computing Super-Mix from its definition.
+func fugueReference(msg []byte) []byte {
+	var s [30]uint32
+	copy(s[22:], fugue256IV[:])
+	ror := func(n int) {
+		var t [30]uint32
+		for i := range t {
+			t[(i+n)%30] = s[i]
+		}
+		s = t
+	}
+	cmix := func() {
+		s[0] ^= s[4]
+		s[1] ^= s[5]
+		s[2] ^= s[6]
+		s[15] ^= s[4]
+		s[16] ^= s[5]
+		s[17] ^= s[6]
+	}
+	mul := func(c, x byte) byte {
+		switch c {
+		case 1:
+			return x
+		case 4:
+			return gfMul4(x)
+		}
+		return gfMul4(x) ^ gfMul2(x) ^ x
+	}
+	m := [4][4]byte{{1, 4, 7, 1}, {1, 1, 4, 7}, {7, 1, 1, 4}, {4, 7, 1, 1}}
+	smix := func() {
+		var u [4][4]byte // u[row][column]
+		for j := range 4 {
+			for i := range 4 {
+				u[i][j] = sbox[byte(s[j]>>(24-8*i))]
+			}
+		}
+		// c[j] = M·U_j, and r[i] collects the bytes of row i outside the
+		// diagonal, times column i of M.
+		var c, r [4][4]byte
+		for j := range 4 {
+			for i := range 4 {
+				for k := range 4 {
+					v := mul(m[k][i], u[i][j])
+					c[j][k] ^= v
+					if i != j {
+						r[i][k] ^= v
+					}
+				}
+			}
+		}
+		for j := range 4 {
+			var w uint32
+			for k := range 4 {
+				w |= uint32(c[(j+k)%4][k]^r[k][(j+k)%4]) << (24 - 8*k)
+			}
+			s[j] = w
+		}
+	}
+
+	padded := append([]byte(nil), msg...)
+	for len(padded)%4 != 0 {
+		padded = append(padded, 0)
+	}
+	padded = binary.BigEndian.AppendUint64(padded, uint64(len(msg))*8)
+	for off := 0; off < len(padded); off += 4 {
+		s[10] ^= s[0]
+		s[0] = binary.BigEndian.Uint32(padded[off:])
+		s[8] ^= s[0]
+		s[1] ^= s[24]
+		for range 2 {
+			ror(3)
+			cmix()
+			smix()
+		}
+	}
+	for range 10 {
+		ror(3)
+		cmix()
+		smix()
+	}
+	for range 13 {
+		s[4] ^= s[0]
+		s[15] ^= s[0]
+		ror(15)
+		smix()
+		s[4] ^= s[0]
+		s[16] ^= s[0]
+		ror(14)
+		smix()
+	}
+	s[4] ^= s[0]
+	s[15] ^= s[0]
+	var out []byte
+	for _, i := range []int{1, 2, 3, 4, 15, 16, 17, 18} {
+		out = binary.BigEndian.AppendUint32(out, s[i])
+	}
+	return out
+}
+
+func TestFugue256Reference(t *testing.T) {
+	msg := make([]byte, 100)
+	for i := range msg {
+		msg[i] = byte(i*29 + 3)
+	}
+	for n := range len(msg) {
+		h := NewFugue256()
+		h.Write(msg[:n])
+		if got, want := h.Sum(nil), fugueReference(msg[:n]); !bytes.Equal(got, want) {
+			t.Errorf("%d bytes: got %x, want %x", n, got, want)
+		}
+	}
+}
+
+func TestFugue256KnownAnswer(t *testing.T) {
+	forEachCPUConfig(t, func(t *testing.T) {
+		h := NewFugue256()
+		want := "d6ec528980c130aad1d1acd28b9dd8dbdeae0d79eded1fca72c2af9f37c2246f"
+		if got := hex.EncodeToString(h.Sum(nil)); got != want {
+			t.Errorf("Fugue-256(\"\") = %s, want %s", got, want)
+		}
+	})
+}
+
+func TestFugue256Streaming(t *testing.T) {
+	msg := make([]byte, 300)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+	h := NewFugue256()
+	h.Write(msg)
+	want := h.Sum(nil)
+	if again := h.Sum(nil); !bytes.Equal(again, want) {
+		t.Fatal("Sum changed the hash state")
+	}
+	for _, step := range []int{1, 3, 5, 64} {
+		h.Reset()
+		for i := 0; i < len(msg); i += step {
+			h.Write(msg[i:min(i+step, len(msg))])
+		}
+		if got := h.Sum(nil); !bytes.Equal(got, want) {
+			t.Errorf("step %d: got %x, want %x", step, got, want)
+		}
+	}
+}
+
+func BenchmarkFugue256(b *testing.B) {
+	buf := make([]byte, 8192)
+	h := NewFugue256()
+	b.SetBytes(int64(len(buf)))
+	for b.Loop() {
+		h.Reset()
+		h.Write(buf)
+		h.Sum(nil)
+	}
+}
@@ -0,0 +1,204 @@
+// Package gf implements arithmetic in the binary fields GF(2^e) for
+// 1 ≤ e ≤ 8, with elements stored in the low e bits of a byte, and the
+// matrix and S-box constructions used to describe AES-like ciphers over them.
+//
+// An element is a polynomial over GF(2) with bit i holding the coefficient
+// of x^i, so that in the AES field 0x02 is x and 0x03 is x+1.
+package gf
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// Field is GF(2^e) defined by an irreducible polynomial of degree e. It is
+// immutable and safe for concurrent use.
+type Field struct {
+	e    int
+	poly uint16
+	inv  [256]byte
+}
+
+var (
+	// AES is GF(2^8) with the AES polynomial x^8 + x^4 + x^3 + x + 1.
+	AES = MustNew(8, 0x11b)
+
+	// GF16 is GF(2^4) with the polynomial x^4 + x + 1, the field of the
+	// small-scale AES variants with 4-bit cells.
+	GF16 = MustNew(4, 0x13)
+)
+
+// New returns GF(2^e) with the given polynomial, whose bit e must be set.
+// It returns an error if e is not between 1 and 8 or if the polynomial does
+// not have degree e or is reducible.
+func New(e int, poly uint16) (*Field, error) {
+	if e < 1 || e > 8 {
+		return nil, errors.New("gf: degree must be between 1 and 8")
+	}
+	if bits.Len16(poly) != e+1 {
+		return nil, errors.New("gf: polynomial does not have the given degree")
+	}
+	if !irreducible(poly) {
+		return nil, errors.New("gf: polynomial is reducible")
+	}
+	f := &Field{e: e, poly: poly}
+	for a := 1; a < f.Size(); a++ {
+		f.inv[a] = f.Pow(byte(a), f.Size()-2)
+	}
+	return f, nil
+}
+
+// MustNew is like New but panics on error.
+func MustNew(e int, poly uint16) *Field {
+	f, err := New(e, poly)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// irreducible reports whether poly has no factor of degree 1 to deg(poly)/2.
+func irreducible(poly uint16) bool {
+	deg := bits.Len16(poly) - 1
+	for d := uint16(2); bits.Len16(d)-1 <= deg/2; d++ {
+		if polyMod(poly, d) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// polyMod returns a mod b for polynomials over GF(2).
+func polyMod(a, b uint16) uint16 {
+	db := bits.Len16(b)
+	for bits.Len16(a) >= db {
+		a ^= b << (bits.Len16(a) - db)
+	}
+	return a
+}
+
+// Bits returns e, the number of bits of an element.
+func (f *Field) Bits() int { return f.e }
+
+// Size returns the number of elements, 2^e.
+func (f *Field) Size() int { return 1 << f.e }
+
+// Poly returns the polynomial of the field, including the x^e term.
+func (f *Field) Poly() uint16 { return f.poly }
+
+// Add returns a + b, which is a ^ b.
+func (f *Field) Add(a, b byte) byte { return a ^ b }
+
+// Mul returns a·b.
+func (f *Field) Mul(a, b byte) byte {
+	var r uint16
+	x := uint16(a)
+	for ; b != 0; b >>= 1 {
+		r ^= x & -uint16(b&1)
+		x <<= 1
+		x ^= f.poly & -(x >> f.e & 1)
+	}
+	return byte(r)
+}
+
+// Pow returns a^n for n ≥ 0, with 0^0 = 1.
+func (f *Field) Pow(a byte, n int) byte {
+	r := byte(1)
+	for ; n > 0; n >>= 1 {
+		if n&1 != 0 {
+			r = f.Mul(r, a)
+		}
+		a = f.Mul(a, a)
+	}
+	return r
+}
+
+// Inv returns the multiplicative inverse of a, and 0 for a = 0, which is the
+// convention of the AES S-box.
+func (f *Field) Inv(a byte) byte { return f.inv[a] }
+
+// Div returns a/b. It panics if b is 0.
+func (f *Field) Div(a, b byte) byte {
+	if b == 0 {
+		panic("gf: division by zero")
+	}
+	return f.Mul(a, f.inv[b])
+}
+
+// MulMatrix returns the product of the matrix m, a slice of rows, and the
+// column vector v.
+func (f *Field) MulMatrix(m [][]byte, v []byte) []byte {
+	out := make([]byte, len(m))
+	for i, row := range m {
+		var acc byte
+		for j, c := range row {
+			acc ^= f.Mul(c, v[j])
+		}
+		out[i] = acc
+	}
+	return out
+}
+
+// InvertMatrix returns the inverse of the square matrix m, or an error if m
+// is not square or is singular.
+func (f *Field) InvertMatrix(m [][]byte) ([][]byte, error) {
+	n := len(m)
+	// Gauss-Jordan elimination on [m | I].
+	a := make([][]byte, n)
+	for i, row := range m {
+		if len(row) != n {
+			return nil, errors.New("gf: matrix is not square")
+		}
+		a[i] = make([]byte, 2*n)
+		copy(a[i], row)
+		a[i][n+i] = 1
+	}
+	for col := range n {
+		p := col
+		for p < n && a[p][col] == 0 {
+			p++
+		}
+		if p == n {
+			return nil, errors.New("gf: matrix is singular")
+		}
+		a[col], a[p] = a[p], a[col]
+		inv := f.inv[a[col][col]]
+		for j := range a[col] {
+			a[col][j] = f.Mul(a[col][j], inv)
+		}
+		for i := range n {
+			if i == col || a[i][col] == 0 {
+				continue
+			}
+			c := a[i][col]
+			for j := range a[i] {
+				a[i][j] ^= f.Mul(c, a[col][j])
+			}
+		}
+	}
+	for i := range a {
+		a[i] = a[i][n:]
+	}
+	return a, nil
+}
+
+// Affine returns L·x ⊕ c over GF(2)^e, where bit i of the result is the
+// parity of rows[i] & x.
+func (f *Field) Affine(rows []byte, c, x byte) byte {
+	y := c
+	for i, r := range rows {
+		y ^= byte(bits.OnesCount8(r&x)&1) << i
+	}
+	return y
+}
+
+// InversionSbox returns the S-box S(x) = L·x⁻¹ ⊕ c, with L given by rows as
+// in Affine. This is how the S-boxes of AES and its small-scale variants are
+// defined: the AES S-box has the rows 0xf1 <<< i and c = 0x63.
+func (f *Field) InversionSbox(rows []byte, c byte) []byte {
+	s := make([]byte, f.Size())
+	for x := range s {
+		s[x] = f.Affine(rows, c, f.inv[x])
+	}
+	return s
+}
@@ -0,0 +1,111 @@
+package gf
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	for _, tc := range []struct {
+		e    int
+		poly uint16
+		ok   bool
+	}{
+		{8, 0x11b, true},
+		{4, 0x13, true},
+		{1, 0x3, true},
+		{2, 0x7, true},
+		{3, 0xb, true},
+		{8, 0x11d, true},
+		{4, 0x15, false},  // (x^2 + x + 1)^2
+		{8, 0x101, false}, // (x + 1)^8
+		{4, 0x11b, false},
+		{0, 0x1, false},
+		{9, 0x211, false},
+	} {
+		_, err := New(tc.e, tc.poly)
+		if (err == nil) != tc.ok {
+			t.Errorf("New(%d, %#x) error = %v", tc.e, tc.poly, err)
+		}
+	}
+}
+
+// FIPS-197, Section 4.2.
+func TestMul(t *testing.T) {
+	if got := AES.Mul(0x57, 0x83); got != 0xc1 {
+		t.Errorf("{57}·{83} = %#02x, want 0xc1", got)
+	}
+	if got := AES.Mul(0x57, 0x13); got != 0xfe {
+		t.Errorf("{57}·{13} = %#02x, want 0xfe", got)
+	}
+}
+
+func TestFieldAxioms(t *testing.T) {
+	for _, f := range []*Field{AES, GF16, MustNew(3, 0xb), MustNew(8, 0x11d)} {
+		n := f.Size()
+		for a := range n {
+			if a != 0 && f.Mul(byte(a), f.Inv(byte(a))) != 1 {
+				t.Fatalf("GF(2^%d): %#x·%#x != 1", f.Bits(), a, f.Inv(byte(a)))
+			}
+			for b := range n {
+				ab := f.Mul(byte(a), byte(b))
+				if int(ab) >= n || ab != f.Mul(byte(b), byte(a)) {
+					t.Fatalf("GF(2^%d): bad product %#x·%#x = %#x", f.Bits(), a, b, ab)
+				}
+				if b != 0 && f.Div(ab, byte(b)) != byte(a) {
+					t.Fatalf("GF(2^%d): (%#x·%#x)/%#x != %#x", f.Bits(), a, b, b, a)
+				}
+			}
+		}
+		if got := f.Pow(2, n-1); got != 1 {
+			t.Errorf("GF(2^%d): x^%d = %#x, want 1", f.Bits(), n-1, got)
+		}
+	}
+}
+
+func TestInvertMatrix(t *testing.T) {
+	mc := [][]byte{{2, 3, 1, 1}, {1, 2, 3, 1}, {1, 1, 2, 3}, {3, 1, 1, 2}}
+	inv, err := AES.InvertMatrix(mc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]byte{{14, 11, 13, 9}, {9, 14, 11, 13}, {13, 9, 14, 11}, {11, 13, 9, 14}}
+	for i := range want {
+		if !bytes.Equal(inv[i], want[i]) {
+			t.Fatalf("inverse of MixColumns = %v, want %v", inv, want)
+		}
+	}
+	v := []byte{0xdb, 0x13, 0x53, 0x45}
+	if got := AES.MulMatrix(mc, v); !bytes.Equal(got, []byte{0x8e, 0x4d, 0xa1, 0xbc}) {
+		t.Errorf("MixColumns(%x) = %x", v, got)
+	}
+
+	if _, err := AES.InvertMatrix([][]byte{{1, 1}, {1, 1}}); err == nil {
+		t.Error("InvertMatrix accepted a singular matrix")
+	}
+	if _, err := AES.InvertMatrix([][]byte{{1, 1}}); err == nil {
+		t.Error("InvertMatrix accepted a matrix that is not square")
+	}
+}
+
+func TestInversionSbox(t *testing.T) {
+	var rows [8]byte
+	for i := range rows {
+		rows[i] = 0xf1<<i | 0xf1>>(8-i)
+	}
+	s := AES.InversionSbox(rows[:], 0x63)
+	// FIPS-197, Figure 7.
+	for x, want := range map[int]byte{0x00: 0x63, 0x01: 0x7c, 0x53: 0xed, 0xff: 0x16, 0x9a: 0xb8} {
+		if s[x] != want {
+			t.Errorf("S(%#02x) = %#02x, want %#02x", x, s[x], want)
+		}
+	}
+
+	// The 4-bit S-box of the small-scale variants of AES, as tabulated by the
+	// SR implementation of Sage.
+	s = GF16.InversionSbox([]byte{0x7, 0xe, 0xd, 0xb}, 0x6)
+	want := []byte{6, 11, 5, 4, 2, 14, 7, 10, 9, 13, 15, 12, 3, 1, 0, 8}
+	if !bytes.Equal(s, want) {
+		t.Errorf("small-scale S-box = %v, want %v", s, want)
+	}
+}
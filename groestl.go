@@ -0,0 +1,268 @@
+package aes
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// Grøstl (Gauravaram, Knudsen, Matusiewicz, Mendel, Rechberger, Schläffer and
+// Thomsen) is a SHA-3 finalist built from two AES-like permutations P and Q
+// over a matrix of 8 rows of bytes. The compression function and the output
+// transformation are:
+//
+//	f(h, m) = P(h ⊕ m) ⊕ Q(m) ⊕ h
+//	Ω(h)    = trunc(P(h) ⊕ h)
+//
+// Grøstl-256 uses 8x8 matrices (64-byte blocks) and 10 rounds, Grøstl-512
+// uses 8x16 matrices (128-byte blocks) and 14 rounds. A round adds a
+// constant, applies the AES S-box to every byte, shifts every row by its own
+// offset and multiplies every column by a circulant MDS matrix. Bytes are
+// mapped to the matrix column by column.
+//
+// The state is kept row by row, so that the S-box layer runs on whole states
+// with SubBytes4HW, and ShiftBytes and MixBytes work on eight columns at a
+// time in 64-bit words.
+
+const (
+	// Groestl256Size is the size of a Grøstl-256 digest in bytes.
+	Groestl256Size = 32
+
+	// Groestl512Size is the size of a Grøstl-512 digest in bytes.
+	Groestl512Size = 64
+
+	// Groestl256BlockSize is the message block size of Grøstl-256 in bytes.
+	Groestl256BlockSize = 64
+
+	// Groestl512BlockSize is the message block size of Grøstl-512 in bytes.
+	Groestl512BlockSize = 128
+)
+
+// Row shifts of the permutations. The second row of offsets is used with
+// 8x16 matrices.
+var (
+	groestlShiftP = [2][8]uint{{0, 1, 2, 3, 4, 5, 6, 7}, {0, 1, 2, 3, 4, 5, 6, 11}}
+	groestlShiftQ = [2][8]uint{{1, 3, 5, 7, 0, 2, 4, 6}, {1, 3, 5, 11, 0, 2, 4, 6}}
+)
+
+// groestlColumns holds the column index j in byte j of every word, shifted to
+// the high nibble, for the round constants.
+var groestlColumns = [2]uint64{0x7060504030201000, 0xf0e0d0c0b0a09080}
+
+const groestlLanes = 0x0101010101010101
+
+// groestlState is the matrix, row by row: 64 bytes (8x8) or 128 bytes (8x16).
+type groestlState [Groestl512BlockSize]byte
+
+// groestlXtime multiplies eight packed bytes by x in GF(2^8).
+func groestlXtime(x uint64) uint64 {
+	return (x&0x7f7f7f7f7f7f7f7f)<<1 ^ (x>>7&0x0101010101010101)*0x1b
+}
+
+// groestlPermute applies P, or Q if q is set, to the first 8*cols bytes of
+// s, with cols 8 or 16.
+func groestlPermute(s *groestlState, cols int, rounds int, q bool) {
+	lanes := cols / 8
+	shift := &groestlShiftP[lanes-1]
+	if q {
+		shift = &groestlShiftQ[lanes-1]
+	}
+
+	// w[l][i] holds columns 8l to 8l+7 of row i.
+	var w [2][8]uint64
+	store := func() {
+		for i := range 8 {
+			for l := range lanes {
+				binary.LittleEndian.PutUint64(s[i*cols+8*l:], w[l][i])
+			}
+		}
+	}
+	for i := range 8 {
+		for l := range lanes {
+			w[l][i] = binary.LittleEndian.Uint64(s[i*cols+8*l:])
+		}
+	}
+	for r := range rounds {
+		// AddRoundConstant
+		rc := uint64(r) * groestlLanes
+		for l := range lanes {
+			if q {
+				for i := range 7 {
+					w[l][i] = ^w[l][i]
+				}
+				w[l][7] ^= ^(groestlColumns[l] ^ rc)
+			} else {
+				w[l][0] ^= groestlColumns[l] ^ rc
+			}
+		}
+
+		// SubBytes
+		store()
+		for i := 0; i < 8*cols; i += 64 {
+			SubBytes4HW((*Block4)(s[i:]))
+		}
+
+		// ShiftBytes: row i rotated left by shift[i] columns
+		for i := range 8 {
+			if lanes == 1 {
+				x := binary.LittleEndian.Uint64(s[8*i:])
+				w[0][i] = bits.RotateLeft64(x, -8*int(shift[i]))
+				continue
+			}
+			lo := binary.LittleEndian.Uint64(s[16*i:])
+			hi := binary.LittleEndian.Uint64(s[16*i+8:])
+			n := 8 * shift[i]
+			if n >= 64 {
+				lo, hi = hi, lo
+				n -= 64
+			}
+			if n > 0 {
+				lo, hi = lo>>n|hi<<(64-n), hi>>n|lo<<(64-n)
+			}
+			w[0][i], w[1][i] = lo, hi
+		}
+
+		// MixBytes: circ(02, 02, 03, 04, 05, 03, 05, 07)
+		for l := range lanes {
+			a := &w[l]
+			var a2, a4 [8]uint64
+			for i := range 8 {
+				a2[i] = groestlXtime(a[i])
+				a4[i] = groestlXtime(a2[i])
+			}
+			var b [8]uint64
+			for i := range 8 {
+				b[i] = a2[i] ^ a2[(i+1)&7] ^
+					a2[(i+2)&7] ^ a[(i+2)&7] ^
+					a4[(i+3)&7] ^
+					a4[(i+4)&7] ^ a[(i+4)&7] ^
+					a2[(i+5)&7] ^ a[(i+5)&7] ^
+					a4[(i+6)&7] ^ a[(i+6)&7] ^
+					a4[(i+7)&7] ^ a2[(i+7)&7] ^ a[(i+7)&7]
+			}
+			*a = b
+		}
+	}
+	store()
+}
+
+// groestlLoad copies a block in column order into a state in row order.
+func groestlLoad(s *groestlState, b []byte, cols int) {
+	for c := range cols {
+		for r := range 8 {
+			s[r*cols+c] = b[8*c+r]
+		}
+	}
+}
+
+// Groestl computes Grøstl-256 or Grøstl-512 and implements hash.Hash. The
+// zero value is not ready for use; create instances with NewGroestl256 or
+// NewGroestl512.
+type Groestl struct {
+	h      groestlState // chaining value, row by row
+	buf    [Groestl512BlockSize]byte
+	n      int
+	blocks uint64
+	size   int
+	bs     int
+	rounds int
+}
+
+var _ hash.Hash = (*Groestl)(nil)
+
+// NewGroestl256 returns a new Grøstl-256 hash.
+func NewGroestl256() *Groestl {
+	d := &Groestl{size: Groestl256Size, bs: Groestl256BlockSize, rounds: 10}
+	d.Reset()
+	return d
+}
+
+// NewGroestl512 returns a new Grøstl-512 hash.
+func NewGroestl512() *Groestl {
+	d := &Groestl{size: Groestl512Size, bs: Groestl512BlockSize, rounds: 14}
+	d.Reset()
+	return d
+}
+
+// Reset resets the hash to its initial state.
+func (d *Groestl) Reset() {
+	// The IV is the digest size in bits, as a big-endian number in the last
+	// bytes of the state.
+	var iv [Groestl512BlockSize]byte
+	binary.BigEndian.PutUint16(iv[d.bs-2:], uint16(8*d.size))
+	groestlLoad(&d.h, iv[:d.bs], d.bs/8)
+	d.n = 0
+	d.blocks = 0
+}
+
+// Size returns the digest size in bytes.
+func (d *Groestl) Size() int { return d.size }
+
+// BlockSize returns the message block size in bytes.
+func (d *Groestl) BlockSize() int { return d.bs }
+
+// block compresses a message block.
+func (d *Groestl) block(m []byte) {
+	cols := d.bs / 8
+	var p, q groestlState
+	groestlLoad(&q, m, cols)
+	for i := range d.bs {
+		p[i] = d.h[i] ^ q[i]
+	}
+	groestlPermute(&p, cols, d.rounds, false)
+	groestlPermute(&q, cols, d.rounds, true)
+	for i := range d.bs {
+		d.h[i] ^= p[i] ^ q[i]
+	}
+	d.blocks++
+}
+
+// Write absorbs p. It never returns an error.
+func (d *Groestl) Write(p []byte) (int, error) {
+	nn := len(p)
+	buf := d.buf[:d.bs]
+	if d.n > 0 {
+		k := copy(buf[d.n:], p)
+		d.n += k
+		p = p[k:]
+		if d.n < d.bs {
+			return nn, nil
+		}
+		d.block(buf)
+		d.n = 0
+	}
+	for len(p) >= d.bs {
+		d.block(p[:d.bs])
+		p = p[d.bs:]
+	}
+	d.n = copy(buf, p)
+	return nn, nil
+}
+
+// Sum appends the digest to b. It does not change the underlying hash state.
+func (d *Groestl) Sum(b []byte) []byte {
+	dd := *d
+	buf := dd.buf[:dd.bs]
+
+	// Padding: a 1 bit, zeros, and the number of blocks as a 64-bit
+	// big-endian number, which includes the padding blocks.
+	clear(buf[dd.n:])
+	buf[dd.n] = 0x80
+	if dd.n+1 > dd.bs-8 {
+		dd.block(buf)
+		clear(buf)
+	}
+	binary.BigEndian.PutUint64(buf[dd.bs-8:], dd.blocks+1)
+	dd.block(buf)
+
+	// Output transformation
+	cols := dd.bs / 8
+	p := dd.h
+	groestlPermute(&p, cols, dd.rounds, false)
+	ret, out := sliceForAppend(b, dd.size)
+	for i, k := dd.bs-dd.size, 0; i < dd.bs; i, k = i+1, k+1 {
+		c, r := i/8, i%8
+		out[k] = p[r*cols+c] ^ dd.h[r*cols+c]
+	}
+	return ret
+}
@@ -0,0 +1,194 @@
+package aes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+)
+
+// groestlReferencePermute applies P or Q to a matrix a[row][column],
+// following the specification step by step.
+func groestlReferencePermute(a [][]byte, q bool, rounds int) {
+	cols := len(a[0])
+	shift := groestlShiftP[cols/8-1]
+	if q {
+		shift = groestlShiftQ[cols/8-1]
+	}
+	coef := [8]byte{2, 2, 3, 4, 5, 3, 5, 7}
+	for r := range rounds {
+		for j := range cols {
+			if q {
+				for i := range 7 {
+					a[i][j] ^= 0xff
+				}
+				a[7][j] ^= 0xff ^ byte(j<<4) ^ byte(r)
+			} else {
+				a[0][j] ^= byte(j<<4) ^ byte(r)
+			}
+		}
+		for i := range a {
+			for j := range a[i] {
+				a[i][j] = sbox[a[i][j]]
+			}
+		}
+		for i := range a {
+			row := append([]byte(nil), a[i]...)
+			for j := range row {
+				a[i][j] = row[(j+int(shift[i]))%cols]
+			}
+		}
+		for j := range cols {
+			var col [8]byte
+			for i := range 8 {
+				for k := range 8 {
+					x, c := a[k][j], coef[(k-i+8)%8]
+					var p byte
+					for ; c > 0; c >>= 1 {
+						if c&1 != 0 {
+							p ^= x
+						}
+						x = gfMul2(x)
+					}
+					col[i] ^= p
+				}
+			}
+			for i := range 8 {
+				a[i][j] = col[i]
+			}
+		}
+	}
+}
+
+func groestlReferenceP(b []byte, q bool, rounds int) []byte {
+	cols := len(b) / 8
+	a := make([][]byte, 8)
+	for i := range a {
+		a[i] = make([]byte, cols)
+		for j := range cols {
+			a[i][j] = b[8*j+i]
+		}
+	}
+	groestlReferencePermute(a, q, rounds)
+	out := make([]byte, len(b))
+	for i := range 8 {
+		for j := range cols {
+			out[8*j+i] = a[i][j]
+		}
+	}
+	return out
+}
+
+func groestlReference(msg []byte, size int) []byte {
+	bs, rounds := Groestl256BlockSize, 10
+	if size == Groestl512Size {
+		bs, rounds = Groestl512BlockSize, 14
+	}
+	xor := func(a, b []byte) []byte {
+		out := make([]byte, len(a))
+		for i := range a {
+			out[i] = a[i] ^ b[i]
+		}
+		return out
+	}
+	h := make([]byte, bs)
+	binary.BigEndian.PutUint16(h[bs-2:], uint16(8*size))
+	m := append(append([]byte(nil), msg...), 0x80)
+	for (len(m)+8)%bs != 0 {
+		m = append(m, 0)
+	}
+	m = binary.BigEndian.AppendUint64(m, uint64((len(m)+8)/bs))
+	for off := 0; off < len(m); off += bs {
+		block := m[off : off+bs]
+		h = xor(xor(groestlReferenceP(xor(h, block), false, rounds), groestlReferenceP(block, true, rounds)), h)
+	}
+	return xor(groestlReferenceP(h, false, rounds), h)[bs-size:]
+}
+
+func TestGroestlReference(t *testing.T) {
+	msg := make([]byte, 400)
+	for i := range msg {
+		msg[i] = byte(i * 13)
+	}
+	for _, size := range []int{Groestl256Size, Groestl512Size} {
+		for _, n := range []int{0, 1, 55, 56, 63, 64, 65, 119, 120, 128, 200, 400} {
+			want := groestlReference(msg[:n], size)
+			h := NewGroestl256()
+			if size == Groestl512Size {
+				h = NewGroestl512()
+			}
+			h.Write(msg[:n])
+			if got := h.Sum(nil); !bytes.Equal(got, want) {
+				t.Errorf("Grøstl-%d, %d bytes: got %x, want %x", 8*size, n, got, want)
+			}
+		}
+	}
+}
+
+func TestGroestlKnownAnswers(t *testing.T) {
+	forEachCPUConfig(t, func(t *testing.T) {
+		tests := []struct {
+			newHash func() *Groestl
+			msg     string
+			want    string
+		}{
+			{NewGroestl256, "",
+				"1a52d11d550039be16107f9c58db9ebcc417f16f736adb2502567119f0083467"},
+			{NewGroestl512, "",
+				"6d3ad29d279110eef3adbd66de2a0345a77baede1557f5d099fce0c03d6dc2ba8e6d4a6633dfbd66053c20faa87d1a11f39a7fbe4a6c2f009801370308fc4ad8"},
+		}
+		for _, tc := range tests {
+			h := tc.newHash()
+			h.Write([]byte(tc.msg))
+			if got := hex.EncodeToString(h.Sum(nil)); got != tc.want {
+				t.Errorf("Grøstl-%d(%q) = %s, want %s", 8*h.Size(), tc.msg, got, tc.want)
+			}
+		}
+	})
+}
+
+func TestGroestlStreaming(t *testing.T) {
+	msg := make([]byte, 1000)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+	for _, newHash := range []func() *Groestl{NewGroestl256, NewGroestl512} {
+		h := newHash()
+		h.Write(msg)
+		want := h.Sum(nil)
+		if again := h.Sum(nil); !bytes.Equal(again, want) {
+			t.Fatal("Sum changed the hash state")
+		}
+		for _, step := range []int{1, 7, 64, 100, 128, 999} {
+			h.Reset()
+			for i := 0; i < len(msg); i += step {
+				h.Write(msg[i:min(i+step, len(msg))])
+			}
+			if got := h.Sum(nil); !bytes.Equal(got, want) {
+				t.Errorf("step %d: got %x, want %x", step, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkGroestl256(b *testing.B) {
+	buf := make([]byte, 8192)
+	h := NewGroestl256()
+	b.SetBytes(int64(len(buf)))
+	for b.Loop() {
+		h.Reset()
+		h.Write(buf)
+		h.Sum(nil)
+	}
+}
+
+func BenchmarkGroestl512(b *testing.B) {
+	buf := make([]byte, 8192)
+	h := NewGroestl512()
+	b.SetBytes(int64(len(buf)))
+	for b.Loop() {
+		h.Reset()
+		h.Write(buf)
+		h.Sum(nil)
+	}
+}
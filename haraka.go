@@ -143,99 +143,75 @@ func mix512(s0, s1, s2, s3 *Block) {
 	binary.LittleEndian.PutUint32(s3[12:16], d2) // new[15] = old[14]
 }
 
-// Haraka256 computes the Haraka-256 v2 hash of a 32-byte input.
-// Returns a 32-byte hash output.
-func Haraka256(input *[32]byte) [32]byte {
-	// Copy input to state
-	var s0, s1 Block
-	copy(s0[:], input[0:16])
-	copy(s1[:], input[16:32])
-
-	// Save original for feed-forward
-	var orig0, orig1 Block
-	orig0 = s0
-	orig1 = s1
+// harakaPi256Generic applies the 5-round π256 permutation of Haraka-256 with
+// the round constants rc (only the first 20 are used).
+func harakaPi256Generic(state *[32]byte, rc *[40][16]byte) {
+	s0 := (*Block)(state[0:16])
+	s1 := (*Block)(state[16:32])
 
-	// 5 rounds
 	// Note: The Go reference applies rounds in interleaved order:
 	// first AES to s0, first AES to s1, second AES to s0, second AES to s1
 	for round := range 5 {
 		rcIdx := round * 4
 
-		// Interleaved AES rounds as per the Go reference implementation
-		Round(&s0, (*Block)(&harakaRC128[rcIdx+0]))
-		Round(&s1, (*Block)(&harakaRC128[rcIdx+1]))
-		Round(&s0, (*Block)(&harakaRC128[rcIdx+2]))
-		Round(&s1, (*Block)(&harakaRC128[rcIdx+3]))
+		Round(s0, (*Block)(&rc[rcIdx+0]))
+		Round(s1, (*Block)(&rc[rcIdx+1]))
+		Round(s0, (*Block)(&rc[rcIdx+2]))
+		Round(s1, (*Block)(&rc[rcIdx+3]))
 
-		// Mix
-		mix2(&s0, &s1)
+		mix2(s0, s1)
 	}
-
-	// Feed-forward XOR
-	XorBlock(&s0, &s0, &orig0)
-	XorBlock(&s1, &s1, &orig1)
-
-	// Output
-	var out [32]byte
-	copy(out[0:16], s0[:])
-	copy(out[16:32], s1[:])
-	return out
 }
 
-// Haraka512 computes the Haraka-512 v2 hash of a 64-byte input.
-// Returns a 32-byte hash output (truncated).
-func Haraka512(input *[64]byte) [32]byte {
-	// Copy input to state
-	var s0, s1, s2, s3 Block
-	copy(s0[:], input[0:16])
-	copy(s1[:], input[16:32])
-	copy(s2[:], input[32:48])
-	copy(s3[:], input[48:64])
-
-	// Save original for feed-forward
-	var orig0, orig1, orig2, orig3 Block
-	orig0 = s0
-	orig1 = s1
-	orig2 = s2
-	orig3 = s3
+// harakaPi512Generic applies the 5-round π512 permutation of Haraka-512 with
+// the round constants rc.
+func harakaPi512Generic(state *[64]byte, rc *[40][16]byte) {
+	s0 := (*Block)(state[0:16])
+	s1 := (*Block)(state[16:32])
+	s2 := (*Block)(state[32:48])
+	s3 := (*Block)(state[48:64])
 
-	// 5 rounds
 	// Reference order: first AES on all 4 blocks, then second AES on all 4 blocks
 	for round := range 5 {
 		rcIdx := round * 8
 
-		// First AES round on each block
-		Round(&s0, (*Block)(&harakaRC128[rcIdx+0]))
-		Round(&s1, (*Block)(&harakaRC128[rcIdx+1]))
-		Round(&s2, (*Block)(&harakaRC128[rcIdx+2]))
-		Round(&s3, (*Block)(&harakaRC128[rcIdx+3]))
+		Round(s0, (*Block)(&rc[rcIdx+0]))
+		Round(s1, (*Block)(&rc[rcIdx+1]))
+		Round(s2, (*Block)(&rc[rcIdx+2]))
+		Round(s3, (*Block)(&rc[rcIdx+3]))
 
-		// Second AES round on each block
-		Round(&s0, (*Block)(&harakaRC128[rcIdx+4]))
-		Round(&s1, (*Block)(&harakaRC128[rcIdx+5]))
-		Round(&s2, (*Block)(&harakaRC128[rcIdx+6]))
-		Round(&s3, (*Block)(&harakaRC128[rcIdx+7]))
+		Round(s0, (*Block)(&rc[rcIdx+4]))
+		Round(s1, (*Block)(&rc[rcIdx+5]))
+		Round(s2, (*Block)(&rc[rcIdx+6]))
+		Round(s3, (*Block)(&rc[rcIdx+7]))
 
-		// Mix
-		mix512(&s0, &s1, &s2, &s3)
+		mix512(s0, s1, s2, s3)
 	}
+}
+
+// Haraka256 computes the Haraka-256 v2 hash of a 32-byte input.
+// Returns a 32-byte hash output.
+func Haraka256(input *[32]byte) [32]byte {
+	out := *input
+	harakaPi256Generic(&out, &harakaRC128)
 
 	// Feed-forward XOR
-	XorBlock(&s0, &s0, &orig0)
-	XorBlock(&s1, &s1, &orig1)
-	XorBlock(&s2, &s2, &orig2)
-	XorBlock(&s3, &s3, &orig3)
+	for i := range out {
+		out[i] ^= input[i]
+	}
+	return out
+}
+
+// Haraka512 computes the Haraka-512 v2 hash of a 64-byte input.
+// Returns a 32-byte hash output (truncated).
+func Haraka512(input *[64]byte) [32]byte {
+	state := *input
+	harakaPi512Generic(&state, &harakaRC128)
 
-	// Truncated output: take bytes from specific positions
-	// In terms of 32-bit dwords, output indices are: 2, 3, 6, 7, 8, 9, 12, 13
-	// Which corresponds to:
-	// s0[8:16] || s1[8:16] || s2[0:8] || s3[0:8]
+	// Feed-forward and truncation: in terms of 32-bit dwords, the output
+	// indices are 2, 3, 6, 7, 8, 9, 12, 13
 	var out [32]byte
-	copy(out[0:8], s0[8:16])
-	copy(out[8:16], s1[8:16])
-	copy(out[16:24], s2[0:8])
-	copy(out[24:32], s3[0:8])
+	harakaTruncate512(&out, &state, input)
 	return out
 }
 
@@ -31,3 +31,94 @@ func Haraka512HW(input *[64]byte) [32]byte {
 	}
 	return out
 }
+
+//go:noescape
+func harakaPermute256HW(state *[32]byte, rc *[40][16]byte)
+
+//go:noescape
+func harakaInversePermute256HW(state *[32]byte, rc *[40][16]byte)
+
+//go:noescape
+func harakaPermute512HW(state *[64]byte, rc *[40][16]byte)
+
+//go:noescape
+func harakaInversePermute512HW(state *[64]byte, rc *[40][16]byte)
+
+func harakaPermute256(state *[32]byte, rc *[40][16]byte) {
+	if CPU.HasAESNI {
+		harakaPermute256HW(state, rc)
+	} else {
+		harakaPi256Generic(state, rc)
+	}
+}
+
+func harakaInversePermute256(state *[32]byte, rc *[40][16]byte) {
+	if CPU.HasAESNI {
+		harakaInversePermute256HW(state, rc)
+	} else {
+		harakaInvPi256Generic(state, rc)
+	}
+}
+
+func harakaPermute512(state *[64]byte, rc *[40][16]byte) {
+	if CPU.HasAESNI {
+		harakaPermute512HW(state, rc)
+	} else {
+		harakaPi512Generic(state, rc)
+	}
+}
+
+func harakaInversePermute512(state *[64]byte, rc *[40][16]byte) {
+	if CPU.HasAESNI {
+		harakaInversePermute512HW(state, rc)
+	} else {
+		harakaInvPi512Generic(state, rc)
+	}
+}
+
+//go:noescape
+func haraka256x4VAES(out *[4][32]byte, in *[4][32]byte, rc *[40][16]byte)
+
+//go:noescape
+func haraka256x2VAES(out *[2][32]byte, in *[2][32]byte, rc *[40][16]byte)
+
+//go:noescape
+func haraka256x4AESNI(out *[4][32]byte, in *[4][32]byte, rc *[40][16]byte)
+
+//go:noescape
+func haraka512x4VAES(out *[4][32]byte, in *[4][64]byte, rc *[40][16]byte)
+
+//go:noescape
+func haraka512x2VAES(out *[2][32]byte, in *[2][64]byte, rc *[40][16]byte)
+
+//go:noescape
+func haraka512x2AESNI(out *[2][32]byte, in *[2][64]byte, rc *[40][16]byte)
+
+func haraka256x4(out *[4][32]byte, in *[4][32]byte, rc *[40][16]byte) {
+	switch {
+	case CPU.HasVAES && CPU.HasAVX512:
+		haraka256x4VAES(out, in, rc)
+	case CPU.HasVAES && CPU.HasAVX2:
+		haraka256x2VAES((*[2][32]byte)(out[0:2]), (*[2][32]byte)(in[0:2]), rc)
+		haraka256x2VAES((*[2][32]byte)(out[2:4]), (*[2][32]byte)(in[2:4]), rc)
+	case CPU.HasAESNI:
+		haraka256x4AESNI(out, in, rc)
+	default:
+		haraka256x4Generic(out, in, rc)
+	}
+}
+
+func haraka512x4(out *[4][32]byte, in *[4][64]byte, rc *[40][16]byte) {
+	switch {
+	case CPU.HasVAES && CPU.HasAVX512:
+		haraka512x4VAES(out, in, rc)
+	case CPU.HasVAES && CPU.HasAVX2:
+		haraka512x2VAES((*[2][32]byte)(out[0:2]), (*[2][64]byte)(in[0:2]), rc)
+		haraka512x2VAES((*[2][32]byte)(out[2:4]), (*[2][64]byte)(in[2:4]), rc)
+	case CPU.HasAESNI:
+		haraka512x2AESNI((*[2][32]byte)(out[0:2]), (*[2][64]byte)(in[0:2]), rc)
+		haraka512x2AESNI((*[2][32]byte)(out[2:4]), (*[2][64]byte)(in[2:4]), rc)
+	default:
+		haraka512x4Generic(out, in, rc)
+	}
+}
@@ -399,3 +399,1371 @@ TEXT ·haraka512HW(SB),NOSPLIT,$0
 	MOVOU X0, 0(DI)
 	MOVOU X2, 16(DI)
 	RET
+
+// Bare Haraka permutations (π256, π512) and their inverses, without the
+// feed-forward. The round constants are passed in so that custom tables can
+// be used. X14 holds zero in the inverse functions.
+
+// HARAKA_ROUND applies one AES round with the round key at memory operand k.
+#define HARAKA_ROUND(k, x) \
+	MOVOU k, X4; \
+	AESENC X4, x
+
+// HARAKA_INV_ROUND inverts HARAKA_ROUND: x = InvSubBytes(InvShiftRows(InvMixColumns(x ^ k))).
+#define HARAKA_INV_ROUND(k, x) \
+	MOVOU k, X4; \
+	PXOR X4, x; \
+	AESIMC x, x; \
+	AESDECLAST X14, x
+
+// HARAKA_MIX2 interleaves the 32-bit words of X0 and X1.
+#define HARAKA_MIX2 \
+	MOVO X0, X2; \
+	PUNPCKLLQ X1, X0; \
+	PUNPCKHLQ X1, X2; \
+	MOVO X2, X1
+
+// HARAKA_INV_MIX2 de-interleaves the 32-bit words of X0 and X1.
+#define HARAKA_INV_MIX2 \
+	PSHUFD $0xD8, X0, X0; \
+	PSHUFD $0xD8, X1, X1; \
+	MOVO X0, X2; \
+	PUNPCKLQDQ X1, X0; \
+	PUNPCKHQDQ X1, X2; \
+	MOVO X2, X1
+
+// HARAKA_MIX512 permutes the 32-bit words of X0-X3:
+// X0 = [s0[3], s2[3], s1[3], s3[3]], X1 = [s2[0], s0[0], s3[0], s1[0]],
+// X2 = [s2[1], s0[1], s3[1], s1[1]], X3 = [s0[2], s2[2], s1[2], s3[2]].
+#define HARAKA_MIX512 \
+	MOVO X2, X4; \
+	PUNPCKLLQ X0, X4; \
+	MOVO X3, X5; \
+	PUNPCKLLQ X1, X5; \
+	PUNPCKHLQ X2, X0; \
+	PUNPCKHLQ X3, X1; \
+	MOVO X4, X6; \
+	PUNPCKLQDQ X5, X6; \
+	PUNPCKHQDQ X5, X4; \
+	MOVO X0, X7; \
+	PUNPCKLQDQ X1, X7; \
+	PUNPCKHQDQ X1, X0; \
+	MOVO X6, X1; \
+	MOVO X4, X2; \
+	MOVO X7, X3
+
+// HARAKA_INV_MIX512 inverts HARAKA_MIX512.
+#define HARAKA_INV_MIX512 \
+	MOVO X1, X4; \
+	PUNPCKLQDQ X2, X4; \
+	PUNPCKHQDQ X2, X1; \
+	MOVO X3, X5; \
+	PUNPCKLQDQ X0, X5; \
+	PUNPCKHQDQ X0, X3; \
+	PSHUFD $0x8D, X4, X4; \
+	PSHUFD $0xD8, X5, X5; \
+	PSHUFD $0x8D, X1, X1; \
+	PSHUFD $0xD8, X3, X3; \
+	MOVO X4, X0; \
+	PUNPCKLQDQ X5, X0; \
+	PUNPCKHQDQ X5, X4; \
+	MOVO X1, X6; \
+	PUNPCKLQDQ X3, X6; \
+	PUNPCKHQDQ X3, X1; \
+	MOVO X1, X3; \
+	MOVO X6, X1; \
+	MOVO X4, X2
+
+// func harakaPermute256HW(state *[32]byte, rc *[40][16]byte)
+TEXT ·harakaPermute256HW(SB),NOSPLIT,$0
+	MOVQ state+0(FP), DI
+	MOVQ rc+8(FP), DX
+
+	MOVOU 0(DI), X0
+	MOVOU 16(DI), X1
+
+	// Round 0 (rc[0..3])
+	HARAKA_ROUND(0(DX), X0)
+	HARAKA_ROUND(16(DX), X1)
+	HARAKA_ROUND(32(DX), X0)
+	HARAKA_ROUND(48(DX), X1)
+	HARAKA_MIX2
+
+	// Round 1 (rc[4..7])
+	HARAKA_ROUND(64(DX), X0)
+	HARAKA_ROUND(80(DX), X1)
+	HARAKA_ROUND(96(DX), X0)
+	HARAKA_ROUND(112(DX), X1)
+	HARAKA_MIX2
+
+	// Round 2 (rc[8..11])
+	HARAKA_ROUND(128(DX), X0)
+	HARAKA_ROUND(144(DX), X1)
+	HARAKA_ROUND(160(DX), X0)
+	HARAKA_ROUND(176(DX), X1)
+	HARAKA_MIX2
+
+	// Round 3 (rc[12..15])
+	HARAKA_ROUND(192(DX), X0)
+	HARAKA_ROUND(208(DX), X1)
+	HARAKA_ROUND(224(DX), X0)
+	HARAKA_ROUND(240(DX), X1)
+	HARAKA_MIX2
+
+	// Round 4 (rc[16..19])
+	HARAKA_ROUND(256(DX), X0)
+	HARAKA_ROUND(272(DX), X1)
+	HARAKA_ROUND(288(DX), X0)
+	HARAKA_ROUND(304(DX), X1)
+	HARAKA_MIX2
+
+	MOVOU X0, 0(DI)
+	MOVOU X1, 16(DI)
+	RET
+
+// func harakaInversePermute256HW(state *[32]byte, rc *[40][16]byte)
+TEXT ·harakaInversePermute256HW(SB),NOSPLIT,$0
+	MOVQ state+0(FP), DI
+	MOVQ rc+8(FP), DX
+
+	MOVOU 0(DI), X0
+	MOVOU 16(DI), X1
+	PXOR X14, X14
+
+	// Round 4 (rc[16..19])
+	HARAKA_INV_MIX2
+	HARAKA_INV_ROUND(304(DX), X1)
+	HARAKA_INV_ROUND(288(DX), X0)
+	HARAKA_INV_ROUND(272(DX), X1)
+	HARAKA_INV_ROUND(256(DX), X0)
+
+	// Round 3 (rc[12..15])
+	HARAKA_INV_MIX2
+	HARAKA_INV_ROUND(240(DX), X1)
+	HARAKA_INV_ROUND(224(DX), X0)
+	HARAKA_INV_ROUND(208(DX), X1)
+	HARAKA_INV_ROUND(192(DX), X0)
+
+	// Round 2 (rc[8..11])
+	HARAKA_INV_MIX2
+	HARAKA_INV_ROUND(176(DX), X1)
+	HARAKA_INV_ROUND(160(DX), X0)
+	HARAKA_INV_ROUND(144(DX), X1)
+	HARAKA_INV_ROUND(128(DX), X0)
+
+	// Round 1 (rc[4..7])
+	HARAKA_INV_MIX2
+	HARAKA_INV_ROUND(112(DX), X1)
+	HARAKA_INV_ROUND(96(DX), X0)
+	HARAKA_INV_ROUND(80(DX), X1)
+	HARAKA_INV_ROUND(64(DX), X0)
+
+	// Round 0 (rc[0..3])
+	HARAKA_INV_MIX2
+	HARAKA_INV_ROUND(48(DX), X1)
+	HARAKA_INV_ROUND(32(DX), X0)
+	HARAKA_INV_ROUND(16(DX), X1)
+	HARAKA_INV_ROUND(0(DX), X0)
+
+	MOVOU X0, 0(DI)
+	MOVOU X1, 16(DI)
+	RET
+
+// func harakaPermute512HW(state *[64]byte, rc *[40][16]byte)
+TEXT ·harakaPermute512HW(SB),NOSPLIT,$0
+	MOVQ state+0(FP), DI
+	MOVQ rc+8(FP), DX
+
+	MOVOU 0(DI), X0
+	MOVOU 16(DI), X1
+	MOVOU 32(DI), X2
+	MOVOU 48(DI), X3
+
+	// Round 0 (rc[0..7])
+	HARAKA_ROUND(0(DX), X0)
+	HARAKA_ROUND(16(DX), X1)
+	HARAKA_ROUND(32(DX), X2)
+	HARAKA_ROUND(48(DX), X3)
+	HARAKA_ROUND(64(DX), X0)
+	HARAKA_ROUND(80(DX), X1)
+	HARAKA_ROUND(96(DX), X2)
+	HARAKA_ROUND(112(DX), X3)
+	HARAKA_MIX512
+
+	// Round 1 (rc[8..15])
+	HARAKA_ROUND(128(DX), X0)
+	HARAKA_ROUND(144(DX), X1)
+	HARAKA_ROUND(160(DX), X2)
+	HARAKA_ROUND(176(DX), X3)
+	HARAKA_ROUND(192(DX), X0)
+	HARAKA_ROUND(208(DX), X1)
+	HARAKA_ROUND(224(DX), X2)
+	HARAKA_ROUND(240(DX), X3)
+	HARAKA_MIX512
+
+	// Round 2 (rc[16..23])
+	HARAKA_ROUND(256(DX), X0)
+	HARAKA_ROUND(272(DX), X1)
+	HARAKA_ROUND(288(DX), X2)
+	HARAKA_ROUND(304(DX), X3)
+	HARAKA_ROUND(320(DX), X0)
+	HARAKA_ROUND(336(DX), X1)
+	HARAKA_ROUND(352(DX), X2)
+	HARAKA_ROUND(368(DX), X3)
+	HARAKA_MIX512
+
+	// Round 3 (rc[24..31])
+	HARAKA_ROUND(384(DX), X0)
+	HARAKA_ROUND(400(DX), X1)
+	HARAKA_ROUND(416(DX), X2)
+	HARAKA_ROUND(432(DX), X3)
+	HARAKA_ROUND(448(DX), X0)
+	HARAKA_ROUND(464(DX), X1)
+	HARAKA_ROUND(480(DX), X2)
+	HARAKA_ROUND(496(DX), X3)
+	HARAKA_MIX512
+
+	// Round 4 (rc[32..39])
+	HARAKA_ROUND(512(DX), X0)
+	HARAKA_ROUND(528(DX), X1)
+	HARAKA_ROUND(544(DX), X2)
+	HARAKA_ROUND(560(DX), X3)
+	HARAKA_ROUND(576(DX), X0)
+	HARAKA_ROUND(592(DX), X1)
+	HARAKA_ROUND(608(DX), X2)
+	HARAKA_ROUND(624(DX), X3)
+	HARAKA_MIX512
+
+	MOVOU X0, 0(DI)
+	MOVOU X1, 16(DI)
+	MOVOU X2, 32(DI)
+	MOVOU X3, 48(DI)
+	RET
+
+// func harakaInversePermute512HW(state *[64]byte, rc *[40][16]byte)
+TEXT ·harakaInversePermute512HW(SB),NOSPLIT,$0
+	MOVQ state+0(FP), DI
+	MOVQ rc+8(FP), DX
+
+	MOVOU 0(DI), X0
+	MOVOU 16(DI), X1
+	MOVOU 32(DI), X2
+	MOVOU 48(DI), X3
+	PXOR X14, X14
+
+	// Round 4 (rc[32..39])
+	HARAKA_INV_MIX512
+	HARAKA_INV_ROUND(624(DX), X3)
+	HARAKA_INV_ROUND(608(DX), X2)
+	HARAKA_INV_ROUND(592(DX), X1)
+	HARAKA_INV_ROUND(576(DX), X0)
+	HARAKA_INV_ROUND(560(DX), X3)
+	HARAKA_INV_ROUND(544(DX), X2)
+	HARAKA_INV_ROUND(528(DX), X1)
+	HARAKA_INV_ROUND(512(DX), X0)
+
+	// Round 3 (rc[24..31])
+	HARAKA_INV_MIX512
+	HARAKA_INV_ROUND(496(DX), X3)
+	HARAKA_INV_ROUND(480(DX), X2)
+	HARAKA_INV_ROUND(464(DX), X1)
+	HARAKA_INV_ROUND(448(DX), X0)
+	HARAKA_INV_ROUND(432(DX), X3)
+	HARAKA_INV_ROUND(416(DX), X2)
+	HARAKA_INV_ROUND(400(DX), X1)
+	HARAKA_INV_ROUND(384(DX), X0)
+
+	// Round 2 (rc[16..23])
+	HARAKA_INV_MIX512
+	HARAKA_INV_ROUND(368(DX), X3)
+	HARAKA_INV_ROUND(352(DX), X2)
+	HARAKA_INV_ROUND(336(DX), X1)
+	HARAKA_INV_ROUND(320(DX), X0)
+	HARAKA_INV_ROUND(304(DX), X3)
+	HARAKA_INV_ROUND(288(DX), X2)
+	HARAKA_INV_ROUND(272(DX), X1)
+	HARAKA_INV_ROUND(256(DX), X0)
+
+	// Round 1 (rc[8..15])
+	HARAKA_INV_MIX512
+	HARAKA_INV_ROUND(240(DX), X3)
+	HARAKA_INV_ROUND(224(DX), X2)
+	HARAKA_INV_ROUND(208(DX), X1)
+	HARAKA_INV_ROUND(192(DX), X0)
+	HARAKA_INV_ROUND(176(DX), X3)
+	HARAKA_INV_ROUND(160(DX), X2)
+	HARAKA_INV_ROUND(144(DX), X1)
+	HARAKA_INV_ROUND(128(DX), X0)
+
+	// Round 0 (rc[0..7])
+	HARAKA_INV_MIX512
+	HARAKA_INV_ROUND(112(DX), X3)
+	HARAKA_INV_ROUND(96(DX), X2)
+	HARAKA_INV_ROUND(80(DX), X1)
+	HARAKA_INV_ROUND(64(DX), X0)
+	HARAKA_INV_ROUND(48(DX), X3)
+	HARAKA_INV_ROUND(32(DX), X2)
+	HARAKA_INV_ROUND(16(DX), X1)
+	HARAKA_INV_ROUND(0(DX), X0)
+
+	MOVOU X0, 0(DI)
+	MOVOU X1, 16(DI)
+	MOVOU X2, 32(DI)
+	MOVOU X3, 48(DI)
+	RET
+
+// Batched Haraka-256/512 over several independent inputs. The VAES kernels
+// place input l in 128-bit lane l of each register; the AES-NI kernels
+// interleave the rounds of separate inputs.
+
+// func haraka256x4VAES(out *[4][32]byte, in *[4][32]byte, rc *[40][16]byte)
+TEXT ·haraka256x4VAES(SB),NOSPLIT,$0
+	MOVQ out+0(FP), DI
+	MOVQ in+8(FP), SI
+	MOVQ rc+16(FP), DX
+
+	// Block j of input l goes to lane l of register j
+	VMOVDQU 0(SI), X0
+	VINSERTI32X4 $1, 32(SI), Z0, Z0
+	VINSERTI32X4 $2, 64(SI), Z0, Z0
+	VINSERTI32X4 $3, 96(SI), Z0, Z0
+	VMOVDQU 16(SI), X1
+	VINSERTI32X4 $1, 48(SI), Z1, Z1
+	VINSERTI32X4 $2, 80(SI), Z1, Z1
+	VINSERTI32X4 $3, 112(SI), Z1, Z1
+
+	// Round 0
+	VBROADCASTI32X4 0(DX), Z3
+	VAESENC Z3, Z0, Z0
+	VBROADCASTI32X4 16(DX), Z3
+	VAESENC Z3, Z1, Z1
+	VBROADCASTI32X4 32(DX), Z3
+	VAESENC Z3, Z0, Z0
+	VBROADCASTI32X4 48(DX), Z3
+	VAESENC Z3, Z1, Z1
+	VPUNPCKHDQ Z1, Z0, Z2
+	VPUNPCKLDQ Z1, Z0, Z0
+
+	// Round 1
+	VBROADCASTI32X4 64(DX), Z3
+	VAESENC Z3, Z0, Z0
+	VBROADCASTI32X4 80(DX), Z3
+	VAESENC Z3, Z2, Z2
+	VBROADCASTI32X4 96(DX), Z3
+	VAESENC Z3, Z0, Z0
+	VBROADCASTI32X4 112(DX), Z3
+	VAESENC Z3, Z2, Z2
+	VPUNPCKHDQ Z2, Z0, Z1
+	VPUNPCKLDQ Z2, Z0, Z0
+
+	// Round 2
+	VBROADCASTI32X4 128(DX), Z3
+	VAESENC Z3, Z0, Z0
+	VBROADCASTI32X4 144(DX), Z3
+	VAESENC Z3, Z1, Z1
+	VBROADCASTI32X4 160(DX), Z3
+	VAESENC Z3, Z0, Z0
+	VBROADCASTI32X4 176(DX), Z3
+	VAESENC Z3, Z1, Z1
+	VPUNPCKHDQ Z1, Z0, Z2
+	VPUNPCKLDQ Z1, Z0, Z0
+
+	// Round 3
+	VBROADCASTI32X4 192(DX), Z3
+	VAESENC Z3, Z0, Z0
+	VBROADCASTI32X4 208(DX), Z3
+	VAESENC Z3, Z2, Z2
+	VBROADCASTI32X4 224(DX), Z3
+	VAESENC Z3, Z0, Z0
+	VBROADCASTI32X4 240(DX), Z3
+	VAESENC Z3, Z2, Z2
+	VPUNPCKHDQ Z2, Z0, Z1
+	VPUNPCKLDQ Z2, Z0, Z0
+
+	// Round 4
+	VBROADCASTI32X4 256(DX), Z3
+	VAESENC Z3, Z0, Z0
+	VBROADCASTI32X4 272(DX), Z3
+	VAESENC Z3, Z1, Z1
+	VBROADCASTI32X4 288(DX), Z3
+	VAESENC Z3, Z0, Z0
+	VBROADCASTI32X4 304(DX), Z3
+	VAESENC Z3, Z1, Z1
+	VPUNPCKHDQ Z1, Z0, Z2
+	VPUNPCKLDQ Z1, Z0, Z0
+
+	// Feed-forward
+	VMOVDQU 0(SI), X1
+	VINSERTI32X4 $1, 32(SI), Z1, Z1
+	VINSERTI32X4 $2, 64(SI), Z1, Z1
+	VINSERTI32X4 $3, 96(SI), Z1, Z1
+	VPXORD Z1, Z0, Z0
+	VMOVDQU 16(SI), X1
+	VINSERTI32X4 $1, 48(SI), Z1, Z1
+	VINSERTI32X4 $2, 80(SI), Z1, Z1
+	VINSERTI32X4 $3, 112(SI), Z1, Z1
+	VPXORD Z1, Z2, Z2
+
+	VMOVDQU X0, 0(DI)
+	VEXTRACTI32X4 $1, Z0, 32(DI)
+	VEXTRACTI32X4 $2, Z0, 64(DI)
+	VEXTRACTI32X4 $3, Z0, 96(DI)
+	VMOVDQU X2, 16(DI)
+	VEXTRACTI32X4 $1, Z2, 48(DI)
+	VEXTRACTI32X4 $2, Z2, 80(DI)
+	VEXTRACTI32X4 $3, Z2, 112(DI)
+	VZEROUPPER
+	RET
+
+// func haraka256x2VAES(out *[2][32]byte, in *[2][32]byte, rc *[40][16]byte)
+TEXT ·haraka256x2VAES(SB),NOSPLIT,$0
+	MOVQ out+0(FP), DI
+	MOVQ in+8(FP), SI
+	MOVQ rc+16(FP), DX
+
+	// Block j of input l goes to lane l of register j
+	VMOVDQU 0(SI), X0
+	VINSERTI128 $1, 32(SI), Y0, Y0
+	VMOVDQU 16(SI), X1
+	VINSERTI128 $1, 48(SI), Y1, Y1
+
+	// Round 0
+	VBROADCASTI128 0(DX), Y3
+	VAESENC Y3, Y0, Y0
+	VBROADCASTI128 16(DX), Y3
+	VAESENC Y3, Y1, Y1
+	VBROADCASTI128 32(DX), Y3
+	VAESENC Y3, Y0, Y0
+	VBROADCASTI128 48(DX), Y3
+	VAESENC Y3, Y1, Y1
+	VPUNPCKHDQ Y1, Y0, Y2
+	VPUNPCKLDQ Y1, Y0, Y0
+
+	// Round 1
+	VBROADCASTI128 64(DX), Y3
+	VAESENC Y3, Y0, Y0
+	VBROADCASTI128 80(DX), Y3
+	VAESENC Y3, Y2, Y2
+	VBROADCASTI128 96(DX), Y3
+	VAESENC Y3, Y0, Y0
+	VBROADCASTI128 112(DX), Y3
+	VAESENC Y3, Y2, Y2
+	VPUNPCKHDQ Y2, Y0, Y1
+	VPUNPCKLDQ Y2, Y0, Y0
+
+	// Round 2
+	VBROADCASTI128 128(DX), Y3
+	VAESENC Y3, Y0, Y0
+	VBROADCASTI128 144(DX), Y3
+	VAESENC Y3, Y1, Y1
+	VBROADCASTI128 160(DX), Y3
+	VAESENC Y3, Y0, Y0
+	VBROADCASTI128 176(DX), Y3
+	VAESENC Y3, Y1, Y1
+	VPUNPCKHDQ Y1, Y0, Y2
+	VPUNPCKLDQ Y1, Y0, Y0
+
+	// Round 3
+	VBROADCASTI128 192(DX), Y3
+	VAESENC Y3, Y0, Y0
+	VBROADCASTI128 208(DX), Y3
+	VAESENC Y3, Y2, Y2
+	VBROADCASTI128 224(DX), Y3
+	VAESENC Y3, Y0, Y0
+	VBROADCASTI128 240(DX), Y3
+	VAESENC Y3, Y2, Y2
+	VPUNPCKHDQ Y2, Y0, Y1
+	VPUNPCKLDQ Y2, Y0, Y0
+
+	// Round 4
+	VBROADCASTI128 256(DX), Y3
+	VAESENC Y3, Y0, Y0
+	VBROADCASTI128 272(DX), Y3
+	VAESENC Y3, Y1, Y1
+	VBROADCASTI128 288(DX), Y3
+	VAESENC Y3, Y0, Y0
+	VBROADCASTI128 304(DX), Y3
+	VAESENC Y3, Y1, Y1
+	VPUNPCKHDQ Y1, Y0, Y2
+	VPUNPCKLDQ Y1, Y0, Y0
+
+	// Feed-forward
+	VMOVDQU 0(SI), X1
+	VINSERTI128 $1, 32(SI), Y1, Y1
+	VPXOR Y1, Y0, Y0
+	VMOVDQU 16(SI), X1
+	VINSERTI128 $1, 48(SI), Y1, Y1
+	VPXOR Y1, Y2, Y2
+
+	VMOVDQU X0, 0(DI)
+	VEXTRACTI128 $1, Y0, 32(DI)
+	VMOVDQU X2, 16(DI)
+	VEXTRACTI128 $1, Y2, 48(DI)
+	VZEROUPPER
+	RET
+
+// func haraka512x4VAES(out *[4][32]byte, in *[4][64]byte, rc *[40][16]byte)
+TEXT ·haraka512x4VAES(SB),NOSPLIT,$0
+	MOVQ out+0(FP), DI
+	MOVQ in+8(FP), SI
+	MOVQ rc+16(FP), DX
+
+	// Block j of input l goes to lane l of register j
+	VMOVDQU 0(SI), X0
+	VINSERTI32X4 $1, 64(SI), Z0, Z0
+	VINSERTI32X4 $2, 128(SI), Z0, Z0
+	VINSERTI32X4 $3, 192(SI), Z0, Z0
+	VMOVDQU 16(SI), X1
+	VINSERTI32X4 $1, 80(SI), Z1, Z1
+	VINSERTI32X4 $2, 144(SI), Z1, Z1
+	VINSERTI32X4 $3, 208(SI), Z1, Z1
+	VMOVDQU 32(SI), X2
+	VINSERTI32X4 $1, 96(SI), Z2, Z2
+	VINSERTI32X4 $2, 160(SI), Z2, Z2
+	VINSERTI32X4 $3, 224(SI), Z2, Z2
+	VMOVDQU 48(SI), X3
+	VINSERTI32X4 $1, 112(SI), Z3, Z3
+	VINSERTI32X4 $2, 176(SI), Z3, Z3
+	VINSERTI32X4 $3, 240(SI), Z3, Z3
+
+	// Round 0
+	VBROADCASTI32X4 0(DX), Z8
+	VAESENC Z8, Z0, Z0
+	VBROADCASTI32X4 16(DX), Z8
+	VAESENC Z8, Z1, Z1
+	VBROADCASTI32X4 32(DX), Z8
+	VAESENC Z8, Z2, Z2
+	VBROADCASTI32X4 48(DX), Z8
+	VAESENC Z8, Z3, Z3
+	VBROADCASTI32X4 64(DX), Z8
+	VAESENC Z8, Z0, Z0
+	VBROADCASTI32X4 80(DX), Z8
+	VAESENC Z8, Z1, Z1
+	VBROADCASTI32X4 96(DX), Z8
+	VAESENC Z8, Z2, Z2
+	VBROADCASTI32X4 112(DX), Z8
+	VAESENC Z8, Z3, Z3
+	VPUNPCKLDQ Z0, Z2, Z4
+	VPUNPCKLDQ Z1, Z3, Z5
+	VPUNPCKHDQ Z2, Z0, Z6
+	VPUNPCKHDQ Z3, Z1, Z7
+	VPUNPCKLQDQ Z5, Z4, Z1
+	VPUNPCKHQDQ Z5, Z4, Z2
+	VPUNPCKLQDQ Z7, Z6, Z3
+	VPUNPCKHQDQ Z7, Z6, Z0
+
+	// Round 1
+	VBROADCASTI32X4 128(DX), Z8
+	VAESENC Z8, Z0, Z0
+	VBROADCASTI32X4 144(DX), Z8
+	VAESENC Z8, Z1, Z1
+	VBROADCASTI32X4 160(DX), Z8
+	VAESENC Z8, Z2, Z2
+	VBROADCASTI32X4 176(DX), Z8
+	VAESENC Z8, Z3, Z3
+	VBROADCASTI32X4 192(DX), Z8
+	VAESENC Z8, Z0, Z0
+	VBROADCASTI32X4 208(DX), Z8
+	VAESENC Z8, Z1, Z1
+	VBROADCASTI32X4 224(DX), Z8
+	VAESENC Z8, Z2, Z2
+	VBROADCASTI32X4 240(DX), Z8
+	VAESENC Z8, Z3, Z3
+	VPUNPCKLDQ Z0, Z2, Z4
+	VPUNPCKLDQ Z1, Z3, Z5
+	VPUNPCKHDQ Z2, Z0, Z6
+	VPUNPCKHDQ Z3, Z1, Z7
+	VPUNPCKLQDQ Z5, Z4, Z1
+	VPUNPCKHQDQ Z5, Z4, Z2
+	VPUNPCKLQDQ Z7, Z6, Z3
+	VPUNPCKHQDQ Z7, Z6, Z0
+
+	// Round 2
+	VBROADCASTI32X4 256(DX), Z8
+	VAESENC Z8, Z0, Z0
+	VBROADCASTI32X4 272(DX), Z8
+	VAESENC Z8, Z1, Z1
+	VBROADCASTI32X4 288(DX), Z8
+	VAESENC Z8, Z2, Z2
+	VBROADCASTI32X4 304(DX), Z8
+	VAESENC Z8, Z3, Z3
+	VBROADCASTI32X4 320(DX), Z8
+	VAESENC Z8, Z0, Z0
+	VBROADCASTI32X4 336(DX), Z8
+	VAESENC Z8, Z1, Z1
+	VBROADCASTI32X4 352(DX), Z8
+	VAESENC Z8, Z2, Z2
+	VBROADCASTI32X4 368(DX), Z8
+	VAESENC Z8, Z3, Z3
+	VPUNPCKLDQ Z0, Z2, Z4
+	VPUNPCKLDQ Z1, Z3, Z5
+	VPUNPCKHDQ Z2, Z0, Z6
+	VPUNPCKHDQ Z3, Z1, Z7
+	VPUNPCKLQDQ Z5, Z4, Z1
+	VPUNPCKHQDQ Z5, Z4, Z2
+	VPUNPCKLQDQ Z7, Z6, Z3
+	VPUNPCKHQDQ Z7, Z6, Z0
+
+	// Round 3
+	VBROADCASTI32X4 384(DX), Z8
+	VAESENC Z8, Z0, Z0
+	VBROADCASTI32X4 400(DX), Z8
+	VAESENC Z8, Z1, Z1
+	VBROADCASTI32X4 416(DX), Z8
+	VAESENC Z8, Z2, Z2
+	VBROADCASTI32X4 432(DX), Z8
+	VAESENC Z8, Z3, Z3
+	VBROADCASTI32X4 448(DX), Z8
+	VAESENC Z8, Z0, Z0
+	VBROADCASTI32X4 464(DX), Z8
+	VAESENC Z8, Z1, Z1
+	VBROADCASTI32X4 480(DX), Z8
+	VAESENC Z8, Z2, Z2
+	VBROADCASTI32X4 496(DX), Z8
+	VAESENC Z8, Z3, Z3
+	VPUNPCKLDQ Z0, Z2, Z4
+	VPUNPCKLDQ Z1, Z3, Z5
+	VPUNPCKHDQ Z2, Z0, Z6
+	VPUNPCKHDQ Z3, Z1, Z7
+	VPUNPCKLQDQ Z5, Z4, Z1
+	VPUNPCKHQDQ Z5, Z4, Z2
+	VPUNPCKLQDQ Z7, Z6, Z3
+	VPUNPCKHQDQ Z7, Z6, Z0
+
+	// Round 4
+	VBROADCASTI32X4 512(DX), Z8
+	VAESENC Z8, Z0, Z0
+	VBROADCASTI32X4 528(DX), Z8
+	VAESENC Z8, Z1, Z1
+	VBROADCASTI32X4 544(DX), Z8
+	VAESENC Z8, Z2, Z2
+	VBROADCASTI32X4 560(DX), Z8
+	VAESENC Z8, Z3, Z3
+	VBROADCASTI32X4 576(DX), Z8
+	VAESENC Z8, Z0, Z0
+	VBROADCASTI32X4 592(DX), Z8
+	VAESENC Z8, Z1, Z1
+	VBROADCASTI32X4 608(DX), Z8
+	VAESENC Z8, Z2, Z2
+	VBROADCASTI32X4 624(DX), Z8
+	VAESENC Z8, Z3, Z3
+	VPUNPCKLDQ Z0, Z2, Z4
+	VPUNPCKLDQ Z1, Z3, Z5
+	VPUNPCKHDQ Z2, Z0, Z6
+	VPUNPCKHDQ Z3, Z1, Z7
+	VPUNPCKLQDQ Z5, Z4, Z1
+	VPUNPCKHQDQ Z5, Z4, Z2
+	VPUNPCKLQDQ Z7, Z6, Z3
+	VPUNPCKHQDQ Z7, Z6, Z0
+
+	// Feed-forward
+	VMOVDQU 0(SI), X4
+	VINSERTI32X4 $1, 64(SI), Z4, Z4
+	VINSERTI32X4 $2, 128(SI), Z4, Z4
+	VINSERTI32X4 $3, 192(SI), Z4, Z4
+	VPXORD Z4, Z0, Z0
+	VMOVDQU 16(SI), X4
+	VINSERTI32X4 $1, 80(SI), Z4, Z4
+	VINSERTI32X4 $2, 144(SI), Z4, Z4
+	VINSERTI32X4 $3, 208(SI), Z4, Z4
+	VPXORD Z4, Z1, Z1
+	VMOVDQU 32(SI), X4
+	VINSERTI32X4 $1, 96(SI), Z4, Z4
+	VINSERTI32X4 $2, 160(SI), Z4, Z4
+	VINSERTI32X4 $3, 224(SI), Z4, Z4
+	VPXORD Z4, Z2, Z2
+	VMOVDQU 48(SI), X4
+	VINSERTI32X4 $1, 112(SI), Z4, Z4
+	VINSERTI32X4 $2, 176(SI), Z4, Z4
+	VINSERTI32X4 $3, 240(SI), Z4, Z4
+	VPXORD Z4, Z3, Z3
+	// Truncate to s0[8:16] || s1[8:16] || s2[0:8] || s3[0:8]
+	VPUNPCKHQDQ Z1, Z0, Z0
+	VPUNPCKLQDQ Z3, Z2, Z2
+
+	VMOVDQU X0, 0(DI)
+	VEXTRACTI32X4 $1, Z0, 32(DI)
+	VEXTRACTI32X4 $2, Z0, 64(DI)
+	VEXTRACTI32X4 $3, Z0, 96(DI)
+	VMOVDQU X2, 16(DI)
+	VEXTRACTI32X4 $1, Z2, 48(DI)
+	VEXTRACTI32X4 $2, Z2, 80(DI)
+	VEXTRACTI32X4 $3, Z2, 112(DI)
+	VZEROUPPER
+	RET
+
+// func haraka512x2VAES(out *[2][32]byte, in *[2][64]byte, rc *[40][16]byte)
+TEXT ·haraka512x2VAES(SB),NOSPLIT,$0
+	MOVQ out+0(FP), DI
+	MOVQ in+8(FP), SI
+	MOVQ rc+16(FP), DX
+
+	// Block j of input l goes to lane l of register j
+	VMOVDQU 0(SI), X0
+	VINSERTI128 $1, 64(SI), Y0, Y0
+	VMOVDQU 16(SI), X1
+	VINSERTI128 $1, 80(SI), Y1, Y1
+	VMOVDQU 32(SI), X2
+	VINSERTI128 $1, 96(SI), Y2, Y2
+	VMOVDQU 48(SI), X3
+	VINSERTI128 $1, 112(SI), Y3, Y3
+
+	// Round 0
+	VBROADCASTI128 0(DX), Y8
+	VAESENC Y8, Y0, Y0
+	VBROADCASTI128 16(DX), Y8
+	VAESENC Y8, Y1, Y1
+	VBROADCASTI128 32(DX), Y8
+	VAESENC Y8, Y2, Y2
+	VBROADCASTI128 48(DX), Y8
+	VAESENC Y8, Y3, Y3
+	VBROADCASTI128 64(DX), Y8
+	VAESENC Y8, Y0, Y0
+	VBROADCASTI128 80(DX), Y8
+	VAESENC Y8, Y1, Y1
+	VBROADCASTI128 96(DX), Y8
+	VAESENC Y8, Y2, Y2
+	VBROADCASTI128 112(DX), Y8
+	VAESENC Y8, Y3, Y3
+	VPUNPCKLDQ Y0, Y2, Y4
+	VPUNPCKLDQ Y1, Y3, Y5
+	VPUNPCKHDQ Y2, Y0, Y6
+	VPUNPCKHDQ Y3, Y1, Y7
+	VPUNPCKLQDQ Y5, Y4, Y1
+	VPUNPCKHQDQ Y5, Y4, Y2
+	VPUNPCKLQDQ Y7, Y6, Y3
+	VPUNPCKHQDQ Y7, Y6, Y0
+
+	// Round 1
+	VBROADCASTI128 128(DX), Y8
+	VAESENC Y8, Y0, Y0
+	VBROADCASTI128 144(DX), Y8
+	VAESENC Y8, Y1, Y1
+	VBROADCASTI128 160(DX), Y8
+	VAESENC Y8, Y2, Y2
+	VBROADCASTI128 176(DX), Y8
+	VAESENC Y8, Y3, Y3
+	VBROADCASTI128 192(DX), Y8
+	VAESENC Y8, Y0, Y0
+	VBROADCASTI128 208(DX), Y8
+	VAESENC Y8, Y1, Y1
+	VBROADCASTI128 224(DX), Y8
+	VAESENC Y8, Y2, Y2
+	VBROADCASTI128 240(DX), Y8
+	VAESENC Y8, Y3, Y3
+	VPUNPCKLDQ Y0, Y2, Y4
+	VPUNPCKLDQ Y1, Y3, Y5
+	VPUNPCKHDQ Y2, Y0, Y6
+	VPUNPCKHDQ Y3, Y1, Y7
+	VPUNPCKLQDQ Y5, Y4, Y1
+	VPUNPCKHQDQ Y5, Y4, Y2
+	VPUNPCKLQDQ Y7, Y6, Y3
+	VPUNPCKHQDQ Y7, Y6, Y0
+
+	// Round 2
+	VBROADCASTI128 256(DX), Y8
+	VAESENC Y8, Y0, Y0
+	VBROADCASTI128 272(DX), Y8
+	VAESENC Y8, Y1, Y1
+	VBROADCASTI128 288(DX), Y8
+	VAESENC Y8, Y2, Y2
+	VBROADCASTI128 304(DX), Y8
+	VAESENC Y8, Y3, Y3
+	VBROADCASTI128 320(DX), Y8
+	VAESENC Y8, Y0, Y0
+	VBROADCASTI128 336(DX), Y8
+	VAESENC Y8, Y1, Y1
+	VBROADCASTI128 352(DX), Y8
+	VAESENC Y8, Y2, Y2
+	VBROADCASTI128 368(DX), Y8
+	VAESENC Y8, Y3, Y3
+	VPUNPCKLDQ Y0, Y2, Y4
+	VPUNPCKLDQ Y1, Y3, Y5
+	VPUNPCKHDQ Y2, Y0, Y6
+	VPUNPCKHDQ Y3, Y1, Y7
+	VPUNPCKLQDQ Y5, Y4, Y1
+	VPUNPCKHQDQ Y5, Y4, Y2
+	VPUNPCKLQDQ Y7, Y6, Y3
+	VPUNPCKHQDQ Y7, Y6, Y0
+
+	// Round 3
+	VBROADCASTI128 384(DX), Y8
+	VAESENC Y8, Y0, Y0
+	VBROADCASTI128 400(DX), Y8
+	VAESENC Y8, Y1, Y1
+	VBROADCASTI128 416(DX), Y8
+	VAESENC Y8, Y2, Y2
+	VBROADCASTI128 432(DX), Y8
+	VAESENC Y8, Y3, Y3
+	VBROADCASTI128 448(DX), Y8
+	VAESENC Y8, Y0, Y0
+	VBROADCASTI128 464(DX), Y8
+	VAESENC Y8, Y1, Y1
+	VBROADCASTI128 480(DX), Y8
+	VAESENC Y8, Y2, Y2
+	VBROADCASTI128 496(DX), Y8
+	VAESENC Y8, Y3, Y3
+	VPUNPCKLDQ Y0, Y2, Y4
+	VPUNPCKLDQ Y1, Y3, Y5
+	VPUNPCKHDQ Y2, Y0, Y6
+	VPUNPCKHDQ Y3, Y1, Y7
+	VPUNPCKLQDQ Y5, Y4, Y1
+	VPUNPCKHQDQ Y5, Y4, Y2
+	VPUNPCKLQDQ Y7, Y6, Y3
+	VPUNPCKHQDQ Y7, Y6, Y0
+
+	// Round 4
+	VBROADCASTI128 512(DX), Y8
+	VAESENC Y8, Y0, Y0
+	VBROADCASTI128 528(DX), Y8
+	VAESENC Y8, Y1, Y1
+	VBROADCASTI128 544(DX), Y8
+	VAESENC Y8, Y2, Y2
+	VBROADCASTI128 560(DX), Y8
+	VAESENC Y8, Y3, Y3
+	VBROADCASTI128 576(DX), Y8
+	VAESENC Y8, Y0, Y0
+	VBROADCASTI128 592(DX), Y8
+	VAESENC Y8, Y1, Y1
+	VBROADCASTI128 608(DX), Y8
+	VAESENC Y8, Y2, Y2
+	VBROADCASTI128 624(DX), Y8
+	VAESENC Y8, Y3, Y3
+	VPUNPCKLDQ Y0, Y2, Y4
+	VPUNPCKLDQ Y1, Y3, Y5
+	VPUNPCKHDQ Y2, Y0, Y6
+	VPUNPCKHDQ Y3, Y1, Y7
+	VPUNPCKLQDQ Y5, Y4, Y1
+	VPUNPCKHQDQ Y5, Y4, Y2
+	VPUNPCKLQDQ Y7, Y6, Y3
+	VPUNPCKHQDQ Y7, Y6, Y0
+
+	// Feed-forward
+	VMOVDQU 0(SI), X4
+	VINSERTI128 $1, 64(SI), Y4, Y4
+	VPXOR Y4, Y0, Y0
+	VMOVDQU 16(SI), X4
+	VINSERTI128 $1, 80(SI), Y4, Y4
+	VPXOR Y4, Y1, Y1
+	VMOVDQU 32(SI), X4
+	VINSERTI128 $1, 96(SI), Y4, Y4
+	VPXOR Y4, Y2, Y2
+	VMOVDQU 48(SI), X4
+	VINSERTI128 $1, 112(SI), Y4, Y4
+	VPXOR Y4, Y3, Y3
+	// Truncate to s0[8:16] || s1[8:16] || s2[0:8] || s3[0:8]
+	VPUNPCKHQDQ Y1, Y0, Y0
+	VPUNPCKLQDQ Y3, Y2, Y2
+
+	VMOVDQU X0, 0(DI)
+	VEXTRACTI128 $1, Y0, 32(DI)
+	VMOVDQU X2, 16(DI)
+	VEXTRACTI128 $1, Y2, 48(DI)
+	VZEROUPPER
+	RET
+
+// func haraka256x4AESNI(out *[4][32]byte, in *[4][32]byte, rc *[40][16]byte)
+TEXT ·haraka256x4AESNI(SB),NOSPLIT,$0
+	MOVQ out+0(FP), DI
+	MOVQ in+8(FP), SI
+	MOVQ rc+16(FP), DX
+
+	MOVOU 0(SI), X0
+	MOVOU 16(SI), X1
+	MOVOU 32(SI), X2
+	MOVOU 48(SI), X3
+	MOVOU 64(SI), X4
+	MOVOU 80(SI), X5
+	MOVOU 96(SI), X6
+	MOVOU 112(SI), X7
+
+	// Round 0
+	MOVOU 0(DX), X8
+	AESENC X8, X0
+	AESENC X8, X2
+	AESENC X8, X4
+	AESENC X8, X6
+	MOVOU 16(DX), X8
+	AESENC X8, X1
+	AESENC X8, X3
+	AESENC X8, X5
+	AESENC X8, X7
+	MOVOU 32(DX), X8
+	AESENC X8, X0
+	AESENC X8, X2
+	AESENC X8, X4
+	AESENC X8, X6
+	MOVOU 48(DX), X8
+	AESENC X8, X1
+	AESENC X8, X3
+	AESENC X8, X5
+	AESENC X8, X7
+	MOVO X0, X9
+	PUNPCKLLQ X1, X0
+	PUNPCKHLQ X1, X9
+	MOVO X2, X10
+	PUNPCKLLQ X3, X2
+	PUNPCKHLQ X3, X10
+	MOVO X4, X11
+	PUNPCKLLQ X5, X4
+	PUNPCKHLQ X5, X11
+	MOVO X6, X12
+	PUNPCKLLQ X7, X6
+	PUNPCKHLQ X7, X12
+
+	// Round 1
+	MOVOU 64(DX), X8
+	AESENC X8, X0
+	AESENC X8, X2
+	AESENC X8, X4
+	AESENC X8, X6
+	MOVOU 80(DX), X8
+	AESENC X8, X9
+	AESENC X8, X10
+	AESENC X8, X11
+	AESENC X8, X12
+	MOVOU 96(DX), X8
+	AESENC X8, X0
+	AESENC X8, X2
+	AESENC X8, X4
+	AESENC X8, X6
+	MOVOU 112(DX), X8
+	AESENC X8, X9
+	AESENC X8, X10
+	AESENC X8, X11
+	AESENC X8, X12
+	MOVO X0, X13
+	PUNPCKLLQ X9, X0
+	PUNPCKHLQ X9, X13
+	MOVO X2, X14
+	PUNPCKLLQ X10, X2
+	PUNPCKHLQ X10, X14
+	MOVO X4, X15
+	PUNPCKLLQ X11, X4
+	PUNPCKHLQ X11, X15
+	MOVO X6, X1
+	PUNPCKLLQ X12, X6
+	PUNPCKHLQ X12, X1
+
+	// Round 2
+	MOVOU 128(DX), X8
+	AESENC X8, X0
+	AESENC X8, X2
+	AESENC X8, X4
+	AESENC X8, X6
+	MOVOU 144(DX), X8
+	AESENC X8, X13
+	AESENC X8, X14
+	AESENC X8, X15
+	AESENC X8, X1
+	MOVOU 160(DX), X8
+	AESENC X8, X0
+	AESENC X8, X2
+	AESENC X8, X4
+	AESENC X8, X6
+	MOVOU 176(DX), X8
+	AESENC X8, X13
+	AESENC X8, X14
+	AESENC X8, X15
+	AESENC X8, X1
+	MOVO X0, X3
+	PUNPCKLLQ X13, X0
+	PUNPCKHLQ X13, X3
+	MOVO X2, X5
+	PUNPCKLLQ X14, X2
+	PUNPCKHLQ X14, X5
+	MOVO X4, X7
+	PUNPCKLLQ X15, X4
+	PUNPCKHLQ X15, X7
+	MOVO X6, X9
+	PUNPCKLLQ X1, X6
+	PUNPCKHLQ X1, X9
+
+	// Round 3
+	MOVOU 192(DX), X8
+	AESENC X8, X0
+	AESENC X8, X2
+	AESENC X8, X4
+	AESENC X8, X6
+	MOVOU 208(DX), X8
+	AESENC X8, X3
+	AESENC X8, X5
+	AESENC X8, X7
+	AESENC X8, X9
+	MOVOU 224(DX), X8
+	AESENC X8, X0
+	AESENC X8, X2
+	AESENC X8, X4
+	AESENC X8, X6
+	MOVOU 240(DX), X8
+	AESENC X8, X3
+	AESENC X8, X5
+	AESENC X8, X7
+	AESENC X8, X9
+	MOVO X0, X10
+	PUNPCKLLQ X3, X0
+	PUNPCKHLQ X3, X10
+	MOVO X2, X11
+	PUNPCKLLQ X5, X2
+	PUNPCKHLQ X5, X11
+	MOVO X4, X12
+	PUNPCKLLQ X7, X4
+	PUNPCKHLQ X7, X12
+	MOVO X6, X13
+	PUNPCKLLQ X9, X6
+	PUNPCKHLQ X9, X13
+
+	// Round 4
+	MOVOU 256(DX), X8
+	AESENC X8, X0
+	AESENC X8, X2
+	AESENC X8, X4
+	AESENC X8, X6
+	MOVOU 272(DX), X8
+	AESENC X8, X10
+	AESENC X8, X11
+	AESENC X8, X12
+	AESENC X8, X13
+	MOVOU 288(DX), X8
+	AESENC X8, X0
+	AESENC X8, X2
+	AESENC X8, X4
+	AESENC X8, X6
+	MOVOU 304(DX), X8
+	AESENC X8, X10
+	AESENC X8, X11
+	AESENC X8, X12
+	AESENC X8, X13
+	MOVO X0, X14
+	PUNPCKLLQ X10, X0
+	PUNPCKHLQ X10, X14
+	MOVO X2, X15
+	PUNPCKLLQ X11, X2
+	PUNPCKHLQ X11, X15
+	MOVO X4, X1
+	PUNPCKLLQ X12, X4
+	PUNPCKHLQ X12, X1
+	MOVO X6, X3
+	PUNPCKLLQ X13, X6
+	PUNPCKHLQ X13, X3
+
+	// Feed-forward
+	MOVOU 0(SI), X5
+	PXOR X5, X0
+	MOVOU 16(SI), X5
+	PXOR X5, X14
+	MOVOU 32(SI), X5
+	PXOR X5, X2
+	MOVOU 48(SI), X5
+	PXOR X5, X15
+	MOVOU 64(SI), X5
+	PXOR X5, X4
+	MOVOU 80(SI), X5
+	PXOR X5, X1
+	MOVOU 96(SI), X5
+	PXOR X5, X6
+	MOVOU 112(SI), X5
+	PXOR X5, X3
+
+	MOVOU X0, 0(DI)
+	MOVOU X14, 16(DI)
+	MOVOU X2, 32(DI)
+	MOVOU X15, 48(DI)
+	MOVOU X4, 64(DI)
+	MOVOU X1, 80(DI)
+	MOVOU X6, 96(DI)
+	MOVOU X3, 112(DI)
+	RET
+
+// func haraka512x2AESNI(out *[2][32]byte, in *[2][64]byte, rc *[40][16]byte)
+TEXT ·haraka512x2AESNI(SB),NOSPLIT,$0
+	MOVQ out+0(FP), DI
+	MOVQ in+8(FP), SI
+	MOVQ rc+16(FP), DX
+
+	MOVOU 0(SI), X0
+	MOVOU 16(SI), X1
+	MOVOU 32(SI), X2
+	MOVOU 48(SI), X3
+	MOVOU 64(SI), X4
+	MOVOU 80(SI), X5
+	MOVOU 96(SI), X6
+	MOVOU 112(SI), X7
+
+	// Round 0
+	MOVOU 0(DX), X8
+	AESENC X8, X0
+	AESENC X8, X4
+	MOVOU 16(DX), X8
+	AESENC X8, X1
+	AESENC X8, X5
+	MOVOU 32(DX), X8
+	AESENC X8, X2
+	AESENC X8, X6
+	MOVOU 48(DX), X8
+	AESENC X8, X3
+	AESENC X8, X7
+	MOVOU 64(DX), X8
+	AESENC X8, X0
+	AESENC X8, X4
+	MOVOU 80(DX), X8
+	AESENC X8, X1
+	AESENC X8, X5
+	MOVOU 96(DX), X8
+	AESENC X8, X2
+	AESENC X8, X6
+	MOVOU 112(DX), X8
+	AESENC X8, X3
+	AESENC X8, X7
+	MOVO X2, X9
+	PUNPCKLLQ X0, X9
+	MOVO X3, X10
+	PUNPCKLLQ X1, X10
+	PUNPCKHLQ X2, X0
+	PUNPCKHLQ X3, X1
+	MOVO X9, X2
+	PUNPCKLQDQ X10, X2
+	PUNPCKHQDQ X10, X9
+	MOVO X0, X3
+	PUNPCKLQDQ X1, X3
+	PUNPCKHQDQ X1, X0
+	MOVO X6, X11
+	PUNPCKLLQ X4, X11
+	MOVO X7, X12
+	PUNPCKLLQ X5, X12
+	PUNPCKHLQ X6, X4
+	PUNPCKHLQ X7, X5
+	MOVO X11, X6
+	PUNPCKLQDQ X12, X6
+	PUNPCKHQDQ X12, X11
+	MOVO X4, X7
+	PUNPCKLQDQ X5, X7
+	PUNPCKHQDQ X5, X4
+
+	// Round 1
+	MOVOU 128(DX), X8
+	AESENC X8, X0
+	AESENC X8, X4
+	MOVOU 144(DX), X8
+	AESENC X8, X2
+	AESENC X8, X6
+	MOVOU 160(DX), X8
+	AESENC X8, X9
+	AESENC X8, X11
+	MOVOU 176(DX), X8
+	AESENC X8, X3
+	AESENC X8, X7
+	MOVOU 192(DX), X8
+	AESENC X8, X0
+	AESENC X8, X4
+	MOVOU 208(DX), X8
+	AESENC X8, X2
+	AESENC X8, X6
+	MOVOU 224(DX), X8
+	AESENC X8, X9
+	AESENC X8, X11
+	MOVOU 240(DX), X8
+	AESENC X8, X3
+	AESENC X8, X7
+	MOVO X9, X13
+	PUNPCKLLQ X0, X13
+	MOVO X3, X14
+	PUNPCKLLQ X2, X14
+	PUNPCKHLQ X9, X0
+	PUNPCKHLQ X3, X2
+	MOVO X13, X9
+	PUNPCKLQDQ X14, X9
+	PUNPCKHQDQ X14, X13
+	MOVO X0, X3
+	PUNPCKLQDQ X2, X3
+	PUNPCKHQDQ X2, X0
+	MOVO X11, X15
+	PUNPCKLLQ X4, X15
+	MOVO X7, X1
+	PUNPCKLLQ X6, X1
+	PUNPCKHLQ X11, X4
+	PUNPCKHLQ X7, X6
+	MOVO X15, X11
+	PUNPCKLQDQ X1, X11
+	PUNPCKHQDQ X1, X15
+	MOVO X4, X7
+	PUNPCKLQDQ X6, X7
+	PUNPCKHQDQ X6, X4
+
+	// Round 2
+	MOVOU 256(DX), X8
+	AESENC X8, X0
+	AESENC X8, X4
+	MOVOU 272(DX), X8
+	AESENC X8, X9
+	AESENC X8, X11
+	MOVOU 288(DX), X8
+	AESENC X8, X13
+	AESENC X8, X15
+	MOVOU 304(DX), X8
+	AESENC X8, X3
+	AESENC X8, X7
+	MOVOU 320(DX), X8
+	AESENC X8, X0
+	AESENC X8, X4
+	MOVOU 336(DX), X8
+	AESENC X8, X9
+	AESENC X8, X11
+	MOVOU 352(DX), X8
+	AESENC X8, X13
+	AESENC X8, X15
+	MOVOU 368(DX), X8
+	AESENC X8, X3
+	AESENC X8, X7
+	MOVO X13, X10
+	PUNPCKLLQ X0, X10
+	MOVO X3, X5
+	PUNPCKLLQ X9, X5
+	PUNPCKHLQ X13, X0
+	PUNPCKHLQ X3, X9
+	MOVO X10, X13
+	PUNPCKLQDQ X5, X13
+	PUNPCKHQDQ X5, X10
+	MOVO X0, X3
+	PUNPCKLQDQ X9, X3
+	PUNPCKHQDQ X9, X0
+	MOVO X15, X12
+	PUNPCKLLQ X4, X12
+	MOVO X7, X2
+	PUNPCKLLQ X11, X2
+	PUNPCKHLQ X15, X4
+	PUNPCKHLQ X7, X11
+	MOVO X12, X15
+	PUNPCKLQDQ X2, X15
+	PUNPCKHQDQ X2, X12
+	MOVO X4, X7
+	PUNPCKLQDQ X11, X7
+	PUNPCKHQDQ X11, X4
+
+	// Round 3
+	MOVOU 384(DX), X8
+	AESENC X8, X0
+	AESENC X8, X4
+	MOVOU 400(DX), X8
+	AESENC X8, X13
+	AESENC X8, X15
+	MOVOU 416(DX), X8
+	AESENC X8, X10
+	AESENC X8, X12
+	MOVOU 432(DX), X8
+	AESENC X8, X3
+	AESENC X8, X7
+	MOVOU 448(DX), X8
+	AESENC X8, X0
+	AESENC X8, X4
+	MOVOU 464(DX), X8
+	AESENC X8, X13
+	AESENC X8, X15
+	MOVOU 480(DX), X8
+	AESENC X8, X10
+	AESENC X8, X12
+	MOVOU 496(DX), X8
+	AESENC X8, X3
+	AESENC X8, X7
+	MOVO X10, X14
+	PUNPCKLLQ X0, X14
+	MOVO X3, X6
+	PUNPCKLLQ X13, X6
+	PUNPCKHLQ X10, X0
+	PUNPCKHLQ X3, X13
+	MOVO X14, X10
+	PUNPCKLQDQ X6, X10
+	PUNPCKHQDQ X6, X14
+	MOVO X0, X3
+	PUNPCKLQDQ X13, X3
+	PUNPCKHQDQ X13, X0
+	MOVO X12, X1
+	PUNPCKLLQ X4, X1
+	MOVO X7, X9
+	PUNPCKLLQ X15, X9
+	PUNPCKHLQ X12, X4
+	PUNPCKHLQ X7, X15
+	MOVO X1, X12
+	PUNPCKLQDQ X9, X12
+	PUNPCKHQDQ X9, X1
+	MOVO X4, X7
+	PUNPCKLQDQ X15, X7
+	PUNPCKHQDQ X15, X4
+
+	// Round 4
+	MOVOU 512(DX), X8
+	AESENC X8, X0
+	AESENC X8, X4
+	MOVOU 528(DX), X8
+	AESENC X8, X10
+	AESENC X8, X12
+	MOVOU 544(DX), X8
+	AESENC X8, X14
+	AESENC X8, X1
+	MOVOU 560(DX), X8
+	AESENC X8, X3
+	AESENC X8, X7
+	MOVOU 576(DX), X8
+	AESENC X8, X0
+	AESENC X8, X4
+	MOVOU 592(DX), X8
+	AESENC X8, X10
+	AESENC X8, X12
+	MOVOU 608(DX), X8
+	AESENC X8, X14
+	AESENC X8, X1
+	MOVOU 624(DX), X8
+	AESENC X8, X3
+	AESENC X8, X7
+	MOVO X14, X5
+	PUNPCKLLQ X0, X5
+	MOVO X3, X11
+	PUNPCKLLQ X10, X11
+	PUNPCKHLQ X14, X0
+	PUNPCKHLQ X3, X10
+	MOVO X5, X14
+	PUNPCKLQDQ X11, X14
+	PUNPCKHQDQ X11, X5
+	MOVO X0, X3
+	PUNPCKLQDQ X10, X3
+	PUNPCKHQDQ X10, X0
+	MOVO X1, X2
+	PUNPCKLLQ X4, X2
+	MOVO X7, X13
+	PUNPCKLLQ X12, X13
+	PUNPCKHLQ X1, X4
+	PUNPCKHLQ X7, X12
+	MOVO X2, X1
+	PUNPCKLQDQ X13, X1
+	PUNPCKHQDQ X13, X2
+	MOVO X4, X7
+	PUNPCKLQDQ X12, X7
+	PUNPCKHQDQ X12, X4
+
+	// Feed-forward
+	MOVOU 0(SI), X6
+	PXOR X6, X0
+	MOVOU 16(SI), X6
+	PXOR X6, X14
+	MOVOU 32(SI), X6
+	PXOR X6, X5
+	MOVOU 48(SI), X6
+	PXOR X6, X3
+	MOVOU 64(SI), X6
+	PXOR X6, X4
+	MOVOU 80(SI), X6
+	PXOR X6, X1
+	MOVOU 96(SI), X6
+	PXOR X6, X2
+	MOVOU 112(SI), X6
+	PXOR X6, X7
+
+	PUNPCKHQDQ X14, X0
+	PUNPCKLQDQ X3, X5
+	MOVOU X0, 0(DI)
+	MOVOU X5, 16(DI)
+	PUNPCKHQDQ X1, X4
+	PUNPCKLQDQ X7, X2
+	MOVOU X4, 32(DI)
+	MOVOU X2, 48(DI)
+	RET
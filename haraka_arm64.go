@@ -31,3 +31,69 @@ func Haraka512HW(input *[64]byte) [32]byte {
 	}
 	return out
 }
+
+//go:noescape
+func harakaPermute256HW(state *[32]byte, rc *[40][16]byte)
+
+//go:noescape
+func harakaInversePermute256HW(state *[32]byte, rc *[40][16]byte)
+
+//go:noescape
+func harakaPermute512HW(state *[64]byte, rc *[40][16]byte)
+
+//go:noescape
+func harakaInversePermute512HW(state *[64]byte, rc *[40][16]byte)
+
+func harakaPermute256(state *[32]byte, rc *[40][16]byte) {
+	if CPU.HasARMCrypto {
+		harakaPermute256HW(state, rc)
+	} else {
+		harakaPi256Generic(state, rc)
+	}
+}
+
+func harakaInversePermute256(state *[32]byte, rc *[40][16]byte) {
+	if CPU.HasARMCrypto {
+		harakaInversePermute256HW(state, rc)
+	} else {
+		harakaInvPi256Generic(state, rc)
+	}
+}
+
+func harakaPermute512(state *[64]byte, rc *[40][16]byte) {
+	if CPU.HasARMCrypto {
+		harakaPermute512HW(state, rc)
+	} else {
+		harakaPi512Generic(state, rc)
+	}
+}
+
+func harakaInversePermute512(state *[64]byte, rc *[40][16]byte) {
+	if CPU.HasARMCrypto {
+		harakaInversePermute512HW(state, rc)
+	} else {
+		harakaInvPi512Generic(state, rc)
+	}
+}
+
+//go:noescape
+func haraka256x4HW(out *[4][32]byte, in *[4][32]byte, rc *[40][16]byte)
+
+//go:noescape
+func haraka512x4HW(out *[4][32]byte, in *[4][64]byte, rc *[40][16]byte)
+
+func haraka256x4(out *[4][32]byte, in *[4][32]byte, rc *[40][16]byte) {
+	if CPU.HasARMCrypto {
+		haraka256x4HW(out, in, rc)
+	} else {
+		haraka256x4Generic(out, in, rc)
+	}
+}
+
+func haraka512x4(out *[4][32]byte, in *[4][64]byte, rc *[40][16]byte) {
+	if CPU.HasARMCrypto {
+		haraka512x4HW(out, in, rc)
+	} else {
+		haraka512x4Generic(out, in, rc)
+	}
+}
@@ -494,3 +494,1670 @@ TEXT ·haraka512HW(SB),NOSPLIT,$0
 	VMOV V3.D[0], V7.D[1]
 	VST1 [V6.B16, V7.B16], (R0)
 	RET
+
+// func harakaPermute256HW(state *[32]byte, rc *[40][16]byte)
+TEXT ·harakaPermute256HW(SB),NOSPLIT,$0
+	MOVD state+0(FP), R0
+	MOVD rc+8(FP), R2
+
+	VLD1 (R0), [V0.B16, V1.B16]
+	VEOR V31.B16, V31.B16, V31.B16
+
+	// Round 0 (rc[0..3])
+	VLD1.P 64(R2), [V16.B16, V17.B16, V18.B16, V19.B16]
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V18.B16, V0.B16, V0.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V19.B16, V1.B16, V1.B16
+	VMOV V0.B16, V6.B16
+	VZIP1 V1.S4, V6.S4, V0.S4
+	VZIP2 V1.S4, V6.S4, V1.S4
+
+	// Round 1 (rc[4..7])
+	VLD1.P 64(R2), [V16.B16, V17.B16, V18.B16, V19.B16]
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V18.B16, V0.B16, V0.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V19.B16, V1.B16, V1.B16
+	VMOV V0.B16, V6.B16
+	VZIP1 V1.S4, V6.S4, V0.S4
+	VZIP2 V1.S4, V6.S4, V1.S4
+
+	// Round 2 (rc[8..11])
+	VLD1.P 64(R2), [V16.B16, V17.B16, V18.B16, V19.B16]
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V18.B16, V0.B16, V0.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V19.B16, V1.B16, V1.B16
+	VMOV V0.B16, V6.B16
+	VZIP1 V1.S4, V6.S4, V0.S4
+	VZIP2 V1.S4, V6.S4, V1.S4
+
+	// Round 3 (rc[12..15])
+	VLD1.P 64(R2), [V16.B16, V17.B16, V18.B16, V19.B16]
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V18.B16, V0.B16, V0.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V19.B16, V1.B16, V1.B16
+	VMOV V0.B16, V6.B16
+	VZIP1 V1.S4, V6.S4, V0.S4
+	VZIP2 V1.S4, V6.S4, V1.S4
+
+	// Round 4 (rc[16..19])
+	VLD1.P 64(R2), [V16.B16, V17.B16, V18.B16, V19.B16]
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V18.B16, V0.B16, V0.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V19.B16, V1.B16, V1.B16
+	VMOV V0.B16, V6.B16
+	VZIP1 V1.S4, V6.S4, V0.S4
+	VZIP2 V1.S4, V6.S4, V1.S4
+
+	VST1 [V0.B16, V1.B16], (R0)
+	RET
+
+// func harakaInversePermute256HW(state *[32]byte, rc *[40][16]byte)
+TEXT ·harakaInversePermute256HW(SB),NOSPLIT,$0
+	MOVD state+0(FP), R0
+	MOVD rc+8(FP), R2
+
+	VLD1 (R0), [V0.B16, V1.B16]
+	VEOR V31.B16, V31.B16, V31.B16
+	ADD $256, R2
+
+	// Round 4 (rc[16..19])
+	VLD1 (R2), [V16.B16, V17.B16, V18.B16, V19.B16]
+	SUB $64, R2
+	VMOV V0.B16, V6.B16
+	VUZP1 V1.S4, V6.S4, V0.S4
+	VUZP2 V1.S4, V6.S4, V1.S4
+	VEOR V19.B16, V1.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V1.B16
+	VEOR V18.B16, V0.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V1.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V0.B16
+
+	// Round 3 (rc[12..15])
+	VLD1 (R2), [V16.B16, V17.B16, V18.B16, V19.B16]
+	SUB $64, R2
+	VMOV V0.B16, V6.B16
+	VUZP1 V1.S4, V6.S4, V0.S4
+	VUZP2 V1.S4, V6.S4, V1.S4
+	VEOR V19.B16, V1.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V1.B16
+	VEOR V18.B16, V0.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V1.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V0.B16
+
+	// Round 2 (rc[8..11])
+	VLD1 (R2), [V16.B16, V17.B16, V18.B16, V19.B16]
+	SUB $64, R2
+	VMOV V0.B16, V6.B16
+	VUZP1 V1.S4, V6.S4, V0.S4
+	VUZP2 V1.S4, V6.S4, V1.S4
+	VEOR V19.B16, V1.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V1.B16
+	VEOR V18.B16, V0.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V1.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V0.B16
+
+	// Round 1 (rc[4..7])
+	VLD1 (R2), [V16.B16, V17.B16, V18.B16, V19.B16]
+	SUB $64, R2
+	VMOV V0.B16, V6.B16
+	VUZP1 V1.S4, V6.S4, V0.S4
+	VUZP2 V1.S4, V6.S4, V1.S4
+	VEOR V19.B16, V1.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V1.B16
+	VEOR V18.B16, V0.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V1.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V0.B16
+
+	// Round 0 (rc[0..3])
+	VLD1 (R2), [V16.B16, V17.B16, V18.B16, V19.B16]
+	VMOV V0.B16, V6.B16
+	VUZP1 V1.S4, V6.S4, V0.S4
+	VUZP2 V1.S4, V6.S4, V1.S4
+	VEOR V19.B16, V1.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V1.B16
+	VEOR V18.B16, V0.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V1.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V0.B16
+
+	VST1 [V0.B16, V1.B16], (R0)
+	RET
+
+// func harakaPermute512HW(state *[64]byte, rc *[40][16]byte)
+TEXT ·harakaPermute512HW(SB),NOSPLIT,$0
+	MOVD state+0(FP), R0
+	MOVD rc+8(FP), R2
+
+	VLD1 (R0), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VEOR V31.B16, V31.B16, V31.B16
+
+	// Round 0 (rc[0..7])
+	VLD1.P 64(R2), [V16.B16, V17.B16, V18.B16, V19.B16]
+	VLD1.P 64(R2), [V20.B16, V21.B16, V22.B16, V23.B16]
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	VZIP1 V0.S4, V2.S4, V4.S4
+	VZIP1 V1.S4, V3.S4, V5.S4
+	VZIP2 V2.S4, V0.S4, V6.S4
+	VZIP2 V3.S4, V1.S4, V7.S4
+	VZIP1 V5.D2, V4.D2, V1.D2
+	VZIP2 V5.D2, V4.D2, V2.D2
+	VZIP1 V7.D2, V6.D2, V3.D2
+	VZIP2 V7.D2, V6.D2, V0.D2
+
+	// Round 1 (rc[8..15])
+	VLD1.P 64(R2), [V16.B16, V17.B16, V18.B16, V19.B16]
+	VLD1.P 64(R2), [V20.B16, V21.B16, V22.B16, V23.B16]
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	VZIP1 V0.S4, V2.S4, V4.S4
+	VZIP1 V1.S4, V3.S4, V5.S4
+	VZIP2 V2.S4, V0.S4, V6.S4
+	VZIP2 V3.S4, V1.S4, V7.S4
+	VZIP1 V5.D2, V4.D2, V1.D2
+	VZIP2 V5.D2, V4.D2, V2.D2
+	VZIP1 V7.D2, V6.D2, V3.D2
+	VZIP2 V7.D2, V6.D2, V0.D2
+
+	// Round 2 (rc[16..23])
+	VLD1.P 64(R2), [V16.B16, V17.B16, V18.B16, V19.B16]
+	VLD1.P 64(R2), [V20.B16, V21.B16, V22.B16, V23.B16]
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	VZIP1 V0.S4, V2.S4, V4.S4
+	VZIP1 V1.S4, V3.S4, V5.S4
+	VZIP2 V2.S4, V0.S4, V6.S4
+	VZIP2 V3.S4, V1.S4, V7.S4
+	VZIP1 V5.D2, V4.D2, V1.D2
+	VZIP2 V5.D2, V4.D2, V2.D2
+	VZIP1 V7.D2, V6.D2, V3.D2
+	VZIP2 V7.D2, V6.D2, V0.D2
+
+	// Round 3 (rc[24..31])
+	VLD1.P 64(R2), [V16.B16, V17.B16, V18.B16, V19.B16]
+	VLD1.P 64(R2), [V20.B16, V21.B16, V22.B16, V23.B16]
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	VZIP1 V0.S4, V2.S4, V4.S4
+	VZIP1 V1.S4, V3.S4, V5.S4
+	VZIP2 V2.S4, V0.S4, V6.S4
+	VZIP2 V3.S4, V1.S4, V7.S4
+	VZIP1 V5.D2, V4.D2, V1.D2
+	VZIP2 V5.D2, V4.D2, V2.D2
+	VZIP1 V7.D2, V6.D2, V3.D2
+	VZIP2 V7.D2, V6.D2, V0.D2
+
+	// Round 4 (rc[32..39])
+	VLD1.P 64(R2), [V16.B16, V17.B16, V18.B16, V19.B16]
+	VLD1.P 64(R2), [V20.B16, V21.B16, V22.B16, V23.B16]
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	VZIP1 V0.S4, V2.S4, V4.S4
+	VZIP1 V1.S4, V3.S4, V5.S4
+	VZIP2 V2.S4, V0.S4, V6.S4
+	VZIP2 V3.S4, V1.S4, V7.S4
+	VZIP1 V5.D2, V4.D2, V1.D2
+	VZIP2 V5.D2, V4.D2, V2.D2
+	VZIP1 V7.D2, V6.D2, V3.D2
+	VZIP2 V7.D2, V6.D2, V0.D2
+
+	VST1 [V0.B16, V1.B16, V2.B16, V3.B16], (R0)
+	RET
+
+// func harakaInversePermute512HW(state *[64]byte, rc *[40][16]byte)
+TEXT ·harakaInversePermute512HW(SB),NOSPLIT,$0
+	MOVD state+0(FP), R0
+	MOVD rc+8(FP), R2
+
+	VLD1 (R0), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VEOR V31.B16, V31.B16, V31.B16
+	ADD $512, R2
+
+	// Round 4 (rc[32..39])
+	VLD1 (R2), [V16.B16, V17.B16, V18.B16, V19.B16]
+	ADD $64, R2, R3
+	VLD1 (R3), [V20.B16, V21.B16, V22.B16, V23.B16]
+	SUB $128, R2
+	VZIP1 V2.D2, V1.D2, V4.D2
+	VZIP2 V2.D2, V1.D2, V5.D2
+	VZIP1 V0.D2, V3.D2, V6.D2
+	VZIP2 V0.D2, V3.D2, V7.D2
+	VUZP1 V6.S4, V4.S4, V8.S4
+	VUZP2 V6.S4, V4.S4, V9.S4
+	VEXT $8, V8.B16, V8.B16, V8.B16
+	VZIP1 V8.D2, V9.D2, V0.D2
+	VZIP2 V9.D2, V8.D2, V2.D2
+	VUZP1 V7.S4, V5.S4, V10.S4
+	VUZP2 V7.S4, V5.S4, V11.S4
+	VEXT $8, V10.B16, V10.B16, V10.B16
+	VZIP1 V10.D2, V11.D2, V1.D2
+	VZIP2 V11.D2, V10.D2, V3.D2
+	VEOR V23.B16, V3.B16, V3.B16
+	AESIMC V3.B16, V3.B16
+	AESD V31.B16, V3.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESIMC V2.B16, V2.B16
+	AESD V31.B16, V2.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V1.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V0.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	AESIMC V3.B16, V3.B16
+	AESD V31.B16, V3.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	AESIMC V2.B16, V2.B16
+	AESD V31.B16, V2.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V1.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V0.B16
+
+	// Round 3 (rc[24..31])
+	VLD1 (R2), [V16.B16, V17.B16, V18.B16, V19.B16]
+	ADD $64, R2, R3
+	VLD1 (R3), [V20.B16, V21.B16, V22.B16, V23.B16]
+	SUB $128, R2
+	VZIP1 V2.D2, V1.D2, V4.D2
+	VZIP2 V2.D2, V1.D2, V5.D2
+	VZIP1 V0.D2, V3.D2, V6.D2
+	VZIP2 V0.D2, V3.D2, V7.D2
+	VUZP1 V6.S4, V4.S4, V8.S4
+	VUZP2 V6.S4, V4.S4, V9.S4
+	VEXT $8, V8.B16, V8.B16, V8.B16
+	VZIP1 V8.D2, V9.D2, V0.D2
+	VZIP2 V9.D2, V8.D2, V2.D2
+	VUZP1 V7.S4, V5.S4, V10.S4
+	VUZP2 V7.S4, V5.S4, V11.S4
+	VEXT $8, V10.B16, V10.B16, V10.B16
+	VZIP1 V10.D2, V11.D2, V1.D2
+	VZIP2 V11.D2, V10.D2, V3.D2
+	VEOR V23.B16, V3.B16, V3.B16
+	AESIMC V3.B16, V3.B16
+	AESD V31.B16, V3.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESIMC V2.B16, V2.B16
+	AESD V31.B16, V2.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V1.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V0.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	AESIMC V3.B16, V3.B16
+	AESD V31.B16, V3.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	AESIMC V2.B16, V2.B16
+	AESD V31.B16, V2.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V1.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V0.B16
+
+	// Round 2 (rc[16..23])
+	VLD1 (R2), [V16.B16, V17.B16, V18.B16, V19.B16]
+	ADD $64, R2, R3
+	VLD1 (R3), [V20.B16, V21.B16, V22.B16, V23.B16]
+	SUB $128, R2
+	VZIP1 V2.D2, V1.D2, V4.D2
+	VZIP2 V2.D2, V1.D2, V5.D2
+	VZIP1 V0.D2, V3.D2, V6.D2
+	VZIP2 V0.D2, V3.D2, V7.D2
+	VUZP1 V6.S4, V4.S4, V8.S4
+	VUZP2 V6.S4, V4.S4, V9.S4
+	VEXT $8, V8.B16, V8.B16, V8.B16
+	VZIP1 V8.D2, V9.D2, V0.D2
+	VZIP2 V9.D2, V8.D2, V2.D2
+	VUZP1 V7.S4, V5.S4, V10.S4
+	VUZP2 V7.S4, V5.S4, V11.S4
+	VEXT $8, V10.B16, V10.B16, V10.B16
+	VZIP1 V10.D2, V11.D2, V1.D2
+	VZIP2 V11.D2, V10.D2, V3.D2
+	VEOR V23.B16, V3.B16, V3.B16
+	AESIMC V3.B16, V3.B16
+	AESD V31.B16, V3.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESIMC V2.B16, V2.B16
+	AESD V31.B16, V2.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V1.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V0.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	AESIMC V3.B16, V3.B16
+	AESD V31.B16, V3.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	AESIMC V2.B16, V2.B16
+	AESD V31.B16, V2.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V1.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V0.B16
+
+	// Round 1 (rc[8..15])
+	VLD1 (R2), [V16.B16, V17.B16, V18.B16, V19.B16]
+	ADD $64, R2, R3
+	VLD1 (R3), [V20.B16, V21.B16, V22.B16, V23.B16]
+	SUB $128, R2
+	VZIP1 V2.D2, V1.D2, V4.D2
+	VZIP2 V2.D2, V1.D2, V5.D2
+	VZIP1 V0.D2, V3.D2, V6.D2
+	VZIP2 V0.D2, V3.D2, V7.D2
+	VUZP1 V6.S4, V4.S4, V8.S4
+	VUZP2 V6.S4, V4.S4, V9.S4
+	VEXT $8, V8.B16, V8.B16, V8.B16
+	VZIP1 V8.D2, V9.D2, V0.D2
+	VZIP2 V9.D2, V8.D2, V2.D2
+	VUZP1 V7.S4, V5.S4, V10.S4
+	VUZP2 V7.S4, V5.S4, V11.S4
+	VEXT $8, V10.B16, V10.B16, V10.B16
+	VZIP1 V10.D2, V11.D2, V1.D2
+	VZIP2 V11.D2, V10.D2, V3.D2
+	VEOR V23.B16, V3.B16, V3.B16
+	AESIMC V3.B16, V3.B16
+	AESD V31.B16, V3.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESIMC V2.B16, V2.B16
+	AESD V31.B16, V2.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V1.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V0.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	AESIMC V3.B16, V3.B16
+	AESD V31.B16, V3.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	AESIMC V2.B16, V2.B16
+	AESD V31.B16, V2.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V1.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V0.B16
+
+	// Round 0 (rc[0..7])
+	VLD1 (R2), [V16.B16, V17.B16, V18.B16, V19.B16]
+	ADD $64, R2, R3
+	VLD1 (R3), [V20.B16, V21.B16, V22.B16, V23.B16]
+	VZIP1 V2.D2, V1.D2, V4.D2
+	VZIP2 V2.D2, V1.D2, V5.D2
+	VZIP1 V0.D2, V3.D2, V6.D2
+	VZIP2 V0.D2, V3.D2, V7.D2
+	VUZP1 V6.S4, V4.S4, V8.S4
+	VUZP2 V6.S4, V4.S4, V9.S4
+	VEXT $8, V8.B16, V8.B16, V8.B16
+	VZIP1 V8.D2, V9.D2, V0.D2
+	VZIP2 V9.D2, V8.D2, V2.D2
+	VUZP1 V7.S4, V5.S4, V10.S4
+	VUZP2 V7.S4, V5.S4, V11.S4
+	VEXT $8, V10.B16, V10.B16, V10.B16
+	VZIP1 V10.D2, V11.D2, V1.D2
+	VZIP2 V11.D2, V10.D2, V3.D2
+	VEOR V23.B16, V3.B16, V3.B16
+	AESIMC V3.B16, V3.B16
+	AESD V31.B16, V3.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESIMC V2.B16, V2.B16
+	AESD V31.B16, V2.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V1.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V0.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	AESIMC V3.B16, V3.B16
+	AESD V31.B16, V3.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	AESIMC V2.B16, V2.B16
+	AESD V31.B16, V2.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V1.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V0.B16
+
+	VST1 [V0.B16, V1.B16, V2.B16, V3.B16], (R0)
+	RET
+
+// Batched Haraka-256/512 over four independent inputs, with the AES rounds
+// of the inputs interleaved.
+
+// func haraka256x4HW(out *[4][32]byte, in *[4][32]byte, rc *[40][16]byte)
+TEXT ·haraka256x4HW(SB),NOSPLIT,$0
+	MOVD out+0(FP), R0
+	MOVD in+8(FP), R1
+	MOVD rc+16(FP), R2
+	MOVD R1, R3
+	VEOR V31.B16, V31.B16, V31.B16
+
+	VLD1.P 32(R3), [V0.B16, V1.B16]
+	VLD1.P 32(R3), [V2.B16, V3.B16]
+	VLD1.P 32(R3), [V4.B16, V5.B16]
+	VLD1.P 32(R3), [V6.B16, V7.B16]
+
+	// Round 0
+	VLD1.P 64(R2), [V20.B16, V21.B16, V22.B16, V23.B16]
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V20.B16, V2.B16, V2.B16
+	AESE V31.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	AESE V31.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	VEOR V20.B16, V6.B16, V6.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V21.B16, V3.B16, V3.B16
+	AESE V31.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	AESE V31.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V21.B16, V7.B16, V7.B16
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V22.B16, V0.B16, V0.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESE V31.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	VEOR V22.B16, V4.B16, V4.B16
+	AESE V31.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V23.B16, V1.B16, V1.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	AESE V31.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	VEOR V23.B16, V5.B16, V5.B16
+	AESE V31.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VZIP1 V1.S4, V0.S4, V16.S4
+	VZIP2 V1.S4, V0.S4, V1.S4
+	VZIP1 V3.S4, V2.S4, V17.S4
+	VZIP2 V3.S4, V2.S4, V3.S4
+	VZIP1 V5.S4, V4.S4, V18.S4
+	VZIP2 V5.S4, V4.S4, V5.S4
+	VZIP1 V7.S4, V6.S4, V19.S4
+	VZIP2 V7.S4, V6.S4, V7.S4
+
+	// Round 1
+	VLD1.P 64(R2), [V20.B16, V21.B16, V22.B16, V23.B16]
+	AESE V31.B16, V16.B16
+	AESMC V16.B16, V16.B16
+	VEOR V20.B16, V16.B16, V16.B16
+	AESE V31.B16, V17.B16
+	AESMC V17.B16, V17.B16
+	VEOR V20.B16, V17.B16, V17.B16
+	AESE V31.B16, V18.B16
+	AESMC V18.B16, V18.B16
+	VEOR V20.B16, V18.B16, V18.B16
+	AESE V31.B16, V19.B16
+	AESMC V19.B16, V19.B16
+	VEOR V20.B16, V19.B16, V19.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V21.B16, V3.B16, V3.B16
+	AESE V31.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	AESE V31.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V21.B16, V7.B16, V7.B16
+	AESE V31.B16, V16.B16
+	AESMC V16.B16, V16.B16
+	VEOR V22.B16, V16.B16, V16.B16
+	AESE V31.B16, V17.B16
+	AESMC V17.B16, V17.B16
+	VEOR V22.B16, V17.B16, V17.B16
+	AESE V31.B16, V18.B16
+	AESMC V18.B16, V18.B16
+	VEOR V22.B16, V18.B16, V18.B16
+	AESE V31.B16, V19.B16
+	AESMC V19.B16, V19.B16
+	VEOR V22.B16, V19.B16, V19.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V23.B16, V1.B16, V1.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	AESE V31.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	VEOR V23.B16, V5.B16, V5.B16
+	AESE V31.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VZIP1 V1.S4, V16.S4, V0.S4
+	VZIP2 V1.S4, V16.S4, V1.S4
+	VZIP1 V3.S4, V17.S4, V2.S4
+	VZIP2 V3.S4, V17.S4, V3.S4
+	VZIP1 V5.S4, V18.S4, V4.S4
+	VZIP2 V5.S4, V18.S4, V5.S4
+	VZIP1 V7.S4, V19.S4, V6.S4
+	VZIP2 V7.S4, V19.S4, V7.S4
+
+	// Round 2
+	VLD1.P 64(R2), [V20.B16, V21.B16, V22.B16, V23.B16]
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V20.B16, V2.B16, V2.B16
+	AESE V31.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	AESE V31.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	VEOR V20.B16, V6.B16, V6.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V21.B16, V3.B16, V3.B16
+	AESE V31.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	AESE V31.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V21.B16, V7.B16, V7.B16
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V22.B16, V0.B16, V0.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESE V31.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	VEOR V22.B16, V4.B16, V4.B16
+	AESE V31.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V23.B16, V1.B16, V1.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	AESE V31.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	VEOR V23.B16, V5.B16, V5.B16
+	AESE V31.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VZIP1 V1.S4, V0.S4, V16.S4
+	VZIP2 V1.S4, V0.S4, V1.S4
+	VZIP1 V3.S4, V2.S4, V17.S4
+	VZIP2 V3.S4, V2.S4, V3.S4
+	VZIP1 V5.S4, V4.S4, V18.S4
+	VZIP2 V5.S4, V4.S4, V5.S4
+	VZIP1 V7.S4, V6.S4, V19.S4
+	VZIP2 V7.S4, V6.S4, V7.S4
+
+	// Round 3
+	VLD1.P 64(R2), [V20.B16, V21.B16, V22.B16, V23.B16]
+	AESE V31.B16, V16.B16
+	AESMC V16.B16, V16.B16
+	VEOR V20.B16, V16.B16, V16.B16
+	AESE V31.B16, V17.B16
+	AESMC V17.B16, V17.B16
+	VEOR V20.B16, V17.B16, V17.B16
+	AESE V31.B16, V18.B16
+	AESMC V18.B16, V18.B16
+	VEOR V20.B16, V18.B16, V18.B16
+	AESE V31.B16, V19.B16
+	AESMC V19.B16, V19.B16
+	VEOR V20.B16, V19.B16, V19.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V21.B16, V3.B16, V3.B16
+	AESE V31.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	AESE V31.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V21.B16, V7.B16, V7.B16
+	AESE V31.B16, V16.B16
+	AESMC V16.B16, V16.B16
+	VEOR V22.B16, V16.B16, V16.B16
+	AESE V31.B16, V17.B16
+	AESMC V17.B16, V17.B16
+	VEOR V22.B16, V17.B16, V17.B16
+	AESE V31.B16, V18.B16
+	AESMC V18.B16, V18.B16
+	VEOR V22.B16, V18.B16, V18.B16
+	AESE V31.B16, V19.B16
+	AESMC V19.B16, V19.B16
+	VEOR V22.B16, V19.B16, V19.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V23.B16, V1.B16, V1.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	AESE V31.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	VEOR V23.B16, V5.B16, V5.B16
+	AESE V31.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VZIP1 V1.S4, V16.S4, V0.S4
+	VZIP2 V1.S4, V16.S4, V1.S4
+	VZIP1 V3.S4, V17.S4, V2.S4
+	VZIP2 V3.S4, V17.S4, V3.S4
+	VZIP1 V5.S4, V18.S4, V4.S4
+	VZIP2 V5.S4, V18.S4, V5.S4
+	VZIP1 V7.S4, V19.S4, V6.S4
+	VZIP2 V7.S4, V19.S4, V7.S4
+
+	// Round 4
+	VLD1.P 64(R2), [V20.B16, V21.B16, V22.B16, V23.B16]
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V20.B16, V2.B16, V2.B16
+	AESE V31.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	AESE V31.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	VEOR V20.B16, V6.B16, V6.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V21.B16, V3.B16, V3.B16
+	AESE V31.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	AESE V31.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V21.B16, V7.B16, V7.B16
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V22.B16, V0.B16, V0.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESE V31.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	VEOR V22.B16, V4.B16, V4.B16
+	AESE V31.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V23.B16, V1.B16, V1.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	AESE V31.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	VEOR V23.B16, V5.B16, V5.B16
+	AESE V31.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VZIP1 V1.S4, V0.S4, V16.S4
+	VZIP2 V1.S4, V0.S4, V1.S4
+	VZIP1 V3.S4, V2.S4, V17.S4
+	VZIP2 V3.S4, V2.S4, V3.S4
+	VZIP1 V5.S4, V4.S4, V18.S4
+	VZIP2 V5.S4, V4.S4, V5.S4
+	VZIP1 V7.S4, V6.S4, V19.S4
+	VZIP2 V7.S4, V6.S4, V7.S4
+
+	// Feed-forward
+	VLD1.P 32(R1), [V20.B16, V21.B16]
+	VEOR V20.B16, V16.B16, V28.B16
+	VEOR V21.B16, V1.B16, V29.B16
+	VST1.P [V28.B16, V29.B16], 32(R0)
+	VLD1.P 32(R1), [V20.B16, V21.B16]
+	VEOR V20.B16, V17.B16, V28.B16
+	VEOR V21.B16, V3.B16, V29.B16
+	VST1.P [V28.B16, V29.B16], 32(R0)
+	VLD1.P 32(R1), [V20.B16, V21.B16]
+	VEOR V20.B16, V18.B16, V28.B16
+	VEOR V21.B16, V5.B16, V29.B16
+	VST1.P [V28.B16, V29.B16], 32(R0)
+	VLD1.P 32(R1), [V20.B16, V21.B16]
+	VEOR V20.B16, V19.B16, V28.B16
+	VEOR V21.B16, V7.B16, V29.B16
+	VST1.P [V28.B16, V29.B16], 32(R0)
+	RET
+
+// func haraka512x4HW(out *[4][32]byte, in *[4][64]byte, rc *[40][16]byte)
+TEXT ·haraka512x4HW(SB),NOSPLIT,$0
+	MOVD out+0(FP), R0
+	MOVD in+8(FP), R1
+	MOVD rc+16(FP), R2
+	MOVD R1, R3
+	VEOR V31.B16, V31.B16, V31.B16
+
+	VLD1.P 64(R3), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VLD1.P 64(R3), [V4.B16, V5.B16, V6.B16, V7.B16]
+	VLD1.P 64(R3), [V8.B16, V9.B16, V10.B16, V11.B16]
+	VLD1.P 64(R3), [V12.B16, V13.B16, V14.B16, V15.B16]
+
+	// Round 0
+	VLD1.P 64(R2), [V20.B16, V21.B16, V22.B16, V23.B16]
+	VLD1.P 64(R2), [V24.B16, V25.B16, V26.B16, V27.B16]
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESE V31.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	AESE V31.B16, V8.B16
+	AESMC V8.B16, V8.B16
+	VEOR V20.B16, V8.B16, V8.B16
+	AESE V31.B16, V12.B16
+	AESMC V12.B16, V12.B16
+	VEOR V20.B16, V12.B16, V12.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESE V31.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	AESE V31.B16, V9.B16
+	AESMC V9.B16, V9.B16
+	VEOR V21.B16, V9.B16, V9.B16
+	AESE V31.B16, V13.B16
+	AESMC V13.B16, V13.B16
+	VEOR V21.B16, V13.B16, V13.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESE V31.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	AESE V31.B16, V10.B16
+	AESMC V10.B16, V10.B16
+	VEOR V22.B16, V10.B16, V10.B16
+	AESE V31.B16, V14.B16
+	AESMC V14.B16, V14.B16
+	VEOR V22.B16, V14.B16, V14.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	AESE V31.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESE V31.B16, V11.B16
+	AESMC V11.B16, V11.B16
+	VEOR V23.B16, V11.B16, V11.B16
+	AESE V31.B16, V15.B16
+	AESMC V15.B16, V15.B16
+	VEOR V23.B16, V15.B16, V15.B16
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V24.B16, V0.B16, V0.B16
+	AESE V31.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	VEOR V24.B16, V4.B16, V4.B16
+	AESE V31.B16, V8.B16
+	AESMC V8.B16, V8.B16
+	VEOR V24.B16, V8.B16, V8.B16
+	AESE V31.B16, V12.B16
+	AESMC V12.B16, V12.B16
+	VEOR V24.B16, V12.B16, V12.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V25.B16, V1.B16, V1.B16
+	AESE V31.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	VEOR V25.B16, V5.B16, V5.B16
+	AESE V31.B16, V9.B16
+	AESMC V9.B16, V9.B16
+	VEOR V25.B16, V9.B16, V9.B16
+	AESE V31.B16, V13.B16
+	AESMC V13.B16, V13.B16
+	VEOR V25.B16, V13.B16, V13.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V26.B16, V2.B16, V2.B16
+	AESE V31.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	VEOR V26.B16, V6.B16, V6.B16
+	AESE V31.B16, V10.B16
+	AESMC V10.B16, V10.B16
+	VEOR V26.B16, V10.B16, V10.B16
+	AESE V31.B16, V14.B16
+	AESMC V14.B16, V14.B16
+	VEOR V26.B16, V14.B16, V14.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V27.B16, V3.B16, V3.B16
+	AESE V31.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V27.B16, V7.B16, V7.B16
+	AESE V31.B16, V11.B16
+	AESMC V11.B16, V11.B16
+	VEOR V27.B16, V11.B16, V11.B16
+	AESE V31.B16, V15.B16
+	AESMC V15.B16, V15.B16
+	VEOR V27.B16, V15.B16, V15.B16
+	VZIP1 V0.S4, V2.S4, V16.S4
+	VZIP1 V1.S4, V3.S4, V17.S4
+	VZIP2 V2.S4, V0.S4, V18.S4
+	VZIP2 V3.S4, V1.S4, V19.S4
+	VZIP1 V17.D2, V16.D2, V1.D2
+	VZIP2 V17.D2, V16.D2, V2.D2
+	VZIP1 V19.D2, V18.D2, V3.D2
+	VZIP2 V19.D2, V18.D2, V0.D2
+	VZIP1 V4.S4, V6.S4, V16.S4
+	VZIP1 V5.S4, V7.S4, V17.S4
+	VZIP2 V6.S4, V4.S4, V18.S4
+	VZIP2 V7.S4, V5.S4, V19.S4
+	VZIP1 V17.D2, V16.D2, V5.D2
+	VZIP2 V17.D2, V16.D2, V6.D2
+	VZIP1 V19.D2, V18.D2, V7.D2
+	VZIP2 V19.D2, V18.D2, V4.D2
+	VZIP1 V8.S4, V10.S4, V16.S4
+	VZIP1 V9.S4, V11.S4, V17.S4
+	VZIP2 V10.S4, V8.S4, V18.S4
+	VZIP2 V11.S4, V9.S4, V19.S4
+	VZIP1 V17.D2, V16.D2, V9.D2
+	VZIP2 V17.D2, V16.D2, V10.D2
+	VZIP1 V19.D2, V18.D2, V11.D2
+	VZIP2 V19.D2, V18.D2, V8.D2
+	VZIP1 V12.S4, V14.S4, V16.S4
+	VZIP1 V13.S4, V15.S4, V17.S4
+	VZIP2 V14.S4, V12.S4, V18.S4
+	VZIP2 V15.S4, V13.S4, V19.S4
+	VZIP1 V17.D2, V16.D2, V13.D2
+	VZIP2 V17.D2, V16.D2, V14.D2
+	VZIP1 V19.D2, V18.D2, V15.D2
+	VZIP2 V19.D2, V18.D2, V12.D2
+
+	// Round 1
+	VLD1.P 64(R2), [V20.B16, V21.B16, V22.B16, V23.B16]
+	VLD1.P 64(R2), [V24.B16, V25.B16, V26.B16, V27.B16]
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESE V31.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	AESE V31.B16, V8.B16
+	AESMC V8.B16, V8.B16
+	VEOR V20.B16, V8.B16, V8.B16
+	AESE V31.B16, V12.B16
+	AESMC V12.B16, V12.B16
+	VEOR V20.B16, V12.B16, V12.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESE V31.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	AESE V31.B16, V9.B16
+	AESMC V9.B16, V9.B16
+	VEOR V21.B16, V9.B16, V9.B16
+	AESE V31.B16, V13.B16
+	AESMC V13.B16, V13.B16
+	VEOR V21.B16, V13.B16, V13.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESE V31.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	AESE V31.B16, V10.B16
+	AESMC V10.B16, V10.B16
+	VEOR V22.B16, V10.B16, V10.B16
+	AESE V31.B16, V14.B16
+	AESMC V14.B16, V14.B16
+	VEOR V22.B16, V14.B16, V14.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	AESE V31.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESE V31.B16, V11.B16
+	AESMC V11.B16, V11.B16
+	VEOR V23.B16, V11.B16, V11.B16
+	AESE V31.B16, V15.B16
+	AESMC V15.B16, V15.B16
+	VEOR V23.B16, V15.B16, V15.B16
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V24.B16, V0.B16, V0.B16
+	AESE V31.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	VEOR V24.B16, V4.B16, V4.B16
+	AESE V31.B16, V8.B16
+	AESMC V8.B16, V8.B16
+	VEOR V24.B16, V8.B16, V8.B16
+	AESE V31.B16, V12.B16
+	AESMC V12.B16, V12.B16
+	VEOR V24.B16, V12.B16, V12.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V25.B16, V1.B16, V1.B16
+	AESE V31.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	VEOR V25.B16, V5.B16, V5.B16
+	AESE V31.B16, V9.B16
+	AESMC V9.B16, V9.B16
+	VEOR V25.B16, V9.B16, V9.B16
+	AESE V31.B16, V13.B16
+	AESMC V13.B16, V13.B16
+	VEOR V25.B16, V13.B16, V13.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V26.B16, V2.B16, V2.B16
+	AESE V31.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	VEOR V26.B16, V6.B16, V6.B16
+	AESE V31.B16, V10.B16
+	AESMC V10.B16, V10.B16
+	VEOR V26.B16, V10.B16, V10.B16
+	AESE V31.B16, V14.B16
+	AESMC V14.B16, V14.B16
+	VEOR V26.B16, V14.B16, V14.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V27.B16, V3.B16, V3.B16
+	AESE V31.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V27.B16, V7.B16, V7.B16
+	AESE V31.B16, V11.B16
+	AESMC V11.B16, V11.B16
+	VEOR V27.B16, V11.B16, V11.B16
+	AESE V31.B16, V15.B16
+	AESMC V15.B16, V15.B16
+	VEOR V27.B16, V15.B16, V15.B16
+	VZIP1 V0.S4, V2.S4, V16.S4
+	VZIP1 V1.S4, V3.S4, V17.S4
+	VZIP2 V2.S4, V0.S4, V18.S4
+	VZIP2 V3.S4, V1.S4, V19.S4
+	VZIP1 V17.D2, V16.D2, V1.D2
+	VZIP2 V17.D2, V16.D2, V2.D2
+	VZIP1 V19.D2, V18.D2, V3.D2
+	VZIP2 V19.D2, V18.D2, V0.D2
+	VZIP1 V4.S4, V6.S4, V16.S4
+	VZIP1 V5.S4, V7.S4, V17.S4
+	VZIP2 V6.S4, V4.S4, V18.S4
+	VZIP2 V7.S4, V5.S4, V19.S4
+	VZIP1 V17.D2, V16.D2, V5.D2
+	VZIP2 V17.D2, V16.D2, V6.D2
+	VZIP1 V19.D2, V18.D2, V7.D2
+	VZIP2 V19.D2, V18.D2, V4.D2
+	VZIP1 V8.S4, V10.S4, V16.S4
+	VZIP1 V9.S4, V11.S4, V17.S4
+	VZIP2 V10.S4, V8.S4, V18.S4
+	VZIP2 V11.S4, V9.S4, V19.S4
+	VZIP1 V17.D2, V16.D2, V9.D2
+	VZIP2 V17.D2, V16.D2, V10.D2
+	VZIP1 V19.D2, V18.D2, V11.D2
+	VZIP2 V19.D2, V18.D2, V8.D2
+	VZIP1 V12.S4, V14.S4, V16.S4
+	VZIP1 V13.S4, V15.S4, V17.S4
+	VZIP2 V14.S4, V12.S4, V18.S4
+	VZIP2 V15.S4, V13.S4, V19.S4
+	VZIP1 V17.D2, V16.D2, V13.D2
+	VZIP2 V17.D2, V16.D2, V14.D2
+	VZIP1 V19.D2, V18.D2, V15.D2
+	VZIP2 V19.D2, V18.D2, V12.D2
+
+	// Round 2
+	VLD1.P 64(R2), [V20.B16, V21.B16, V22.B16, V23.B16]
+	VLD1.P 64(R2), [V24.B16, V25.B16, V26.B16, V27.B16]
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESE V31.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	AESE V31.B16, V8.B16
+	AESMC V8.B16, V8.B16
+	VEOR V20.B16, V8.B16, V8.B16
+	AESE V31.B16, V12.B16
+	AESMC V12.B16, V12.B16
+	VEOR V20.B16, V12.B16, V12.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESE V31.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	AESE V31.B16, V9.B16
+	AESMC V9.B16, V9.B16
+	VEOR V21.B16, V9.B16, V9.B16
+	AESE V31.B16, V13.B16
+	AESMC V13.B16, V13.B16
+	VEOR V21.B16, V13.B16, V13.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESE V31.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	AESE V31.B16, V10.B16
+	AESMC V10.B16, V10.B16
+	VEOR V22.B16, V10.B16, V10.B16
+	AESE V31.B16, V14.B16
+	AESMC V14.B16, V14.B16
+	VEOR V22.B16, V14.B16, V14.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	AESE V31.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESE V31.B16, V11.B16
+	AESMC V11.B16, V11.B16
+	VEOR V23.B16, V11.B16, V11.B16
+	AESE V31.B16, V15.B16
+	AESMC V15.B16, V15.B16
+	VEOR V23.B16, V15.B16, V15.B16
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V24.B16, V0.B16, V0.B16
+	AESE V31.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	VEOR V24.B16, V4.B16, V4.B16
+	AESE V31.B16, V8.B16
+	AESMC V8.B16, V8.B16
+	VEOR V24.B16, V8.B16, V8.B16
+	AESE V31.B16, V12.B16
+	AESMC V12.B16, V12.B16
+	VEOR V24.B16, V12.B16, V12.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V25.B16, V1.B16, V1.B16
+	AESE V31.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	VEOR V25.B16, V5.B16, V5.B16
+	AESE V31.B16, V9.B16
+	AESMC V9.B16, V9.B16
+	VEOR V25.B16, V9.B16, V9.B16
+	AESE V31.B16, V13.B16
+	AESMC V13.B16, V13.B16
+	VEOR V25.B16, V13.B16, V13.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V26.B16, V2.B16, V2.B16
+	AESE V31.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	VEOR V26.B16, V6.B16, V6.B16
+	AESE V31.B16, V10.B16
+	AESMC V10.B16, V10.B16
+	VEOR V26.B16, V10.B16, V10.B16
+	AESE V31.B16, V14.B16
+	AESMC V14.B16, V14.B16
+	VEOR V26.B16, V14.B16, V14.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V27.B16, V3.B16, V3.B16
+	AESE V31.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V27.B16, V7.B16, V7.B16
+	AESE V31.B16, V11.B16
+	AESMC V11.B16, V11.B16
+	VEOR V27.B16, V11.B16, V11.B16
+	AESE V31.B16, V15.B16
+	AESMC V15.B16, V15.B16
+	VEOR V27.B16, V15.B16, V15.B16
+	VZIP1 V0.S4, V2.S4, V16.S4
+	VZIP1 V1.S4, V3.S4, V17.S4
+	VZIP2 V2.S4, V0.S4, V18.S4
+	VZIP2 V3.S4, V1.S4, V19.S4
+	VZIP1 V17.D2, V16.D2, V1.D2
+	VZIP2 V17.D2, V16.D2, V2.D2
+	VZIP1 V19.D2, V18.D2, V3.D2
+	VZIP2 V19.D2, V18.D2, V0.D2
+	VZIP1 V4.S4, V6.S4, V16.S4
+	VZIP1 V5.S4, V7.S4, V17.S4
+	VZIP2 V6.S4, V4.S4, V18.S4
+	VZIP2 V7.S4, V5.S4, V19.S4
+	VZIP1 V17.D2, V16.D2, V5.D2
+	VZIP2 V17.D2, V16.D2, V6.D2
+	VZIP1 V19.D2, V18.D2, V7.D2
+	VZIP2 V19.D2, V18.D2, V4.D2
+	VZIP1 V8.S4, V10.S4, V16.S4
+	VZIP1 V9.S4, V11.S4, V17.S4
+	VZIP2 V10.S4, V8.S4, V18.S4
+	VZIP2 V11.S4, V9.S4, V19.S4
+	VZIP1 V17.D2, V16.D2, V9.D2
+	VZIP2 V17.D2, V16.D2, V10.D2
+	VZIP1 V19.D2, V18.D2, V11.D2
+	VZIP2 V19.D2, V18.D2, V8.D2
+	VZIP1 V12.S4, V14.S4, V16.S4
+	VZIP1 V13.S4, V15.S4, V17.S4
+	VZIP2 V14.S4, V12.S4, V18.S4
+	VZIP2 V15.S4, V13.S4, V19.S4
+	VZIP1 V17.D2, V16.D2, V13.D2
+	VZIP2 V17.D2, V16.D2, V14.D2
+	VZIP1 V19.D2, V18.D2, V15.D2
+	VZIP2 V19.D2, V18.D2, V12.D2
+
+	// Round 3
+	VLD1.P 64(R2), [V20.B16, V21.B16, V22.B16, V23.B16]
+	VLD1.P 64(R2), [V24.B16, V25.B16, V26.B16, V27.B16]
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESE V31.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	AESE V31.B16, V8.B16
+	AESMC V8.B16, V8.B16
+	VEOR V20.B16, V8.B16, V8.B16
+	AESE V31.B16, V12.B16
+	AESMC V12.B16, V12.B16
+	VEOR V20.B16, V12.B16, V12.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESE V31.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	AESE V31.B16, V9.B16
+	AESMC V9.B16, V9.B16
+	VEOR V21.B16, V9.B16, V9.B16
+	AESE V31.B16, V13.B16
+	AESMC V13.B16, V13.B16
+	VEOR V21.B16, V13.B16, V13.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESE V31.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	AESE V31.B16, V10.B16
+	AESMC V10.B16, V10.B16
+	VEOR V22.B16, V10.B16, V10.B16
+	AESE V31.B16, V14.B16
+	AESMC V14.B16, V14.B16
+	VEOR V22.B16, V14.B16, V14.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	AESE V31.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESE V31.B16, V11.B16
+	AESMC V11.B16, V11.B16
+	VEOR V23.B16, V11.B16, V11.B16
+	AESE V31.B16, V15.B16
+	AESMC V15.B16, V15.B16
+	VEOR V23.B16, V15.B16, V15.B16
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V24.B16, V0.B16, V0.B16
+	AESE V31.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	VEOR V24.B16, V4.B16, V4.B16
+	AESE V31.B16, V8.B16
+	AESMC V8.B16, V8.B16
+	VEOR V24.B16, V8.B16, V8.B16
+	AESE V31.B16, V12.B16
+	AESMC V12.B16, V12.B16
+	VEOR V24.B16, V12.B16, V12.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V25.B16, V1.B16, V1.B16
+	AESE V31.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	VEOR V25.B16, V5.B16, V5.B16
+	AESE V31.B16, V9.B16
+	AESMC V9.B16, V9.B16
+	VEOR V25.B16, V9.B16, V9.B16
+	AESE V31.B16, V13.B16
+	AESMC V13.B16, V13.B16
+	VEOR V25.B16, V13.B16, V13.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V26.B16, V2.B16, V2.B16
+	AESE V31.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	VEOR V26.B16, V6.B16, V6.B16
+	AESE V31.B16, V10.B16
+	AESMC V10.B16, V10.B16
+	VEOR V26.B16, V10.B16, V10.B16
+	AESE V31.B16, V14.B16
+	AESMC V14.B16, V14.B16
+	VEOR V26.B16, V14.B16, V14.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V27.B16, V3.B16, V3.B16
+	AESE V31.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V27.B16, V7.B16, V7.B16
+	AESE V31.B16, V11.B16
+	AESMC V11.B16, V11.B16
+	VEOR V27.B16, V11.B16, V11.B16
+	AESE V31.B16, V15.B16
+	AESMC V15.B16, V15.B16
+	VEOR V27.B16, V15.B16, V15.B16
+	VZIP1 V0.S4, V2.S4, V16.S4
+	VZIP1 V1.S4, V3.S4, V17.S4
+	VZIP2 V2.S4, V0.S4, V18.S4
+	VZIP2 V3.S4, V1.S4, V19.S4
+	VZIP1 V17.D2, V16.D2, V1.D2
+	VZIP2 V17.D2, V16.D2, V2.D2
+	VZIP1 V19.D2, V18.D2, V3.D2
+	VZIP2 V19.D2, V18.D2, V0.D2
+	VZIP1 V4.S4, V6.S4, V16.S4
+	VZIP1 V5.S4, V7.S4, V17.S4
+	VZIP2 V6.S4, V4.S4, V18.S4
+	VZIP2 V7.S4, V5.S4, V19.S4
+	VZIP1 V17.D2, V16.D2, V5.D2
+	VZIP2 V17.D2, V16.D2, V6.D2
+	VZIP1 V19.D2, V18.D2, V7.D2
+	VZIP2 V19.D2, V18.D2, V4.D2
+	VZIP1 V8.S4, V10.S4, V16.S4
+	VZIP1 V9.S4, V11.S4, V17.S4
+	VZIP2 V10.S4, V8.S4, V18.S4
+	VZIP2 V11.S4, V9.S4, V19.S4
+	VZIP1 V17.D2, V16.D2, V9.D2
+	VZIP2 V17.D2, V16.D2, V10.D2
+	VZIP1 V19.D2, V18.D2, V11.D2
+	VZIP2 V19.D2, V18.D2, V8.D2
+	VZIP1 V12.S4, V14.S4, V16.S4
+	VZIP1 V13.S4, V15.S4, V17.S4
+	VZIP2 V14.S4, V12.S4, V18.S4
+	VZIP2 V15.S4, V13.S4, V19.S4
+	VZIP1 V17.D2, V16.D2, V13.D2
+	VZIP2 V17.D2, V16.D2, V14.D2
+	VZIP1 V19.D2, V18.D2, V15.D2
+	VZIP2 V19.D2, V18.D2, V12.D2
+
+	// Round 4
+	VLD1.P 64(R2), [V20.B16, V21.B16, V22.B16, V23.B16]
+	VLD1.P 64(R2), [V24.B16, V25.B16, V26.B16, V27.B16]
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESE V31.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	AESE V31.B16, V8.B16
+	AESMC V8.B16, V8.B16
+	VEOR V20.B16, V8.B16, V8.B16
+	AESE V31.B16, V12.B16
+	AESMC V12.B16, V12.B16
+	VEOR V20.B16, V12.B16, V12.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESE V31.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	AESE V31.B16, V9.B16
+	AESMC V9.B16, V9.B16
+	VEOR V21.B16, V9.B16, V9.B16
+	AESE V31.B16, V13.B16
+	AESMC V13.B16, V13.B16
+	VEOR V21.B16, V13.B16, V13.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESE V31.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	AESE V31.B16, V10.B16
+	AESMC V10.B16, V10.B16
+	VEOR V22.B16, V10.B16, V10.B16
+	AESE V31.B16, V14.B16
+	AESMC V14.B16, V14.B16
+	VEOR V22.B16, V14.B16, V14.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	AESE V31.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESE V31.B16, V11.B16
+	AESMC V11.B16, V11.B16
+	VEOR V23.B16, V11.B16, V11.B16
+	AESE V31.B16, V15.B16
+	AESMC V15.B16, V15.B16
+	VEOR V23.B16, V15.B16, V15.B16
+	AESE V31.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VEOR V24.B16, V0.B16, V0.B16
+	AESE V31.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	VEOR V24.B16, V4.B16, V4.B16
+	AESE V31.B16, V8.B16
+	AESMC V8.B16, V8.B16
+	VEOR V24.B16, V8.B16, V8.B16
+	AESE V31.B16, V12.B16
+	AESMC V12.B16, V12.B16
+	VEOR V24.B16, V12.B16, V12.B16
+	AESE V31.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VEOR V25.B16, V1.B16, V1.B16
+	AESE V31.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	VEOR V25.B16, V5.B16, V5.B16
+	AESE V31.B16, V9.B16
+	AESMC V9.B16, V9.B16
+	VEOR V25.B16, V9.B16, V9.B16
+	AESE V31.B16, V13.B16
+	AESMC V13.B16, V13.B16
+	VEOR V25.B16, V13.B16, V13.B16
+	AESE V31.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	VEOR V26.B16, V2.B16, V2.B16
+	AESE V31.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	VEOR V26.B16, V6.B16, V6.B16
+	AESE V31.B16, V10.B16
+	AESMC V10.B16, V10.B16
+	VEOR V26.B16, V10.B16, V10.B16
+	AESE V31.B16, V14.B16
+	AESMC V14.B16, V14.B16
+	VEOR V26.B16, V14.B16, V14.B16
+	AESE V31.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VEOR V27.B16, V3.B16, V3.B16
+	AESE V31.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V27.B16, V7.B16, V7.B16
+	AESE V31.B16, V11.B16
+	AESMC V11.B16, V11.B16
+	VEOR V27.B16, V11.B16, V11.B16
+	AESE V31.B16, V15.B16
+	AESMC V15.B16, V15.B16
+	VEOR V27.B16, V15.B16, V15.B16
+	VZIP1 V0.S4, V2.S4, V16.S4
+	VZIP1 V1.S4, V3.S4, V17.S4
+	VZIP2 V2.S4, V0.S4, V18.S4
+	VZIP2 V3.S4, V1.S4, V19.S4
+	VZIP1 V17.D2, V16.D2, V1.D2
+	VZIP2 V17.D2, V16.D2, V2.D2
+	VZIP1 V19.D2, V18.D2, V3.D2
+	VZIP2 V19.D2, V18.D2, V0.D2
+	VZIP1 V4.S4, V6.S4, V16.S4
+	VZIP1 V5.S4, V7.S4, V17.S4
+	VZIP2 V6.S4, V4.S4, V18.S4
+	VZIP2 V7.S4, V5.S4, V19.S4
+	VZIP1 V17.D2, V16.D2, V5.D2
+	VZIP2 V17.D2, V16.D2, V6.D2
+	VZIP1 V19.D2, V18.D2, V7.D2
+	VZIP2 V19.D2, V18.D2, V4.D2
+	VZIP1 V8.S4, V10.S4, V16.S4
+	VZIP1 V9.S4, V11.S4, V17.S4
+	VZIP2 V10.S4, V8.S4, V18.S4
+	VZIP2 V11.S4, V9.S4, V19.S4
+	VZIP1 V17.D2, V16.D2, V9.D2
+	VZIP2 V17.D2, V16.D2, V10.D2
+	VZIP1 V19.D2, V18.D2, V11.D2
+	VZIP2 V19.D2, V18.D2, V8.D2
+	VZIP1 V12.S4, V14.S4, V16.S4
+	VZIP1 V13.S4, V15.S4, V17.S4
+	VZIP2 V14.S4, V12.S4, V18.S4
+	VZIP2 V15.S4, V13.S4, V19.S4
+	VZIP1 V17.D2, V16.D2, V13.D2
+	VZIP2 V17.D2, V16.D2, V14.D2
+	VZIP1 V19.D2, V18.D2, V15.D2
+	VZIP2 V19.D2, V18.D2, V12.D2
+
+	// Feed-forward
+	VLD1.P 64(R1), [V20.B16, V21.B16, V22.B16, V23.B16]
+	VEOR V20.B16, V0.B16, V0.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	VZIP2 V1.D2, V0.D2, V28.D2
+	VZIP1 V3.D2, V2.D2, V29.D2
+	VST1.P [V28.B16, V29.B16], 32(R0)
+	VLD1.P 64(R1), [V20.B16, V21.B16, V22.B16, V23.B16]
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VZIP2 V5.D2, V4.D2, V28.D2
+	VZIP1 V7.D2, V6.D2, V29.D2
+	VST1.P [V28.B16, V29.B16], 32(R0)
+	VLD1.P 64(R1), [V20.B16, V21.B16, V22.B16, V23.B16]
+	VEOR V20.B16, V8.B16, V8.B16
+	VEOR V21.B16, V9.B16, V9.B16
+	VEOR V22.B16, V10.B16, V10.B16
+	VEOR V23.B16, V11.B16, V11.B16
+	VZIP2 V9.D2, V8.D2, V28.D2
+	VZIP1 V11.D2, V10.D2, V29.D2
+	VST1.P [V28.B16, V29.B16], 32(R0)
+	VLD1.P 64(R1), [V20.B16, V21.B16, V22.B16, V23.B16]
+	VEOR V20.B16, V12.B16, V12.B16
+	VEOR V21.B16, V13.B16, V13.B16
+	VEOR V22.B16, V14.B16, V14.B16
+	VEOR V23.B16, V15.B16, V15.B16
+	VZIP2 V13.D2, V12.D2, V28.D2
+	VZIP1 V15.D2, V14.D2, V29.D2
+	VST1.P [V28.B16, V29.B16], 32(R0)
+	RET
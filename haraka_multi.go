@@ -0,0 +1,49 @@
+package aes
+
+// Batched Haraka v2 for many independent inputs, as needed by hash-based
+// signatures and Merkle trees. Single Haraka calls are latency-bound: each
+// uses only two or four AES blocks per round. The batched kernels interleave
+// the rounds of four inputs (AES-NI, ARM Crypto Extensions) or place them in
+// the 128-bit lanes of 256/512-bit registers (VAES).
+
+// Haraka256x4 computes Haraka-256 of four independent 32-byte inputs.
+// It returns the same results as four Haraka256 calls.
+func Haraka256x4(out *[4][32]byte, in *[4][32]byte) {
+	haraka256x4(out, in, &harakaRC128)
+}
+
+// Haraka512x4 computes Haraka-512 of four independent 64-byte inputs.
+// It returns the same results as four Haraka512 calls.
+func Haraka512x4(out *[4][32]byte, in *[4][64]byte) {
+	haraka512x4(out, in, &harakaRC128)
+}
+
+// harakaTruncate512 applies the Haraka-512 feed-forward to the permuted
+// state and keeps s0[8:16] || s1[8:16] || s2[0:8] || s3[0:8].
+func harakaTruncate512(out *[32]byte, state, input *[64]byte) {
+	for i := range state {
+		state[i] ^= input[i]
+	}
+	copy(out[0:8], state[8:16])
+	copy(out[8:16], state[24:32])
+	copy(out[16:24], state[32:40])
+	copy(out[24:32], state[48:56])
+}
+
+func haraka256x4Generic(out *[4][32]byte, in *[4][32]byte, rc *[40][16]byte) {
+	for i := range in {
+		state := in[i]
+		harakaPi256Generic(&state, rc)
+		for j := range state {
+			out[i][j] = state[j] ^ in[i][j]
+		}
+	}
+}
+
+func haraka512x4Generic(out *[4][32]byte, in *[4][64]byte, rc *[40][16]byte) {
+	for i := range in {
+		state := in[i]
+		harakaPi512Generic(&state, rc)
+		harakaTruncate512(&out[i], &state, &in[i])
+	}
+}
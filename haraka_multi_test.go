@@ -0,0 +1,91 @@
+package aes
+
+import "testing"
+
+func TestHaraka256x4(t *testing.T) {
+	forEachCPUConfig(t, func(t *testing.T) {
+		for n := 0; n < 8; n++ {
+			var in [4][32]byte
+			for i := range in {
+				for j := range in[i] {
+					in[i][j] = byte(n*97 + i*32 + j*5)
+				}
+			}
+			var out [4][32]byte
+			Haraka256x4(&out, &in)
+			for i := range in {
+				if want := Haraka256(&in[i]); out[i] != want {
+					t.Fatalf("%+v: Haraka256x4 input %d mismatch\nGot:      %x\nExpected: %x", CPU, i, out[i], want)
+				}
+			}
+		}
+	})
+}
+
+func TestHaraka512x4(t *testing.T) {
+	forEachCPUConfig(t, func(t *testing.T) {
+		for n := 0; n < 8; n++ {
+			var in [4][64]byte
+			for i := range in {
+				for j := range in[i] {
+					in[i][j] = byte(n*89 + i*64 + j*3)
+				}
+			}
+			var out [4][32]byte
+			Haraka512x4(&out, &in)
+			for i := range in {
+				if want := Haraka512(&in[i]); out[i] != want {
+					t.Fatalf("%+v: Haraka512x4 input %d mismatch\nGot:      %x\nExpected: %x", CPU, i, out[i], want)
+				}
+			}
+		}
+	})
+}
+
+func TestHarakaX4CustomConstants(t *testing.T) {
+	rc := (*[40][16]byte)(harakaTestConstants())
+	forEachCPUConfig(t, func(t *testing.T) {
+		var in256 [4][32]byte
+		var in512 [4][64]byte
+		for i := range in512 {
+			for j := range in512[i] {
+				in512[i][j] = byte(i*7 + j)
+			}
+			copy(in256[i][:], in512[i][:])
+		}
+
+		var got, want [4][32]byte
+		haraka256x4(&got, &in256, rc)
+		haraka256x4Generic(&want, &in256, rc)
+		if got != want {
+			t.Errorf("%+v: haraka256x4 with custom constants mismatch", CPU)
+		}
+		haraka512x4(&got, &in512, rc)
+		haraka512x4Generic(&want, &in512, rc)
+		if got != want {
+			t.Errorf("%+v: haraka512x4 with custom constants mismatch", CPU)
+		}
+	})
+}
+
+func BenchmarkHaraka256x4(b *testing.B) {
+	var in [4][32]byte
+	var out [4][32]byte
+
+	b.SetBytes(4 * 32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Haraka256x4(&out, &in)
+	}
+}
+
+func BenchmarkHaraka512x4(b *testing.B) {
+	var in [4][64]byte
+	var out [4][32]byte
+
+	b.SetBytes(4 * 64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Haraka512x4(&out, &in)
+	}
+}
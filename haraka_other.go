@@ -11,3 +11,27 @@ func Haraka256HW(input *[32]byte) [32]byte {
 func Haraka512HW(input *[64]byte) [32]byte {
 	return Haraka512(input)
 }
+
+func harakaPermute256(state *[32]byte, rc *[40][16]byte) {
+	harakaPi256Generic(state, rc)
+}
+
+func harakaInversePermute256(state *[32]byte, rc *[40][16]byte) {
+	harakaInvPi256Generic(state, rc)
+}
+
+func harakaPermute512(state *[64]byte, rc *[40][16]byte) {
+	harakaPi512Generic(state, rc)
+}
+
+func harakaInversePermute512(state *[64]byte, rc *[40][16]byte) {
+	harakaInvPi512Generic(state, rc)
+}
+
+func haraka256x4(out *[4][32]byte, in *[4][32]byte, rc *[40][16]byte) {
+	haraka256x4Generic(out, in, rc)
+}
+
+func haraka512x4(out *[4][32]byte, in *[4][64]byte, rc *[40][16]byte) {
+	haraka512x4Generic(out, in, rc)
+}
@@ -0,0 +1,147 @@
+package aes
+
+import "encoding/binary"
+
+// HarakaRoundConstants is a table of 40 128-bit round constants for the Haraka
+// permutations. π512 uses all 40 entries (8 per round), π256 the first 20.
+type HarakaRoundConstants [40][16]byte
+
+// HarakaV2RoundConstants returns a copy of the fixed Haraka v2 constants.
+func HarakaV2RoundConstants() *HarakaRoundConstants {
+	rc := HarakaRoundConstants(harakaRC128)
+	return &rc
+}
+
+// HarakaPermutation256 is the bare π256 permutation of Haraka-256: five rounds
+// of two AES rounds per 128-bit half followed by MIX2, without the
+// feed-forward. It is safe for concurrent use.
+type HarakaPermutation256 struct {
+	rc [40][16]byte
+}
+
+// HarakaPermutation512 is the bare π512 permutation of Haraka-512: five rounds
+// of two AES rounds per 128-bit word followed by MIX512, without the
+// feed-forward or truncation. It is safe for concurrent use.
+type HarakaPermutation512 struct {
+	rc [40][16]byte
+}
+
+// NewHarakaPermutation256 returns π256 with the round constants rc, or with
+// the Haraka v2 constants if rc is nil.
+func NewHarakaPermutation256(rc *HarakaRoundConstants) *HarakaPermutation256 {
+	p := &HarakaPermutation256{rc: harakaRC128}
+	if rc != nil {
+		p.rc = *rc
+	}
+	return p
+}
+
+// NewHarakaPermutation512 returns π512 with the round constants rc, or with
+// the Haraka v2 constants if rc is nil.
+func NewHarakaPermutation512(rc *HarakaRoundConstants) *HarakaPermutation512 {
+	p := &HarakaPermutation512{rc: harakaRC128}
+	if rc != nil {
+		p.rc = *rc
+	}
+	return p
+}
+
+// Permute applies π256 to state in-place.
+func (p *HarakaPermutation256) Permute(state *[32]byte) {
+	harakaPermute256(state, &p.rc)
+}
+
+// InversePermute applies the inverse of π256 to state in-place.
+func (p *HarakaPermutation256) InversePermute(state *[32]byte) {
+	harakaInversePermute256(state, &p.rc)
+}
+
+// Permute applies π512 to state in-place.
+func (p *HarakaPermutation512) Permute(state *[64]byte) {
+	harakaPermute512(state, &p.rc)
+}
+
+// InversePermute applies the inverse of π512 to state in-place.
+func (p *HarakaPermutation512) InversePermute(state *[64]byte) {
+	harakaInversePermute512(state, &p.rc)
+}
+
+// invRoundHaraka inverts Round: x = InvSubBytes(InvShiftRows(InvMixColumns(x ⊕ k))).
+func invRoundHaraka(x, k *Block) {
+	XorBlock(x, x, k)
+	InvMixColumns(x)
+	InvFinalRoundNoKey(x)
+}
+
+// invMix2 inverts mix2.
+func invMix2(s0, s1 *Block) {
+	// s0 = [a0, b0, a1, b1], s1 = [a2, b2, a3, b3]
+	a0 := binary.LittleEndian.Uint32(s0[0:4])
+	b0 := binary.LittleEndian.Uint32(s0[4:8])
+	a1 := binary.LittleEndian.Uint32(s0[8:12])
+	b1 := binary.LittleEndian.Uint32(s0[12:16])
+	a2 := binary.LittleEndian.Uint32(s1[0:4])
+	b2 := binary.LittleEndian.Uint32(s1[4:8])
+	a3 := binary.LittleEndian.Uint32(s1[8:12])
+	b3 := binary.LittleEndian.Uint32(s1[12:16])
+
+	binary.LittleEndian.PutUint32(s0[0:4], a0)
+	binary.LittleEndian.PutUint32(s0[4:8], a1)
+	binary.LittleEndian.PutUint32(s0[8:12], a2)
+	binary.LittleEndian.PutUint32(s0[12:16], a3)
+	binary.LittleEndian.PutUint32(s1[0:4], b0)
+	binary.LittleEndian.PutUint32(s1[4:8], b1)
+	binary.LittleEndian.PutUint32(s1[8:12], b2)
+	binary.LittleEndian.PutUint32(s1[12:16], b3)
+}
+
+// harakaInvMix512[i] is the position of word i after mix512.
+var harakaInvMix512 = [16]int{5, 9, 12, 0, 7, 11, 14, 2, 4, 8, 13, 1, 6, 10, 15, 3}
+
+// invMix512 inverts mix512.
+func invMix512(s0, s1, s2, s3 *Block) {
+	blocks := [4]*Block{s0, s1, s2, s3}
+	var w [16]uint32
+	for i := range w {
+		w[i] = binary.LittleEndian.Uint32(blocks[i/4][4*(i%4):])
+	}
+	for i, j := range harakaInvMix512 {
+		binary.LittleEndian.PutUint32(blocks[i/4][4*(i%4):], w[j])
+	}
+}
+
+// harakaInvPi256Generic applies the inverse of harakaPi256Generic.
+func harakaInvPi256Generic(state *[32]byte, rc *[40][16]byte) {
+	s0 := (*Block)(state[0:16])
+	s1 := (*Block)(state[16:32])
+
+	for round := 4; round >= 0; round-- {
+		rcIdx := round * 4
+		invMix2(s0, s1)
+		invRoundHaraka(s1, (*Block)(&rc[rcIdx+3]))
+		invRoundHaraka(s0, (*Block)(&rc[rcIdx+2]))
+		invRoundHaraka(s1, (*Block)(&rc[rcIdx+1]))
+		invRoundHaraka(s0, (*Block)(&rc[rcIdx+0]))
+	}
+}
+
+// harakaInvPi512Generic applies the inverse of harakaPi512Generic.
+func harakaInvPi512Generic(state *[64]byte, rc *[40][16]byte) {
+	s := [4]*Block{
+		(*Block)(state[0:16]),
+		(*Block)(state[16:32]),
+		(*Block)(state[32:48]),
+		(*Block)(state[48:64]),
+	}
+
+	for round := 4; round >= 0; round-- {
+		rcIdx := round * 8
+		invMix512(s[0], s[1], s[2], s[3])
+		for i := range s {
+			invRoundHaraka(s[i], (*Block)(&rc[rcIdx+4+i]))
+		}
+		for i := range s {
+			invRoundHaraka(s[i], (*Block)(&rc[rcIdx+i]))
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package aes
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestHarakaPermutationMatchesHash(t *testing.T) {
+	p256 := NewHarakaPermutation256(nil)
+	p512 := NewHarakaPermutation512(nil)
+
+	for i := 0; i < 32; i++ {
+		var in256 [32]byte
+		for j := range in256 {
+			in256[j] = byte(i*7 + j*13)
+		}
+		state := in256
+		p256.Permute(&state)
+		for j := range state {
+			state[j] ^= in256[j]
+		}
+		if want := Haraka256(&in256); state != want {
+			t.Errorf("π256 ⊕ input mismatch for input %d\nGot:      %x\nExpected: %x", i, state, want)
+		}
+
+		var in512 [64]byte
+		for j := range in512 {
+			in512[j] = byte(i*11 + j*17)
+		}
+		state512 := in512
+		p512.Permute(&state512)
+		for j := range state512 {
+			state512[j] ^= in512[j]
+		}
+		var got [32]byte
+		copy(got[0:8], state512[8:16])
+		copy(got[8:16], state512[24:32])
+		copy(got[16:24], state512[32:40])
+		copy(got[24:32], state512[48:56])
+		if want := Haraka512(&in512); got != want {
+			t.Errorf("π512 ⊕ input mismatch for input %d\nGot:      %x\nExpected: %x", i, got, want)
+		}
+	}
+}
+
+func harakaTestConstants() *HarakaRoundConstants {
+	var rc HarakaRoundConstants
+	for i := range rc {
+		for j := range rc[i] {
+			rc[i][j] = byte(i*31 + j*7 + 1)
+		}
+	}
+	return &rc
+}
+
+func TestHarakaPermutationRoundtrip(t *testing.T) {
+	for _, rc := range []*HarakaRoundConstants{nil, harakaTestConstants()} {
+		p256 := NewHarakaPermutation256(rc)
+		p512 := NewHarakaPermutation512(rc)
+
+		var original256, state256 [32]byte
+		for i := range original256 {
+			original256[i] = byte(i * 3)
+		}
+		state256 = original256
+		p256.Permute(&state256)
+		if state256 == original256 {
+			t.Error("π256 left the state unchanged")
+		}
+		p256.InversePermute(&state256)
+		if state256 != original256 {
+			t.Errorf("π256 roundtrip failed\nOriginal:  %x\nRoundtrip: %x", original256, state256)
+		}
+
+		var original512, state512 [64]byte
+		for i := range original512 {
+			original512[i] = byte(i * 5)
+		}
+		state512 = original512
+		p512.Permute(&state512)
+		if state512 == original512 {
+			t.Error("π512 left the state unchanged")
+		}
+		p512.InversePermute(&state512)
+		if state512 != original512 {
+			t.Errorf("π512 roundtrip failed\nOriginal:  %x\nRoundtrip: %x", original512, state512)
+		}
+	}
+}
+
+func TestHarakaPermutationCustomConstants(t *testing.T) {
+	if *HarakaV2RoundConstants() != HarakaRoundConstants(harakaRC128) {
+		t.Fatal("HarakaV2RoundConstants does not return the Haraka v2 constants")
+	}
+
+	rc := harakaTestConstants()
+	p := NewHarakaPermutation256(rc)
+	rc[0][0] ^= 1 // the permutation must keep its own copy
+
+	var state, expected [32]byte
+	for i := range state {
+		state[i] = byte(i)
+	}
+	expected = state
+	p.Permute(&state)
+
+	rc[0][0] ^= 1
+	harakaPi256Generic(&expected, (*[40][16]byte)(rc))
+	if state != expected {
+		t.Errorf("π256 with custom constants mismatch\nGot:      %x\nExpected: %x", state, expected)
+	}
+
+	var def [32]byte
+	for i := range def {
+		def[i] = byte(i)
+	}
+	NewHarakaPermutation256(nil).Permute(&def)
+	if def == state {
+		t.Error("custom constants produced the same output as the default constants")
+	}
+}
+
+// Test hardware vs software implementation consistency
+func TestHarakaPermutationHardwareSoftwareMatch(t *testing.T) {
+	for _, rc := range []*[40][16]byte{&harakaRC128, (*[40][16]byte)(harakaTestConstants())} {
+		for i := 0; i < 16; i++ {
+			var hw256, sw256 [32]byte
+			for j := range hw256 {
+				hw256[j] = byte(i*29 + j*3)
+			}
+			sw256 = hw256
+			harakaPermute256(&hw256, rc)
+			harakaPi256Generic(&sw256, rc)
+			if hw256 != sw256 {
+				t.Errorf("π256 hardware/software mismatch\nHardware: %x\nSoftware: %x", hw256, sw256)
+			}
+			harakaInversePermute256(&hw256, rc)
+			harakaInvPi256Generic(&sw256, rc)
+			if hw256 != sw256 {
+				t.Errorf("π256 inverse hardware/software mismatch\nHardware: %x\nSoftware: %x", hw256, sw256)
+			}
+
+			var hw512, sw512 [64]byte
+			for j := range hw512 {
+				hw512[j] = byte(i*37 + j*5)
+			}
+			sw512 = hw512
+			harakaPermute512(&hw512, rc)
+			harakaPi512Generic(&sw512, rc)
+			if !bytes.Equal(hw512[:], sw512[:]) {
+				t.Errorf("π512 hardware/software mismatch\nHardware: %x\nSoftware: %x", hw512, sw512)
+			}
+			harakaInversePermute512(&hw512, rc)
+			harakaInvPi512Generic(&sw512, rc)
+			if !bytes.Equal(hw512[:], sw512[:]) {
+				t.Errorf("π512 inverse hardware/software mismatch\nHardware: %x\nSoftware: %x", hw512, sw512)
+			}
+		}
+	}
+}
+
+func BenchmarkHarakaPermutation256(b *testing.B) {
+	p := NewHarakaPermutation256(nil)
+	var state [32]byte
+
+	b.SetBytes(32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Permute(&state)
+	}
+}
+
+func BenchmarkHarakaPermutation512(b *testing.B) {
+	p := NewHarakaPermutation512(nil)
+	var state [64]byte
+
+	b.SetBytes(64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Permute(&state)
+	}
+}
+
+func BenchmarkHarakaPermutation512Inverse(b *testing.B) {
+	p := NewHarakaPermutation512(nil)
+	var state [64]byte
+
+	b.SetBytes(64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.InversePermute(&state)
+	}
+}
@@ -0,0 +1,151 @@
+package aes
+
+import (
+	"hash"
+	"io"
+)
+
+// Haraka-S is the sponge over the π512 permutation used by SPHINCS+-Haraka.
+//
+// The 64-byte state has a 32-byte rate. Input is absorbed 32 bytes at a time
+// and padded with a 0x1F domain byte and a final 0x80 bit; each output block
+// is the rate part of the state after a further permutation call.
+//
+// In SPHINCS+ the round constants are tweaked with the public seed: the 640
+// bytes of Haraka-S(seed), computed with the Haraka v2 constants, become the
+// 40 round constants of every later Haraka call.
+
+const (
+	// HarakaSSize is the digest size in bytes returned by Sum.
+	HarakaSSize = 32
+
+	// HarakaSRate is the Haraka-S rate in bytes.
+	HarakaSRate = 32
+)
+
+// HarakaS computes the Haraka-S sponge. It implements hash.Hash, with Sum
+// returning the first 32 output bytes, and io.Reader to produce output of any
+// length. Writing after the first Read panics.
+type HarakaS struct {
+	rc        [40][16]byte
+	state     [64]byte
+	n         int // position in the rate, for absorbing or squeezing
+	squeezing bool
+}
+
+var (
+	_ hash.Hash = (*HarakaS)(nil)
+	_ io.Reader = (*HarakaS)(nil)
+)
+
+// NewHarakaS returns a Haraka-S sponge whose round constants are tweaked with
+// seed, as in SPHINCS+. An empty seed selects the Haraka v2 constants.
+func NewHarakaS(seed []byte) *HarakaS {
+	h := &HarakaS{rc: harakaRC128}
+	if len(seed) > 0 {
+		h.rc = *HarakaSRoundConstants(seed)
+	}
+	return h
+}
+
+// NewHarakaSWithConstants returns a Haraka-S sponge using the given round
+// constants, or the Haraka v2 constants if rc is nil.
+func NewHarakaSWithConstants(rc *HarakaRoundConstants) *HarakaS {
+	h := &HarakaS{rc: harakaRC128}
+	if rc != nil {
+		h.rc = *rc
+	}
+	return h
+}
+
+// HarakaSRoundConstants derives seed-tweaked round constants: the first 640
+// output bytes of Haraka-S(seed) under the Haraka v2 constants.
+func HarakaSRoundConstants(seed []byte) *HarakaRoundConstants {
+	h := NewHarakaSWithConstants(nil)
+	h.Write(seed)
+	var rc HarakaRoundConstants
+	for i := range rc {
+		h.Read(rc[i][:])
+	}
+	return &rc
+}
+
+// Reset resets the sponge to its initial state, keeping its round constants.
+func (h *HarakaS) Reset() {
+	clear(h.state[:])
+	h.n = 0
+	h.squeezing = false
+}
+
+// Size returns the digest size in bytes.
+func (h *HarakaS) Size() int { return HarakaSSize }
+
+// BlockSize returns the rate in bytes.
+func (h *HarakaS) BlockSize() int { return HarakaSRate }
+
+// Write absorbs p. It never returns an error.
+func (h *HarakaS) Write(p []byte) (int, error) {
+	if h.squeezing {
+		panic("aes: write to Haraka-S after read")
+	}
+	nn := len(p)
+	for len(p) > 0 {
+		k := min(HarakaSRate-h.n, len(p))
+		for i := range k {
+			h.state[h.n+i] ^= p[i]
+		}
+		h.n += k
+		p = p[k:]
+		if h.n == HarakaSRate {
+			harakaPermute512(&h.state, &h.rc)
+			h.n = 0
+		}
+	}
+	return nn, nil
+}
+
+// pad finishes absorbing and switches to squeezing.
+func (h *HarakaS) pad() {
+	h.state[h.n] ^= 0x1f
+	h.state[HarakaSRate-1] ^= 0x80
+	h.n = HarakaSRate
+	h.squeezing = true
+}
+
+// Read squeezes len(p) bytes of output. It never returns an error.
+func (h *HarakaS) Read(p []byte) (int, error) {
+	if !h.squeezing {
+		h.pad()
+	}
+	nn := len(p)
+	for len(p) > 0 {
+		if h.n == HarakaSRate {
+			harakaPermute512(&h.state, &h.rc)
+			h.n = 0
+		}
+		k := copy(p, h.state[h.n:HarakaSRate])
+		h.n += k
+		p = p[k:]
+	}
+	return nn, nil
+}
+
+// Sum appends the first 32 output bytes to b. It does not change the
+// underlying sponge state and can only be called before Read.
+func (h *HarakaS) Sum(b []byte) []byte {
+	if h.squeezing {
+		panic("aes: Haraka-S Sum after read")
+	}
+	c := *h
+	var out [HarakaSSize]byte
+	c.Read(out[:])
+	return append(b, out[:]...)
+}
+
+// HarakaSSum fills out with Haraka-S(in) under the given round constants, or
+// the Haraka v2 constants if rc is nil.
+func HarakaSSum(out, in []byte, rc *HarakaRoundConstants) {
+	h := NewHarakaSWithConstants(rc)
+	h.Write(in)
+	h.Read(out)
+}
@@ -0,0 +1,174 @@
+package aes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// harakaSReference follows haraka_S from the SPHINCS+ reference code: absorb
+// the whole message, pad, then squeeze full blocks and a final partial block.
+func harakaSReference(out, m []byte, rc *[40][16]byte) {
+	var s [64]byte
+	for len(m) >= HarakaSRate {
+		for i := range HarakaSRate {
+			s[i] ^= m[i]
+		}
+		harakaPi512Generic(&s, rc)
+		m = m[HarakaSRate:]
+	}
+	var t [HarakaSRate]byte
+	copy(t[:], m)
+	t[len(m)] = 0x1f
+	t[HarakaSRate-1] |= 0x80
+	for i := range t {
+		s[i] ^= t[i]
+	}
+	for len(out) > 0 {
+		harakaPi512Generic(&s, rc)
+		out = out[copy(out, s[:HarakaSRate]):]
+	}
+}
+
+func TestHarakaSMatchesReference(t *testing.T) {
+	msg := make([]byte, 200)
+	for i := range msg {
+		msg[i] = byte(i*13 + 5)
+	}
+	rc := HarakaSRoundConstants([]byte("public seed 0123"))
+
+	for _, mlen := range []int{0, 1, 31, 32, 33, 63, 64, 65, 200} {
+		for _, outlen := range []int{1, 31, 32, 33, 100} {
+			for _, consts := range []*HarakaRoundConstants{nil, rc} {
+				want := make([]byte, outlen)
+				ref := (*[40][16]byte)(consts)
+				if ref == nil {
+					ref = &harakaRC128
+				}
+				harakaSReference(want, msg[:mlen], ref)
+
+				got := make([]byte, outlen)
+				HarakaSSum(got, msg[:mlen], consts)
+				if !bytes.Equal(got, want) {
+					t.Errorf("mlen=%d outlen=%d: mismatch\nGot:      %x\nExpected: %x", mlen, outlen, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestHarakaSStreaming(t *testing.T) {
+	msg := make([]byte, 300)
+	for i := range msg {
+		msg[i] = byte(i * 7)
+	}
+	seed := []byte("0123456789abcdef")
+
+	want := make([]byte, 150)
+	h := NewHarakaS(seed)
+	h.Write(msg)
+	h.Read(want)
+
+	for _, step := range []int{1, 5, 31, 32, 33, 100} {
+		h := NewHarakaS(seed)
+		for i := 0; i < len(msg); i += step {
+			h.Write(msg[i:min(i+step, len(msg))])
+		}
+		sum := h.Sum(nil)
+		if !bytes.Equal(sum, want[:HarakaSSize]) {
+			t.Errorf("step %d: Sum mismatch\nGot:      %x\nExpected: %x", step, sum, want[:HarakaSSize])
+		}
+		got := make([]byte, len(want))
+		for i := 0; i < len(got); i += step {
+			h.Read(got[i:min(i+step, len(got))])
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("step %d: Read mismatch\nGot:      %x\nExpected: %x", step, got, want)
+		}
+	}
+
+	h.Reset()
+	h.Write(msg)
+	if sum := h.Sum(nil); !bytes.Equal(sum, want[:HarakaSSize]) {
+		t.Errorf("Reset did not restore the initial state\nGot:      %x\nExpected: %x", sum, want[:HarakaSSize])
+	}
+}
+
+func TestHarakaSRoundConstants(t *testing.T) {
+	seed := []byte("public seed 0123")
+	var want [640]byte
+	HarakaSSum(want[:], seed, nil)
+
+	rc := HarakaSRoundConstants(seed)
+	for i := range rc {
+		if !bytes.Equal(rc[i][:], want[16*i:16*i+16]) {
+			t.Fatalf("constant %d: got %x, expected %x", i, rc[i], want[16*i:16*i+16])
+		}
+	}
+
+	if *NewHarakaS(nil) != *NewHarakaSWithConstants(nil) {
+		t.Error("an empty seed should select the Haraka v2 constants")
+	}
+	if *NewHarakaS(seed) != *NewHarakaSWithConstants(rc) {
+		t.Error("NewHarakaS does not use the seed-tweaked constants")
+	}
+}
+
+func TestHarakaSWriteAfterRead(t *testing.T) {
+	h := NewHarakaS(nil)
+	var out [8]byte
+	h.Read(out[:])
+	defer func() {
+		if recover() == nil {
+			t.Error("Write after Read did not panic")
+		}
+	}()
+	h.Write([]byte{0})
+}
+
+func TestHarakaSKnownAnswers(t *testing.T) {
+	seq := make([]byte, 64)
+	for i := range seq {
+		seq[i] = byte(i)
+	}
+	tests := []struct {
+		name     string
+		seed     []byte
+		msg      []byte
+		expected string
+	}{
+		{"empty", nil, nil, "ae551e5b5bfb0c3e4febd1003dc18065769bae2d06ab3870aa4169fd7a529b52ccd04a93dcefb0cc882c3983acb0ca61"},
+		{"abc", nil, []byte("abc"), "c07f10e570e64f8bf5bf870376a2ce983485be6cc00aa14b158f8a9f95d0d9207a6d57f4e2ff745806414d0a3aa575fa"},
+		{"sequential", nil, seq, "cfbc92bc9b22ec2dd8245e3f7335083551a3c22754d45a2939e58682971989999d75c22d9fe41f831d55cb05220baf98"},
+		{"seeded", seq[:16], seq[:48], "8d6f4520be71f5b6be7c63f9d19e50a5037fbd6186e27e76709a196cf026f8bd13b5c5fdeab938e266ce821b9069ffcb"},
+	}
+	for _, tc := range tests {
+		h := NewHarakaS(tc.seed)
+		h.Write(tc.msg)
+		got := make([]byte, 48)
+		h.Read(got)
+		if hex.EncodeToString(got) != tc.expected {
+			t.Errorf("%s: got %x, expected %s", tc.name, got, tc.expected)
+		}
+	}
+}
+
+func BenchmarkHarakaS(b *testing.B) {
+	h := NewHarakaS([]byte("0123456789abcdef"))
+	data := make([]byte, 1024)
+
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.Reset()
+		h.Write(data)
+		h.Sum(nil)
+	}
+}
+
+func BenchmarkHarakaSRoundConstants(b *testing.B) {
+	seed := []byte("0123456789abcdef")
+	for i := 0; i < b.N; i++ {
+		HarakaSRoundConstants(seed)
+	}
+}
@@ -0,0 +1,199 @@
+package aes
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"unsafe"
+)
+
+// HCTR2 is a length-preserving tweakable wide-block encryption mode
+// (Crowley, Huckleberry and Biggers, "Length-preserving encryption with
+// HCTR2", ePrint 2021/1441). It is used by Linux fscrypt for filename
+// encryption. Every bit of the output depends on every bit of the input and
+// the tweak, so equal prefixes do not leak.
+//
+// For a message P = M || N with |M| = 16 and a tweak T:
+//
+//	h  = E(0), L = E(1)               (le128 encoding)
+//	MM = M ⊕ H(T, N)
+//	UU = E(MM)
+//	S  = MM ⊕ UU ⊕ L
+//	V  = N ⊕ XCTR(S)                  XCTR(S) = E(S ⊕ 1) || E(S ⊕ 2) || ...
+//	U  = UU ⊕ H(T, V)
+//	C  = U || V
+//
+// where H(T, X) = POLYVAL_h(le128(2|T| + 2 + [16 ∤ |X|]) || pad0(T) || pad(X)),
+// |T| is in bits, pad0 appends zeros and pad appends 0x01 then zeros to a
+// partial final block.
+
+// HCTR2MinInputSize is the smallest message HCTR2 can encrypt.
+const HCTR2MinInputSize = 16
+
+// hctr2Batch is the number of XCTR keystream blocks generated at a time.
+const hctr2Batch = 32
+
+// HCTR2 holds the AES key schedule and the derived hashing key.
+// It is safe for concurrent use.
+type HCTR2 struct {
+	ks    *KeySchedule
+	invKS *KeySchedule
+	l     Block
+	hash  Polyval // keyed with h; copied for each message
+}
+
+// NewHCTR2 returns HCTR2 instantiated with the AES-128 or AES-256 key schedule ks.
+func NewHCTR2(ks *KeySchedule) (*HCTR2, error) {
+	if ks == nil || (ks.Rounds() != 10 && ks.Rounds() != 14) {
+		return nil, errors.New("aes: HCTR2 key schedule must be AES-128 or AES-256")
+	}
+	c := &HCTR2{ks: ks, invKS: InverseKeySchedule(ks)}
+
+	var h Block
+	EncryptBlockAES(&h, ks)
+	c.l[0] = 1
+	EncryptBlockAES(&c.l, ks)
+	c.hash.init(&h)
+	return c, nil
+}
+
+// encryptBlocks encrypts blocks in place with AES.
+func (c *HCTR2) encryptBlocks(blocks []Block) {
+	if c.ks.Rounds() == 10 {
+		EncryptBlocksAES128(blocks, c.ks)
+	} else {
+		EncryptBlocksAES256(blocks, c.ks)
+	}
+}
+
+// decryptBlock decrypts a single block with AES (equivalent inverse cipher).
+func (c *HCTR2) decryptBlock(block *Block) {
+	AddRoundKey(block, c.invKS.GetRoundKey(0))
+	if c.invKS.Rounds() == 10 {
+		var keys RoundKeys10
+		for i := range keys {
+			keys[i] = *c.invKS.GetRoundKey(i + 1)
+		}
+		InvRounds10WithFinalHW(block, &keys)
+	} else {
+		var keys RoundKeys14
+		for i := range keys {
+			keys[i] = *c.invKS.GetRoundKey(i + 1)
+		}
+		InvRounds14WithFinalHW(block, &keys)
+	}
+}
+
+// polyvalPadded absorbs data into p, padding a partial final block with pad
+// followed by zeros.
+func polyvalPadded(p *Polyval, data []byte, pad byte) {
+	full := len(data) &^ (PolyvalBlockSize - 1)
+	p.Update(data[:full])
+	if full < len(data) {
+		var b Block
+		copy(b[:], data[full:])
+		b[len(data)-full] = pad
+		p.Update(b[:])
+	}
+}
+
+// hashTweakMessage computes H(T, X) into dst.
+func (c *HCTR2) hashTweakMessage(dst *Block, tweak, msg []byte) {
+	p := c.hash
+
+	var b Block
+	v := 2*8*uint64(len(tweak)) + 2
+	if len(msg)%PolyvalBlockSize != 0 {
+		v++
+	}
+	binary.LittleEndian.PutUint64(b[:8], v)
+	p.Update(b[:])
+	polyvalPadded(&p, tweak, 0)
+	polyvalPadded(&p, msg, 1)
+	*dst = p.Sum()
+}
+
+// xctr XORs src with the XCTR keystream for s into dst.
+func (c *HCTR2) xctr(dst, src []byte, s *Block) {
+	var ks [hctr2Batch]Block
+	ksBytes := unsafe.Slice(&ks[0][0], len(ks)*16)
+	s0 := binary.LittleEndian.Uint64(s[:8])
+	ctr := uint64(1)
+
+	for len(src) > 0 {
+		n := min((len(src)+15)/16, hctr2Batch)
+		for i := range n {
+			ks[i] = *s
+			binary.LittleEndian.PutUint64(ks[i][:8], s0^ctr)
+			ctr++
+		}
+		c.encryptBlocks(ks[:n])
+		k := min(len(src), n*16)
+		subtle.XORBytes(dst[:k], src[:k], ksBytes[:k])
+		dst, src = dst[k:], src[k:]
+	}
+}
+
+func (c *HCTR2) checkLengths(dst, src []byte) {
+	if len(src) < HCTR2MinInputSize {
+		panic("aes: HCTR2 input must be at least 16 bytes")
+	}
+	if len(dst) < len(src) {
+		panic("aes: HCTR2 output smaller than input")
+	}
+	if inexactOverlap(dst[:len(src)], src) {
+		panic("aes: invalid buffer overlap")
+	}
+}
+
+// Encrypt encrypts src into dst under tweak. The ciphertext has the same
+// length as src, which must be at least 16 bytes. dst and src may overlap
+// entirely or not at all.
+func (c *HCTR2) Encrypt(dst, src, tweak []byte) {
+	c.checkLengths(dst, src)
+	dst = dst[:len(src)]
+	n := src[HCTR2MinInputSize:]
+
+	var mm, uu, s Block
+	c.hashTweakMessage(&mm, tweak, n)
+	xorBlocks(&mm, (*Block)(src))
+
+	uu = mm
+	EncryptBlockAES(&uu, c.ks)
+	XorBlock(&s, &mm, &uu)
+	xorBlocks(&s, &c.l)
+
+	v := dst[HCTR2MinInputSize:]
+	c.xctr(v, n, &s)
+
+	var u Block
+	c.hashTweakMessage(&u, tweak, v)
+	xorBlocks(&u, &uu)
+	copy(dst, u[:])
+}
+
+// Decrypt decrypts src into dst under tweak. The plaintext has the same
+// length as src, which must be at least 16 bytes. dst and src may overlap
+// entirely or not at all.
+func (c *HCTR2) Decrypt(dst, src, tweak []byte) {
+	c.checkLengths(dst, src)
+	dst = dst[:len(src)]
+	v := src[HCTR2MinInputSize:]
+
+	var uu, mm, s Block
+	c.hashTweakMessage(&uu, tweak, v)
+	xorBlocks(&uu, (*Block)(src))
+
+	mm = uu
+	c.decryptBlock(&mm)
+	XorBlock(&s, &mm, &uu)
+	xorBlocks(&s, &c.l)
+
+	n := dst[HCTR2MinInputSize:]
+	c.xctr(n, v, &s)
+
+	var m Block
+	c.hashTweakMessage(&m, tweak, n)
+	xorBlocks(&m, &mm)
+	copy(dst, m[:])
+}
@@ -0,0 +1,237 @@
+package aes
+
+import (
+	"bytes"
+	stdaes "crypto/aes"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+)
+
+// hctr2Reference implements HCTR2 encryption directly from the specification,
+// using crypto/aes and the bit-by-bit POLYVAL reference.
+func hctr2Reference(key, tweak, msg []byte) []byte {
+	block, err := stdaes.NewCipher(key)
+	if err != nil {
+		panic(err)
+	}
+	enc := func(b []byte) []byte {
+		out := make([]byte, 16)
+		block.Encrypt(out, b)
+		return out
+	}
+	xor := func(a, b []byte) []byte {
+		out := make([]byte, len(a))
+		for i := range a {
+			out[i] = a[i] ^ b[i]
+		}
+		return out
+	}
+	le128 := func(v uint64) []byte {
+		b := make([]byte, 16)
+		binary.LittleEndian.PutUint64(b, v)
+		return b
+	}
+	h := enc(le128(0))
+	l := enc(le128(1))
+
+	hash := func(x []byte) []byte {
+		v := 2*8*uint64(len(tweak)) + 2
+		if len(x)%16 != 0 {
+			v++
+		}
+		in := le128(v)
+		t := append([]byte(nil), tweak...)
+		for len(t)%16 != 0 {
+			t = append(t, 0)
+		}
+		in = append(in, t...)
+		m := append([]byte(nil), x...)
+		if len(m)%16 != 0 {
+			m = append(m, 1)
+			for len(m)%16 != 0 {
+				m = append(m, 0)
+			}
+		}
+		in = append(in, m...)
+		sum := polyvalReference((*Block)(h), in)
+		return sum[:]
+	}
+
+	m, n := msg[:16], msg[16:]
+	mm := xor(m, hash(n))
+	uu := enc(mm)
+	s := xor(xor(mm, uu), l)
+	var v []byte
+	for i := uint64(1); len(v) < len(n); i++ {
+		v = append(v, enc(xor(s, le128(i)))...)
+	}
+	v = xor(n, v[:len(n)])
+	u := xor(uu, hash(v))
+	return append(u, v...)
+}
+
+func newTestHCTR2(t testing.TB, key []byte) *HCTR2 {
+	ks, err := NewKeySchedule(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewHCTR2(ks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestHCTR2MatchesReference(t *testing.T) {
+	for _, keySize := range []int{16, 32} {
+		key := make([]byte, keySize)
+		for i := range key {
+			key[i] = byte(i * 3)
+		}
+		c := newTestHCTR2(t, key)
+
+		for _, msgLen := range []int{16, 17, 31, 32, 33, 48, 255, 512, 600} {
+			for _, tweakLen := range []int{0, 1, 16, 32, 33} {
+				msg := make([]byte, msgLen)
+				tweak := make([]byte, tweakLen)
+				for i := range msg {
+					msg[i] = byte(i * 7)
+				}
+				for i := range tweak {
+					tweak[i] = byte(0xa0 + i)
+				}
+
+				want := hctr2Reference(key, tweak, msg)
+				got := make([]byte, msgLen)
+				c.Encrypt(got, msg, tweak)
+				if !bytes.Equal(got, want) {
+					t.Fatalf("AES-%d msg=%d tweak=%d: mismatch\ngot:  %x\nwant: %x", keySize*8, msgLen, tweakLen, got, want)
+				}
+
+				pt := make([]byte, msgLen)
+				c.Decrypt(pt, got, tweak)
+				if !bytes.Equal(pt, msg) {
+					t.Fatalf("AES-%d msg=%d tweak=%d: Decrypt mismatch", keySize*8, msgLen, tweakLen)
+				}
+			}
+		}
+	}
+}
+
+func TestHCTR2InPlace(t *testing.T) {
+	c := newTestHCTR2(t, make([]byte, 32))
+	tweak := []byte("file name tweak")
+	msg := []byte("a record that is longer than one block")
+
+	want := make([]byte, len(msg))
+	c.Encrypt(want, msg, tweak)
+
+	buf := bytes.Clone(msg)
+	c.Encrypt(buf, buf, tweak)
+	if !bytes.Equal(buf, want) {
+		t.Error("in-place Encrypt mismatch")
+	}
+	c.Decrypt(buf, buf, tweak)
+	if !bytes.Equal(buf, msg) {
+		t.Error("in-place Decrypt mismatch")
+	}
+}
+
+func TestHCTR2Diffusion(t *testing.T) {
+	c := newTestHCTR2(t, make([]byte, 16))
+	msg := make([]byte, 100)
+	tweak := make([]byte, 32)
+	ct := make([]byte, len(msg))
+	c.Encrypt(ct, msg, tweak)
+
+	// Flipping the last plaintext bit must change the first ciphertext block
+	msg2 := bytes.Clone(msg)
+	msg2[len(msg2)-1] ^= 1
+	ct2 := make([]byte, len(msg))
+	c.Encrypt(ct2, msg2, tweak)
+	if bytes.Equal(ct[:16], ct2[:16]) || bytes.Equal(ct[16:32], ct2[16:32]) {
+		t.Error("plaintext change did not propagate")
+	}
+
+	tweak[0] ^= 1
+	c.Encrypt(ct2, msg, tweak)
+	if bytes.Equal(ct[:16], ct2[:16]) || bytes.Equal(ct[16:32], ct2[16:32]) {
+		t.Error("tweak change did not propagate")
+	}
+}
+
+func TestHCTR2Errors(t *testing.T) {
+	if _, err := NewHCTR2(nil); err == nil {
+		t.Error("expected error for nil key schedule")
+	}
+	ks, _ := NewKeySchedule(make([]byte, 24))
+	if _, err := NewHCTR2(ks); err == nil {
+		t.Error("expected error for AES-192")
+	}
+
+	c := newTestHCTR2(t, make([]byte, 16))
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for a 15-byte input")
+		}
+	}()
+	c.Encrypt(make([]byte, 15), make([]byte, 15), nil)
+}
+
+// Known-answer vectors generated by this implementation (and matching
+// hctr2Reference); the HCTR2 paper's vectors are not included in this tree.
+func TestHCTR2KnownAnswer(t *testing.T) {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	tweak := make([]byte, 32)
+	for i := range tweak {
+		tweak[i] = byte(0x80 + i)
+	}
+	msg := make([]byte, 45)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+
+	tests := []struct {
+		keySize int
+		want    string
+	}{
+		{16, "1121060daf642e0e7be543d13eaedc5f63253d8a3683ccd67bb6c235baff012c83f2202a5cc8f40437a54324b9"},
+		{32, "daa22056b9c5888d4f1e84c3a94e585695c3a7819e5d60feea23cc37c84c3f41790dac7cdaf7a02ec46e24758d"},
+	}
+	for _, tt := range tests {
+		c := newTestHCTR2(t, key[:tt.keySize])
+		ct := make([]byte, len(msg))
+		c.Encrypt(ct, msg, tweak)
+		if got := hex.EncodeToString(ct); got != tt.want {
+			t.Errorf("AES-%d:\ngot:  %s\nwant: %s", tt.keySize*8, got, tt.want)
+		}
+	}
+}
+
+func BenchmarkHCTR2Encrypt(b *testing.B) {
+	c := newTestHCTR2(b, make([]byte, 32))
+	tweak := make([]byte, 32)
+	msg := make([]byte, 4096)
+
+	b.SetBytes(int64(len(msg)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Encrypt(msg, msg, tweak)
+	}
+}
+
+func BenchmarkHCTR2Decrypt(b *testing.B) {
+	c := newTestHCTR2(b, make([]byte, 32))
+	tweak := make([]byte, 32)
+	msg := make([]byte, 4096)
+
+	b.SetBytes(int64(len(msg)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Decrypt(msg, msg, tweak)
+	}
+}
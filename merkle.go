@@ -0,0 +1,381 @@
+package aes
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// Binary Merkle trees over the short-input hashes of this package.
+//
+// Leaves are hashed with a variable-length hash over a 0x00 byte followed by
+// the leaf data, as in RFC 6962, and internal nodes with a fixed-input 2-to-1
+// compression function applied to the concatenation of their children, so
+// leaf and node digests come from separate domains. A level with an odd
+// number of nodes promotes its last node unchanged to the next level, so a
+// tree over n leaves has ⌈log2(n)⌉ levels above the leaves.
+//
+// The root compresses the top node with the number of leaves. A proof's leaf
+// count is therefore authenticated by the root: it fixes the shape of the
+// tree, and with it the depth at which the proven digest must sit, so an
+// internal node cannot be passed off as a leaf digest.
+//
+// Each level is compressed four node pairs at a time with the batched
+// primitives, and large levels are split across goroutines.
+
+// MerkleHash selects the hash functions of a Merkle tree.
+type MerkleHash int
+
+const (
+	// MerkleHaraka uses 32-byte nodes. Internal nodes are Haraka-512 of the
+	// two children, and leaves are the first 32 bytes of Haraka-S of 0x00
+	// followed by the leaf data.
+	MerkleHaraka MerkleHash = iota
+
+	// MerkleAreion uses 16-byte nodes. Internal nodes are the first 16 bytes
+	// of Areion-256-DM of the two children, and leaves the first 16 bytes of
+	// Areion-512-MD of 0x00 followed by the leaf data.
+	MerkleAreion
+)
+
+// merkleParallelThreshold is the number of work items (leaves or node pairs)
+// below which a level is processed on the calling goroutine.
+var merkleParallelThreshold = 4096
+
+// merkleLeafPrefix is prepended to leaf data before hashing.
+var merkleLeafPrefix = []byte{0x00}
+
+// merkleReaderBatch is the number of chunks NewMerkleTreeFromReader reads
+// and hashes at a time.
+const merkleReaderBatch = 1024
+
+var (
+	errMerkleNoLeaves   = errors.New("aes: Merkle tree has no leaves")
+	errMerkleHash       = errors.New("aes: unknown Merkle hash")
+	errMerkleIndex      = errors.New("aes: Merkle leaf index out of range")
+	errMerkleChunkSize  = errors.New("aes: invalid Merkle chunk size")
+	errMerkleDigestSize = errors.New("aes: invalid Merkle leaf digest size")
+)
+
+// Size returns the node size in bytes, or 0 for an unknown hash.
+func (h MerkleHash) Size() int {
+	switch h {
+	case MerkleHaraka:
+		return 32
+	case MerkleAreion:
+		return 16
+	}
+	return 0
+}
+
+// Leaf returns the leaf digest of data, the hash of 0x00 || data.
+func (h MerkleHash) Leaf(data []byte) []byte {
+	out := make([]byte, h.Size())
+	h.leaf(out, data)
+	return out
+}
+
+func (h MerkleHash) leaf(out, data []byte) {
+	switch h {
+	case MerkleHaraka:
+		s := NewHarakaSWithConstants(nil)
+		s.Write(merkleLeafPrefix)
+		s.Write(data)
+		s.Read(out)
+	case MerkleAreion:
+		var d Areion512MD
+		d.Reset()
+		d.Write(merkleLeafPrefix)
+		d.Write(data)
+		var sum [Areion512MDSize]byte
+		d.Sum(sum[:0])
+		copy(out, sum[:])
+	}
+}
+
+// Node returns the parent of the left and right child digests.
+func (h MerkleHash) Node(left, right []byte) []byte {
+	n := h.Size()
+	in := make([]byte, 2*n)
+	copy(in, left)
+	copy(in[n:], right)
+	out := make([]byte, n)
+	h.compress(out, in, 1)
+	return out
+}
+
+// root writes the root of a tree with the given top node and number of
+// leaves to out: the node of top and a block holding the leaf count as a
+// 64-bit little-endian integer.
+func (h MerkleHash) root(out, top []byte, leaves int) {
+	n := h.Size()
+	in := make([]byte, 2*n)
+	copy(in, top)
+	binary.LittleEndian.PutUint64(in[n:], uint64(leaves))
+	h.compress(out, in, 1)
+}
+
+// compress hashes the pairs node pairs of src into dst.
+func (h MerkleHash) compress(dst, src []byte, pairs int) {
+	switch h {
+	case MerkleHaraka:
+		var in [4][64]byte
+		var out [4][32]byte
+		for pairs >= 4 {
+			for i := range in {
+				copy(in[i][:], src[64*i:])
+			}
+			Haraka512x4(&out, &in)
+			for i := range out {
+				copy(dst[32*i:], out[i][:])
+			}
+			dst, src, pairs = dst[128:], src[256:], pairs-4
+		}
+		for ; pairs > 0; pairs-- {
+			out := Haraka512HW((*[64]byte)(src))
+			copy(dst, out[:])
+			dst, src = dst[32:], src[64:]
+		}
+	case MerkleAreion:
+		var in, out [4][32]byte
+		for pairs >= 4 {
+			for i := range in {
+				copy(in[i][:], src[32*i:])
+			}
+			AreionHash256DMx4(&out, &in)
+			for i := range out {
+				copy(dst[16*i:], out[i][:16])
+			}
+			dst, src, pairs = dst[64:], src[128:], pairs-4
+		}
+		for ; pairs > 0; pairs-- {
+			out := AreionHash256DM((*[32]byte)(src))
+			copy(dst, out[:16])
+			dst, src = dst[16:], src[32:]
+		}
+	}
+}
+
+// merkleParallel calls f on consecutive ranges covering [0, n), in parallel
+// when n is large. Range boundaries are multiples of 4 so that the batched
+// primitives see full batches.
+func merkleParallel(n int, f func(lo, hi int)) {
+	parallelRanges(n, merkleParallelThreshold, f)
+}
+
+// parallelRanges calls f on consecutive ranges covering [0, n), split across
+// goroutines when n is at least threshold. Range boundaries are multiples of
+// 4.
+func parallelRanges(n, threshold int, f func(lo, hi int)) {
+	workers := runtime.GOMAXPROCS(0)
+	if n < threshold || workers < 2 {
+		f(0, n)
+		return
+	}
+	step := ((n+workers-1)/workers + 3) &^ 3
+	var wg sync.WaitGroup
+	for lo := 0; lo < n; lo += step {
+		hi := min(lo+step, n)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f(lo, hi)
+		}()
+	}
+	wg.Wait()
+}
+
+// MerkleTree is a binary Merkle tree that keeps every level, so that
+// authentication paths can be produced for any leaf.
+type MerkleTree struct {
+	hash   MerkleHash
+	levels [][]byte // levels[0] holds the leaf digests, the last level the top node
+	root   []byte
+}
+
+// NewMerkleTree hashes the given leaves and builds a tree over them.
+func NewMerkleTree(h MerkleHash, leaves [][]byte) (*MerkleTree, error) {
+	n := h.Size()
+	if n == 0 {
+		return nil, errMerkleHash
+	}
+	if len(leaves) == 0 {
+		return nil, errMerkleNoLeaves
+	}
+	digests := make([]byte, len(leaves)*n)
+	merkleParallel(len(leaves), func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			h.leaf(digests[i*n:(i+1)*n], leaves[i])
+		}
+	})
+	return newMerkleTree(h, digests), nil
+}
+
+// NewMerkleTreeFromDigests builds a tree over leaf digests that have already
+// been computed, concatenated in digests.
+func NewMerkleTreeFromDigests(h MerkleHash, digests []byte) (*MerkleTree, error) {
+	n := h.Size()
+	if n == 0 {
+		return nil, errMerkleHash
+	}
+	if len(digests) == 0 {
+		return nil, errMerkleNoLeaves
+	}
+	if len(digests)%n != 0 {
+		return nil, errMerkleDigestSize
+	}
+	return newMerkleTree(h, append([]byte(nil), digests...)), nil
+}
+
+// NewMerkleTreeFromReader splits r into chunkSize-byte leaves (the last one
+// may be shorter) and builds a tree over them. Empty input gives a single
+// empty leaf.
+func NewMerkleTreeFromReader(h MerkleHash, r io.Reader, chunkSize int) (*MerkleTree, error) {
+	n := h.Size()
+	if n == 0 {
+		return nil, errMerkleHash
+	}
+	if chunkSize <= 0 {
+		return nil, errMerkleChunkSize
+	}
+
+	var digests []byte
+	buf := make([]byte, merkleReaderBatch*chunkSize)
+	chunks := make([][]byte, 0, merkleReaderBatch)
+	for done := false; !done; {
+		k, err := io.ReadFull(r, buf)
+		switch err {
+		case nil:
+		case io.EOF, io.ErrUnexpectedEOF:
+			done = true
+		default:
+			return nil, err
+		}
+		chunks = chunks[:0]
+		for off := 0; off < k; off += chunkSize {
+			chunks = append(chunks, buf[off:min(off+chunkSize, k)])
+		}
+		if len(chunks) == 0 && len(digests) == 0 {
+			chunks = append(chunks, nil)
+		}
+		base := len(digests)
+		digests = append(digests, make([]byte, len(chunks)*n)...)
+		merkleParallel(len(chunks), func(lo, hi int) {
+			for i := lo; i < hi; i++ {
+				h.leaf(digests[base+i*n:base+(i+1)*n], chunks[i])
+			}
+		})
+	}
+	return newMerkleTree(h, digests), nil
+}
+
+func newMerkleTree(h MerkleHash, digests []byte) *MerkleTree {
+	n := h.Size()
+	t := &MerkleTree{hash: h, levels: [][]byte{digests}}
+	for level := digests; len(level) > n; {
+		nodes := len(level) / n
+		pairs := nodes / 2
+		next := make([]byte, (nodes+1)/2*n)
+		merkleParallel(pairs, func(lo, hi int) {
+			h.compress(next[lo*n:hi*n], level[2*lo*n:2*hi*n], hi-lo)
+		})
+		if nodes%2 == 1 {
+			copy(next[pairs*n:], level[(nodes-1)*n:])
+		}
+		t.levels = append(t.levels, next)
+		level = next
+	}
+	t.root = make([]byte, n)
+	h.root(t.root, t.levels[len(t.levels)-1], len(digests)/n)
+	return t
+}
+
+// Hash returns the hash functions used by the tree.
+func (t *MerkleTree) Hash() MerkleHash { return t.hash }
+
+// Len returns the number of leaves.
+func (t *MerkleTree) Len() int { return len(t.levels[0]) / t.hash.Size() }
+
+// Root returns the root digest, which commits to the number of leaves.
+func (t *MerkleTree) Root() []byte {
+	return append([]byte(nil), t.root...)
+}
+
+// Leaf returns the digest of leaf i.
+func (t *MerkleTree) Leaf(i int) []byte {
+	n := t.hash.Size()
+	return append([]byte(nil), t.levels[0][i*n:(i+1)*n]...)
+}
+
+// MerkleProof is an authentication path for one leaf of a tree.
+type MerkleProof struct {
+	Index  int      // leaf index
+	Leaves int      // number of leaves in the tree, authenticated by the root
+	Path   [][]byte // sibling digests from the leaf level up, skipping promoted nodes
+}
+
+// Proof returns the authentication path of leaf i.
+func (t *MerkleTree) Proof(i int) (*MerkleProof, error) {
+	if i < 0 || i >= t.Len() {
+		return nil, errMerkleIndex
+	}
+	n := t.hash.Size()
+	p := &MerkleProof{Index: i, Leaves: t.Len()}
+	for _, level := range t.levels[:len(t.levels)-1] {
+		if sib := i ^ 1; sib < len(level)/n {
+			p.Path = append(p.Path, append([]byte(nil), level[sib*n:(sib+1)*n]...))
+		}
+		i >>= 1
+	}
+	return p, nil
+}
+
+// VerifyMerkleProof reports whether p proves that leaf is in the tree with
+// the given root.
+func VerifyMerkleProof(h MerkleHash, root, leaf []byte, p *MerkleProof) bool {
+	if h.Size() == 0 {
+		return false
+	}
+	return VerifyMerkleProofDigest(h, root, h.Leaf(leaf), p)
+}
+
+// VerifyMerkleProofDigest is like VerifyMerkleProof, but takes the leaf
+// digest instead of the leaf data. p.Leaves is checked against the root, so
+// the digest is only accepted at the leaf level of a tree of that size.
+func VerifyMerkleProofDigest(h MerkleHash, root, digest []byte, p *MerkleProof) bool {
+	n := h.Size()
+	if n == 0 || len(digest) != n || len(root) != n || p == nil {
+		return false
+	}
+	if p.Index < 0 || p.Index >= p.Leaves {
+		return false
+	}
+
+	node := make([]byte, 2*n)
+	copy(node, digest)
+	path := p.Path
+	for i, nodes := p.Index, p.Leaves; nodes > 1; i, nodes = i>>1, (nodes+1)/2 {
+		if i^1 >= nodes {
+			continue // promoted
+		}
+		if len(path) == 0 || len(path[0]) != n {
+			return false
+		}
+		if i&1 == 1 {
+			copy(node[n:], node[:n])
+			copy(node, path[0])
+		} else {
+			copy(node[n:], path[0])
+		}
+		h.compress(node[:n], node, 1)
+		path = path[1:]
+	}
+	if len(path) != 0 {
+		return false
+	}
+	h.root(node[:n], node[:n], p.Leaves)
+	return subtle.ConstantTimeCompare(node[:n], root) == 1
+}
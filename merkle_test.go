@@ -0,0 +1,242 @@
+package aes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+)
+
+var merkleHashes = []MerkleHash{MerkleHaraka, MerkleAreion}
+
+// merkleRootReference computes the root one node at a time, then binds the
+// leaf count.
+func merkleRootReference(h MerkleHash, leaves [][]byte) []byte {
+	level := make([][]byte, len(leaves))
+	for i, leaf := range leaves {
+		level[i] = h.Leaf(leaf)
+	}
+	for len(level) > 1 {
+		var next [][]byte
+		for i := 0; i+1 < len(level); i += 2 {
+			next = append(next, h.Node(level[i], level[i+1]))
+		}
+		if len(level)%2 == 1 {
+			next = append(next, level[len(level)-1])
+		}
+		level = next
+	}
+	count := make([]byte, h.Size())
+	binary.LittleEndian.PutUint64(count, uint64(len(leaves)))
+	return h.Node(level[0], count)
+}
+
+func merkleTestLeaves(n int) [][]byte {
+	leaves := make([][]byte, n)
+	for i := range leaves {
+		leaves[i] = []byte(fmt.Sprintf("leaf %d", i))
+	}
+	return leaves
+}
+
+func TestMerkleNode(t *testing.T) {
+	var in [64]byte
+	for i := range in {
+		in[i] = byte(i)
+	}
+	want := Haraka512(&in)
+	if got := MerkleHaraka.Node(in[:32], in[32:]); !bytes.Equal(got, want[:]) {
+		t.Errorf("Haraka node mismatch\nGot:      %x\nExpected: %x", got, want)
+	}
+	dm := AreionHash256DM((*[32]byte)(in[:32]))
+	if got := MerkleAreion.Node(in[:16], in[16:32]); !bytes.Equal(got, dm[:16]) {
+		t.Errorf("Areion node mismatch\nGot:      %x\nExpected: %x", got, dm[:16])
+	}
+}
+
+// Leaves are hashed with a 0x00 prefix, as in RFC 6962.
+func TestMerkleLeaf(t *testing.T) {
+	data := []byte("leaf data")
+	prefixed := append([]byte{0}, data...)
+	want := make([]byte, 32)
+	HarakaSSum(want, prefixed, nil)
+	if got := MerkleHaraka.Leaf(data); !bytes.Equal(got, want) {
+		t.Errorf("Haraka leaf mismatch\nGot:      %x\nExpected: %x", got, want)
+	}
+	md := Areion512MDSum(prefixed)
+	if got := MerkleAreion.Leaf(data); !bytes.Equal(got, md[:16]) {
+		t.Errorf("Areion leaf mismatch\nGot:      %x\nExpected: %x", got, md[:16])
+	}
+}
+
+// An internal node must not verify as a leaf digest, and the leaf count of a
+// proof is bound to the root.
+func TestMerkleProofShape(t *testing.T) {
+	for _, h := range merkleHashes {
+		leaves := merkleTestLeaves(4)
+		tree, _ := NewMerkleTree(h, leaves)
+		root := tree.Root()
+		n01 := h.Node(tree.Leaf(0), tree.Leaf(1))
+		n23 := h.Node(tree.Leaf(2), tree.Leaf(3))
+		if VerifyMerkleProofDigest(h, root, n01, &MerkleProof{Index: 0, Leaves: 2, Path: [][]byte{n23}}) {
+			t.Errorf("hash %d: internal node accepted as a leaf digest", h)
+		}
+
+		p, _ := tree.Proof(3)
+		p.Leaves = 5
+		if VerifyMerkleProof(h, root, leaves[3], p) {
+			t.Errorf("hash %d: proof accepted with a wrong leaf count", h)
+		}
+	}
+}
+
+func TestMerkleTreeRootAndProofs(t *testing.T) {
+	for _, h := range merkleHashes {
+		for n := 1; n <= 37; n++ {
+			leaves := merkleTestLeaves(n)
+			tree, err := NewMerkleTree(h, leaves)
+			if err != nil {
+				t.Fatal(err)
+			}
+			root := tree.Root()
+			if want := merkleRootReference(h, leaves); !bytes.Equal(root, want) {
+				t.Fatalf("hash %d, %d leaves: root mismatch\nGot:      %x\nExpected: %x", h, n, root, want)
+			}
+			if tree.Len() != n || len(root) != h.Size() {
+				t.Fatalf("hash %d, %d leaves: bad Len or root size", h, n)
+			}
+
+			for i := range leaves {
+				p, err := tree.Proof(i)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !VerifyMerkleProof(h, root, leaves[i], p) {
+					t.Fatalf("hash %d, %d leaves: proof %d does not verify", h, n, i)
+				}
+				if VerifyMerkleProof(h, root, []byte("other"), p) {
+					t.Fatalf("hash %d, %d leaves: proof %d verifies a wrong leaf", h, n, i)
+				}
+				if len(p.Path) > 0 {
+					p.Path[0][0] ^= 1
+					if VerifyMerkleProof(h, root, leaves[i], p) {
+						t.Fatalf("hash %d, %d leaves: proof %d verifies with a tampered path", h, n, i)
+					}
+					p.Path[0][0] ^= 1
+				}
+				if n > 1 {
+					p.Index = (i + 1) % n
+					if VerifyMerkleProof(h, root, leaves[i], p) {
+						t.Fatalf("hash %d, %d leaves: proof %d verifies at index %d", h, n, i, p.Index)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestMerkleTreeParallel(t *testing.T) {
+	saved := merkleParallelThreshold
+	defer func() { merkleParallelThreshold = saved }()
+
+	leaves := merkleTestLeaves(1001)
+	for _, h := range merkleHashes {
+		merkleParallelThreshold = 1 << 30
+		serial, _ := NewMerkleTree(h, leaves)
+		merkleParallelThreshold = 8
+		parallel, _ := NewMerkleTree(h, leaves)
+		if !bytes.Equal(serial.Root(), parallel.Root()) {
+			t.Errorf("hash %d: parallel root mismatch\nGot:      %x\nExpected: %x", h, parallel.Root(), serial.Root())
+		}
+		if want := merkleRootReference(h, leaves); !bytes.Equal(parallel.Root(), want) {
+			t.Errorf("hash %d: parallel root does not match the reference", h)
+		}
+	}
+}
+
+func TestMerkleTreeFromReader(t *testing.T) {
+	data := make([]byte, 3000)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	for _, h := range merkleHashes {
+		for _, chunkSize := range []int{1, 100, 128, 5000} {
+			var leaves [][]byte
+			for off := 0; off < len(data); off += chunkSize {
+				leaves = append(leaves, data[off:min(off+chunkSize, len(data))])
+			}
+			want, _ := NewMerkleTree(h, leaves)
+			got, err := NewMerkleTreeFromReader(h, bytes.NewReader(data), chunkSize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Len() != len(leaves) || !bytes.Equal(got.Root(), want.Root()) {
+				t.Errorf("hash %d, chunk size %d: reader tree mismatch", h, chunkSize)
+			}
+		}
+
+		empty, err := NewMerkleTreeFromReader(h, bytes.NewReader(nil), 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if empty.Len() != 1 || !bytes.Equal(empty.Leaf(0), h.Leaf(nil)) {
+			t.Errorf("hash %d: empty input should give a single empty leaf", h)
+		}
+	}
+}
+
+func TestMerkleTreeFromDigests(t *testing.T) {
+	leaves := merkleTestLeaves(11)
+	for _, h := range merkleHashes {
+		want, _ := NewMerkleTree(h, leaves)
+		var digests []byte
+		for i := range leaves {
+			digests = append(digests, want.Leaf(i)...)
+		}
+		got, err := NewMerkleTreeFromDigests(h, digests)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got.Root(), want.Root()) {
+			t.Errorf("hash %d: root from digests mismatch", h)
+		}
+		p, _ := got.Proof(3)
+		if !VerifyMerkleProofDigest(h, got.Root(), want.Leaf(3), p) {
+			t.Errorf("hash %d: digest proof does not verify", h)
+		}
+	}
+}
+
+func TestMerkleTreeErrors(t *testing.T) {
+	if _, err := NewMerkleTree(MerkleHaraka, nil); err == nil {
+		t.Error("expected an error for an empty tree")
+	}
+	if _, err := NewMerkleTree(MerkleHash(99), merkleTestLeaves(2)); err == nil {
+		t.Error("expected an error for an unknown hash")
+	}
+	if _, err := NewMerkleTreeFromDigests(MerkleAreion, make([]byte, 17)); err == nil {
+		t.Error("expected an error for a truncated digest")
+	}
+	if _, err := NewMerkleTreeFromReader(MerkleAreion, bytes.NewReader(nil), 0); err == nil {
+		t.Error("expected an error for a zero chunk size")
+	}
+	tree, _ := NewMerkleTree(MerkleAreion, merkleTestLeaves(3))
+	if _, err := tree.Proof(3); err == nil {
+		t.Error("expected an error for an out-of-range leaf")
+	}
+	if VerifyMerkleProof(MerkleAreion, tree.Root(), []byte("leaf 0"), nil) {
+		t.Error("nil proof should not verify")
+	}
+}
+
+func BenchmarkMerkleTree(b *testing.B) {
+	for _, h := range merkleHashes {
+		digests := make([]byte, 1<<16*h.Size())
+		b.Run(fmt.Sprintf("hash=%d", h), func(b *testing.B) {
+			b.SetBytes(int64(len(digests)))
+			for i := 0; i < b.N; i++ {
+				NewMerkleTreeFromDigests(h, digests)
+			}
+		})
+	}
+}
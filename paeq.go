@@ -0,0 +1,229 @@
+package aes
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+)
+
+// PAEQ authenticated encryption (Biryukov and Khovratovich, "PAEQ:
+// Parallelizable Permutation-Based Authenticated Encryption", ISC 2014) over
+// the AESQ permutation.
+//
+// PAEQ-k uses a k-bit key K and a k-bit nonce N. Message blocks Mi of
+// 64-|K| bytes are processed independently:
+//
+//	Vi = AESQ(D(i, d) || N || K)
+//	Ci = Mi ⊕ Vi[:64-|K|]
+//	Ti = AESQ(Ci || Vi[64-|K|:] ⊕ K)[:t]
+//
+// D(i, d) fills the remaining 64-2|K| bytes with the little-endian block
+// counter i and ends with a domain byte d. A shorter last block is padded
+// with 10* before the second call, and marked by its domain. Associated data
+// blocks Aj of 56-|K| bytes are absorbed as
+//
+//	Tj = AESQ(Aj || D8(j, d) || K)[:t]
+//
+// with an 8-byte counter block D8. The tag is K ⊕ (T0 ⊕ ΣTi ⊕ ΣTj), where
+// T0 = AESQ(D(0, 0) || N || K)[:t] binds the nonce even to empty messages.
+//
+// The t variants have a 256-bit tag. PAEQ-128tnm adds the associated data
+// hash ΣTj to the nonce before encrypting, so that repeating a nonce with
+// different associated data does not repeat the key stream.
+
+// PAEQVariant selects a PAEQ parameter set.
+type PAEQVariant int
+
+const (
+	// PAEQ64 uses a 64-bit key, nonce and tag.
+	PAEQ64 PAEQVariant = iota
+
+	// PAEQ80 uses an 80-bit key, nonce and tag.
+	PAEQ80
+
+	// PAEQ128 uses a 128-bit key, nonce and tag.
+	PAEQ128
+
+	// PAEQ128t uses a 128-bit key and nonce and a 256-bit tag.
+	PAEQ128t
+
+	// PAEQ128tnm is PAEQ128t with the associated data mixed into the nonce.
+	PAEQ128tnm
+)
+
+// Domain bytes of the counter blocks.
+const (
+	paeqDomainNonce      = 0
+	paeqDomainMessage    = 1
+	paeqDomainMessageEnd = 2
+	paeqDomainAD         = 3
+	paeqDomainADEnd      = 4
+)
+
+type paeqParams struct {
+	keySize, tagSize int
+	nonceMisuse      bool
+}
+
+var paeqVariants = [...]paeqParams{
+	PAEQ64:     {8, 8, false},
+	PAEQ80:     {10, 10, false},
+	PAEQ128:    {16, 16, false},
+	PAEQ128t:   {16, 32, false},
+	PAEQ128tnm: {16, 32, true},
+}
+
+var errPAEQOpen = errors.New("aes: PAEQ message authentication failed")
+
+// paeq implements cipher.AEAD. It only holds the key and is safe for
+// concurrent use.
+type paeq struct {
+	paeqParams
+	key [16]byte
+}
+
+// NewPAEQ returns the given PAEQ variant keyed with key, whose size must be
+// the variant's key size.
+func NewPAEQ(variant PAEQVariant, key []byte) (cipher.AEAD, error) {
+	if variant < PAEQ64 || variant > PAEQ128tnm {
+		return nil, errors.New("aes: unknown PAEQ variant")
+	}
+	p := &paeq{paeqParams: paeqVariants[variant]}
+	if len(key) != p.keySize {
+		return nil, errors.New("aes: invalid PAEQ key size")
+	}
+	copy(p.key[:], key)
+	return p, nil
+}
+
+// NonceSize returns the nonce size in bytes, which equals the key size.
+func (p *paeq) NonceSize() int { return p.keySize }
+
+// Overhead returns the tag size in bytes.
+func (p *paeq) Overhead() int { return p.tagSize }
+
+// counterBlock sets s to D(i, d) || N || K.
+func (p *paeq) counterBlock(s *AESQ, nonce []byte, i uint64, d byte) {
+	k := p.keySize
+	clear(s[:])
+	binary.LittleEndian.PutUint64(s[:8], i)
+	s[64-2*k-1] = d
+	copy(s[64-2*k:], nonce)
+	copy(s[64-k:], p.key[:k])
+}
+
+// absorbAD adds the associated data hash to tag.
+func (p *paeq) absorbAD(tag, ad []byte) {
+	k := p.keySize
+	bs := 56 - k
+	var s AESQ
+	for j := uint64(1); len(ad) > 0; j++ {
+		n := min(len(ad), bs)
+		clear(s[:])
+		copy(s[:], ad[:n])
+		d := byte(paeqDomainAD)
+		if n < bs {
+			s[n] = 0x01
+			d = paeqDomainADEnd
+		}
+		binary.LittleEndian.PutUint64(s[bs:], j)
+		s[bs+7] = d
+		copy(s[64-k:], p.key[:k])
+		s.Permute()
+		subtle.XORBytes(tag, tag, s[:p.tagSize])
+		ad = ad[n:]
+	}
+}
+
+// crypt encrypts or decrypts src into dst and adds the message blocks to tag.
+func (p *paeq) crypt(dst, src, nonce, tag []byte, decrypt bool) {
+	k := p.keySize
+	bs := 64 - k
+	var v, w AESQ
+
+	p.counterBlock(&v, nonce, 0, paeqDomainNonce)
+	v.Permute()
+	subtle.XORBytes(tag, tag, v[:p.tagSize])
+
+	for i := uint64(1); len(src) > 0; i++ {
+		n := min(len(src), bs)
+		d := byte(paeqDomainMessage)
+		if n < bs {
+			d = paeqDomainMessageEnd
+		}
+		p.counterBlock(&v, nonce, i, d)
+		v.Permute()
+
+		clear(w[:])
+		if decrypt {
+			copy(w[:], src[:n])
+		}
+		subtle.XORBytes(dst[:n], src[:n], v[:n])
+		if !decrypt {
+			copy(w[:], dst[:n])
+		}
+		if n < bs {
+			w[n] = 0x01
+		}
+		subtle.XORBytes(w[bs:], v[bs:], p.key[:k])
+		w.Permute()
+		subtle.XORBytes(tag, tag, w[:p.tagSize])
+
+		dst, src = dst[n:], src[n:]
+	}
+}
+
+// seal computes the tag of the message into tag, encrypting or decrypting src
+// into dst on the way.
+func (p *paeq) seal(tag, dst, src, nonce, ad []byte, decrypt bool) {
+	p.absorbAD(tag, ad)
+	if p.nonceMisuse {
+		var n [16]byte
+		subtle.XORBytes(n[:p.keySize], nonce, tag[:p.keySize])
+		nonce = n[:p.keySize]
+	}
+	p.crypt(dst, src, nonce, tag, decrypt)
+	subtle.XORBytes(tag[:p.keySize], tag[:p.keySize], p.key[:p.keySize])
+}
+
+// Seal encrypts and authenticates plaintext, authenticates additionalData,
+// and appends the result to dst.
+func (p *paeq) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != p.keySize {
+		panic("aes: incorrect nonce length given to PAEQ")
+	}
+	ret, out := sliceForAppend(dst, len(plaintext)+p.tagSize)
+	if inexactOverlap(out, plaintext) {
+		panic("aes: invalid buffer overlap")
+	}
+	tag := out[len(plaintext):]
+	clear(tag)
+	p.seal(tag, out[:len(plaintext)], plaintext, nonce, additionalData, false)
+	return ret
+}
+
+// Open authenticates and decrypts ciphertext, authenticates additionalData,
+// and appends the resulting plaintext to dst.
+func (p *paeq) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != p.keySize {
+		panic("aes: incorrect nonce length given to PAEQ")
+	}
+	if len(ciphertext) < p.tagSize {
+		return nil, errPAEQOpen
+	}
+	tag := ciphertext[len(ciphertext)-p.tagSize:]
+	ciphertext = ciphertext[:len(ciphertext)-p.tagSize]
+
+	ret, out := sliceForAppend(dst, len(ciphertext))
+	if inexactOverlap(out, ciphertext) {
+		panic("aes: invalid buffer overlap")
+	}
+	var expected [32]byte
+	p.seal(expected[:p.tagSize], out, ciphertext, nonce, additionalData, true)
+	if subtle.ConstantTimeCompare(expected[:p.tagSize], tag) != 1 {
+		clear(out)
+		return nil, errPAEQOpen
+	}
+	return ret, nil
+}
@@ -0,0 +1,242 @@
+package aes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+var paeqTestVariants = []PAEQVariant{PAEQ64, PAEQ80, PAEQ128, PAEQ128t, PAEQ128tnm}
+
+// paeqReference transcribes the PAEQ equations one block at a time.
+func paeqReference(v PAEQVariant, key, nonce, plaintext, ad []byte) []byte {
+	params := paeqVariants[v]
+	k, tl := params.keySize, params.tagSize
+	perm := func(in []byte) AESQ {
+		var s AESQ
+		copy(s[:], in)
+		aesqReference(&s)
+		return s
+	}
+	counter := func(n []byte, i uint64, d byte) []byte {
+		b := make([]byte, 64-2*k)
+		for j := range 8 {
+			b[j] = byte(i >> (8 * j))
+		}
+		b[len(b)-1] = d
+		return append(append(b, n...), key...)
+	}
+
+	tag := make([]byte, tl)
+	xorTag := func(s AESQ) {
+		for i := range tag {
+			tag[i] ^= s[i]
+		}
+	}
+
+	for j := uint64(1); len(ad) > 0; j++ {
+		n := min(len(ad), 56-k)
+		blk := make([]byte, 56-k)
+		copy(blk, ad[:n])
+		d := byte(3)
+		if n < 56-k {
+			blk[n] = 1
+			d = 4
+		}
+		ctr := []byte{byte(j), byte(j >> 8), byte(j >> 16), byte(j >> 24), byte(j >> 32), byte(j >> 40), byte(j >> 48), d}
+		xorTag(perm(append(append(blk, ctr...), key...)))
+		ad = ad[n:]
+	}
+	if params.nonceMisuse {
+		n := bytes.Clone(nonce)
+		for i := range n {
+			n[i] ^= tag[i]
+		}
+		nonce = n
+	}
+
+	xorTag(perm(counter(nonce, 0, 0)))
+	var ct []byte
+	for i := uint64(1); len(plaintext) > 0; i++ {
+		n := min(len(plaintext), 64-k)
+		d := byte(1)
+		if n < 64-k {
+			d = 2
+		}
+		vi := perm(counter(nonce, i, d))
+		c := make([]byte, n)
+		for j := range c {
+			c[j] = plaintext[j] ^ vi[j]
+		}
+		ct = append(ct, c...)
+		w := make([]byte, 64)
+		copy(w, c)
+		if n < 64-k {
+			w[n] = 1
+		}
+		for j := range k {
+			w[64-k+j] = vi[64-k+j] ^ key[j]
+		}
+		xorTag(perm(w))
+		plaintext = plaintext[n:]
+	}
+	for i := range k {
+		tag[i] ^= key[i]
+	}
+	return append(ct, tag...)
+}
+
+func paeqTestInputs(v PAEQVariant) (key, nonce, msg, ad []byte) {
+	k := paeqVariants[v].keySize
+	key = make([]byte, k)
+	nonce = make([]byte, k)
+	for i := range key {
+		key[i] = byte(i)
+		nonce[i] = byte(0xa0 + i)
+	}
+	msg = make([]byte, 300)
+	ad = make([]byte, 150)
+	for i := range msg {
+		msg[i] = byte(i * 3)
+	}
+	for i := range ad {
+		ad[i] = byte(i * 5)
+	}
+	return
+}
+
+func TestPAEQMatchesReference(t *testing.T) {
+	for _, v := range paeqTestVariants {
+		key, nonce, msg, ad := paeqTestInputs(v)
+		a, err := NewPAEQ(v, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, mlen := range []int{0, 1, 47, 48, 49, 54, 56, 57, 112, 300} {
+			for _, adlen := range []int{0, 1, 40, 46, 48, 49, 150} {
+				want := paeqReference(v, key, nonce, msg[:mlen], ad[:adlen])
+				got := a.Seal(nil, nonce, msg[:mlen], ad[:adlen])
+				if !bytes.Equal(got, want) {
+					t.Fatalf("variant %d, mlen=%d adlen=%d: got %x, expected %x", v, mlen, adlen, got, want)
+				}
+				pt, err := a.Open(nil, nonce, got, ad[:adlen])
+				if err != nil || !bytes.Equal(pt, msg[:mlen]) {
+					t.Fatalf("variant %d, mlen=%d adlen=%d: Open failed: %v", v, mlen, adlen, err)
+				}
+			}
+		}
+	}
+}
+
+func TestPAEQInPlace(t *testing.T) {
+	for _, v := range paeqTestVariants {
+		key, nonce, msg, ad := paeqTestInputs(v)
+		a, _ := NewPAEQ(v, key)
+		want := a.Seal(nil, nonce, msg, ad)
+
+		buf := make([]byte, len(msg), len(msg)+a.Overhead())
+		copy(buf, msg)
+		ct := a.Seal(buf[:0], nonce, buf, ad)
+		if !bytes.Equal(ct, want) {
+			t.Fatalf("variant %d: in-place Seal mismatch", v)
+		}
+		pt, err := a.Open(ct[:0], nonce, ct, ad)
+		if err != nil || !bytes.Equal(pt, msg) {
+			t.Fatalf("variant %d: in-place Open failed: %v", v, err)
+		}
+	}
+}
+
+func TestPAEQTamper(t *testing.T) {
+	for _, v := range paeqTestVariants {
+		key, nonce, msg, ad := paeqTestInputs(v)
+		a, _ := NewPAEQ(v, key)
+		ct := a.Seal(nil, nonce, msg[:100], ad[:20])
+
+		for _, i := range []int{0, 50, 99, 100, len(ct) - 1} {
+			bad := bytes.Clone(ct)
+			bad[i] ^= 1
+			if _, err := a.Open(nil, nonce, bad, ad[:20]); err == nil {
+				t.Errorf("variant %d: modified byte %d accepted", v, i)
+			}
+		}
+		badAD := bytes.Clone(ad[:20])
+		badAD[3] ^= 1
+		if _, err := a.Open(nil, nonce, ct, badAD); err == nil {
+			t.Errorf("variant %d: modified associated data accepted", v)
+		}
+		badNonce := bytes.Clone(nonce)
+		badNonce[0] ^= 1
+		if _, err := a.Open(nil, badNonce, ct, ad[:20]); err == nil {
+			t.Errorf("variant %d: modified nonce accepted", v)
+		}
+		if _, err := a.Open(nil, nonce, ct[:a.Overhead()-1], nil); err == nil {
+			t.Errorf("variant %d: short ciphertext accepted", v)
+		}
+	}
+}
+
+func TestPAEQNonceMisuse(t *testing.T) {
+	key, nonce, msg, _ := paeqTestInputs(PAEQ128tnm)
+	nm, _ := NewPAEQ(PAEQ128tnm, key)
+	c1 := nm.Seal(nil, nonce, msg[:64], []byte("header 1"))
+	c2 := nm.Seal(nil, nonce, msg[:64], []byte("header 2"))
+	if bytes.Equal(c1[:48], c2[:48]) {
+		t.Error("PAEQ-128tnm repeated the key stream for different associated data")
+	}
+
+	plain, _ := NewPAEQ(PAEQ128t, key)
+	c1 = plain.Seal(nil, nonce, msg[:64], []byte("header 1"))
+	c2 = plain.Seal(nil, nonce, msg[:64], []byte("header 2"))
+	if !bytes.Equal(c1[:48], c2[:48]) {
+		t.Error("PAEQ-128t key stream depends on the associated data")
+	}
+}
+
+func TestPAEQErrors(t *testing.T) {
+	if _, err := NewPAEQ(PAEQ128, make([]byte, 15)); err == nil {
+		t.Error("wrong key size accepted")
+	}
+	if _, err := NewPAEQ(PAEQ128tnm+1, make([]byte, 16)); err == nil {
+		t.Error("unknown variant accepted")
+	}
+	for _, v := range paeqTestVariants {
+		a, err := NewPAEQ(v, make([]byte, paeqVariants[v].keySize))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.NonceSize() != paeqVariants[v].keySize || a.Overhead() != paeqVariants[v].tagSize {
+			t.Errorf("variant %d: unexpected sizes %d, %d", v, a.NonceSize(), a.Overhead())
+		}
+	}
+}
+
+// Regression values; not yet checked against the CAESAR submission KATs.
+func TestPAEQKnownAnswers(t *testing.T) {
+	expected := map[PAEQVariant]string{
+		PAEQ64:     "c34e3426d8de73539b1bc7865d2ac2dd987450199ba807c1b14ab942260b49c82e91c9face4ee005",
+		PAEQ80:     "64573d3852cfb66e3707d23ac6f0e03004cae57d19612ad02a2571238d194087adc5fbc79c0652bad555",
+		PAEQ128:    "2d18db8f430a8a5ca7d7b93e66071b7e1ed04ef69c5ce46948506739c34e6c8c5cac4d9f5b8b21cccfb677393c130ab1",
+		PAEQ128t:   "2d18db8f430a8a5ca7d7b93e66071b7e1ed04ef69c5ce46948506739c34e6c8c5cac4d9f5b8b21cccfb677393c130ab1842281b228b6711af0499aa0a43a2d95",
+		PAEQ128tnm: "ae889c6e38e342887512e6c7b92f184d5f4d81e9c09bc7cdf66341f49402e2de53d4f9b805c1e12b8bb1cd20489e0ff8c393730b7f024575003ba05991c85377",
+	}
+	for _, v := range paeqTestVariants {
+		key, nonce, msg, ad := paeqTestInputs(v)
+		a, _ := NewPAEQ(v, key)
+		got := a.Seal(nil, nonce, msg[:32], ad[:16])
+		if hex.EncodeToString(got) != expected[v] {
+			t.Errorf("variant %d: got %x, expected %s", v, got, expected[v])
+		}
+	}
+}
+
+func BenchmarkPAEQ128(b *testing.B) {
+	a, _ := NewPAEQ(PAEQ128, make([]byte, 16))
+	nonce := make([]byte, 16)
+	msg := make([]byte, 4096)
+	out := make([]byte, 0, len(msg)+a.Overhead())
+	b.SetBytes(int64(len(msg)))
+	for i := 0; i < b.N; i++ {
+		a.Seal(out, nonce, msg, nil)
+	}
+}
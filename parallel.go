@@ -338,3 +338,83 @@ func InvFinalRoundNoKey4(blocks *Block4) {
 	InvFinalRoundNoKey(b2)
 	InvFinalRoundNoKey(b3)
 }
+
+// SubBytes2 applies the AES S-box to 2 blocks (software)
+func SubBytes2(blocks *Block2) {
+	b0, b1 := block2Ptrs(blocks)
+	SubBytes(b0)
+	SubBytes(b1)
+}
+
+// SubBytes4 applies the AES S-box to 4 blocks (software)
+func SubBytes4(blocks *Block4) {
+	b0, b1, b2, b3 := block4Ptrs(blocks)
+	SubBytes(b0)
+	SubBytes(b1)
+	SubBytes(b2)
+	SubBytes(b3)
+}
+
+// InvSubBytes2 applies the inverse AES S-box to 2 blocks (software)
+func InvSubBytes2(blocks *Block2) {
+	b0, b1 := block2Ptrs(blocks)
+	InvSubBytes(b0)
+	InvSubBytes(b1)
+}
+
+// InvSubBytes4 applies the inverse AES S-box to 4 blocks (software)
+func InvSubBytes4(blocks *Block4) {
+	b0, b1, b2, b3 := block4Ptrs(blocks)
+	InvSubBytes(b0)
+	InvSubBytes(b1)
+	InvSubBytes(b2)
+	InvSubBytes(b3)
+}
+
+// ShiftRows2 applies ShiftRows to 2 blocks (software)
+func ShiftRows2(blocks *Block2) {
+	b0, b1 := block2Ptrs(blocks)
+	ShiftRows(b0)
+	ShiftRows(b1)
+}
+
+// ShiftRows4 applies ShiftRows to 4 blocks (software)
+func ShiftRows4(blocks *Block4) {
+	b0, b1, b2, b3 := block4Ptrs(blocks)
+	ShiftRows(b0)
+	ShiftRows(b1)
+	ShiftRows(b2)
+	ShiftRows(b3)
+}
+
+// InvShiftRows2 applies InvShiftRows to 2 blocks (software)
+func InvShiftRows2(blocks *Block2) {
+	b0, b1 := block2Ptrs(blocks)
+	InvShiftRows(b0)
+	InvShiftRows(b1)
+}
+
+// InvShiftRows4 applies InvShiftRows to 4 blocks (software)
+func InvShiftRows4(blocks *Block4) {
+	b0, b1, b2, b3 := block4Ptrs(blocks)
+	InvShiftRows(b0)
+	InvShiftRows(b1)
+	InvShiftRows(b2)
+	InvShiftRows(b3)
+}
+
+// MixColumns2 applies MixColumns to 2 blocks (software)
+func MixColumns2(blocks *Block2) {
+	b0, b1 := block2Ptrs(blocks)
+	MixColumns(b0)
+	MixColumns(b1)
+}
+
+// MixColumns4 applies MixColumns to 4 blocks (software)
+func MixColumns4(blocks *Block4) {
+	b0, b1, b2, b3 := block4Ptrs(blocks)
+	MixColumns(b0)
+	MixColumns(b1)
+	MixColumns(b2)
+	MixColumns(b3)
+}
@@ -0,0 +1,157 @@
+package aes
+
+// Permutation is a public permutation together with its state. Permute and
+// InversePermute update the state in place; Bytes returns the state itself,
+// so that constructions such as Sponge and Duplex can absorb into it and
+// read from it directly.
+//
+// The permutation state types of this package (Areion256, Areion512, AESQ
+// and the Simpira types) implement Permutation. Keyed permutations return a
+// fresh state with NewState.
+type Permutation interface {
+	// Size returns the state size in bytes.
+	Size() int
+
+	// Bytes returns the state. Changes to the returned slice change the state.
+	Bytes() []byte
+
+	// Permute applies the permutation to the state.
+	Permute()
+
+	// InversePermute applies the inverse permutation to the state.
+	InversePermute()
+}
+
+var (
+	_ Permutation = (*Areion256)(nil)
+	_ Permutation = (*Areion512)(nil)
+	_ Permutation = (*AESQ)(nil)
+	_ Permutation = (*Simpira1)(nil)
+	_ Permutation = (*Simpira2)(nil)
+	_ Permutation = (*Simpira3)(nil)
+	_ Permutation = (*Simpira4)(nil)
+	_ Permutation = (*Simpira6)(nil)
+	_ Permutation = (*Simpira8)(nil)
+)
+
+// Size returns 32.
+func (state *Areion256) Size() int { return len(state) }
+
+// Bytes returns the state.
+func (state *Areion256) Bytes() []byte { return state[:] }
+
+// Size returns 64.
+func (state *Areion512) Size() int { return len(state) }
+
+// Bytes returns the state.
+func (state *Areion512) Bytes() []byte { return state[:] }
+
+// Size returns 64.
+func (state *AESQ) Size() int { return len(state) }
+
+// Bytes returns the state.
+func (state *AESQ) Bytes() []byte { return state[:] }
+
+// Size returns 16.
+func (s *Simpira1) Size() int { return len(s) }
+
+// Bytes returns the state.
+func (s *Simpira1) Bytes() []byte { return s[:] }
+
+// Size returns 32.
+func (s *Simpira2) Size() int { return len(s) }
+
+// Bytes returns the state.
+func (s *Simpira2) Bytes() []byte { return s[:] }
+
+// Size returns 48.
+func (s *Simpira3) Size() int { return len(s) }
+
+// Bytes returns the state.
+func (s *Simpira3) Bytes() []byte { return s[:] }
+
+// Size returns 64.
+func (s *Simpira4) Size() int { return len(s) }
+
+// Bytes returns the state.
+func (s *Simpira4) Bytes() []byte { return s[:] }
+
+// Size returns 96.
+func (s *Simpira6) Size() int { return len(s) }
+
+// Bytes returns the state.
+func (s *Simpira6) Bytes() []byte { return s[:] }
+
+// Size returns 128.
+func (s *Simpira8) Size() int { return len(s) }
+
+// Bytes returns the state.
+func (s *Simpira8) Bytes() []byte { return s[:] }
+
+// harakaState256 is a π256 state bound to its round constants.
+type harakaState256 struct {
+	p     *HarakaPermutation256
+	state [32]byte
+}
+
+func (s *harakaState256) Size() int       { return len(s.state) }
+func (s *harakaState256) Bytes() []byte   { return s.state[:] }
+func (s *harakaState256) Permute()        { s.p.Permute(&s.state) }
+func (s *harakaState256) InversePermute() { s.p.InversePermute(&s.state) }
+
+// harakaState512 is a π512 state bound to its round constants.
+type harakaState512 struct {
+	p     *HarakaPermutation512
+	state [64]byte
+}
+
+func (s *harakaState512) Size() int       { return len(s.state) }
+func (s *harakaState512) Bytes() []byte   { return s.state[:] }
+func (s *harakaState512) Permute()        { s.p.Permute(&s.state) }
+func (s *harakaState512) InversePermute() { s.p.InversePermute(&s.state) }
+
+// NewState returns a zero π256 state that implements Permutation.
+func (p *HarakaPermutation256) NewState() Permutation {
+	return &harakaState256{p: p}
+}
+
+// NewState returns a zero π512 state that implements Permutation.
+func (p *HarakaPermutation512) NewState() Permutation {
+	return &harakaState512{p: p}
+}
+
+// pholkosState256 is a Pholkos-256 block with a fixed key and tweak.
+type pholkosState256 struct {
+	ctx   *Pholkos256Context
+	state Pholkos256Block
+}
+
+func (s *pholkosState256) Size() int       { return len(s.state) }
+func (s *pholkosState256) Bytes() []byte   { return s.state[:] }
+func (s *pholkosState256) Permute()        { s.ctx.EncryptHW(&s.state) }
+func (s *pholkosState256) InversePermute() { s.ctx.DecryptHW(&s.state) }
+
+// pholkosState512 is a Pholkos-512 block with a fixed key and tweak.
+type pholkosState512 struct {
+	ctx   *Pholkos512Context
+	state Pholkos512Block
+}
+
+func (s *pholkosState512) Size() int       { return len(s.state) }
+func (s *pholkosState512) Bytes() []byte   { return s.state[:] }
+func (s *pholkosState512) Permute()        { s.ctx.EncryptHW(&s.state) }
+func (s *pholkosState512) InversePermute() { s.ctx.DecryptHW(&s.state) }
+
+// NewState returns a zero block that implements Permutation, with Permute
+// encrypting under the context's key and tweak and InversePermute
+// decrypting. With a public key, this is a fixed-key permutation.
+func (ctx *Pholkos256Context) NewState() Permutation {
+	return &pholkosState256{ctx: ctx}
+}
+
+// NewState returns a zero block that implements Permutation, with Permute
+// encrypting under the context's key and tweak and InversePermute
+// decrypting. With a public key, this is a fixed-key permutation.
+func (ctx *Pholkos512Context) NewState() Permutation {
+	return &pholkosState512{ctx: ctx}
+}
@@ -0,0 +1,84 @@
+package aes
+
+import (
+	"bytes"
+	"testing"
+)
+
+// testPermutations returns a new state of every Permutation implementation.
+func testPermutations() map[string]Permutation {
+	var pkey Pholkos256Key
+	var tweak PholkosTweak
+	return map[string]Permutation{
+		"Areion256":  new(Areion256),
+		"Areion512":  new(Areion512),
+		"AESQ":       new(AESQ),
+		"Simpira1":   new(Simpira1),
+		"Simpira2":   new(Simpira2),
+		"Simpira3":   new(Simpira3),
+		"Simpira4":   new(Simpira4),
+		"Simpira6":   new(Simpira6),
+		"Simpira8":   new(Simpira8),
+		"Haraka256":  NewHarakaPermutation256(nil).NewState(),
+		"Haraka512":  NewHarakaPermutation512(nil).NewState(),
+		"Pholkos256": NewPholkos256Context(&pkey, &tweak).NewState(),
+		"Pholkos512": NewPholkos512Context(&pkey, &tweak).NewState(),
+	}
+}
+
+func TestPermutationInterface(t *testing.T) {
+	sizes := map[string]int{
+		"Areion256": 32, "Areion512": 64, "AESQ": 64,
+		"Simpira1": 16, "Simpira2": 32, "Simpira3": 48, "Simpira4": 64, "Simpira6": 96, "Simpira8": 128,
+		"Haraka256": 32, "Haraka512": 64, "Pholkos256": 32, "Pholkos512": 64,
+	}
+	for name, p := range testPermutations() {
+		if p.Size() != sizes[name] || len(p.Bytes()) != sizes[name] {
+			t.Errorf("%s: size %d, %d bytes, expected %d", name, p.Size(), len(p.Bytes()), sizes[name])
+			continue
+		}
+		state := p.Bytes()
+		for i := range state {
+			state[i] = byte(i)
+		}
+		orig := bytes.Clone(state)
+		p.Permute()
+		if bytes.Equal(p.Bytes(), orig) {
+			t.Errorf("%s: Permute did not change the state", name)
+		}
+		p.InversePermute()
+		if !bytes.Equal(p.Bytes(), orig) {
+			t.Errorf("%s: InversePermute(Permute(x)) != x", name)
+		}
+	}
+}
+
+func TestPermutationAdapters(t *testing.T) {
+	var in [64]byte
+	for i := range in {
+		in[i] = byte(i * 5)
+	}
+
+	hp := NewHarakaPermutation512(nil)
+	want := in
+	hp.Permute(&want)
+	s := hp.NewState()
+	copy(s.Bytes(), in[:])
+	s.Permute()
+	if !bytes.Equal(s.Bytes(), want[:]) {
+		t.Error("Haraka512 state does not match HarakaPermutation512")
+	}
+
+	var key Pholkos256Key
+	var tweak PholkosTweak
+	key[0], tweak[0] = 1, 2
+	ctx := NewPholkos512Context(&key, &tweak)
+	block := Pholkos512Block(in)
+	ctx.Encrypt(&block)
+	s = ctx.NewState()
+	copy(s.Bytes(), in[:])
+	s.Permute()
+	if !bytes.Equal(s.Bytes(), block[:]) {
+		t.Error("Pholkos512 state does not match Pholkos512Context.Encrypt")
+	}
+}
@@ -90,6 +90,11 @@ type Pholkos256Context struct {
 	// Round tweakeys: 8 steps × 2 rounds + 1 = 17 round tweakeys
 	// Each round tweakey has 2 substates (256 bits total)
 	rtk [17][2]Block
+	// Round tweakeys for the same key and an all-zero tweak, used to derive
+	// the round tweakeys of other tweaks without running the key schedule
+	krk [17][2]Block
+	// Tweak used to derive rtk
+	tweak PholkosTweak
 }
 
 // Pholkos512Context holds precomputed round tweakeys for Pholkos-512 encryption.
@@ -97,6 +102,10 @@ type Pholkos512Context struct {
 	// Round tweakeys: 10 steps × 2 rounds + 1 = 21 round tweakeys
 	// Each round tweakey has 4 substates (512 bits total)
 	rtk [21][4]Block
+	// Round tweakeys for the same key and an all-zero tweak
+	krk [21][4]Block
+	// Tweak used to derive rtk
+	tweak PholkosTweak
 }
 
 // NewPholkos256Context creates a new Pholkos-256 context with precomputed round tweakeys.
@@ -110,6 +119,7 @@ func NewPholkos256Context(key *Pholkos256Key, tweak *PholkosTweak) *Pholkos256Co
 func (ctx *Pholkos256Context) Schedule(key *Pholkos256Key, tweak *PholkosTweak) {
 	// Number of rounds = steps × 2 = 16
 	numRounds := pholkos256Steps * 2
+	ctx.tweak = *tweak
 
 	// Initialize key state K⁰ (no expansion needed for 256-bit key)
 	var keyState [2]Block
@@ -124,11 +134,12 @@ func (ctx *Pholkos256Context) Schedule(key *Pholkos256Key, tweak *PholkosTweak)
 	for i := range numRounds + 1 {
 		// γ function: RTK = K ⊕ T, with RC added to first substate
 		// Tweak is XORed to each substate (T is 128-bit, repeated to each 128-bit substate)
+		ctx.krk[i] = keyState
+		// Add round constant to first substate only
+		XorBlock(&ctx.krk[i][0], &ctx.krk[i][0], (*Block)(&pholkosRoundConstants[i]))
 		for j := range 2 {
-			XorBlock(&ctx.rtk[i][j], &keyState[j], &tweakState)
+			XorBlock(&ctx.rtk[i][j], &ctx.krk[i][j], &tweakState)
 		}
-		// Add round constant to first substate only
-		XorBlock(&ctx.rtk[i][0], &ctx.rtk[i][0], (*Block)(&pholkosRoundConstants[i]))
 
 		// Update key and tweak states for next round
 		if i < numRounds {
@@ -157,6 +168,20 @@ func (ctx *Pholkos256Context) Retweak(key *Pholkos256Key, tweak *PholkosTweak) {
 	ctx.Schedule(key, tweak)
 }
 
+// setTweak derives the round tweakeys for tweak from krk, the round tweakeys
+// of the same key with an all-zero tweak. The tweak schedule is linear and
+// independent of the key, so this avoids re-running the key schedule.
+// ctx.krk is left unset; the result is only meant for single-block calls.
+func (ctx *Pholkos256Context) setTweak(krk *[17][2]Block, tweak *PholkosTweak) {
+	ctx.tweak = *tweak
+	t := Block(*tweak)
+	for i := range ctx.rtk {
+		XorBlock(&ctx.rtk[i][0], &krk[i][0], &t)
+		XorBlock(&ctx.rtk[i][1], &krk[i][1], &t)
+		applyTau(&t)
+	}
+}
+
 // pholkos256PermuteWords applies the word-wise permutation π256 to the key state.
 func pholkos256PermuteWords(state *[2]Block) {
 	// State has 8 words (32-bit each): 4 in each substate
@@ -380,6 +405,7 @@ func (ctx *Pholkos512Context) Schedule512(key *Pholkos512Key, tweak *PholkosTwea
 
 func (ctx *Pholkos512Context) schedule(key *Pholkos512Key, tweak *PholkosTweak) {
 	numRounds := pholkos512Steps * 2
+	ctx.tweak = *tweak
 
 	// Initialize key state K⁰
 	var keyState [4]Block
@@ -396,11 +422,12 @@ func (ctx *Pholkos512Context) schedule(key *Pholkos512Key, tweak *PholkosTweak)
 	for i := range numRounds + 1 {
 		// γ function: RTK = K ⊕ T, with RC added to first substate
 		// Tweak is XORed to each substate (T is 128-bit, j mod 4 for 512-bit)
+		ctx.krk[i] = keyState
+		// Add round constant to first substate only
+		XorBlock(&ctx.krk[i][0], &ctx.krk[i][0], (*Block)(&pholkosRoundConstants[i]))
 		for j := range 4 {
-			XorBlock(&ctx.rtk[i][j], &keyState[j], &tweakState)
+			XorBlock(&ctx.rtk[i][j], &ctx.krk[i][j], &tweakState)
 		}
-		// Add round constant to first substate only
-		XorBlock(&ctx.rtk[i][0], &ctx.rtk[i][0], (*Block)(&pholkosRoundConstants[i]))
 
 		// Update key and tweak states for next round
 		if i < numRounds {
@@ -424,6 +451,19 @@ func (ctx *Pholkos512Context) schedule(key *Pholkos512Key, tweak *PholkosTweak)
 	}
 }
 
+// setTweak derives the round tweakeys for tweak from krk, the round tweakeys
+// of the same key with an all-zero tweak.
+func (ctx *Pholkos512Context) setTweak(krk *[21][4]Block, tweak *PholkosTweak) {
+	ctx.tweak = *tweak
+	t := Block(*tweak)
+	for i := range ctx.rtk {
+		for j := range 4 {
+			XorBlock(&ctx.rtk[i][j], &krk[i][j], &t)
+		}
+		applyTau(&t)
+	}
+}
+
 // pholkos512PermuteWords applies the word-wise permutation π512 to the key state.
 func pholkos512PermuteWords(state *[4]Block) {
 	// State has 16 words (32-bit each): 4 in each substate
@@ -13,3 +13,59 @@ func pholkos512EncryptAsm(block *Pholkos512Block, rtk *[21][4]Block)
 
 //go:noescape
 func pholkos512DecryptAsm(block *Pholkos512Block, rtk *[21][4]Block)
+
+//go:noescape
+func pholkos256EncryptBlocksAVX512(blocks *Pholkos256Block, tweaks *PholkosTweak, n int, rtk *[17][2]Block)
+
+//go:noescape
+func pholkos256DecryptBlocksAVX512(blocks *Pholkos256Block, tweaks *PholkosTweak, n int, rtk *[17][2]Block)
+
+//go:noescape
+func pholkos512EncryptBlocksAVX512(blocks *Pholkos512Block, tweaks *PholkosTweak, n int, rtk *[21][4]Block)
+
+//go:noescape
+func pholkos512DecryptBlocksAVX512(blocks *Pholkos512Block, tweaks *PholkosTweak, n int, rtk *[21][4]Block)
+
+// pholkos256EncryptBlocksWide encrypts the leading blocks that fit the VAES
+// kernel (4 at a time) and returns how many were processed.
+func pholkos256EncryptBlocksWide(blocks []Pholkos256Block, tweaks []PholkosTweak, rtk *[17][2]Block) int {
+	n := len(blocks) &^ 3
+	if n == 0 || !CPU.HasVAES || !CPU.HasAVX512 {
+		return 0
+	}
+	pholkos256EncryptBlocksAVX512(&blocks[0], &tweaks[0], n, rtk)
+	return n
+}
+
+// pholkos256DecryptBlocksWide decrypts the leading blocks that fit the VAES
+// kernel (4 at a time) and returns how many were processed.
+func pholkos256DecryptBlocksWide(blocks []Pholkos256Block, tweaks []PholkosTweak, rtk *[17][2]Block) int {
+	n := len(blocks) &^ 3
+	if n == 0 || !CPU.HasVAES || !CPU.HasAVX512 {
+		return 0
+	}
+	pholkos256DecryptBlocksAVX512(&blocks[0], &tweaks[0], n, rtk)
+	return n
+}
+
+// pholkos512EncryptBlocksWide encrypts the leading blocks that fit the VAES
+// kernel (2 at a time) and returns how many were processed.
+func pholkos512EncryptBlocksWide(blocks []Pholkos512Block, tweaks []PholkosTweak, rtk *[21][4]Block) int {
+	n := len(blocks) &^ 1
+	if n == 0 || !CPU.HasVAES || !CPU.HasAVX512 {
+		return 0
+	}
+	pholkos512EncryptBlocksAVX512(&blocks[0], &tweaks[0], n, rtk)
+	return n
+}
+
+// pholkos512DecryptBlocksWide decrypts the leading blocks that fit the VAES
+// kernel (2 at a time) and returns how many were processed.
+func pholkos512DecryptBlocksWide(blocks []Pholkos512Block, tweaks []PholkosTweak, rtk *[21][4]Block) int {
+	n := len(blocks) &^ 1
+	if n == 0 || !CPU.HasVAES || !CPU.HasAVX512 {
+		return 0
+	}
+	pholkos512DecryptBlocksAVX512(&blocks[0], &tweaks[0], n, rtk)
+	return n
+}
@@ -1068,3 +1068,1106 @@ TEXT ·pholkos512DecryptAsm(SB),NOSPLIT,$0
 	MOVOU X2, 32(AX)
 	MOVOU X3, 48(AX)
 	RET
+
+// Byte shuffle for the tweak permutation τ (πτ)
+DATA pholkos_tau_mask<>+0x00(SB)/8, $0x0605000f02010c0b
+DATA pholkos_tau_mask<>+0x08(SB)/8, $0x0e0d08070a090403
+GLOBL pholkos_tau_mask<>(SB), RODATA|NOPTR, $16
+
+// Byte shuffle for τ⁻¹
+DATA pholkos_tau_inv_mask<>+0x00(SB)/8, $0x0c07060908030205
+DATA pholkos_tau_inv_mask<>+0x08(SB)/8, $0x040f0e01000b0a0d
+GLOBL pholkos_tau_inv_mask<>(SB), RODATA|NOPTR, $16
+
+// Byte shuffle for τ²⁰ (last Pholkos-512 round tweak)
+DATA pholkos_tau20_mask<>+0x00(SB)/8, $0x0b0a090807060504
+DATA pholkos_tau20_mask<>+0x08(SB)/8, $0x030201000f0e0d0c
+GLOBL pholkos_tau20_mask<>(SB), RODATA|NOPTR, $16
+
+// VPERMD indices for π256 applied to two states in one ZMM register (π256 is self-inverse)
+DATA pholkos256x2_perm<>+0x00(SB)/8, $0x0000000500000000
+DATA pholkos256x2_perm<>+0x08(SB)/8, $0x0000000700000002
+DATA pholkos256x2_perm<>+0x10(SB)/8, $0x0000000100000004
+DATA pholkos256x2_perm<>+0x18(SB)/8, $0x0000000300000006
+DATA pholkos256x2_perm<>+0x20(SB)/8, $0x0000000d00000008
+DATA pholkos256x2_perm<>+0x28(SB)/8, $0x0000000f0000000a
+DATA pholkos256x2_perm<>+0x30(SB)/8, $0x000000090000000c
+DATA pholkos256x2_perm<>+0x38(SB)/8, $0x0000000b0000000e
+GLOBL pholkos256x2_perm<>(SB), RODATA|NOPTR, $64
+
+// VPERMD indices for π512
+DATA pholkos512_perm<>+0x00(SB)/8, $0x0000000500000000
+DATA pholkos512_perm<>+0x08(SB)/8, $0x0000000f0000000a
+DATA pholkos512_perm<>+0x10(SB)/8, $0x0000000900000004
+DATA pholkos512_perm<>+0x18(SB)/8, $0x000000030000000e
+DATA pholkos512_perm<>+0x20(SB)/8, $0x0000000d00000008
+DATA pholkos512_perm<>+0x28(SB)/8, $0x0000000700000002
+DATA pholkos512_perm<>+0x30(SB)/8, $0x000000010000000c
+DATA pholkos512_perm<>+0x38(SB)/8, $0x0000000b00000006
+GLOBL pholkos512_perm<>(SB), RODATA|NOPTR, $64
+
+// VPERMD indices for π512⁻¹
+DATA pholkos512_perm_inv<>+0x00(SB)/8, $0x0000000d00000000
+DATA pholkos512_perm_inv<>+0x08(SB)/8, $0x000000070000000a
+DATA pholkos512_perm_inv<>+0x10(SB)/8, $0x0000000100000004
+DATA pholkos512_perm_inv<>+0x18(SB)/8, $0x0000000b0000000e
+DATA pholkos512_perm_inv<>+0x20(SB)/8, $0x0000000500000008
+DATA pholkos512_perm_inv<>+0x28(SB)/8, $0x0000000f00000002
+DATA pholkos512_perm_inv<>+0x30(SB)/8, $0x000000090000000c
+DATA pholkos512_perm_inv<>+0x38(SB)/8, $0x0000000300000006
+GLOBL pholkos512_perm_inv<>(SB), RODATA|NOPTR, $64
+
+
+// Pholkos-256 encryption of independent blocks with per-block tweaks using VAES (AVX-512).
+// Two Pholkos-256 states share a ZMM register; each iteration processes 4 blocks
+// in two registers to hide AES latency. Tweak registers hold [Ta, Ta, Tb, Tb].
+// rtk is the schedule for an all-zero tweak; round r uses rtk[r] ⊕ τʳ(T).
+// n must be a multiple of 4.
+// func pholkos256EncryptBlocksAVX512(blocks *Pholkos256Block, tweaks *PholkosTweak, n int, rtk *[17][2]Block)
+TEXT ·pholkos256EncryptBlocksAVX512(SB),NOSPLIT,$0
+	MOVQ blocks+0(FP), AX
+	MOVQ tweaks+8(FP), SI
+	MOVQ n+16(FP), CX
+	MOVQ rtk+24(FP), BX
+
+	VBROADCASTI32X4 pholkos_tau_mask<>(SB), Z7
+	VMOVDQU64 pholkos256x2_perm<>(SB), Z9
+
+p256EncryptBlocksAVX512_loop:
+	CMPQ CX, $0
+	JLE p256EncryptBlocksAVX512_done
+
+	VMOVDQU64 0(AX), Z0
+	VMOVDQU64 64(AX), Z1
+	VMOVDQU64 0(SI), Y2
+	VMOVDQU64 32(SI), Y3
+	VSHUFI64X2 $0x50, Z2, Z2, Z2
+	VSHUFI64X2 $0x50, Z3, Z3, Z3
+
+	// Round 0: RTK[0] = rtk[0] ⊕ T
+	VBROADCASTI64X4 0(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VPXORQ Z5, Z0, Z0
+	VPXORQ Z6, Z1, Z1
+
+	// Round 1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 32(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+
+	// Round 2
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 64(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+
+	// Round 3
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 96(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+
+	// Round 4
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 128(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+
+	// Round 5
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 160(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+
+	// Round 6
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 192(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+
+	// Round 7
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 224(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+
+	// Round 8
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 256(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+
+	// Round 9
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 288(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+
+	// Round 10
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 320(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+
+	// Round 11
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 352(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+
+	// Round 12
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 384(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+
+	// Round 13
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 416(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+
+	// Round 14
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 448(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+
+	// Round 15
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 480(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+
+	// Round 16
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 512(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENCLAST Z5, Z0, Z0
+	VAESENCLAST Z6, Z1, Z1
+
+	VMOVDQU64 Z0, 0(AX)
+	VMOVDQU64 Z1, 64(AX)
+	ADDQ $128, AX
+	ADDQ $64, SI
+	SUBQ $4, CX
+	JMP p256EncryptBlocksAVX512_loop
+
+p256EncryptBlocksAVX512_done:
+	VZEROUPPER
+	RET
+
+// Pholkos-256 decryption of independent blocks with per-block tweaks using VAES (AVX-512).
+// There is no vector AESIMC, so InvMixColumns is computed as
+// VAESDEC(VAESENCLAST(x, 0), 0), and the next round tweakey is folded into
+// VAESDECLAST whenever no word permutation separates the two rounds.
+// n must be a multiple of 4.
+// func pholkos256DecryptBlocksAVX512(blocks *Pholkos256Block, tweaks *PholkosTweak, n int, rtk *[17][2]Block)
+TEXT ·pholkos256DecryptBlocksAVX512(SB),NOSPLIT,$0
+	MOVQ blocks+0(FP), AX
+	MOVQ tweaks+8(FP), SI
+	MOVQ n+16(FP), CX
+	MOVQ rtk+24(FP), BX
+
+	VBROADCASTI32X4 pholkos_tau_inv_mask<>(SB), Z7
+	VMOVDQU64 pholkos256x2_perm<>(SB), Z9
+	VPXORQ Z10, Z10, Z10
+
+p256DecryptBlocksAVX512_loop:
+	CMPQ CX, $0
+	JLE p256DecryptBlocksAVX512_done
+
+	VMOVDQU64 0(AX), Z0
+	VMOVDQU64 64(AX), Z1
+	VMOVDQU64 0(SI), Y2
+	VMOVDQU64 32(SI), Y3
+	VSHUFI64X2 $0x50, Z2, Z2, Z2
+	VSHUFI64X2 $0x50, Z3, Z3, Z3
+	// τ has order 16, so τ¹⁶(T) = T
+
+	// Undo round 16: XOR RTK[16], then InvShiftRows/InvSubBytes and XOR RTK[15]
+	VBROADCASTI64X4 512(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VPXORQ Z5, Z0, Z0
+	VPXORQ Z6, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 480(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z5, Z0, Z0
+	VAESDECLAST Z6, Z1, Z1
+
+	// Undo round 15
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 448(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z10, Z0, Z0
+	VAESDECLAST Z10, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+	VPXORQ Z5, Z0, Z0
+	VPXORQ Z6, Z1, Z1
+
+	// Undo round 14
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 416(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z5, Z0, Z0
+	VAESDECLAST Z6, Z1, Z1
+
+	// Undo round 13
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 384(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z10, Z0, Z0
+	VAESDECLAST Z10, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+	VPXORQ Z5, Z0, Z0
+	VPXORQ Z6, Z1, Z1
+
+	// Undo round 12
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 352(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z5, Z0, Z0
+	VAESDECLAST Z6, Z1, Z1
+
+	// Undo round 11
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 320(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z10, Z0, Z0
+	VAESDECLAST Z10, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+	VPXORQ Z5, Z0, Z0
+	VPXORQ Z6, Z1, Z1
+
+	// Undo round 10
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 288(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z5, Z0, Z0
+	VAESDECLAST Z6, Z1, Z1
+
+	// Undo round 9
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 256(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z10, Z0, Z0
+	VAESDECLAST Z10, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+	VPXORQ Z5, Z0, Z0
+	VPXORQ Z6, Z1, Z1
+
+	// Undo round 8
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 224(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z5, Z0, Z0
+	VAESDECLAST Z6, Z1, Z1
+
+	// Undo round 7
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 192(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z10, Z0, Z0
+	VAESDECLAST Z10, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+	VPXORQ Z5, Z0, Z0
+	VPXORQ Z6, Z1, Z1
+
+	// Undo round 6
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 160(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z5, Z0, Z0
+	VAESDECLAST Z6, Z1, Z1
+
+	// Undo round 5
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 128(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z10, Z0, Z0
+	VAESDECLAST Z10, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+	VPXORQ Z5, Z0, Z0
+	VPXORQ Z6, Z1, Z1
+
+	// Undo round 4
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 96(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z5, Z0, Z0
+	VAESDECLAST Z6, Z1, Z1
+
+	// Undo round 3
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 64(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z10, Z0, Z0
+	VAESDECLAST Z10, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+	VPXORQ Z5, Z0, Z0
+	VPXORQ Z6, Z1, Z1
+
+	// Undo round 2
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 32(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z5, Z0, Z0
+	VAESDECLAST Z6, Z1, Z1
+
+	// Undo round 1
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VBROADCASTI64X4 0(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z5, Z0, Z0
+	VAESDECLAST Z6, Z1, Z1
+
+	VMOVDQU64 Z0, 0(AX)
+	VMOVDQU64 Z1, 64(AX)
+	ADDQ $128, AX
+	ADDQ $64, SI
+	SUBQ $4, CX
+	JMP p256DecryptBlocksAVX512_loop
+
+p256DecryptBlocksAVX512_done:
+	VZEROUPPER
+	RET
+
+// Pholkos-512 encryption of independent blocks with per-block tweaks using VAES (AVX-512).
+// One Pholkos-512 state fills a ZMM register; each iteration processes 2 blocks
+// to hide AES latency. Tweak registers hold [T, T, T, T].
+// rtk is the schedule for an all-zero tweak; round r uses rtk[r] ⊕ τʳ(T).
+// n must be a multiple of 2.
+// func pholkos512EncryptBlocksAVX512(blocks *Pholkos512Block, tweaks *PholkosTweak, n int, rtk *[21][4]Block)
+TEXT ·pholkos512EncryptBlocksAVX512(SB),NOSPLIT,$0
+	MOVQ blocks+0(FP), AX
+	MOVQ tweaks+8(FP), SI
+	MOVQ n+16(FP), CX
+	MOVQ rtk+24(FP), BX
+
+	VBROADCASTI32X4 pholkos_tau_mask<>(SB), Z7
+	VMOVDQU64 pholkos512_perm<>(SB), Z9
+
+p512EncryptBlocksAVX512_loop:
+	CMPQ CX, $0
+	JLE p512EncryptBlocksAVX512_done
+
+	VMOVDQU64 0(AX), Z0
+	VMOVDQU64 64(AX), Z1
+	VBROADCASTI32X4 0(SI), Z2
+	VBROADCASTI32X4 16(SI), Z3
+
+	// Round 0: RTK[0] = rtk[0] ⊕ T
+	VMOVDQU64 0(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VPXORQ Z5, Z0, Z0
+	VPXORQ Z6, Z1, Z1
+
+	// Round 1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 64(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+
+	// Round 2
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 128(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+
+	// Round 3
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 192(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+
+	// Round 4
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 256(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+
+	// Round 5
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 320(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+
+	// Round 6
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 384(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+
+	// Round 7
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 448(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+
+	// Round 8
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 512(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+
+	// Round 9
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 576(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+
+	// Round 10
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 640(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+
+	// Round 11
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 704(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+
+	// Round 12
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 768(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+
+	// Round 13
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 832(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+
+	// Round 14
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 896(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+
+	// Round 15
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 960(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+
+	// Round 16
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 1024(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+
+	// Round 17
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 1088(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+
+	// Round 18
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 1152(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+
+	// Round 19
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 1216(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENC Z5, Z0, Z0
+	VAESENC Z6, Z1, Z1
+
+	// Round 20
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 1280(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESENCLAST Z5, Z0, Z0
+	VAESENCLAST Z6, Z1, Z1
+
+	VMOVDQU64 Z0, 0(AX)
+	VMOVDQU64 Z1, 64(AX)
+	ADDQ $128, AX
+	ADDQ $32, SI
+	SUBQ $2, CX
+	JMP p512EncryptBlocksAVX512_loop
+
+p512EncryptBlocksAVX512_done:
+	VZEROUPPER
+	RET
+
+// Pholkos-512 decryption of independent blocks with per-block tweaks using VAES (AVX-512).
+// There is no vector AESIMC, so InvMixColumns is computed as
+// VAESDEC(VAESENCLAST(x, 0), 0), and the next round tweakey is folded into
+// VAESDECLAST whenever no word permutation separates the two rounds.
+// n must be a multiple of 2.
+// func pholkos512DecryptBlocksAVX512(blocks *Pholkos512Block, tweaks *PholkosTweak, n int, rtk *[21][4]Block)
+TEXT ·pholkos512DecryptBlocksAVX512(SB),NOSPLIT,$0
+	MOVQ blocks+0(FP), AX
+	MOVQ tweaks+8(FP), SI
+	MOVQ n+16(FP), CX
+	MOVQ rtk+24(FP), BX
+
+	VBROADCASTI32X4 pholkos_tau_inv_mask<>(SB), Z7
+	VBROADCASTI32X4 pholkos_tau20_mask<>(SB), Z8
+	VMOVDQU64 pholkos512_perm_inv<>(SB), Z9
+	VPXORQ Z10, Z10, Z10
+
+p512DecryptBlocksAVX512_loop:
+	CMPQ CX, $0
+	JLE p512DecryptBlocksAVX512_done
+
+	VMOVDQU64 0(AX), Z0
+	VMOVDQU64 64(AX), Z1
+	VBROADCASTI32X4 0(SI), Z2
+	VBROADCASTI32X4 16(SI), Z3
+	VPSHUFB Z8, Z2, Z2
+	VPSHUFB Z8, Z3, Z3
+
+	// Undo round 20: XOR RTK[20], then InvShiftRows/InvSubBytes and XOR RTK[19]
+	VMOVDQU64 1280(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VPXORQ Z5, Z0, Z0
+	VPXORQ Z6, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 1216(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z5, Z0, Z0
+	VAESDECLAST Z6, Z1, Z1
+
+	// Undo round 19
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 1152(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z10, Z0, Z0
+	VAESDECLAST Z10, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+	VPXORQ Z5, Z0, Z0
+	VPXORQ Z6, Z1, Z1
+
+	// Undo round 18
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 1088(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z5, Z0, Z0
+	VAESDECLAST Z6, Z1, Z1
+
+	// Undo round 17
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 1024(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z10, Z0, Z0
+	VAESDECLAST Z10, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+	VPXORQ Z5, Z0, Z0
+	VPXORQ Z6, Z1, Z1
+
+	// Undo round 16
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 960(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z5, Z0, Z0
+	VAESDECLAST Z6, Z1, Z1
+
+	// Undo round 15
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 896(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z10, Z0, Z0
+	VAESDECLAST Z10, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+	VPXORQ Z5, Z0, Z0
+	VPXORQ Z6, Z1, Z1
+
+	// Undo round 14
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 832(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z5, Z0, Z0
+	VAESDECLAST Z6, Z1, Z1
+
+	// Undo round 13
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 768(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z10, Z0, Z0
+	VAESDECLAST Z10, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+	VPXORQ Z5, Z0, Z0
+	VPXORQ Z6, Z1, Z1
+
+	// Undo round 12
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 704(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z5, Z0, Z0
+	VAESDECLAST Z6, Z1, Z1
+
+	// Undo round 11
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 640(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z10, Z0, Z0
+	VAESDECLAST Z10, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+	VPXORQ Z5, Z0, Z0
+	VPXORQ Z6, Z1, Z1
+
+	// Undo round 10
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 576(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z5, Z0, Z0
+	VAESDECLAST Z6, Z1, Z1
+
+	// Undo round 9
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 512(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z10, Z0, Z0
+	VAESDECLAST Z10, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+	VPXORQ Z5, Z0, Z0
+	VPXORQ Z6, Z1, Z1
+
+	// Undo round 8
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 448(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z5, Z0, Z0
+	VAESDECLAST Z6, Z1, Z1
+
+	// Undo round 7
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 384(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z10, Z0, Z0
+	VAESDECLAST Z10, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+	VPXORQ Z5, Z0, Z0
+	VPXORQ Z6, Z1, Z1
+
+	// Undo round 6
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 320(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z5, Z0, Z0
+	VAESDECLAST Z6, Z1, Z1
+
+	// Undo round 5
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 256(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z10, Z0, Z0
+	VAESDECLAST Z10, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+	VPXORQ Z5, Z0, Z0
+	VPXORQ Z6, Z1, Z1
+
+	// Undo round 4
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 192(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z5, Z0, Z0
+	VAESDECLAST Z6, Z1, Z1
+
+	// Undo round 3
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 128(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z10, Z0, Z0
+	VAESDECLAST Z10, Z1, Z1
+	VPERMD Z0, Z9, Z0
+	VPERMD Z1, Z9, Z1
+	VPXORQ Z5, Z0, Z0
+	VPXORQ Z6, Z1, Z1
+
+	// Undo round 2
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 64(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z5, Z0, Z0
+	VAESDECLAST Z6, Z1, Z1
+
+	// Undo round 1
+	VAESENCLAST Z10, Z0, Z0
+	VAESENCLAST Z10, Z1, Z1
+	VAESDEC Z10, Z0, Z0
+	VAESDEC Z10, Z1, Z1
+	VPSHUFB Z7, Z2, Z2
+	VPSHUFB Z7, Z3, Z3
+	VMOVDQU64 0(BX), Z4
+	VPXORQ Z2, Z4, Z5
+	VPXORQ Z3, Z4, Z6
+	VAESDECLAST Z5, Z0, Z0
+	VAESDECLAST Z6, Z1, Z1
+
+	VMOVDQU64 Z0, 0(AX)
+	VMOVDQU64 Z1, 64(AX)
+	ADDQ $128, AX
+	ADDQ $32, SI
+	SUBQ $2, CX
+	JMP p512DecryptBlocksAVX512_loop
+
+p512DecryptBlocksAVX512_done:
+	VZEROUPPER
+	RET
@@ -13,3 +13,59 @@ func pholkos512EncryptAsm(block *Pholkos512Block, rtk *[21][4]Block)
 
 //go:noescape
 func pholkos512DecryptAsm(block *Pholkos512Block, rtk *[21][4]Block)
+
+//go:noescape
+func pholkos256EncryptBlocksAsm(blocks *Pholkos256Block, tweaks *PholkosTweak, n int, rtk *[17][2]Block)
+
+//go:noescape
+func pholkos256DecryptBlocksAsm(blocks *Pholkos256Block, tweaks *PholkosTweak, n int, rtk *[17][2]Block)
+
+//go:noescape
+func pholkos512EncryptBlocksAsm(blocks *Pholkos512Block, tweaks *PholkosTweak, n int, rtk *[21][4]Block)
+
+//go:noescape
+func pholkos512DecryptBlocksAsm(blocks *Pholkos512Block, tweaks *PholkosTweak, n int, rtk *[21][4]Block)
+
+// pholkos256EncryptBlocksWide encrypts the leading blocks that fit the ARM
+// Crypto kernel (4 at a time) and returns how many were processed.
+func pholkos256EncryptBlocksWide(blocks []Pholkos256Block, tweaks []PholkosTweak, rtk *[17][2]Block) int {
+	n := len(blocks) &^ 3
+	if n == 0 || !CPU.HasARMCrypto {
+		return 0
+	}
+	pholkos256EncryptBlocksAsm(&blocks[0], &tweaks[0], n, rtk)
+	return n
+}
+
+// pholkos256DecryptBlocksWide decrypts the leading blocks that fit the ARM
+// Crypto kernel (4 at a time) and returns how many were processed.
+func pholkos256DecryptBlocksWide(blocks []Pholkos256Block, tweaks []PholkosTweak, rtk *[17][2]Block) int {
+	n := len(blocks) &^ 3
+	if n == 0 || !CPU.HasARMCrypto {
+		return 0
+	}
+	pholkos256DecryptBlocksAsm(&blocks[0], &tweaks[0], n, rtk)
+	return n
+}
+
+// pholkos512EncryptBlocksWide encrypts the leading blocks that fit the ARM
+// Crypto kernel (2 at a time) and returns how many were processed.
+func pholkos512EncryptBlocksWide(blocks []Pholkos512Block, tweaks []PholkosTweak, rtk *[21][4]Block) int {
+	n := len(blocks) &^ 1
+	if n == 0 || !CPU.HasARMCrypto {
+		return 0
+	}
+	pholkos512EncryptBlocksAsm(&blocks[0], &tweaks[0], n, rtk)
+	return n
+}
+
+// pholkos512DecryptBlocksWide decrypts the leading blocks that fit the ARM
+// Crypto kernel (2 at a time) and returns how many were processed.
+func pholkos512DecryptBlocksWide(blocks []Pholkos512Block, tweaks []PholkosTweak, rtk *[21][4]Block) int {
+	n := len(blocks) &^ 1
+	if n == 0 || !CPU.HasARMCrypto {
+		return 0
+	}
+	pholkos512DecryptBlocksAsm(&blocks[0], &tweaks[0], n, rtk)
+	return n
+}
@@ -640,3 +640,3744 @@ pholkos512_dec_step_loop:
 	MOVD block+0(FP), R0
 	VST1 [V0.B16, V1.B16, V2.B16, V3.B16], (R0)
 	RET
+
+DATA pholkosTau<>+0x00(SB)/8, $0x0605000f02010c0b
+DATA pholkosTau<>+0x08(SB)/8, $0x0e0d08070a090403
+GLOBL pholkosTau<>(SB), RODATA|NOPTR, $16
+
+DATA pholkosTauInv<>+0x00(SB)/8, $0x0c07060908030205
+DATA pholkosTauInv<>+0x08(SB)/8, $0x040f0e01000b0a0d
+GLOBL pholkosTauInv<>(SB), RODATA|NOPTR, $16
+
+DATA pholkosTau20<>+0x00(SB)/8, $0x0b0a090807060504
+DATA pholkosTau20<>+0x08(SB)/8, $0x030201000f0e0d0c
+GLOBL pholkosTau20<>(SB), RODATA|NOPTR, $16
+
+DATA pholkosOddWords<>+0x00(SB)/8, $0xffffffff00000000
+DATA pholkosOddWords<>+0x08(SB)/8, $0xffffffff00000000
+GLOBL pholkosOddWords<>(SB), RODATA|NOPTR, $16
+
+// Pholkos-256 encryption of independent blocks with per-block tweaks using
+// ARM Crypto extensions. Four states are interleaved to hide the latency of
+// AESE and AESMC. rtk holds the round tweakeys for an all-zero tweak; round r
+// uses rtk[r] ⊕ τʳ(T). n must be a nonzero multiple of 4.
+// func pholkos256EncryptBlocksAsm(blocks *Pholkos256Block, tweaks *PholkosTweak, n int, rtk *[17][2]Block)
+TEXT ·pholkos256EncryptBlocksAsm(SB),NOSPLIT,$0
+	MOVD blocks+0(FP), R0
+	MOVD tweaks+8(FP), R1
+	MOVD n+16(FP), R2
+	MOVD rtk+24(FP), R3
+
+	MOVD $pholkosTau<>(SB), R4
+	VLD1 (R4), [V14.B16]
+	MOVD $pholkosOddWords<>(SB), R4
+	VLD1 (R4), [V25.B16]
+	VEOR V15.B16, V15.B16, V15.B16
+
+p256EncBlocks_loop:
+	MOVD R0, R5
+	VLD1.P 64(R5), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VLD1.P 64(R5), [V4.B16, V5.B16, V6.B16, V7.B16]
+	VLD1.P 64(R1), [V8.B16, V9.B16, V10.B16, V11.B16]
+	MOVD R3, R6
+
+	// Round 0: rtk[0] ⊕ T
+	VLD1.P 32(R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	// Round 1
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1.P 32(R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	// Round 2
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1.P 32(R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VEOR V1.B16, V0.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V0.B16, V0.B16
+	VEOR V24.B16, V1.B16, V1.B16
+	VEOR V3.B16, V2.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V2.B16, V2.B16
+	VEOR V24.B16, V3.B16, V3.B16
+	VEOR V5.B16, V4.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V4.B16, V4.B16
+	VEOR V24.B16, V5.B16, V5.B16
+	VEOR V7.B16, V6.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V6.B16, V6.B16
+	VEOR V24.B16, V7.B16, V7.B16
+
+	// Round 3
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1.P 32(R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	// Round 4
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1.P 32(R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VEOR V1.B16, V0.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V0.B16, V0.B16
+	VEOR V24.B16, V1.B16, V1.B16
+	VEOR V3.B16, V2.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V2.B16, V2.B16
+	VEOR V24.B16, V3.B16, V3.B16
+	VEOR V5.B16, V4.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V4.B16, V4.B16
+	VEOR V24.B16, V5.B16, V5.B16
+	VEOR V7.B16, V6.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V6.B16, V6.B16
+	VEOR V24.B16, V7.B16, V7.B16
+
+	// Round 5
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1.P 32(R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	// Round 6
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1.P 32(R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VEOR V1.B16, V0.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V0.B16, V0.B16
+	VEOR V24.B16, V1.B16, V1.B16
+	VEOR V3.B16, V2.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V2.B16, V2.B16
+	VEOR V24.B16, V3.B16, V3.B16
+	VEOR V5.B16, V4.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V4.B16, V4.B16
+	VEOR V24.B16, V5.B16, V5.B16
+	VEOR V7.B16, V6.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V6.B16, V6.B16
+	VEOR V24.B16, V7.B16, V7.B16
+
+	// Round 7
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1.P 32(R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	// Round 8
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1.P 32(R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VEOR V1.B16, V0.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V0.B16, V0.B16
+	VEOR V24.B16, V1.B16, V1.B16
+	VEOR V3.B16, V2.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V2.B16, V2.B16
+	VEOR V24.B16, V3.B16, V3.B16
+	VEOR V5.B16, V4.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V4.B16, V4.B16
+	VEOR V24.B16, V5.B16, V5.B16
+	VEOR V7.B16, V6.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V6.B16, V6.B16
+	VEOR V24.B16, V7.B16, V7.B16
+
+	// Round 9
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1.P 32(R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	// Round 10
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1.P 32(R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VEOR V1.B16, V0.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V0.B16, V0.B16
+	VEOR V24.B16, V1.B16, V1.B16
+	VEOR V3.B16, V2.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V2.B16, V2.B16
+	VEOR V24.B16, V3.B16, V3.B16
+	VEOR V5.B16, V4.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V4.B16, V4.B16
+	VEOR V24.B16, V5.B16, V5.B16
+	VEOR V7.B16, V6.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V6.B16, V6.B16
+	VEOR V24.B16, V7.B16, V7.B16
+
+	// Round 11
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1.P 32(R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	// Round 12
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1.P 32(R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VEOR V1.B16, V0.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V0.B16, V0.B16
+	VEOR V24.B16, V1.B16, V1.B16
+	VEOR V3.B16, V2.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V2.B16, V2.B16
+	VEOR V24.B16, V3.B16, V3.B16
+	VEOR V5.B16, V4.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V4.B16, V4.B16
+	VEOR V24.B16, V5.B16, V5.B16
+	VEOR V7.B16, V6.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V6.B16, V6.B16
+	VEOR V24.B16, V7.B16, V7.B16
+
+	// Round 13
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1.P 32(R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	// Round 14
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1.P 32(R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VEOR V1.B16, V0.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V0.B16, V0.B16
+	VEOR V24.B16, V1.B16, V1.B16
+	VEOR V3.B16, V2.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V2.B16, V2.B16
+	VEOR V24.B16, V3.B16, V3.B16
+	VEOR V5.B16, V4.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V4.B16, V4.B16
+	VEOR V24.B16, V5.B16, V5.B16
+	VEOR V7.B16, V6.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V6.B16, V6.B16
+	VEOR V24.B16, V7.B16, V7.B16
+
+	// Round 15
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1.P 32(R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	// Round 16
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1.P 32(R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESE V15.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	VST1.P [V0.B16, V1.B16, V2.B16, V3.B16], 64(R0)
+	VST1.P [V4.B16, V5.B16, V6.B16, V7.B16], 64(R0)
+	SUBS $4, R2, R2
+	BNE p256EncBlocks_loop
+	RET
+
+// Pholkos-256 decryption of independent blocks with per-block tweaks using
+// ARM Crypto extensions. τ¹⁶ is the identity, so the tweaks are stepped back
+// from T with τ⁻¹. n must be a nonzero multiple of 4.
+// func pholkos256DecryptBlocksAsm(blocks *Pholkos256Block, tweaks *PholkosTweak, n int, rtk *[17][2]Block)
+TEXT ·pholkos256DecryptBlocksAsm(SB),NOSPLIT,$0
+	MOVD blocks+0(FP), R0
+	MOVD tweaks+8(FP), R1
+	MOVD n+16(FP), R2
+	MOVD rtk+24(FP), R3
+
+	MOVD $pholkosTauInv<>(SB), R4
+	VLD1 (R4), [V14.B16]
+	MOVD $pholkosOddWords<>(SB), R4
+	VLD1 (R4), [V25.B16]
+	VEOR V15.B16, V15.B16, V15.B16
+
+p256DecBlocks_loop:
+	MOVD R0, R5
+	VLD1.P 64(R5), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VLD1.P 64(R5), [V4.B16, V5.B16, V6.B16, V7.B16]
+	VLD1.P 64(R1), [V8.B16, V9.B16, V10.B16, V11.B16]
+	ADD $512, R3, R6
+
+	// Round 16
+	VLD1 (R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	SUB $32, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESD V15.B16, V0.B16
+	AESD V15.B16, V1.B16
+	AESD V15.B16, V2.B16
+	AESD V15.B16, V3.B16
+	AESD V15.B16, V4.B16
+	AESD V15.B16, V5.B16
+	AESD V15.B16, V6.B16
+	AESD V15.B16, V7.B16
+
+	// Round 15
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1 (R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	SUB $32, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+	VEOR V1.B16, V0.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V0.B16, V0.B16
+	VEOR V24.B16, V1.B16, V1.B16
+	VEOR V3.B16, V2.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V2.B16, V2.B16
+	VEOR V24.B16, V3.B16, V3.B16
+	VEOR V5.B16, V4.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V4.B16, V4.B16
+	VEOR V24.B16, V5.B16, V5.B16
+	VEOR V7.B16, V6.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V6.B16, V6.B16
+	VEOR V24.B16, V7.B16, V7.B16
+
+	// Round 14
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1 (R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	SUB $32, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+
+	// Round 13
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1 (R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	SUB $32, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+	VEOR V1.B16, V0.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V0.B16, V0.B16
+	VEOR V24.B16, V1.B16, V1.B16
+	VEOR V3.B16, V2.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V2.B16, V2.B16
+	VEOR V24.B16, V3.B16, V3.B16
+	VEOR V5.B16, V4.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V4.B16, V4.B16
+	VEOR V24.B16, V5.B16, V5.B16
+	VEOR V7.B16, V6.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V6.B16, V6.B16
+	VEOR V24.B16, V7.B16, V7.B16
+
+	// Round 12
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1 (R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	SUB $32, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+
+	// Round 11
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1 (R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	SUB $32, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+	VEOR V1.B16, V0.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V0.B16, V0.B16
+	VEOR V24.B16, V1.B16, V1.B16
+	VEOR V3.B16, V2.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V2.B16, V2.B16
+	VEOR V24.B16, V3.B16, V3.B16
+	VEOR V5.B16, V4.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V4.B16, V4.B16
+	VEOR V24.B16, V5.B16, V5.B16
+	VEOR V7.B16, V6.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V6.B16, V6.B16
+	VEOR V24.B16, V7.B16, V7.B16
+
+	// Round 10
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1 (R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	SUB $32, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+
+	// Round 9
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1 (R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	SUB $32, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+	VEOR V1.B16, V0.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V0.B16, V0.B16
+	VEOR V24.B16, V1.B16, V1.B16
+	VEOR V3.B16, V2.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V2.B16, V2.B16
+	VEOR V24.B16, V3.B16, V3.B16
+	VEOR V5.B16, V4.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V4.B16, V4.B16
+	VEOR V24.B16, V5.B16, V5.B16
+	VEOR V7.B16, V6.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V6.B16, V6.B16
+	VEOR V24.B16, V7.B16, V7.B16
+
+	// Round 8
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1 (R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	SUB $32, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+
+	// Round 7
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1 (R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	SUB $32, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+	VEOR V1.B16, V0.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V0.B16, V0.B16
+	VEOR V24.B16, V1.B16, V1.B16
+	VEOR V3.B16, V2.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V2.B16, V2.B16
+	VEOR V24.B16, V3.B16, V3.B16
+	VEOR V5.B16, V4.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V4.B16, V4.B16
+	VEOR V24.B16, V5.B16, V5.B16
+	VEOR V7.B16, V6.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V6.B16, V6.B16
+	VEOR V24.B16, V7.B16, V7.B16
+
+	// Round 6
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1 (R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	SUB $32, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+
+	// Round 5
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1 (R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	SUB $32, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+	VEOR V1.B16, V0.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V0.B16, V0.B16
+	VEOR V24.B16, V1.B16, V1.B16
+	VEOR V3.B16, V2.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V2.B16, V2.B16
+	VEOR V24.B16, V3.B16, V3.B16
+	VEOR V5.B16, V4.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V4.B16, V4.B16
+	VEOR V24.B16, V5.B16, V5.B16
+	VEOR V7.B16, V6.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V6.B16, V6.B16
+	VEOR V24.B16, V7.B16, V7.B16
+
+	// Round 4
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1 (R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	SUB $32, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+
+	// Round 3
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1 (R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	SUB $32, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+	VEOR V1.B16, V0.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V0.B16, V0.B16
+	VEOR V24.B16, V1.B16, V1.B16
+	VEOR V3.B16, V2.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V2.B16, V2.B16
+	VEOR V24.B16, V3.B16, V3.B16
+	VEOR V5.B16, V4.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V4.B16, V4.B16
+	VEOR V24.B16, V5.B16, V5.B16
+	VEOR V7.B16, V6.B16, V24.B16
+	VAND V25.B16, V24.B16, V24.B16
+	VEOR V24.B16, V6.B16, V6.B16
+	VEOR V24.B16, V7.B16, V7.B16
+
+	// Round 2
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1 (R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	SUB $32, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+
+	// Round 1
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1 (R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	SUB $32, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+
+	// Round 0: rtk[0] ⊕ T
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VTBL V14.B16, [V10.B16], V10.B16
+	VTBL V14.B16, [V11.B16], V11.B16
+	VLD1 (R6), [V12.B16, V13.B16]
+	VEOR V8.B16, V12.B16, V16.B16
+	VEOR V8.B16, V13.B16, V17.B16
+	VEOR V9.B16, V12.B16, V18.B16
+	VEOR V9.B16, V13.B16, V19.B16
+	VEOR V10.B16, V12.B16, V20.B16
+	VEOR V10.B16, V13.B16, V21.B16
+	VEOR V11.B16, V12.B16, V22.B16
+	VEOR V11.B16, V13.B16, V23.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	VST1.P [V0.B16, V1.B16, V2.B16, V3.B16], 64(R0)
+	VST1.P [V4.B16, V5.B16, V6.B16, V7.B16], 64(R0)
+	SUBS $4, R2, R2
+	BNE p256DecBlocks_loop
+	RET
+
+// Pholkos-512 encryption of independent blocks with per-block tweaks using
+// ARM Crypto extensions, two states at a time. rtk holds the round tweakeys
+// for an all-zero tweak. n must be a nonzero multiple of 2.
+// func pholkos512EncryptBlocksAsm(blocks *Pholkos512Block, tweaks *PholkosTweak, n int, rtk *[21][4]Block)
+TEXT ·pholkos512EncryptBlocksAsm(SB),NOSPLIT,$0
+	MOVD blocks+0(FP), R0
+	MOVD tweaks+8(FP), R1
+	MOVD n+16(FP), R2
+	MOVD rtk+24(FP), R3
+
+	MOVD $pholkosTau<>(SB), R4
+	VLD1 (R4), [V14.B16]
+	VEOR V15.B16, V15.B16, V15.B16
+
+p512EncBlocks_loop:
+	MOVD R0, R5
+	VLD1.P 64(R5), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VLD1.P 64(R5), [V4.B16, V5.B16, V6.B16, V7.B16]
+	VLD1.P 32(R1), [V8.B16, V9.B16]
+	MOVD R3, R6
+
+	// Round 0: rtk[0] ⊕ T
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	// Round 1
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	// Round 2
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VMOV V0.B16, V24.B16
+	VMOV V1.B16, V25.B16
+	VMOV V2.B16, V26.B16
+	VMOV V3.B16, V27.B16
+	VMOV V25.S[1], V0.S[1]
+	VMOV V26.S[2], V0.S[2]
+	VMOV V27.S[3], V0.S[3]
+	VMOV V26.S[1], V1.S[1]
+	VMOV V27.S[2], V1.S[2]
+	VMOV V24.S[3], V1.S[3]
+	VMOV V27.S[1], V2.S[1]
+	VMOV V24.S[2], V2.S[2]
+	VMOV V25.S[3], V2.S[3]
+	VMOV V24.S[1], V3.S[1]
+	VMOV V25.S[2], V3.S[2]
+	VMOV V26.S[3], V3.S[3]
+	VMOV V4.B16, V24.B16
+	VMOV V5.B16, V25.B16
+	VMOV V6.B16, V26.B16
+	VMOV V7.B16, V27.B16
+	VMOV V25.S[1], V4.S[1]
+	VMOV V26.S[2], V4.S[2]
+	VMOV V27.S[3], V4.S[3]
+	VMOV V26.S[1], V5.S[1]
+	VMOV V27.S[2], V5.S[2]
+	VMOV V24.S[3], V5.S[3]
+	VMOV V27.S[1], V6.S[1]
+	VMOV V24.S[2], V6.S[2]
+	VMOV V25.S[3], V6.S[3]
+	VMOV V24.S[1], V7.S[1]
+	VMOV V25.S[2], V7.S[2]
+	VMOV V26.S[3], V7.S[3]
+
+	// Round 3
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	// Round 4
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VMOV V0.B16, V24.B16
+	VMOV V1.B16, V25.B16
+	VMOV V2.B16, V26.B16
+	VMOV V3.B16, V27.B16
+	VMOV V25.S[1], V0.S[1]
+	VMOV V26.S[2], V0.S[2]
+	VMOV V27.S[3], V0.S[3]
+	VMOV V26.S[1], V1.S[1]
+	VMOV V27.S[2], V1.S[2]
+	VMOV V24.S[3], V1.S[3]
+	VMOV V27.S[1], V2.S[1]
+	VMOV V24.S[2], V2.S[2]
+	VMOV V25.S[3], V2.S[3]
+	VMOV V24.S[1], V3.S[1]
+	VMOV V25.S[2], V3.S[2]
+	VMOV V26.S[3], V3.S[3]
+	VMOV V4.B16, V24.B16
+	VMOV V5.B16, V25.B16
+	VMOV V6.B16, V26.B16
+	VMOV V7.B16, V27.B16
+	VMOV V25.S[1], V4.S[1]
+	VMOV V26.S[2], V4.S[2]
+	VMOV V27.S[3], V4.S[3]
+	VMOV V26.S[1], V5.S[1]
+	VMOV V27.S[2], V5.S[2]
+	VMOV V24.S[3], V5.S[3]
+	VMOV V27.S[1], V6.S[1]
+	VMOV V24.S[2], V6.S[2]
+	VMOV V25.S[3], V6.S[3]
+	VMOV V24.S[1], V7.S[1]
+	VMOV V25.S[2], V7.S[2]
+	VMOV V26.S[3], V7.S[3]
+
+	// Round 5
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	// Round 6
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VMOV V0.B16, V24.B16
+	VMOV V1.B16, V25.B16
+	VMOV V2.B16, V26.B16
+	VMOV V3.B16, V27.B16
+	VMOV V25.S[1], V0.S[1]
+	VMOV V26.S[2], V0.S[2]
+	VMOV V27.S[3], V0.S[3]
+	VMOV V26.S[1], V1.S[1]
+	VMOV V27.S[2], V1.S[2]
+	VMOV V24.S[3], V1.S[3]
+	VMOV V27.S[1], V2.S[1]
+	VMOV V24.S[2], V2.S[2]
+	VMOV V25.S[3], V2.S[3]
+	VMOV V24.S[1], V3.S[1]
+	VMOV V25.S[2], V3.S[2]
+	VMOV V26.S[3], V3.S[3]
+	VMOV V4.B16, V24.B16
+	VMOV V5.B16, V25.B16
+	VMOV V6.B16, V26.B16
+	VMOV V7.B16, V27.B16
+	VMOV V25.S[1], V4.S[1]
+	VMOV V26.S[2], V4.S[2]
+	VMOV V27.S[3], V4.S[3]
+	VMOV V26.S[1], V5.S[1]
+	VMOV V27.S[2], V5.S[2]
+	VMOV V24.S[3], V5.S[3]
+	VMOV V27.S[1], V6.S[1]
+	VMOV V24.S[2], V6.S[2]
+	VMOV V25.S[3], V6.S[3]
+	VMOV V24.S[1], V7.S[1]
+	VMOV V25.S[2], V7.S[2]
+	VMOV V26.S[3], V7.S[3]
+
+	// Round 7
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	// Round 8
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VMOV V0.B16, V24.B16
+	VMOV V1.B16, V25.B16
+	VMOV V2.B16, V26.B16
+	VMOV V3.B16, V27.B16
+	VMOV V25.S[1], V0.S[1]
+	VMOV V26.S[2], V0.S[2]
+	VMOV V27.S[3], V0.S[3]
+	VMOV V26.S[1], V1.S[1]
+	VMOV V27.S[2], V1.S[2]
+	VMOV V24.S[3], V1.S[3]
+	VMOV V27.S[1], V2.S[1]
+	VMOV V24.S[2], V2.S[2]
+	VMOV V25.S[3], V2.S[3]
+	VMOV V24.S[1], V3.S[1]
+	VMOV V25.S[2], V3.S[2]
+	VMOV V26.S[3], V3.S[3]
+	VMOV V4.B16, V24.B16
+	VMOV V5.B16, V25.B16
+	VMOV V6.B16, V26.B16
+	VMOV V7.B16, V27.B16
+	VMOV V25.S[1], V4.S[1]
+	VMOV V26.S[2], V4.S[2]
+	VMOV V27.S[3], V4.S[3]
+	VMOV V26.S[1], V5.S[1]
+	VMOV V27.S[2], V5.S[2]
+	VMOV V24.S[3], V5.S[3]
+	VMOV V27.S[1], V6.S[1]
+	VMOV V24.S[2], V6.S[2]
+	VMOV V25.S[3], V6.S[3]
+	VMOV V24.S[1], V7.S[1]
+	VMOV V25.S[2], V7.S[2]
+	VMOV V26.S[3], V7.S[3]
+
+	// Round 9
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	// Round 10
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VMOV V0.B16, V24.B16
+	VMOV V1.B16, V25.B16
+	VMOV V2.B16, V26.B16
+	VMOV V3.B16, V27.B16
+	VMOV V25.S[1], V0.S[1]
+	VMOV V26.S[2], V0.S[2]
+	VMOV V27.S[3], V0.S[3]
+	VMOV V26.S[1], V1.S[1]
+	VMOV V27.S[2], V1.S[2]
+	VMOV V24.S[3], V1.S[3]
+	VMOV V27.S[1], V2.S[1]
+	VMOV V24.S[2], V2.S[2]
+	VMOV V25.S[3], V2.S[3]
+	VMOV V24.S[1], V3.S[1]
+	VMOV V25.S[2], V3.S[2]
+	VMOV V26.S[3], V3.S[3]
+	VMOV V4.B16, V24.B16
+	VMOV V5.B16, V25.B16
+	VMOV V6.B16, V26.B16
+	VMOV V7.B16, V27.B16
+	VMOV V25.S[1], V4.S[1]
+	VMOV V26.S[2], V4.S[2]
+	VMOV V27.S[3], V4.S[3]
+	VMOV V26.S[1], V5.S[1]
+	VMOV V27.S[2], V5.S[2]
+	VMOV V24.S[3], V5.S[3]
+	VMOV V27.S[1], V6.S[1]
+	VMOV V24.S[2], V6.S[2]
+	VMOV V25.S[3], V6.S[3]
+	VMOV V24.S[1], V7.S[1]
+	VMOV V25.S[2], V7.S[2]
+	VMOV V26.S[3], V7.S[3]
+
+	// Round 11
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	// Round 12
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VMOV V0.B16, V24.B16
+	VMOV V1.B16, V25.B16
+	VMOV V2.B16, V26.B16
+	VMOV V3.B16, V27.B16
+	VMOV V25.S[1], V0.S[1]
+	VMOV V26.S[2], V0.S[2]
+	VMOV V27.S[3], V0.S[3]
+	VMOV V26.S[1], V1.S[1]
+	VMOV V27.S[2], V1.S[2]
+	VMOV V24.S[3], V1.S[3]
+	VMOV V27.S[1], V2.S[1]
+	VMOV V24.S[2], V2.S[2]
+	VMOV V25.S[3], V2.S[3]
+	VMOV V24.S[1], V3.S[1]
+	VMOV V25.S[2], V3.S[2]
+	VMOV V26.S[3], V3.S[3]
+	VMOV V4.B16, V24.B16
+	VMOV V5.B16, V25.B16
+	VMOV V6.B16, V26.B16
+	VMOV V7.B16, V27.B16
+	VMOV V25.S[1], V4.S[1]
+	VMOV V26.S[2], V4.S[2]
+	VMOV V27.S[3], V4.S[3]
+	VMOV V26.S[1], V5.S[1]
+	VMOV V27.S[2], V5.S[2]
+	VMOV V24.S[3], V5.S[3]
+	VMOV V27.S[1], V6.S[1]
+	VMOV V24.S[2], V6.S[2]
+	VMOV V25.S[3], V6.S[3]
+	VMOV V24.S[1], V7.S[1]
+	VMOV V25.S[2], V7.S[2]
+	VMOV V26.S[3], V7.S[3]
+
+	// Round 13
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	// Round 14
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VMOV V0.B16, V24.B16
+	VMOV V1.B16, V25.B16
+	VMOV V2.B16, V26.B16
+	VMOV V3.B16, V27.B16
+	VMOV V25.S[1], V0.S[1]
+	VMOV V26.S[2], V0.S[2]
+	VMOV V27.S[3], V0.S[3]
+	VMOV V26.S[1], V1.S[1]
+	VMOV V27.S[2], V1.S[2]
+	VMOV V24.S[3], V1.S[3]
+	VMOV V27.S[1], V2.S[1]
+	VMOV V24.S[2], V2.S[2]
+	VMOV V25.S[3], V2.S[3]
+	VMOV V24.S[1], V3.S[1]
+	VMOV V25.S[2], V3.S[2]
+	VMOV V26.S[3], V3.S[3]
+	VMOV V4.B16, V24.B16
+	VMOV V5.B16, V25.B16
+	VMOV V6.B16, V26.B16
+	VMOV V7.B16, V27.B16
+	VMOV V25.S[1], V4.S[1]
+	VMOV V26.S[2], V4.S[2]
+	VMOV V27.S[3], V4.S[3]
+	VMOV V26.S[1], V5.S[1]
+	VMOV V27.S[2], V5.S[2]
+	VMOV V24.S[3], V5.S[3]
+	VMOV V27.S[1], V6.S[1]
+	VMOV V24.S[2], V6.S[2]
+	VMOV V25.S[3], V6.S[3]
+	VMOV V24.S[1], V7.S[1]
+	VMOV V25.S[2], V7.S[2]
+	VMOV V26.S[3], V7.S[3]
+
+	// Round 15
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	// Round 16
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VMOV V0.B16, V24.B16
+	VMOV V1.B16, V25.B16
+	VMOV V2.B16, V26.B16
+	VMOV V3.B16, V27.B16
+	VMOV V25.S[1], V0.S[1]
+	VMOV V26.S[2], V0.S[2]
+	VMOV V27.S[3], V0.S[3]
+	VMOV V26.S[1], V1.S[1]
+	VMOV V27.S[2], V1.S[2]
+	VMOV V24.S[3], V1.S[3]
+	VMOV V27.S[1], V2.S[1]
+	VMOV V24.S[2], V2.S[2]
+	VMOV V25.S[3], V2.S[3]
+	VMOV V24.S[1], V3.S[1]
+	VMOV V25.S[2], V3.S[2]
+	VMOV V26.S[3], V3.S[3]
+	VMOV V4.B16, V24.B16
+	VMOV V5.B16, V25.B16
+	VMOV V6.B16, V26.B16
+	VMOV V7.B16, V27.B16
+	VMOV V25.S[1], V4.S[1]
+	VMOV V26.S[2], V4.S[2]
+	VMOV V27.S[3], V4.S[3]
+	VMOV V26.S[1], V5.S[1]
+	VMOV V27.S[2], V5.S[2]
+	VMOV V24.S[3], V5.S[3]
+	VMOV V27.S[1], V6.S[1]
+	VMOV V24.S[2], V6.S[2]
+	VMOV V25.S[3], V6.S[3]
+	VMOV V24.S[1], V7.S[1]
+	VMOV V25.S[2], V7.S[2]
+	VMOV V26.S[3], V7.S[3]
+
+	// Round 17
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	// Round 18
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VMOV V0.B16, V24.B16
+	VMOV V1.B16, V25.B16
+	VMOV V2.B16, V26.B16
+	VMOV V3.B16, V27.B16
+	VMOV V25.S[1], V0.S[1]
+	VMOV V26.S[2], V0.S[2]
+	VMOV V27.S[3], V0.S[3]
+	VMOV V26.S[1], V1.S[1]
+	VMOV V27.S[2], V1.S[2]
+	VMOV V24.S[3], V1.S[3]
+	VMOV V27.S[1], V2.S[1]
+	VMOV V24.S[2], V2.S[2]
+	VMOV V25.S[3], V2.S[3]
+	VMOV V24.S[1], V3.S[1]
+	VMOV V25.S[2], V3.S[2]
+	VMOV V26.S[3], V3.S[3]
+	VMOV V4.B16, V24.B16
+	VMOV V5.B16, V25.B16
+	VMOV V6.B16, V26.B16
+	VMOV V7.B16, V27.B16
+	VMOV V25.S[1], V4.S[1]
+	VMOV V26.S[2], V4.S[2]
+	VMOV V27.S[3], V4.S[3]
+	VMOV V26.S[1], V5.S[1]
+	VMOV V27.S[2], V5.S[2]
+	VMOV V24.S[3], V5.S[3]
+	VMOV V27.S[1], V6.S[1]
+	VMOV V24.S[2], V6.S[2]
+	VMOV V25.S[3], V6.S[3]
+	VMOV V24.S[1], V7.S[1]
+	VMOV V25.S[2], V7.S[2]
+	VMOV V26.S[3], V7.S[3]
+
+	// Round 19
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V15.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	// Round 20
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1.P 64(R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	AESE V15.B16, V0.B16
+	AESE V15.B16, V1.B16
+	AESE V15.B16, V2.B16
+	AESE V15.B16, V3.B16
+	AESE V15.B16, V4.B16
+	AESE V15.B16, V5.B16
+	AESE V15.B16, V6.B16
+	AESE V15.B16, V7.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	VST1.P [V0.B16, V1.B16, V2.B16, V3.B16], 64(R0)
+	VST1.P [V4.B16, V5.B16, V6.B16, V7.B16], 64(R0)
+	SUBS $2, R2, R2
+	BNE p512EncBlocks_loop
+	RET
+
+// Pholkos-512 decryption of independent blocks with per-block tweaks using
+// ARM Crypto extensions, two states at a time. The tweaks start at τ²⁰(T) and
+// are stepped back with τ⁻¹. n must be a nonzero multiple of 2.
+// func pholkos512DecryptBlocksAsm(blocks *Pholkos512Block, tweaks *PholkosTweak, n int, rtk *[21][4]Block)
+TEXT ·pholkos512DecryptBlocksAsm(SB),NOSPLIT,$0
+	MOVD blocks+0(FP), R0
+	MOVD tweaks+8(FP), R1
+	MOVD n+16(FP), R2
+	MOVD rtk+24(FP), R3
+
+	MOVD $pholkosTauInv<>(SB), R4
+	VLD1 (R4), [V14.B16]
+	MOVD $pholkosTau20<>(SB), R4
+	VLD1 (R4), [V28.B16]
+	VEOR V15.B16, V15.B16, V15.B16
+
+p512DecBlocks_loop:
+	MOVD R0, R5
+	VLD1.P 64(R5), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VLD1.P 64(R5), [V4.B16, V5.B16, V6.B16, V7.B16]
+	VLD1.P 32(R1), [V8.B16, V9.B16]
+	ADD $1280, R3, R6
+	VTBL V28.B16, [V8.B16], V8.B16
+	VTBL V28.B16, [V9.B16], V9.B16
+
+	// Round 20
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	SUB $64, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESD V15.B16, V0.B16
+	AESD V15.B16, V1.B16
+	AESD V15.B16, V2.B16
+	AESD V15.B16, V3.B16
+	AESD V15.B16, V4.B16
+	AESD V15.B16, V5.B16
+	AESD V15.B16, V6.B16
+	AESD V15.B16, V7.B16
+
+	// Round 19
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	SUB $64, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+	VMOV V0.B16, V24.B16
+	VMOV V1.B16, V25.B16
+	VMOV V2.B16, V26.B16
+	VMOV V3.B16, V27.B16
+	VMOV V27.S[1], V0.S[1]
+	VMOV V26.S[2], V0.S[2]
+	VMOV V25.S[3], V0.S[3]
+	VMOV V24.S[1], V1.S[1]
+	VMOV V27.S[2], V1.S[2]
+	VMOV V26.S[3], V1.S[3]
+	VMOV V25.S[1], V2.S[1]
+	VMOV V24.S[2], V2.S[2]
+	VMOV V27.S[3], V2.S[3]
+	VMOV V26.S[1], V3.S[1]
+	VMOV V25.S[2], V3.S[2]
+	VMOV V24.S[3], V3.S[3]
+	VMOV V4.B16, V24.B16
+	VMOV V5.B16, V25.B16
+	VMOV V6.B16, V26.B16
+	VMOV V7.B16, V27.B16
+	VMOV V27.S[1], V4.S[1]
+	VMOV V26.S[2], V4.S[2]
+	VMOV V25.S[3], V4.S[3]
+	VMOV V24.S[1], V5.S[1]
+	VMOV V27.S[2], V5.S[2]
+	VMOV V26.S[3], V5.S[3]
+	VMOV V25.S[1], V6.S[1]
+	VMOV V24.S[2], V6.S[2]
+	VMOV V27.S[3], V6.S[3]
+	VMOV V26.S[1], V7.S[1]
+	VMOV V25.S[2], V7.S[2]
+	VMOV V24.S[3], V7.S[3]
+
+	// Round 18
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	SUB $64, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+
+	// Round 17
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	SUB $64, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+	VMOV V0.B16, V24.B16
+	VMOV V1.B16, V25.B16
+	VMOV V2.B16, V26.B16
+	VMOV V3.B16, V27.B16
+	VMOV V27.S[1], V0.S[1]
+	VMOV V26.S[2], V0.S[2]
+	VMOV V25.S[3], V0.S[3]
+	VMOV V24.S[1], V1.S[1]
+	VMOV V27.S[2], V1.S[2]
+	VMOV V26.S[3], V1.S[3]
+	VMOV V25.S[1], V2.S[1]
+	VMOV V24.S[2], V2.S[2]
+	VMOV V27.S[3], V2.S[3]
+	VMOV V26.S[1], V3.S[1]
+	VMOV V25.S[2], V3.S[2]
+	VMOV V24.S[3], V3.S[3]
+	VMOV V4.B16, V24.B16
+	VMOV V5.B16, V25.B16
+	VMOV V6.B16, V26.B16
+	VMOV V7.B16, V27.B16
+	VMOV V27.S[1], V4.S[1]
+	VMOV V26.S[2], V4.S[2]
+	VMOV V25.S[3], V4.S[3]
+	VMOV V24.S[1], V5.S[1]
+	VMOV V27.S[2], V5.S[2]
+	VMOV V26.S[3], V5.S[3]
+	VMOV V25.S[1], V6.S[1]
+	VMOV V24.S[2], V6.S[2]
+	VMOV V27.S[3], V6.S[3]
+	VMOV V26.S[1], V7.S[1]
+	VMOV V25.S[2], V7.S[2]
+	VMOV V24.S[3], V7.S[3]
+
+	// Round 16
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	SUB $64, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+
+	// Round 15
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	SUB $64, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+	VMOV V0.B16, V24.B16
+	VMOV V1.B16, V25.B16
+	VMOV V2.B16, V26.B16
+	VMOV V3.B16, V27.B16
+	VMOV V27.S[1], V0.S[1]
+	VMOV V26.S[2], V0.S[2]
+	VMOV V25.S[3], V0.S[3]
+	VMOV V24.S[1], V1.S[1]
+	VMOV V27.S[2], V1.S[2]
+	VMOV V26.S[3], V1.S[3]
+	VMOV V25.S[1], V2.S[1]
+	VMOV V24.S[2], V2.S[2]
+	VMOV V27.S[3], V2.S[3]
+	VMOV V26.S[1], V3.S[1]
+	VMOV V25.S[2], V3.S[2]
+	VMOV V24.S[3], V3.S[3]
+	VMOV V4.B16, V24.B16
+	VMOV V5.B16, V25.B16
+	VMOV V6.B16, V26.B16
+	VMOV V7.B16, V27.B16
+	VMOV V27.S[1], V4.S[1]
+	VMOV V26.S[2], V4.S[2]
+	VMOV V25.S[3], V4.S[3]
+	VMOV V24.S[1], V5.S[1]
+	VMOV V27.S[2], V5.S[2]
+	VMOV V26.S[3], V5.S[3]
+	VMOV V25.S[1], V6.S[1]
+	VMOV V24.S[2], V6.S[2]
+	VMOV V27.S[3], V6.S[3]
+	VMOV V26.S[1], V7.S[1]
+	VMOV V25.S[2], V7.S[2]
+	VMOV V24.S[3], V7.S[3]
+
+	// Round 14
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	SUB $64, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+
+	// Round 13
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	SUB $64, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+	VMOV V0.B16, V24.B16
+	VMOV V1.B16, V25.B16
+	VMOV V2.B16, V26.B16
+	VMOV V3.B16, V27.B16
+	VMOV V27.S[1], V0.S[1]
+	VMOV V26.S[2], V0.S[2]
+	VMOV V25.S[3], V0.S[3]
+	VMOV V24.S[1], V1.S[1]
+	VMOV V27.S[2], V1.S[2]
+	VMOV V26.S[3], V1.S[3]
+	VMOV V25.S[1], V2.S[1]
+	VMOV V24.S[2], V2.S[2]
+	VMOV V27.S[3], V2.S[3]
+	VMOV V26.S[1], V3.S[1]
+	VMOV V25.S[2], V3.S[2]
+	VMOV V24.S[3], V3.S[3]
+	VMOV V4.B16, V24.B16
+	VMOV V5.B16, V25.B16
+	VMOV V6.B16, V26.B16
+	VMOV V7.B16, V27.B16
+	VMOV V27.S[1], V4.S[1]
+	VMOV V26.S[2], V4.S[2]
+	VMOV V25.S[3], V4.S[3]
+	VMOV V24.S[1], V5.S[1]
+	VMOV V27.S[2], V5.S[2]
+	VMOV V26.S[3], V5.S[3]
+	VMOV V25.S[1], V6.S[1]
+	VMOV V24.S[2], V6.S[2]
+	VMOV V27.S[3], V6.S[3]
+	VMOV V26.S[1], V7.S[1]
+	VMOV V25.S[2], V7.S[2]
+	VMOV V24.S[3], V7.S[3]
+
+	// Round 12
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	SUB $64, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+
+	// Round 11
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	SUB $64, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+	VMOV V0.B16, V24.B16
+	VMOV V1.B16, V25.B16
+	VMOV V2.B16, V26.B16
+	VMOV V3.B16, V27.B16
+	VMOV V27.S[1], V0.S[1]
+	VMOV V26.S[2], V0.S[2]
+	VMOV V25.S[3], V0.S[3]
+	VMOV V24.S[1], V1.S[1]
+	VMOV V27.S[2], V1.S[2]
+	VMOV V26.S[3], V1.S[3]
+	VMOV V25.S[1], V2.S[1]
+	VMOV V24.S[2], V2.S[2]
+	VMOV V27.S[3], V2.S[3]
+	VMOV V26.S[1], V3.S[1]
+	VMOV V25.S[2], V3.S[2]
+	VMOV V24.S[3], V3.S[3]
+	VMOV V4.B16, V24.B16
+	VMOV V5.B16, V25.B16
+	VMOV V6.B16, V26.B16
+	VMOV V7.B16, V27.B16
+	VMOV V27.S[1], V4.S[1]
+	VMOV V26.S[2], V4.S[2]
+	VMOV V25.S[3], V4.S[3]
+	VMOV V24.S[1], V5.S[1]
+	VMOV V27.S[2], V5.S[2]
+	VMOV V26.S[3], V5.S[3]
+	VMOV V25.S[1], V6.S[1]
+	VMOV V24.S[2], V6.S[2]
+	VMOV V27.S[3], V6.S[3]
+	VMOV V26.S[1], V7.S[1]
+	VMOV V25.S[2], V7.S[2]
+	VMOV V24.S[3], V7.S[3]
+
+	// Round 10
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	SUB $64, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+
+	// Round 9
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	SUB $64, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+	VMOV V0.B16, V24.B16
+	VMOV V1.B16, V25.B16
+	VMOV V2.B16, V26.B16
+	VMOV V3.B16, V27.B16
+	VMOV V27.S[1], V0.S[1]
+	VMOV V26.S[2], V0.S[2]
+	VMOV V25.S[3], V0.S[3]
+	VMOV V24.S[1], V1.S[1]
+	VMOV V27.S[2], V1.S[2]
+	VMOV V26.S[3], V1.S[3]
+	VMOV V25.S[1], V2.S[1]
+	VMOV V24.S[2], V2.S[2]
+	VMOV V27.S[3], V2.S[3]
+	VMOV V26.S[1], V3.S[1]
+	VMOV V25.S[2], V3.S[2]
+	VMOV V24.S[3], V3.S[3]
+	VMOV V4.B16, V24.B16
+	VMOV V5.B16, V25.B16
+	VMOV V6.B16, V26.B16
+	VMOV V7.B16, V27.B16
+	VMOV V27.S[1], V4.S[1]
+	VMOV V26.S[2], V4.S[2]
+	VMOV V25.S[3], V4.S[3]
+	VMOV V24.S[1], V5.S[1]
+	VMOV V27.S[2], V5.S[2]
+	VMOV V26.S[3], V5.S[3]
+	VMOV V25.S[1], V6.S[1]
+	VMOV V24.S[2], V6.S[2]
+	VMOV V27.S[3], V6.S[3]
+	VMOV V26.S[1], V7.S[1]
+	VMOV V25.S[2], V7.S[2]
+	VMOV V24.S[3], V7.S[3]
+
+	// Round 8
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	SUB $64, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+
+	// Round 7
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	SUB $64, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+	VMOV V0.B16, V24.B16
+	VMOV V1.B16, V25.B16
+	VMOV V2.B16, V26.B16
+	VMOV V3.B16, V27.B16
+	VMOV V27.S[1], V0.S[1]
+	VMOV V26.S[2], V0.S[2]
+	VMOV V25.S[3], V0.S[3]
+	VMOV V24.S[1], V1.S[1]
+	VMOV V27.S[2], V1.S[2]
+	VMOV V26.S[3], V1.S[3]
+	VMOV V25.S[1], V2.S[1]
+	VMOV V24.S[2], V2.S[2]
+	VMOV V27.S[3], V2.S[3]
+	VMOV V26.S[1], V3.S[1]
+	VMOV V25.S[2], V3.S[2]
+	VMOV V24.S[3], V3.S[3]
+	VMOV V4.B16, V24.B16
+	VMOV V5.B16, V25.B16
+	VMOV V6.B16, V26.B16
+	VMOV V7.B16, V27.B16
+	VMOV V27.S[1], V4.S[1]
+	VMOV V26.S[2], V4.S[2]
+	VMOV V25.S[3], V4.S[3]
+	VMOV V24.S[1], V5.S[1]
+	VMOV V27.S[2], V5.S[2]
+	VMOV V26.S[3], V5.S[3]
+	VMOV V25.S[1], V6.S[1]
+	VMOV V24.S[2], V6.S[2]
+	VMOV V27.S[3], V6.S[3]
+	VMOV V26.S[1], V7.S[1]
+	VMOV V25.S[2], V7.S[2]
+	VMOV V24.S[3], V7.S[3]
+
+	// Round 6
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	SUB $64, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+
+	// Round 5
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	SUB $64, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+	VMOV V0.B16, V24.B16
+	VMOV V1.B16, V25.B16
+	VMOV V2.B16, V26.B16
+	VMOV V3.B16, V27.B16
+	VMOV V27.S[1], V0.S[1]
+	VMOV V26.S[2], V0.S[2]
+	VMOV V25.S[3], V0.S[3]
+	VMOV V24.S[1], V1.S[1]
+	VMOV V27.S[2], V1.S[2]
+	VMOV V26.S[3], V1.S[3]
+	VMOV V25.S[1], V2.S[1]
+	VMOV V24.S[2], V2.S[2]
+	VMOV V27.S[3], V2.S[3]
+	VMOV V26.S[1], V3.S[1]
+	VMOV V25.S[2], V3.S[2]
+	VMOV V24.S[3], V3.S[3]
+	VMOV V4.B16, V24.B16
+	VMOV V5.B16, V25.B16
+	VMOV V6.B16, V26.B16
+	VMOV V7.B16, V27.B16
+	VMOV V27.S[1], V4.S[1]
+	VMOV V26.S[2], V4.S[2]
+	VMOV V25.S[3], V4.S[3]
+	VMOV V24.S[1], V5.S[1]
+	VMOV V27.S[2], V5.S[2]
+	VMOV V26.S[3], V5.S[3]
+	VMOV V25.S[1], V6.S[1]
+	VMOV V24.S[2], V6.S[2]
+	VMOV V27.S[3], V6.S[3]
+	VMOV V26.S[1], V7.S[1]
+	VMOV V25.S[2], V7.S[2]
+	VMOV V24.S[3], V7.S[3]
+
+	// Round 4
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	SUB $64, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+
+	// Round 3
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	SUB $64, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+	VMOV V0.B16, V24.B16
+	VMOV V1.B16, V25.B16
+	VMOV V2.B16, V26.B16
+	VMOV V3.B16, V27.B16
+	VMOV V27.S[1], V0.S[1]
+	VMOV V26.S[2], V0.S[2]
+	VMOV V25.S[3], V0.S[3]
+	VMOV V24.S[1], V1.S[1]
+	VMOV V27.S[2], V1.S[2]
+	VMOV V26.S[3], V1.S[3]
+	VMOV V25.S[1], V2.S[1]
+	VMOV V24.S[2], V2.S[2]
+	VMOV V27.S[3], V2.S[3]
+	VMOV V26.S[1], V3.S[1]
+	VMOV V25.S[2], V3.S[2]
+	VMOV V24.S[3], V3.S[3]
+	VMOV V4.B16, V24.B16
+	VMOV V5.B16, V25.B16
+	VMOV V6.B16, V26.B16
+	VMOV V7.B16, V27.B16
+	VMOV V27.S[1], V4.S[1]
+	VMOV V26.S[2], V4.S[2]
+	VMOV V25.S[3], V4.S[3]
+	VMOV V24.S[1], V5.S[1]
+	VMOV V27.S[2], V5.S[2]
+	VMOV V26.S[3], V5.S[3]
+	VMOV V25.S[1], V6.S[1]
+	VMOV V24.S[2], V6.S[2]
+	VMOV V27.S[3], V6.S[3]
+	VMOV V26.S[1], V7.S[1]
+	VMOV V25.S[2], V7.S[2]
+	VMOV V24.S[3], V7.S[3]
+
+	// Round 2
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	SUB $64, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+
+	// Round 1
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	SUB $64, R6, R6
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESD V15.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V15.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESD V15.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V15.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESD V15.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESD V15.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESD V15.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V15.B16, V7.B16
+
+	// Round 0: rtk[0] ⊕ T
+	VTBL V14.B16, [V8.B16], V8.B16
+	VTBL V14.B16, [V9.B16], V9.B16
+	VLD1 (R6), [V10.B16, V11.B16, V12.B16, V13.B16]
+	VEOR V8.B16, V10.B16, V16.B16
+	VEOR V8.B16, V11.B16, V17.B16
+	VEOR V8.B16, V12.B16, V18.B16
+	VEOR V8.B16, V13.B16, V19.B16
+	VEOR V9.B16, V10.B16, V20.B16
+	VEOR V9.B16, V11.B16, V21.B16
+	VEOR V9.B16, V12.B16, V22.B16
+	VEOR V9.B16, V13.B16, V23.B16
+	VEOR V16.B16, V0.B16, V0.B16
+	VEOR V17.B16, V1.B16, V1.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	VEOR V23.B16, V7.B16, V7.B16
+
+	VST1.P [V0.B16, V1.B16, V2.B16, V3.B16], 64(R0)
+	VST1.P [V4.B16, V5.B16, V6.B16, V7.B16], 64(R0)
+	SUBS $2, R2, R2
+	BNE p512DecBlocks_loop
+	RET
@@ -0,0 +1,164 @@
+package aes
+
+// Batched Pholkos encryption of independent blocks, each with its own tweak.
+//
+// The round tweakeys are RTKʳ = Kʳ ⊕ τʳ(T) ⊕ RCʳ, which is linear in the tweak T.
+// Contexts therefore keep the round tweakeys for an all-zero tweak next to
+// the scheduled ones, and the batched functions derive each block's round
+// tweakeys from them on the fly. With VAES/AVX-512 several Pholkos states are
+// interleaved across vector lanes, and with ARM Crypto several states are
+// interleaved across registers; the remaining blocks use the single-block
+// hardware path.
+
+// pholkosCounterBatch is the number of counter tweaks generated at a time.
+const pholkosCounterBatch = 64
+
+// incTweak increments a tweak interpreted as a 128-bit little-endian counter.
+func incTweak(t *PholkosTweak) {
+	for i := range t {
+		t[i]++
+		if t[i] != 0 {
+			return
+		}
+	}
+}
+
+// EncryptBlocks encrypts blocks in place under the context's key, using
+// tweaks[i] as the tweak for blocks[i]. The tweak the context was created
+// with is ignored. It panics if len(tweaks) != len(blocks).
+func (ctx *Pholkos256Context) EncryptBlocks(blocks []Pholkos256Block, tweaks []PholkosTweak) {
+	if len(tweaks) != len(blocks) {
+		panic("aes: Pholkos EncryptBlocks requires one tweak per block")
+	}
+	if len(blocks) == 0 {
+		return
+	}
+	var c Pholkos256Context
+
+	i := pholkos256EncryptBlocksWide(blocks, tweaks, &ctx.krk)
+	for ; i < len(blocks); i++ {
+		c.setTweak(&ctx.krk, &tweaks[i])
+		c.EncryptHW(&blocks[i])
+	}
+}
+
+// DecryptBlocks decrypts blocks in place under the context's key, using
+// tweaks[i] as the tweak for blocks[i]. The tweak the context was created
+// with is ignored. It panics if len(tweaks) != len(blocks).
+func (ctx *Pholkos256Context) DecryptBlocks(blocks []Pholkos256Block, tweaks []PholkosTweak) {
+	if len(tweaks) != len(blocks) {
+		panic("aes: Pholkos DecryptBlocks requires one tweak per block")
+	}
+	if len(blocks) == 0 {
+		return
+	}
+	var c Pholkos256Context
+
+	i := pholkos256DecryptBlocksWide(blocks, tweaks, &ctx.krk)
+	for ; i < len(blocks); i++ {
+		c.setTweak(&ctx.krk, &tweaks[i])
+		c.DecryptHW(&blocks[i])
+	}
+}
+
+// EncryptBlocksCounter encrypts blocks in place, using start as the tweak of
+// the first block and incrementing it (as a 128-bit little-endian integer)
+// for each following block, as is usual for sector numbers.
+func (ctx *Pholkos256Context) EncryptBlocksCounter(blocks []Pholkos256Block, start *PholkosTweak) {
+	var tweaks [pholkosCounterBatch]PholkosTweak
+	t := *start
+	for len(blocks) > 0 {
+		n := min(len(blocks), pholkosCounterBatch)
+		for i := range n {
+			tweaks[i] = t
+			incTweak(&t)
+		}
+		ctx.EncryptBlocks(blocks[:n], tweaks[:n])
+		blocks = blocks[n:]
+	}
+}
+
+// DecryptBlocksCounter decrypts blocks encrypted with EncryptBlocksCounter.
+func (ctx *Pholkos256Context) DecryptBlocksCounter(blocks []Pholkos256Block, start *PholkosTweak) {
+	var tweaks [pholkosCounterBatch]PholkosTweak
+	t := *start
+	for len(blocks) > 0 {
+		n := min(len(blocks), pholkosCounterBatch)
+		for i := range n {
+			tweaks[i] = t
+			incTweak(&t)
+		}
+		ctx.DecryptBlocks(blocks[:n], tweaks[:n])
+		blocks = blocks[n:]
+	}
+}
+
+// EncryptBlocks encrypts blocks in place under the context's key, using
+// tweaks[i] as the tweak for blocks[i]. The tweak the context was created
+// with is ignored. It panics if len(tweaks) != len(blocks).
+func (ctx *Pholkos512Context) EncryptBlocks(blocks []Pholkos512Block, tweaks []PholkosTweak) {
+	if len(tweaks) != len(blocks) {
+		panic("aes: Pholkos EncryptBlocks requires one tweak per block")
+	}
+	if len(blocks) == 0 {
+		return
+	}
+	var c Pholkos512Context
+
+	i := pholkos512EncryptBlocksWide(blocks, tweaks, &ctx.krk)
+	for ; i < len(blocks); i++ {
+		c.setTweak(&ctx.krk, &tweaks[i])
+		c.EncryptHW(&blocks[i])
+	}
+}
+
+// DecryptBlocks decrypts blocks in place under the context's key, using
+// tweaks[i] as the tweak for blocks[i]. The tweak the context was created
+// with is ignored. It panics if len(tweaks) != len(blocks).
+func (ctx *Pholkos512Context) DecryptBlocks(blocks []Pholkos512Block, tweaks []PholkosTweak) {
+	if len(tweaks) != len(blocks) {
+		panic("aes: Pholkos DecryptBlocks requires one tweak per block")
+	}
+	if len(blocks) == 0 {
+		return
+	}
+	var c Pholkos512Context
+
+	i := pholkos512DecryptBlocksWide(blocks, tweaks, &ctx.krk)
+	for ; i < len(blocks); i++ {
+		c.setTweak(&ctx.krk, &tweaks[i])
+		c.DecryptHW(&blocks[i])
+	}
+}
+
+// EncryptBlocksCounter encrypts blocks in place, using start as the tweak of
+// the first block and incrementing it (as a 128-bit little-endian integer)
+// for each following block.
+func (ctx *Pholkos512Context) EncryptBlocksCounter(blocks []Pholkos512Block, start *PholkosTweak) {
+	var tweaks [pholkosCounterBatch]PholkosTweak
+	t := *start
+	for len(blocks) > 0 {
+		n := min(len(blocks), pholkosCounterBatch)
+		for i := range n {
+			tweaks[i] = t
+			incTweak(&t)
+		}
+		ctx.EncryptBlocks(blocks[:n], tweaks[:n])
+		blocks = blocks[n:]
+	}
+}
+
+// DecryptBlocksCounter decrypts blocks encrypted with EncryptBlocksCounter.
+func (ctx *Pholkos512Context) DecryptBlocksCounter(blocks []Pholkos512Block, start *PholkosTweak) {
+	var tweaks [pholkosCounterBatch]PholkosTweak
+	t := *start
+	for len(blocks) > 0 {
+		n := min(len(blocks), pholkosCounterBatch)
+		for i := range n {
+			tweaks[i] = t
+			incTweak(&t)
+		}
+		ctx.DecryptBlocks(blocks[:n], tweaks[:n])
+		blocks = blocks[n:]
+	}
+}
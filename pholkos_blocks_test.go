@@ -0,0 +1,242 @@
+package aes
+
+import (
+	"bytes"
+	"testing"
+)
+
+func pholkosTestTweaks(n int) []PholkosTweak {
+	tweaks := make([]PholkosTweak, n)
+	for i := range tweaks {
+		for j := range tweaks[i] {
+			tweaks[i][j] = byte(i*31 + j*7)
+		}
+	}
+	return tweaks
+}
+
+func TestPholkos256EncryptBlocks(t *testing.T) {
+	forEachCPUConfig(t, func(t *testing.T) {
+		var key Pholkos256Key
+		for i := range key {
+			key[i] = byte(i + 3)
+		}
+		ctx := NewPholkos256Context(&key, &PholkosTweak{0xff, 0xee})
+
+		for _, n := range []int{0, 1, 3, 4, 5, 8, 13, 64} {
+			tweaks := pholkosTestTweaks(n)
+			blocks := make([]Pholkos256Block, n)
+			for i := range blocks {
+				for j := range blocks[i] {
+					blocks[i][j] = byte(i ^ j*3)
+				}
+			}
+			original := bytes.Clone(pholkos256BlocksBytes(blocks))
+
+			// Reference: one context per block
+			expected := make([]Pholkos256Block, n)
+			copy(expected, blocks)
+			for i := range expected {
+				Pholkos256Encrypt(&expected[i], &key, &tweaks[i])
+			}
+
+			ctx.EncryptBlocks(blocks, tweaks)
+			for i := range blocks {
+				if blocks[i] != expected[i] {
+					t.Fatalf("n=%d block %d:\ngot:  %x\nwant: %x", n, i, blocks[i], expected[i])
+				}
+			}
+
+			ctx.DecryptBlocks(blocks, tweaks)
+			if !bytes.Equal(pholkos256BlocksBytes(blocks), original) {
+				t.Fatalf("n=%d: DecryptBlocks did not restore the plaintext", n)
+			}
+		}
+	})
+}
+
+func TestPholkos512EncryptBlocks(t *testing.T) {
+	forEachCPUConfig(t, func(t *testing.T) {
+		var key Pholkos256Key
+		var key512 Pholkos512Key
+		for i := range key {
+			key[i] = byte(i * 9)
+		}
+		for i := range key512 {
+			key512[i] = byte(i * 5)
+		}
+
+		for _, ctx := range []*Pholkos512Context{
+			NewPholkos512Context(&key, &PholkosTweak{1, 2, 3}),
+			NewPholkos512Context512(&key512, &PholkosTweak{4, 5, 6}),
+		} {
+			for _, n := range []int{0, 1, 2, 3, 7, 32} {
+				tweaks := pholkosTestTweaks(n)
+				blocks := make([]Pholkos512Block, n)
+				for i := range blocks {
+					for j := range blocks[i] {
+						blocks[i][j] = byte(i*13 + j)
+					}
+				}
+				original := append([]Pholkos512Block(nil), blocks...)
+
+				expected := append([]Pholkos512Block(nil), blocks...)
+				var c Pholkos512Context
+				for i := range expected {
+					c.setTweak(&ctx.krk, &tweaks[i])
+					c.Encrypt(&expected[i])
+				}
+
+				ctx.EncryptBlocks(blocks, tweaks)
+				for i := range blocks {
+					if blocks[i] != expected[i] {
+						t.Fatalf("n=%d block %d:\ngot:  %x\nwant: %x", n, i, blocks[i], expected[i])
+					}
+				}
+
+				ctx.DecryptBlocks(blocks, tweaks)
+				for i := range blocks {
+					if blocks[i] != original[i] {
+						t.Fatalf("n=%d block %d: DecryptBlocks did not restore the plaintext", n, i)
+					}
+				}
+			}
+		}
+	})
+}
+
+func TestPholkos512EncryptBlocksMatchesSingle(t *testing.T) {
+	var key Pholkos256Key
+	for i := range key {
+		key[i] = byte(0x55 ^ i)
+	}
+	tweaks := pholkosTestTweaks(6)
+	blocks := make([]Pholkos512Block, 6)
+	expected := make([]Pholkos512Block, 6)
+	for i := range blocks {
+		blocks[i][0] = byte(i)
+		expected[i] = blocks[i]
+		Pholkos512Encrypt(&expected[i], &key, &tweaks[i])
+	}
+	NewPholkos512Context(&key, &PholkosTweak{}).EncryptBlocks(blocks, tweaks)
+	for i := range blocks {
+		if blocks[i] != expected[i] {
+			t.Errorf("block %d mismatch", i)
+		}
+	}
+}
+
+func TestPholkosEncryptBlocksCounter(t *testing.T) {
+	forEachCPUConfig(t, func(t *testing.T) {
+		var key Pholkos256Key
+		ctx := NewPholkos256Context(&key, &PholkosTweak{})
+		ctx512 := NewPholkos512Context(&key, &PholkosTweak{})
+
+		// Start close to a carry across the first two bytes
+		start := PholkosTweak{0xfe, 0xff, 0x01}
+		n := 150
+		tweaks := make([]PholkosTweak, n)
+		tw := start
+		for i := range tweaks {
+			tweaks[i] = tw
+			incTweak(&tw)
+		}
+		if tweaks[2] != (PholkosTweak{0x00, 0x00, 0x02}) {
+			t.Fatalf("unexpected counter increment: %x", tweaks[2])
+		}
+
+		blocks := make([]Pholkos256Block, n)
+		expected := make([]Pholkos256Block, n)
+		for i := range blocks {
+			blocks[i][i%32] = byte(i)
+			expected[i] = blocks[i]
+		}
+		ctx.EncryptBlocks(expected, tweaks)
+		ctx.EncryptBlocksCounter(blocks, &start)
+		for i := range blocks {
+			if blocks[i] != expected[i] {
+				t.Fatalf("Pholkos-256 counter block %d mismatch", i)
+			}
+		}
+		ctx.DecryptBlocksCounter(blocks, &start)
+		for i := range blocks {
+			var want Pholkos256Block
+			want[i%32] = byte(i)
+			if blocks[i] != want {
+				t.Fatalf("Pholkos-256 counter decrypt block %d mismatch", i)
+			}
+		}
+
+		blocks512 := make([]Pholkos512Block, n)
+		expected512 := make([]Pholkos512Block, n)
+		ctx512.EncryptBlocks(expected512, tweaks)
+		ctx512.EncryptBlocksCounter(blocks512, &start)
+		for i := range blocks512 {
+			if blocks512[i] != expected512[i] {
+				t.Fatalf("Pholkos-512 counter block %d mismatch", i)
+			}
+		}
+		ctx512.DecryptBlocksCounter(blocks512, &start)
+		for i := range blocks512 {
+			if blocks512[i] != (Pholkos512Block{}) {
+				t.Fatalf("Pholkos-512 counter decrypt block %d mismatch", i)
+			}
+		}
+	})
+}
+
+func pholkos256BlocksBytes(blocks []Pholkos256Block) []byte {
+	out := make([]byte, 0, len(blocks)*32)
+	for i := range blocks {
+		out = append(out, blocks[i][:]...)
+	}
+	return out
+}
+
+func BenchmarkPholkos256EncryptBlocks(b *testing.B) {
+	ctx := NewPholkos256Context(&Pholkos256Key{}, &PholkosTweak{})
+	blocks := make([]Pholkos256Block, 128)
+	var start PholkosTweak
+
+	b.SetBytes(int64(len(blocks) * 32))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ctx.EncryptBlocksCounter(blocks, &start)
+	}
+}
+
+func BenchmarkPholkos256DecryptBlocks(b *testing.B) {
+	ctx := NewPholkos256Context(&Pholkos256Key{}, &PholkosTweak{})
+	blocks := make([]Pholkos256Block, 128)
+	var start PholkosTweak
+
+	b.SetBytes(int64(len(blocks) * 32))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ctx.DecryptBlocksCounter(blocks, &start)
+	}
+}
+
+func BenchmarkPholkos512EncryptBlocks(b *testing.B) {
+	ctx := NewPholkos512Context(&Pholkos256Key{}, &PholkosTweak{})
+	blocks := make([]Pholkos512Block, 64)
+	var start PholkosTweak
+
+	b.SetBytes(int64(len(blocks) * 64))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ctx.EncryptBlocksCounter(blocks, &start)
+	}
+}
+
+func BenchmarkPholkos512DecryptBlocks(b *testing.B) {
+	ctx := NewPholkos512Context(&Pholkos256Key{}, &PholkosTweak{})
+	blocks := make([]Pholkos512Block, 64)
+	var start PholkosTweak
+
+	b.SetBytes(int64(len(blocks) * 64))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ctx.DecryptBlocksCounter(blocks, &start)
+	}
+}
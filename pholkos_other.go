@@ -21,3 +21,20 @@ func (ctx *Pholkos512Context) EncryptHW(block *Pholkos512Block) {
 func (ctx *Pholkos512Context) DecryptHW(block *Pholkos512Block) {
 	ctx.Decrypt(block)
 }
+
+// No batched kernels on this platform; the caller processes every block.
+func pholkos256EncryptBlocksWide(blocks []Pholkos256Block, tweaks []PholkosTweak, rtk *[17][2]Block) int {
+	return 0
+}
+
+func pholkos256DecryptBlocksWide(blocks []Pholkos256Block, tweaks []PholkosTweak, rtk *[17][2]Block) int {
+	return 0
+}
+
+func pholkos512EncryptBlocksWide(blocks []Pholkos512Block, tweaks []PholkosTweak, rtk *[21][4]Block) int {
+	return 0
+}
+
+func pholkos512DecryptBlocksWide(blocks []Pholkos512Block, tweaks []PholkosTweak, rtk *[21][4]Block) int {
+	return 0
+}
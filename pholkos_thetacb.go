@@ -0,0 +1,318 @@
+package aes
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"unsafe"
+)
+
+// ΘCB3 authenticated encryption instantiated with Pholkos.
+//
+// ΘCB3 (Krovetz and Rogaway, "The Software Performance of Authenticated-Encryption
+// Modes", FSE 2011) turns a tweakable block cipher into a nonce-based AEAD with
+// one TBC call per block. With Pholkos-256 and Pholkos-512 the blocks are 32 and
+// 64 bytes, and the 128-bit tweak carries the nonce, the block index and a domain.
+//
+// For a message M = M1 ... Mm M* (|Mi| = n, |M*| < n) and associated data
+// A = A1 ... Aa A*:
+//
+//	Ci       = E(N, i, msg)(Mi)
+//	C*       = M* ⊕ E(N, m, pad)(0ⁿ)[:|M*|]                  if M* ≠ ε
+//	Checksum = M1 ⊕ ... ⊕ Mm (⊕ M*10*)
+//	Final    = E(N, m, tag)(Checksum), or E(N, m, tagPartial) if M* ≠ ε
+//	Auth     = ⊕ E(i, ad)(Ai) (⊕ E(a, adPartial)(A*10*))
+//	Tag      = (Final ⊕ Auth)[:32]
+//
+// Tweak encoding: nonce (12 bytes) || big-endian uint32(domain<<28 | index).
+// Associated data tweaks use an all-zero nonce field; their domains are distinct
+// from message domains, so the tweak sets are disjoint.
+
+const (
+	// PholkosAEADNonceSize is the nonce size of the Pholkos ΘCB3 AEADs.
+	PholkosAEADNonceSize = 12
+
+	// PholkosAEADTagSize is the tag size of the Pholkos ΘCB3 AEADs.
+	PholkosAEADTagSize = 32
+
+	// pholkosAEADMaxBlocks bounds the block indices of the message and of the
+	// associated data to the 28 bits of the tweak.
+	pholkosAEADMaxBlocks = 1<<28 - 1
+)
+
+// Tweak domains for ΘCB3
+const (
+	pholkosDomainMessage    = 1 // full message block (N, i)
+	pholkosDomainTag        = 2 // final tag, no partial block (N, m, $)
+	pholkosDomainPad        = 3 // pad for the partial block (N, m, *)
+	pholkosDomainTagPartial = 4 // final tag after a partial block (N, m, *$)
+	pholkosDomainAD         = 5 // full associated data block (i)
+	pholkosDomainADPartial  = 6 // partial associated data block (a, *)
+)
+
+var errPholkosAEADOpen = errors.New("aes: Pholkos AEAD message authentication failed")
+
+// pholkosTBC is a Pholkos instance with a fixed key and per-call tweaks.
+type pholkosTBC interface {
+	blockSize() int
+	encrypt(block []byte, tweak *PholkosTweak)
+	decrypt(block []byte, tweak *PholkosTweak)
+	encryptBlocks(blocks []byte, tweaks []PholkosTweak)
+	decryptBlocks(blocks []byte, tweaks []PholkosTweak)
+}
+
+// pholkosAEADBatch is the number of full blocks processed per batched call.
+const pholkosAEADBatch = 32
+
+// pholkos256TBC evaluates Pholkos-256 under a fixed key.
+type pholkos256TBC struct {
+	base Pholkos256Context // scheduled with a zero tweak
+}
+
+func (t *pholkos256TBC) blockSize() int { return 32 }
+
+func (t *pholkos256TBC) encrypt(block []byte, tweak *PholkosTweak) {
+	var ctx Pholkos256Context
+	ctx.setTweak(&t.base.krk, tweak)
+	ctx.EncryptHW((*Pholkos256Block)(block))
+}
+
+func (t *pholkos256TBC) decrypt(block []byte, tweak *PholkosTweak) {
+	var ctx Pholkos256Context
+	ctx.setTweak(&t.base.krk, tweak)
+	ctx.DecryptHW((*Pholkos256Block)(block))
+}
+
+func (t *pholkos256TBC) encryptBlocks(blocks []byte, tweaks []PholkosTweak) {
+	t.base.EncryptBlocks(unsafe.Slice((*Pholkos256Block)(blocks), len(tweaks)), tweaks)
+}
+
+func (t *pholkos256TBC) decryptBlocks(blocks []byte, tweaks []PholkosTweak) {
+	t.base.DecryptBlocks(unsafe.Slice((*Pholkos256Block)(blocks), len(tweaks)), tweaks)
+}
+
+// pholkos512TBC evaluates Pholkos-512 under a fixed key.
+type pholkos512TBC struct {
+	base Pholkos512Context // scheduled with a zero tweak
+}
+
+func (t *pholkos512TBC) blockSize() int { return 64 }
+
+func (t *pholkos512TBC) encrypt(block []byte, tweak *PholkosTweak) {
+	var ctx Pholkos512Context
+	ctx.setTweak(&t.base.krk, tweak)
+	ctx.EncryptHW((*Pholkos512Block)(block))
+}
+
+func (t *pholkos512TBC) decrypt(block []byte, tweak *PholkosTweak) {
+	var ctx Pholkos512Context
+	ctx.setTweak(&t.base.krk, tweak)
+	ctx.DecryptHW((*Pholkos512Block)(block))
+}
+
+func (t *pholkos512TBC) encryptBlocks(blocks []byte, tweaks []PholkosTweak) {
+	t.base.EncryptBlocks(unsafe.Slice((*Pholkos512Block)(blocks), len(tweaks)), tweaks)
+}
+
+func (t *pholkos512TBC) decryptBlocks(blocks []byte, tweaks []PholkosTweak) {
+	t.base.DecryptBlocks(unsafe.Slice((*Pholkos512Block)(blocks), len(tweaks)), tweaks)
+}
+
+// pholkosThetaCB implements cipher.AEAD using ΘCB3 over a Pholkos TBC.
+type pholkosThetaCB struct {
+	tbc pholkosTBC
+}
+
+// NewPholkos256AEAD returns ΘCB3 instantiated with Pholkos-256-256.
+func NewPholkos256AEAD(key *Pholkos256Key) cipher.AEAD {
+	t := &pholkos256TBC{}
+	t.base.Schedule(key, &PholkosTweak{})
+	return &pholkosThetaCB{tbc: t}
+}
+
+// NewPholkos512AEAD returns ΘCB3 instantiated with Pholkos-512-256.
+func NewPholkos512AEAD(key *Pholkos256Key) cipher.AEAD {
+	t := &pholkos512TBC{}
+	t.base.Schedule256(key, &PholkosTweak{})
+	return &pholkosThetaCB{tbc: t}
+}
+
+// NewPholkos512AEAD512 returns ΘCB3 instantiated with Pholkos-512-512.
+func NewPholkos512AEAD512(key *Pholkos512Key) cipher.AEAD {
+	t := &pholkos512TBC{}
+	t.base.Schedule512(key, &PholkosTweak{})
+	return &pholkosThetaCB{tbc: t}
+}
+
+// NonceSize returns the nonce size in bytes.
+func (a *pholkosThetaCB) NonceSize() int { return PholkosAEADNonceSize }
+
+// Overhead returns the tag size in bytes.
+func (a *pholkosThetaCB) Overhead() int { return PholkosAEADTagSize }
+
+// pholkosTweak encodes a nonce, domain and block index into a tweak.
+func pholkosTweak(tweak *PholkosTweak, nonce []byte, domain uint32, index uint64) {
+	if nonce != nil {
+		copy(tweak[:12], nonce)
+	} else {
+		clear(tweak[:12])
+	}
+	binary.BigEndian.PutUint32(tweak[12:], domain<<28|uint32(index))
+}
+
+// auth computes the associated data hash.
+func (a *pholkosThetaCB) auth(sum, ad []byte) {
+	n := a.tbc.blockSize()
+	buf := make([]byte, n)
+	var tweak PholkosTweak
+
+	i := uint64(0)
+	for ; len(ad) >= n; ad = ad[n:] {
+		i++
+		copy(buf, ad[:n])
+		pholkosTweak(&tweak, nil, pholkosDomainAD, i)
+		a.tbc.encrypt(buf, &tweak)
+		subtle.XORBytes(sum, sum, buf)
+	}
+	if len(ad) > 0 {
+		clear(buf)
+		copy(buf, ad)
+		buf[len(ad)] = 0x80
+		pholkosTweak(&tweak, nil, pholkosDomainADPartial, i)
+		a.tbc.encrypt(buf, &tweak)
+		subtle.XORBytes(sum, sum, buf)
+	}
+}
+
+// finalize computes the tag from the checksum and the associated data hash.
+func (a *pholkosThetaCB) finalize(tag, checksum, nonce, ad []byte, m uint64, partial bool) {
+	var tweak PholkosTweak
+	domain := uint32(pholkosDomainTag)
+	if partial {
+		domain = pholkosDomainTagPartial
+	}
+	pholkosTweak(&tweak, nonce, domain, m)
+	a.tbc.encrypt(checksum, &tweak)
+	a.auth(checksum, ad)
+	copy(tag, checksum[:PholkosAEADTagSize])
+}
+
+// tooLarge reports whether a message or associated data of the given lengths
+// would overflow the block index of the tweak.
+func (a *pholkosThetaCB) tooLarge(msgLen, adLen int) bool {
+	n := uint64(a.tbc.blockSize())
+	return uint64(msgLen)/n > pholkosAEADMaxBlocks || uint64(adLen)/n > pholkosAEADMaxBlocks
+}
+
+// Seal encrypts and authenticates plaintext, authenticates additionalData,
+// and appends the result to dst.
+func (a *pholkosThetaCB) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != PholkosAEADNonceSize {
+		panic("aes: incorrect nonce length given to Pholkos AEAD")
+	}
+	if a.tooLarge(len(plaintext), len(additionalData)) {
+		panic("aes: message too large for Pholkos AEAD")
+	}
+	n := a.tbc.blockSize()
+
+	ret, out := sliceForAppend(dst, len(plaintext)+PholkosAEADTagSize)
+	if inexactOverlap(out, plaintext) {
+		panic("aes: invalid buffer overlap")
+	}
+
+	checksum := make([]byte, n)
+	var tweak PholkosTweak
+
+	var tweaks [pholkosAEADBatch]PholkosTweak
+
+	m := uint64(0)
+	for len(plaintext) >= n {
+		k := min(len(plaintext)/n, pholkosAEADBatch)
+		for j := range k {
+			m++
+			subtle.XORBytes(checksum, checksum, plaintext[j*n:(j+1)*n])
+			pholkosTweak(&tweaks[j], nonce, pholkosDomainMessage, m)
+		}
+		copy(out, plaintext[:k*n])
+		a.tbc.encryptBlocks(out[:k*n], tweaks[:k])
+		plaintext = plaintext[k*n:]
+		out = out[k*n:]
+	}
+
+	partial := len(plaintext) > 0
+	if partial {
+		pad := make([]byte, n)
+		pholkosTweak(&tweak, nonce, pholkosDomainPad, m)
+		a.tbc.encrypt(pad, &tweak)
+
+		subtle.XORBytes(checksum, checksum, plaintext)
+		checksum[len(plaintext)] ^= 0x80
+		subtle.XORBytes(out, plaintext, pad[:len(plaintext)])
+		out = out[len(plaintext):]
+	}
+
+	a.finalize(out, checksum, nonce, additionalData, m, partial)
+	return ret
+}
+
+// Open authenticates and decrypts ciphertext, authenticates additionalData,
+// and appends the resulting plaintext to dst.
+func (a *pholkosThetaCB) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != PholkosAEADNonceSize {
+		panic("aes: incorrect nonce length given to Pholkos AEAD")
+	}
+	if len(ciphertext) < PholkosAEADTagSize || a.tooLarge(len(ciphertext)-PholkosAEADTagSize, len(additionalData)) {
+		return nil, errPholkosAEADOpen
+	}
+	n := a.tbc.blockSize()
+
+	tag := ciphertext[len(ciphertext)-PholkosAEADTagSize:]
+	ciphertext = ciphertext[:len(ciphertext)-PholkosAEADTagSize]
+
+	ret, out := sliceForAppend(dst, len(ciphertext))
+	if inexactOverlap(out, ciphertext) {
+		panic("aes: invalid buffer overlap")
+	}
+	plaintext := out
+
+	checksum := make([]byte, n)
+	var tweak PholkosTweak
+
+	var tweaks [pholkosAEADBatch]PholkosTweak
+
+	m := uint64(0)
+	for len(ciphertext) >= n {
+		k := min(len(ciphertext)/n, pholkosAEADBatch)
+		for j := range k {
+			m++
+			pholkosTweak(&tweaks[j], nonce, pholkosDomainMessage, m)
+		}
+		copy(out, ciphertext[:k*n])
+		a.tbc.decryptBlocks(out[:k*n], tweaks[:k])
+		for j := range k {
+			subtle.XORBytes(checksum, checksum, out[j*n:(j+1)*n])
+		}
+		ciphertext = ciphertext[k*n:]
+		out = out[k*n:]
+	}
+
+	partial := len(ciphertext) > 0
+	if partial {
+		pad := make([]byte, n)
+		pholkosTweak(&tweak, nonce, pholkosDomainPad, m)
+		a.tbc.encrypt(pad, &tweak)
+
+		subtle.XORBytes(out, ciphertext, pad[:len(ciphertext)])
+		subtle.XORBytes(checksum, checksum, out[:len(ciphertext)])
+		checksum[len(ciphertext)] ^= 0x80
+	}
+
+	var expected [PholkosAEADTagSize]byte
+	a.finalize(expected[:], checksum, nonce, additionalData, m, partial)
+	if subtle.ConstantTimeCompare(expected[:], tag) != 1 {
+		clear(plaintext)
+		return nil, errPholkosAEADOpen
+	}
+	return ret, nil
+}
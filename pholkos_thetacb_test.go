@@ -0,0 +1,309 @@
+package aes
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"encoding/hex"
+	"sync"
+	"testing"
+)
+
+func TestPholkosSetTweakMatchesSchedule(t *testing.T) {
+	var key Pholkos256Key
+	var key512 Pholkos512Key
+	var tweak PholkosTweak
+	for i := range key {
+		key[i] = byte(i * 5)
+	}
+	for i := range key512 {
+		key512[i] = byte(i * 3)
+	}
+	for i := range tweak {
+		tweak[i] = byte(0xf0 - i)
+	}
+
+	base256 := NewPholkos256Context(&key, &PholkosTweak{})
+	var ctx256 Pholkos256Context
+	ctx256.setTweak(&base256.krk, &tweak)
+	if ctx256.rtk != NewPholkos256Context(&key, &tweak).rtk {
+		t.Error("Pholkos-256 setTweak does not match Schedule")
+	}
+
+	base512 := NewPholkos512Context512(&key512, &PholkosTweak{})
+	var ctx512 Pholkos512Context
+	ctx512.setTweak(&base512.krk, &tweak)
+	if ctx512.rtk != NewPholkos512Context512(&key512, &tweak).rtk {
+		t.Error("Pholkos-512 setTweak does not match Schedule512")
+	}
+
+	// The cached zero-tweak round tweakeys do not depend on the tweak.
+	if base256.krk != NewPholkos256Context(&key, &tweak).krk || base256.krk != base256.rtk {
+		t.Error("Pholkos-256 zero-tweak round tweakeys depend on the tweak")
+	}
+	if base512.krk != NewPholkos512Context512(&key512, &tweak).krk || base512.krk != base512.rtk {
+		t.Error("Pholkos-512 zero-tweak round tweakeys depend on the tweak")
+	}
+}
+
+// thetaCBReference256 computes ΘCB3 with Pholkos-256 directly from the
+// specification, using the one-shot Pholkos256Encrypt function.
+func thetaCBReference256(key *Pholkos256Key, nonce, msg, ad []byte) []byte {
+	enc := func(block []byte, n []byte, domain uint32, i uint64) {
+		var tweak PholkosTweak
+		pholkosTweak(&tweak, n, domain, i)
+		Pholkos256Encrypt((*Pholkos256Block)(block), key, &tweak)
+	}
+
+	var out []byte
+	checksum := make([]byte, 32)
+	m := uint64(0)
+	for ; len(msg) >= 32; msg = msg[32:] {
+		m++
+		c := bytes.Clone(msg[:32])
+		for j := range c {
+			checksum[j] ^= c[j]
+		}
+		enc(c, nonce, pholkosDomainMessage, m)
+		out = append(out, c...)
+	}
+	domain := uint32(pholkosDomainTag)
+	if len(msg) > 0 {
+		pad := make([]byte, 32)
+		enc(pad, nonce, pholkosDomainPad, m)
+		for j := range msg {
+			out = append(out, msg[j]^pad[j])
+			checksum[j] ^= msg[j]
+		}
+		checksum[len(msg)] ^= 0x80
+		domain = pholkosDomainTagPartial
+	}
+	enc(checksum, nonce, domain, m)
+
+	a := uint64(0)
+	for ; len(ad) >= 32; ad = ad[32:] {
+		a++
+		b := bytes.Clone(ad[:32])
+		enc(b, nil, pholkosDomainAD, a)
+		for j := range b {
+			checksum[j] ^= b[j]
+		}
+	}
+	if len(ad) > 0 {
+		b := make([]byte, 32)
+		copy(b, ad)
+		b[len(ad)] = 0x80
+		enc(b, nil, pholkosDomainADPartial, a)
+		for j := range b {
+			checksum[j] ^= b[j]
+		}
+	}
+	return append(out, checksum[:PholkosAEADTagSize]...)
+}
+
+func TestPholkos256AEADMatchesReference(t *testing.T) {
+	var key Pholkos256Key
+	for i := range key {
+		key[i] = byte(i)
+	}
+	aead := NewPholkos256AEAD(&key)
+	nonce := []byte("pholkos-nonc")
+
+	for _, msgLen := range []int{0, 1, 31, 32, 33, 64, 100} {
+		for _, adLen := range []int{0, 5, 32, 70} {
+			msg := make([]byte, msgLen)
+			ad := make([]byte, adLen)
+			for i := range msg {
+				msg[i] = byte(i * 7)
+			}
+			for i := range ad {
+				ad[i] = byte(i * 11)
+			}
+			got := aead.Seal(nil, nonce, msg, ad)
+			want := thetaCBReference256(&key, nonce, msg, ad)
+			if !bytes.Equal(got, want) {
+				t.Errorf("msg=%d ad=%d: Seal mismatch\ngot:  %x\nwant: %x", msgLen, adLen, got, want)
+			}
+		}
+	}
+}
+
+func testPholkosAEADRoundtrip(t *testing.T, name string, aead cipher.AEAD) {
+	nonce := make([]byte, aead.NonceSize())
+	for i := range nonce {
+		nonce[i] = byte(i + 1)
+	}
+	for _, msgLen := range []int{0, 1, 15, 16, 31, 32, 33, 63, 64, 65, 200, 1000} {
+		msg := make([]byte, msgLen)
+		for i := range msg {
+			msg[i] = byte(i)
+		}
+		ad := []byte("associated data")
+
+		sealed := aead.Seal([]byte("prefix"), nonce, msg, ad)
+		if len(sealed) != 6+msgLen+aead.Overhead() {
+			t.Fatalf("%s: sealed length %d", name, len(sealed))
+		}
+		opened, err := aead.Open(nil, nonce, sealed[6:], ad)
+		if err != nil {
+			t.Fatalf("%s msg=%d: Open failed: %v", name, msgLen, err)
+		}
+		if !bytes.Equal(opened, msg) {
+			t.Errorf("%s msg=%d: roundtrip mismatch", name, msgLen)
+		}
+
+		// In-place
+		buf := append([]byte(nil), msg...)
+		ct := aead.Seal(buf[:0], nonce, buf, ad)
+		pt, err := aead.Open(ct[:0], nonce, ct, ad)
+		if err != nil || !bytes.Equal(pt, msg) {
+			t.Errorf("%s msg=%d: in-place roundtrip failed", name, msgLen)
+		}
+
+		// Any modification must be rejected
+		for _, pos := range []int{0, len(sealed) - 7, len(sealed) - 1} {
+			if pos < 6 {
+				continue
+			}
+			bad := bytes.Clone(sealed[6:])
+			bad[pos-6] ^= 1
+			if _, err := aead.Open(nil, nonce, bad, ad); err == nil {
+				t.Errorf("%s msg=%d: forgery at %d accepted", name, msgLen, pos)
+			}
+		}
+		if _, err := aead.Open(nil, nonce, sealed[6:], []byte("other data")); err == nil {
+			t.Errorf("%s msg=%d: wrong AD accepted", name, msgLen)
+		}
+		otherNonce := bytes.Clone(nonce)
+		otherNonce[0] ^= 1
+		if _, err := aead.Open(nil, otherNonce, sealed[6:], ad); err == nil {
+			t.Errorf("%s msg=%d: wrong nonce accepted", name, msgLen)
+		}
+	}
+}
+
+func TestPholkosAEADRoundtrip(t *testing.T) {
+	var key Pholkos256Key
+	var key512 Pholkos512Key
+	for i := range key {
+		key[i] = byte(0x40 + i)
+	}
+	for i := range key512 {
+		key512[i] = byte(0x80 + i)
+	}
+	testPholkosAEADRoundtrip(t, "Pholkos-256", NewPholkos256AEAD(&key))
+	testPholkosAEADRoundtrip(t, "Pholkos-512-256", NewPholkos512AEAD(&key))
+	testPholkosAEADRoundtrip(t, "Pholkos-512-512", NewPholkos512AEAD512(&key512))
+}
+
+func TestPholkosAEADShortCiphertext(t *testing.T) {
+	aead := NewPholkos256AEAD(&Pholkos256Key{})
+	if _, err := aead.Open(nil, make([]byte, 12), make([]byte, 31), nil); err == nil {
+		t.Error("expected error for ciphertext shorter than the tag")
+	}
+}
+
+func TestPholkosAEADLimits(t *testing.T) {
+	a := NewPholkos256AEAD(&Pholkos256Key{}).(*pholkosThetaCB)
+	limit := 32 * (pholkosAEADMaxBlocks + 1)
+	if a.tooLarge(limit-1, limit-1) {
+		t.Error("lengths within the tweak index range rejected")
+	}
+	if !a.tooLarge(limit, 0) {
+		t.Error("message overflowing the tweak index accepted")
+	}
+	if !a.tooLarge(0, limit) {
+		t.Error("associated data overflowing the tweak index accepted")
+	}
+}
+
+func TestPholkosAEADConcurrent(t *testing.T) {
+	var key Pholkos256Key
+	aead := NewPholkos256AEAD(&key)
+	nonce := make([]byte, PholkosAEADNonceSize)
+	msg := make([]byte, 100)
+	want := aead.Seal(nil, nonce, msg, nil)
+
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 50 {
+				ct := aead.Seal(nil, nonce, msg, []byte{byte(g), byte(i)})
+				if _, err := aead.Open(nil, nonce, ct, []byte{byte(g), byte(i)}); err != nil {
+					t.Error(err)
+					return
+				}
+				if !bytes.Equal(aead.Seal(nil, nonce, msg, nil), want) {
+					t.Error("concurrent Seal gave a different result")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// The Pholkos authors publish no ΘCB3 test vectors, so these values are
+// generated by this implementation. They pin the tweak encoding and padding.
+func TestPholkosAEADKnownAnswer(t *testing.T) {
+	var key Pholkos256Key
+	var key512 Pholkos512Key
+	for i := range key {
+		key[i] = byte(i)
+	}
+	for i := range key512 {
+		key512[i] = byte(i)
+	}
+	nonce := make([]byte, 12)
+	for i := range nonce {
+		nonce[i] = byte(0xa0 + i)
+	}
+	msg := make([]byte, 77)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+	ad := []byte("Pholkos ThetaCB3")
+
+	tests := []struct {
+		name string
+		aead cipher.AEAD
+		want string
+	}{
+		{"Pholkos-256", NewPholkos256AEAD(&key), "ae41b43fbb654e412a48a2c5798ef6fe74b858e809fd88cd05bb732dca2229005f70561e4cece0189c7e5e23c6162f461f86fdc5d8fdf43d802bd11c846b06da49799b84a2b7b96bae72999403857a8c2c23cd47a734dc3b2b7562e54e52a68f0f0b8d47f93a61b28221e755be"},
+		{"Pholkos-512-256", NewPholkos512AEAD(&key), "025e674135faeeb3d95bc2d76d89999aaeb7bcecb1fcbf5ea090bbade910fbd55b3a16836527a83c97e561dddd2cff82cce2209730659bd86647be0406fa539bb2441aa41428bf5e5a320d5ed6d18d416f5ab6900464d1c939168bf56d6b194ede9dd7c3745e0761dcc4852c0e"},
+		{"Pholkos-512-512", NewPholkos512AEAD512(&key512), "d0190d117973058fbac8e71e9f9698d2e8a9730048c7f8cb49127f0467d1591c3bdd967c8113cf2eabb4d4a3868060a953d090b87062dfe52b6866253f82174fd12563cf83cda14addfc14696f5c22612b135114f7fa963f19d58419c5598a336132850094b558ac015d37d6e6"},
+	}
+	for _, tt := range tests {
+		got := hex.EncodeToString(tt.aead.Seal(nil, nonce, msg, ad))
+		if got != tt.want {
+			t.Errorf("%s:\ngot:  %s\nwant: %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func BenchmarkPholkos256AEADSeal(b *testing.B) {
+	aead := NewPholkos256AEAD(&Pholkos256Key{})
+	nonce := make([]byte, 12)
+	msg := make([]byte, 4096)
+	out := make([]byte, 0, len(msg)+aead.Overhead())
+
+	b.SetBytes(int64(len(msg)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		aead.Seal(out, nonce, msg, nil)
+	}
+}
+
+func BenchmarkPholkos512AEADSeal(b *testing.B) {
+	aead := NewPholkos512AEAD(&Pholkos256Key{})
+	nonce := make([]byte, 12)
+	msg := make([]byte, 4096)
+	out := make([]byte, 0, len(msg)+aead.Overhead())
+
+	b.SetBytes(int64(len(msg)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		aead.Seal(out, nonce, msg, nil)
+	}
+}
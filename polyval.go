@@ -0,0 +1,135 @@
+package aes
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// POLYVAL is the universal hash function of AES-GCM-SIV (RFC 8452) and HCTR2.
+// It operates in GF(2^128) defined by x^128 + x^127 + x^126 + x^121 + 1, with
+// little-endian block encoding, and computes
+//
+//	S_0 = 0,  S_j = dot(S_{j-1} ⊕ X_j, H),  where dot(a, b) = a·b·x^-128.
+//
+// The hardware paths use PCLMULQDQ (amd64) or PMULL (arm64) and aggregate
+// several blocks per reduction using precomputed powers of H.
+
+// PolyvalBlockSize is the POLYVAL block size in bytes.
+const PolyvalBlockSize = 16
+
+// polyvalPowers is the number of precomputed key powers.
+const polyvalPowers = 8
+
+// polyvalReduce is x^63 + x^62 + x^57, the constant used by the Montgomery
+// reduction modulo the POLYVAL polynomial.
+const polyvalReduce = 0xc200000000000000
+
+// Polyval computes POLYVAL over a sequence of 16-byte blocks.
+// The zero value is not usable; create instances with NewPolyval.
+type Polyval struct {
+	// powers[i] holds H^(8-i) in Montgomery form, so that dot(X, powers[i])
+	// multiplies by H^(8-i)·x^(-128·(8-i)). powers[7] is H.
+	powers [polyvalPowers]Block
+	acc    Block
+}
+
+// NewPolyval returns a POLYVAL instance keyed with h.
+func NewPolyval(h *Block) *Polyval {
+	p := &Polyval{}
+	p.init(h)
+	return p
+}
+
+func (p *Polyval) init(h *Block) {
+	p.powers[polyvalPowers-1] = *h
+	for i := polyvalPowers - 2; i >= 0; i-- {
+		polyvalDot(&p.powers[i], &p.powers[i+1], h)
+	}
+	p.acc = Block{}
+}
+
+// Reset clears the accumulator, keeping the key.
+func (p *Polyval) Reset() {
+	p.acc = Block{}
+}
+
+// Update absorbs data, whose length must be a multiple of 16 bytes.
+func (p *Polyval) Update(data []byte) {
+	if len(data)%PolyvalBlockSize != 0 {
+		panic("aes: POLYVAL input length must be a multiple of 16 bytes")
+	}
+	if len(data) > 0 {
+		polyvalBlocks(&p.acc, &p.powers, data)
+	}
+}
+
+// Sum returns the current POLYVAL value. It does not change the state.
+func (p *Polyval) Sum() Block {
+	return p.acc
+}
+
+// polyvalDot computes dst = a·b·x^-128 in the POLYVAL field.
+func polyvalDot(dst, a, b *Block) {
+	a0 := binary.LittleEndian.Uint64(a[:8])
+	a1 := binary.LittleEndian.Uint64(a[8:])
+	b0 := binary.LittleEndian.Uint64(b[:8])
+	b1 := binary.LittleEndian.Uint64(b[8:])
+
+	// Karatsuba multiplication into a 256-bit product d3:d2:d1:d0
+	l1, l0 := clmul64(a0, b0)
+	h1, h0 := clmul64(a1, b1)
+	m1, m0 := clmul64(a0^a1, b0^b1)
+	m0 ^= l0 ^ h0
+	m1 ^= l1 ^ h1
+	d0, d1, d2, d3 := l0, l1^m0, h0^m1, h1
+
+	r0, r1 := polyvalMontgomery(d0, d1, d2, d3)
+	binary.LittleEndian.PutUint64(dst[:8], r0)
+	binary.LittleEndian.PutUint64(dst[8:], r1)
+}
+
+// polyvalMontgomery returns d·x^-128 mod P for a 256-bit d. Since P ≡ 1 mod x^64,
+// adding d0·P clears the lowest word; this is done twice.
+func polyvalMontgomery(d0, d1, d2, d3 uint64) (uint64, uint64) {
+	// d0·(x^127 + x^126 + x^121) = d0·polyvalReduce·x^64
+	d1 ^= d0<<63 ^ d0<<62 ^ d0<<57
+	d2 ^= d0>>1 ^ d0>>2 ^ d0>>7 ^ d0
+	d2 ^= d1<<63 ^ d1<<62 ^ d1<<57
+	d3 ^= d1>>1 ^ d1>>2 ^ d1>>7 ^ d1
+	return d2, d3
+}
+
+// clmul64 returns the 128-bit carry-less product of x and y in constant time.
+func clmul64(x, y uint64) (hi, lo uint64) {
+	lo = bmul64(x, y)
+	hi = bits.Reverse64(bmul64(bits.Reverse64(x), bits.Reverse64(y))) >> 1
+	return
+}
+
+// bmul64 returns the low 64 bits of the carry-less product of x and y.
+// Integer multiplications are done on operands with holes of 3 bits, so
+// that carries never reach a position of the same residue class.
+func bmul64(x, y uint64) uint64 {
+	const (
+		m0 = 0x1111111111111111
+		m1 = 0x2222222222222222
+		m2 = 0x4444444444444444
+		m3 = 0x8888888888888888
+	)
+	x0, x1, x2, x3 := x&m0, x&m1, x&m2, x&m3
+	y0, y1, y2, y3 := y&m0, y&m1, y&m2, y&m3
+	z0 := (x0 * y0) ^ (x1 * y3) ^ (x2 * y2) ^ (x3 * y1)
+	z1 := (x0 * y1) ^ (x1 * y0) ^ (x2 * y3) ^ (x3 * y2)
+	z2 := (x0 * y2) ^ (x1 * y1) ^ (x2 * y0) ^ (x3 * y3)
+	z3 := (x0 * y3) ^ (x1 * y2) ^ (x2 * y1) ^ (x3 * y0)
+	return (z0 & m0) | (z1 & m1) | (z2 & m2) | (z3 & m3)
+}
+
+// polyvalBlocksGeneric absorbs full blocks one at a time.
+func polyvalBlocksGeneric(acc *Block, h *Block, data []byte) {
+	for len(data) >= PolyvalBlockSize {
+		xorBlocks(acc, (*Block)(data))
+		polyvalDot(acc, acc, h)
+		data = data[PolyvalBlockSize:]
+	}
+}
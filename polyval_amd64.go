@@ -0,0 +1,14 @@
+//go:build !purego
+
+package aes
+
+//go:noescape
+func polyvalBlocksCLMUL(acc *Block, powers *[polyvalPowers]Block, data *byte, n int)
+
+func polyvalBlocks(acc *Block, powers *[polyvalPowers]Block, data []byte) {
+	if !CPU.HasPCLMULQDQ {
+		polyvalBlocksGeneric(acc, &powers[polyvalPowers-1], data)
+		return
+	}
+	polyvalBlocksCLMUL(acc, powers, &data[0], len(data)/PolyvalBlockSize)
+}
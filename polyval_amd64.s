@@ -0,0 +1,205 @@
+//go:build !purego
+
+#include "textflag.h"
+
+// x^63 + x^62 + x^57, for the Montgomery reduction modulo the POLYVAL polynomial
+DATA polyval_reduce<>+0x00(SB)/8, $0xc200000000000000
+DATA polyval_reduce<>+0x08(SB)/8, $0x0000000000000000
+GLOBL polyval_reduce<>(SB), RODATA|NOPTR, $16
+
+// func polyvalBlocksCLMUL(acc *Block, powers *[8]Block, data *byte, n int)
+TEXT ·polyvalBlocksCLMUL(SB), NOSPLIT, $0-32
+	MOVQ acc+0(FP), DI
+	MOVQ powers+8(FP), SI
+	MOVQ data+16(FP), DX
+	MOVQ n+24(FP), CX
+	MOVOU (DI), X0
+	MOVOU polyval_reduce<>(SB), X9
+
+	CMPQ CX, $8
+	JB   single
+
+loop8:
+	// S = (S ⊕ X1)·H^8 ⊕ X2·H^7 ⊕ ... ⊕ X8·H, with a single reduction
+	PXOR X1, X1
+	PXOR X2, X2
+	PXOR X3, X3
+	MOVOU 0(DX), X4
+	PXOR X0, X4
+	MOVOU 0(SI), X5
+	MOVOU X4, X6
+	PCLMULQDQ $0x00, X5, X6
+	MOVOU X4, X7
+	PCLMULQDQ $0x11, X5, X7
+	MOVOU X4, X8
+	PCLMULQDQ $0x01, X5, X8
+	PCLMULQDQ $0x10, X5, X4
+	PXOR X6, X1
+	PXOR X7, X2
+	PXOR X8, X3
+	PXOR X4, X3
+	MOVOU 16(DX), X4
+	MOVOU 16(SI), X5
+	MOVOU X4, X6
+	PCLMULQDQ $0x00, X5, X6
+	MOVOU X4, X7
+	PCLMULQDQ $0x11, X5, X7
+	MOVOU X4, X8
+	PCLMULQDQ $0x01, X5, X8
+	PCLMULQDQ $0x10, X5, X4
+	PXOR X6, X1
+	PXOR X7, X2
+	PXOR X8, X3
+	PXOR X4, X3
+	MOVOU 32(DX), X4
+	MOVOU 32(SI), X5
+	MOVOU X4, X6
+	PCLMULQDQ $0x00, X5, X6
+	MOVOU X4, X7
+	PCLMULQDQ $0x11, X5, X7
+	MOVOU X4, X8
+	PCLMULQDQ $0x01, X5, X8
+	PCLMULQDQ $0x10, X5, X4
+	PXOR X6, X1
+	PXOR X7, X2
+	PXOR X8, X3
+	PXOR X4, X3
+	MOVOU 48(DX), X4
+	MOVOU 48(SI), X5
+	MOVOU X4, X6
+	PCLMULQDQ $0x00, X5, X6
+	MOVOU X4, X7
+	PCLMULQDQ $0x11, X5, X7
+	MOVOU X4, X8
+	PCLMULQDQ $0x01, X5, X8
+	PCLMULQDQ $0x10, X5, X4
+	PXOR X6, X1
+	PXOR X7, X2
+	PXOR X8, X3
+	PXOR X4, X3
+	MOVOU 64(DX), X4
+	MOVOU 64(SI), X5
+	MOVOU X4, X6
+	PCLMULQDQ $0x00, X5, X6
+	MOVOU X4, X7
+	PCLMULQDQ $0x11, X5, X7
+	MOVOU X4, X8
+	PCLMULQDQ $0x01, X5, X8
+	PCLMULQDQ $0x10, X5, X4
+	PXOR X6, X1
+	PXOR X7, X2
+	PXOR X8, X3
+	PXOR X4, X3
+	MOVOU 80(DX), X4
+	MOVOU 80(SI), X5
+	MOVOU X4, X6
+	PCLMULQDQ $0x00, X5, X6
+	MOVOU X4, X7
+	PCLMULQDQ $0x11, X5, X7
+	MOVOU X4, X8
+	PCLMULQDQ $0x01, X5, X8
+	PCLMULQDQ $0x10, X5, X4
+	PXOR X6, X1
+	PXOR X7, X2
+	PXOR X8, X3
+	PXOR X4, X3
+	MOVOU 96(DX), X4
+	MOVOU 96(SI), X5
+	MOVOU X4, X6
+	PCLMULQDQ $0x00, X5, X6
+	MOVOU X4, X7
+	PCLMULQDQ $0x11, X5, X7
+	MOVOU X4, X8
+	PCLMULQDQ $0x01, X5, X8
+	PCLMULQDQ $0x10, X5, X4
+	PXOR X6, X1
+	PXOR X7, X2
+	PXOR X8, X3
+	PXOR X4, X3
+	MOVOU 112(DX), X4
+	MOVOU 112(SI), X5
+	MOVOU X4, X6
+	PCLMULQDQ $0x00, X5, X6
+	MOVOU X4, X7
+	PCLMULQDQ $0x11, X5, X7
+	MOVOU X4, X8
+	PCLMULQDQ $0x01, X5, X8
+	PCLMULQDQ $0x10, X5, X4
+	PXOR X6, X1
+	PXOR X7, X2
+	PXOR X8, X3
+	PXOR X4, X3
+
+	// Fold the middle product into lo = X1 and hi = X2
+	MOVOU X3, X4
+	PSLLDQ $8, X4
+	PXOR X4, X1
+	PSRLDQ $8, X3
+	PXOR X3, X2
+
+	// Montgomery reduction: clear the low word twice (P ≡ 1 mod x^64)
+	MOVOU X1, X4
+	PCLMULQDQ $0x00, X9, X4
+	PSHUFD $0x4e, X1, X1
+	PXOR X4, X1
+	MOVOU X1, X4
+	PCLMULQDQ $0x00, X9, X4
+	PSHUFD $0x4e, X1, X1
+	PXOR X4, X1
+	PXOR X2, X1
+	MOVOU X1, X0
+
+	ADDQ $128, DX
+	SUBQ $8, CX
+	CMPQ CX, $8
+	JAE  loop8
+
+single:
+	TESTQ CX, CX
+	JZ    done
+	MOVOU 112(SI), X5
+
+loop1:
+	MOVOU (DX), X4
+	PXOR X0, X4
+	PXOR X1, X1
+	PXOR X2, X2
+	PXOR X3, X3
+	MOVOU X4, X6
+	PCLMULQDQ $0x00, X5, X6
+	MOVOU X4, X7
+	PCLMULQDQ $0x11, X5, X7
+	MOVOU X4, X8
+	PCLMULQDQ $0x01, X5, X8
+	PCLMULQDQ $0x10, X5, X4
+	PXOR X6, X1
+	PXOR X7, X2
+	PXOR X8, X3
+	PXOR X4, X3
+
+	// Fold the middle product into lo = X1 and hi = X2
+	MOVOU X3, X4
+	PSLLDQ $8, X4
+	PXOR X4, X1
+	PSRLDQ $8, X3
+	PXOR X3, X2
+
+	// Montgomery reduction: clear the low word twice (P ≡ 1 mod x^64)
+	MOVOU X1, X4
+	PCLMULQDQ $0x00, X9, X4
+	PSHUFD $0x4e, X1, X1
+	PXOR X4, X1
+	MOVOU X1, X4
+	PCLMULQDQ $0x00, X9, X4
+	PSHUFD $0x4e, X1, X1
+	PXOR X4, X1
+	PXOR X2, X1
+	MOVOU X1, X0
+
+	ADDQ $16, DX
+	DECQ CX
+	JNZ  loop1
+
+done:
+	MOVOU X0, (DI)
+	RET
@@ -0,0 +1,14 @@
+//go:build !purego
+
+package aes
+
+//go:noescape
+func polyvalBlocksPMULL(acc *Block, powers *[polyvalPowers]Block, data *byte, n int)
+
+func polyvalBlocks(acc *Block, powers *[polyvalPowers]Block, data []byte) {
+	if !CPU.HasPMULL {
+		polyvalBlocksGeneric(acc, &powers[polyvalPowers-1], data)
+		return
+	}
+	polyvalBlocksPMULL(acc, powers, &data[0], len(data)/PolyvalBlockSize)
+}
@@ -0,0 +1,164 @@
+//go:build !purego
+
+#include "textflag.h"
+
+// func polyvalBlocksPMULL(acc *Block, powers *[8]Block, data *byte, n int)
+TEXT ·polyvalBlocksPMULL(SB), NOSPLIT, $0-32
+	MOVD acc+0(FP), R0
+	MOVD powers+8(FP), R1
+	MOVD data+16(FP), R2
+	MOVD n+24(FP), R3
+
+	VLD1 (R0), [V0.B16]
+	VLD1.P 64(R1), [V11.B16, V12.B16, V13.B16, V14.B16]
+	VLD1 (R1), [V15.B16, V16.B16, V17.B16, V18.B16]
+
+	// V9 = x^63 + x^62 + x^57, V10 = 0
+	MOVD $0xc200000000000000, R4
+	VEOR V9.B16, V9.B16, V9.B16
+	VMOV R4, V9.D[0]
+	VEOR V10.B16, V10.B16, V10.B16
+
+	CMP $8, R3
+	BLT single
+
+loop8:
+	// S = (S ⊕ X1)·H^8 ⊕ X2·H^7 ⊕ ... ⊕ X8·H, with a single reduction
+	VLD1.P 64(R2), [V19.B16, V20.B16, V21.B16, V22.B16]
+	VLD1.P 64(R2), [V23.B16, V24.B16, V25.B16, V26.B16]
+	VEOR V0.B16, V19.B16, V19.B16
+	VEOR    V1.B16, V1.B16, V1.B16
+	VEOR    V2.B16, V2.B16, V2.B16
+	VEOR    V3.B16, V3.B16, V3.B16
+	VEXT    $8, V11.B16, V11.B16, V8.B16
+	VPMULL  V11.D1, V19.D1, V6.Q1
+	VPMULL2 V11.D2, V19.D2, V7.Q1
+	VEOR    V6.B16, V1.B16, V1.B16
+	VEOR    V7.B16, V2.B16, V2.B16
+	VPMULL  V8.D1, V19.D1, V6.Q1
+	VPMULL2 V8.D2, V19.D2, V7.Q1
+	VEOR    V6.B16, V3.B16, V3.B16
+	VEOR    V7.B16, V3.B16, V3.B16
+	VEXT    $8, V12.B16, V12.B16, V8.B16
+	VPMULL  V12.D1, V20.D1, V6.Q1
+	VPMULL2 V12.D2, V20.D2, V7.Q1
+	VEOR    V6.B16, V1.B16, V1.B16
+	VEOR    V7.B16, V2.B16, V2.B16
+	VPMULL  V8.D1, V20.D1, V6.Q1
+	VPMULL2 V8.D2, V20.D2, V7.Q1
+	VEOR    V6.B16, V3.B16, V3.B16
+	VEOR    V7.B16, V3.B16, V3.B16
+	VEXT    $8, V13.B16, V13.B16, V8.B16
+	VPMULL  V13.D1, V21.D1, V6.Q1
+	VPMULL2 V13.D2, V21.D2, V7.Q1
+	VEOR    V6.B16, V1.B16, V1.B16
+	VEOR    V7.B16, V2.B16, V2.B16
+	VPMULL  V8.D1, V21.D1, V6.Q1
+	VPMULL2 V8.D2, V21.D2, V7.Q1
+	VEOR    V6.B16, V3.B16, V3.B16
+	VEOR    V7.B16, V3.B16, V3.B16
+	VEXT    $8, V14.B16, V14.B16, V8.B16
+	VPMULL  V14.D1, V22.D1, V6.Q1
+	VPMULL2 V14.D2, V22.D2, V7.Q1
+	VEOR    V6.B16, V1.B16, V1.B16
+	VEOR    V7.B16, V2.B16, V2.B16
+	VPMULL  V8.D1, V22.D1, V6.Q1
+	VPMULL2 V8.D2, V22.D2, V7.Q1
+	VEOR    V6.B16, V3.B16, V3.B16
+	VEOR    V7.B16, V3.B16, V3.B16
+	VEXT    $8, V15.B16, V15.B16, V8.B16
+	VPMULL  V15.D1, V23.D1, V6.Q1
+	VPMULL2 V15.D2, V23.D2, V7.Q1
+	VEOR    V6.B16, V1.B16, V1.B16
+	VEOR    V7.B16, V2.B16, V2.B16
+	VPMULL  V8.D1, V23.D1, V6.Q1
+	VPMULL2 V8.D2, V23.D2, V7.Q1
+	VEOR    V6.B16, V3.B16, V3.B16
+	VEOR    V7.B16, V3.B16, V3.B16
+	VEXT    $8, V16.B16, V16.B16, V8.B16
+	VPMULL  V16.D1, V24.D1, V6.Q1
+	VPMULL2 V16.D2, V24.D2, V7.Q1
+	VEOR    V6.B16, V1.B16, V1.B16
+	VEOR    V7.B16, V2.B16, V2.B16
+	VPMULL  V8.D1, V24.D1, V6.Q1
+	VPMULL2 V8.D2, V24.D2, V7.Q1
+	VEOR    V6.B16, V3.B16, V3.B16
+	VEOR    V7.B16, V3.B16, V3.B16
+	VEXT    $8, V17.B16, V17.B16, V8.B16
+	VPMULL  V17.D1, V25.D1, V6.Q1
+	VPMULL2 V17.D2, V25.D2, V7.Q1
+	VEOR    V6.B16, V1.B16, V1.B16
+	VEOR    V7.B16, V2.B16, V2.B16
+	VPMULL  V8.D1, V25.D1, V6.Q1
+	VPMULL2 V8.D2, V25.D2, V7.Q1
+	VEOR    V6.B16, V3.B16, V3.B16
+	VEOR    V7.B16, V3.B16, V3.B16
+	VEXT    $8, V18.B16, V18.B16, V8.B16
+	VPMULL  V18.D1, V26.D1, V6.Q1
+	VPMULL2 V18.D2, V26.D2, V7.Q1
+	VEOR    V6.B16, V1.B16, V1.B16
+	VEOR    V7.B16, V2.B16, V2.B16
+	VPMULL  V8.D1, V26.D1, V6.Q1
+	VPMULL2 V8.D2, V26.D2, V7.Q1
+	VEOR    V6.B16, V3.B16, V3.B16
+	VEOR    V7.B16, V3.B16, V3.B16
+
+	// Fold the middle product into lo = V1 and hi = V2
+	VEXT    $8, V3.B16, V10.B16, V4.B16
+	VEOR    V4.B16, V1.B16, V1.B16
+	VEXT    $8, V10.B16, V3.B16, V4.B16
+	VEOR    V4.B16, V2.B16, V2.B16
+
+	// Montgomery reduction: clear the low word twice (P ≡ 1 mod x^64)
+	VPMULL  V9.D1, V1.D1, V4.Q1
+	VEXT    $8, V1.B16, V1.B16, V1.B16
+	VEOR    V4.B16, V1.B16, V1.B16
+	VPMULL  V9.D1, V1.D1, V4.Q1
+	VEXT    $8, V1.B16, V1.B16, V1.B16
+	VEOR    V4.B16, V1.B16, V1.B16
+	VEOR    V2.B16, V1.B16, V0.B16
+
+	SUB $8, R3
+	CMP $8, R3
+	BGE loop8
+
+single:
+	CBZ R3, done
+
+loop1:
+	VLD1.P 16(R2), [V19.B16]
+	VEOR V0.B16, V19.B16, V19.B16
+	VEOR    V1.B16, V1.B16, V1.B16
+	VEOR    V2.B16, V2.B16, V2.B16
+	VEOR    V3.B16, V3.B16, V3.B16
+	VEXT    $8, V18.B16, V18.B16, V8.B16
+	VPMULL  V18.D1, V19.D1, V6.Q1
+	VPMULL2 V18.D2, V19.D2, V7.Q1
+	VEOR    V6.B16, V1.B16, V1.B16
+	VEOR    V7.B16, V2.B16, V2.B16
+	VPMULL  V8.D1, V19.D1, V6.Q1
+	VPMULL2 V8.D2, V19.D2, V7.Q1
+	VEOR    V6.B16, V3.B16, V3.B16
+	VEOR    V7.B16, V3.B16, V3.B16
+
+	// Fold the middle product into lo = V1 and hi = V2
+	VEXT    $8, V3.B16, V10.B16, V4.B16
+	VEOR    V4.B16, V1.B16, V1.B16
+	VEXT    $8, V10.B16, V3.B16, V4.B16
+	VEOR    V4.B16, V2.B16, V2.B16
+
+	// Montgomery reduction: clear the low word twice (P ≡ 1 mod x^64)
+	VPMULL  V9.D1, V1.D1, V4.Q1
+	VEXT    $8, V1.B16, V1.B16, V1.B16
+	VEOR    V4.B16, V1.B16, V1.B16
+	VPMULL  V9.D1, V1.D1, V4.Q1
+	VEXT    $8, V1.B16, V1.B16, V1.B16
+	VEOR    V4.B16, V1.B16, V1.B16
+	VEOR    V2.B16, V1.B16, V0.B16
+
+	SUB $1, R3
+	CBNZ R3, loop1
+
+done:
+	VST1 [V0.B16], (R0)
+	RET
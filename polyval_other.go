@@ -0,0 +1,7 @@
+//go:build (!amd64 && !arm64) || purego
+
+package aes
+
+func polyvalBlocks(acc *Block, powers *[polyvalPowers]Block, data []byte) {
+	polyvalBlocksGeneric(acc, &powers[polyvalPowers-1], data)
+}
@@ -0,0 +1,214 @@
+package aes
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+// polyvalReference computes POLYVAL bit by bit: dot(a, b) = a·b·x^-128 mod P.
+func polyvalReference(h *Block, data []byte) Block {
+	le := func(b []byte) *big.Int {
+		r := make([]byte, len(b))
+		for i := range b {
+			r[len(b)-1-i] = b[i]
+		}
+		return new(big.Int).SetBytes(r)
+	}
+	p := new(big.Int).SetBit(new(big.Int), 128, 1)
+	for _, e := range []int{127, 126, 121, 0} {
+		p.SetBit(p, e, 1)
+	}
+	dot := func(a, b *big.Int) *big.Int {
+		r := new(big.Int)
+		for i := 0; i < 128; i++ {
+			if b.Bit(i) == 1 {
+				r.Xor(r, new(big.Int).Lsh(a, uint(i)))
+			}
+		}
+		for i := 254; i >= 128; i-- {
+			if r.Bit(i) == 1 {
+				r.Xor(r, new(big.Int).Lsh(p, uint(i-128)))
+			}
+		}
+		for i := 0; i < 128; i++ {
+			if r.Bit(0) == 1 {
+				r.Xor(r, p)
+			}
+			r.Rsh(r, 1)
+		}
+		return r
+	}
+
+	hh := le(h[:])
+	s := new(big.Int)
+	for ; len(data) > 0; data = data[16:] {
+		s = dot(s.Xor(s, le(data[:16])), hh)
+	}
+	var out Block
+	b := s.FillBytes(make([]byte, 16))
+	for i := range out {
+		out[i] = b[15-i]
+	}
+	return out
+}
+
+// RFC 8452, Appendix A
+func TestPolyvalRFC8452(t *testing.T) {
+	h := hexToBytes("25629347589242761d31f826ba4b757b")
+	x := hexToBytes("4f4f95668c83dfb6401762bb2d01a262d1a24ddd2721d006bbe45f20d3c9f362")
+	want := "f7a3b47b846119fae5b7866cf5e5b77e"
+
+	p := NewPolyval((*Block)(h))
+	p.Update(x)
+	sum := p.Sum()
+	if got := hex.EncodeToString(sum[:]); got != want {
+		t.Errorf("POLYVAL mismatch:\ngot:  %s\nwant: %s", got, want)
+	}
+
+	// Block by block
+	p.Reset()
+	p.Update(x[:16])
+	p.Update(x[16:])
+	if sum2 := p.Sum(); sum2 != sum {
+		t.Error("incremental POLYVAL mismatch")
+	}
+}
+
+// POLYVAL inputs and results of the AES-GCM-SIV test vectors in RFC 8452,
+// Appendix C, with the trailing zero bytes of the length block left out.
+var polyvalRFC8452AppendixC = []struct {
+	h, input, want string
+}{
+	{"d9b360279694941ac5dbc6987ada7377", "00000000000000000000000000000000", "00000000000000000000000000000000"},
+	{"d9b360279694941ac5dbc6987ada7377", "01000000000000000000000000000000000000000000000040", "eb93b7740962c5e49d2a90a7dc5cec74"},
+	{"d9b360279694941ac5dbc6987ada7377", "01000000000000000000000000000000000000000000000060", "48eb6c6c5a2dbe4a1dde508fee06361b"},
+	{"d9b360279694941ac5dbc6987ada7377", "01000000000000000000000000000000000000000000000080", "20806c26e3c1de019e111255708031d6"},
+	{"d9b360279694941ac5dbc6987ada7377", "010000000000000000000000000000000200000000000000000000000000000000000000000000000001", "ce6edc9a50b36d9a98986bbf6a261c3b"},
+	{"0533fd71f4119257361a3ff1469dd4e5", "489c8fde2be2cf97e74e932d4ed87d00c9882e5386fd9f92ec00000000000000780000000000000048", "bf160bc9ded8c63057d2c38aae552fb4"},
+	{"64779ab10ee8a280272f14cc8851b727", "0da55210cc1c1b0abde3b2f204d1e9f8b06bc47f0000000000000000000000001db2316fd568378da107b52b00000000a00000000000000060", "cc86ee22c861e1fd474c84676b42739c"},
+	{"27c2959ed4daea3b1f52e849478de376", "f37de21c7ff901cfe8a69615a93fdf7a98cad481796245709f0000000000000021702de0de18baa9c9596291b0846600c80000000000000078", "c4fa5e5b713853703bcf8e6424505fa5"},
+	{"670b98154076ddb59b7a9137d0dcc0f0", "9c2159058b1f0fe91433a5bdc20e214eab7fecef4454a10ef0657df21ac70000b202b370ef9768ec6561c4fe6b7e7296fa850000000000000000000000000000f00000000000000090", "4e4108f09f41d797dc9256f8da8d58c7"},
+	{"cb8c3aa3f8dbaeb4b28a3e86ff6625f8", "734320ccc9d9bbbb19cb81b2af4ecbc3e72834321f7aa0f70b7282b4f33df23f16754100000000000000000000000000ced532ce4159b035277d4dfbb7db62968b13cd4eec00000000000000000000001801000000000000a8", "ffd503c7dd712eb3791b7114b17bb0cf"},
+}
+
+func TestPolyvalRFC8452AppendixC(t *testing.T) {
+	forEachCPUConfig(t, func(t *testing.T) {
+		for i, tc := range polyvalRFC8452AppendixC {
+			var h Block
+			copy(h[:], hexToBytes(tc.h))
+			x := hexToBytes(tc.input)
+			x = append(x, make([]byte, -len(x)&15)...)
+
+			p := NewPolyval(&h)
+			p.Update(x)
+			if got := p.Sum(); hex.EncodeToString(got[:]) != tc.want {
+				t.Errorf("vector %d: got %x, want %s", i, got, tc.want)
+			}
+			if ref := polyvalReference(&h, x); hex.EncodeToString(ref[:]) != tc.want {
+				t.Errorf("vector %d: reference got %x, want %s", i, ref, tc.want)
+			}
+		}
+	})
+}
+
+func TestPolyvalMatchesReference(t *testing.T) {
+	var h Block
+	for i := range h {
+		h[i] = byte(i*37 + 1)
+	}
+	data := make([]byte, 16*40)
+	for i := range data {
+		data[i] = byte(i*i + 7)
+	}
+
+	// Lengths around the 8-block aggregation boundary
+	for _, n := range []int{0, 1, 2, 7, 8, 9, 15, 16, 17, 40} {
+		want := polyvalReference(&h, data[:16*n])
+
+		p := NewPolyval(&h)
+		p.Update(data[:16*n])
+		if got := p.Sum(); got != want {
+			t.Errorf("%d blocks: got %x, want %x", n, got, want)
+		}
+
+		var generic Block
+		polyvalBlocksGeneric(&generic, &h, data[:16*n])
+		if generic != want {
+			t.Errorf("%d blocks: generic got %x, want %x", n, generic, want)
+		}
+	}
+}
+
+func TestPolyvalChunking(t *testing.T) {
+	var h Block
+	h[0] = 0x42
+	data := make([]byte, 16*25)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	p := NewPolyval(&h)
+	p.Update(data)
+	want := p.Sum()
+
+	p.Reset()
+	for _, n := range []int{3, 9, 1, 12} {
+		p.Update(data[:16*n])
+		data = data[16*n:]
+	}
+	if got := p.Sum(); got != want {
+		t.Error("chunked POLYVAL mismatch")
+	}
+}
+
+func TestPolyvalUpdatePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for partial block")
+		}
+	}()
+	NewPolyval(&Block{}).Update(make([]byte, 17))
+}
+
+func TestClmul64(t *testing.T) {
+	for _, tc := range [][2]uint64{
+		{0xffffffffffffffff, 0xffffffffffffffff},
+		{0x8000000000000001, 0x8000000000000001},
+		{0x0123456789abcdef, 0xfedcba9876543210},
+	} {
+		hi, lo := clmul64(tc[0], tc[1])
+		want := new(big.Int)
+		x := new(big.Int).SetUint64(tc[0])
+		for i := 0; i < 64; i++ {
+			if tc[1]>>i&1 == 1 {
+				want.Xor(want, new(big.Int).Lsh(x, uint(i)))
+			}
+		}
+		got := new(big.Int).Lsh(new(big.Int).SetUint64(hi), 64)
+		got.Or(got, new(big.Int).SetUint64(lo))
+		if got.Cmp(want) != 0 {
+			t.Errorf("clmul64(%x, %x) = %x, want %x", tc[0], tc[1], got, want)
+		}
+	}
+}
+
+func BenchmarkPolyval(b *testing.B) {
+	p := NewPolyval(&Block{1})
+	data := make([]byte, 4096)
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Update(data)
+	}
+}
+
+func BenchmarkPolyvalGeneric(b *testing.B) {
+	var acc Block
+	h := Block{1}
+	data := make([]byte, 4096)
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		polyvalBlocksGeneric(&acc, &h, data)
+	}
+}
@@ -0,0 +1,309 @@
+package aes
+
+import (
+	"encoding/binary"
+	"hash"
+)
+
+// SHAvite-3 (Biham and Dunkelman) is a SHA-3 candidate built on the HAIFA
+// construction. Its compression function is a Feistel network whose round
+// function is a short sequence of AES rounds, keyed by an expansion of the
+// message block, the bit counter and the salt (zero here).
+//
+// SHAvite-3-256 has a 256-bit chaining value split into two 128-bit halves
+// (L, R), and 12 rounds of
+//
+//	(L, R) = (R, L ⊕ AESENC(AESENC(AESENC(R ⊕ k0, k1), k2), 0))
+//
+// SHAvite-3-512 has four 128-bit branches (A, B, C, D) and 14 rounds of
+//
+//	(A, B, C, D) = (D, A ⊕ F(B), B, C ⊕ F'(D))
+//
+// where F and F' are four AES rounds with different round keys. The two
+// functions of a round are computed together with Round2HW. The output of
+// the rounds is added to the chaining value.
+//
+// The message expansion alternates nonlinear steps (one keyless AES round on
+// a rotated window of earlier words, added to the previous four words) and
+// linear steps, with the counter mixed into four fixed positions. A
+// nonlinear step only reads words at least 16 positions back, so four steps
+// run at a time with Round4HW and a zero key.
+//
+// Messages are padded with a 0x80 byte, zeros, the counter and the 16-bit
+// digest size in bits. The counter of a block is the number of message bits
+// hashed up to its end, or 0 for a block without message bits.
+
+const (
+	// SHAvite256Size is the size of a SHAvite-3-256 digest in bytes.
+	SHAvite256Size = 32
+
+	// SHAvite512Size is the size of a SHAvite-3-512 digest in bytes.
+	SHAvite512Size = 64
+
+	// SHAvite256BlockSize is the message block size of SHAvite-3-256 in bytes.
+	SHAvite256BlockSize = 64
+
+	// SHAvite512BlockSize is the message block size of SHAvite-3-512 in bytes.
+	SHAvite512BlockSize = 128
+)
+
+var shavite256IV = [8]uint32{
+	0x49bb3e47, 0x2674860d, 0xa8b392ac, 0x021ac4e6,
+	0x409283cf, 0x620e5d86, 0x6d929dcb, 0x96cc2a8b,
+}
+
+var shavite512IV = [16]uint32{
+	0x72fccdd8, 0x79ca4727, 0x128a077b, 0x40d55aec,
+	0xd1901a06, 0x430ae307, 0xb29f5cd1, 0xdf07fbfc,
+	0x8e45d73d, 0x681ab538, 0xbde86578, 0xdd577e47,
+	0xe275eade, 0x502d9fcd, 0xb9357178, 0x022a4b9a,
+}
+
+// shaviteCounter is a group of four counter words, added to the expanded
+// message at word offset u.
+type shaviteCounter struct {
+	u int
+	w [4]uint32
+}
+
+// shaviteNonlinear computes the nonlinear expansion steps at word offsets
+// u, u+4, ..., u+4*(steps-1) of rk, four at a time. The step at u is
+//
+//	rk[u:u+4] = AESENC((rk[u-d+1], rk[u-d+2], rk[u-d+3], rk[u-d]), 0) ⊕ rk[u-4:u]
+//
+// where d is the window distance, 16 or 32 words. The counter words are
+// added as soon as the step at their offset is computed.
+func shaviteNonlinear(rk []byte, u, steps, d int, counter *[4]shaviteCounter) {
+	var x Block4
+	var zero Key4
+	for s := 0; s < steps; s += 4 {
+		for j := range 4 {
+			w := 4 * (u + 4*j)
+			copy(x[16*j:16*j+12], rk[w-4*d+4:w-4*d+16])
+			copy(x[16*j+12:16*j+16], rk[w-4*d:w-4*d+4])
+		}
+		Round4HW(&x, &zero)
+		for j := range 4 {
+			w := 4 * u
+			XorBlock((*Block)(rk[w:]), (*Block)(x[16*j:]), (*Block)(rk[w-16:]))
+			for _, c := range counter {
+				if c.u == u {
+					for i, v := range c.w {
+						binary.LittleEndian.PutUint32(rk[w+4*i:], binary.LittleEndian.Uint32(rk[w+4*i:])^v)
+					}
+				}
+			}
+			u += 4
+		}
+	}
+}
+
+// shaviteLinear computes steps linear expansion steps from word offset u:
+// rk[u+i] = rk[u-d+i] ⊕ rk[u-l+i], where l is 3 or 7.
+func shaviteLinear(rk []byte, u, steps, d, l int) {
+	for w := 4 * u; w < 4*(u+4*steps); w += 4 {
+		v := binary.LittleEndian.Uint32(rk[w-4*d:]) ^ binary.LittleEndian.Uint32(rk[w-4*l:])
+		binary.LittleEndian.PutUint32(rk[w:], v)
+	}
+}
+
+// shavite256Compress updates the chaining value h with the 64-byte block m
+// and the 64-bit counter.
+func shavite256Compress(h *[32]byte, m []byte, counter uint64) {
+	c0, c1 := uint32(counter), uint32(counter>>32)
+	var rk [144 * 4]byte
+	copy(rk[:], m[:SHAvite256BlockSize])
+	ctr := [4]shaviteCounter{
+		{16, [4]uint32{c0, ^c1}},
+		{56, [4]uint32{0, c1, ^c0}},
+		{84, [4]uint32{0, 0, c1, ^c0}},
+		{124, [4]uint32{c0, 0, 0, ^c1}},
+	}
+	for u := 16; u < len(rk)/4; u += 32 {
+		shaviteNonlinear(rk[:], u, 4, 16, &ctr)
+		shaviteLinear(rk[:], u+16, 4, 16, 3)
+	}
+
+	p := *h
+	var zero Block
+	l, r := (*Block)(p[0:16]), (*Block)(p[16:32])
+	for i := range 12 {
+		k := rk[48*i:]
+		var x Block
+		XorBlock(&x, r, (*Block)(k[0:16]))
+		RoundHW(&x, (*Block)(k[16:32]))
+		RoundHW(&x, (*Block)(k[32:48]))
+		RoundHW(&x, &zero)
+		XorBlock(l, l, &x)
+		l, r = r, l
+	}
+	// After an even number of rounds, l and r are back in place.
+	for i := range h {
+		h[i] ^= p[i]
+	}
+}
+
+// shavite512Compress updates the chaining value h with the 128-byte block m
+// and the 128-bit counter (lo, hi).
+func shavite512Compress(h *[64]byte, m []byte, lo, hi uint64) {
+	c := [4]uint32{uint32(lo), uint32(lo >> 32), uint32(hi), uint32(hi >> 32)}
+	var rk [448 * 4]byte
+	copy(rk[:], m[:SHAvite512BlockSize])
+	ctr := [4]shaviteCounter{
+		{32, [4]uint32{c[0], c[1], c[2], ^c[3]}},
+		{164, [4]uint32{c[3], c[2], c[1], ^c[0]}},
+		{316, [4]uint32{c[2], c[3], c[0], ^c[1]}},
+		{440, [4]uint32{c[1], c[0], c[3], ^c[2]}},
+	}
+	for u := 32; ; u += 64 {
+		shaviteNonlinear(rk[:], u, 8, 32, &ctr)
+		if u+32 == len(rk)/4 {
+			break
+		}
+		shaviteLinear(rk[:], u+32, 8, 32, 7)
+	}
+
+	p := *h
+	var zero Key2
+	b := [4]*Block{(*Block)(p[0:16]), (*Block)(p[16:32]), (*Block)(p[32:48]), (*Block)(p[48:64])}
+	for i := range 14 {
+		k := rk[128*i:]
+		var x Block2
+		var keys Key2
+		XorBlock((*Block)(x[0:16]), b[1], (*Block)(k[0:16]))
+		XorBlock((*Block)(x[16:32]), b[3], (*Block)(k[64:80]))
+		for j := 1; j < 4; j++ {
+			copy(keys[0:16], k[16*j:16*j+16])
+			copy(keys[16:32], k[64+16*j:64+16*j+16])
+			Round2HW(&x, &keys)
+		}
+		Round2HW(&x, &zero)
+		XorBlock(b[0], b[0], (*Block)(x[0:16]))
+		XorBlock(b[2], b[2], (*Block)(x[16:32]))
+		b[0], b[1], b[2], b[3] = b[3], b[0], b[1], b[2]
+	}
+	for i := range h {
+		h[i] ^= b[i/16][i%16]
+	}
+}
+
+// SHAvite computes SHAvite-3-256 or SHAvite-3-512 and implements hash.Hash.
+// The zero value is not ready for use; create instances with NewSHAvite256
+// or NewSHAvite512.
+type SHAvite struct {
+	h    [SHAvite512Size]byte // chaining value, 32 or 64 bytes
+	buf  [SHAvite512BlockSize]byte
+	n    int
+	len  uint64 // bytes hashed in full blocks
+	size int
+}
+
+var _ hash.Hash = (*SHAvite)(nil)
+
+// NewSHAvite256 returns a new SHAvite-3-256 hash.
+func NewSHAvite256() *SHAvite {
+	d := &SHAvite{size: SHAvite256Size}
+	d.Reset()
+	return d
+}
+
+// NewSHAvite512 returns a new SHAvite-3-512 hash.
+func NewSHAvite512() *SHAvite {
+	d := &SHAvite{size: SHAvite512Size}
+	d.Reset()
+	return d
+}
+
+// Reset resets the hash to its initial state.
+func (d *SHAvite) Reset() {
+	if d.size == SHAvite256Size {
+		for i, w := range shavite256IV {
+			binary.LittleEndian.PutUint32(d.h[4*i:], w)
+		}
+	} else {
+		for i, w := range shavite512IV {
+			binary.LittleEndian.PutUint32(d.h[4*i:], w)
+		}
+	}
+	d.n = 0
+	d.len = 0
+}
+
+// Size returns the digest size in bytes.
+func (d *SHAvite) Size() int { return d.size }
+
+// BlockSize returns the message block size in bytes.
+func (d *SHAvite) BlockSize() int { return 2 * d.size }
+
+// compress compresses the block m with the bit counter (lo, hi).
+func (d *SHAvite) compress(m []byte, lo, hi uint64) {
+	if d.size == SHAvite256Size {
+		shavite256Compress((*[32]byte)(d.h[:32]), m, lo)
+	} else {
+		shavite512Compress(&d.h, m, lo, hi)
+	}
+}
+
+// block compresses a full message block.
+func (d *SHAvite) block(m []byte) {
+	d.len += uint64(len(m))
+	d.compress(m, d.len<<3, d.len>>61)
+}
+
+// Write absorbs p. It never returns an error.
+func (d *SHAvite) Write(p []byte) (int, error) {
+	nn := len(p)
+	bs := d.BlockSize()
+	buf := d.buf[:bs]
+	if d.n > 0 {
+		k := copy(buf[d.n:], p)
+		d.n += k
+		p = p[k:]
+		if d.n < bs {
+			return nn, nil
+		}
+		d.block(buf)
+		d.n = 0
+	}
+	for len(p) >= bs {
+		d.block(p[:bs])
+		p = p[bs:]
+	}
+	d.n = copy(buf, p)
+	return nn, nil
+}
+
+// Sum appends the digest to b. It does not change the underlying hash state.
+func (d *SHAvite) Sum(b []byte) []byte {
+	dd := *d
+	bs := dd.BlockSize()
+	buf := dd.buf[:bs]
+	total := dd.len + uint64(dd.n)
+	lo, hi := total<<3, total>>61
+
+	// The trailer is the counter (8 or 16 bytes) and the digest size.
+	trailer := bs/8 + 2
+
+	// The counter of a block is zero if it has no message bits.
+	var clo, chi uint64
+	if dd.n > 0 {
+		clo, chi = lo, hi
+	}
+	clear(buf[dd.n:])
+	buf[dd.n] = 0x80
+	if dd.n+1 > bs-trailer {
+		dd.compress(buf, clo, chi)
+		clear(buf)
+		clo, chi = 0, 0
+	}
+	binary.LittleEndian.PutUint64(buf[bs-trailer:], lo)
+	if dd.size == SHAvite512Size {
+		binary.LittleEndian.PutUint64(buf[bs-trailer+8:], hi)
+	}
+	binary.LittleEndian.PutUint16(buf[bs-2:], uint16(8*dd.size))
+	dd.compress(buf, clo, chi)
+
+	ret, out := sliceForAppend(b, dd.size)
+	copy(out, dd.h[:dd.size])
+	return ret
+}
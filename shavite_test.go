@@ -0,0 +1,241 @@
+package aes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+)
+
+// shaviteAES applies a keyless AES round to four 32-bit words.
+func shaviteAES(x0, x1, x2, x3 uint32) (uint32, uint32, uint32, uint32) {
+	var b Block
+	binary.LittleEndian.PutUint32(b[0:], x0)
+	binary.LittleEndian.PutUint32(b[4:], x1)
+	binary.LittleEndian.PutUint32(b[8:], x2)
+	binary.LittleEndian.PutUint32(b[12:], x3)
+	RoundNoKey(&b)
+	return binary.LittleEndian.Uint32(b[0:]), binary.LittleEndian.Uint32(b[4:]),
+		binary.LittleEndian.Uint32(b[8:]), binary.LittleEndian.Uint32(b[12:])
+}
+
+// shaviteExpandReference expands a message block of n words (16 or 32) into
+// the round keys one step at a time. at lists, for each word offset where
+// the counter is mixed in, the counter words added at that offset.
+func shaviteExpandReference(m []byte, words int, at map[int][4]uint32) []uint32 {
+	n := len(m) / 4
+	rk := make([]uint32, words)
+	for i := range n {
+		rk[i] = binary.LittleEndian.Uint32(m[4*i:])
+	}
+	l := 3
+	if n == 32 {
+		l = 7
+	}
+	for u := n; u < words; {
+		for range n / 4 {
+			x0, x1, x2, x3 := shaviteAES(rk[u-n+1], rk[u-n+2], rk[u-n+3], rk[u-n])
+			rk[u], rk[u+1], rk[u+2], rk[u+3] = x0^rk[u-4], x1^rk[u-3], x2^rk[u-2], x3^rk[u-1]
+			for i, c := range at[u] {
+				rk[u+i] ^= c
+			}
+			u += 4
+		}
+		for range n / 4 {
+			if u == words {
+				break
+			}
+			for i := range 4 {
+				rk[u+i] = rk[u+i-n] ^ rk[u+i-l]
+			}
+			u += 4
+		}
+	}
+	return rk
+}
+
+// shaviteF computes the round function on the branch x with the round keys
+// rk[0:4k]: k-1 keyed AES rounds after the initial key addition, then a
+// keyless one.
+func shaviteF(x [4]uint32, rk []uint32) [4]uint32 {
+	for i := range x {
+		x[i] ^= rk[i]
+	}
+	for j := 4; j <= len(rk); j += 4 {
+		x[0], x[1], x[2], x[3] = shaviteAES(x[0], x[1], x[2], x[3])
+		if j < len(rk) {
+			for i := range x {
+				x[i] ^= rk[j+i]
+			}
+		}
+	}
+	return x
+}
+
+// shaviteReference hashes msg with word-level compression functions written
+// from the description of SHAvite-3.
+func shaviteReference(msg []byte, size int) []byte {
+	bs := 2 * size
+	trailer := bs/8 + 2
+	var h []uint32
+	if size == SHAvite256Size {
+		h = append(h, shavite256IV[:]...)
+	} else {
+		h = append(h, shavite512IV[:]...)
+	}
+
+	bits := uint64(len(msg)) * 8
+	padded := append(append([]byte(nil), msg...), 0x80)
+	for len(padded)%bs != bs-trailer {
+		padded = append(padded, 0)
+	}
+	padded = binary.LittleEndian.AppendUint64(padded, bits)
+	if size == SHAvite512Size {
+		padded = binary.LittleEndian.AppendUint64(padded, 0)
+	}
+	padded = binary.LittleEndian.AppendUint16(padded, uint16(8*size))
+
+	for off := 0; off < len(padded); off += bs {
+		var counter uint64
+		if off < len(msg) {
+			counter = min(uint64(off+bs), uint64(len(msg))) * 8
+		}
+		c0, c1 := uint32(counter), uint32(counter>>32)
+		var p [][4]uint32
+		for i := 0; i < len(h); i += 4 {
+			p = append(p, [4]uint32(h[i:i+4]))
+		}
+		if size == SHAvite256Size {
+			rk := shaviteExpandReference(padded[off:off+bs], 144, map[int][4]uint32{
+				16: {c0, ^c1}, 56: {0, c1, ^c0}, 84: {0, 0, c1, ^c0}, 124: {c0, 0, 0, ^c1},
+			})
+			for r := range 12 {
+				f := shaviteF(p[1], rk[12*r:12*r+12])
+				for i := range f {
+					f[i] ^= p[0][i]
+				}
+				p[0], p[1] = p[1], f
+			}
+		} else {
+			rk := shaviteExpandReference(padded[off:off+bs], 448, map[int][4]uint32{
+				32: {c0, c1, 0, ^uint32(0)}, 164: {0, 0, c1, ^c0},
+				316: {0, 0, c0, ^c1}, 440: {c1, c0, 0, ^uint32(0)},
+			})
+			for r := range 14 {
+				f := shaviteF(p[1], rk[32*r:32*r+16])
+				g := shaviteF(p[3], rk[32*r+16:32*r+32])
+				for i := range f {
+					f[i] ^= p[0][i]
+					g[i] ^= p[2][i]
+				}
+				p[0], p[1], p[2], p[3] = p[3], f, p[1], g
+			}
+		}
+		for i := range h {
+			h[i] ^= p[i/4][i%4]
+		}
+	}
+
+	out := make([]byte, 0, size)
+	for _, w := range h {
+		out = binary.LittleEndian.AppendUint32(out, w)
+	}
+	return out
+}
+
+func TestSHAviteReference(t *testing.T) {
+	msg := make([]byte, 600)
+	for i := range msg {
+		msg[i] = byte(i * 7)
+	}
+	forEachCPUConfig(t, func(t *testing.T) {
+		for _, size := range []int{SHAvite256Size, SHAvite512Size} {
+			for _, n := range []int{0, 1, 53, 54, 63, 64, 65, 109, 110, 127, 128, 129, 256, 600} {
+				want := shaviteReference(msg[:n], size)
+				var h *SHAvite
+				if size == SHAvite256Size {
+					h = NewSHAvite256()
+				} else {
+					h = NewSHAvite512()
+				}
+				h.Write(msg[:n])
+				if got := h.Sum(nil); !bytes.Equal(got, want) {
+					t.Errorf("SHAvite-3-%d, %d bytes: got %x, want %x", 8*size, n, got, want)
+				}
+			}
+		}
+	})
+}
+
+func TestSHAviteStreaming(t *testing.T) {
+	msg := make([]byte, 1000)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+	for _, newHash := range []func() *SHAvite{NewSHAvite256, NewSHAvite512} {
+		h := newHash()
+		h.Write(msg)
+		want := h.Sum(nil)
+		if again := h.Sum(nil); !bytes.Equal(again, want) {
+			t.Fatal("Sum changed the hash state")
+		}
+		for _, step := range []int{1, 13, 64, 127, 128, 500} {
+			h.Reset()
+			for i := 0; i < len(msg); i += step {
+				h.Write(msg[i:min(i+step, len(msg))])
+			}
+			if got := h.Sum(nil); !bytes.Equal(got, want) {
+				t.Errorf("step %d: got %x, want %x", step, got, want)
+			}
+		}
+	}
+}
+
+// The SHAvite-3-512 empty-message digest is the Len = 0 entry of
+// ShortMsgKAT_512.txt in the SHAvite-3 round 2 SHA-3 submission package. The
+// other digests are regression values.
+func TestSHAviteKnownAnswers(t *testing.T) {
+	tests := []struct {
+		newHash func() *SHAvite
+		msg     string
+		want    string
+	}{
+		{NewSHAvite256, "",
+			"08c5825af2e9e5947286a8fe208bd5f8c6a7c8e4da598947d7ff8eda0fcd2bd7"},
+		{NewSHAvite256, "abc",
+			"1fa8520307d2c36719d04d4f778f8dea6e06380bca083c2d121208b9363fae2d"},
+		{NewSHAvite512, "",
+			"a485c1b2578459d1efc5dddd840bb0b4a650ac82fe68f58c4442ccda747da006b2d1dc6b4a4eb7d84ff91e1f466fef429d259acd995dddcad16fa545c7a6e5ba"},
+		{NewSHAvite512, "abc",
+			"0fb0b216b377e6d95db1b6d9b6c8b59f08d4e29814071c8c0f827b32e68c15362f24bcc15ad6b1c925a03f00092997f7628cb47f27c9ad7a22e4c00fbb2c16e3"},
+	}
+	for _, tc := range tests {
+		h := tc.newHash()
+		h.Write([]byte(tc.msg))
+		if got := hex.EncodeToString(h.Sum(nil)); got != tc.want {
+			t.Errorf("SHAvite-3-%d(%q) = %s, want %s", 8*h.Size(), tc.msg, got, tc.want)
+		}
+	}
+}
+
+func BenchmarkSHAvite256(b *testing.B) {
+	buf := make([]byte, 8192)
+	h := NewSHAvite256()
+	b.SetBytes(int64(len(buf)))
+	for b.Loop() {
+		h.Reset()
+		h.Write(buf)
+		h.Sum(nil)
+	}
+}
+
+func BenchmarkSHAvite512(b *testing.B) {
+	buf := make([]byte, 8192)
+	h := NewSHAvite512()
+	b.SetBytes(int64(len(buf)))
+	for b.Loop() {
+		h.Reset()
+		h.Write(buf)
+		h.Sum(nil)
+	}
+}
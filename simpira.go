@@ -0,0 +1,243 @@
+package aes
+
+// Simpira v2 (Gueron and Mouha), a family of permutations over b 128-bit
+// blocks built from a single function:
+//
+//	F_c(x) = AESENC(AESENC(x, C_c), 0)
+//
+// where C_c has the little-endian 32-bit words (0x00, 0x10, 0x20, 0x30), each
+// XORed with the counter c and b. The counter starts at 1 and is incremented
+// at every use of F.
+//
+//	b = 1       F applied 6 times (12 AES rounds)
+//	b = 2, 3    type-1 generalized Feistel network, 15 rounds
+//	b = 4       type-1.x (4, 2) network with two F per round, 15 rounds
+//	b = 6, 8    type-2 network with the Suzaki-Minematsu block shuffles,
+//	            15 and 18 rounds
+//
+// The Feistel rounds are computed in place as in the reference code: blocks
+// are never moved, and each round works on a rotated or shuffled view of the
+// state. Inverses recompute F on the same blocks in reverse order, except for
+// b = 1, which inverts the AES rounds.
+
+// Simpira1 is a 128-bit Simpira v2 state (b = 1).
+type Simpira1 [16]byte
+
+// Simpira2 is a 256-bit Simpira v2 state (b = 2).
+type Simpira2 [32]byte
+
+// Simpira3 is a 384-bit Simpira v2 state (b = 3).
+type Simpira3 [48]byte
+
+// Simpira4 is a 512-bit Simpira v2 state (b = 4).
+type Simpira4 [64]byte
+
+// Simpira6 is a 768-bit Simpira v2 state (b = 6).
+type Simpira6 [96]byte
+
+// Simpira8 is a 1024-bit Simpira v2 state (b = 8).
+type Simpira8 [128]byte
+
+// simpiraStep is one F application of a Feistel round,
+// x[dst] ^= F_c(x[src]), with cb = c ^ b. The assembly kernels read steps as
+// 4-byte records.
+type simpiraStep struct {
+	src, dst, cb, _ uint8
+}
+
+// simpiraSchedule holds the F applications of a permutation in counter
+// order, and in reverse order for the inverse.
+type simpiraSchedule struct {
+	forward, inverse []simpiraStep
+}
+
+// simpiraSchedules is indexed by b, for b > 1.
+var simpiraSchedules = [...]simpiraSchedule{
+	2: newSimpiraSchedule(2, simpiraRotating(2, 15, 1)),
+	3: newSimpiraSchedule(3, simpiraRotating(3, 15, 1)),
+	4: newSimpiraSchedule(4, simpiraRotating(4, 15, 2)),
+	6: newSimpiraSchedule(6, simpiraShuffled([]int{3, 0, 1, 4, 5, 2}, 15)),
+	8: newSimpiraSchedule(8, simpiraShuffled([]int{3, 0, 1, 4, 7, 2, 5, 6}, 18)),
+}
+
+// newSimpiraSchedule numbers the (src, dst) pairs of a network over b blocks
+// with the counter.
+func newSimpiraSchedule(b int, pairs [][2]int) simpiraSchedule {
+	var s simpiraSchedule
+	for i, p := range pairs {
+		s.forward = append(s.forward, simpiraStep{src: uint8(p[0]), dst: uint8(p[1]), cb: uint8((i + 1) ^ b)})
+	}
+	for i := len(s.forward) - 1; i >= 0; i-- {
+		s.inverse = append(s.inverse, s.forward[i])
+	}
+	return s
+}
+
+// simpiraRotating returns the F applications of the type-1 (one F per round)
+// and type-1.x (4, 2) networks, whose view rotates by one block every round:
+//
+//	(x0, x1, x2, x3) -> (x1 ^ F(x0), x2 ^ F(x3), x3, x0)
+func simpiraRotating(b, rounds, fs int) [][2]int {
+	var s [][2]int
+	for r := range rounds {
+		s = append(s, [2]int{r % b, (r + 1) % b})
+		if fs == 2 {
+			s = append(s, [2]int{(r + 3) % b, (r + 2) % b})
+		}
+	}
+	return s
+}
+
+// simpiraShuffled returns the F applications of a type-2 network, where every
+// round computes x[2j+1] ^= F(x[2j]) and block i then moves to position
+// shuffle[i].
+func simpiraShuffled(shuffle []int, rounds int) [][2]int {
+	b := len(shuffle)
+	view := make([]int, b) // view[i] is the block at position i
+	for i := range view {
+		view[i] = i
+	}
+	var s [][2]int
+	for range rounds {
+		for j := 0; j < b; j += 2 {
+			s = append(s, [2]int{view[j], view[j+1]})
+		}
+		next := make([]int, b)
+		for i, p := range shuffle {
+			next[p] = view[i]
+		}
+		view = next
+	}
+	return s
+}
+
+// simpiraConstant returns C_c for c ^ b = cb.
+func simpiraConstant(cb uint8) Block {
+	var k Block
+	for i := range 4 {
+		k[4*i] = byte(0x10*i) ^ cb
+	}
+	return k
+}
+
+// simpiraBlock returns block i of a state.
+func simpiraBlock(state []byte, i int) *Block {
+	return (*Block)(state[16*i:])
+}
+
+// simpiraFeistelGeneric applies the given F applications to state.
+func simpiraFeistelGeneric(state []byte, steps []simpiraStep) {
+	var zero Block
+	for _, st := range steps {
+		k := simpiraConstant(st.cb)
+		t := *simpiraBlock(state, int(st.src))
+		Round(&t, &k)
+		Round(&t, &zero)
+		dst := simpiraBlock(state, int(st.dst))
+		XorBlock(dst, dst, &t)
+	}
+}
+
+// simpira1Generic applies Simpira v2 with b = 1, or its inverse.
+func simpira1Generic(x *Block, inverse bool) {
+	var zero Block
+	if inverse {
+		for c := 6; c >= 1; c-- {
+			k := simpiraConstant(uint8(c ^ 1))
+			InvRoundNoKey(x)
+			XorBlock(x, x, &k)
+			InvRoundNoKey(x)
+		}
+		return
+	}
+	for c := 1; c <= 6; c++ {
+		k := simpiraConstant(uint8(c ^ 1))
+		Round(x, &k)
+		Round(x, &zero)
+	}
+}
+
+// simpira1HW is simpira1Generic with the hardware round functions.
+func simpira1HW(x *Block, inverse bool) {
+	var zero Block
+	if inverse {
+		for c := 6; c >= 1; c-- {
+			k := simpiraConstant(uint8(c ^ 1))
+			InvRoundNoKeyHW(x)
+			XorBlock(x, x, &k)
+			InvRoundNoKeyHW(x)
+		}
+		return
+	}
+	for c := 1; c <= 6; c++ {
+		k := simpiraConstant(uint8(c ^ 1))
+		RoundHW(x, &k)
+		RoundHW(x, &zero)
+	}
+}
+
+// simpiraPermuteGeneric applies the permutation over len(state)/16 blocks
+// with the software AES round.
+func simpiraPermuteGeneric(state []byte, inverse bool) {
+	b := len(state) / 16
+	if b == 1 {
+		simpira1Generic(simpiraBlock(state, 0), inverse)
+		return
+	}
+	if inverse {
+		simpiraFeistelGeneric(state, simpiraSchedules[b].inverse)
+	} else {
+		simpiraFeistelGeneric(state, simpiraSchedules[b].forward)
+	}
+}
+
+// simpiraPermute is like simpiraPermuteGeneric, using AES instructions when
+// available.
+func simpiraPermute(state []byte, inverse bool) {
+	b := len(state) / 16
+	if b == 1 {
+		simpira1HW(simpiraBlock(state, 0), inverse)
+		return
+	}
+	if inverse {
+		simpiraFeistel(state, simpiraSchedules[b].inverse)
+	} else {
+		simpiraFeistel(state, simpiraSchedules[b].forward)
+	}
+}
+
+// Permute applies Simpira v2 with b = 1 in place.
+func (s *Simpira1) Permute() { simpiraPermute(s[:], false) }
+
+// InversePermute applies the inverse of Simpira v2 with b = 1 in place.
+func (s *Simpira1) InversePermute() { simpiraPermute(s[:], true) }
+
+// Permute applies Simpira v2 with b = 2 in place.
+func (s *Simpira2) Permute() { simpiraPermute(s[:], false) }
+
+// InversePermute applies the inverse of Simpira v2 with b = 2 in place.
+func (s *Simpira2) InversePermute() { simpiraPermute(s[:], true) }
+
+// Permute applies Simpira v2 with b = 3 in place.
+func (s *Simpira3) Permute() { simpiraPermute(s[:], false) }
+
+// InversePermute applies the inverse of Simpira v2 with b = 3 in place.
+func (s *Simpira3) InversePermute() { simpiraPermute(s[:], true) }
+
+// Permute applies Simpira v2 with b = 4 in place.
+func (s *Simpira4) Permute() { simpiraPermute(s[:], false) }
+
+// InversePermute applies the inverse of Simpira v2 with b = 4 in place.
+func (s *Simpira4) InversePermute() { simpiraPermute(s[:], true) }
+
+// Permute applies Simpira v2 with b = 6 in place.
+func (s *Simpira6) Permute() { simpiraPermute(s[:], false) }
+
+// InversePermute applies the inverse of Simpira v2 with b = 6 in place.
+func (s *Simpira6) InversePermute() { simpiraPermute(s[:], true) }
+
+// Permute applies Simpira v2 with b = 8 in place.
+func (s *Simpira8) Permute() { simpiraPermute(s[:], false) }
+
+// InversePermute applies the inverse of Simpira v2 with b = 8 in place.
+func (s *Simpira8) InversePermute() { simpiraPermute(s[:], true) }
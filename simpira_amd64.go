@@ -0,0 +1,14 @@
+//go:build amd64 && !purego
+
+package aes
+
+//go:noescape
+func simpiraFeistelAESNI(state *byte, steps *simpiraStep, n int)
+
+func simpiraFeistel(state []byte, steps []simpiraStep) {
+	if CPU.HasAESNI {
+		simpiraFeistelAESNI(&state[0], &steps[0], len(steps))
+	} else {
+		simpiraFeistelGeneric(state, steps)
+	}
+}
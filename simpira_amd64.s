@@ -0,0 +1,49 @@
+// Simpira v2 AES-NI hardware acceleration for AMD64
+#include "textflag.h"
+
+// Words of the round constant before the counter is mixed in
+DATA simpiraBase<>+0(SB)/4, $0x00
+DATA simpiraBase<>+4(SB)/4, $0x10
+DATA simpiraBase<>+8(SB)/4, $0x20
+DATA simpiraBase<>+12(SB)/4, $0x30
+GLOBL simpiraBase<>(SB), (NOPTR+RODATA), $16
+
+// func simpiraFeistelAESNI(state *byte, steps *simpiraStep, n int)
+//
+// Each step is a {src, dst, c^b, 0} record: state[dst] ^= F(state[src]),
+// F(x) = AESENC(AESENC(x, C), 0).
+TEXT ·simpiraFeistelAESNI(SB),NOSPLIT,$0
+	MOVQ state+0(FP), DI
+	MOVQ steps+8(FP), SI
+	MOVQ n+16(FP), CX
+	TESTQ CX, CX
+	JZ done
+
+	MOVOU simpiraBase<>(SB), X6
+	PXOR X7, X7
+
+loop:
+	MOVBQZX 0(SI), AX
+	MOVBQZX 1(SI), BX
+	MOVBLZX 2(SI), DX
+	SHLQ $4, AX
+	SHLQ $4, BX
+
+	// C = base ^ (c^b in every word)
+	MOVL DX, X1
+	PSHUFD $0x00, X1, X1
+	PXOR X6, X1
+
+	MOVOU (DI)(AX*1), X0
+	AESENC X1, X0
+	AESENC X7, X0
+	MOVOU (DI)(BX*1), X2
+	PXOR X2, X0
+	MOVOU X0, (DI)(BX*1)
+
+	ADDQ $4, SI
+	DECQ CX
+	JNZ loop
+
+done:
+	RET
@@ -0,0 +1,14 @@
+//go:build arm64 && !purego
+
+package aes
+
+//go:noescape
+func simpiraFeistelHW(state *byte, steps *simpiraStep, n int)
+
+func simpiraFeistel(state []byte, steps []simpiraStep) {
+	if CPU.HasARMCrypto {
+		simpiraFeistelHW(&state[0], &steps[0], len(steps))
+	} else {
+		simpiraFeistelGeneric(state, steps)
+	}
+}
@@ -0,0 +1,51 @@
+// Simpira v2 ARM Crypto hardware acceleration for ARM64
+#include "textflag.h"
+
+// Words of the round constant before the counter is mixed in
+DATA simpiraBase<>+0(SB)/4, $0x00
+DATA simpiraBase<>+4(SB)/4, $0x10
+DATA simpiraBase<>+8(SB)/4, $0x20
+DATA simpiraBase<>+12(SB)/4, $0x30
+GLOBL simpiraBase<>(SB), (NOPTR+RODATA), $16
+
+// func simpiraFeistelHW(state *byte, steps *simpiraStep, n int)
+//
+// Each step is a {src, dst, c^b, 0} record: state[dst] ^= F(state[src]),
+// F(x) = AESENC(AESENC(x, C), 0). With AESE adding the key first, F is
+// AESE 0, AESMC, AESE C, AESMC.
+TEXT ·simpiraFeistelHW(SB),NOSPLIT,$0
+	MOVD state+0(FP), R0
+	MOVD steps+8(FP), R1
+	MOVD n+16(FP), R2
+	CBZ R2, done
+
+	MOVD $simpiraBase<>(SB), R3
+	VLD1 (R3), [V6.B16]
+	VEOR V7.B16, V7.B16, V7.B16
+
+loop:
+	MOVBU 0(R1), R4
+	MOVBU 1(R1), R5
+	MOVBU 2(R1), R6
+	ADD R4<<4, R0, R4
+	ADD R5<<4, R0, R5
+
+	// C = base ^ (c^b in every word)
+	VDUP R6, V1.S4
+	VEOR V6.B16, V1.B16, V1.B16
+
+	VLD1 (R4), [V0.B16]
+	AESE V7.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V1.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	VLD1 (R5), [V2.B16]
+	VEOR V2.B16, V0.B16, V0.B16
+	VST1 [V0.B16], (R5)
+
+	ADD $4, R1
+	SUBS $1, R2
+	BNE loop
+
+done:
+	RET
@@ -0,0 +1,7 @@
+//go:build (!amd64 && !arm64) || purego
+
+package aes
+
+func simpiraFeistel(state []byte, steps []simpiraStep) {
+	simpiraFeistelGeneric(state, steps)
+}
@@ -0,0 +1,226 @@
+package aes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// simpiraTestState returns a state of b blocks filled with a pattern.
+func simpiraTestState(b int) []byte {
+	s := make([]byte, 16*b)
+	for i := range s {
+		s[i] = byte(i*7 + b)
+	}
+	return s
+}
+
+// simpiraFReference computes F_c(x) with the software AES round.
+func simpiraFReference(x Block, c, b int) Block {
+	k := Block{0: byte(c ^ b), 4: byte(0x10 ^ c ^ b), 8: byte(0x20 ^ c ^ b), 12: byte(0x30 ^ c ^ b)}
+	var zero Block
+	Round(&x, &k)
+	Round(&x, &zero)
+	return x
+}
+
+// simpiraReference follows the round functions of the specification, moving
+// blocks between rounds, and returns the blocks in position order.
+func simpiraReference(state []byte) []Block {
+	b := len(state) / 16
+	x := make([]Block, b)
+	for i := range x {
+		copy(x[i][:], state[16*i:])
+	}
+	c := 1
+	f := func(v Block) Block {
+		y := simpiraFReference(v, c, b)
+		c++
+		return y
+	}
+	xor := func(a, b Block) Block {
+		XorBlock(&a, &a, &b)
+		return a
+	}
+
+	switch b {
+	case 1:
+		for range 6 {
+			x[0] = f(x[0])
+		}
+	case 2, 3:
+		for range 15 {
+			// (x0, x1, ..., xb-1) -> (x1 ^ F(x0), x2, ..., x0)
+			y := append(x[1:b:b], x[0])
+			y[0] = xor(y[0], f(x[0]))
+			x = y
+		}
+	case 4:
+		for range 15 {
+			f0 := f(x[0])
+			f1 := f(x[3])
+			x = []Block{xor(x[1], f0), xor(x[2], f1), x[3], x[0]}
+		}
+	case 6, 8:
+		shuffle := map[int][]int{6: {3, 0, 1, 4, 5, 2}, 8: {3, 0, 1, 4, 7, 2, 5, 6}}[b]
+		rounds := map[int]int{6: 15, 8: 18}[b]
+		for range rounds {
+			for j := 0; j < b; j += 2 {
+				x[j+1] = xor(x[j+1], f(x[j]))
+			}
+			y := make([]Block, b)
+			for i, p := range shuffle {
+				y[p] = x[i]
+			}
+			x = y
+		}
+	}
+	return x
+}
+
+// simpiraReferenceView returns the position of each physical block after the
+// last round, as tracked by the in-place schedules.
+func simpiraReferenceView(b int) []int {
+	view := make([]int, b)
+	for i := range view {
+		view[i] = i
+	}
+	switch b {
+	case 2, 3, 4:
+		for i := range view {
+			view[i] = (i + 15) % b
+		}
+	case 6, 8:
+		shuffle := map[int][]int{6: {3, 0, 1, 4, 5, 2}, 8: {3, 0, 1, 4, 7, 2, 5, 6}}[b]
+		rounds := map[int]int{6: 15, 8: 18}[b]
+		for range rounds {
+			next := make([]int, b)
+			for i, p := range shuffle {
+				next[p] = view[i]
+			}
+			view = next
+		}
+	}
+	return view
+}
+
+var simpiraSizes = []int{1, 2, 3, 4, 6, 8}
+
+func TestSimpiraMatchesReference(t *testing.T) {
+	for _, b := range simpiraSizes {
+		state := simpiraTestState(b)
+		ref := simpiraReference(state)
+		view := simpiraReferenceView(b)
+
+		got := bytes.Clone(state)
+		simpiraPermuteGeneric(got, false)
+		for i, blk := range ref {
+			if !bytes.Equal(got[16*view[i]:16*view[i]+16], blk[:]) {
+				t.Errorf("b=%d: position %d mismatch", b, i)
+			}
+		}
+	}
+}
+
+func TestSimpiraInverse(t *testing.T) {
+	forEachCPUConfig(t, func(t *testing.T) {
+		for _, b := range simpiraSizes {
+			state := simpiraTestState(b)
+
+			want := bytes.Clone(state)
+			simpiraPermuteGeneric(want, false)
+			got := bytes.Clone(state)
+			simpiraPermute(got, false)
+			if !bytes.Equal(got, want) {
+				t.Fatalf("%+v: b=%d: hardware and software permutations differ", CPU, b)
+			}
+
+			simpiraPermute(got, true)
+			if !bytes.Equal(got, state) {
+				t.Fatalf("%+v: b=%d: inverse permutation failed", CPU, b)
+			}
+			simpiraPermuteGeneric(want, true)
+			if !bytes.Equal(want, state) {
+				t.Fatalf("%+v: b=%d: software inverse permutation failed", CPU, b)
+			}
+		}
+	})
+}
+
+func TestSimpiraTypes(t *testing.T) {
+	check := func(name string, s []byte, permute, inverse func()) {
+		t.Helper()
+		orig := bytes.Clone(s)
+		want := bytes.Clone(s)
+		simpiraPermuteGeneric(want, false)
+		permute()
+		if !bytes.Equal(s, want) {
+			t.Errorf("%s: Permute mismatch", name)
+		}
+		inverse()
+		if !bytes.Equal(s, orig) {
+			t.Errorf("%s: InversePermute mismatch", name)
+		}
+	}
+	var s1 Simpira1
+	var s2 Simpira2
+	var s3 Simpira3
+	var s4 Simpira4
+	var s6 Simpira6
+	var s8 Simpira8
+	copy(s1[:], simpiraTestState(1))
+	copy(s2[:], simpiraTestState(2))
+	copy(s3[:], simpiraTestState(3))
+	copy(s4[:], simpiraTestState(4))
+	copy(s6[:], simpiraTestState(6))
+	copy(s8[:], simpiraTestState(8))
+	check("Simpira1", s1[:], s1.Permute, s1.InversePermute)
+	check("Simpira2", s2[:], s2.Permute, s2.InversePermute)
+	check("Simpira3", s3[:], s3.Permute, s3.InversePermute)
+	check("Simpira4", s4[:], s4.Permute, s4.InversePermute)
+	check("Simpira6", s6[:], s6.Permute, s6.InversePermute)
+	check("Simpira8", s8[:], s8.Permute, s8.InversePermute)
+}
+
+// Regression values for the all-zero state.
+func TestSimpiraKnownAnswers(t *testing.T) {
+	tests := map[int]string{
+		1: "cd100e8d27b6f8f79ccf527f4b0344bd",
+		2: "6b95ca7d8cda46cf97ab4430a8ef27c631b464a6ed106a553e30a83ba08c14c2",
+		3: "c4280f781e69fc3a59c32229ff39e5283ea512dae45655eaf2d46850c50bba59c7def11379cb16b18f01a0aa53e07fa1",
+		4: "a6e91efd33aabdf61860f2015b0f99449d98e6b61bc0d2d884c9715ff9a2697100b146f88dfee9e7367ceec80b2cbacc3ae9944f4322b9724f4d1717eeb31596",
+		6: "9236688b9d98eaa569e690f11fb7cfa1f555e8977e67cbd0efb3955652b93211d2d8626b344530429aac0ce4ee42779a2885662f63c16414631f516b0b9a6492bb356d9e8e5d356f7ee92dbab7a9b2a449e7f2686b68afb60cdfaebdce1e5a22",
+		8: "0691de1d9c1053fcf58d7e6bcb0aa5cc65f8771e69d18936d389686a9ff92361df55c6e9935c70450687a7ceccfd3fbab6c2b6e1d6531eedfa2592ab5c006774ed8807cccfc4c38ff793394f1528c55834c546871403ed7e5d797a445a6b69f3bd96be8e38228e8b01c0aa185c58d39c355b6f049e29234f32e0217433003ea0",
+	}
+	for _, b := range simpiraSizes {
+		s := make([]byte, 16*b)
+		simpiraPermute(s, false)
+		if hex.EncodeToString(s) != tests[b] {
+			t.Errorf("b=%d: got %x, expected %s", b, s, tests[b])
+		}
+	}
+}
+
+func BenchmarkSimpira2(b *testing.B) {
+	var s Simpira2
+	b.SetBytes(int64(len(s)))
+	for i := 0; i < b.N; i++ {
+		s.Permute()
+	}
+}
+
+func BenchmarkSimpira4(b *testing.B) {
+	var s Simpira4
+	b.SetBytes(int64(len(s)))
+	for i := 0; i < b.N; i++ {
+		s.Permute()
+	}
+}
+
+func BenchmarkSimpira8(b *testing.B) {
+	var s Simpira8
+	b.SetBytes(int64(len(s)))
+	for i := 0; i < b.N; i++ {
+		s.Permute()
+	}
+}
@@ -0,0 +1,308 @@
+package aes
+
+import (
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// SM4 (GB/T 32907-2016) is a 128-bit block cipher with a 128-bit key and 32
+// rounds of an unbalanced Feistel network over four 32-bit words:
+//
+//	X(i+4) = X(i) ⊕ L(τ(X(i+1) ⊕ X(i+2) ⊕ X(i+3) ⊕ rk(i)))
+//	L(B)   = B ⊕ (B <<< 2) ⊕ (B <<< 10) ⊕ (B <<< 18) ⊕ (B <<< 24)
+//
+// where τ applies an 8-bit S-box to each byte. The ciphertext is the last
+// four words in reverse order.
+//
+// The SM4 S-box is an inversion in GF(2^8) between two affine maps, like the
+// AES S-box, but with another field polynomial. Since the two fields are
+// isomorphic, it can be written as
+//
+//	S(x) = Mout·SubBytes(Min·x ⊕ 0x3e) ⊕ 0x6c
+//
+// for two 8x8 bit matrices. The batch functions use this to run τ on the
+// words of 4 or 8 blocks at once with SubBytesHW or SubBytes2HW, which are
+// AES final rounds in hardware, and apply the bit matrices to 8 bytes at a
+// time in 64-bit words. Single blocks use a table.
+
+const (
+	// SM4BlockSize is the SM4 block size in bytes.
+	SM4BlockSize = 16
+
+	// SM4KeySize is the SM4 key size in bytes.
+	SM4KeySize = 16
+
+	sm4Rounds = 32
+)
+
+var sm4Sbox = [256]byte{
+	0xd6, 0x90, 0xe9, 0xfe, 0xcc, 0xe1, 0x3d, 0xb7, 0x16, 0xb6, 0x14, 0xc2, 0x28, 0xfb, 0x2c, 0x05,
+	0x2b, 0x67, 0x9a, 0x76, 0x2a, 0xbe, 0x04, 0xc3, 0xaa, 0x44, 0x13, 0x26, 0x49, 0x86, 0x06, 0x99,
+	0x9c, 0x42, 0x50, 0xf4, 0x91, 0xef, 0x98, 0x7a, 0x33, 0x54, 0x0b, 0x43, 0xed, 0xcf, 0xac, 0x62,
+	0xe4, 0xb3, 0x1c, 0xa9, 0xc9, 0x08, 0xe8, 0x95, 0x80, 0xdf, 0x94, 0xfa, 0x75, 0x8f, 0x3f, 0xa6,
+	0x47, 0x07, 0xa7, 0xfc, 0xf3, 0x73, 0x17, 0xba, 0x83, 0x59, 0x3c, 0x19, 0xe6, 0x85, 0x4f, 0xa8,
+	0x68, 0x6b, 0x81, 0xb2, 0x71, 0x64, 0xda, 0x8b, 0xf8, 0xeb, 0x0f, 0x4b, 0x70, 0x56, 0x9d, 0x35,
+	0x1e, 0x24, 0x0e, 0x5e, 0x63, 0x58, 0xd1, 0xa2, 0x25, 0x22, 0x7c, 0x3b, 0x01, 0x21, 0x78, 0x87,
+	0xd4, 0x00, 0x46, 0x57, 0x9f, 0xd3, 0x27, 0x52, 0x4c, 0x36, 0x02, 0xe7, 0xa0, 0xc4, 0xc8, 0x9e,
+	0xea, 0xbf, 0x8a, 0xd2, 0x40, 0xc7, 0x38, 0xb5, 0xa3, 0xf7, 0xf2, 0xce, 0xf9, 0x61, 0x15, 0xa1,
+	0xe0, 0xae, 0x5d, 0xa4, 0x9b, 0x34, 0x1a, 0x55, 0xad, 0x93, 0x32, 0x30, 0xf5, 0x8c, 0xb1, 0xe3,
+	0x1d, 0xf6, 0xe2, 0x2e, 0x82, 0x66, 0xca, 0x60, 0xc0, 0x29, 0x23, 0xab, 0x0d, 0x53, 0x4e, 0x6f,
+	0xd5, 0xdb, 0x37, 0x45, 0xde, 0xfd, 0x8e, 0x2f, 0x03, 0xff, 0x6a, 0x72, 0x6d, 0x6c, 0x5b, 0x51,
+	0x8d, 0x1b, 0xaf, 0x92, 0xbb, 0xdd, 0xbc, 0x7f, 0x11, 0xd9, 0x5c, 0x41, 0x1f, 0x10, 0x5a, 0xd8,
+	0x0a, 0xc1, 0x31, 0x88, 0xa5, 0xcd, 0x7b, 0xbd, 0x2d, 0x74, 0xd0, 0x12, 0xb8, 0xe5, 0xb4, 0xb0,
+	0x89, 0x69, 0x97, 0x4a, 0x0c, 0x96, 0x77, 0x7e, 0x65, 0xb9, 0xf1, 0x09, 0xc5, 0x6e, 0xc6, 0x84,
+	0x18, 0xf0, 0x7d, 0xec, 0x3a, 0xdc, 0x4d, 0x20, 0x79, 0xee, 0x5f, 0x3e, 0xd7, 0xcb, 0x39, 0x48,
+}
+
+var sm4FK = [4]uint32{0xa3b1bac6, 0x56aa3350, 0x677d9197, 0xb27022dc}
+
+// Columns of the bit matrices around the AES S-box: bit j of x selects
+// column j.
+var (
+	sm4In  = [8]byte{0x8c, 0x30, 0x85, 0x9f, 0xdc, 0x2e, 0xc5, 0x08}
+	sm4Out = [8]byte{0xb8, 0xca, 0x3e, 0x67, 0xe0, 0x50, 0x9d, 0xc0}
+)
+
+const (
+	sm4InConst  = 0x3e
+	sm4OutConst = 0x6c
+	sm4Lanes    = 0x0101010101010101
+)
+
+// sm4T[x] is L(S(x) << 24). L commutes with rotations, so the other bytes of
+// a word use the same table, rotated.
+var sm4T = func() (t [256]uint32) {
+	for x := range t {
+		t[x] = sm4L(uint32(sm4Sbox[x]) << 24)
+	}
+	return t
+}()
+
+// sm4L is the linear transformation of the round function.
+func sm4L(b uint32) uint32 {
+	return b ^ bits.RotateLeft32(b, 2) ^ bits.RotateLeft32(b, 10) ^
+		bits.RotateLeft32(b, 18) ^ bits.RotateLeft32(b, 24)
+}
+
+// sm4Tau applies the S-box to each byte of a word.
+func sm4Tau(a uint32) uint32 {
+	return uint32(sm4Sbox[a>>24])<<24 | uint32(sm4Sbox[a>>16&0xff])<<16 |
+		uint32(sm4Sbox[a>>8&0xff])<<8 | uint32(sm4Sbox[a&0xff])
+}
+
+// sm4F is the round transformation L(τ(a)).
+func sm4F(a uint32) uint32 {
+	return sm4T[a>>24] ^ bits.RotateLeft32(sm4T[a>>16&0xff], -8) ^
+		bits.RotateLeft32(sm4T[a>>8&0xff], -16) ^ bits.RotateLeft32(sm4T[a&0xff], -24)
+}
+
+// sm4Affine multiplies each of the eight bytes of x by the bit matrix m.
+func sm4Affine(x uint64, m *[8]byte) uint64 {
+	var y uint64
+	for j := range 8 {
+		y ^= (x >> j & sm4Lanes) * uint64(m[j])
+	}
+	return y
+}
+
+// SM4 is SM4 with an expanded key. It is safe for concurrent use.
+type SM4 struct {
+	enc [sm4Rounds]uint32
+	dec [sm4Rounds]uint32
+}
+
+// NewSM4 expands a 16-byte key.
+func NewSM4(key []byte) (*SM4, error) {
+	if len(key) != SM4KeySize {
+		return nil, errors.New("aes: SM4 key must be 16 bytes")
+	}
+	var k [4]uint32
+	for i := range k {
+		k[i] = binary.BigEndian.Uint32(key[4*i:]) ^ sm4FK[i]
+	}
+	c := &SM4{}
+	for i := range sm4Rounds {
+		// CK(i) has the bytes 7·(4i+j) mod 256.
+		var ck uint32
+		for j := range 4 {
+			ck = ck<<8 | uint32(byte(7*(4*i+j)))
+		}
+		b := sm4Tau(k[1] ^ k[2] ^ k[3] ^ ck)
+		rk := k[0] ^ b ^ bits.RotateLeft32(b, 13) ^ bits.RotateLeft32(b, 23)
+		k = [4]uint32{k[1], k[2], k[3], rk}
+		c.enc[i] = rk
+		c.dec[sm4Rounds-1-i] = rk
+	}
+	return c, nil
+}
+
+// BlockSize returns the block size in bytes.
+func (c *SM4) BlockSize() int { return SM4BlockSize }
+
+// Encrypt encrypts src into dst. dst and src may point to the same block.
+func (c *SM4) Encrypt(dst, src *Block) {
+	sm4Crypt(&c.enc, dst, src)
+}
+
+// Decrypt decrypts src into dst. dst and src may point to the same block.
+func (c *SM4) Decrypt(dst, src *Block) {
+	sm4Crypt(&c.dec, dst, src)
+}
+
+// Encrypt4 encrypts four blocks.
+func (c *SM4) Encrypt4(dst, src *Block4) {
+	sm4Crypt4(&c.enc, dst, src)
+}
+
+// Decrypt4 decrypts four blocks.
+func (c *SM4) Decrypt4(dst, src *Block4) {
+	sm4Crypt4(&c.dec, dst, src)
+}
+
+// Encrypt8 encrypts eight blocks.
+func (c *SM4) Encrypt8(dst, src *[8 * SM4BlockSize]byte) {
+	sm4Crypt8(&c.enc, dst, src)
+}
+
+// Decrypt8 decrypts eight blocks.
+func (c *SM4) Decrypt8(dst, src *[8 * SM4BlockSize]byte) {
+	sm4Crypt8(&c.dec, dst, src)
+}
+
+// EncryptBlocks encrypts each 16-byte block of src into dst, independently
+// (ECB), eight or four blocks at a time. len(src) must be a multiple of the
+// block size and dst must be at least as long; dst and src may be the same
+// slice.
+func (c *SM4) EncryptBlocks(dst, src []byte) {
+	sm4Blocks(&c.enc, dst, src)
+}
+
+// DecryptBlocks decrypts each 16-byte block of src into dst, independently.
+func (c *SM4) DecryptBlocks(dst, src []byte) {
+	sm4Blocks(&c.dec, dst, src)
+}
+
+func sm4Blocks(rk *[sm4Rounds]uint32, dst, src []byte) {
+	checkBlocks("SM4", dst, src)
+	for len(src) >= 8*SM4BlockSize {
+		sm4Crypt8(rk, (*[8 * SM4BlockSize]byte)(dst), (*[8 * SM4BlockSize]byte)(src))
+		dst, src = dst[8*SM4BlockSize:], src[8*SM4BlockSize:]
+	}
+	if len(src) >= 4*SM4BlockSize {
+		sm4Crypt4(rk, (*Block4)(dst), (*Block4)(src))
+		dst, src = dst[4*SM4BlockSize:], src[4*SM4BlockSize:]
+	}
+	for len(src) > 0 {
+		sm4Crypt(rk, (*Block)(dst), (*Block)(src))
+		dst, src = dst[SM4BlockSize:], src[SM4BlockSize:]
+	}
+}
+
+func sm4Crypt(rk *[sm4Rounds]uint32, dst, src *Block) {
+	x0 := binary.BigEndian.Uint32(src[0:])
+	x1 := binary.BigEndian.Uint32(src[4:])
+	x2 := binary.BigEndian.Uint32(src[8:])
+	x3 := binary.BigEndian.Uint32(src[12:])
+	for i := 0; i < sm4Rounds; i += 4 {
+		x0 ^= sm4F(x1 ^ x2 ^ x3 ^ rk[i])
+		x1 ^= sm4F(x2 ^ x3 ^ x0 ^ rk[i+1])
+		x2 ^= sm4F(x3 ^ x0 ^ x1 ^ rk[i+2])
+		x3 ^= sm4F(x0 ^ x1 ^ x2 ^ rk[i+3])
+	}
+	binary.BigEndian.PutUint32(dst[0:], x3)
+	binary.BigEndian.PutUint32(dst[4:], x2)
+	binary.BigEndian.PutUint32(dst[8:], x1)
+	binary.BigEndian.PutUint32(dst[12:], x0)
+}
+
+// sm4Crypt4Generic runs four blocks, with the S-box layer of every round on
+// one block of four words.
+func sm4Crypt4Generic(rk *[sm4Rounds]uint32, dst, src *Block4) {
+	var x [4][4]uint32 // x[i][b] is word i of block b
+	for b := range 4 {
+		for i := range 4 {
+			x[i][b] = binary.BigEndian.Uint32(src[16*b+4*i:])
+		}
+	}
+	var s Block
+	for r := range sm4Rounds {
+		a, b, c, d := &x[r&3], &x[(r+1)&3], &x[(r+2)&3], &x[(r+3)&3]
+		var t [4]uint32
+		for k := range 4 {
+			t[k] = b[k] ^ c[k] ^ d[k] ^ rk[r]
+		}
+		sm4TauIn(s[:], t[:])
+		SubBytesHW(&s)
+		sm4TauOut(t[:], s[:])
+		for k := range 4 {
+			a[k] ^= sm4L(t[k])
+		}
+	}
+	for b := range 4 {
+		for i := range 4 {
+			binary.BigEndian.PutUint32(dst[16*b+4*i:], x[3-i][b])
+		}
+	}
+}
+
+// sm4Crypt8Generic runs eight blocks, with the S-box layer of every round on
+// two blocks of four words.
+func sm4Crypt8Generic(rk *[sm4Rounds]uint32, dst, src *[8 * SM4BlockSize]byte) {
+	var x [4][8]uint32
+	for b := range 8 {
+		for i := range 4 {
+			x[i][b] = binary.BigEndian.Uint32(src[16*b+4*i:])
+		}
+	}
+	var s Block2
+	for r := range sm4Rounds {
+		a, b, c, d := &x[r&3], &x[(r+1)&3], &x[(r+2)&3], &x[(r+3)&3]
+		var t [8]uint32
+		for k := range 8 {
+			t[k] = b[k] ^ c[k] ^ d[k] ^ rk[r]
+		}
+		sm4TauIn(s[:], t[:])
+		SubBytes2HW(&s)
+		sm4TauOut(t[:], s[:])
+		for k := range 8 {
+			a[k] ^= sm4L(t[k])
+		}
+	}
+	for b := range 8 {
+		for i := range 4 {
+			binary.BigEndian.PutUint32(dst[16*b+4*i:], x[3-i][b])
+		}
+	}
+}
+
+// sm4TauIn maps the bytes of the words t, two words per 64-bit lane, to the
+// input of the AES S-box in s.
+func sm4TauIn(s []byte, t []uint32) {
+	for k := 0; k < len(t); k += 2 {
+		w := uint64(t[k]) | uint64(t[k+1])<<32
+		w = sm4Affine(w, &sm4In) ^ sm4InConst*sm4Lanes
+		binary.LittleEndian.PutUint64(s[4*k:], w)
+	}
+}
+
+// sm4TauOut maps the output of the AES S-box in s back to the words t,
+// completing τ.
+func sm4TauOut(t []uint32, s []byte) {
+	for k := 0; k < len(t); k += 2 {
+		w := binary.LittleEndian.Uint64(s[4*k:])
+		w = sm4Affine(w, &sm4Out) ^ sm4OutConst*sm4Lanes
+		t[k], t[k+1] = uint32(w), uint32(w>>32)
+	}
+}
+
+// NewSM4Cipher returns SM4 with the given 16-byte key as a cipher.Block, for
+// use with the modes of crypto/cipher.
+func NewSM4Cipher(key []byte) (cipher.Block, error) {
+	c, err := NewSM4(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipherBlock{"SM4", c}, nil
+}
@@ -0,0 +1,28 @@
+//go:build amd64 && !purego
+
+package aes
+
+//go:noescape
+func sm4Crypt4AESNI(rk *[sm4Rounds]uint32, dst, src *Block4)
+
+//go:noescape
+func sm4Crypt8VAES(rk *[sm4Rounds]uint32, dst, src *[8 * SM4BlockSize]byte)
+
+func sm4Crypt4(rk *[sm4Rounds]uint32, dst, src *Block4) {
+	if CPU.HasAESNI {
+		sm4Crypt4AESNI(rk, dst, src)
+	} else {
+		sm4Crypt4Generic(rk, dst, src)
+	}
+}
+
+func sm4Crypt8(rk *[sm4Rounds]uint32, dst, src *[8 * SM4BlockSize]byte) {
+	if CPU.HasVAES && CPU.HasAVX2 {
+		sm4Crypt8VAES(rk, dst, src)
+	} else if CPU.HasAESNI {
+		sm4Crypt4AESNI(rk, (*Block4)(dst[:64]), (*Block4)(src[:64]))
+		sm4Crypt4AESNI(rk, (*Block4)(dst[64:]), (*Block4)(src[64:]))
+	} else {
+		sm4Crypt8Generic(rk, dst, src)
+	}
+}
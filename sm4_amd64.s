@@ -0,0 +1,265 @@
+// SM4 with AES-NI for AMD64
+#include "textflag.h"
+
+// Low nibble mask
+DATA sm4Consts<>+0x00(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA sm4Consts<>+0x08(SB)/8, $0x0f0f0f0f0f0f0f0f
+// Min·x ^ 0x3e for the low nibble x
+DATA sm4Consts<>+0x10(SB)/8, $0x078b37bb820eb23e
+DATA sm4Consts<>+0x18(SB)/8, $0x9814a8241d912da1
+// Min·(x << 4) for the high nibble x
+DATA sm4Consts<>+0x20(SB)/8, $0x37eb19c5f22edc00
+DATA sm4Consts<>+0x28(SB)/8, $0x3fe311cdfa26d408
+// Mout·x ^ 0x6c for the low nibble x
+DATA sm4Consts<>+0x30(SB)/8, $0x2098ea521ea6d46c
+DATA sm4Consts<>+0x38(SB)/8, $0x47ff8d3579c1b30b
+// Mout·(x << 4) for the high nibble x
+DATA sm4Consts<>+0x40(SB)/8, $0x2dcd7d9db050e000
+DATA sm4Consts<>+0x48(SB)/8, $0xed0dbd5d709020c0
+// InvShiftRows: {0, 13, 10, 7, 4, 1, 14, 11, 8, 5, 2, 15, 12, 9, 6, 3}
+DATA sm4Consts<>+0x50(SB)/8, $0x0b0e0104070a0d00
+DATA sm4Consts<>+0x58(SB)/8, $0x0306090c0f020508
+// Rotation of every word left by 8 bits
+DATA sm4Consts<>+0x60(SB)/8, $0x0605040702010003
+DATA sm4Consts<>+0x68(SB)/8, $0x0e0d0c0f0a09080b
+// Byte swap of every word
+DATA sm4Consts<>+0x70(SB)/8, $0x0405060700010203
+DATA sm4Consts<>+0x78(SB)/8, $0x0c0d0e0f08090a0b
+GLOBL sm4Consts<>(SB), RODATA|NOPTR, $128
+
+// SM4ROUND computes A ^= L(τ(B ^ C ^ D ^ rk)) on word rk at off(AX), for four
+// blocks with one word of each block in every 32-bit lane.
+//
+// τ is Mout·S(Min·x ^ 0x3e) ^ 0x6c with the AES S-box S, the bit matrices
+// applied with two nibble lookups, and L(x) = x ^ (x <<< 24) ^ (v <<< 2) with
+// v = x ^ (x <<< 8) ^ (x <<< 16).
+#define SM4ROUND(off, A, B, C, D) \
+	MOVL off(AX), R8;    \
+	MOVQ R8, X4;         \
+	PSHUFD $0x00, X4, X4; \
+	PXOR B, X4;          \
+	PXOR C, X4;          \
+	PXOR D, X4;          \
+	MOVOU X4, X5;        \
+	PSRLL $4, X5;        \
+	PAND X8, X5;         \
+	PAND X8, X4;         \
+	MOVOU X9, X6;        \
+	PSHUFB X4, X6;       \
+	MOVOU X10, X7;       \
+	PSHUFB X5, X7;       \
+	PXOR X7, X6;         \
+	PSHUFB X13, X6;      \
+	AESENCLAST X15, X6;  \
+	MOVOU X6, X5;        \
+	PSRLL $4, X5;        \
+	PAND X8, X5;         \
+	PAND X8, X6;         \
+	MOVOU X11, X4;       \
+	PSHUFB X6, X4;       \
+	MOVOU X12, X7;       \
+	PSHUFB X5, X7;       \
+	PXOR X7, X4;         \
+	MOVOU X4, X5;        \
+	PSHUFB X14, X5;      \
+	MOVOU X5, X6;        \
+	PSHUFB X14, X6;      \
+	PXOR X4, X5;         \
+	PXOR X6, X5;         \
+	PSHUFB X14, X6;      \
+	PXOR X6, X4;         \
+	MOVOU X5, X7;        \
+	PSLLL $2, X5;        \
+	PSRLL $30, X7;       \
+	POR X7, X5;          \
+	PXOR X5, X4;         \
+	PXOR X4, A
+
+// func sm4Crypt4AESNI(rk *[32]uint32, dst, src *Block4)
+TEXT ·sm4Crypt4AESNI(SB),NOSPLIT,$0
+	MOVQ rk+0(FP), AX
+	MOVQ dst+8(FP), DI
+	MOVQ src+16(FP), SI
+
+	MOVOU (SI), X0
+	MOVOU 16(SI), X1
+	MOVOU 32(SI), X2
+	MOVOU 48(SI), X3
+
+	// Load the words as big-endian numbers, and transpose so that Xi holds
+	// word i of the four blocks.
+	MOVOU sm4Consts<>+0x70(SB), X4
+	PSHUFB X4, X0
+	PSHUFB X4, X1
+	PSHUFB X4, X2
+	PSHUFB X4, X3
+	MOVOU X0, X4
+	PUNPCKLLQ X1, X0
+	PUNPCKHLQ X1, X4
+	MOVOU X2, X5
+	PUNPCKLLQ X3, X2
+	PUNPCKHLQ X3, X5
+	MOVOU X0, X1
+	PUNPCKLQDQ X2, X0
+	PUNPCKHQDQ X2, X1
+	MOVOU X4, X2
+	PUNPCKLQDQ X5, X2
+	MOVOU X4, X3
+	PUNPCKHQDQ X5, X3
+
+	MOVOU sm4Consts<>+0x00(SB), X8
+	MOVOU sm4Consts<>+0x10(SB), X9
+	MOVOU sm4Consts<>+0x20(SB), X10
+	MOVOU sm4Consts<>+0x30(SB), X11
+	MOVOU sm4Consts<>+0x40(SB), X12
+	MOVOU sm4Consts<>+0x50(SB), X13
+	MOVOU sm4Consts<>+0x60(SB), X14
+	PXOR X15, X15
+
+	MOVQ $8, CX
+
+loop:
+	SM4ROUND(0, X0, X1, X2, X3)
+	SM4ROUND(4, X1, X2, X3, X0)
+	SM4ROUND(8, X2, X3, X0, X1)
+	SM4ROUND(12, X3, X0, X1, X2)
+	ADDQ $16, AX
+	DECQ CX
+	JNZ loop
+
+	// Block b is words 35, 34, 33, 32 of lane b: transpose X3, X2, X1, X0.
+	MOVOU X3, X4
+	PUNPCKLLQ X2, X4
+	MOVOU X3, X5
+	PUNPCKHLQ X2, X5
+	MOVOU X1, X6
+	PUNPCKLLQ X0, X6
+	MOVOU X1, X7
+	PUNPCKHLQ X0, X7
+	MOVOU X4, X0
+	PUNPCKLQDQ X6, X0
+	MOVOU X4, X1
+	PUNPCKHQDQ X6, X1
+	MOVOU X5, X2
+	PUNPCKLQDQ X7, X2
+	MOVOU X5, X3
+	PUNPCKHQDQ X7, X3
+
+	MOVOU sm4Consts<>+0x70(SB), X4
+	PSHUFB X4, X0
+	PSHUFB X4, X1
+	PSHUFB X4, X2
+	PSHUFB X4, X3
+	MOVOU X0, (DI)
+	MOVOU X1, 16(DI)
+	MOVOU X2, 32(DI)
+	MOVOU X3, 48(DI)
+	RET
+
+// SM4ROUND8 is SM4ROUND for eight blocks, with VAES on 256-bit registers.
+#define SM4ROUND8(off, A, B, C, D) \
+	VPBROADCASTD off(AX), Y4;   \
+	VPXOR B, Y4, Y4;            \
+	VPXOR C, Y4, Y4;            \
+	VPXOR D, Y4, Y4;            \
+	VPSRLD $4, Y4, Y5;          \
+	VPAND Y8, Y5, Y5;           \
+	VPAND Y8, Y4, Y4;           \
+	VPSHUFB Y4, Y9, Y6;         \
+	VPSHUFB Y5, Y10, Y7;        \
+	VPXOR Y7, Y6, Y6;           \
+	VPSHUFB Y13, Y6, Y6;        \
+	VAESENCLAST Y15, Y6, Y6;    \
+	VPSRLD $4, Y6, Y5;          \
+	VPAND Y8, Y5, Y5;           \
+	VPAND Y8, Y6, Y6;           \
+	VPSHUFB Y6, Y11, Y4;        \
+	VPSHUFB Y5, Y12, Y7;        \
+	VPXOR Y7, Y4, Y4;           \
+	VPSHUFB Y14, Y4, Y5;        \
+	VPSHUFB Y14, Y5, Y6;        \
+	VPXOR Y4, Y5, Y5;           \
+	VPXOR Y6, Y5, Y5;           \
+	VPSHUFB Y14, Y6, Y6;        \
+	VPXOR Y6, Y4, Y4;           \
+	VPSLLD $2, Y5, Y7;          \
+	VPSRLD $30, Y5, Y5;         \
+	VPOR Y7, Y5, Y5;            \
+	VPXOR Y5, Y4, Y4;           \
+	VPXOR Y4, A, A
+
+// func sm4Crypt8VAES(rk *[32]uint32, dst, src *[128]byte)
+TEXT ·sm4Crypt8VAES(SB),NOSPLIT,$0
+	MOVQ rk+0(FP), AX
+	MOVQ dst+8(FP), DI
+	MOVQ src+16(FP), SI
+
+	// Yi holds blocks i and i+4. The 128-bit halves are transposed as in
+	// sm4Crypt4AESNI, so that Yi holds word i of blocks 0-3 and 4-7.
+	VMOVDQU (SI), X0
+	VINSERTI128 $1, 64(SI), Y0, Y0
+	VMOVDQU 16(SI), X1
+	VINSERTI128 $1, 80(SI), Y1, Y1
+	VMOVDQU 32(SI), X2
+	VINSERTI128 $1, 96(SI), Y2, Y2
+	VMOVDQU 48(SI), X3
+	VINSERTI128 $1, 112(SI), Y3, Y3
+
+	VBROADCASTI128 sm4Consts<>+0x70(SB), Y4
+	VPSHUFB Y4, Y0, Y0
+	VPSHUFB Y4, Y1, Y1
+	VPSHUFB Y4, Y2, Y2
+	VPSHUFB Y4, Y3, Y3
+	VPUNPCKHDQ Y1, Y0, Y4
+	VPUNPCKLDQ Y1, Y0, Y0
+	VPUNPCKHDQ Y3, Y2, Y5
+	VPUNPCKLDQ Y3, Y2, Y2
+	VPUNPCKHQDQ Y2, Y0, Y1
+	VPUNPCKLQDQ Y2, Y0, Y0
+	VPUNPCKLQDQ Y5, Y4, Y2
+	VPUNPCKHQDQ Y5, Y4, Y3
+
+	VBROADCASTI128 sm4Consts<>+0x00(SB), Y8
+	VBROADCASTI128 sm4Consts<>+0x10(SB), Y9
+	VBROADCASTI128 sm4Consts<>+0x20(SB), Y10
+	VBROADCASTI128 sm4Consts<>+0x30(SB), Y11
+	VBROADCASTI128 sm4Consts<>+0x40(SB), Y12
+	VBROADCASTI128 sm4Consts<>+0x50(SB), Y13
+	VBROADCASTI128 sm4Consts<>+0x60(SB), Y14
+	VPXOR Y15, Y15, Y15
+
+	MOVQ $8, CX
+
+loop8:
+	SM4ROUND8(0, Y0, Y1, Y2, Y3)
+	SM4ROUND8(4, Y1, Y2, Y3, Y0)
+	SM4ROUND8(8, Y2, Y3, Y0, Y1)
+	SM4ROUND8(12, Y3, Y0, Y1, Y2)
+	ADDQ $16, AX
+	DECQ CX
+	JNZ loop8
+
+	VPUNPCKLDQ Y2, Y3, Y4
+	VPUNPCKHDQ Y2, Y3, Y5
+	VPUNPCKLDQ Y0, Y1, Y6
+	VPUNPCKHDQ Y0, Y1, Y7
+	VPUNPCKLQDQ Y6, Y4, Y0
+	VPUNPCKHQDQ Y6, Y4, Y1
+	VPUNPCKLQDQ Y7, Y5, Y2
+	VPUNPCKHQDQ Y7, Y5, Y3
+
+	VBROADCASTI128 sm4Consts<>+0x70(SB), Y4
+	VPSHUFB Y4, Y0, Y0
+	VPSHUFB Y4, Y1, Y1
+	VPSHUFB Y4, Y2, Y2
+	VPSHUFB Y4, Y3, Y3
+	VMOVDQU X0, (DI)
+	VEXTRACTI128 $1, Y0, 64(DI)
+	VMOVDQU X1, 16(DI)
+	VEXTRACTI128 $1, Y1, 80(DI)
+	VMOVDQU X2, 32(DI)
+	VEXTRACTI128 $1, Y2, 96(DI)
+	VMOVDQU X3, 48(DI)
+	VEXTRACTI128 $1, Y3, 112(DI)
+	VZEROUPPER
+	RET
@@ -0,0 +1,25 @@
+//go:build arm64 && !purego
+
+package aes
+
+//go:noescape
+func sm4Crypt4HW(rk *[sm4Rounds]uint32, dst, src *Block4)
+
+//go:noescape
+func sm4Crypt8HW(rk *[sm4Rounds]uint32, dst, src *[8 * SM4BlockSize]byte)
+
+func sm4Crypt4(rk *[sm4Rounds]uint32, dst, src *Block4) {
+	if CPU.HasARMCrypto {
+		sm4Crypt4HW(rk, dst, src)
+	} else {
+		sm4Crypt4Generic(rk, dst, src)
+	}
+}
+
+func sm4Crypt8(rk *[sm4Rounds]uint32, dst, src *[8 * SM4BlockSize]byte) {
+	if CPU.HasARMCrypto {
+		sm4Crypt8HW(rk, dst, src)
+	} else {
+		sm4Crypt8Generic(rk, dst, src)
+	}
+}
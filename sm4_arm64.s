@@ -0,0 +1,200 @@
+// SM4 with ARM Crypto for ARM64
+#include "textflag.h"
+
+// Low nibble mask
+DATA sm4Consts<>+0x00(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA sm4Consts<>+0x08(SB)/8, $0x0f0f0f0f0f0f0f0f
+// Min·x ^ 0x3e for the low nibble x
+DATA sm4Consts<>+0x10(SB)/8, $0x078b37bb820eb23e
+DATA sm4Consts<>+0x18(SB)/8, $0x9814a8241d912da1
+// Min·(x << 4) for the high nibble x
+DATA sm4Consts<>+0x20(SB)/8, $0x37eb19c5f22edc00
+DATA sm4Consts<>+0x28(SB)/8, $0x3fe311cdfa26d408
+// Mout·x ^ 0x6c for the low nibble x
+DATA sm4Consts<>+0x30(SB)/8, $0x2098ea521ea6d46c
+DATA sm4Consts<>+0x38(SB)/8, $0x47ff8d3579c1b30b
+// Mout·(x << 4) for the high nibble x
+DATA sm4Consts<>+0x40(SB)/8, $0x2dcd7d9db050e000
+DATA sm4Consts<>+0x48(SB)/8, $0xed0dbd5d709020c0
+// InvShiftRows: {0, 13, 10, 7, 4, 1, 14, 11, 8, 5, 2, 15, 12, 9, 6, 3}
+DATA sm4Consts<>+0x50(SB)/8, $0x0b0e0104070a0d00
+DATA sm4Consts<>+0x58(SB)/8, $0x0306090c0f020508
+// Rotation of every word left by 8 bits
+DATA sm4Consts<>+0x60(SB)/8, $0x0605040702010003
+DATA sm4Consts<>+0x68(SB)/8, $0x0e0d0c0f0a09080b
+GLOBL sm4Consts<>(SB), RODATA|NOPTR, $112
+
+// SM4ROUND computes A ^= L(τ(B ^ C ^ D ^ rk)) on the next round key at R0,
+// for four blocks with one word of each block in every 32-bit lane.
+//
+// τ is Mout·S(Min·x ^ 0x3e) ^ 0x6c with the AES S-box S, the bit matrices
+// applied with two nibble lookups, and L(x) = x ^ (x <<< 24) ^ (v <<< 2) with
+// v = x ^ (x <<< 8) ^ (x <<< 16). AESE with a zero key is ShiftRows and
+// SubBytes, so the input is shuffled by InvShiftRows first.
+#define SM4ROUND(A, B, C, D) \
+	MOVWU.P 4(R0), R3;                \
+	VDUP R3, V4.S4;                   \
+	VEOR B, V4.B16, V4.B16;           \
+	VEOR C, V4.B16, V4.B16;           \
+	VEOR D, V4.B16, V4.B16;           \
+	VUSHR $4, V4.B16, V5.B16;         \
+	VAND V16.B16, V4.B16, V4.B16;     \
+	VTBL V4.B16, [V17.B16], V6.B16;   \
+	VTBL V5.B16, [V18.B16], V7.B16;   \
+	VEOR V7.B16, V6.B16, V6.B16;      \
+	VTBL V21.B16, [V6.B16], V6.B16;   \
+	AESE V23.B16, V6.B16;             \
+	VUSHR $4, V6.B16, V5.B16;         \
+	VAND V16.B16, V6.B16, V6.B16;     \
+	VTBL V6.B16, [V19.B16], V4.B16;   \
+	VTBL V5.B16, [V20.B16], V7.B16;   \
+	VEOR V7.B16, V4.B16, V4.B16;      \
+	VTBL V22.B16, [V4.B16], V5.B16;   \
+	VTBL V22.B16, [V5.B16], V6.B16;   \
+	VEOR V4.B16, V5.B16, V5.B16;      \
+	VEOR V6.B16, V5.B16, V5.B16;      \
+	VTBL V22.B16, [V6.B16], V6.B16;   \
+	VEOR V6.B16, V4.B16, V4.B16;      \
+	VSHL $2, V5.S4, V7.S4;            \
+	VSRI $30, V5.S4, V7.S4;           \
+	VEOR V7.B16, V4.B16, V4.B16;      \
+	VEOR V4.B16, A, A
+
+// func sm4Crypt4HW(rk *[32]uint32, dst, src *Block4)
+TEXT ·sm4Crypt4HW(SB),NOSPLIT,$0
+	MOVD rk+0(FP), R0
+	MOVD dst+8(FP), R1
+	MOVD src+16(FP), R2
+
+	// Vi holds word i of the four blocks, as big-endian numbers.
+	VLD4 (R2), [V0.S4, V1.S4, V2.S4, V3.S4]
+	VREV32 V0.B16, V0.B16
+	VREV32 V1.B16, V1.B16
+	VREV32 V2.B16, V2.B16
+	VREV32 V3.B16, V3.B16
+
+	MOVD $sm4Consts<>(SB), R4
+	VLD1.P 64(R4), [V16.B16, V17.B16, V18.B16, V19.B16]
+	VLD1 (R4), [V20.B16, V21.B16, V22.B16]
+	VEOR V23.B16, V23.B16, V23.B16
+
+	MOVD $8, R5
+
+loop:
+	SM4ROUND(V0.B16, V1.B16, V2.B16, V3.B16)
+	SM4ROUND(V1.B16, V2.B16, V3.B16, V0.B16)
+	SM4ROUND(V2.B16, V3.B16, V0.B16, V1.B16)
+	SM4ROUND(V3.B16, V0.B16, V1.B16, V2.B16)
+	SUBS $1, R5
+	BNE loop
+
+	// Block b is words 35, 34, 33, 32 of lane b.
+	VREV32 V3.B16, V4.B16
+	VREV32 V2.B16, V5.B16
+	VREV32 V1.B16, V6.B16
+	VREV32 V0.B16, V7.B16
+	VST4 [V4.S4, V5.S4, V6.S4, V7.S4], (R1)
+	RET
+
+// SM4ROUND8 is SM4ROUND for eight blocks: A-D hold blocks 0-3 and E-H
+// blocks 4-7. The two halves are interleaved, with V24-V27 as the
+// temporaries of the second one.
+#define SM4ROUND8(A, B, C, D, E, F, G, H) \
+	MOVWU.P 4(R0), R3;                \
+	VDUP R3, V4.S4;                   \
+	VDUP R3, V24.S4;                  \
+	VEOR B, V4.B16, V4.B16;           \
+	VEOR F, V24.B16, V24.B16;         \
+	VEOR C, V4.B16, V4.B16;           \
+	VEOR G, V24.B16, V24.B16;         \
+	VEOR D, V4.B16, V4.B16;           \
+	VEOR H, V24.B16, V24.B16;         \
+	VUSHR $4, V4.B16, V5.B16;         \
+	VUSHR $4, V24.B16, V25.B16;       \
+	VAND V16.B16, V4.B16, V4.B16;     \
+	VAND V16.B16, V24.B16, V24.B16;   \
+	VTBL V4.B16, [V17.B16], V6.B16;   \
+	VTBL V24.B16, [V17.B16], V26.B16; \
+	VTBL V5.B16, [V18.B16], V7.B16;   \
+	VTBL V25.B16, [V18.B16], V27.B16; \
+	VEOR V7.B16, V6.B16, V6.B16;      \
+	VEOR V27.B16, V26.B16, V26.B16;   \
+	VTBL V21.B16, [V6.B16], V6.B16;   \
+	VTBL V21.B16, [V26.B16], V26.B16; \
+	AESE V23.B16, V6.B16;             \
+	AESE V23.B16, V26.B16;            \
+	VUSHR $4, V6.B16, V5.B16;         \
+	VUSHR $4, V26.B16, V25.B16;       \
+	VAND V16.B16, V6.B16, V6.B16;     \
+	VAND V16.B16, V26.B16, V26.B16;   \
+	VTBL V6.B16, [V19.B16], V4.B16;   \
+	VTBL V26.B16, [V19.B16], V24.B16; \
+	VTBL V5.B16, [V20.B16], V7.B16;   \
+	VTBL V25.B16, [V20.B16], V27.B16; \
+	VEOR V7.B16, V4.B16, V4.B16;      \
+	VEOR V27.B16, V24.B16, V24.B16;   \
+	VTBL V22.B16, [V4.B16], V5.B16;   \
+	VTBL V22.B16, [V24.B16], V25.B16; \
+	VTBL V22.B16, [V5.B16], V6.B16;   \
+	VTBL V22.B16, [V25.B16], V26.B16; \
+	VEOR V4.B16, V5.B16, V5.B16;      \
+	VEOR V24.B16, V25.B16, V25.B16;   \
+	VEOR V6.B16, V5.B16, V5.B16;      \
+	VEOR V26.B16, V25.B16, V25.B16;   \
+	VTBL V22.B16, [V6.B16], V6.B16;   \
+	VTBL V22.B16, [V26.B16], V26.B16; \
+	VEOR V6.B16, V4.B16, V4.B16;      \
+	VEOR V26.B16, V24.B16, V24.B16;   \
+	VSHL $2, V5.S4, V7.S4;            \
+	VSHL $2, V25.S4, V27.S4;          \
+	VSRI $30, V5.S4, V7.S4;           \
+	VSRI $30, V25.S4, V27.S4;         \
+	VEOR V7.B16, V4.B16, V4.B16;      \
+	VEOR V27.B16, V24.B16, V24.B16;   \
+	VEOR V4.B16, A, A;                \
+	VEOR V24.B16, E, E
+
+// func sm4Crypt8HW(rk *[32]uint32, dst, src *[128]byte)
+TEXT ·sm4Crypt8HW(SB),NOSPLIT,$0
+	MOVD rk+0(FP), R0
+	MOVD dst+8(FP), R1
+	MOVD src+16(FP), R2
+
+	// Vi and V(i+8) hold word i of blocks 0-3 and 4-7.
+	VLD4.P 64(R2), [V0.S4, V1.S4, V2.S4, V3.S4]
+	VLD4 (R2), [V8.S4, V9.S4, V10.S4, V11.S4]
+	VREV32 V0.B16, V0.B16
+	VREV32 V1.B16, V1.B16
+	VREV32 V2.B16, V2.B16
+	VREV32 V3.B16, V3.B16
+	VREV32 V8.B16, V8.B16
+	VREV32 V9.B16, V9.B16
+	VREV32 V10.B16, V10.B16
+	VREV32 V11.B16, V11.B16
+
+	MOVD $sm4Consts<>(SB), R4
+	VLD1.P 64(R4), [V16.B16, V17.B16, V18.B16, V19.B16]
+	VLD1 (R4), [V20.B16, V21.B16, V22.B16]
+	VEOR V23.B16, V23.B16, V23.B16
+
+	MOVD $8, R5
+
+loop8:
+	SM4ROUND8(V0.B16, V1.B16, V2.B16, V3.B16, V8.B16, V9.B16, V10.B16, V11.B16)
+	SM4ROUND8(V1.B16, V2.B16, V3.B16, V0.B16, V9.B16, V10.B16, V11.B16, V8.B16)
+	SM4ROUND8(V2.B16, V3.B16, V0.B16, V1.B16, V10.B16, V11.B16, V8.B16, V9.B16)
+	SM4ROUND8(V3.B16, V0.B16, V1.B16, V2.B16, V11.B16, V8.B16, V9.B16, V10.B16)
+	SUBS $1, R5
+	BNE loop8
+
+	VREV32 V3.B16, V4.B16
+	VREV32 V2.B16, V5.B16
+	VREV32 V1.B16, V6.B16
+	VREV32 V0.B16, V7.B16
+	VREV32 V11.B16, V12.B16
+	VREV32 V10.B16, V13.B16
+	VREV32 V9.B16, V14.B16
+	VREV32 V8.B16, V15.B16
+	VST4.P [V4.S4, V5.S4, V6.S4, V7.S4], 64(R1)
+	VST4 [V12.S4, V13.S4, V14.S4, V15.S4], (R1)
+	RET
@@ -0,0 +1,11 @@
+//go:build (!amd64 && !arm64) || purego
+
+package aes
+
+func sm4Crypt4(rk *[sm4Rounds]uint32, dst, src *Block4) {
+	sm4Crypt4Generic(rk, dst, src)
+}
+
+func sm4Crypt8(rk *[sm4Rounds]uint32, dst, src *[8 * SM4BlockSize]byte) {
+	sm4Crypt8Generic(rk, dst, src)
+}
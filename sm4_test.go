@@ -0,0 +1,188 @@
+package aes
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"encoding/hex"
+	"testing"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// GB/T 32907-2016, Appendix A.
+func TestSM4Vectors(t *testing.T) {
+	key := mustHex(t, "0123456789abcdeffedcba9876543210")
+	c, err := NewSM4(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b Block
+	copy(b[:], key)
+	c.Encrypt(&b, &b)
+	if want := "681edf34d206965e86b3e94f536e4246"; hex.EncodeToString(b[:]) != want {
+		t.Fatalf("Encrypt = %x, want %s", b, want)
+	}
+	c.Decrypt(&b, &b)
+	if !bytes.Equal(b[:], key) {
+		t.Fatalf("Decrypt = %x, want %x", b, key)
+	}
+
+	if testing.Short() {
+		t.Skip("skipping 1000000 encryptions in short mode")
+	}
+	for range 1000000 {
+		c.Encrypt(&b, &b)
+	}
+	if want := "595298c7c6fd271f0402f804c33d3f66"; hex.EncodeToString(b[:]) != want {
+		t.Fatalf("Encrypt^1000000 = %x, want %s", b, want)
+	}
+}
+
+// The S-box computed around the AES S-box matches the table, on every byte.
+func TestSM4SboxFromAES(t *testing.T) {
+	forEachCPUConfig(t, func(t *testing.T) {
+		for x := 0; x < 256; x += 32 {
+			var w [8]uint32 // bytes x to x+31
+			for k := range w {
+				for j := range 4 {
+					w[k] |= uint32(x+4*k+j) << (8 * j)
+				}
+			}
+			var s Block2
+			sm4TauIn(s[:], w[:])
+			SubBytes2HW(&s)
+			sm4TauOut(w[:], s[:])
+			for k := range w {
+				for j := range 4 {
+					in := x + 4*k + j
+					if got := byte(w[k] >> (8 * j)); got != sm4Sbox[in] {
+						t.Fatalf("S(%#02x) = %#02x, want %#02x", in, got, sm4Sbox[in])
+					}
+				}
+			}
+		}
+	})
+}
+
+func TestSM4Batches(t *testing.T) {
+	key := make([]byte, SM4KeySize)
+	for i := range key {
+		key[i] = byte(i * 29)
+	}
+	c, _ := NewSM4(key)
+
+	src := make([]byte, 13*SM4BlockSize)
+	for i := range src {
+		src[i] = byte(i*7 + 3)
+	}
+	want := make([]byte, len(src))
+	for i := 0; i < len(src); i += SM4BlockSize {
+		c.Encrypt((*Block)(want[i:]), (*Block)(src[i:]))
+	}
+
+	forEachCPUConfig(t, func(t *testing.T) {
+		var b4 Block4
+		c.Encrypt4(&b4, (*Block4)(src))
+		if !bytes.Equal(b4[:], want[:64]) {
+			t.Fatalf("Encrypt4 = %x, want %x", b4, want[:64])
+		}
+		c.Decrypt4(&b4, &b4)
+		if !bytes.Equal(b4[:], src[:64]) {
+			t.Fatal("Decrypt4 does not invert Encrypt4")
+		}
+
+		var b8 [8 * SM4BlockSize]byte
+		c.Encrypt8(&b8, (*[8 * SM4BlockSize]byte)(src))
+		if !bytes.Equal(b8[:], want[:128]) {
+			t.Fatalf("Encrypt8 = %x, want %x", b8, want[:128])
+		}
+		c.Decrypt8(&b8, &b8)
+		if !bytes.Equal(b8[:], src[:128]) {
+			t.Fatal("Decrypt8 does not invert Encrypt8")
+		}
+
+		for n := 0; n <= len(src); n += SM4BlockSize {
+			out := make([]byte, n)
+			c.EncryptBlocks(out, src[:n])
+			if !bytes.Equal(out, want[:n]) {
+				t.Fatalf("EncryptBlocks(%d bytes) = %x, want %x", n, out, want[:n])
+			}
+			c.DecryptBlocks(out, out)
+			if !bytes.Equal(out, src[:n]) {
+				t.Fatalf("DecryptBlocks(%d bytes) does not invert EncryptBlocks", n)
+			}
+		}
+	})
+}
+
+func TestSM4Cipher(t *testing.T) {
+	if _, err := NewSM4Cipher(make([]byte, 15)); err == nil {
+		t.Error("NewSM4Cipher accepted a 15-byte key")
+	}
+
+	key := mustHex(t, "0123456789abcdeffedcba9876543210")
+	block, err := NewSM4Cipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.BlockSize() != SM4BlockSize {
+		t.Fatalf("BlockSize() = %d", block.BlockSize())
+	}
+	out := make([]byte, 16)
+	block.Encrypt(out, key)
+	if want := "681edf34d206965e86b3e94f536e4246"; hex.EncodeToString(out) != want {
+		t.Fatalf("Encrypt = %x, want %s", out, want)
+	}
+
+	// Round trip through a crypto/cipher mode.
+	iv := make([]byte, SM4BlockSize)
+	msg := bytes.Repeat([]byte("sm4 in cbc mode!"), 5)
+	ct := make([]byte, len(msg))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ct, msg)
+	pt := make([]byte, len(ct))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(pt, ct)
+	if !bytes.Equal(pt, msg) {
+		t.Fatal("CBC round trip failed")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Encrypt did not panic on a short block")
+		}
+	}()
+	block.Encrypt(out, key[:8])
+}
+
+func BenchmarkSM4Encrypt(b *testing.B) {
+	c, _ := NewSM4(make([]byte, SM4KeySize))
+	var x Block
+	b.SetBytes(SM4BlockSize)
+	for b.Loop() {
+		c.Encrypt(&x, &x)
+	}
+}
+
+func BenchmarkSM4Encrypt4(b *testing.B) {
+	c, _ := NewSM4(make([]byte, SM4KeySize))
+	var x Block4
+	b.SetBytes(int64(len(x)))
+	for b.Loop() {
+		c.Encrypt4(&x, &x)
+	}
+}
+
+func BenchmarkSM4Encrypt8(b *testing.B) {
+	c, _ := NewSM4(make([]byte, SM4KeySize))
+	var x [8 * SM4BlockSize]byte
+	b.SetBytes(int64(len(x)))
+	for b.Loop() {
+		c.Encrypt8(&x, &x)
+	}
+}
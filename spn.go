@@ -0,0 +1,391 @@
+package aes
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jedisct1/go-aes/gf"
+)
+
+// SPNConfig describes an AES-like substitution-permutation network, for
+// toy ciphers, reduced variants and AES with other components. The state is
+// a matrix of Rows x Cols cells of e bits, where e is the degree of Field,
+// stored one cell per byte in column-major order like the AES state. A round
+// is SubBytes, ShiftRows, MixColumns and AddRoundKey, and encryption starts
+// with an AddRoundKey.
+//
+// The zero value of every field selects the AES component, so the zero
+// SPNConfig is AES, and the AES key schedule rule with the configured S-box
+// and field is used when KeySchedule is nil.
+type SPNConfig struct {
+	// Field is the field of the cells. nil is gf.AES.
+	Field *gf.Field
+
+	// Rows and Cols are the dimensions of the state. 0 is 4.
+	Rows, Cols int
+
+	// Sbox is the S-box, with Field.Size() entries. nil is the AES S-box
+	// for 8-bit cells, and the inversion of Field for other sizes.
+	Sbox []byte
+
+	// ShiftRows are the left rotations of the rows, modulo Cols. nil
+	// rotates row i by i.
+	ShiftRows []int
+
+	// MixColumns is the Rows x Rows matrix over Field applied to every
+	// column. nil is the AES matrix for 4 rows, (3 2; 2 3) for 2 rows and
+	// the identity for 1 row.
+	MixColumns [][]byte
+
+	// FinalMixColumns makes the last round a full round. AES omits
+	// MixColumns in the last round.
+	FinalMixColumns bool
+
+	// Rounds is the number of rounds. 0 is the AES rule: 6 plus the larger
+	// of Cols and the number of key columns.
+	Rounds int
+
+	// KeySchedule expands the key. nil is SPNAESKeySchedule.
+	KeySchedule SPNKeySchedule
+}
+
+// SPNKeySchedule expands a key of cells into rounds+1 round keys of
+// s.Rows()*s.Cols() cells. It can use the field and the S-box of s.
+type SPNKeySchedule func(s *SPN, key []byte, rounds int) ([][]byte, error)
+
+// SPN is an SPNConfig with an expanded key. It is safe for concurrent use.
+type SPN struct {
+	f          *gf.Field
+	rows, cols int
+	sbox       []byte
+	invSbox    []byte
+	shifts     []int
+	mix        [][]byte
+	invMix     [][]byte
+	finalMix   bool
+	rounds     int
+	rk         [][]byte
+}
+
+var aesMixColumns = [][]byte{{2, 3, 1, 1}, {1, 2, 3, 1}, {1, 1, 2, 3}, {3, 1, 1, 2}}
+
+// NewSPN builds the cipher described by cfg with the given key, a sequence
+// of cells. With the zero SPNConfig and a 16, 24 or 32-byte key, it is AES.
+func NewSPN(cfg SPNConfig, key []byte) (*SPN, error) {
+	s := &SPN{
+		f:        cfg.Field,
+		rows:     cfg.Rows,
+		cols:     cfg.Cols,
+		finalMix: cfg.FinalMixColumns,
+		rounds:   cfg.Rounds,
+	}
+	if s.f == nil {
+		s.f = gf.AES
+	}
+	if s.rows == 0 {
+		s.rows = 4
+	}
+	if s.cols == 0 {
+		s.cols = 4
+	}
+	if s.rows < 0 || s.cols < 0 {
+		return nil, errors.New("aes: invalid SPN dimensions")
+	}
+	n := s.f.Size()
+
+	switch {
+	case cfg.Sbox != nil:
+		s.sbox = slices.Clone(cfg.Sbox)
+	case s.f.Bits() == 8 && s.f.Poly() == gf.AES.Poly():
+		s.sbox = slices.Clone(sbox[:])
+	default:
+		s.sbox = make([]byte, n)
+		for x := range s.sbox {
+			s.sbox[x] = s.f.Inv(byte(x))
+		}
+	}
+	if len(s.sbox) != n {
+		return nil, fmt.Errorf("aes: SPN S-box must have %d entries", n)
+	}
+	s.invSbox = make([]byte, n)
+	seen := make([]bool, n)
+	for x, y := range s.sbox {
+		if int(y) >= n || seen[y] {
+			return nil, errors.New("aes: SPN S-box is not a permutation")
+		}
+		seen[y] = true
+		s.invSbox[y] = byte(x)
+	}
+
+	s.shifts = cfg.ShiftRows
+	if s.shifts == nil {
+		s.shifts = make([]int, s.rows)
+		for i := range s.shifts {
+			s.shifts[i] = i
+		}
+	}
+	if len(s.shifts) != s.rows {
+		return nil, fmt.Errorf("aes: SPN ShiftRows must have %d offsets", s.rows)
+	}
+	s.shifts = slices.Clone(s.shifts)
+	for i, r := range s.shifts {
+		s.shifts[i] = (r%s.cols + s.cols) % s.cols
+	}
+
+	s.mix = cfg.MixColumns
+	if s.mix == nil {
+		switch s.rows {
+		case 1:
+			s.mix = [][]byte{{1}}
+		case 2:
+			s.mix = [][]byte{{3, 2}, {2, 3}}
+		case 4:
+			s.mix = aesMixColumns
+		default:
+			return nil, errors.New("aes: SPN MixColumns is required for this number of rows")
+		}
+	}
+	if len(s.mix) != s.rows {
+		return nil, fmt.Errorf("aes: SPN MixColumns must be %dx%d", s.rows, s.rows)
+	}
+	for _, row := range s.mix {
+		if slices.ContainsFunc(row, func(c byte) bool { return int(c) >= n }) {
+			return nil, errors.New("aes: SPN MixColumns has entries outside the field")
+		}
+	}
+	invMix, err := s.f.InvertMatrix(s.mix)
+	if err != nil {
+		return nil, fmt.Errorf("aes: SPN MixColumns: %w", err)
+	}
+	s.mix = cloneMatrix(s.mix)
+	s.invMix = invMix
+
+	if slices.ContainsFunc(key, func(c byte) bool { return int(c) >= n }) {
+		return nil, errors.New("aes: SPN key has cells outside the field")
+	}
+	if s.rounds == 0 {
+		if len(key)%s.rows != 0 {
+			return nil, errors.New("aes: SPN key must be a whole number of columns")
+		}
+		s.rounds = max(s.cols, len(key)/s.rows) + 6
+	}
+	if s.rounds < 1 {
+		return nil, errors.New("aes: invalid number of SPN rounds")
+	}
+
+	ks := cfg.KeySchedule
+	if ks == nil {
+		ks = SPNAESKeySchedule
+	}
+	s.rk, err = ks(s, key, s.rounds)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.rk) != s.rounds+1 {
+		return nil, fmt.Errorf("aes: SPN key schedule returned %d round keys, want %d", len(s.rk), s.rounds+1)
+	}
+	for _, k := range s.rk {
+		if len(k) != s.Cells() {
+			return nil, fmt.Errorf("aes: SPN round keys must have %d cells", s.Cells())
+		}
+	}
+	return s, nil
+}
+
+func cloneMatrix(m [][]byte) [][]byte {
+	c := make([][]byte, len(m))
+	for i, row := range m {
+		c[i] = slices.Clone(row)
+	}
+	return c
+}
+
+// SPNAESKeySchedule is the AES key expansion generalized to columns of
+// s.Rows() cells: the key is split into Nk columns, and every column after
+// them is the column Nk positions before xored with the previous column,
+// which is first rotated up by one cell, passed through the S-box and xored
+// with x^(i/Nk - 1) in its first cell when i is a multiple of Nk, or only
+// passed through the S-box when Nk > 6 and i = 4 mod Nk.
+func SPNAESKeySchedule(s *SPN, key []byte, rounds int) ([][]byte, error) {
+	r := s.rows
+	if len(key) == 0 || len(key)%r != 0 {
+		return nil, errors.New("aes: SPN key must be a whole number of columns")
+	}
+	nk := len(key) / r
+	w := make([]byte, s.Cells()*(rounds+1))
+	copy(w, key)
+	for i := nk; i < len(w)/r; i++ {
+		t := slices.Clone(w[(i-1)*r : i*r])
+		switch {
+		case i%nk == 0:
+			t = append(t[1:], t[0])
+			for j := range t {
+				t[j] = s.sbox[t[j]]
+			}
+			t[0] ^= s.f.Pow(2, i/nk-1)
+		case nk > 6 && i%nk == 4:
+			for j := range t {
+				t[j] = s.sbox[t[j]]
+			}
+		}
+		for j := range t {
+			w[i*r+j] = w[(i-nk)*r+j] ^ t[j]
+		}
+	}
+	rk := make([][]byte, rounds+1)
+	for i := range rk {
+		rk[i] = w[i*s.Cells() : (i+1)*s.Cells()]
+	}
+	return rk, nil
+}
+
+// SmallScaleAES returns the configuration of the small-scale variant
+// SR(n, r, c, e) of AES by Cid, Murphy and Robshaw: n rounds on r rows and c
+// columns of e-bit cells, with r and c in {1, 2, 4} and e in {4, 8}. The key
+// has r·c cells. The 4-bit cells are in GF(2^4) with x^4 + x + 1, and their
+// S-box is the inversion followed by the affine map with the rows 0x7 <<< i
+// and the constant 0x6.
+//
+// As in AES, the last round has no MixColumns; set FinalMixColumns to get
+// SR*(n, r, c, e). The key schedule is the AES rule, so SR(10, 4, 4, 8) is
+// AES-128.
+func SmallScaleAES(n, r, c, e int) (SPNConfig, error) {
+	valid := func(x int) bool { return x == 1 || x == 2 || x == 4 }
+	if n < 1 || n > 10 || !valid(r) || !valid(c) {
+		return SPNConfig{}, errors.New("aes: SR(n, r, c, e) needs 1 ≤ n ≤ 10 and r, c in {1, 2, 4}")
+	}
+	cfg := SPNConfig{Rows: r, Cols: c, Rounds: n}
+	switch e {
+	case 4:
+		cfg.Field = gf.GF16
+		cfg.Sbox = gf.GF16.InversionSbox([]byte{0x7, 0xe, 0xd, 0xb}, 0x6)
+	case 8:
+		cfg.Field = gf.AES
+	default:
+		return SPNConfig{}, errors.New("aes: SR(n, r, c, e) needs e in {4, 8}")
+	}
+	return cfg, nil
+}
+
+// Field returns the field of the cells.
+func (s *SPN) Field() *gf.Field { return s.f }
+
+// Rows returns the number of rows of the state.
+func (s *SPN) Rows() int { return s.rows }
+
+// Cols returns the number of columns of the state.
+func (s *SPN) Cols() int { return s.cols }
+
+// Cells returns the number of cells of the state, Rows()*Cols().
+func (s *SPN) Cells() int { return s.rows * s.cols }
+
+// Rounds returns the number of rounds.
+func (s *SPN) Rounds() int { return s.rounds }
+
+// SubCell applies the S-box to a single cell.
+func (s *SPN) SubCell(x byte) byte { return s.sbox[x] }
+
+// RoundKey returns round key i, for 0 ≤ i ≤ Rounds(). The caller must not
+// modify it.
+func (s *SPN) RoundKey(i int) []byte { return s.rk[i] }
+
+// Encrypt encrypts the Cells() cells of src into dst, which may overlap.
+func (s *SPN) Encrypt(dst, src []byte) {
+	state := s.load(src)
+	s.AddRoundKey(state, 0)
+	for r := 1; r <= s.rounds; r++ {
+		s.SubBytes(state)
+		s.ShiftRows(state)
+		if r < s.rounds || s.finalMix {
+			s.MixColumns(state)
+		}
+		s.AddRoundKey(state, r)
+	}
+	copy(dst, state)
+}
+
+// Decrypt decrypts the Cells() cells of src into dst, which may overlap.
+func (s *SPN) Decrypt(dst, src []byte) {
+	state := s.load(src)
+	for r := s.rounds; r >= 1; r-- {
+		s.AddRoundKey(state, r)
+		if r < s.rounds || s.finalMix {
+			s.InvMixColumns(state)
+		}
+		s.InvShiftRows(state)
+		s.InvSubBytes(state)
+	}
+	s.AddRoundKey(state, 0)
+	copy(dst, state)
+}
+
+func (s *SPN) load(src []byte) []byte {
+	if len(src) < s.Cells() {
+		panic("aes: SPN input not full block")
+	}
+	state := slices.Clone(src[:s.Cells()])
+	if slices.ContainsFunc(state, func(c byte) bool { return int(c) >= s.f.Size() }) {
+		panic("aes: SPN input has cells outside the field")
+	}
+	return state
+}
+
+// SubBytes applies the S-box to every cell of state.
+func (s *SPN) SubBytes(state []byte) {
+	for i, x := range state[:s.Cells()] {
+		state[i] = s.sbox[x]
+	}
+}
+
+// InvSubBytes is the inverse of SubBytes.
+func (s *SPN) InvSubBytes(state []byte) {
+	for i, x := range state[:s.Cells()] {
+		state[i] = s.invSbox[x]
+	}
+}
+
+// ShiftRows rotates every row of state to the left by its offset.
+func (s *SPN) ShiftRows(state []byte) {
+	s.shiftRows(state, 1)
+}
+
+// InvShiftRows is the inverse of ShiftRows.
+func (s *SPN) InvShiftRows(state []byte) {
+	s.shiftRows(state, -1)
+}
+
+func (s *SPN) shiftRows(state []byte, dir int) {
+	row := make([]byte, s.cols)
+	for i, sh := range s.shifts {
+		for j := range row {
+			row[j] = state[i+s.rows*(((j+dir*sh)%s.cols+s.cols)%s.cols)]
+		}
+		for j, x := range row {
+			state[i+s.rows*j] = x
+		}
+	}
+}
+
+// MixColumns multiplies every column of state by the MixColumns matrix.
+func (s *SPN) MixColumns(state []byte) {
+	s.mixColumns(state, s.mix)
+}
+
+// InvMixColumns is the inverse of MixColumns.
+func (s *SPN) InvMixColumns(state []byte) {
+	s.mixColumns(state, s.invMix)
+}
+
+func (s *SPN) mixColumns(state []byte, m [][]byte) {
+	for j := 0; j < s.Cells(); j += s.rows {
+		copy(state[j:], s.f.MulMatrix(m, state[j:j+s.rows]))
+	}
+}
+
+// AddRoundKey xors round key i into state.
+func (s *SPN) AddRoundKey(state []byte, i int) {
+	for j, k := range s.rk[i] {
+		state[j] ^= k
+	}
+}
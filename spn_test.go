@@ -0,0 +1,243 @@
+package aes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/jedisct1/go-aes/gf"
+)
+
+// FIPS-197, Appendix C.
+func TestSPNDefaultIsAES(t *testing.T) {
+	pt := mustHex(t, "00112233445566778899aabbccddeeff")
+	for _, v := range []struct{ key, ct string }{
+		{"000102030405060708090a0b0c0d0e0f", "69c4e0d86a7b0430d8cdb78070b4c55a"},
+		{"000102030405060708090a0b0c0d0e0f1011121314151617", "dda97ca4864cdfe06eaf70a0ec0d7191"},
+		{"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", "8ea2b7ca516745bfeafc49904b496089"},
+	} {
+		key := mustHex(t, v.key)
+		s, err := NewSPN(SPNConfig{}, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := len(key)/4 + 6; s.Rounds() != want {
+			t.Errorf("Rounds() = %d, want %d", s.Rounds(), want)
+		}
+		ks, _ := NewKeySchedule(key)
+		for i := 0; i <= s.Rounds(); i++ {
+			if !bytes.Equal(s.RoundKey(i), ks.GetRoundKey(i)[:]) {
+				t.Fatalf("AES-%d round key %d = %x, want %x", 8*len(key), i, s.RoundKey(i), ks.GetRoundKey(i))
+			}
+		}
+
+		out := make([]byte, 16)
+		s.Encrypt(out, pt)
+		if hex.EncodeToString(out) != v.ct {
+			t.Fatalf("AES-%d Encrypt = %x, want %s", 8*len(key), out, v.ct)
+		}
+		s.Decrypt(out, out)
+		if !bytes.Equal(out, pt) {
+			t.Fatalf("AES-%d Decrypt = %x, want %x", 8*len(key), out, pt)
+		}
+	}
+}
+
+// The steps of the default SPN are the AES steps.
+func TestSPNStepsMatchAES(t *testing.T) {
+	s, _ := NewSPN(SPNConfig{}, make([]byte, 16))
+	var b Block
+	for i := range b {
+		b[i] = byte(i*37 + 11)
+	}
+	state := bytes.Clone(b[:])
+
+	for _, step := range []struct {
+		name string
+		spn  func([]byte)
+		aes  func(*Block)
+	}{
+		{"SubBytes", s.SubBytes, SubBytes},
+		{"ShiftRows", s.ShiftRows, ShiftRows},
+		{"MixColumns", s.MixColumns, MixColumns},
+		{"InvMixColumns", s.InvMixColumns, InvMixColumns},
+		{"InvShiftRows", s.InvShiftRows, InvShiftRows},
+		{"InvSubBytes", s.InvSubBytes, InvSubBytes},
+	} {
+		step.spn(state)
+		step.aes(&b)
+		if !bytes.Equal(state, b[:]) {
+			t.Fatalf("%s = %x, want %x", step.name, state, b)
+		}
+	}
+}
+
+func TestSmallScaleAES(t *testing.T) {
+	key := mustHex(t, "2b7e151628aed2a6abf7158809cf4f3c")
+	cfg, err := SmallScaleAES(10, 4, 4, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewSPN(cfg, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := make([]byte, 16)
+	s.Encrypt(out, mustHex(t, "3243f6a8885a308d313198a2e0370734"))
+	if want := "3925841d02dc09fbdc118597196a0b32"; hex.EncodeToString(out) != want {
+		t.Fatalf("SR(10, 4, 4, 8) = %x, want %s", out, want)
+	}
+
+	for _, e := range []int{4, 8} {
+		for _, r := range []int{1, 2, 4} {
+			for _, c := range []int{1, 2, 4} {
+				for _, star := range []bool{false, true} {
+					name := fmt.Sprintf("SR(4, %d, %d, %d) star=%v", r, c, e, star)
+					cfg, err := SmallScaleAES(4, r, c, e)
+					if err != nil {
+						t.Fatal(err)
+					}
+					cfg.FinalMixColumns = star
+					key := make([]byte, r*c)
+					pt := make([]byte, r*c)
+					for i := range key {
+						key[i] = byte(i*5+1) & byte(1<<e-1)
+						pt[i] = byte(i*3+7) & byte(1<<e-1)
+					}
+					s, err := NewSPN(cfg, key)
+					if err != nil {
+						t.Fatalf("%s: %v", name, err)
+					}
+					if s.Rounds() != 4 || s.Cells() != r*c {
+						t.Fatalf("%s: %d rounds on %d cells", name, s.Rounds(), s.Cells())
+					}
+					ct := make([]byte, len(pt))
+					s.Encrypt(ct, pt)
+					for _, x := range ct {
+						if int(x) >= s.Field().Size() {
+							t.Fatalf("%s: cell %#x outside the field", name, x)
+						}
+					}
+					if bytes.Equal(ct, pt) {
+						t.Fatalf("%s: Encrypt is the identity", name)
+					}
+					s.Decrypt(ct, ct)
+					if !bytes.Equal(ct, pt) {
+						t.Fatalf("%s: Decrypt does not invert Encrypt", name)
+					}
+				}
+			}
+		}
+	}
+
+	for _, p := range [][4]int{{0, 4, 4, 8}, {11, 4, 4, 8}, {10, 3, 4, 8}, {10, 4, 8, 8}, {10, 4, 4, 6}} {
+		if _, err := SmallScaleAES(p[0], p[1], p[2], p[3]); err == nil {
+			t.Errorf("SmallScaleAES%v accepted invalid parameters", p)
+		}
+	}
+}
+
+// The 4-bit S-box of SR is the one of the reference implementation in Sage.
+func TestSmallScaleAESSbox(t *testing.T) {
+	cfg, _ := SmallScaleAES(1, 1, 1, 4)
+	s, err := NewSPN(cfg, []byte{0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{6, 11, 5, 4, 2, 14, 7, 10, 9, 13, 15, 12, 3, 1, 0, 8}
+	for x, y := range want {
+		if got := s.SubCell(byte(x)); got != y {
+			t.Fatalf("S(%#x) = %#x, want %#x", x, got, y)
+		}
+	}
+}
+
+func TestSPNCustom(t *testing.T) {
+	// AES with a different S-box, offsets and matrix.
+	f := gf.AES
+	sb := f.InversionSbox([]byte{0x1f, 0x3e, 0x7c, 0xf8, 0xf1, 0xe3, 0xc7, 0x8f}, 0x05)
+	cfg := SPNConfig{
+		Sbox:            sb,
+		ShiftRows:       []int{0, 3, 2, 1},
+		MixColumns:      [][]byte{{1, 1, 4, 9}, {9, 1, 1, 4}, {4, 9, 1, 1}, {1, 4, 9, 1}},
+		FinalMixColumns: true,
+		Rounds:          7,
+	}
+	key := make([]byte, 16)
+	s, err := NewSPN(cfg, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pt := []byte("custom spn block")
+	ct := make([]byte, 16)
+	s.Encrypt(ct, pt)
+	std, _ := NewSPN(SPNConfig{}, key)
+	ref := make([]byte, 16)
+	std.Encrypt(ref, pt)
+	if bytes.Equal(ct, ref) {
+		t.Fatal("the custom configuration encrypts like AES")
+	}
+	s.Decrypt(ct, ct)
+	if !bytes.Equal(ct, pt) {
+		t.Fatal("Decrypt does not invert Encrypt")
+	}
+
+	// A custom key schedule: the key in every round.
+	cfg = SPNConfig{
+		Rows:   2,
+		Cols:   3,
+		Rounds: 5,
+		KeySchedule: func(s *SPN, key []byte, rounds int) ([][]byte, error) {
+			rk := make([][]byte, rounds+1)
+			for i := range rk {
+				rk[i] = key
+			}
+			return rk, nil
+		},
+	}
+	s, err = NewSPN(cfg, []byte{1, 2, 3, 4, 5, 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pt = []byte{9, 8, 7, 6, 5, 4}
+	ct = make([]byte, 6)
+	s.Encrypt(ct, pt)
+	s.Decrypt(ct, ct)
+	if !bytes.Equal(ct, pt) {
+		t.Fatal("Decrypt does not invert Encrypt with a custom key schedule")
+	}
+}
+
+func TestSPNInvalid(t *testing.T) {
+	key := make([]byte, 16)
+	notPerm := make([]byte, 256)
+	for _, c := range []struct {
+		name string
+		cfg  SPNConfig
+		key  []byte
+	}{
+		{"non-permutation S-box", SPNConfig{Sbox: notPerm}, key},
+		{"short S-box", SPNConfig{Sbox: sbox[:16]}, key},
+		{"singular MixColumns", SPNConfig{MixColumns: [][]byte{{1, 1, 1, 1}, {1, 1, 1, 1}, {2, 3, 1, 1}, {1, 2, 3, 1}}}, key},
+		{"non-square MixColumns", SPNConfig{MixColumns: [][]byte{{1, 2, 3}, {1, 2, 3}, {1, 2, 3}, {1, 2, 3}}}, key},
+		{"no default MixColumns", SPNConfig{Rows: 3}, make([]byte, 12)},
+		{"ShiftRows length", SPNConfig{ShiftRows: []int{0, 1, 2}}, key},
+		{"key length", SPNConfig{}, make([]byte, 15)},
+		{"key cells", SPNConfig{Field: gf.GF16}, bytes.Repeat([]byte{0x10}, 16)},
+		{"round keys", SPNConfig{KeySchedule: func(*SPN, []byte, int) ([][]byte, error) { return nil, nil }}, key},
+	} {
+		if _, err := NewSPN(c.cfg, c.key); err == nil {
+			t.Errorf("%s: NewSPN succeeded", c.name)
+		}
+	}
+}
+
+func BenchmarkSPNEncrypt(b *testing.B) {
+	s, _ := NewSPN(SPNConfig{}, make([]byte, 16))
+	x := make([]byte, 16)
+	b.SetBytes(16)
+	for b.Loop() {
+		s.Encrypt(x, x)
+	}
+}
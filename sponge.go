@@ -0,0 +1,126 @@
+package aes
+
+import (
+	"hash"
+	"io"
+)
+
+// Sponge is a hash function and extendable-output function over any
+// Permutation. It implements hash.Hash, with Sum returning the first Size()
+// output bytes, and io.Reader to produce output of any length. Writing after
+// the first Read panics.
+//
+// Input is absorbed rate bytes at a time into the outer part of the state and
+// padded as in Haraka-S, with a 0x1F domain byte and a final 0x80 bit. The
+// remaining capacity bytes are never directly written or output; a capacity
+// of c bytes gives a generic security level of 4c bits.
+type Sponge struct {
+	p         Permutation
+	rate      int
+	size      int
+	n         int // position in the rate, for absorbing or squeezing
+	squeezing bool
+	saved     []byte // copy of the state for Sum
+}
+
+var (
+	_ hash.Hash = (*Sponge)(nil)
+	_ io.Reader = (*Sponge)(nil)
+)
+
+// NewSponge returns a sponge that owns the state p, with a rate of rate bytes
+// and a digest size of size bytes. The state is reset to zero. It panics if
+// rate is not between 1 and p.Size()-1, or if size is not positive.
+func NewSponge(p Permutation, rate, size int) *Sponge {
+	if rate <= 0 || rate >= p.Size() {
+		panic("aes: invalid sponge rate")
+	}
+	if size <= 0 {
+		panic("aes: invalid sponge digest size")
+	}
+	s := &Sponge{p: p, rate: rate, size: size, saved: make([]byte, p.Size())}
+	s.Reset()
+	return s
+}
+
+// Reset resets the sponge to the all-zero state.
+func (s *Sponge) Reset() {
+	clear(s.p.Bytes())
+	s.n = 0
+	s.squeezing = false
+}
+
+// Size returns the digest size in bytes.
+func (s *Sponge) Size() int { return s.size }
+
+// BlockSize returns the rate in bytes.
+func (s *Sponge) BlockSize() int { return s.rate }
+
+// Write absorbs p. It never returns an error.
+func (s *Sponge) Write(p []byte) (int, error) {
+	if s.squeezing {
+		panic("aes: write to sponge after read")
+	}
+	state := s.p.Bytes()
+	nn := len(p)
+	for len(p) > 0 {
+		k := min(s.rate-s.n, len(p))
+		for i := range k {
+			state[s.n+i] ^= p[i]
+		}
+		s.n += k
+		p = p[k:]
+		if s.n == s.rate {
+			s.p.Permute()
+			s.n = 0
+		}
+	}
+	return nn, nil
+}
+
+// pad finishes absorbing and switches to squeezing.
+func (s *Sponge) pad() {
+	state := s.p.Bytes()
+	state[s.n] ^= 0x1f
+	state[s.rate-1] ^= 0x80
+	s.n = s.rate
+	s.squeezing = true
+}
+
+// Read squeezes len(p) bytes of output. It never returns an error.
+func (s *Sponge) Read(p []byte) (int, error) {
+	if !s.squeezing {
+		s.pad()
+	}
+	state := s.p.Bytes()
+	nn := len(p)
+	for len(p) > 0 {
+		if s.n == s.rate {
+			s.p.Permute()
+			s.n = 0
+		}
+		k := copy(p, state[s.n:s.rate])
+		s.n += k
+		p = p[k:]
+	}
+	return nn, nil
+}
+
+// Sum appends the first Size() output bytes to b. It does not change the
+// underlying sponge state and can only be called before Read.
+func (s *Sponge) Sum(b []byte) []byte {
+	if s.squeezing {
+		panic("aes: sponge Sum after read")
+	}
+	state := s.p.Bytes()
+	copy(s.saved, state)
+	n := s.n
+
+	ret, out := sliceForAppend(b, s.size)
+	s.Read(out)
+
+	copy(state, s.saved)
+	s.n = n
+	s.squeezing = false
+	return ret
+}
@@ -0,0 +1,102 @@
+package aes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestSpongeMatchesHarakaS(t *testing.T) {
+	msg := make([]byte, 100)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+	for _, n := range []int{0, 1, 31, 32, 33, 64, 100} {
+		want := make([]byte, 80)
+		HarakaSSum(want, msg[:n], nil)
+
+		s := NewSponge(NewHarakaPermutation512(nil).NewState(), HarakaSRate, HarakaSSize)
+		s.Write(msg[:n])
+		if got := s.Sum(nil); !bytes.Equal(got, want[:HarakaSSize]) {
+			t.Errorf("%d bytes: Sum = %x, expected %x", n, got, want[:HarakaSSize])
+		}
+		got := make([]byte, 80)
+		s.Read(got)
+		if !bytes.Equal(got, want) {
+			t.Errorf("%d bytes: Read = %x, expected %x", n, got, want)
+		}
+	}
+}
+
+func TestSpongeStreaming(t *testing.T) {
+	msg := make([]byte, 300)
+	for i := range msg {
+		msg[i] = byte(i * 3)
+	}
+	s := NewSponge(new(Areion512), 32, 32)
+	s.Write(msg)
+	want := s.Sum(nil)
+
+	for _, step := range []int{1, 7, 31, 32, 33, 100} {
+		s.Reset()
+		for i := 0; i < len(msg); i += step {
+			s.Write(msg[i:min(i+step, len(msg))])
+		}
+		// Sum must not disturb the state
+		s.Sum(nil)
+		if got := s.Sum(nil); !bytes.Equal(got, want) {
+			t.Errorf("step %d: got %x, expected %x", step, got, want)
+		}
+	}
+
+	// Sum then more input gives the hash of the whole input
+	s.Reset()
+	s.Write(msg[:100])
+	s.Sum(nil)
+	s.Write(msg[100:])
+	if got := s.Sum(nil); !bytes.Equal(got, want) {
+		t.Errorf("Write after Sum: got %x, expected %x", got, want)
+	}
+}
+
+func TestSpongeInvalidParameters(t *testing.T) {
+	for _, tc := range []struct{ rate, size int }{{0, 32}, {64, 32}, {32, 0}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("rate %d, size %d: NewSponge did not panic", tc.rate, tc.size)
+				}
+			}()
+			NewSponge(new(Areion512), tc.rate, tc.size)
+		}()
+	}
+}
+
+func TestSpongeKnownAnswers(t *testing.T) {
+	tests := []struct {
+		name     string
+		p        Permutation
+		rate     int
+		expected string
+	}{
+		{"Areion512", new(Areion512), 32, "be888e281a6f3d739024fa7ab15fd8ce33a53bd1fca782b20a886e3d83a242a8"},
+		{"AESQ", new(AESQ), 32, "526a3bcc245a6a78edab7df3a7d60272ec872765c1e3ea7715433fdcf62b0ee7"},
+		{"Simpira4", new(Simpira4), 32, "c86c42ad48ac91633fd684df337f1a2313d045e004fc474acbd951305d33f27c"},
+	}
+	for _, tc := range tests {
+		s := NewSponge(tc.p, tc.rate, 32)
+		s.Write([]byte("abc"))
+		if got := hex.EncodeToString(s.Sum(nil)); got != tc.expected {
+			t.Errorf("%s: got %s, expected %s", tc.name, got, tc.expected)
+		}
+	}
+}
+
+func BenchmarkSpongeAreion512(b *testing.B) {
+	s := NewSponge(new(Areion512), 32, 32)
+	buf := make([]byte, 1024)
+	b.SetBytes(int64(len(buf)))
+	for i := 0; i < b.N; i++ {
+		s.Write(buf)
+	}
+}
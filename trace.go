@@ -0,0 +1,452 @@
+package aes
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Tracing reports the intermediate states of a computation, step by step, for
+// debugging new constructions and for teaching. The traced functions are
+// software implementations that produce the same results as their optimized
+// counterparts, and call a Tracer after every step.
+//
+// AES, KIASU-BC and Deoxys-BC are traced with the labels of FIPS-197 Appendix
+// C: the state at the start of every round, after SubBytes, ShiftRows and
+// MixColumns, and the round key, whose addition gives the next start state.
+// Decryption follows the inverse cipher of FIPS-197 and uses the labels with
+// an "i" prefix. The wider permutations and ciphers also report the state of
+// a lane after every AddRoundKey, and the whole state after the permutations
+// that mix their lanes.
+
+// Step labels of TraceEvent.
+const (
+	TraceStepInput       = "input"  // input, before the first step
+	TraceStepStart       = "start"  // state at the start of a round
+	TraceStepSubBytes    = "s_box"  // state after SubBytes
+	TraceStepShiftRows   = "s_row"  // state after ShiftRows
+	TraceStepMixColumns  = "m_col"  // state after MixColumns
+	TraceStepRoundKey    = "k_sch"  // round key, or value about to be added
+	TraceStepAddRoundKey = "k_add"  // state after AddRoundKey
+	TraceStepMix         = "mix"    // whole state after a permutation of the lanes
+	TraceStepOutput      = "output" // output, after the last step
+
+	TraceStepInvInput       = "iinput"
+	TraceStepInvStart       = "istart"
+	TraceStepInvSubBytes    = "is_box"
+	TraceStepInvShiftRows   = "is_row"
+	TraceStepInvRoundKey    = "ik_sch"
+	TraceStepInvAddRoundKey = "ik_add"
+	TraceStepInvOutput      = "ioutput"
+)
+
+// TraceEvent is a step of a traced computation.
+type TraceEvent struct {
+	// Round is the round number, 0 before the first round.
+	Round int
+
+	// Step is one of the TraceStep labels.
+	Step string
+
+	// Lane is the 128-bit block of the state that the step applies to, or -1
+	// when the step applies to the whole state. Ciphers with a 128-bit block
+	// only use lane 0. When a new lane value is computed from another lane,
+	// as in Areion, the steps are reported on the lane that receives it.
+	Lane int
+
+	// State is the lane, or the whole state when Lane is -1, after the
+	// step. For TraceStepRoundKey, it is the value about to be added.
+	State []byte
+}
+
+// MarshalJSON encodes the event as an object with the round, step, lane and
+// the state in hexadecimal.
+func (e TraceEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Round int    `json:"round"`
+		Step  string `json:"step"`
+		Lane  int    `json:"lane"`
+		State string `json:"state"`
+	}{e.Round, e.Step, e.Lane, hex.EncodeToString(e.State)})
+}
+
+// Tracer receives the steps of a traced computation. The State of an event
+// is only valid during the call.
+type Tracer interface {
+	Trace(e TraceEvent)
+}
+
+// TracerFunc adapts a function to the Tracer interface.
+type TracerFunc func(e TraceEvent)
+
+// Trace calls f(e).
+func (f TracerFunc) Trace(e TraceEvent) { f(e) }
+
+// TraceLog is a Tracer that records all the events.
+type TraceLog struct {
+	Events []TraceEvent `json:"events"`
+}
+
+// Trace appends a copy of e to the log.
+func (l *TraceLog) Trace(e TraceEvent) {
+	e.State = append([]byte(nil), e.State...)
+	l.Events = append(l.Events, e)
+}
+
+// WriteTo writes the events in the format of FIPS-197 Appendix C, one per
+// line: "round[ r].step" followed by the state in hexadecimal. When the log
+// has several lanes, the label of a lane step is prefixed with its index,
+// as in "round[ 1].x1.s_box".
+func (l *TraceLog) WriteTo(w io.Writer) (int64, error) {
+	lanes := false
+	for _, e := range l.Events {
+		lanes = lanes || e.Lane != 0
+	}
+	var n int64
+	for _, e := range l.Events {
+		label := e.Step
+		if lanes && e.Lane >= 0 {
+			label = fmt.Sprintf("x%d.%s", e.Lane, e.Step)
+		}
+		m, err := fmt.Fprintf(w, "%-20s%x\n", fmt.Sprintf("round[%2d].%s", e.Round, label), e.State)
+		n += int64(m)
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func traceBlock(t Tracer, round int, step string, lane int, b *Block) {
+	t.Trace(TraceEvent{Round: round, Step: step, Lane: lane, State: b[:]})
+}
+
+// traceState reports the concatenation of the lanes as the whole state.
+func traceState(t Tracer, round int, step string, lanes ...*Block) {
+	state := make([]byte, 0, 16*len(lanes))
+	for _, l := range lanes {
+		state = append(state, l[:]...)
+	}
+	t.Trace(TraceEvent{Round: round, Step: step, Lane: -1, State: state})
+}
+
+// TraceAddRoundKey is AddRoundKey, reporting the round key and the result as
+// steps of the given round and lane.
+func TraceAddRoundKey(t Tracer, round, lane int, block, roundKey *Block) {
+	traceBlock(t, round, TraceStepRoundKey, lane, roundKey)
+	AddRoundKey(block, roundKey)
+	traceBlock(t, round, TraceStepAddRoundKey, lane, block)
+}
+
+// TraceRoundNoKey is RoundNoKey, reporting its steps.
+func TraceRoundNoKey(t Tracer, round, lane int, block *Block) {
+	TraceFinalRoundNoKey(t, round, lane, block)
+	MixColumns(block)
+	traceBlock(t, round, TraceStepMixColumns, lane, block)
+}
+
+// TraceFinalRoundNoKey is FinalRoundNoKey, reporting its steps.
+func TraceFinalRoundNoKey(t Tracer, round, lane int, block *Block) {
+	SubBytes(block)
+	traceBlock(t, round, TraceStepSubBytes, lane, block)
+	ShiftRows(block)
+	traceBlock(t, round, TraceStepShiftRows, lane, block)
+}
+
+// TraceRound is Round, reporting its steps.
+func TraceRound(t Tracer, round, lane int, block, roundKey *Block) {
+	TraceRoundNoKey(t, round, lane, block)
+	TraceAddRoundKey(t, round, lane, block, roundKey)
+}
+
+// TraceFinalRound is FinalRound, reporting its steps.
+func TraceFinalRound(t Tracer, round, lane int, block, roundKey *Block) {
+	TraceFinalRoundNoKey(t, round, lane, block)
+	TraceAddRoundKey(t, round, lane, block, roundKey)
+}
+
+// TraceRoundKeyFirst is RoundKeyFirst, reporting its steps.
+func TraceRoundKeyFirst(t Tracer, round, lane int, block, roundKey *Block) {
+	TraceAddRoundKey(t, round, lane, block, roundKey)
+	TraceRoundNoKey(t, round, lane, block)
+}
+
+// traceCipher is the AES cipher with the round keys rk, traced as in FIPS-197
+// Appendix C.
+func traceCipher(t Tracer, block *Block, rk []Block) {
+	n := len(rk) - 1
+	traceBlock(t, 0, TraceStepInput, 0, block)
+	traceBlock(t, 0, TraceStepRoundKey, 0, &rk[0])
+	AddRoundKey(block, &rk[0])
+	for r := 1; r <= n; r++ {
+		traceBlock(t, r, TraceStepStart, 0, block)
+		SubBytes(block)
+		traceBlock(t, r, TraceStepSubBytes, 0, block)
+		ShiftRows(block)
+		traceBlock(t, r, TraceStepShiftRows, 0, block)
+		if r < n {
+			MixColumns(block)
+			traceBlock(t, r, TraceStepMixColumns, 0, block)
+		}
+		traceBlock(t, r, TraceStepRoundKey, 0, &rk[r])
+		AddRoundKey(block, &rk[r])
+	}
+	traceBlock(t, n, TraceStepOutput, 0, block)
+}
+
+// traceInvCipher is the AES inverse cipher with the round keys rk of the
+// cipher, traced as in FIPS-197 Appendix C.
+func traceInvCipher(t Tracer, block *Block, rk []Block) {
+	n := len(rk) - 1
+	traceBlock(t, 0, TraceStepInvInput, 0, block)
+	traceBlock(t, 0, TraceStepInvRoundKey, 0, &rk[n])
+	AddRoundKey(block, &rk[n])
+	for r := 1; r <= n; r++ {
+		traceBlock(t, r, TraceStepInvStart, 0, block)
+		InvShiftRows(block)
+		traceBlock(t, r, TraceStepInvShiftRows, 0, block)
+		InvSubBytes(block)
+		traceBlock(t, r, TraceStepInvSubBytes, 0, block)
+		traceBlock(t, r, TraceStepInvRoundKey, 0, &rk[n-r])
+		AddRoundKey(block, &rk[n-r])
+		if r < n {
+			traceBlock(t, r, TraceStepInvAddRoundKey, 0, block)
+			InvMixColumns(block)
+		}
+	}
+	traceBlock(t, n, TraceStepInvOutput, 0, block)
+}
+
+// TraceEncryptBlockAES is EncryptBlockAES, reporting every step to t.
+func TraceEncryptBlockAES(t Tracer, block *Block, ks *KeySchedule) {
+	traceCipher(t, block, ks.keys)
+}
+
+// TraceDecryptBlockAES decrypts block with the inverse cipher of FIPS-197,
+// reporting every step to t.
+func TraceDecryptBlockAES(t Tracer, block *Block, ks *KeySchedule) {
+	traceInvCipher(t, block, ks.keys)
+}
+
+// TraceEncrypt is KiasuEncrypt, reporting every step to t. The round keys
+// are the tweaked ones.
+func (ctx *KiasuContext) TraceEncrypt(t Tracer, block [16]byte, tweak [8]byte) [16]byte {
+	keys := ctx.getTweakedKeys(tweak)
+	traceCipher(t, (*Block)(&block), keys[:])
+	return block
+}
+
+// TraceDecrypt is KiasuDecrypt, reporting every step to t.
+func (ctx *KiasuContext) TraceDecrypt(t Tracer, block [16]byte, tweak [8]byte) [16]byte {
+	keys := ctx.getTweakedKeys(tweak)
+	traceInvCipher(t, (*Block)(&block), keys[:])
+	return block
+}
+
+// TraceDeoxysBC256Encrypt is DeoxysBC256Encrypt, reporting every step to t.
+// The round keys are the subtweakeys.
+func TraceDeoxysBC256Encrypt(t Tracer, rk *DeoxysBC256RoundKeys, plaintext *Block) Block {
+	state := *plaintext
+	traceCipher(t, &state, rk.STK[:])
+	return state
+}
+
+// TraceDeoxysBC256Decrypt is DeoxysBC256Decrypt, reporting every step to t.
+func TraceDeoxysBC256Decrypt(t Tracer, rk *DeoxysBC256RoundKeys, ciphertext *Block) Block {
+	state := *ciphertext
+	traceInvCipher(t, &state, rk.STK[:])
+	return state
+}
+
+// TracePermute is Permute, reporting every step to t. Round r computes the
+// new value of one lane from the other, which gets a final round.
+func (state *Areion256) TracePermute(t Tracer) {
+	x := [2]*Block{(*Block)(state[0:16]), (*Block)(state[16:32])}
+	traceState(t, 0, TraceStepInput, x[:]...)
+	for r := range 10 {
+		a, b := r%2, 1-r%2
+		temp := *x[a]
+		TraceRoundNoKey(t, r+1, b, &temp)
+		TraceAddRoundKey(t, r+1, b, &temp, (*Block)(&areionRoundConstants[r]))
+		TraceRoundNoKey(t, r+1, b, &temp)
+		TraceAddRoundKey(t, r+1, b, &temp, x[b])
+		TraceFinalRoundNoKey(t, r+1, a, x[a])
+		*x[b] = temp
+	}
+	traceState(t, 10, TraceStepOutput, x[:]...)
+}
+
+// TracePermute is Permute, reporting every step to t. The rotation of the
+// lanes at the end is reported as a TraceStepMix step.
+func (state *Areion512) TracePermute(t Tracer) {
+	var x [4]*Block
+	for i := range x {
+		x[i] = (*Block)(state[16*i : 16*i+16])
+	}
+	traceState(t, 0, TraceStepInput, x[:]...)
+	for r := range 15 {
+		a, b, c, d := r%4, (r+1)%4, (r+2)%4, (r+3)%4
+		temp := *x[a]
+		TraceRoundNoKey(t, r+1, b, &temp)
+		TraceAddRoundKey(t, r+1, b, &temp, x[b])
+		*x[b] = temp
+		temp = *x[c]
+		TraceRoundNoKey(t, r+1, d, &temp)
+		TraceAddRoundKey(t, r+1, d, &temp, x[d])
+		*x[d] = temp
+		TraceFinalRoundNoKey(t, r+1, a, x[a])
+		TraceFinalRoundNoKey(t, r+1, c, x[c])
+		TraceAddRoundKey(t, r+1, c, x[c], (*Block)(&areionRoundConstants[r]))
+		TraceRoundNoKey(t, r+1, c, x[c])
+	}
+	*x[0], *x[1], *x[2], *x[3] = *x[3], *x[0], *x[1], *x[2]
+	traceState(t, 15, TraceStepMix, x[:]...)
+	traceState(t, 15, TraceStepOutput, x[:]...)
+}
+
+// TraceHaraka256 is Haraka256, reporting every step to t. Every round of
+// Haraka is two AES rounds on each lane, numbered separately, followed by the
+// mixing of the lanes. The output is the hash, after the feed-forward.
+func TraceHaraka256(t Tracer, input *[32]byte) [32]byte {
+	state := *input
+	s := [2]*Block{(*Block)(state[0:16]), (*Block)(state[16:32])}
+	traceState(t, 0, TraceStepInput, s[:]...)
+	for round := range 5 {
+		for k := range 4 {
+			TraceRound(t, 2*round+1+k/2, k%2, s[k%2], (*Block)(&harakaRC128[4*round+k]))
+		}
+		mix2(s[0], s[1])
+		traceState(t, 2*round+2, TraceStepMix, s[:]...)
+	}
+	for i := range state {
+		state[i] ^= input[i]
+	}
+	traceState(t, 10, TraceStepOutput, s[:]...)
+	return state
+}
+
+// TraceHaraka512 is Haraka512, reporting every step to t, as TraceHaraka256.
+func TraceHaraka512(t Tracer, input *[64]byte) [32]byte {
+	state := *input
+	var s [4]*Block
+	for i := range s {
+		s[i] = (*Block)(state[16*i : 16*i+16])
+	}
+	traceState(t, 0, TraceStepInput, s[:]...)
+	for round := range 5 {
+		for k := range 8 {
+			TraceRound(t, 2*round+1+k/4, k%4, s[k%4], (*Block)(&harakaRC128[8*round+k]))
+		}
+		mix512(s[0], s[1], s[2], s[3])
+		traceState(t, 2*round+2, TraceStepMix, s[:]...)
+	}
+	var out [32]byte
+	harakaTruncate512(&out, &state, input)
+	t.Trace(TraceEvent{Round: 10, Step: TraceStepOutput, Lane: -1, State: out[:]})
+	return out
+}
+
+// tracePholkos runs the Pholkos rounds with the round tweakeys rtk on the
+// lanes s, reporting every step to t.
+func tracePholkos(t Tracer, s []*Block, rtk [][]Block, permute func()) {
+	traceState(t, 0, TraceStepInput, s...)
+	for j := range s {
+		TraceAddRoundKey(t, 0, j, s[j], &rtk[0][j])
+	}
+	rounds := len(rtk) - 1
+	for r := 1; r <= rounds; r++ {
+		for j := range s {
+			if r < rounds {
+				TraceRound(t, r, j, s[j], &rtk[r][j])
+			} else {
+				TraceFinalRound(t, r, j, s[j], &rtk[r][j])
+			}
+		}
+		if r%2 == 0 && r < rounds {
+			permute()
+			traceState(t, r, TraceStepMix, s...)
+		}
+	}
+	traceState(t, rounds, TraceStepOutput, s...)
+}
+
+// TraceEncrypt is Encrypt, reporting every step to t. The word permutation
+// between the steps of two rounds is reported as a TraceStepMix step.
+func (ctx *Pholkos256Context) TraceEncrypt(t Tracer, block *Pholkos256Block) {
+	s := []*Block{(*Block)(block[0:16]), (*Block)(block[16:32])}
+	rtk := make([][]Block, len(ctx.rtk))
+	for i := range rtk {
+		rtk[i] = ctx.rtk[i][:]
+	}
+	tracePholkos(t, s, rtk, func() { pholkos256PermuteWordsState(s[0], s[1]) })
+}
+
+// TraceEncrypt is Encrypt, reporting every step to t, as for Pholkos-256.
+func (ctx *Pholkos512Context) TraceEncrypt(t Tracer, block *Pholkos512Block) {
+	s := make([]*Block, 4)
+	for i := range s {
+		s[i] = (*Block)(block[16*i : 16*i+16])
+	}
+	rtk := make([][]Block, len(ctx.rtk))
+	for i := range rtk {
+		rtk[i] = ctx.rtk[i][:]
+	}
+	tracePholkos(t, s, rtk, func() { pholkos512PermuteWordsState(s[0], s[1], s[2], s[3]) })
+}
+
+// traceVistrutah runs the Vistrutah rounds with the fixed key fk and the
+// step keys rk on the lanes s, reporting every step to t.
+func traceVistrutah(t Tracer, s []*Block, fk []Block, rk [][]Block, mix func()) {
+	steps := len(rk) - 1
+	traceState(t, 0, TraceStepInput, s...)
+	for j := range s {
+		TraceAddRoundKey(t, 0, j, s[j], &rk[0][j])
+		TraceRound(t, 1, j, s[j], &fk[j])
+	}
+	var zero Block
+	for i := 1; i < steps; i++ {
+		for j := range s {
+			TraceRound(t, 2*i, j, s[j], &zero)
+		}
+		mix()
+		traceState(t, 2*i, TraceStepMix, s...)
+		for j := range s {
+			TraceAddRoundKey(t, 2*i, j, s[j], &rk[i][j])
+		}
+		TraceAddRoundKey(t, 2*i, 0, s[0], &vistrutahRoundConstants[i-1])
+		for j := range s {
+			TraceRound(t, 2*i+1, j, s[j], &fk[j])
+		}
+	}
+	for j := range s {
+		TraceFinalRound(t, 2*steps, j, s[j], &rk[steps][j])
+	}
+	traceState(t, 2*steps, TraceStepOutput, s...)
+}
+
+// TraceEncrypt is Encrypt, reporting every step to t. The mixing layer is
+// reported as a TraceStepMix step.
+func (c *Vistrutah256Cipher) TraceEncrypt(t Tracer, dst, src *Vistrutah256Block) {
+	*dst = *src
+	s := []*Block{(*Block)(dst[0:16]), (*Block)(dst[16:32])}
+	rk := make([][]Block, len(c.rk))
+	for i := range rk {
+		rk[i] = c.rk[i][:]
+	}
+	traceVistrutah(t, s, c.fk[:], rk, func() { mixingLayer256(s[0], s[1]) })
+}
+
+// TraceEncrypt is Encrypt, reporting every step to t, as for Vistrutah-256.
+func (c *Vistrutah512Cipher) TraceEncrypt(t Tracer, dst, src *Vistrutah512Block) {
+	*dst = *src
+	s := make([]*Block, 4)
+	for i := range s {
+		s[i] = (*Block)(dst[16*i : 16*i+16])
+	}
+	rk := make([][]Block, len(c.rk))
+	for i := range rk {
+		rk[i] = c.rk[i][:]
+	}
+	traceVistrutah(t, s, c.fk[:], rk, func() { mixingLayer512(s[0], s[1], s[2], s[3]) })
+}
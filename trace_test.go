@@ -0,0 +1,357 @@
+package aes
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// FIPS-197, Appendix C.1, cipher.
+const fips197C1 = `round[ 0].input     00112233445566778899aabbccddeeff
+round[ 0].k_sch     000102030405060708090a0b0c0d0e0f
+round[ 1].start     00102030405060708090a0b0c0d0e0f0
+round[ 1].s_box     63cab7040953d051cd60e0e7ba70e18c
+round[ 1].s_row     6353e08c0960e104cd70b751bacad0e7
+round[ 1].m_col     5f72641557f5bc92f7be3b291db9f91a
+round[ 1].k_sch     d6aa74fdd2af72fadaa678f1d6ab76fe
+round[ 2].start     89d810e8855ace682d1843d8cb128fe4
+round[ 2].s_box     a761ca9b97be8b45d8ad1a611fc97369
+round[ 2].s_row     a7be1a6997ad739bd8c9ca451f618b61
+round[ 2].m_col     ff87968431d86a51645151fa773ad009
+round[ 2].k_sch     b692cf0b643dbdf1be9bc5006830b3fe
+round[ 3].start     4915598f55e5d7a0daca94fa1f0a63f7
+round[ 3].s_box     3b59cb73fcd90ee05774222dc067fb68
+round[ 3].s_row     3bd92268fc74fb735767cbe0c0590e2d
+round[ 3].m_col     4c9c1e66f771f0762c3f868e534df256
+round[ 3].k_sch     b6ff744ed2c2c9bf6c590cbf0469bf41
+round[ 4].start     fa636a2825b339c940668a3157244d17
+round[ 4].s_box     2dfb02343f6d12dd09337ec75b36e3f0
+round[ 4].s_row     2d6d7ef03f33e334093602dd5bfb12c7
+round[ 4].m_col     6385b79ffc538df997be478e7547d691
+round[ 4].k_sch     47f7f7bc95353e03f96c32bcfd058dfd
+round[ 5].start     247240236966b3fa6ed2753288425b6c
+round[ 5].s_box     36400926f9336d2d9fb59d23c42c3950
+round[ 5].s_row     36339d50f9b539269f2c092dc4406d23
+round[ 5].m_col     f4bcd45432e554d075f1d6c51dd03b3c
+round[ 5].k_sch     3caaa3e8a99f9deb50f3af57adf622aa
+round[ 6].start     c81677bc9b7ac93b25027992b0261996
+round[ 6].s_box     e847f56514dadde23f77b64fe7f7d490
+round[ 6].s_row     e8dab6901477d4653ff7f5e2e747dd4f
+round[ 6].m_col     9816ee7400f87f556b2c049c8e5ad036
+round[ 6].k_sch     5e390f7df7a69296a7553dc10aa31f6b
+round[ 7].start     c62fe109f75eedc3cc79395d84f9cf5d
+round[ 7].s_box     b415f8016858552e4bb6124c5f998a4c
+round[ 7].s_row     b458124c68b68a014b99f82e5f15554c
+round[ 7].m_col     c57e1c159a9bd286f05f4be098c63439
+round[ 7].k_sch     14f9701ae35fe28c440adf4d4ea9c026
+round[ 8].start     d1876c0f79c4300ab45594add66ff41f
+round[ 8].s_box     3e175076b61c04678dfc2295f6a8bfc0
+round[ 8].s_row     3e1c22c0b6fcbf768da85067f6170495
+round[ 8].m_col     baa03de7a1f9b56ed5512cba5f414d23
+round[ 8].k_sch     47438735a41c65b9e016baf4aebf7ad2
+round[ 9].start     fde3bad205e5d0d73547964ef1fe37f1
+round[ 9].s_box     5411f4b56bd9700e96a0902fa1bb9aa1
+round[ 9].s_row     54d990a16ba09ab596bbf40ea111702f
+round[ 9].m_col     e9f74eec023020f61bf2ccf2353c21c7
+round[ 9].k_sch     549932d1f08557681093ed9cbe2c974e
+round[10].start     bd6e7c3df2b5779e0b61216e8b10b689
+round[10].s_box     7a9f102789d5f50b2beffd9f3dca4ea7
+round[10].s_row     7ad5fda789ef4e272bca100b3d9ff59f
+round[10].k_sch     13111d7fe3944a17f307a78b4d2b30c5
+round[10].output    69c4e0d86a7b0430d8cdb78070b4c55a
+`
+
+// FIPS-197, Appendix C.1, inverse cipher.
+const fips197C1Inverse = `round[ 0].iinput    69c4e0d86a7b0430d8cdb78070b4c55a
+round[ 0].ik_sch    13111d7fe3944a17f307a78b4d2b30c5
+round[ 1].istart    7ad5fda789ef4e272bca100b3d9ff59f
+round[ 1].is_row    7a9f102789d5f50b2beffd9f3dca4ea7
+round[ 1].is_box    bd6e7c3df2b5779e0b61216e8b10b689
+round[ 1].ik_sch    549932d1f08557681093ed9cbe2c974e
+round[ 1].ik_add    e9f74eec023020f61bf2ccf2353c21c7
+round[ 2].istart    54d990a16ba09ab596bbf40ea111702f
+round[ 2].is_row    5411f4b56bd9700e96a0902fa1bb9aa1
+round[ 2].is_box    fde3bad205e5d0d73547964ef1fe37f1
+round[ 2].ik_sch    47438735a41c65b9e016baf4aebf7ad2
+round[ 2].ik_add    baa03de7a1f9b56ed5512cba5f414d23
+round[ 3].istart    3e1c22c0b6fcbf768da85067f6170495
+round[ 3].is_row    3e175076b61c04678dfc2295f6a8bfc0
+round[ 3].is_box    d1876c0f79c4300ab45594add66ff41f
+round[ 3].ik_sch    14f9701ae35fe28c440adf4d4ea9c026
+round[ 3].ik_add    c57e1c159a9bd286f05f4be098c63439
+round[ 4].istart    b458124c68b68a014b99f82e5f15554c
+round[ 4].is_row    b415f8016858552e4bb6124c5f998a4c
+round[ 4].is_box    c62fe109f75eedc3cc79395d84f9cf5d
+round[ 4].ik_sch    5e390f7df7a69296a7553dc10aa31f6b
+round[ 4].ik_add    9816ee7400f87f556b2c049c8e5ad036
+round[ 5].istart    e8dab6901477d4653ff7f5e2e747dd4f
+round[ 5].is_row    e847f56514dadde23f77b64fe7f7d490
+round[ 5].is_box    c81677bc9b7ac93b25027992b0261996
+round[ 5].ik_sch    3caaa3e8a99f9deb50f3af57adf622aa
+round[ 5].ik_add    f4bcd45432e554d075f1d6c51dd03b3c
+round[ 6].istart    36339d50f9b539269f2c092dc4406d23
+round[ 6].is_row    36400926f9336d2d9fb59d23c42c3950
+round[ 6].is_box    247240236966b3fa6ed2753288425b6c
+round[ 6].ik_sch    47f7f7bc95353e03f96c32bcfd058dfd
+round[ 6].ik_add    6385b79ffc538df997be478e7547d691
+round[ 7].istart    2d6d7ef03f33e334093602dd5bfb12c7
+round[ 7].is_row    2dfb02343f6d12dd09337ec75b36e3f0
+round[ 7].is_box    fa636a2825b339c940668a3157244d17
+round[ 7].ik_sch    b6ff744ed2c2c9bf6c590cbf0469bf41
+round[ 7].ik_add    4c9c1e66f771f0762c3f868e534df256
+round[ 8].istart    3bd92268fc74fb735767cbe0c0590e2d
+round[ 8].is_row    3b59cb73fcd90ee05774222dc067fb68
+round[ 8].is_box    4915598f55e5d7a0daca94fa1f0a63f7
+round[ 8].ik_sch    b692cf0b643dbdf1be9bc5006830b3fe
+round[ 8].ik_add    ff87968431d86a51645151fa773ad009
+round[ 9].istart    a7be1a6997ad739bd8c9ca451f618b61
+round[ 9].is_row    a761ca9b97be8b45d8ad1a611fc97369
+round[ 9].is_box    89d810e8855ace682d1843d8cb128fe4
+round[ 9].ik_sch    d6aa74fdd2af72fadaa678f1d6ab76fe
+round[ 9].ik_add    5f72641557f5bc92f7be3b291db9f91a
+round[10].istart    6353e08c0960e104cd70b751bacad0e7
+round[10].is_row    63cab7040953d051cd60e0e7ba70e18c
+round[10].is_box    00102030405060708090a0b0c0d0e0f0
+round[10].ik_sch    000102030405060708090a0b0c0d0e0f
+round[10].ioutput   00112233445566778899aabbccddeeff
+`
+
+func TestTraceAESFIPS197(t *testing.T) {
+	ks, _ := NewKeySchedule(mustHex(t, "000102030405060708090a0b0c0d0e0f"))
+	pt := mustHex(t, "00112233445566778899aabbccddeeff")
+	var b Block
+	copy(b[:], pt)
+
+	var log TraceLog
+	TraceEncryptBlockAES(&log, &b, ks)
+	var out strings.Builder
+	if _, err := log.WriteTo(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != fips197C1 {
+		t.Fatalf("trace:\n%s\nwant:\n%s", out.String(), fips197C1)
+	}
+
+	log = TraceLog{}
+	TraceDecryptBlockAES(&log, &b, ks)
+	if !bytes.Equal(b[:], pt) {
+		t.Fatalf("TraceDecryptBlockAES = %x, want %x", b, pt)
+	}
+	out.Reset()
+	if _, err := log.WriteTo(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != fips197C1Inverse {
+		t.Fatalf("inverse trace:\n%s\nwant:\n%s", out.String(), fips197C1Inverse)
+	}
+}
+
+// FIPS-197, Appendix C.2 and C.3.
+func TestTraceAESKeySizes(t *testing.T) {
+	pt := mustHex(t, "00112233445566778899aabbccddeeff")
+	for _, v := range []struct{ key, ct string }{
+		{"000102030405060708090a0b0c0d0e0f1011121314151617", "dda97ca4864cdfe06eaf70a0ec0d7191"},
+		{"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", "8ea2b7ca516745bfeafc49904b496089"},
+	} {
+		ks, _ := NewKeySchedule(mustHex(t, v.key))
+		var b Block
+		copy(b[:], pt)
+		var log TraceLog
+		TraceEncryptBlockAES(&log, &b, ks)
+		last := log.Events[len(log.Events)-1]
+		if last.Round != ks.Rounds() || last.Step != TraceStepOutput || !bytes.Equal(last.State, mustHex(t, v.ct)) {
+			t.Fatalf("last event %+v, want output %s in round %d", last, v.ct, ks.Rounds())
+		}
+		TraceDecryptBlockAES(&log, &b, ks)
+		if !bytes.Equal(b[:], pt) {
+			t.Fatalf("TraceDecryptBlockAES = %x, want %x", b, pt)
+		}
+	}
+}
+
+func TestTraceRounds(t *testing.T) {
+	var b, k Block
+	for i := range b {
+		b[i] = byte(i * 13)
+		k[i] = byte(i*7 + 1)
+	}
+	for _, f := range []struct {
+		name   string
+		traced func(Tracer, int, int, *Block, *Block)
+		plain  func(*Block, *Block)
+		steps  int
+	}{
+		{"Round", TraceRound, Round, 5},
+		{"FinalRound", TraceFinalRound, FinalRound, 4},
+		{"RoundKeyFirst", TraceRoundKeyFirst, RoundKeyFirst, 5},
+		{"AddRoundKey", TraceAddRoundKey, AddRoundKey, 2},
+		{"RoundNoKey", func(t Tracer, r, l int, b, _ *Block) { TraceRoundNoKey(t, r, l, b) }, func(b, _ *Block) { RoundNoKey(b) }, 3},
+		{"FinalRoundNoKey", func(t Tracer, r, l int, b, _ *Block) { TraceFinalRoundNoKey(t, r, l, b) }, func(b, _ *Block) { FinalRoundNoKey(b) }, 2},
+	} {
+		got, want := b, b
+		var log TraceLog
+		f.traced(&log, 3, 1, &got, &k)
+		f.plain(&want, &k)
+		if got != want {
+			t.Errorf("Trace%s = %x, want %x", f.name, got, want)
+		}
+		if len(log.Events) != f.steps {
+			t.Errorf("Trace%s reported %d steps, want %d", f.name, len(log.Events), f.steps)
+		}
+		last := log.Events[len(log.Events)-1]
+		if last.Round != 3 || last.Lane != 1 || !bytes.Equal(last.State, got[:]) {
+			t.Errorf("Trace%s: last event %+v, want the result in round 3, lane 1", f.name, last)
+		}
+	}
+}
+
+// The traced functions compute the same results as the optimized ones.
+func TestTraceMatchesImplementations(t *testing.T) {
+	var key32 [32]byte
+	var in64 [64]byte
+	for i := range in64 {
+		in64[i] = byte(i*11 + 5)
+	}
+	for i := range key32 {
+		key32[i] = byte(i*3 + 1)
+	}
+	var log TraceLog
+
+	t.Run("KIASU", func(t *testing.T) {
+		ctx, _ := NewKiasuContext([16]byte(key32[:16]))
+		tweak := [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+		in := [16]byte(in64[:16])
+		ct := ctx.TraceEncrypt(&log, in, tweak)
+		if ct != ctx.KiasuEncrypt(in, tweak) {
+			t.Fatal("TraceEncrypt differs from KiasuEncrypt")
+		}
+		if ctx.TraceDecrypt(&log, ct, tweak) != in {
+			t.Fatal("TraceDecrypt does not invert TraceEncrypt")
+		}
+	})
+
+	t.Run("Deoxys-BC-256", func(t *testing.T) {
+		tk := Tweakey256(key32)
+		rk := NewDeoxysBC256(&tk)
+		in := Block(in64[:16])
+		ct := TraceDeoxysBC256Encrypt(&log, rk, &in)
+		if ct != DeoxysBC256Encrypt(rk, &in) {
+			t.Fatal("TraceDeoxysBC256Encrypt differs from DeoxysBC256Encrypt")
+		}
+		if TraceDeoxysBC256Decrypt(&log, rk, &ct) != in {
+			t.Fatal("TraceDeoxysBC256Decrypt does not invert TraceDeoxysBC256Encrypt")
+		}
+	})
+
+	t.Run("Areion", func(t *testing.T) {
+		a, b := Areion256(in64[:32]), Areion256(in64[:32])
+		a.TracePermute(&log)
+		b.Permute()
+		if a != b {
+			t.Fatal("Areion256 TracePermute differs from Permute")
+		}
+		c, d := Areion512(in64), Areion512(in64)
+		c.TracePermute(&log)
+		d.Permute()
+		if c != d {
+			t.Fatal("Areion512 TracePermute differs from Permute")
+		}
+	})
+
+	t.Run("Haraka", func(t *testing.T) {
+		in := [32]byte(in64[:32])
+		if TraceHaraka256(&log, &in) != Haraka256(&in) {
+			t.Fatal("TraceHaraka256 differs from Haraka256")
+		}
+		if TraceHaraka512(&log, &in64) != Haraka512(&in64) {
+			t.Fatal("TraceHaraka512 differs from Haraka512")
+		}
+	})
+
+	t.Run("Pholkos", func(t *testing.T) {
+		key := Pholkos256Key(key32)
+		tweak := PholkosTweak(in64[48:])
+		a, b := Pholkos256Block(in64[:32]), Pholkos256Block(in64[:32])
+		ctx := NewPholkos256Context(&key, &tweak)
+		ctx.TraceEncrypt(&log, &a)
+		ctx.Encrypt(&b)
+		if a != b {
+			t.Fatal("Pholkos-256 TraceEncrypt differs from Encrypt")
+		}
+		c, d := Pholkos512Block(in64), Pholkos512Block(in64)
+		ctx512 := NewPholkos512Context(&key, &tweak)
+		ctx512.TraceEncrypt(&log, &c)
+		ctx512.Encrypt(&d)
+		if c != d {
+			t.Fatal("Pholkos-512 TraceEncrypt differs from Encrypt")
+		}
+	})
+
+	t.Run("Vistrutah", func(t *testing.T) {
+		for _, rounds := range []int{Vistrutah256RoundsShort, Vistrutah256RoundsLong} {
+			c, _ := NewVistrutah256Cipher(key32[:], rounds)
+			src := Vistrutah256Block(in64[:32])
+			var a, b Vistrutah256Block
+			c.TraceEncrypt(&log, &a, &src)
+			c.Encrypt(&b, &src)
+			if a != b {
+				t.Fatalf("Vistrutah-256 with %d rounds: TraceEncrypt differs from Encrypt", rounds)
+			}
+		}
+		for _, rounds := range []int{Vistrutah512RoundsShort256Key, Vistrutah512RoundsLong256Key} {
+			c, _ := NewVistrutah512Cipher(key32[:], rounds)
+			src := Vistrutah512Block(in64)
+			var a, b Vistrutah512Block
+			c.TraceEncrypt(&log, &a, &src)
+			c.Encrypt(&b, &src)
+			if a != b {
+				t.Fatalf("Vistrutah-512 with %d rounds: TraceEncrypt differs from Encrypt", rounds)
+			}
+		}
+	})
+}
+
+func TestTraceLogFormats(t *testing.T) {
+	var log TraceLog
+	in := [32]byte{}
+	TraceHaraka256(&log, &in)
+
+	var out strings.Builder
+	log.WriteTo(&out)
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != len(log.Events) {
+		t.Fatalf("%d lines for %d events", len(lines), len(log.Events))
+	}
+	for i, want := range []string{"round[ 0].input ", "round[ 1].x0.s_box ", "round[ 1].x0.s_row "} {
+		if !strings.HasPrefix(lines[i], want) {
+			t.Errorf("line %d = %q, want prefix %q", i, lines[i], want)
+		}
+	}
+	if !strings.Contains(out.String(), "round[ 2].mix ") {
+		t.Error("no mix step in round 2")
+	}
+
+	data, err := json.Marshal(&log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Events []struct {
+			Round int    `json:"round"`
+			Step  string `json:"step"`
+			Lane  int    `json:"lane"`
+			State string `json:"state"`
+		} `json:"events"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Events) != len(log.Events) {
+		t.Fatalf("JSON has %d events, want %d", len(decoded.Events), len(log.Events))
+	}
+	e := decoded.Events[1]
+	if e.Round != 1 || e.Step != TraceStepSubBytes || e.Lane != 0 || e.State != strings.Repeat("63", 16) {
+		t.Errorf("second JSON event = %+v", e)
+	}
+}
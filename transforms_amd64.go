@@ -0,0 +1,218 @@
+//go:build amd64 && !purego
+
+package aes
+
+// Individual AES transformations using AES-NI instructions. The AES
+// instructions only implement whole rounds, so the transformations are
+// obtained by undoing the unwanted steps with byte shuffles or with the
+// inverse instructions.
+
+// aesniSubBytes applies SubBytes to a block using AESENCLAST
+//
+//go:noescape
+func aesniSubBytes(block *Block)
+
+// aesniSubBytes2 applies SubBytes to 2 blocks using AESENCLAST
+//
+//go:noescape
+func aesniSubBytes2(blocks *Block2)
+
+// aesniSubBytes4 applies SubBytes to 4 blocks using AESENCLAST
+//
+//go:noescape
+func aesniSubBytes4(blocks *Block4)
+
+// aesniInvSubBytes applies InvSubBytes to a block using AESDECLAST
+//
+//go:noescape
+func aesniInvSubBytes(block *Block)
+
+// aesniInvSubBytes2 applies InvSubBytes to 2 blocks using AESDECLAST
+//
+//go:noescape
+func aesniInvSubBytes2(blocks *Block2)
+
+// aesniInvSubBytes4 applies InvSubBytes to 4 blocks using AESDECLAST
+//
+//go:noescape
+func aesniInvSubBytes4(blocks *Block4)
+
+// aesniShiftRows applies ShiftRows to a block using PSHUFB
+//
+//go:noescape
+func aesniShiftRows(block *Block)
+
+// aesniShiftRows2 applies ShiftRows to 2 blocks using PSHUFB
+//
+//go:noescape
+func aesniShiftRows2(blocks *Block2)
+
+// aesniShiftRows4 applies ShiftRows to 4 blocks using PSHUFB
+//
+//go:noescape
+func aesniShiftRows4(blocks *Block4)
+
+// aesniInvShiftRows applies InvShiftRows to a block using PSHUFB
+//
+//go:noescape
+func aesniInvShiftRows(block *Block)
+
+// aesniInvShiftRows2 applies InvShiftRows to 2 blocks using PSHUFB
+//
+//go:noescape
+func aesniInvShiftRows2(blocks *Block2)
+
+// aesniInvShiftRows4 applies InvShiftRows to 4 blocks using PSHUFB
+//
+//go:noescape
+func aesniInvShiftRows4(blocks *Block4)
+
+// aesniMixColumns applies MixColumns to a block using AESDECLAST and AESENC
+//
+//go:noescape
+func aesniMixColumns(block *Block)
+
+// aesniMixColumns2 applies MixColumns to 2 blocks using AESDECLAST and AESENC
+//
+//go:noescape
+func aesniMixColumns2(blocks *Block2)
+
+// aesniMixColumns4 applies MixColumns to 4 blocks using AESDECLAST and AESENC
+//
+//go:noescape
+func aesniMixColumns4(blocks *Block4)
+
+// SubBytesHW applies the AES S-box to each byte with hardware acceleration if available
+func SubBytesHW(block *Block) {
+	if CPU.HasAESNI {
+		aesniSubBytes(block)
+	} else {
+		SubBytes(block)
+	}
+}
+
+// SubBytes2HW applies the AES S-box to 2 blocks with hardware acceleration if available
+func SubBytes2HW(blocks *Block2) {
+	if CPU.HasAESNI {
+		aesniSubBytes2(blocks)
+	} else {
+		SubBytes2(blocks)
+	}
+}
+
+// SubBytes4HW applies the AES S-box to 4 blocks with hardware acceleration if available
+func SubBytes4HW(blocks *Block4) {
+	if CPU.HasAESNI {
+		aesniSubBytes4(blocks)
+	} else {
+		SubBytes4(blocks)
+	}
+}
+
+// InvSubBytesHW applies the inverse AES S-box to each byte with hardware acceleration if available
+func InvSubBytesHW(block *Block) {
+	if CPU.HasAESNI {
+		aesniInvSubBytes(block)
+	} else {
+		InvSubBytes(block)
+	}
+}
+
+// InvSubBytes2HW applies the inverse AES S-box to 2 blocks with hardware acceleration if available
+func InvSubBytes2HW(blocks *Block2) {
+	if CPU.HasAESNI {
+		aesniInvSubBytes2(blocks)
+	} else {
+		InvSubBytes2(blocks)
+	}
+}
+
+// InvSubBytes4HW applies the inverse AES S-box to 4 blocks with hardware acceleration if available
+func InvSubBytes4HW(blocks *Block4) {
+	if CPU.HasAESNI {
+		aesniInvSubBytes4(blocks)
+	} else {
+		InvSubBytes4(blocks)
+	}
+}
+
+// ShiftRowsHW applies ShiftRows to a block with hardware acceleration if available
+func ShiftRowsHW(block *Block) {
+	if CPU.HasAESNI {
+		aesniShiftRows(block)
+	} else {
+		ShiftRows(block)
+	}
+}
+
+// ShiftRows2HW applies ShiftRows to 2 blocks with hardware acceleration if available
+func ShiftRows2HW(blocks *Block2) {
+	if CPU.HasAESNI {
+		aesniShiftRows2(blocks)
+	} else {
+		ShiftRows2(blocks)
+	}
+}
+
+// ShiftRows4HW applies ShiftRows to 4 blocks with hardware acceleration if available
+func ShiftRows4HW(blocks *Block4) {
+	if CPU.HasAESNI {
+		aesniShiftRows4(blocks)
+	} else {
+		ShiftRows4(blocks)
+	}
+}
+
+// InvShiftRowsHW applies InvShiftRows to a block with hardware acceleration if available
+func InvShiftRowsHW(block *Block) {
+	if CPU.HasAESNI {
+		aesniInvShiftRows(block)
+	} else {
+		InvShiftRows(block)
+	}
+}
+
+// InvShiftRows2HW applies InvShiftRows to 2 blocks with hardware acceleration if available
+func InvShiftRows2HW(blocks *Block2) {
+	if CPU.HasAESNI {
+		aesniInvShiftRows2(blocks)
+	} else {
+		InvShiftRows2(blocks)
+	}
+}
+
+// InvShiftRows4HW applies InvShiftRows to 4 blocks with hardware acceleration if available
+func InvShiftRows4HW(blocks *Block4) {
+	if CPU.HasAESNI {
+		aesniInvShiftRows4(blocks)
+	} else {
+		InvShiftRows4(blocks)
+	}
+}
+
+// MixColumnsHW applies MixColumns to a block with hardware acceleration if available
+func MixColumnsHW(block *Block) {
+	if CPU.HasAESNI {
+		aesniMixColumns(block)
+	} else {
+		MixColumns(block)
+	}
+}
+
+// MixColumns2HW applies MixColumns to 2 blocks with hardware acceleration if available
+func MixColumns2HW(blocks *Block2) {
+	if CPU.HasAESNI {
+		aesniMixColumns2(blocks)
+	} else {
+		MixColumns2(blocks)
+	}
+}
+
+// MixColumns4HW applies MixColumns to 4 blocks with hardware acceleration if available
+func MixColumns4HW(blocks *Block4) {
+	if CPU.HasAESNI {
+		aesniMixColumns4(blocks)
+	} else {
+		MixColumns4(blocks)
+	}
+}
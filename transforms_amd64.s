@@ -0,0 +1,285 @@
+// Individual AES transformations with AES-NI for AMD64
+#include "textflag.h"
+
+// ShiftRows as a PSHUFB mask: {0, 5, 10, 15, 4, 9, 14, 3, 8, 13, 2, 7, 12, 1, 6, 11}
+DATA shiftRowsMask<>+0x00(SB)/8, $0x030e09040f0a0500
+DATA shiftRowsMask<>+0x08(SB)/8, $0x0b06010c07020d08
+GLOBL shiftRowsMask<>(SB), RODATA|NOPTR, $16
+
+// InvShiftRows as a PSHUFB mask: {0, 13, 10, 7, 4, 1, 14, 11, 8, 5, 2, 15, 12, 9, 6, 3}
+DATA invShiftRowsMask<>+0x00(SB)/8, $0x0b0e0104070a0d00
+DATA invShiftRowsMask<>+0x08(SB)/8, $0x0306090c0f020508
+GLOBL invShiftRowsMask<>(SB), RODATA|NOPTR, $16
+
+// func aesniSubBytes(block *Block)
+TEXT ·aesniSubBytes(SB),NOSPLIT,$0
+	MOVQ block+0(FP), AX
+
+	MOVOU (AX), X0
+	MOVOU invShiftRowsMask<>(SB), X4
+	PXOR X5, X5
+
+	// Undo the ShiftRows of AESENCLAST, with a zero round key
+	PSHUFB X4, X0
+	AESENCLAST X5, X0
+
+	MOVOU X0, (AX)
+	RET
+
+// func aesniSubBytes2(blocks *Block2)
+TEXT ·aesniSubBytes2(SB),NOSPLIT,$0
+	MOVQ blocks+0(FP), AX
+
+	MOVOU (AX), X0
+	MOVOU 16(AX), X1
+	MOVOU invShiftRowsMask<>(SB), X4
+	PXOR X5, X5
+
+	PSHUFB X4, X0
+	PSHUFB X4, X1
+	AESENCLAST X5, X0
+	AESENCLAST X5, X1
+
+	MOVOU X0, (AX)
+	MOVOU X1, 16(AX)
+	RET
+
+// func aesniSubBytes4(blocks *Block4)
+TEXT ·aesniSubBytes4(SB),NOSPLIT,$0
+	MOVQ blocks+0(FP), AX
+
+	MOVOU (AX), X0
+	MOVOU 16(AX), X1
+	MOVOU 32(AX), X2
+	MOVOU 48(AX), X3
+	MOVOU invShiftRowsMask<>(SB), X4
+	PXOR X5, X5
+
+	PSHUFB X4, X0
+	PSHUFB X4, X1
+	PSHUFB X4, X2
+	PSHUFB X4, X3
+	AESENCLAST X5, X0
+	AESENCLAST X5, X1
+	AESENCLAST X5, X2
+	AESENCLAST X5, X3
+
+	MOVOU X0, (AX)
+	MOVOU X1, 16(AX)
+	MOVOU X2, 32(AX)
+	MOVOU X3, 48(AX)
+	RET
+
+// func aesniInvSubBytes(block *Block)
+TEXT ·aesniInvSubBytes(SB),NOSPLIT,$0
+	MOVQ block+0(FP), AX
+
+	MOVOU (AX), X0
+	MOVOU shiftRowsMask<>(SB), X4
+	PXOR X5, X5
+
+	// Undo the InvShiftRows of AESDECLAST, with a zero round key
+	PSHUFB X4, X0
+	AESDECLAST X5, X0
+
+	MOVOU X0, (AX)
+	RET
+
+// func aesniInvSubBytes2(blocks *Block2)
+TEXT ·aesniInvSubBytes2(SB),NOSPLIT,$0
+	MOVQ blocks+0(FP), AX
+
+	MOVOU (AX), X0
+	MOVOU 16(AX), X1
+	MOVOU shiftRowsMask<>(SB), X4
+	PXOR X5, X5
+
+	PSHUFB X4, X0
+	PSHUFB X4, X1
+	AESDECLAST X5, X0
+	AESDECLAST X5, X1
+
+	MOVOU X0, (AX)
+	MOVOU X1, 16(AX)
+	RET
+
+// func aesniInvSubBytes4(blocks *Block4)
+TEXT ·aesniInvSubBytes4(SB),NOSPLIT,$0
+	MOVQ blocks+0(FP), AX
+
+	MOVOU (AX), X0
+	MOVOU 16(AX), X1
+	MOVOU 32(AX), X2
+	MOVOU 48(AX), X3
+	MOVOU shiftRowsMask<>(SB), X4
+	PXOR X5, X5
+
+	PSHUFB X4, X0
+	PSHUFB X4, X1
+	PSHUFB X4, X2
+	PSHUFB X4, X3
+	AESDECLAST X5, X0
+	AESDECLAST X5, X1
+	AESDECLAST X5, X2
+	AESDECLAST X5, X3
+
+	MOVOU X0, (AX)
+	MOVOU X1, 16(AX)
+	MOVOU X2, 32(AX)
+	MOVOU X3, 48(AX)
+	RET
+
+// func aesniShiftRows(block *Block)
+TEXT ·aesniShiftRows(SB),NOSPLIT,$0
+	MOVQ block+0(FP), AX
+
+	MOVOU (AX), X0
+	MOVOU shiftRowsMask<>(SB), X4
+
+	// ShiftRows is a byte permutation
+	PSHUFB X4, X0
+
+	MOVOU X0, (AX)
+	RET
+
+// func aesniShiftRows2(blocks *Block2)
+TEXT ·aesniShiftRows2(SB),NOSPLIT,$0
+	MOVQ blocks+0(FP), AX
+
+	MOVOU (AX), X0
+	MOVOU 16(AX), X1
+	MOVOU shiftRowsMask<>(SB), X4
+
+	PSHUFB X4, X0
+	PSHUFB X4, X1
+
+	MOVOU X0, (AX)
+	MOVOU X1, 16(AX)
+	RET
+
+// func aesniShiftRows4(blocks *Block4)
+TEXT ·aesniShiftRows4(SB),NOSPLIT,$0
+	MOVQ blocks+0(FP), AX
+
+	MOVOU (AX), X0
+	MOVOU 16(AX), X1
+	MOVOU 32(AX), X2
+	MOVOU 48(AX), X3
+	MOVOU shiftRowsMask<>(SB), X4
+
+	PSHUFB X4, X0
+	PSHUFB X4, X1
+	PSHUFB X4, X2
+	PSHUFB X4, X3
+
+	MOVOU X0, (AX)
+	MOVOU X1, 16(AX)
+	MOVOU X2, 32(AX)
+	MOVOU X3, 48(AX)
+	RET
+
+// func aesniInvShiftRows(block *Block)
+TEXT ·aesniInvShiftRows(SB),NOSPLIT,$0
+	MOVQ block+0(FP), AX
+
+	MOVOU (AX), X0
+	MOVOU invShiftRowsMask<>(SB), X4
+
+	// InvShiftRows is a byte permutation
+	PSHUFB X4, X0
+
+	MOVOU X0, (AX)
+	RET
+
+// func aesniInvShiftRows2(blocks *Block2)
+TEXT ·aesniInvShiftRows2(SB),NOSPLIT,$0
+	MOVQ blocks+0(FP), AX
+
+	MOVOU (AX), X0
+	MOVOU 16(AX), X1
+	MOVOU invShiftRowsMask<>(SB), X4
+
+	PSHUFB X4, X0
+	PSHUFB X4, X1
+
+	MOVOU X0, (AX)
+	MOVOU X1, 16(AX)
+	RET
+
+// func aesniInvShiftRows4(blocks *Block4)
+TEXT ·aesniInvShiftRows4(SB),NOSPLIT,$0
+	MOVQ blocks+0(FP), AX
+
+	MOVOU (AX), X0
+	MOVOU 16(AX), X1
+	MOVOU 32(AX), X2
+	MOVOU 48(AX), X3
+	MOVOU invShiftRowsMask<>(SB), X4
+
+	PSHUFB X4, X0
+	PSHUFB X4, X1
+	PSHUFB X4, X2
+	PSHUFB X4, X3
+
+	MOVOU X0, (AX)
+	MOVOU X1, 16(AX)
+	MOVOU X2, 32(AX)
+	MOVOU X3, 48(AX)
+	RET
+
+// func aesniMixColumns(block *Block)
+TEXT ·aesniMixColumns(SB),NOSPLIT,$0
+	MOVQ block+0(FP), AX
+
+	MOVOU (AX), X0
+	PXOR X5, X5
+
+	// AESDECLAST with a zero key undoes the ShiftRows and SubBytes of AESENC,
+	// leaving only MixColumns
+	AESDECLAST X5, X0
+	AESENC X5, X0
+
+	MOVOU X0, (AX)
+	RET
+
+// func aesniMixColumns2(blocks *Block2)
+TEXT ·aesniMixColumns2(SB),NOSPLIT,$0
+	MOVQ blocks+0(FP), AX
+
+	MOVOU (AX), X0
+	MOVOU 16(AX), X1
+	PXOR X5, X5
+
+	AESDECLAST X5, X0
+	AESDECLAST X5, X1
+	AESENC X5, X0
+	AESENC X5, X1
+
+	MOVOU X0, (AX)
+	MOVOU X1, 16(AX)
+	RET
+
+// func aesniMixColumns4(blocks *Block4)
+TEXT ·aesniMixColumns4(SB),NOSPLIT,$0
+	MOVQ blocks+0(FP), AX
+
+	MOVOU (AX), X0
+	MOVOU 16(AX), X1
+	MOVOU 32(AX), X2
+	MOVOU 48(AX), X3
+	PXOR X5, X5
+
+	AESDECLAST X5, X0
+	AESDECLAST X5, X1
+	AESDECLAST X5, X2
+	AESDECLAST X5, X3
+	AESENC X5, X0
+	AESENC X5, X1
+	AESENC X5, X2
+	AESENC X5, X3
+
+	MOVOU X0, (AX)
+	MOVOU X1, 16(AX)
+	MOVOU X2, 32(AX)
+	MOVOU X3, 48(AX)
+	RET
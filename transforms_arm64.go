@@ -0,0 +1,217 @@
+//go:build arm64 && !purego
+
+package aes
+
+// Individual AES transformations using ARM Crypto extensions. The AES
+// instructions only implement parts of rounds, so the transformations are
+// obtained by undoing the unwanted steps with byte permutations.
+
+// armSubBytes applies SubBytes to a block using AESE
+//
+//go:noescape
+func armSubBytes(block *Block)
+
+// armSubBytes2 applies SubBytes to 2 blocks using AESE
+//
+//go:noescape
+func armSubBytes2(blocks *Block2)
+
+// armSubBytes4 applies SubBytes to 4 blocks using AESE
+//
+//go:noescape
+func armSubBytes4(blocks *Block4)
+
+// armInvSubBytes applies InvSubBytes to a block using AESD
+//
+//go:noescape
+func armInvSubBytes(block *Block)
+
+// armInvSubBytes2 applies InvSubBytes to 2 blocks using AESD
+//
+//go:noescape
+func armInvSubBytes2(blocks *Block2)
+
+// armInvSubBytes4 applies InvSubBytes to 4 blocks using AESD
+//
+//go:noescape
+func armInvSubBytes4(blocks *Block4)
+
+// armShiftRows applies ShiftRows to a block using TBL
+//
+//go:noescape
+func armShiftRows(block *Block)
+
+// armShiftRows2 applies ShiftRows to 2 blocks using TBL
+//
+//go:noescape
+func armShiftRows2(blocks *Block2)
+
+// armShiftRows4 applies ShiftRows to 4 blocks using TBL
+//
+//go:noescape
+func armShiftRows4(blocks *Block4)
+
+// armInvShiftRows applies InvShiftRows to a block using TBL
+//
+//go:noescape
+func armInvShiftRows(block *Block)
+
+// armInvShiftRows2 applies InvShiftRows to 2 blocks using TBL
+//
+//go:noescape
+func armInvShiftRows2(blocks *Block2)
+
+// armInvShiftRows4 applies InvShiftRows to 4 blocks using TBL
+//
+//go:noescape
+func armInvShiftRows4(blocks *Block4)
+
+// armMixColumns applies MixColumns to a block using AESMC
+//
+//go:noescape
+func armMixColumns(block *Block)
+
+// armMixColumns2 applies MixColumns to 2 blocks using AESMC
+//
+//go:noescape
+func armMixColumns2(blocks *Block2)
+
+// armMixColumns4 applies MixColumns to 4 blocks using AESMC
+//
+//go:noescape
+func armMixColumns4(blocks *Block4)
+
+// SubBytesHW applies the AES S-box to each byte with hardware acceleration if available
+func SubBytesHW(block *Block) {
+	if CPU.HasARMCrypto {
+		armSubBytes(block)
+	} else {
+		SubBytes(block)
+	}
+}
+
+// SubBytes2HW applies the AES S-box to 2 blocks with hardware acceleration if available
+func SubBytes2HW(blocks *Block2) {
+	if CPU.HasARMCrypto {
+		armSubBytes2(blocks)
+	} else {
+		SubBytes2(blocks)
+	}
+}
+
+// SubBytes4HW applies the AES S-box to 4 blocks with hardware acceleration if available
+func SubBytes4HW(blocks *Block4) {
+	if CPU.HasARMCrypto {
+		armSubBytes4(blocks)
+	} else {
+		SubBytes4(blocks)
+	}
+}
+
+// InvSubBytesHW applies the inverse AES S-box to each byte with hardware acceleration if available
+func InvSubBytesHW(block *Block) {
+	if CPU.HasARMCrypto {
+		armInvSubBytes(block)
+	} else {
+		InvSubBytes(block)
+	}
+}
+
+// InvSubBytes2HW applies the inverse AES S-box to 2 blocks with hardware acceleration if available
+func InvSubBytes2HW(blocks *Block2) {
+	if CPU.HasARMCrypto {
+		armInvSubBytes2(blocks)
+	} else {
+		InvSubBytes2(blocks)
+	}
+}
+
+// InvSubBytes4HW applies the inverse AES S-box to 4 blocks with hardware acceleration if available
+func InvSubBytes4HW(blocks *Block4) {
+	if CPU.HasARMCrypto {
+		armInvSubBytes4(blocks)
+	} else {
+		InvSubBytes4(blocks)
+	}
+}
+
+// ShiftRowsHW applies ShiftRows to a block with hardware acceleration if available
+func ShiftRowsHW(block *Block) {
+	if CPU.HasARMCrypto {
+		armShiftRows(block)
+	} else {
+		ShiftRows(block)
+	}
+}
+
+// ShiftRows2HW applies ShiftRows to 2 blocks with hardware acceleration if available
+func ShiftRows2HW(blocks *Block2) {
+	if CPU.HasARMCrypto {
+		armShiftRows2(blocks)
+	} else {
+		ShiftRows2(blocks)
+	}
+}
+
+// ShiftRows4HW applies ShiftRows to 4 blocks with hardware acceleration if available
+func ShiftRows4HW(blocks *Block4) {
+	if CPU.HasARMCrypto {
+		armShiftRows4(blocks)
+	} else {
+		ShiftRows4(blocks)
+	}
+}
+
+// InvShiftRowsHW applies InvShiftRows to a block with hardware acceleration if available
+func InvShiftRowsHW(block *Block) {
+	if CPU.HasARMCrypto {
+		armInvShiftRows(block)
+	} else {
+		InvShiftRows(block)
+	}
+}
+
+// InvShiftRows2HW applies InvShiftRows to 2 blocks with hardware acceleration if available
+func InvShiftRows2HW(blocks *Block2) {
+	if CPU.HasARMCrypto {
+		armInvShiftRows2(blocks)
+	} else {
+		InvShiftRows2(blocks)
+	}
+}
+
+// InvShiftRows4HW applies InvShiftRows to 4 blocks with hardware acceleration if available
+func InvShiftRows4HW(blocks *Block4) {
+	if CPU.HasARMCrypto {
+		armInvShiftRows4(blocks)
+	} else {
+		InvShiftRows4(blocks)
+	}
+}
+
+// MixColumnsHW applies MixColumns to a block with hardware acceleration if available
+func MixColumnsHW(block *Block) {
+	if CPU.HasARMCrypto {
+		armMixColumns(block)
+	} else {
+		MixColumns(block)
+	}
+}
+
+// MixColumns2HW applies MixColumns to 2 blocks with hardware acceleration if available
+func MixColumns2HW(blocks *Block2) {
+	if CPU.HasARMCrypto {
+		armMixColumns2(blocks)
+	} else {
+		MixColumns2(blocks)
+	}
+}
+
+// MixColumns4HW applies MixColumns to 4 blocks with hardware acceleration if available
+func MixColumns4HW(blocks *Block4) {
+	if CPU.HasARMCrypto {
+		armMixColumns4(blocks)
+	} else {
+		MixColumns4(blocks)
+	}
+}
@@ -0,0 +1,246 @@
+// Individual AES transformations with ARM Crypto extensions for ARM64
+#include "textflag.h"
+
+// ShiftRows as a TBL index: {0, 5, 10, 15, 4, 9, 14, 3, 8, 13, 2, 7, 12, 1, 6, 11}
+DATA shiftRowsIdx<>+0x00(SB)/8, $0x030e09040f0a0500
+DATA shiftRowsIdx<>+0x08(SB)/8, $0x0b06010c07020d08
+GLOBL shiftRowsIdx<>(SB), RODATA|NOPTR, $16
+
+// InvShiftRows as a TBL index: {0, 13, 10, 7, 4, 1, 14, 11, 8, 5, 2, 15, 12, 9, 6, 3}
+DATA invShiftRowsIdx<>+0x00(SB)/8, $0x0b0e0104070a0d00
+DATA invShiftRowsIdx<>+0x08(SB)/8, $0x0306090c0f020508
+GLOBL invShiftRowsIdx<>(SB), RODATA|NOPTR, $16
+
+// func armSubBytes(block *Block)
+TEXT ·armSubBytes(SB),NOSPLIT,$0
+	MOVD block+0(FP), R0
+	MOVD $invShiftRowsIdx<>(SB), R1
+
+	VLD1 (R0), [V0.B16]
+	VLD1 (R1), [V4.B16]
+	VEOR V5.B16, V5.B16, V5.B16
+
+	// Undo the ShiftRows of AESE, with a zero round key
+	VTBL V4.B16, [V0.B16], V0.B16
+	AESE V5.B16, V0.B16
+
+	VST1 [V0.B16], (R0)
+	RET
+
+// func armSubBytes2(blocks *Block2)
+TEXT ·armSubBytes2(SB),NOSPLIT,$0
+	MOVD blocks+0(FP), R0
+	MOVD $invShiftRowsIdx<>(SB), R1
+
+	VLD1 (R0), [V0.B16, V1.B16]
+	VLD1 (R1), [V4.B16]
+	VEOR V5.B16, V5.B16, V5.B16
+
+	VTBL V4.B16, [V0.B16], V0.B16
+	VTBL V4.B16, [V1.B16], V1.B16
+	AESE V5.B16, V0.B16
+	AESE V5.B16, V1.B16
+
+	VST1 [V0.B16, V1.B16], (R0)
+	RET
+
+// func armSubBytes4(blocks *Block4)
+TEXT ·armSubBytes4(SB),NOSPLIT,$0
+	MOVD blocks+0(FP), R0
+	MOVD $invShiftRowsIdx<>(SB), R1
+
+	VLD1 (R0), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VLD1 (R1), [V4.B16]
+	VEOR V5.B16, V5.B16, V5.B16
+
+	VTBL V4.B16, [V0.B16], V0.B16
+	VTBL V4.B16, [V1.B16], V1.B16
+	VTBL V4.B16, [V2.B16], V2.B16
+	VTBL V4.B16, [V3.B16], V3.B16
+	AESE V5.B16, V0.B16
+	AESE V5.B16, V1.B16
+	AESE V5.B16, V2.B16
+	AESE V5.B16, V3.B16
+
+	VST1 [V0.B16, V1.B16, V2.B16, V3.B16], (R0)
+	RET
+
+// func armInvSubBytes(block *Block)
+TEXT ·armInvSubBytes(SB),NOSPLIT,$0
+	MOVD block+0(FP), R0
+	MOVD $shiftRowsIdx<>(SB), R1
+
+	VLD1 (R0), [V0.B16]
+	VLD1 (R1), [V4.B16]
+	VEOR V5.B16, V5.B16, V5.B16
+
+	// Undo the InvShiftRows of AESD, with a zero round key
+	VTBL V4.B16, [V0.B16], V0.B16
+	AESD V5.B16, V0.B16
+
+	VST1 [V0.B16], (R0)
+	RET
+
+// func armInvSubBytes2(blocks *Block2)
+TEXT ·armInvSubBytes2(SB),NOSPLIT,$0
+	MOVD blocks+0(FP), R0
+	MOVD $shiftRowsIdx<>(SB), R1
+
+	VLD1 (R0), [V0.B16, V1.B16]
+	VLD1 (R1), [V4.B16]
+	VEOR V5.B16, V5.B16, V5.B16
+
+	VTBL V4.B16, [V0.B16], V0.B16
+	VTBL V4.B16, [V1.B16], V1.B16
+	AESD V5.B16, V0.B16
+	AESD V5.B16, V1.B16
+
+	VST1 [V0.B16, V1.B16], (R0)
+	RET
+
+// func armInvSubBytes4(blocks *Block4)
+TEXT ·armInvSubBytes4(SB),NOSPLIT,$0
+	MOVD blocks+0(FP), R0
+	MOVD $shiftRowsIdx<>(SB), R1
+
+	VLD1 (R0), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VLD1 (R1), [V4.B16]
+	VEOR V5.B16, V5.B16, V5.B16
+
+	VTBL V4.B16, [V0.B16], V0.B16
+	VTBL V4.B16, [V1.B16], V1.B16
+	VTBL V4.B16, [V2.B16], V2.B16
+	VTBL V4.B16, [V3.B16], V3.B16
+	AESD V5.B16, V0.B16
+	AESD V5.B16, V1.B16
+	AESD V5.B16, V2.B16
+	AESD V5.B16, V3.B16
+
+	VST1 [V0.B16, V1.B16, V2.B16, V3.B16], (R0)
+	RET
+
+// func armShiftRows(block *Block)
+TEXT ·armShiftRows(SB),NOSPLIT,$0
+	MOVD block+0(FP), R0
+	MOVD $shiftRowsIdx<>(SB), R1
+
+	VLD1 (R0), [V0.B16]
+	VLD1 (R1), [V4.B16]
+
+	// ShiftRows is a byte permutation
+	VTBL V4.B16, [V0.B16], V0.B16
+
+	VST1 [V0.B16], (R0)
+	RET
+
+// func armShiftRows2(blocks *Block2)
+TEXT ·armShiftRows2(SB),NOSPLIT,$0
+	MOVD blocks+0(FP), R0
+	MOVD $shiftRowsIdx<>(SB), R1
+
+	VLD1 (R0), [V0.B16, V1.B16]
+	VLD1 (R1), [V4.B16]
+
+	VTBL V4.B16, [V0.B16], V0.B16
+	VTBL V4.B16, [V1.B16], V1.B16
+
+	VST1 [V0.B16, V1.B16], (R0)
+	RET
+
+// func armShiftRows4(blocks *Block4)
+TEXT ·armShiftRows4(SB),NOSPLIT,$0
+	MOVD blocks+0(FP), R0
+	MOVD $shiftRowsIdx<>(SB), R1
+
+	VLD1 (R0), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VLD1 (R1), [V4.B16]
+
+	VTBL V4.B16, [V0.B16], V0.B16
+	VTBL V4.B16, [V1.B16], V1.B16
+	VTBL V4.B16, [V2.B16], V2.B16
+	VTBL V4.B16, [V3.B16], V3.B16
+
+	VST1 [V0.B16, V1.B16, V2.B16, V3.B16], (R0)
+	RET
+
+// func armInvShiftRows(block *Block)
+TEXT ·armInvShiftRows(SB),NOSPLIT,$0
+	MOVD block+0(FP), R0
+	MOVD $invShiftRowsIdx<>(SB), R1
+
+	VLD1 (R0), [V0.B16]
+	VLD1 (R1), [V4.B16]
+
+	// InvShiftRows is a byte permutation
+	VTBL V4.B16, [V0.B16], V0.B16
+
+	VST1 [V0.B16], (R0)
+	RET
+
+// func armInvShiftRows2(blocks *Block2)
+TEXT ·armInvShiftRows2(SB),NOSPLIT,$0
+	MOVD blocks+0(FP), R0
+	MOVD $invShiftRowsIdx<>(SB), R1
+
+	VLD1 (R0), [V0.B16, V1.B16]
+	VLD1 (R1), [V4.B16]
+
+	VTBL V4.B16, [V0.B16], V0.B16
+	VTBL V4.B16, [V1.B16], V1.B16
+
+	VST1 [V0.B16, V1.B16], (R0)
+	RET
+
+// func armInvShiftRows4(blocks *Block4)
+TEXT ·armInvShiftRows4(SB),NOSPLIT,$0
+	MOVD blocks+0(FP), R0
+	MOVD $invShiftRowsIdx<>(SB), R1
+
+	VLD1 (R0), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VLD1 (R1), [V4.B16]
+
+	VTBL V4.B16, [V0.B16], V0.B16
+	VTBL V4.B16, [V1.B16], V1.B16
+	VTBL V4.B16, [V2.B16], V2.B16
+	VTBL V4.B16, [V3.B16], V3.B16
+
+	VST1 [V0.B16, V1.B16, V2.B16, V3.B16], (R0)
+	RET
+
+// func armMixColumns(block *Block)
+TEXT ·armMixColumns(SB),NOSPLIT,$0
+	MOVD block+0(FP), R0
+
+	VLD1 (R0), [V0.B16]
+
+	// AESMC is MixColumns alone
+	AESMC V0.B16, V0.B16
+
+	VST1 [V0.B16], (R0)
+	RET
+
+// func armMixColumns2(blocks *Block2)
+TEXT ·armMixColumns2(SB),NOSPLIT,$0
+	MOVD blocks+0(FP), R0
+
+	VLD1 (R0), [V0.B16, V1.B16]
+
+	AESMC V0.B16, V0.B16
+	AESMC V1.B16, V1.B16
+
+	VST1 [V0.B16, V1.B16], (R0)
+	RET
+
+// func armMixColumns4(blocks *Block4)
+TEXT ·armMixColumns4(SB),NOSPLIT,$0
+	MOVD blocks+0(FP), R0
+
+	VLD1 (R0), [V0.B16, V1.B16, V2.B16, V3.B16]
+
+	AESMC V0.B16, V0.B16
+	AESMC V1.B16, V1.B16
+	AESMC V2.B16, V2.B16
+	AESMC V3.B16, V3.B16
+
+	VST1 [V0.B16, V1.B16, V2.B16, V3.B16], (R0)
+	RET
@@ -0,0 +1,65 @@
+//go:build (!amd64 && !arm64) || purego
+
+package aes
+
+// Software fallback for the individual transformations
+
+func SubBytesHW(block *Block) {
+	SubBytes(block)
+}
+
+func SubBytes2HW(blocks *Block2) {
+	SubBytes2(blocks)
+}
+
+func SubBytes4HW(blocks *Block4) {
+	SubBytes4(blocks)
+}
+
+func InvSubBytesHW(block *Block) {
+	InvSubBytes(block)
+}
+
+func InvSubBytes2HW(blocks *Block2) {
+	InvSubBytes2(blocks)
+}
+
+func InvSubBytes4HW(blocks *Block4) {
+	InvSubBytes4(blocks)
+}
+
+func ShiftRowsHW(block *Block) {
+	ShiftRows(block)
+}
+
+func ShiftRows2HW(blocks *Block2) {
+	ShiftRows2(blocks)
+}
+
+func ShiftRows4HW(blocks *Block4) {
+	ShiftRows4(blocks)
+}
+
+func InvShiftRowsHW(block *Block) {
+	InvShiftRows(block)
+}
+
+func InvShiftRows2HW(blocks *Block2) {
+	InvShiftRows2(blocks)
+}
+
+func InvShiftRows4HW(blocks *Block4) {
+	InvShiftRows4(blocks)
+}
+
+func MixColumnsHW(block *Block) {
+	MixColumns(block)
+}
+
+func MixColumns2HW(blocks *Block2) {
+	MixColumns2(blocks)
+}
+
+func MixColumns4HW(blocks *Block4) {
+	MixColumns4(blocks)
+}
@@ -0,0 +1,153 @@
+package aes
+
+import "testing"
+
+// transformFuncs lists every individual transformation in its software,
+// 1-block, 2-block and 4-block hardware forms.
+var transformFuncs = []struct {
+	name string
+	sw   func(*Block)
+	hw   func(*Block)
+	hw2  func(*Block2)
+	hw4  func(*Block4)
+}{
+	{"SubBytes", SubBytes, SubBytesHW, SubBytes2HW, SubBytes4HW},
+	{"InvSubBytes", InvSubBytes, InvSubBytesHW, InvSubBytes2HW, InvSubBytes4HW},
+	{"ShiftRows", ShiftRows, ShiftRowsHW, ShiftRows2HW, ShiftRows4HW},
+	{"InvShiftRows", InvShiftRows, InvShiftRowsHW, InvShiftRows2HW, InvShiftRows4HW},
+	{"MixColumns", MixColumns, MixColumnsHW, MixColumns2HW, MixColumns4HW},
+	{"InvMixColumns", InvMixColumns, InvMixColumnsHW, InvMixColumns2HW, InvMixColumns4HW},
+}
+
+func TestTransformsHW(t *testing.T) {
+	forEachCPUConfig(t, func(t *testing.T) {
+		for _, f := range transformFuncs {
+			var b4 Block4
+			for i := range b4 {
+				b4[i] = byte(i*37 + 11)
+			}
+			for range 8 {
+				want := b4
+				for i := 0; i < len(want); i += 16 {
+					f.sw((*Block)(want[i:]))
+				}
+
+				one := b4
+				for i := 0; i < len(one); i += 16 {
+					f.hw((*Block)(one[i:]))
+				}
+				if one != want {
+					t.Fatalf("%sHW = %x, want %x", f.name, one, want)
+				}
+
+				two := b4
+				f.hw2((*Block2)(two[:32]))
+				f.hw2((*Block2)(two[32:]))
+				if two != want {
+					t.Fatalf("%s2HW = %x, want %x", f.name, two, want)
+				}
+
+				four := b4
+				f.hw4(&four)
+				if four != want {
+					t.Fatalf("%s4HW = %x, want %x", f.name, four, want)
+				}
+				b4 = want
+			}
+		}
+	})
+}
+
+func TestTransformsSoftware(t *testing.T) {
+	var b4 Block4
+	for i := range b4 {
+		b4[i] = byte(i*91 + 5)
+	}
+	for _, f := range []struct {
+		name string
+		sw   func(*Block)
+		sw2  func(*Block2)
+		sw4  func(*Block4)
+	}{
+		{"SubBytes", SubBytes, SubBytes2, SubBytes4},
+		{"InvSubBytes", InvSubBytes, InvSubBytes2, InvSubBytes4},
+		{"ShiftRows", ShiftRows, ShiftRows2, ShiftRows4},
+		{"InvShiftRows", InvShiftRows, InvShiftRows2, InvShiftRows4},
+		{"MixColumns", MixColumns, MixColumns2, MixColumns4},
+	} {
+		want := b4
+		for i := 0; i < len(want); i += 16 {
+			f.sw((*Block)(want[i:]))
+		}
+		two := b4
+		f.sw2((*Block2)(two[:32]))
+		f.sw2((*Block2)(two[32:]))
+		four := b4
+		f.sw4(&four)
+		if two != want || four != want {
+			t.Errorf("%s2/%s4 do not match %s", f.name, f.name, f.name)
+		}
+	}
+}
+
+// The transformations composed in the order of a round give the round, and
+// their inverses undo it.
+func TestTransformsComposeRound(t *testing.T) {
+	forEachCPUConfig(t, func(t *testing.T) {
+		var in, key Block
+		for i := range in {
+			in[i] = byte(i * 17)
+			key[i] = byte(i*5 + 1)
+		}
+		want := in
+		RoundHW(&want, &key)
+
+		b := in
+		SubBytesHW(&b)
+		ShiftRowsHW(&b)
+		MixColumnsHW(&b)
+		XorBlock(&b, &b, &key)
+		if b != want {
+			t.Fatalf("SubBytesHW, ShiftRowsHW, MixColumnsHW = %x, want %x", b, want)
+		}
+
+		XorBlock(&b, &b, &key)
+		InvMixColumnsHW(&b)
+		InvShiftRowsHW(&b)
+		InvSubBytesHW(&b)
+		if b != in {
+			t.Errorf("inverse transformations = %x, want %x", b, in)
+		}
+	})
+}
+
+func TestSubBytes4AllBytes(t *testing.T) {
+	for i := 0; i < 256; i += 64 {
+		var b Block4
+		for j := range b {
+			b[j] = byte(i + j)
+		}
+		SubBytes4HW(&b)
+		for j := range b {
+			if b[j] != sbox[i+j] {
+				t.Fatalf("S(%#02x) = %#02x, want %#02x", i+j, b[j], sbox[i+j])
+			}
+		}
+	}
+}
+
+func BenchmarkSubBytes4HW(b *testing.B) {
+	var x Block4
+	b.SetBytes(int64(len(x)))
+	for b.Loop() {
+		SubBytes4HW(&x)
+	}
+}
+
+func BenchmarkMixColumns4HW(b *testing.B) {
+	var x Block4
+	b.SetBytes(int64(len(x)))
+	for b.Loop() {
+		MixColumns4HW(&x)
+	}
+}
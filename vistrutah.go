@@ -300,92 +300,16 @@ func invMixingLayer512(s0, s1, s2, s3 *Block) {
 
 // Vistrutah256Encrypt encrypts a 32-byte plaintext block using Vistrutah-256.
 // Key must be 16 or 32 bytes. Rounds should be Vistrutah256RoundsShort (10) or Vistrutah256RoundsLong (14).
+// It expands the key on every call; use Vistrutah256Cipher to encrypt several blocks.
 func Vistrutah256Encrypt(plaintext, ciphertext, key []byte, rounds int) {
 	if len(plaintext) != 32 || len(ciphertext) != 32 {
 		panic("vistrutah256: plaintext and ciphertext must be 32 bytes")
 	}
-	if len(key) != 16 && len(key) != 32 {
-		panic("vistrutah256: key must be 16 or 32 bytes")
+	c, err := NewVistrutah256Cipher(key, rounds)
+	if err != nil {
+		panic(err.Error())
 	}
-	if rounds%RoundsPerStep != 0 || rounds < 2 {
-		panic("vistrutah256: rounds must be even and >= 2")
-	}
-
-	var fixedKey [32]byte
-	var roundKey [32]byte
-	steps := rounds / RoundsPerStep
-
-	var s0, s1 Block
-	copy(s0[:], plaintext[:16])
-	copy(s1[:], plaintext[16:32])
-
-	if len(key) == 16 {
-		copy(fixedKey[:16], key)
-		copy(fixedKey[16:], key)
-	} else {
-		copy(fixedKey[:], key)
-	}
-
-	// Initialize round key: swap halves
-	copy(roundKey[:16], fixedKey[16:32])
-	copy(roundKey[16:], fixedKey[:16])
-
-	var fk0, fk1 Block
-	copy(fk0[:], fixedKey[:16])
-	copy(fk1[:], fixedKey[16:32])
-
-	var rk0, rk1 Block
-	copy(rk0[:], roundKey[:16])
-	copy(rk1[:], roundKey[16:32])
-
-	// Initial XOR and first AES round
-	xorBlocks(&s0, &rk0)
-	xorBlocks(&s1, &rk1)
-	Round(&s0, &fk0)
-	Round(&s1, &fk1)
-
-	// Main loop
-	var zero Block
-	for i := 1; i < steps; i++ {
-		// AES round with zero key
-		Round(&s0, &zero)
-		Round(&s1, &zero)
-
-		// Mixing layer
-		mixingLayer256(&s0, &s1)
-
-		// Permute round keys
-		copy(rk0[:], roundKey[:16])
-		copy(rk1[:], roundKey[16:32])
-		vistrutahApplyPerm(&vistrutahP4, &rk0)
-		vistrutahApplyPerm(&vistrutahP5, &rk1)
-		copy(roundKey[:16], rk0[:])
-		copy(roundKey[16:], rk1[:])
-
-		// XOR state with round keys
-		xorBlocks(&s0, &rk0)
-		xorBlocks(&s1, &rk1)
-
-		// XOR round constant with s0
-		xorBlocks(&s0, &vistrutahRoundConstants[i-1])
-
-		// AES round with fixed key
-		Round(&s0, &fk0)
-		Round(&s1, &fk1)
-	}
-
-	// Final permutation
-	copy(rk0[:], roundKey[:16])
-	copy(rk1[:], roundKey[16:32])
-	vistrutahApplyPerm(&vistrutahP4, &rk0)
-	vistrutahApplyPerm(&vistrutahP5, &rk1)
-
-	// Final AES round (no MixColumns)
-	FinalRound(&s0, &rk0)
-	FinalRound(&s1, &rk1)
-
-	copy(ciphertext[:16], s0[:])
-	copy(ciphertext[16:], s1[:])
+	c.encryptGeneric((*[32]byte)(ciphertext), (*[32]byte)(plaintext))
 }
 
 // Vistrutah256Decrypt decrypts a 32-byte ciphertext block using Vistrutah-256.
@@ -393,233 +317,25 @@ func Vistrutah256Decrypt(ciphertext, plaintext, key []byte, rounds int) {
 	if len(plaintext) != 32 || len(ciphertext) != 32 {
 		panic("vistrutah256: plaintext and ciphertext must be 32 bytes")
 	}
-	if len(key) != 16 && len(key) != 32 {
-		panic("vistrutah256: key must be 16 or 32 bytes")
+	c, err := NewVistrutah256Cipher(key, rounds)
+	if err != nil {
+		panic(err.Error())
 	}
-	if rounds%RoundsPerStep != 0 || rounds < 2 {
-		panic("vistrutah256: rounds must be even and >= 2")
-	}
-
-	var fixedKey [32]byte
-	var roundKey [32]byte
-	steps := rounds / RoundsPerStep
-
-	var s0, s1 Block
-	copy(s0[:], ciphertext[:16])
-	copy(s1[:], ciphertext[16:32])
-
-	if len(key) == 16 {
-		copy(fixedKey[:16], key)
-		copy(fixedKey[16:], key)
-	} else {
-		copy(fixedKey[:], key)
-	}
-
-	// Initialize round key: swap halves
-	copy(roundKey[:16], fixedKey[16:32])
-	copy(roundKey[16:], fixedKey[:16])
-
-	// Advance round keys to final state
-	var rk0, rk1 Block
-	copy(rk0[:], roundKey[:16])
-	copy(rk1[:], roundKey[16:32])
-	for i := 0; i < steps; i++ {
-		vistrutahApplyPerm(&vistrutahP4, &rk0)
-		vistrutahApplyPerm(&vistrutahP5, &rk1)
-	}
-	copy(roundKey[:16], rk0[:])
-	copy(roundKey[16:], rk1[:])
-
-	var fk0, fk1 Block
-	copy(fk0[:], fixedKey[:16])
-	copy(fk1[:], fixedKey[16:32])
-
-	// Apply InvMixColumns to fixed keys for use with aesdec
-	fk0Imc := fk0
-	fk1Imc := fk1
-	InvMixColumns(&fk0Imc)
-	InvMixColumns(&fk1Imc)
-
-	copy(rk0[:], roundKey[:16])
-	copy(rk1[:], roundKey[16:32])
-
-	// Initial XOR and first inverse AES round
-	xorBlocks(&s0, &rk0)
-	xorBlocks(&s1, &rk1)
-	InvRound(&s0, &fk0Imc)
-	InvRound(&s1, &fk1Imc)
-
-	// Main loop (backwards)
-	for i := steps - 1; i >= 1; i-- {
-		// Inverse permute round keys
-		copy(rk0[:], roundKey[:16])
-		copy(rk1[:], roundKey[16:32])
-		vistrutahApplyPerm(&vistrutahP4Inv, &rk0)
-		vistrutahApplyPerm(&vistrutahP5Inv, &rk1)
-		copy(roundKey[:16], rk0[:])
-		copy(roundKey[16:], rk1[:])
-
-		// Inverse final round (SubBytes/ShiftRows only)
-		InvFinalRound(&s0, &rk0)
-		InvFinalRound(&s1, &rk1)
-
-		// XOR round constant
-		xorBlocks(&s0, &vistrutahRoundConstants[i-1])
-
-		// Inverse mixing layer
-		invMixingLayer256(&s0, &s1)
-
-		// Apply InvMixColumns to state
-		InvMixColumns(&s0)
-		InvMixColumns(&s1)
-
-		// Inverse AES round
-		InvRound(&s0, &fk0Imc)
-		InvRound(&s1, &fk1Imc)
-	}
-
-	// Final inverse permutation
-	copy(rk0[:], roundKey[:16])
-	copy(rk1[:], roundKey[16:32])
-	vistrutahApplyPerm(&vistrutahP4Inv, &rk0)
-	vistrutahApplyPerm(&vistrutahP5Inv, &rk1)
-
-	// Final inverse round
-	InvFinalRound(&s0, &rk0)
-	InvFinalRound(&s1, &rk1)
-
-	copy(plaintext[:16], s0[:])
-	copy(plaintext[16:], s1[:])
+	c.decryptGeneric((*[32]byte)(plaintext), (*[32]byte)(ciphertext))
 }
 
 // Vistrutah512Encrypt encrypts a 64-byte plaintext block using Vistrutah-512.
 // Key must be 32 or 64 bytes.
+// It expands the key on every call; use Vistrutah512Cipher to encrypt several blocks.
 func Vistrutah512Encrypt(plaintext, ciphertext, key []byte, rounds int) {
 	if len(plaintext) != 64 || len(ciphertext) != 64 {
 		panic("vistrutah512: plaintext and ciphertext must be 64 bytes")
 	}
-	if len(key) != 32 && len(key) != 64 {
-		panic("vistrutah512: key must be 32 or 64 bytes")
-	}
-	if rounds%RoundsPerStep != 0 || rounds < 2 {
-		panic("vistrutah512: rounds must be even and >= 2")
-	}
-
-	var fixedKey [64]byte
-	var roundKey [64]byte
-	steps := rounds / RoundsPerStep
-
-	var s0, s1, s2, s3 Block
-	copy(s0[:], plaintext[:16])
-	copy(s1[:], plaintext[16:32])
-	copy(s2[:], plaintext[32:48])
-	copy(s3[:], plaintext[48:64])
-
-	if len(key) == 32 {
-		copy(fixedKey[:32], key)
-		copy(fixedKey[32:], key)
-	} else {
-		copy(fixedKey[:], key)
-	}
-
-	// Apply KEXP shuffle to second half
-	var temp [32]byte
-	copy(temp[:], fixedKey[32:64])
-	for i := 0; i < 32; i++ {
-		fixedKey[32+i] = temp[vistrutahKexpShuffle[i]]
+	c, err := NewVistrutah512Cipher(key, rounds)
+	if err != nil {
+		panic(err.Error())
 	}
-
-	// Initialize round key: interleave halves
-	copy(roundKey[:16], fixedKey[16:32])
-	copy(roundKey[16:32], fixedKey[:16])
-	copy(roundKey[32:48], fixedKey[48:64])
-	copy(roundKey[48:64], fixedKey[32:48])
-
-	var fk0, fk1, fk2, fk3 Block
-	copy(fk0[:], fixedKey[:16])
-	copy(fk1[:], fixedKey[16:32])
-	copy(fk2[:], fixedKey[32:48])
-	copy(fk3[:], fixedKey[48:64])
-
-	var rk0, rk1, rk2, rk3 Block
-	copy(rk0[:], roundKey[:16])
-	copy(rk1[:], roundKey[16:32])
-	copy(rk2[:], roundKey[32:48])
-	copy(rk3[:], roundKey[48:64])
-
-	// Initial XOR and first AES round
-	xorBlocks(&s0, &rk0)
-	xorBlocks(&s1, &rk1)
-	xorBlocks(&s2, &rk2)
-	xorBlocks(&s3, &rk3)
-
-	Round(&s0, &fk0)
-	Round(&s1, &fk1)
-	Round(&s2, &fk2)
-	Round(&s3, &fk3)
-
-	// Main loop
-	var zero Block
-	for i := 1; i < steps; i++ {
-		// AES round with zero key
-		Round(&s0, &zero)
-		Round(&s1, &zero)
-		Round(&s2, &zero)
-		Round(&s3, &zero)
-
-		// Mixing layer
-		mixingLayer512(&s0, &s1, &s2, &s3)
-
-		// Rotate round keys
-		copy(rk0[:], roundKey[:16])
-		copy(rk1[:], roundKey[16:32])
-		copy(rk2[:], roundKey[32:48])
-		copy(rk3[:], roundKey[48:64])
-		vistrutahRotateBytes(&rk0, 5)
-		vistrutahRotateBytes(&rk1, 10)
-		vistrutahRotateBytes(&rk2, 5)
-		vistrutahRotateBytes(&rk3, 10)
-		copy(roundKey[:16], rk0[:])
-		copy(roundKey[16:32], rk1[:])
-		copy(roundKey[32:48], rk2[:])
-		copy(roundKey[48:64], rk3[:])
-
-		// XOR state with round keys
-		xorBlocks(&s0, &rk0)
-		xorBlocks(&s1, &rk1)
-		xorBlocks(&s2, &rk2)
-		xorBlocks(&s3, &rk3)
-
-		// XOR round constant with s0
-		xorBlocks(&s0, &vistrutahRoundConstants[i-1])
-
-		// AES round with fixed key
-		Round(&s0, &fk0)
-		Round(&s1, &fk1)
-		Round(&s2, &fk2)
-		Round(&s3, &fk3)
-	}
-
-	// Final rotation
-	copy(rk0[:], roundKey[:16])
-	copy(rk1[:], roundKey[16:32])
-	copy(rk2[:], roundKey[32:48])
-	copy(rk3[:], roundKey[48:64])
-	vistrutahRotateBytes(&rk0, 5)
-	vistrutahRotateBytes(&rk1, 10)
-	vistrutahRotateBytes(&rk2, 5)
-	vistrutahRotateBytes(&rk3, 10)
-
-	// Final AES round (no MixColumns)
-	FinalRound(&s0, &rk0)
-	FinalRound(&s1, &rk1)
-	FinalRound(&s2, &rk2)
-	FinalRound(&s3, &rk3)
-
-	copy(ciphertext[:16], s0[:])
-	copy(ciphertext[16:32], s1[:])
-	copy(ciphertext[32:48], s2[:])
-	copy(ciphertext[48:64], s3[:])
+	c.encryptGeneric((*[64]byte)(ciphertext), (*[64]byte)(plaintext))
 }
 
 // Vistrutah512Decrypt decrypts a 64-byte ciphertext block using Vistrutah-512.
@@ -627,151 +343,9 @@ func Vistrutah512Decrypt(ciphertext, plaintext, key []byte, rounds int) {
 	if len(plaintext) != 64 || len(ciphertext) != 64 {
 		panic("vistrutah512: plaintext and ciphertext must be 64 bytes")
 	}
-	if len(key) != 32 && len(key) != 64 {
-		panic("vistrutah512: key must be 32 or 64 bytes")
+	c, err := NewVistrutah512Cipher(key, rounds)
+	if err != nil {
+		panic(err.Error())
 	}
-	if rounds%RoundsPerStep != 0 || rounds < 2 {
-		panic("vistrutah512: rounds must be even and >= 2")
-	}
-
-	var fixedKey [64]byte
-	var roundKey [64]byte
-	steps := rounds / RoundsPerStep
-
-	var s0, s1, s2, s3 Block
-	copy(s0[:], ciphertext[:16])
-	copy(s1[:], ciphertext[16:32])
-	copy(s2[:], ciphertext[32:48])
-	copy(s3[:], ciphertext[48:64])
-
-	if len(key) == 32 {
-		copy(fixedKey[:32], key)
-		copy(fixedKey[32:], key)
-	} else {
-		copy(fixedKey[:], key)
-	}
-
-	// Apply KEXP shuffle to second half
-	var temp [32]byte
-	copy(temp[:], fixedKey[32:64])
-	for i := 0; i < 32; i++ {
-		fixedKey[32+i] = temp[vistrutahKexpShuffle[i]]
-	}
-
-	// Initialize round key: interleave halves
-	copy(roundKey[:16], fixedKey[16:32])
-	copy(roundKey[16:32], fixedKey[:16])
-	copy(roundKey[32:48], fixedKey[48:64])
-	copy(roundKey[48:64], fixedKey[32:48])
-
-	// Advance round keys to final state
-	var rk0, rk1, rk2, rk3 Block
-	copy(rk0[:], roundKey[:16])
-	copy(rk1[:], roundKey[16:32])
-	copy(rk2[:], roundKey[32:48])
-	copy(rk3[:], roundKey[48:64])
-	for i := 0; i < steps; i++ {
-		vistrutahRotateBytes(&rk0, 5)
-		vistrutahRotateBytes(&rk1, 10)
-		vistrutahRotateBytes(&rk2, 5)
-		vistrutahRotateBytes(&rk3, 10)
-	}
-	copy(roundKey[:16], rk0[:])
-	copy(roundKey[16:32], rk1[:])
-	copy(roundKey[32:48], rk2[:])
-	copy(roundKey[48:64], rk3[:])
-
-	var fk0, fk1, fk2, fk3 Block
-	copy(fk0[:], fixedKey[:16])
-	copy(fk1[:], fixedKey[16:32])
-	copy(fk2[:], fixedKey[32:48])
-	copy(fk3[:], fixedKey[48:64])
-
-	// Apply InvMixColumns to fixed keys
-	fk0Imc := fk0
-	fk1Imc := fk1
-	fk2Imc := fk2
-	fk3Imc := fk3
-	InvMixColumns(&fk0Imc)
-	InvMixColumns(&fk1Imc)
-	InvMixColumns(&fk2Imc)
-	InvMixColumns(&fk3Imc)
-
-	copy(rk0[:], roundKey[:16])
-	copy(rk1[:], roundKey[16:32])
-	copy(rk2[:], roundKey[32:48])
-	copy(rk3[:], roundKey[48:64])
-
-	// Initial XOR and first inverse AES round
-	xorBlocks(&s0, &rk0)
-	xorBlocks(&s1, &rk1)
-	xorBlocks(&s2, &rk2)
-	xorBlocks(&s3, &rk3)
-
-	InvRound(&s0, &fk0Imc)
-	InvRound(&s1, &fk1Imc)
-	InvRound(&s2, &fk2Imc)
-	InvRound(&s3, &fk3Imc)
-
-	// Main loop (backwards)
-	for i := steps - 1; i >= 1; i-- {
-		// Inverse rotate round keys (rotate backward = rotate forward by 16-shift)
-		copy(rk0[:], roundKey[:16])
-		copy(rk1[:], roundKey[16:32])
-		copy(rk2[:], roundKey[32:48])
-		copy(rk3[:], roundKey[48:64])
-		vistrutahRotateBytes(&rk0, 16-5)
-		vistrutahRotateBytes(&rk1, 16-10)
-		vistrutahRotateBytes(&rk2, 16-5)
-		vistrutahRotateBytes(&rk3, 16-10)
-		copy(roundKey[:16], rk0[:])
-		copy(roundKey[16:32], rk1[:])
-		copy(roundKey[32:48], rk2[:])
-		copy(roundKey[48:64], rk3[:])
-
-		// Inverse final round
-		InvFinalRound(&s0, &rk0)
-		InvFinalRound(&s1, &rk1)
-		InvFinalRound(&s2, &rk2)
-		InvFinalRound(&s3, &rk3)
-
-		// XOR round constant
-		xorBlocks(&s0, &vistrutahRoundConstants[i-1])
-
-		// Inverse mixing layer
-		invMixingLayer512(&s0, &s1, &s2, &s3)
-
-		// Apply InvMixColumns to state
-		InvMixColumns(&s0)
-		InvMixColumns(&s1)
-		InvMixColumns(&s2)
-		InvMixColumns(&s3)
-
-		// Inverse AES round
-		InvRound(&s0, &fk0Imc)
-		InvRound(&s1, &fk1Imc)
-		InvRound(&s2, &fk2Imc)
-		InvRound(&s3, &fk3Imc)
-	}
-
-	// Final inverse rotation
-	copy(rk0[:], roundKey[:16])
-	copy(rk1[:], roundKey[16:32])
-	copy(rk2[:], roundKey[32:48])
-	copy(rk3[:], roundKey[48:64])
-	vistrutahRotateBytes(&rk0, 16-5)
-	vistrutahRotateBytes(&rk1, 16-10)
-	vistrutahRotateBytes(&rk2, 16-5)
-	vistrutahRotateBytes(&rk3, 16-10)
-
-	// Final inverse round
-	InvFinalRound(&s0, &rk0)
-	InvFinalRound(&s1, &rk1)
-	InvFinalRound(&s2, &rk2)
-	InvFinalRound(&s3, &rk3)
-
-	copy(plaintext[:16], s0[:])
-	copy(plaintext[16:32], s1[:])
-	copy(plaintext[32:48], s2[:])
-	copy(plaintext[48:64], s3[:])
+	c.decryptGeneric((*[64]byte)(plaintext), (*[64]byte)(ciphertext))
 }
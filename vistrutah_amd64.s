@@ -783,3 +783,900 @@ dec512_loop_end:
 	MOVOU X2, 32(BX)
 	MOVOU X3, 48(BX)
 	RET
+
+// Vistrutah-256 encryption of n independent blocks with the expanded key:
+// rk holds steps+1 round keys and rc the round constants. Four blocks are
+// processed at a time, then the remaining ones one by one.
+// func vistrutah256EncryptBlocksAsm(dst, src *byte, n int, rk *[2]Block, fk *[2]Block, rc *Block, steps int)
+TEXT ·vistrutah256EncryptBlocksAsm(SB), NOSPLIT, $0-56
+	MOVQ dst+0(FP), AX
+	MOVQ src+8(FP), BX
+	MOVQ n+16(FP), CX
+	MOVQ rk+24(FP), DX
+	MOVQ fk+32(FP), SI
+	MOVQ rc+40(FP), DI
+	MOVQ steps+48(FP), R8
+
+	MOVOU (SI), X8
+	MOVOU 16(SI), X9
+	PXOR X10, X10
+	MOVOU reorg_mask<>(SB), X11
+
+enc256_loop4:
+	CMPQ CX, $4
+	JB enc256_loop1
+	MOVOU 0(BX), X0
+	MOVOU 16(BX), X1
+	MOVOU 32(BX), X2
+	MOVOU 48(BX), X3
+	MOVOU 64(BX), X4
+	MOVOU 80(BX), X5
+	MOVOU 96(BX), X6
+	MOVOU 112(BX), X7
+	MOVQ DX, R10
+	MOVOU (R10), X12
+	MOVOU 16(R10), X13
+	PXOR X12, X0
+	PXOR X12, X2
+	PXOR X12, X4
+	PXOR X12, X6
+	PXOR X13, X1
+	PXOR X13, X3
+	PXOR X13, X5
+	PXOR X13, X7
+	AESENC X8, X0
+	AESENC X8, X2
+	AESENC X8, X4
+	AESENC X8, X6
+	AESENC X9, X1
+	AESENC X9, X3
+	AESENC X9, X5
+	AESENC X9, X7
+	MOVQ R8, R9
+	DECQ R9
+	JZ enc256_x4_last
+	MOVQ DI, R11
+
+enc256_x4_step:
+	ADDQ $32, R10
+	AESENC X10, X0
+	AESENC X10, X2
+	AESENC X10, X4
+	AESENC X10, X6
+	AESENC X10, X1
+	AESENC X10, X3
+	AESENC X10, X5
+	AESENC X10, X7
+	PSHUFB X11, X0
+	PSHUFB X11, X1
+	MOVOA X0, X14
+	PUNPCKLQDQ X1, X0
+	PUNPCKHQDQ X1, X14
+	MOVOA X14, X1
+	PSHUFB X11, X2
+	PSHUFB X11, X3
+	MOVOA X2, X14
+	PUNPCKLQDQ X3, X2
+	PUNPCKHQDQ X3, X14
+	MOVOA X14, X3
+	PSHUFB X11, X4
+	PSHUFB X11, X5
+	MOVOA X4, X14
+	PUNPCKLQDQ X5, X4
+	PUNPCKHQDQ X5, X14
+	MOVOA X14, X5
+	PSHUFB X11, X6
+	PSHUFB X11, X7
+	MOVOA X6, X14
+	PUNPCKLQDQ X7, X6
+	PUNPCKHQDQ X7, X14
+	MOVOA X14, X7
+	MOVOU (R10), X12
+	MOVOU (R11), X15
+	PXOR X15, X12
+	MOVOU 16(R10), X13
+	PXOR X12, X0
+	PXOR X12, X2
+	PXOR X12, X4
+	PXOR X12, X6
+	PXOR X13, X1
+	PXOR X13, X3
+	PXOR X13, X5
+	PXOR X13, X7
+	AESENC X8, X0
+	AESENC X8, X2
+	AESENC X8, X4
+	AESENC X8, X6
+	AESENC X9, X1
+	AESENC X9, X3
+	AESENC X9, X5
+	AESENC X9, X7
+	ADDQ $16, R11
+	DECQ R9
+	JNZ enc256_x4_step
+
+enc256_x4_last:
+	ADDQ $32, R10
+	MOVOU (R10), X12
+	MOVOU 16(R10), X13
+	AESENCLAST X12, X0
+	AESENCLAST X12, X2
+	AESENCLAST X12, X4
+	AESENCLAST X12, X6
+	AESENCLAST X13, X1
+	AESENCLAST X13, X3
+	AESENCLAST X13, X5
+	AESENCLAST X13, X7
+	MOVOU X0, 0(AX)
+	MOVOU X1, 16(AX)
+	MOVOU X2, 32(AX)
+	MOVOU X3, 48(AX)
+	MOVOU X4, 64(AX)
+	MOVOU X5, 80(AX)
+	MOVOU X6, 96(AX)
+	MOVOU X7, 112(AX)
+	ADDQ $128, AX
+	ADDQ $128, BX
+	SUBQ $4, CX
+	JMP enc256_loop4
+
+enc256_loop1:
+	TESTQ CX, CX
+	JZ enc256_done
+	MOVOU 0(BX), X0
+	MOVOU 16(BX), X1
+	MOVQ DX, R10
+	MOVOU (R10), X12
+	MOVOU 16(R10), X13
+	PXOR X12, X0
+	PXOR X13, X1
+	AESENC X8, X0
+	AESENC X9, X1
+	MOVQ R8, R9
+	DECQ R9
+	JZ enc256_x1_last
+	MOVQ DI, R11
+
+enc256_x1_step:
+	ADDQ $32, R10
+	AESENC X10, X0
+	AESENC X10, X1
+	PSHUFB X11, X0
+	PSHUFB X11, X1
+	MOVOA X0, X14
+	PUNPCKLQDQ X1, X0
+	PUNPCKHQDQ X1, X14
+	MOVOA X14, X1
+	MOVOU (R10), X12
+	MOVOU (R11), X15
+	PXOR X15, X12
+	MOVOU 16(R10), X13
+	PXOR X12, X0
+	PXOR X13, X1
+	AESENC X8, X0
+	AESENC X9, X1
+	ADDQ $16, R11
+	DECQ R9
+	JNZ enc256_x1_step
+
+enc256_x1_last:
+	ADDQ $32, R10
+	MOVOU (R10), X12
+	MOVOU 16(R10), X13
+	AESENCLAST X12, X0
+	AESENCLAST X13, X1
+	MOVOU X0, 0(AX)
+	MOVOU X1, 16(AX)
+	ADDQ $32, AX
+	ADDQ $32, BX
+	DECQ CX
+	JMP enc256_loop1
+
+enc256_done:
+	RET
+
+// Vistrutah-256 decryption of n independent blocks with the expanded key.
+// fkImc is InvMixColumns of the fixed key. Four blocks are processed at a
+// time, then the remaining ones one by one.
+// func vistrutah256DecryptBlocksAsm(dst, src *byte, n int, rk *[2]Block, fkImc *[2]Block, rc *Block, steps int)
+TEXT ·vistrutah256DecryptBlocksAsm(SB), NOSPLIT, $0-56
+	MOVQ dst+0(FP), AX
+	MOVQ src+8(FP), BX
+	MOVQ n+16(FP), CX
+	MOVQ rk+24(FP), DX
+	MOVQ fkImc+32(FP), SI
+	MOVQ rc+40(FP), DI
+	MOVQ steps+48(FP), R8
+
+	MOVOU (SI), X8
+	MOVOU 16(SI), X9
+	MOVOU inv_reorg_mask<>(SB), X11
+
+dec256_loop4:
+	CMPQ CX, $4
+	JB dec256_loop1
+	MOVOU 0(BX), X0
+	MOVOU 16(BX), X1
+	MOVOU 32(BX), X2
+	MOVOU 48(BX), X3
+	MOVOU 64(BX), X4
+	MOVOU 80(BX), X5
+	MOVOU 96(BX), X6
+	MOVOU 112(BX), X7
+	MOVQ R8, R10
+	SHLQ $5, R10
+	ADDQ DX, R10
+	MOVOU (R10), X12
+	MOVOU 16(R10), X13
+	PXOR X12, X0
+	PXOR X12, X2
+	PXOR X12, X4
+	PXOR X12, X6
+	PXOR X13, X1
+	PXOR X13, X3
+	PXOR X13, X5
+	PXOR X13, X7
+	AESDEC X8, X0
+	AESDEC X8, X2
+	AESDEC X8, X4
+	AESDEC X8, X6
+	AESDEC X9, X1
+	AESDEC X9, X3
+	AESDEC X9, X5
+	AESDEC X9, X7
+	MOVQ R8, R9
+	DECQ R9
+	JZ dec256_x4_last
+	MOVQ R9, R11
+	SHLQ $4, R11
+	LEAQ -16(DI)(R11*1), R11
+
+dec256_x4_step:
+	SUBQ $32, R10
+	MOVOU (R10), X12
+	MOVOU (R11), X15
+	PXOR X15, X12
+	MOVOU 16(R10), X13
+	AESDECLAST X12, X0
+	AESDECLAST X12, X2
+	AESDECLAST X12, X4
+	AESDECLAST X12, X6
+	AESDECLAST X13, X1
+	AESDECLAST X13, X3
+	AESDECLAST X13, X5
+	AESDECLAST X13, X7
+	MOVOA X0, X14
+	PUNPCKLQDQ X1, X0
+	PUNPCKHQDQ X1, X14
+	MOVOA X14, X1
+	PSHUFB X11, X0
+	PSHUFB X11, X1
+	MOVOA X2, X14
+	PUNPCKLQDQ X3, X2
+	PUNPCKHQDQ X3, X14
+	MOVOA X14, X3
+	PSHUFB X11, X2
+	PSHUFB X11, X3
+	MOVOA X4, X14
+	PUNPCKLQDQ X5, X4
+	PUNPCKHQDQ X5, X14
+	MOVOA X14, X5
+	PSHUFB X11, X4
+	PSHUFB X11, X5
+	MOVOA X6, X14
+	PUNPCKLQDQ X7, X6
+	PUNPCKHQDQ X7, X14
+	MOVOA X14, X7
+	PSHUFB X11, X6
+	PSHUFB X11, X7
+	AESIMC X0, X0
+	AESIMC X2, X2
+	AESIMC X4, X4
+	AESIMC X6, X6
+	AESIMC X1, X1
+	AESIMC X3, X3
+	AESIMC X5, X5
+	AESIMC X7, X7
+	AESDEC X8, X0
+	AESDEC X8, X2
+	AESDEC X8, X4
+	AESDEC X8, X6
+	AESDEC X9, X1
+	AESDEC X9, X3
+	AESDEC X9, X5
+	AESDEC X9, X7
+	SUBQ $16, R11
+	DECQ R9
+	JNZ dec256_x4_step
+
+dec256_x4_last:
+	MOVOU (DX), X12
+	MOVOU 16(DX), X13
+	AESDECLAST X12, X0
+	AESDECLAST X12, X2
+	AESDECLAST X12, X4
+	AESDECLAST X12, X6
+	AESDECLAST X13, X1
+	AESDECLAST X13, X3
+	AESDECLAST X13, X5
+	AESDECLAST X13, X7
+	MOVOU X0, 0(AX)
+	MOVOU X1, 16(AX)
+	MOVOU X2, 32(AX)
+	MOVOU X3, 48(AX)
+	MOVOU X4, 64(AX)
+	MOVOU X5, 80(AX)
+	MOVOU X6, 96(AX)
+	MOVOU X7, 112(AX)
+	ADDQ $128, AX
+	ADDQ $128, BX
+	SUBQ $4, CX
+	JMP dec256_loop4
+
+dec256_loop1:
+	TESTQ CX, CX
+	JZ dec256_done
+	MOVOU 0(BX), X0
+	MOVOU 16(BX), X1
+	MOVQ R8, R10
+	SHLQ $5, R10
+	ADDQ DX, R10
+	MOVOU (R10), X12
+	MOVOU 16(R10), X13
+	PXOR X12, X0
+	PXOR X13, X1
+	AESDEC X8, X0
+	AESDEC X9, X1
+	MOVQ R8, R9
+	DECQ R9
+	JZ dec256_x1_last
+	MOVQ R9, R11
+	SHLQ $4, R11
+	LEAQ -16(DI)(R11*1), R11
+
+dec256_x1_step:
+	SUBQ $32, R10
+	MOVOU (R10), X12
+	MOVOU (R11), X15
+	PXOR X15, X12
+	MOVOU 16(R10), X13
+	AESDECLAST X12, X0
+	AESDECLAST X13, X1
+	MOVOA X0, X14
+	PUNPCKLQDQ X1, X0
+	PUNPCKHQDQ X1, X14
+	MOVOA X14, X1
+	PSHUFB X11, X0
+	PSHUFB X11, X1
+	AESIMC X0, X0
+	AESIMC X1, X1
+	AESDEC X8, X0
+	AESDEC X9, X1
+	SUBQ $16, R11
+	DECQ R9
+	JNZ dec256_x1_step
+
+dec256_x1_last:
+	MOVOU (DX), X12
+	MOVOU 16(DX), X13
+	AESDECLAST X12, X0
+	AESDECLAST X13, X1
+	MOVOU X0, 0(AX)
+	MOVOU X1, 16(AX)
+	ADDQ $32, AX
+	ADDQ $32, BX
+	DECQ CX
+	JMP dec256_loop1
+
+dec256_done:
+	RET
+
+// Vistrutah-512 encryption of n independent blocks with the expanded key,
+// two blocks at a time, then the remaining one.
+// func vistrutah512EncryptBlocksAsm(dst, src *byte, n int, rk *[4]Block, fk *[4]Block, rc *Block, steps int)
+TEXT ·vistrutah512EncryptBlocksAsm(SB), NOSPLIT, $0-56
+	MOVQ dst+0(FP), AX
+	MOVQ src+8(FP), BX
+	MOVQ n+16(FP), CX
+	MOVQ rk+24(FP), DX
+	MOVQ fk+32(FP), SI
+	MOVQ rc+40(FP), DI
+	MOVQ steps+48(FP), R8
+
+	PXOR X12, X12
+
+enc512_loop2:
+	CMPQ CX, $2
+	JB enc512_loop1
+	MOVOU 0(BX), X0
+	MOVOU 16(BX), X1
+	MOVOU 32(BX), X2
+	MOVOU 48(BX), X3
+	MOVOU 64(BX), X4
+	MOVOU 80(BX), X5
+	MOVOU 96(BX), X6
+	MOVOU 112(BX), X7
+	MOVQ DX, R10
+	MOVOU 0(R10), X13
+	PXOR X13, X0
+	PXOR X13, X4
+	MOVOU 16(R10), X13
+	PXOR X13, X1
+	PXOR X13, X5
+	MOVOU 32(R10), X13
+	PXOR X13, X2
+	PXOR X13, X6
+	MOVOU 48(R10), X13
+	PXOR X13, X3
+	PXOR X13, X7
+	MOVOU 0(SI), X15
+	AESENC X15, X0
+	AESENC X15, X4
+	MOVOU 16(SI), X15
+	AESENC X15, X1
+	AESENC X15, X5
+	MOVOU 32(SI), X15
+	AESENC X15, X2
+	AESENC X15, X6
+	MOVOU 48(SI), X15
+	AESENC X15, X3
+	AESENC X15, X7
+	MOVQ R8, R9
+	DECQ R9
+	JZ enc512_x2_last
+	MOVQ DI, R11
+
+enc512_x2_step:
+	ADDQ $64, R10
+	AESENC X12, X0
+	AESENC X12, X1
+	AESENC X12, X2
+	AESENC X12, X3
+	AESENC X12, X4
+	AESENC X12, X5
+	AESENC X12, X6
+	AESENC X12, X7
+	MOVOA X0, X8
+	MOVOA X2, X9
+	PUNPCKLBW X1, X0
+	PUNPCKHBW X1, X8
+	PUNPCKLBW X3, X2
+	PUNPCKHBW X3, X9
+	MOVOA X0, X10
+	MOVOA X8, X11
+	PUNPCKLWL X2, X0
+	PUNPCKHWL X2, X10
+	PUNPCKLWL X9, X8
+	PUNPCKHWL X9, X11
+	MOVOA X8, X1
+	MOVOA X10, X2
+	MOVOA X11, X3
+	MOVOA X4, X8
+	MOVOA X6, X9
+	PUNPCKLBW X5, X4
+	PUNPCKHBW X5, X8
+	PUNPCKLBW X7, X6
+	PUNPCKHBW X7, X9
+	MOVOA X4, X10
+	MOVOA X8, X11
+	PUNPCKLWL X6, X4
+	PUNPCKHWL X6, X10
+	PUNPCKLWL X9, X8
+	PUNPCKHWL X9, X11
+	MOVOA X8, X5
+	MOVOA X10, X6
+	MOVOA X11, X7
+	MOVOU 0(R10), X13
+	MOVOU (R11), X14
+	PXOR X14, X13
+	PXOR X13, X0
+	PXOR X13, X4
+	MOVOU 16(R10), X13
+	PXOR X13, X1
+	PXOR X13, X5
+	MOVOU 32(R10), X13
+	PXOR X13, X2
+	PXOR X13, X6
+	MOVOU 48(R10), X13
+	PXOR X13, X3
+	PXOR X13, X7
+	MOVOU 0(SI), X15
+	AESENC X15, X0
+	AESENC X15, X4
+	MOVOU 16(SI), X15
+	AESENC X15, X1
+	AESENC X15, X5
+	MOVOU 32(SI), X15
+	AESENC X15, X2
+	AESENC X15, X6
+	MOVOU 48(SI), X15
+	AESENC X15, X3
+	AESENC X15, X7
+	ADDQ $16, R11
+	DECQ R9
+	JNZ enc512_x2_step
+
+enc512_x2_last:
+	ADDQ $64, R10
+	MOVOU 0(R10), X13
+	AESENCLAST X13, X0
+	AESENCLAST X13, X4
+	MOVOU 16(R10), X13
+	AESENCLAST X13, X1
+	AESENCLAST X13, X5
+	MOVOU 32(R10), X13
+	AESENCLAST X13, X2
+	AESENCLAST X13, X6
+	MOVOU 48(R10), X13
+	AESENCLAST X13, X3
+	AESENCLAST X13, X7
+	MOVOU X0, 0(AX)
+	MOVOU X1, 16(AX)
+	MOVOU X2, 32(AX)
+	MOVOU X3, 48(AX)
+	MOVOU X4, 64(AX)
+	MOVOU X5, 80(AX)
+	MOVOU X6, 96(AX)
+	MOVOU X7, 112(AX)
+	ADDQ $128, AX
+	ADDQ $128, BX
+	SUBQ $2, CX
+	JMP enc512_loop2
+
+enc512_loop1:
+	TESTQ CX, CX
+	JZ enc512_done
+	MOVOU 0(BX), X0
+	MOVOU 16(BX), X1
+	MOVOU 32(BX), X2
+	MOVOU 48(BX), X3
+	MOVQ DX, R10
+	MOVOU 0(R10), X13
+	PXOR X13, X0
+	MOVOU 16(R10), X13
+	PXOR X13, X1
+	MOVOU 32(R10), X13
+	PXOR X13, X2
+	MOVOU 48(R10), X13
+	PXOR X13, X3
+	MOVOU 0(SI), X15
+	AESENC X15, X0
+	MOVOU 16(SI), X15
+	AESENC X15, X1
+	MOVOU 32(SI), X15
+	AESENC X15, X2
+	MOVOU 48(SI), X15
+	AESENC X15, X3
+	MOVQ R8, R9
+	DECQ R9
+	JZ enc512_x1_last
+	MOVQ DI, R11
+
+enc512_x1_step:
+	ADDQ $64, R10
+	AESENC X12, X0
+	AESENC X12, X1
+	AESENC X12, X2
+	AESENC X12, X3
+	MOVOA X0, X8
+	MOVOA X2, X9
+	PUNPCKLBW X1, X0
+	PUNPCKHBW X1, X8
+	PUNPCKLBW X3, X2
+	PUNPCKHBW X3, X9
+	MOVOA X0, X10
+	MOVOA X8, X11
+	PUNPCKLWL X2, X0
+	PUNPCKHWL X2, X10
+	PUNPCKLWL X9, X8
+	PUNPCKHWL X9, X11
+	MOVOA X8, X1
+	MOVOA X10, X2
+	MOVOA X11, X3
+	MOVOU 0(R10), X13
+	MOVOU (R11), X14
+	PXOR X14, X13
+	PXOR X13, X0
+	MOVOU 16(R10), X13
+	PXOR X13, X1
+	MOVOU 32(R10), X13
+	PXOR X13, X2
+	MOVOU 48(R10), X13
+	PXOR X13, X3
+	MOVOU 0(SI), X15
+	AESENC X15, X0
+	MOVOU 16(SI), X15
+	AESENC X15, X1
+	MOVOU 32(SI), X15
+	AESENC X15, X2
+	MOVOU 48(SI), X15
+	AESENC X15, X3
+	ADDQ $16, R11
+	DECQ R9
+	JNZ enc512_x1_step
+
+enc512_x1_last:
+	ADDQ $64, R10
+	MOVOU 0(R10), X13
+	AESENCLAST X13, X0
+	MOVOU 16(R10), X13
+	AESENCLAST X13, X1
+	MOVOU 32(R10), X13
+	AESENCLAST X13, X2
+	MOVOU 48(R10), X13
+	AESENCLAST X13, X3
+	MOVOU X0, 0(AX)
+	MOVOU X1, 16(AX)
+	MOVOU X2, 32(AX)
+	MOVOU X3, 48(AX)
+	ADDQ $64, AX
+	ADDQ $64, BX
+	DECQ CX
+	JMP enc512_loop1
+
+enc512_done:
+	RET
+
+// Vistrutah-512 decryption of n independent blocks with the expanded key,
+// two blocks at a time, then the remaining one.
+// func vistrutah512DecryptBlocksAsm(dst, src *byte, n int, rk *[4]Block, fkImc *[4]Block, rc *Block, steps int)
+TEXT ·vistrutah512DecryptBlocksAsm(SB), NOSPLIT, $0-56
+	MOVQ dst+0(FP), AX
+	MOVQ src+8(FP), BX
+	MOVQ n+16(FP), CX
+	MOVQ rk+24(FP), DX
+	MOVQ fkImc+32(FP), SI
+	MOVQ rc+40(FP), DI
+	MOVQ steps+48(FP), R8
+
+	MOVOU extract_mask_512<>(SB), X12
+
+dec512_loop2:
+	CMPQ CX, $2
+	JB dec512_loop1
+	MOVOU 0(BX), X0
+	MOVOU 16(BX), X1
+	MOVOU 32(BX), X2
+	MOVOU 48(BX), X3
+	MOVOU 64(BX), X4
+	MOVOU 80(BX), X5
+	MOVOU 96(BX), X6
+	MOVOU 112(BX), X7
+	MOVQ R8, R10
+	SHLQ $6, R10
+	ADDQ DX, R10
+	MOVOU 0(R10), X13
+	PXOR X13, X0
+	PXOR X13, X4
+	MOVOU 16(R10), X13
+	PXOR X13, X1
+	PXOR X13, X5
+	MOVOU 32(R10), X13
+	PXOR X13, X2
+	PXOR X13, X6
+	MOVOU 48(R10), X13
+	PXOR X13, X3
+	PXOR X13, X7
+	MOVOU 0(SI), X15
+	AESDEC X15, X0
+	AESDEC X15, X4
+	MOVOU 16(SI), X15
+	AESDEC X15, X1
+	AESDEC X15, X5
+	MOVOU 32(SI), X15
+	AESDEC X15, X2
+	AESDEC X15, X6
+	MOVOU 48(SI), X15
+	AESDEC X15, X3
+	AESDEC X15, X7
+	MOVQ R8, R9
+	DECQ R9
+	JZ dec512_x2_last
+	MOVQ R9, R11
+	SHLQ $4, R11
+	LEAQ -16(DI)(R11*1), R11
+
+dec512_x2_step:
+	SUBQ $64, R10
+	MOVOU 0(R10), X13
+	MOVOU (R11), X14
+	PXOR X14, X13
+	AESDECLAST X13, X0
+	AESDECLAST X13, X4
+	MOVOU 16(R10), X13
+	AESDECLAST X13, X1
+	AESDECLAST X13, X5
+	MOVOU 32(R10), X13
+	AESDECLAST X13, X2
+	AESDECLAST X13, X6
+	MOVOU 48(R10), X13
+	AESDECLAST X13, X3
+	AESDECLAST X13, X7
+	PSHUFB X12, X0
+	PSHUFB X12, X1
+	PSHUFB X12, X2
+	PSHUFB X12, X3
+	MOVOA X0, X8
+	MOVOA X1, X9
+	PUNPCKLLQ X2, X0
+	PUNPCKHLQ X2, X8
+	PUNPCKLLQ X3, X1
+	PUNPCKHLQ X3, X9
+	MOVOA X0, X10
+	MOVOA X8, X11
+	PUNPCKLQDQ X1, X0
+	PUNPCKHQDQ X1, X10
+	PUNPCKLQDQ X9, X8
+	PUNPCKHQDQ X9, X11
+	MOVOA X10, X1
+	MOVOA X8, X2
+	MOVOA X11, X3
+	PSHUFB X12, X4
+	PSHUFB X12, X5
+	PSHUFB X12, X6
+	PSHUFB X12, X7
+	MOVOA X4, X8
+	MOVOA X5, X9
+	PUNPCKLLQ X6, X4
+	PUNPCKHLQ X6, X8
+	PUNPCKLLQ X7, X5
+	PUNPCKHLQ X7, X9
+	MOVOA X4, X10
+	MOVOA X8, X11
+	PUNPCKLQDQ X5, X4
+	PUNPCKHQDQ X5, X10
+	PUNPCKLQDQ X9, X8
+	PUNPCKHQDQ X9, X11
+	MOVOA X10, X5
+	MOVOA X8, X6
+	MOVOA X11, X7
+	AESIMC X0, X0
+	AESIMC X1, X1
+	AESIMC X2, X2
+	AESIMC X3, X3
+	AESIMC X4, X4
+	AESIMC X5, X5
+	AESIMC X6, X6
+	AESIMC X7, X7
+	MOVOU 0(SI), X15
+	AESDEC X15, X0
+	AESDEC X15, X4
+	MOVOU 16(SI), X15
+	AESDEC X15, X1
+	AESDEC X15, X5
+	MOVOU 32(SI), X15
+	AESDEC X15, X2
+	AESDEC X15, X6
+	MOVOU 48(SI), X15
+	AESDEC X15, X3
+	AESDEC X15, X7
+	SUBQ $16, R11
+	DECQ R9
+	JNZ dec512_x2_step
+
+dec512_x2_last:
+	MOVOU 0(DX), X13
+	AESDECLAST X13, X0
+	AESDECLAST X13, X4
+	MOVOU 16(DX), X13
+	AESDECLAST X13, X1
+	AESDECLAST X13, X5
+	MOVOU 32(DX), X13
+	AESDECLAST X13, X2
+	AESDECLAST X13, X6
+	MOVOU 48(DX), X13
+	AESDECLAST X13, X3
+	AESDECLAST X13, X7
+	MOVOU X0, 0(AX)
+	MOVOU X1, 16(AX)
+	MOVOU X2, 32(AX)
+	MOVOU X3, 48(AX)
+	MOVOU X4, 64(AX)
+	MOVOU X5, 80(AX)
+	MOVOU X6, 96(AX)
+	MOVOU X7, 112(AX)
+	ADDQ $128, AX
+	ADDQ $128, BX
+	SUBQ $2, CX
+	JMP dec512_loop2
+
+dec512_loop1:
+	TESTQ CX, CX
+	JZ dec512_done
+	MOVOU 0(BX), X0
+	MOVOU 16(BX), X1
+	MOVOU 32(BX), X2
+	MOVOU 48(BX), X3
+	MOVQ R8, R10
+	SHLQ $6, R10
+	ADDQ DX, R10
+	MOVOU 0(R10), X13
+	PXOR X13, X0
+	MOVOU 16(R10), X13
+	PXOR X13, X1
+	MOVOU 32(R10), X13
+	PXOR X13, X2
+	MOVOU 48(R10), X13
+	PXOR X13, X3
+	MOVOU 0(SI), X15
+	AESDEC X15, X0
+	MOVOU 16(SI), X15
+	AESDEC X15, X1
+	MOVOU 32(SI), X15
+	AESDEC X15, X2
+	MOVOU 48(SI), X15
+	AESDEC X15, X3
+	MOVQ R8, R9
+	DECQ R9
+	JZ dec512_x1_last
+	MOVQ R9, R11
+	SHLQ $4, R11
+	LEAQ -16(DI)(R11*1), R11
+
+dec512_x1_step:
+	SUBQ $64, R10
+	MOVOU 0(R10), X13
+	MOVOU (R11), X14
+	PXOR X14, X13
+	AESDECLAST X13, X0
+	MOVOU 16(R10), X13
+	AESDECLAST X13, X1
+	MOVOU 32(R10), X13
+	AESDECLAST X13, X2
+	MOVOU 48(R10), X13
+	AESDECLAST X13, X3
+	PSHUFB X12, X0
+	PSHUFB X12, X1
+	PSHUFB X12, X2
+	PSHUFB X12, X3
+	MOVOA X0, X8
+	MOVOA X1, X9
+	PUNPCKLLQ X2, X0
+	PUNPCKHLQ X2, X8
+	PUNPCKLLQ X3, X1
+	PUNPCKHLQ X3, X9
+	MOVOA X0, X10
+	MOVOA X8, X11
+	PUNPCKLQDQ X1, X0
+	PUNPCKHQDQ X1, X10
+	PUNPCKLQDQ X9, X8
+	PUNPCKHQDQ X9, X11
+	MOVOA X10, X1
+	MOVOA X8, X2
+	MOVOA X11, X3
+	AESIMC X0, X0
+	AESIMC X1, X1
+	AESIMC X2, X2
+	AESIMC X3, X3
+	MOVOU 0(SI), X15
+	AESDEC X15, X0
+	MOVOU 16(SI), X15
+	AESDEC X15, X1
+	MOVOU 32(SI), X15
+	AESDEC X15, X2
+	MOVOU 48(SI), X15
+	AESDEC X15, X3
+	SUBQ $16, R11
+	DECQ R9
+	JNZ dec512_x1_step
+
+dec512_x1_last:
+	MOVOU 0(DX), X13
+	AESDECLAST X13, X0
+	MOVOU 16(DX), X13
+	AESDECLAST X13, X1
+	MOVOU 32(DX), X13
+	AESDECLAST X13, X2
+	MOVOU 48(DX), X13
+	AESDECLAST X13, X3
+	MOVOU X0, 0(AX)
+	MOVOU X1, 16(AX)
+	MOVOU X2, 32(AX)
+	MOVOU X3, 48(AX)
+	ADDQ $64, AX
+	ADDQ $64, BX
+	DECQ CX
+	JMP dec512_loop1
+
+dec512_done:
+	RET
@@ -9,6 +9,20 @@ func vistrutah256DecryptAsm(ciphertext, plaintext, key *byte, keySize, rounds in
 func vistrutah512EncryptAsm(plaintext, ciphertext, key *byte, keySize, rounds int, roundConstants, kexpShuffle *byte)
 func vistrutah512DecryptAsm(ciphertext, plaintext, key *byte, keySize, rounds int, roundConstants, kexpShuffle *byte)
 
+// Batched kernels working on an expanded key - implemented in vistrutah_arm64.s
+//
+//go:noescape
+func vistrutah256EncryptBlocksAsm(dst, src *byte, n int, rk *[2]Block, fk *[2]Block, rc *Block, steps int)
+
+//go:noescape
+func vistrutah256DecryptBlocksAsm(dst, src *byte, n int, rk *[2]Block, fkImc *[2]Block, rc *Block, steps int)
+
+//go:noescape
+func vistrutah512EncryptBlocksAsm(dst, src *byte, n int, rk *[4]Block, fk *[4]Block, rc *Block, steps int)
+
+//go:noescape
+func vistrutah512DecryptBlocksAsm(dst, src *byte, n int, rk *[4]Block, fkImc *[4]Block, rc *Block, steps int)
+
 // Vistrutah256EncryptHW encrypts a 256-bit block using ARM Crypto
 func Vistrutah256EncryptHW(plaintext, ciphertext, key []byte, rounds int) {
 	if !CPU.HasARMCrypto {
@@ -88,3 +102,45 @@ func Vistrutah512DecryptHW(ciphertext, plaintext, key []byte, rounds int) {
 		&vistrutahKexpShuffle[0],
 	)
 }
+
+// encryptBlocksHW encrypts the blocks of src into dst with the ARM Crypto
+// kernel and reports whether it was available. src must not be empty.
+func (c *Vistrutah256Cipher) encryptBlocksHW(dst, src []byte) bool {
+	if !CPU.HasARMCrypto {
+		return false
+	}
+	vistrutah256EncryptBlocksAsm(&dst[0], &src[0], len(src)/Vistrutah256BlockSize,
+		&c.rk[0], &c.fk, &vistrutahRoundConstants[0], len(c.rk)-1)
+	return true
+}
+
+// decryptBlocksHW is the inverse of encryptBlocksHW.
+func (c *Vistrutah256Cipher) decryptBlocksHW(dst, src []byte) bool {
+	if !CPU.HasARMCrypto {
+		return false
+	}
+	vistrutah256DecryptBlocksAsm(&dst[0], &src[0], len(src)/Vistrutah256BlockSize,
+		&c.rk[0], &c.fkImc, &vistrutahRoundConstants[0], len(c.rk)-1)
+	return true
+}
+
+// encryptBlocksHW encrypts the blocks of src into dst with the ARM Crypto
+// kernel and reports whether it was available. src must not be empty.
+func (c *Vistrutah512Cipher) encryptBlocksHW(dst, src []byte) bool {
+	if !CPU.HasARMCrypto {
+		return false
+	}
+	vistrutah512EncryptBlocksAsm(&dst[0], &src[0], len(src)/Vistrutah512BlockSize,
+		&c.rk[0], &c.fk, &vistrutahRoundConstants[0], len(c.rk)-1)
+	return true
+}
+
+// decryptBlocksHW is the inverse of encryptBlocksHW.
+func (c *Vistrutah512Cipher) decryptBlocksHW(dst, src []byte) bool {
+	if !CPU.HasARMCrypto {
+		return false
+	}
+	vistrutah512DecryptBlocksAsm(&dst[0], &src[0], len(src)/Vistrutah512BlockSize,
+		&c.rk[0], &c.fkImc, &vistrutahRoundConstants[0], len(c.rk)-1)
+	return true
+}
@@ -764,3 +764,751 @@ dec512_loop_end_arm:
 	// Store plaintext
 	VST1 [V0.B16, V1.B16, V2.B16, V3.B16], (R1)
 	RET
+
+// Vistrutah-256 encryption of n independent blocks with the expanded key:
+// rk holds steps+1 round keys and rc the round constants. Four blocks are
+// processed at a time, then the remaining ones one by one.
+// func vistrutah256EncryptBlocksAsm(dst, src *byte, n int, rk *[2]Block, fk *[2]Block, rc *Block, steps int)
+TEXT ·vistrutah256EncryptBlocksAsm(SB), NOSPLIT, $0-56
+	MOVD dst+0(FP), R0
+	MOVD src+8(FP), R1
+	MOVD n+16(FP), R2
+	MOVD rk+24(FP), R3
+	MOVD fk+32(FP), R4
+	MOVD rc+40(FP), R5
+	MOVD steps+48(FP), R6
+
+	VLD1 (R4), [V16.B16, V17.B16]
+
+enc256_loop4:
+	CMP $4, R2
+	BLT enc256_loop1
+	VLD1.P 64(R1), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VLD1.P 64(R1), [V4.B16, V5.B16, V6.B16, V7.B16]
+	MOVD R3, R8
+	VLD1.P 32(R8), [V18.B16, V19.B16]
+	AESE V18.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V18.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V18.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V18.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V19.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V19.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V19.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V19.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	SUBS $1, R6, R7
+	BEQ enc256_x4_last
+	MOVD R5, R9
+
+enc256_x4_step:
+	AESE V16.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V16.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V16.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V16.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V17.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V17.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V17.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V17.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VMOV V0.B16, V8.B16
+	VUZP1 V1.B16, V0.B16, V0.B16
+	VUZP2 V1.B16, V8.B16, V1.B16
+	VMOV V2.B16, V9.B16
+	VUZP1 V3.B16, V2.B16, V2.B16
+	VUZP2 V3.B16, V9.B16, V3.B16
+	VMOV V4.B16, V10.B16
+	VUZP1 V5.B16, V4.B16, V4.B16
+	VUZP2 V5.B16, V10.B16, V5.B16
+	VMOV V6.B16, V11.B16
+	VUZP1 V7.B16, V6.B16, V6.B16
+	VUZP2 V7.B16, V11.B16, V7.B16
+	VLD1.P 32(R8), [V18.B16, V19.B16]
+	VLD1.P 16(R9), [V20.B16]
+	VEOR V20.B16, V18.B16, V18.B16
+	AESE V18.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V18.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V18.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V18.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V19.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V19.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V19.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V19.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	SUBS $1, R7, R7
+	BNE enc256_x4_step
+
+enc256_x4_last:
+	VLD1 (R8), [V18.B16, V19.B16]
+	AESE V16.B16, V0.B16
+	VEOR V18.B16, V0.B16, V0.B16
+	AESE V16.B16, V2.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	AESE V16.B16, V4.B16
+	VEOR V18.B16, V4.B16, V4.B16
+	AESE V16.B16, V6.B16
+	VEOR V18.B16, V6.B16, V6.B16
+	AESE V17.B16, V1.B16
+	VEOR V19.B16, V1.B16, V1.B16
+	AESE V17.B16, V3.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	AESE V17.B16, V5.B16
+	VEOR V19.B16, V5.B16, V5.B16
+	AESE V17.B16, V7.B16
+	VEOR V19.B16, V7.B16, V7.B16
+	VST1.P [V0.B16, V1.B16, V2.B16, V3.B16], 64(R0)
+	VST1.P [V4.B16, V5.B16, V6.B16, V7.B16], 64(R0)
+	SUB $4, R2, R2
+	B enc256_loop4
+
+enc256_loop1:
+	CBZ R2, enc256_done
+	VLD1.P 32(R1), [V0.B16, V1.B16]
+	MOVD R3, R8
+	VLD1.P 32(R8), [V18.B16, V19.B16]
+	AESE V18.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V19.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	SUBS $1, R6, R7
+	BEQ enc256_x1_last
+	MOVD R5, R9
+
+enc256_x1_step:
+	AESE V16.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V17.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	VMOV V0.B16, V8.B16
+	VUZP1 V1.B16, V0.B16, V0.B16
+	VUZP2 V1.B16, V8.B16, V1.B16
+	VLD1.P 32(R8), [V18.B16, V19.B16]
+	VLD1.P 16(R9), [V20.B16]
+	VEOR V20.B16, V18.B16, V18.B16
+	AESE V18.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V19.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	SUBS $1, R7, R7
+	BNE enc256_x1_step
+
+enc256_x1_last:
+	VLD1 (R8), [V18.B16, V19.B16]
+	AESE V16.B16, V0.B16
+	VEOR V18.B16, V0.B16, V0.B16
+	AESE V17.B16, V1.B16
+	VEOR V19.B16, V1.B16, V1.B16
+	VST1.P [V0.B16, V1.B16], 32(R0)
+	SUB $1, R2, R2
+	B enc256_loop1
+
+enc256_done:
+	RET
+
+// Vistrutah-256 decryption of n independent blocks with the expanded key.
+// fkImc is InvMixColumns of the fixed key. Four blocks are processed at a
+// time, then the remaining ones one by one.
+// func vistrutah256DecryptBlocksAsm(dst, src *byte, n int, rk *[2]Block, fkImc *[2]Block, rc *Block, steps int)
+TEXT ·vistrutah256DecryptBlocksAsm(SB), NOSPLIT, $0-56
+	MOVD dst+0(FP), R0
+	MOVD src+8(FP), R1
+	MOVD n+16(FP), R2
+	MOVD rk+24(FP), R3
+	MOVD fkImc+32(FP), R4
+	MOVD rc+40(FP), R5
+	MOVD steps+48(FP), R6
+
+	VLD1 (R4), [V16.B16, V17.B16]
+	VEOR V31.B16, V31.B16, V31.B16
+
+dec256_loop4:
+	CMP $4, R2
+	BLT dec256_loop1
+	VLD1.P 64(R1), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VLD1.P 64(R1), [V4.B16, V5.B16, V6.B16, V7.B16]
+	LSL $5, R6, R10
+	ADD R3, R10, R8
+	VLD1 (R8), [V18.B16, V19.B16]
+	AESD V18.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V18.B16, V2.B16
+	AESIMC V2.B16, V2.B16
+	AESD V18.B16, V4.B16
+	AESIMC V4.B16, V4.B16
+	AESD V18.B16, V6.B16
+	AESIMC V6.B16, V6.B16
+	AESD V19.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V19.B16, V3.B16
+	AESIMC V3.B16, V3.B16
+	AESD V19.B16, V5.B16
+	AESIMC V5.B16, V5.B16
+	AESD V19.B16, V7.B16
+	AESIMC V7.B16, V7.B16
+	SUBS $1, R6, R7
+	BEQ dec256_x4_last
+	SUB $1, R7, R10
+	LSL $4, R10, R10
+	ADD R5, R10, R9
+
+dec256_x4_step:
+	SUB $32, R8, R8
+	VLD1 (R8), [V18.B16, V19.B16]
+	VLD1 (R9), [V20.B16]
+	SUB $16, R9, R9
+	VEOR V20.B16, V18.B16, V18.B16
+	AESD V16.B16, V0.B16
+	VEOR V18.B16, V0.B16, V0.B16
+	AESD V16.B16, V2.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	AESD V16.B16, V4.B16
+	VEOR V18.B16, V4.B16, V4.B16
+	AESD V16.B16, V6.B16
+	VEOR V18.B16, V6.B16, V6.B16
+	AESD V17.B16, V1.B16
+	VEOR V19.B16, V1.B16, V1.B16
+	AESD V17.B16, V3.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	AESD V17.B16, V5.B16
+	VEOR V19.B16, V5.B16, V5.B16
+	AESD V17.B16, V7.B16
+	VEOR V19.B16, V7.B16, V7.B16
+	VMOV V0.B16, V8.B16
+	VZIP1 V1.B16, V0.B16, V0.B16
+	VZIP2 V1.B16, V8.B16, V1.B16
+	VMOV V2.B16, V9.B16
+	VZIP1 V3.B16, V2.B16, V2.B16
+	VZIP2 V3.B16, V9.B16, V3.B16
+	VMOV V4.B16, V10.B16
+	VZIP1 V5.B16, V4.B16, V4.B16
+	VZIP2 V5.B16, V10.B16, V5.B16
+	VMOV V6.B16, V11.B16
+	VZIP1 V7.B16, V6.B16, V6.B16
+	VZIP2 V7.B16, V11.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V31.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V2.B16
+	AESIMC V2.B16, V2.B16
+	AESD V31.B16, V3.B16
+	AESIMC V3.B16, V3.B16
+	AESD V31.B16, V4.B16
+	AESIMC V4.B16, V4.B16
+	AESD V31.B16, V5.B16
+	AESIMC V5.B16, V5.B16
+	AESD V31.B16, V6.B16
+	AESIMC V6.B16, V6.B16
+	AESD V31.B16, V7.B16
+	AESIMC V7.B16, V7.B16
+	SUBS $1, R7, R7
+	BNE dec256_x4_step
+
+dec256_x4_last:
+	VLD1 (R3), [V18.B16, V19.B16]
+	AESD V16.B16, V0.B16
+	VEOR V18.B16, V0.B16, V0.B16
+	AESD V16.B16, V2.B16
+	VEOR V18.B16, V2.B16, V2.B16
+	AESD V16.B16, V4.B16
+	VEOR V18.B16, V4.B16, V4.B16
+	AESD V16.B16, V6.B16
+	VEOR V18.B16, V6.B16, V6.B16
+	AESD V17.B16, V1.B16
+	VEOR V19.B16, V1.B16, V1.B16
+	AESD V17.B16, V3.B16
+	VEOR V19.B16, V3.B16, V3.B16
+	AESD V17.B16, V5.B16
+	VEOR V19.B16, V5.B16, V5.B16
+	AESD V17.B16, V7.B16
+	VEOR V19.B16, V7.B16, V7.B16
+	VST1.P [V0.B16, V1.B16, V2.B16, V3.B16], 64(R0)
+	VST1.P [V4.B16, V5.B16, V6.B16, V7.B16], 64(R0)
+	SUB $4, R2, R2
+	B dec256_loop4
+
+dec256_loop1:
+	CBZ R2, dec256_done
+	VLD1.P 32(R1), [V0.B16, V1.B16]
+	LSL $5, R6, R10
+	ADD R3, R10, R8
+	VLD1 (R8), [V18.B16, V19.B16]
+	AESD V18.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V19.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	SUBS $1, R6, R7
+	BEQ dec256_x1_last
+	SUB $1, R7, R10
+	LSL $4, R10, R10
+	ADD R5, R10, R9
+
+dec256_x1_step:
+	SUB $32, R8, R8
+	VLD1 (R8), [V18.B16, V19.B16]
+	VLD1 (R9), [V20.B16]
+	SUB $16, R9, R9
+	VEOR V20.B16, V18.B16, V18.B16
+	AESD V16.B16, V0.B16
+	VEOR V18.B16, V0.B16, V0.B16
+	AESD V17.B16, V1.B16
+	VEOR V19.B16, V1.B16, V1.B16
+	VMOV V0.B16, V8.B16
+	VZIP1 V1.B16, V0.B16, V0.B16
+	VZIP2 V1.B16, V8.B16, V1.B16
+	AESIMC V0.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	SUBS $1, R7, R7
+	BNE dec256_x1_step
+
+dec256_x1_last:
+	VLD1 (R3), [V18.B16, V19.B16]
+	AESD V16.B16, V0.B16
+	VEOR V18.B16, V0.B16, V0.B16
+	AESD V17.B16, V1.B16
+	VEOR V19.B16, V1.B16, V1.B16
+	VST1.P [V0.B16, V1.B16], 32(R0)
+	SUB $1, R2, R2
+	B dec256_loop1
+
+dec256_done:
+	RET
+
+// Vistrutah-512 encryption of n independent blocks with the expanded key,
+// two blocks at a time, then the remaining one.
+// func vistrutah512EncryptBlocksAsm(dst, src *byte, n int, rk *[4]Block, fk *[4]Block, rc *Block, steps int)
+TEXT ·vistrutah512EncryptBlocksAsm(SB), NOSPLIT, $0-56
+	MOVD dst+0(FP), R0
+	MOVD src+8(FP), R1
+	MOVD n+16(FP), R2
+	MOVD rk+24(FP), R3
+	MOVD fk+32(FP), R4
+	MOVD rc+40(FP), R5
+	MOVD steps+48(FP), R6
+
+	VLD1 (R4), [V16.B16, V17.B16, V18.B16, V19.B16]
+	MOVD $mix_idx0<>(SB), R10
+	VLD1 (R10), [V24.B16]
+	MOVD $mix_idx1<>(SB), R10
+	VLD1 (R10), [V25.B16]
+	MOVD $mix_idx2<>(SB), R10
+	VLD1 (R10), [V26.B16]
+	MOVD $mix_idx3<>(SB), R10
+	VLD1 (R10), [V27.B16]
+
+enc512_loop2:
+	CMP $2, R2
+	BLT enc512_loop1
+	VLD1.P 64(R1), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VLD1.P 64(R1), [V4.B16, V5.B16, V6.B16, V7.B16]
+	MOVD R3, R8
+	VLD1.P 64(R8), [V20.B16, V21.B16, V22.B16, V23.B16]
+	AESE V20.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V20.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V21.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V21.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V22.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V22.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V23.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V23.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	SUBS $1, R6, R7
+	BEQ enc512_x2_last
+	MOVD R5, R9
+
+enc512_x2_step:
+	AESE V16.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V16.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V17.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V17.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V18.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V18.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V19.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V19.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	VTBL V24.B16, [V0.B16, V1.B16, V2.B16, V3.B16], V8.B16
+	VTBL V25.B16, [V0.B16, V1.B16, V2.B16, V3.B16], V9.B16
+	VTBL V26.B16, [V0.B16, V1.B16, V2.B16, V3.B16], V10.B16
+	VTBL V27.B16, [V0.B16, V1.B16, V2.B16, V3.B16], V11.B16
+	VMOV V8.B16, V0.B16
+	VMOV V9.B16, V1.B16
+	VMOV V10.B16, V2.B16
+	VMOV V11.B16, V3.B16
+	VTBL V24.B16, [V4.B16, V5.B16, V6.B16, V7.B16], V8.B16
+	VTBL V25.B16, [V4.B16, V5.B16, V6.B16, V7.B16], V9.B16
+	VTBL V26.B16, [V4.B16, V5.B16, V6.B16, V7.B16], V10.B16
+	VTBL V27.B16, [V4.B16, V5.B16, V6.B16, V7.B16], V11.B16
+	VMOV V8.B16, V4.B16
+	VMOV V9.B16, V5.B16
+	VMOV V10.B16, V6.B16
+	VMOV V11.B16, V7.B16
+	VLD1.P 64(R8), [V20.B16, V21.B16, V22.B16, V23.B16]
+	VLD1.P 16(R9), [V28.B16]
+	VEOR V28.B16, V20.B16, V20.B16
+	AESE V20.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V20.B16, V4.B16
+	AESMC V4.B16, V4.B16
+	AESE V21.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V21.B16, V5.B16
+	AESMC V5.B16, V5.B16
+	AESE V22.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V22.B16, V6.B16
+	AESMC V6.B16, V6.B16
+	AESE V23.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	AESE V23.B16, V7.B16
+	AESMC V7.B16, V7.B16
+	SUBS $1, R7, R7
+	BNE enc512_x2_step
+
+enc512_x2_last:
+	VLD1 (R8), [V20.B16, V21.B16, V22.B16, V23.B16]
+	AESE V16.B16, V0.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESE V16.B16, V4.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	AESE V17.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESE V17.B16, V5.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	AESE V18.B16, V2.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESE V18.B16, V6.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	AESE V19.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	AESE V19.B16, V7.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VST1.P [V0.B16, V1.B16, V2.B16, V3.B16], 64(R0)
+	VST1.P [V4.B16, V5.B16, V6.B16, V7.B16], 64(R0)
+	SUB $2, R2, R2
+	B enc512_loop2
+
+enc512_loop1:
+	CBZ R2, enc512_done
+	VLD1.P 64(R1), [V0.B16, V1.B16, V2.B16, V3.B16]
+	MOVD R3, R8
+	VLD1.P 64(R8), [V20.B16, V21.B16, V22.B16, V23.B16]
+	AESE V20.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V21.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V22.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V23.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	SUBS $1, R6, R7
+	BEQ enc512_x1_last
+	MOVD R5, R9
+
+enc512_x1_step:
+	AESE V16.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V17.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V18.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V19.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	VTBL V24.B16, [V0.B16, V1.B16, V2.B16, V3.B16], V8.B16
+	VTBL V25.B16, [V0.B16, V1.B16, V2.B16, V3.B16], V9.B16
+	VTBL V26.B16, [V0.B16, V1.B16, V2.B16, V3.B16], V10.B16
+	VTBL V27.B16, [V0.B16, V1.B16, V2.B16, V3.B16], V11.B16
+	VMOV V8.B16, V0.B16
+	VMOV V9.B16, V1.B16
+	VMOV V10.B16, V2.B16
+	VMOV V11.B16, V3.B16
+	VLD1.P 64(R8), [V20.B16, V21.B16, V22.B16, V23.B16]
+	VLD1.P 16(R9), [V28.B16]
+	VEOR V28.B16, V20.B16, V20.B16
+	AESE V20.B16, V0.B16
+	AESMC V0.B16, V0.B16
+	AESE V21.B16, V1.B16
+	AESMC V1.B16, V1.B16
+	AESE V22.B16, V2.B16
+	AESMC V2.B16, V2.B16
+	AESE V23.B16, V3.B16
+	AESMC V3.B16, V3.B16
+	SUBS $1, R7, R7
+	BNE enc512_x1_step
+
+enc512_x1_last:
+	VLD1 (R8), [V20.B16, V21.B16, V22.B16, V23.B16]
+	AESE V16.B16, V0.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESE V17.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESE V18.B16, V2.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESE V19.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	VST1.P [V0.B16, V1.B16, V2.B16, V3.B16], 64(R0)
+	SUB $1, R2, R2
+	B enc512_loop1
+
+enc512_done:
+	RET
+
+// Vistrutah-512 decryption of n independent blocks with the expanded key,
+// two blocks at a time, then the remaining one.
+// func vistrutah512DecryptBlocksAsm(dst, src *byte, n int, rk *[4]Block, fkImc *[4]Block, rc *Block, steps int)
+TEXT ·vistrutah512DecryptBlocksAsm(SB), NOSPLIT, $0-56
+	MOVD dst+0(FP), R0
+	MOVD src+8(FP), R1
+	MOVD n+16(FP), R2
+	MOVD rk+24(FP), R3
+	MOVD fkImc+32(FP), R4
+	MOVD rc+40(FP), R5
+	MOVD steps+48(FP), R6
+
+	VLD1 (R4), [V16.B16, V17.B16, V18.B16, V19.B16]
+	VEOR V31.B16, V31.B16, V31.B16
+	MOVD $inv_mix_idx0<>(SB), R10
+	VLD1 (R10), [V24.B16]
+	MOVD $inv_mix_idx1<>(SB), R10
+	VLD1 (R10), [V25.B16]
+	MOVD $inv_mix_idx2<>(SB), R10
+	VLD1 (R10), [V26.B16]
+	MOVD $inv_mix_idx3<>(SB), R10
+	VLD1 (R10), [V27.B16]
+
+dec512_loop2:
+	CMP $2, R2
+	BLT dec512_loop1
+	VLD1.P 64(R1), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VLD1.P 64(R1), [V4.B16, V5.B16, V6.B16, V7.B16]
+	LSL $6, R6, R10
+	ADD R3, R10, R8
+	VLD1 (R8), [V20.B16, V21.B16, V22.B16, V23.B16]
+	AESD V20.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V20.B16, V4.B16
+	AESIMC V4.B16, V4.B16
+	AESD V21.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V21.B16, V5.B16
+	AESIMC V5.B16, V5.B16
+	AESD V22.B16, V2.B16
+	AESIMC V2.B16, V2.B16
+	AESD V22.B16, V6.B16
+	AESIMC V6.B16, V6.B16
+	AESD V23.B16, V3.B16
+	AESIMC V3.B16, V3.B16
+	AESD V23.B16, V7.B16
+	AESIMC V7.B16, V7.B16
+	SUBS $1, R6, R7
+	BEQ dec512_x2_last
+	SUB $1, R7, R10
+	LSL $4, R10, R10
+	ADD R5, R10, R9
+
+dec512_x2_step:
+	SUB $64, R8, R8
+	VLD1 (R8), [V20.B16, V21.B16, V22.B16, V23.B16]
+	VLD1 (R9), [V28.B16]
+	SUB $16, R9, R9
+	VEOR V28.B16, V20.B16, V20.B16
+	AESD V16.B16, V0.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESD V16.B16, V4.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	AESD V17.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESD V17.B16, V5.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	AESD V18.B16, V2.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESD V18.B16, V6.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	AESD V19.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	AESD V19.B16, V7.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VTBL V24.B16, [V0.B16, V1.B16, V2.B16, V3.B16], V8.B16
+	VTBL V25.B16, [V0.B16, V1.B16, V2.B16, V3.B16], V9.B16
+	VTBL V26.B16, [V0.B16, V1.B16, V2.B16, V3.B16], V10.B16
+	VTBL V27.B16, [V0.B16, V1.B16, V2.B16, V3.B16], V11.B16
+	VMOV V8.B16, V0.B16
+	VMOV V9.B16, V1.B16
+	VMOV V10.B16, V2.B16
+	VMOV V11.B16, V3.B16
+	VTBL V24.B16, [V4.B16, V5.B16, V6.B16, V7.B16], V8.B16
+	VTBL V25.B16, [V4.B16, V5.B16, V6.B16, V7.B16], V9.B16
+	VTBL V26.B16, [V4.B16, V5.B16, V6.B16, V7.B16], V10.B16
+	VTBL V27.B16, [V4.B16, V5.B16, V6.B16, V7.B16], V11.B16
+	VMOV V8.B16, V4.B16
+	VMOV V9.B16, V5.B16
+	VMOV V10.B16, V6.B16
+	VMOV V11.B16, V7.B16
+	AESIMC V0.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESIMC V4.B16, V4.B16
+	AESIMC V5.B16, V5.B16
+	AESIMC V6.B16, V6.B16
+	AESIMC V7.B16, V7.B16
+	AESD V31.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V2.B16
+	AESIMC V2.B16, V2.B16
+	AESD V31.B16, V3.B16
+	AESIMC V3.B16, V3.B16
+	AESD V31.B16, V4.B16
+	AESIMC V4.B16, V4.B16
+	AESD V31.B16, V5.B16
+	AESIMC V5.B16, V5.B16
+	AESD V31.B16, V6.B16
+	AESIMC V6.B16, V6.B16
+	AESD V31.B16, V7.B16
+	AESIMC V7.B16, V7.B16
+	SUBS $1, R7, R7
+	BNE dec512_x2_step
+
+dec512_x2_last:
+	VLD1 (R3), [V20.B16, V21.B16, V22.B16, V23.B16]
+	AESD V16.B16, V0.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESD V16.B16, V4.B16
+	VEOR V20.B16, V4.B16, V4.B16
+	AESD V17.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESD V17.B16, V5.B16
+	VEOR V21.B16, V5.B16, V5.B16
+	AESD V18.B16, V2.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESD V18.B16, V6.B16
+	VEOR V22.B16, V6.B16, V6.B16
+	AESD V19.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	AESD V19.B16, V7.B16
+	VEOR V23.B16, V7.B16, V7.B16
+	VST1.P [V0.B16, V1.B16, V2.B16, V3.B16], 64(R0)
+	VST1.P [V4.B16, V5.B16, V6.B16, V7.B16], 64(R0)
+	SUB $2, R2, R2
+	B dec512_loop2
+
+dec512_loop1:
+	CBZ R2, dec512_done
+	VLD1.P 64(R1), [V0.B16, V1.B16, V2.B16, V3.B16]
+	LSL $6, R6, R10
+	ADD R3, R10, R8
+	VLD1 (R8), [V20.B16, V21.B16, V22.B16, V23.B16]
+	AESD V20.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V21.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V22.B16, V2.B16
+	AESIMC V2.B16, V2.B16
+	AESD V23.B16, V3.B16
+	AESIMC V3.B16, V3.B16
+	SUBS $1, R6, R7
+	BEQ dec512_x1_last
+	SUB $1, R7, R10
+	LSL $4, R10, R10
+	ADD R5, R10, R9
+
+dec512_x1_step:
+	SUB $64, R8, R8
+	VLD1 (R8), [V20.B16, V21.B16, V22.B16, V23.B16]
+	VLD1 (R9), [V28.B16]
+	SUB $16, R9, R9
+	VEOR V28.B16, V20.B16, V20.B16
+	AESD V16.B16, V0.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESD V17.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESD V18.B16, V2.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESD V19.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	VTBL V24.B16, [V0.B16, V1.B16, V2.B16, V3.B16], V8.B16
+	VTBL V25.B16, [V0.B16, V1.B16, V2.B16, V3.B16], V9.B16
+	VTBL V26.B16, [V0.B16, V1.B16, V2.B16, V3.B16], V10.B16
+	VTBL V27.B16, [V0.B16, V1.B16, V2.B16, V3.B16], V11.B16
+	VMOV V8.B16, V0.B16
+	VMOV V9.B16, V1.B16
+	VMOV V10.B16, V2.B16
+	VMOV V11.B16, V3.B16
+	AESIMC V0.B16, V0.B16
+	AESIMC V1.B16, V1.B16
+	AESIMC V2.B16, V2.B16
+	AESIMC V3.B16, V3.B16
+	AESD V31.B16, V0.B16
+	AESIMC V0.B16, V0.B16
+	AESD V31.B16, V1.B16
+	AESIMC V1.B16, V1.B16
+	AESD V31.B16, V2.B16
+	AESIMC V2.B16, V2.B16
+	AESD V31.B16, V3.B16
+	AESIMC V3.B16, V3.B16
+	SUBS $1, R7, R7
+	BNE dec512_x1_step
+
+dec512_x1_last:
+	VLD1 (R3), [V20.B16, V21.B16, V22.B16, V23.B16]
+	AESD V16.B16, V0.B16
+	VEOR V20.B16, V0.B16, V0.B16
+	AESD V17.B16, V1.B16
+	VEOR V21.B16, V1.B16, V1.B16
+	AESD V18.B16, V2.B16
+	VEOR V22.B16, V2.B16, V2.B16
+	AESD V19.B16, V3.B16
+	VEOR V23.B16, V3.B16, V3.B16
+	VST1.P [V0.B16, V1.B16, V2.B16, V3.B16], 64(R0)
+	SUB $1, R2, R2
+	B dec512_loop1
+
+dec512_done:
+	RET
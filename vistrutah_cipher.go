@@ -0,0 +1,345 @@
+package aes
+
+import "errors"
+
+// vistrutahMaxRounds is the largest round count covered by the round constants.
+const vistrutahMaxRounds = RoundsPerStep * (len(vistrutahRoundConstants) + 1)
+
+// Vistrutah256Block is a 256-bit Vistrutah-256 block.
+type Vistrutah256Block [Vistrutah256BlockSize]byte
+
+// Vistrutah512Block is a 512-bit Vistrutah-512 block.
+type Vistrutah512Block [Vistrutah512BlockSize]byte
+
+// Vistrutah256Cipher is Vistrutah-256 with an expanded key.
+// It is safe for concurrent use.
+type Vistrutah256Cipher struct {
+	rounds int
+	fk     [2]Block   // fixed key
+	fkImc  [2]Block   // InvMixColumns(fixed key), for decryption
+	rk     [][2]Block // round keys, one per step plus the final one
+}
+
+// Vistrutah512Cipher is Vistrutah-512 with an expanded key.
+// It is safe for concurrent use.
+type Vistrutah512Cipher struct {
+	rounds int
+	fk     [4]Block
+	fkImc  [4]Block
+	rk     [][4]Block
+}
+
+func checkVistrutahRounds(rounds int) bool {
+	return rounds%RoundsPerStep == 0 && rounds >= 2 && rounds <= vistrutahMaxRounds
+}
+
+// NewVistrutah256Cipher expands a 16 or 32-byte key for the given number of
+// rounds, usually Vistrutah256RoundsShort or Vistrutah256RoundsLong.
+func NewVistrutah256Cipher(key []byte, rounds int) (*Vistrutah256Cipher, error) {
+	if len(key) != 16 && len(key) != 32 {
+		return nil, errors.New("aes: Vistrutah-256 key must be 16 or 32 bytes")
+	}
+	if !checkVistrutahRounds(rounds) {
+		return nil, errors.New("aes: Vistrutah-256 rounds must be even and between 2 and 98")
+	}
+	c := &Vistrutah256Cipher{rounds: rounds}
+
+	var fixedKey [32]byte
+	if len(key) == 16 {
+		copy(fixedKey[:16], key)
+		copy(fixedKey[16:], key)
+	} else {
+		copy(fixedKey[:], key)
+	}
+	copy(c.fk[0][:], fixedKey[:16])
+	copy(c.fk[1][:], fixedKey[16:])
+	for i := range c.fk {
+		c.fkImc[i] = c.fk[i]
+		InvMixColumns(&c.fkImc[i])
+	}
+
+	// The round key starts as the swapped halves of the fixed key and is
+	// permuted by P4 and P5 at every step.
+	steps := rounds / RoundsPerStep
+	c.rk = make([][2]Block, steps+1)
+	c.rk[0] = [2]Block{c.fk[1], c.fk[0]}
+	for i := 1; i <= steps; i++ {
+		c.rk[i] = c.rk[i-1]
+		vistrutahApplyPerm(&vistrutahP4, &c.rk[i][0])
+		vistrutahApplyPerm(&vistrutahP5, &c.rk[i][1])
+	}
+	return c, nil
+}
+
+// BlockSize returns the block size in bytes.
+func (c *Vistrutah256Cipher) BlockSize() int { return Vistrutah256BlockSize }
+
+// Rounds returns the number of rounds.
+func (c *Vistrutah256Cipher) Rounds() int { return c.rounds }
+
+// Encrypt encrypts src into dst. dst and src may point to the same block.
+func (c *Vistrutah256Cipher) Encrypt(dst, src *Vistrutah256Block) {
+	if c.encryptBlocksHW(dst[:], src[:]) {
+		return
+	}
+	c.encryptGeneric((*[32]byte)(dst), (*[32]byte)(src))
+}
+
+// Decrypt decrypts src into dst. dst and src may point to the same block.
+func (c *Vistrutah256Cipher) Decrypt(dst, src *Vistrutah256Block) {
+	if c.decryptBlocksHW(dst[:], src[:]) {
+		return
+	}
+	c.decryptGeneric((*[32]byte)(dst), (*[32]byte)(src))
+}
+
+// EncryptBlocks encrypts each 32-byte block of src into dst, independently
+// (ECB). len(src) must be a multiple of the block size and dst must be at
+// least as long; dst and src may be the same slice.
+func (c *Vistrutah256Cipher) EncryptBlocks(dst, src []byte) {
+	checkVistrutahBlocks(dst, src, Vistrutah256BlockSize)
+	if len(src) == 0 || c.encryptBlocksHW(dst, src) {
+		return
+	}
+	for len(src) > 0 {
+		c.Encrypt((*Vistrutah256Block)(dst), (*Vistrutah256Block)(src))
+		dst, src = dst[Vistrutah256BlockSize:], src[Vistrutah256BlockSize:]
+	}
+}
+
+// DecryptBlocks decrypts each 32-byte block of src into dst, independently.
+func (c *Vistrutah256Cipher) DecryptBlocks(dst, src []byte) {
+	checkVistrutahBlocks(dst, src, Vistrutah256BlockSize)
+	if len(src) == 0 || c.decryptBlocksHW(dst, src) {
+		return
+	}
+	for len(src) > 0 {
+		c.Decrypt((*Vistrutah256Block)(dst), (*Vistrutah256Block)(src))
+		dst, src = dst[Vistrutah256BlockSize:], src[Vistrutah256BlockSize:]
+	}
+}
+
+func (c *Vistrutah256Cipher) encryptGeneric(dst, src *[32]byte) {
+	steps := c.rounds / RoundsPerStep
+
+	var s0, s1 Block
+	copy(s0[:], src[:16])
+	copy(s1[:], src[16:])
+
+	xorBlocks(&s0, &c.rk[0][0])
+	xorBlocks(&s1, &c.rk[0][1])
+	Round(&s0, &c.fk[0])
+	Round(&s1, &c.fk[1])
+
+	var zero Block
+	for i := 1; i < steps; i++ {
+		Round(&s0, &zero)
+		Round(&s1, &zero)
+		mixingLayer256(&s0, &s1)
+		xorBlocks(&s0, &c.rk[i][0])
+		xorBlocks(&s1, &c.rk[i][1])
+		xorBlocks(&s0, &vistrutahRoundConstants[i-1])
+		Round(&s0, &c.fk[0])
+		Round(&s1, &c.fk[1])
+	}
+
+	FinalRound(&s0, &c.rk[steps][0])
+	FinalRound(&s1, &c.rk[steps][1])
+
+	copy(dst[:16], s0[:])
+	copy(dst[16:], s1[:])
+}
+
+func (c *Vistrutah256Cipher) decryptGeneric(dst, src *[32]byte) {
+	steps := c.rounds / RoundsPerStep
+
+	var s0, s1 Block
+	copy(s0[:], src[:16])
+	copy(s1[:], src[16:])
+
+	xorBlocks(&s0, &c.rk[steps][0])
+	xorBlocks(&s1, &c.rk[steps][1])
+	InvRound(&s0, &c.fkImc[0])
+	InvRound(&s1, &c.fkImc[1])
+
+	for i := steps - 1; i >= 1; i-- {
+		InvFinalRound(&s0, &c.rk[i][0])
+		InvFinalRound(&s1, &c.rk[i][1])
+		xorBlocks(&s0, &vistrutahRoundConstants[i-1])
+		invMixingLayer256(&s0, &s1)
+		InvMixColumns(&s0)
+		InvMixColumns(&s1)
+		InvRound(&s0, &c.fkImc[0])
+		InvRound(&s1, &c.fkImc[1])
+	}
+
+	InvFinalRound(&s0, &c.rk[0][0])
+	InvFinalRound(&s1, &c.rk[0][1])
+
+	copy(dst[:16], s0[:])
+	copy(dst[16:], s1[:])
+}
+
+// NewVistrutah512Cipher expands a 32 or 64-byte key for the given number of
+// rounds (see the Vistrutah512Rounds constants).
+func NewVistrutah512Cipher(key []byte, rounds int) (*Vistrutah512Cipher, error) {
+	if len(key) != 32 && len(key) != 64 {
+		return nil, errors.New("aes: Vistrutah-512 key must be 32 or 64 bytes")
+	}
+	if !checkVistrutahRounds(rounds) {
+		return nil, errors.New("aes: Vistrutah-512 rounds must be even and between 2 and 98")
+	}
+	c := &Vistrutah512Cipher{rounds: rounds}
+
+	var fixedKey [64]byte
+	if len(key) == 32 {
+		copy(fixedKey[:32], key)
+		copy(fixedKey[32:], key)
+	} else {
+		copy(fixedKey[:], key)
+	}
+
+	// Apply KEXP shuffle to second half
+	var temp [32]byte
+	copy(temp[:], fixedKey[32:])
+	for i := range 32 {
+		fixedKey[32+i] = temp[vistrutahKexpShuffle[i]]
+	}
+	for i := range c.fk {
+		copy(c.fk[i][:], fixedKey[16*i:])
+		c.fkImc[i] = c.fk[i]
+		InvMixColumns(&c.fkImc[i])
+	}
+
+	// The round key interleaves the fixed key halves and is rotated at every step.
+	steps := rounds / RoundsPerStep
+	c.rk = make([][4]Block, steps+1)
+	c.rk[0] = [4]Block{c.fk[1], c.fk[0], c.fk[3], c.fk[2]}
+	for i := 1; i <= steps; i++ {
+		c.rk[i] = c.rk[i-1]
+		vistrutahRotateBytes(&c.rk[i][0], 5)
+		vistrutahRotateBytes(&c.rk[i][1], 10)
+		vistrutahRotateBytes(&c.rk[i][2], 5)
+		vistrutahRotateBytes(&c.rk[i][3], 10)
+	}
+	return c, nil
+}
+
+// BlockSize returns the block size in bytes.
+func (c *Vistrutah512Cipher) BlockSize() int { return Vistrutah512BlockSize }
+
+// Rounds returns the number of rounds.
+func (c *Vistrutah512Cipher) Rounds() int { return c.rounds }
+
+// Encrypt encrypts src into dst. dst and src may point to the same block.
+func (c *Vistrutah512Cipher) Encrypt(dst, src *Vistrutah512Block) {
+	if c.encryptBlocksHW(dst[:], src[:]) {
+		return
+	}
+	c.encryptGeneric((*[64]byte)(dst), (*[64]byte)(src))
+}
+
+// Decrypt decrypts src into dst. dst and src may point to the same block.
+func (c *Vistrutah512Cipher) Decrypt(dst, src *Vistrutah512Block) {
+	if c.decryptBlocksHW(dst[:], src[:]) {
+		return
+	}
+	c.decryptGeneric((*[64]byte)(dst), (*[64]byte)(src))
+}
+
+// EncryptBlocks encrypts each 64-byte block of src into dst, independently
+// (ECB). len(src) must be a multiple of the block size and dst must be at
+// least as long; dst and src may be the same slice.
+func (c *Vistrutah512Cipher) EncryptBlocks(dst, src []byte) {
+	checkVistrutahBlocks(dst, src, Vistrutah512BlockSize)
+	if len(src) == 0 || c.encryptBlocksHW(dst, src) {
+		return
+	}
+	for len(src) > 0 {
+		c.Encrypt((*Vistrutah512Block)(dst), (*Vistrutah512Block)(src))
+		dst, src = dst[Vistrutah512BlockSize:], src[Vistrutah512BlockSize:]
+	}
+}
+
+// DecryptBlocks decrypts each 64-byte block of src into dst, independently.
+func (c *Vistrutah512Cipher) DecryptBlocks(dst, src []byte) {
+	checkVistrutahBlocks(dst, src, Vistrutah512BlockSize)
+	if len(src) == 0 || c.decryptBlocksHW(dst, src) {
+		return
+	}
+	for len(src) > 0 {
+		c.Decrypt((*Vistrutah512Block)(dst), (*Vistrutah512Block)(src))
+		dst, src = dst[Vistrutah512BlockSize:], src[Vistrutah512BlockSize:]
+	}
+}
+
+func (c *Vistrutah512Cipher) encryptGeneric(dst, src *[64]byte) {
+	steps := c.rounds / RoundsPerStep
+
+	var s [4]Block
+	for j := range s {
+		copy(s[j][:], src[16*j:])
+		xorBlocks(&s[j], &c.rk[0][j])
+		Round(&s[j], &c.fk[j])
+	}
+
+	var zero Block
+	for i := 1; i < steps; i++ {
+		for j := range s {
+			Round(&s[j], &zero)
+		}
+		mixingLayer512(&s[0], &s[1], &s[2], &s[3])
+		for j := range s {
+			xorBlocks(&s[j], &c.rk[i][j])
+		}
+		xorBlocks(&s[0], &vistrutahRoundConstants[i-1])
+		for j := range s {
+			Round(&s[j], &c.fk[j])
+		}
+	}
+
+	for j := range s {
+		FinalRound(&s[j], &c.rk[steps][j])
+		copy(dst[16*j:], s[j][:])
+	}
+}
+
+func (c *Vistrutah512Cipher) decryptGeneric(dst, src *[64]byte) {
+	steps := c.rounds / RoundsPerStep
+
+	var s [4]Block
+	for j := range s {
+		copy(s[j][:], src[16*j:])
+		xorBlocks(&s[j], &c.rk[steps][j])
+		InvRound(&s[j], &c.fkImc[j])
+	}
+
+	for i := steps - 1; i >= 1; i-- {
+		for j := range s {
+			InvFinalRound(&s[j], &c.rk[i][j])
+		}
+		xorBlocks(&s[0], &vistrutahRoundConstants[i-1])
+		invMixingLayer512(&s[0], &s[1], &s[2], &s[3])
+		for j := range s {
+			InvMixColumns(&s[j])
+			InvRound(&s[j], &c.fkImc[j])
+		}
+	}
+
+	for j := range s {
+		InvFinalRound(&s[j], &c.rk[0][j])
+		copy(dst[16*j:], s[j][:])
+	}
+}
+
+func checkVistrutahBlocks(dst, src []byte, blockSize int) {
+	if len(src)%blockSize != 0 {
+		panic("aes: Vistrutah input not full blocks")
+	}
+	if len(dst) < len(src) {
+		panic("aes: Vistrutah output smaller than input")
+	}
+	if inexactOverlap(dst[:len(src)], src) {
+		panic("aes: invalid buffer overlap")
+	}
+}
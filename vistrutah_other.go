@@ -24,3 +24,12 @@ func Vistrutah512EncryptHW(plaintext, ciphertext, key []byte, rounds int) {
 func Vistrutah512DecryptHW(ciphertext, plaintext, key []byte, rounds int) {
 	Vistrutah512Decrypt(ciphertext, plaintext, key, rounds)
 }
+
+// No batched kernels on this platform; the caller uses the generic code.
+func (c *Vistrutah256Cipher) encryptBlocksHW(dst, src []byte) bool { return false }
+
+func (c *Vistrutah256Cipher) decryptBlocksHW(dst, src []byte) bool { return false }
+
+func (c *Vistrutah512Cipher) encryptBlocksHW(dst, src []byte) bool { return false }
+
+func (c *Vistrutah512Cipher) decryptBlocksHW(dst, src []byte) bool { return false }
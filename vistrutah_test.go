@@ -560,3 +560,223 @@ func BenchmarkVistrutah512DecryptHW(b *testing.B) {
 		Vistrutah512DecryptHW(ciphertext[:], plaintext[:], key[:], Vistrutah512RoundsLong256Key)
 	}
 }
+
+func TestVistrutahCipherErrors(t *testing.T) {
+	for _, tc := range []struct {
+		keySize, rounds int
+	}{{15, 14}, {24, 14}, {64, 14}, {32, 0}, {32, 13}, {32, 100}} {
+		if _, err := NewVistrutah256Cipher(make([]byte, tc.keySize), tc.rounds); err == nil {
+			t.Errorf("Vistrutah-256: expected error for key=%d rounds=%d", tc.keySize, tc.rounds)
+		}
+	}
+	for _, tc := range []struct {
+		keySize, rounds int
+	}{{16, 14}, {48, 14}, {128, 14}, {64, -2}, {64, 9}, {64, 100}} {
+		if _, err := NewVistrutah512Cipher(make([]byte, tc.keySize), tc.rounds); err == nil {
+			t.Errorf("Vistrutah-512: expected error for key=%d rounds=%d", tc.keySize, tc.rounds)
+		}
+	}
+	if _, err := NewVistrutah256Cipher(make([]byte, 32), vistrutahMaxRounds); err != nil {
+		t.Errorf("unexpected error for the maximum round count: %v", err)
+	}
+}
+
+func TestVistrutah256Cipher(t *testing.T) {
+	for _, rounds := range []int{2, Vistrutah256RoundsShort, Vistrutah256RoundsLong, vistrutahMaxRounds} {
+		for _, keySize := range []int{16, 32} {
+			key := make([]byte, keySize)
+			for i := range key {
+				key[i] = byte(i*3 + rounds)
+			}
+			c, err := NewVistrutah256Cipher(key, rounds)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var pt, ct, generic Vistrutah256Block
+			for i := range pt {
+				pt[i] = byte(i * 13)
+			}
+			var want [32]byte
+			Vistrutah256Encrypt(pt[:], want[:], key, rounds)
+
+			c.Encrypt(&ct, &pt)
+			c.encryptGeneric((*[32]byte)(&generic), (*[32]byte)(&pt))
+			if [32]byte(ct) != want || [32]byte(generic) != want {
+				t.Fatalf("rounds=%d key=%d: Encrypt mismatch", rounds, keySize)
+			}
+
+			// In place
+			buf := pt
+			c.Encrypt(&buf, &buf)
+			if buf != ct {
+				t.Errorf("rounds=%d key=%d: in-place Encrypt mismatch", rounds, keySize)
+			}
+			c.Decrypt(&buf, &buf)
+			if buf != pt {
+				t.Errorf("rounds=%d key=%d: in-place Decrypt mismatch", rounds, keySize)
+			}
+			c.decryptGeneric((*[32]byte)(&buf), (*[32]byte)(&ct))
+			if buf != pt {
+				t.Errorf("rounds=%d key=%d: generic Decrypt mismatch", rounds, keySize)
+			}
+		}
+	}
+}
+
+func TestVistrutah512Cipher(t *testing.T) {
+	for _, rounds := range []int{2, Vistrutah512RoundsShort256Key, Vistrutah512RoundsLong512Key, vistrutahMaxRounds} {
+		for _, keySize := range []int{32, 64} {
+			key := make([]byte, keySize)
+			for i := range key {
+				key[i] = byte(i*5 + rounds)
+			}
+			c, err := NewVistrutah512Cipher(key, rounds)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var pt, ct, generic Vistrutah512Block
+			for i := range pt {
+				pt[i] = byte(i * 17)
+			}
+			var want [64]byte
+			Vistrutah512Encrypt(pt[:], want[:], key, rounds)
+
+			c.Encrypt(&ct, &pt)
+			c.encryptGeneric((*[64]byte)(&generic), (*[64]byte)(&pt))
+			if [64]byte(ct) != want || [64]byte(generic) != want {
+				t.Fatalf("rounds=%d key=%d: Encrypt mismatch", rounds, keySize)
+			}
+
+			buf := pt
+			c.Encrypt(&buf, &buf)
+			if buf != ct {
+				t.Errorf("rounds=%d key=%d: in-place Encrypt mismatch", rounds, keySize)
+			}
+			c.Decrypt(&buf, &buf)
+			if buf != pt {
+				t.Errorf("rounds=%d key=%d: in-place Decrypt mismatch", rounds, keySize)
+			}
+			c.decryptGeneric((*[64]byte)(&buf), (*[64]byte)(&ct))
+			if buf != pt {
+				t.Errorf("rounds=%d key=%d: generic Decrypt mismatch", rounds, keySize)
+			}
+		}
+	}
+}
+
+func TestVistrutahCipherEncryptBlocks(t *testing.T) {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i * 7)
+	}
+	src := make([]byte, 9*64)
+	for i := range src {
+		src[i] = byte(i)
+	}
+
+	forEachCPUConfig(t, func(t *testing.T) {
+		for _, rounds := range []int{2, Vistrutah256RoundsLong} {
+			c256, _ := NewVistrutah256Cipher(key, rounds)
+			c512, _ := NewVistrutah512Cipher(key, rounds)
+			for _, c := range []interface {
+				BlockSize() int
+				EncryptBlocks(dst, src []byte)
+				DecryptBlocks(dst, src []byte)
+			}{c256, c512} {
+				bs := c.BlockSize()
+				for n := 0; n <= 9; n++ {
+					in := src[:n*bs]
+					dst := make([]byte, len(in))
+					c.EncryptBlocks(dst, in)
+					for i := 0; i < len(in); i += bs {
+						want := make([]byte, bs)
+						if bs == 32 {
+							Vistrutah256Encrypt(in[i:i+bs], want, key, rounds)
+						} else {
+							Vistrutah512Encrypt(in[i:i+bs], want, key, rounds)
+						}
+						if !bytes.Equal(dst[i:i+bs], want) {
+							t.Fatalf("block size %d, rounds %d, n=%d: block %d mismatch", bs, rounds, n, i/bs)
+						}
+					}
+					c.DecryptBlocks(dst, dst)
+					if !bytes.Equal(dst, in) {
+						t.Fatalf("block size %d, rounds %d, n=%d: DecryptBlocks did not restore the input", bs, rounds, n)
+					}
+				}
+			}
+		}
+	})
+
+	c256, _ := NewVistrutah256Cipher(key, Vistrutah256RoundsLong)
+	c512, _ := NewVistrutah512Cipher(key, Vistrutah512RoundsLong256Key)
+	for _, c := range []interface {
+		BlockSize() int
+		EncryptBlocks(dst, src []byte)
+	}{c256, c512} {
+		bs := c.BlockSize()
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("block size %d: expected panic for partial block", bs)
+				}
+			}()
+			c.EncryptBlocks(make([]byte, len(src)), src[:bs+1])
+		}()
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("block size %d: expected panic for overlapping buffers", bs)
+				}
+			}()
+			buf := make([]byte, 2*bs+1)
+			c.EncryptBlocks(buf[1:], buf[:2*bs])
+		}()
+	}
+}
+
+func BenchmarkVistrutah256CipherEncrypt(b *testing.B) {
+	c, _ := NewVistrutah256Cipher(make([]byte, 32), Vistrutah256RoundsLong)
+	var block Vistrutah256Block
+
+	b.SetBytes(Vistrutah256BlockSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Encrypt(&block, &block)
+	}
+}
+
+func BenchmarkVistrutah512CipherEncrypt(b *testing.B) {
+	c, _ := NewVistrutah512Cipher(make([]byte, 64), Vistrutah512RoundsLong512Key)
+	var block Vistrutah512Block
+
+	b.SetBytes(Vistrutah512BlockSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Encrypt(&block, &block)
+	}
+}
+
+func BenchmarkVistrutah256CipherEncryptBlocks(b *testing.B) {
+	c, _ := NewVistrutah256Cipher(make([]byte, 32), Vistrutah256RoundsLong)
+	buf := make([]byte, 64*Vistrutah256BlockSize)
+
+	b.SetBytes(int64(len(buf)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.EncryptBlocks(buf, buf)
+	}
+}
+
+func BenchmarkVistrutah512CipherEncryptBlocks(b *testing.B) {
+	c, _ := NewVistrutah512Cipher(make([]byte, 64), Vistrutah512RoundsLong512Key)
+	buf := make([]byte, 32*Vistrutah512BlockSize)
+
+	b.SetBytes(int64(len(buf)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.EncryptBlocks(buf, buf)
+	}
+}